@@ -28,6 +28,36 @@ func main() {
 	// Migrate up the database
 	di.RunDatabaseMigrations(ctx)
 
+	// Watch dynamic parameters defaults for changes
+	di.StartDynamicParametersConfigWatcher(ctx, &wg)
+
+	// Apply and revert scheduled dynamic parameters changes
+	di.StartDynamicParameterScheduler(ctx, &wg)
+
+	// Mark as offline the devices that stopped reporting
+	di.StartOfflineDeviceSweeper(ctx, &wg)
+
+	// Escalate the alerts nobody acknowledged in time
+	di.StartAlertEscalator(ctx, &wg)
+
+	// Push the queued webhook deliveries to the customers
+	di.StartWebhookDeliveryWorker(ctx, &wg)
+
+	// Close and summarize the maintenance windows that reached their end
+	di.StartMaintenanceWindowCloser(ctx, &wg)
+
+	// Halt, advance and complete the running firmware campaigns
+	di.StartFirmwareCampaignProgressor(ctx, &wg)
+
+	// Write the requested exports to the object storage
+	di.StartExportJobWorker(ctx, &wg)
+
+	// Generate the compliance reports of the month just over
+	di.StartMonthlySiteReportScheduler(ctx, &wg)
+
+	// Fan out the live events to the connected dashboards
+	di.StartStreamHub(ctx, &wg)
+
 	// Start Http Server
 	go func() {
 		errorsChannel <- di.HttpServices.Router.ListenAndServe(
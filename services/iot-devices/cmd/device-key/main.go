@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/configs"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const usage = `usage:
+  device-key -device id
+
+prints the key the device sends in the X-Device-Key header, derived from DEVICE_KEYS_SECRET`
+
+func main() {
+	flags := flag.NewFlagSet("device-key", flag.ExitOnError)
+	deviceID := flags.String("device", "", "device whose key is printed")
+	_ = flags.Parse(os.Args[1:])
+
+	if *deviceID == "" {
+		exitWithError(fmt.Errorf("missing -device\n%s", usage))
+	}
+
+	config := configs.LoadEnvConfig()
+	if config.DeviceKeysSecret == "" {
+		exitWithError(fmt.Errorf("DEVICE_KEYS_SECRET is not set"))
+	}
+
+	fmt.Println(amf_http_server.DeviceKey(config.DeviceKeysSecret, *deviceID))
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
@@ -0,0 +1,292 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	alerting_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/application"
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	alerting_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/infra"
+	alerting_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/infra/http"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const (
+	createAlertRuleJsonSchemaFileName = "create-alert-rule.schema.json"
+	updateAlertRuleJsonSchemaFileName = "update-alert-rule.schema.json"
+
+	alertTransitionJsonSchemaFileName      = "alert-transition.schema.json"
+	snoozeAlertJsonSchemaFileName          = "snooze-alert.schema.json"
+	putAlertEscalationPolicySchemaFileName = "put-alert-escalation-policy.schema.json"
+)
+
+type AlertingServices struct {
+	AlertRuleEngine               *alerting_application.AlertRuleEngine
+	CreateAlertRuleCommandHandler *alerting_application.CreateAlertRuleCommandHandler
+	UpdateAlertRuleCommandHandler *alerting_application.UpdateAlertRuleCommandHandler
+	DeleteAlertRuleCommandHandler *alerting_application.DeleteAlertRuleCommandHandler
+	FindAlertRuleQueryHandler     *alerting_application.FindAlertRuleQueryHandler
+	SearchAlertRulesQueryHandler  *alerting_application.SearchAlertRulesQueryHandler
+
+	AlertLifecycle                         *alerting_application.AlertLifecycle
+	AlertEscalator                         *alerting_application.AlertEscalator
+	AcknowledgeAlertCommandHandler         *alerting_application.AcknowledgeAlertCommandHandler
+	SnoozeAlertCommandHandler              *alerting_application.SnoozeAlertCommandHandler
+	ResolveAlertCommandHandler             *alerting_application.ResolveAlertCommandHandler
+	PutAlertEscalationPolicyCommandHandler *alerting_application.PutAlertEscalationPolicyCommandHandler
+	FindAlertQueryHandler                  *alerting_application.FindAlertQueryHandler
+	SearchAlertsQueryHandler               *alerting_application.SearchAlertsQueryHandler
+	SearchAlertAuditTrailQueryHandler      *alerting_application.SearchAlertAuditTrailQueryHandler
+	FindAlertEscalationPolicyQueryHandler  *alerting_application.FindAlertEscalationPolicyQueryHandler
+}
+
+func InitAlertingServices(commonServices *CommonServices, httpServices *HttpServices) *AlertingServices {
+	ruleRepository := alerting_infra.NewPostgresAlertRuleRepository(commonServices.DatabaseConnectionPool)
+	stateRepository := alerting_infra.NewRedisAlertRuleStateRepository(commonServices.RedisClient)
+	alertRepository := alerting_infra.NewPostgresAlertRepository(commonServices.DatabaseConnectionPool)
+	auditTrail := alerting_infra.NewPostgresAlertAuditTrail(commonServices.DatabaseConnectionPool)
+	escalationPolicyRepository := alerting_infra.NewPostgresAlertEscalationPolicyRepository(commonServices.DatabaseConnectionPool)
+
+	// Without a supervisor configured alerts are only escalated for the tenants with a policy of their own
+	escalationPolicies := alerting_application.NewAlertEscalationPolicies(
+		escalationPolicyRepository,
+		alerting_domain.AlertEscalationPolicy{
+			EscalateAfter: time.Duration(commonServices.Config.AlertEscalationAfter) * time.Second,
+			Supervisor:    commonServices.Config.AlertEscalationSupervisor,
+		},
+	)
+	alertLifecycle := alerting_application.NewAlertLifecycle(
+		alertRepository,
+		auditTrail,
+		commonServices.EventBus,
+		commonServices.DistributedMutex,
+		commonServices.UlidProvider,
+		commonServices.TimeProvider,
+	)
+
+	alertingServices := &AlertingServices{
+		AlertRuleEngine: alerting_application.NewAlertRuleEngine(
+			ruleRepository,
+			stateRepository,
+			commonServices.EventBus,
+			commonServices.DistributedMutex,
+		),
+		CreateAlertRuleCommandHandler: alerting_application.NewCreateAlertRuleCommandHandler(
+			ruleRepository,
+			commonServices.TimeProvider,
+		),
+		UpdateAlertRuleCommandHandler: alerting_application.NewUpdateAlertRuleCommandHandler(
+			ruleRepository,
+			stateRepository,
+			commonServices.TimeProvider,
+		),
+		DeleteAlertRuleCommandHandler: alerting_application.NewDeleteAlertRuleCommandHandler(ruleRepository, stateRepository),
+		FindAlertRuleQueryHandler:     alerting_application.NewFindAlertRuleQueryHandler(ruleRepository),
+		SearchAlertRulesQueryHandler:  alerting_application.NewSearchAlertRulesQueryHandler(ruleRepository),
+
+		AlertLifecycle: alertLifecycle,
+		AlertEscalator: alerting_application.NewAlertEscalator(
+			alertRepository,
+			escalationPolicies,
+			alertLifecycle,
+			commonServices.DistributedMutex,
+			commonServices.TimeProvider,
+		),
+		AcknowledgeAlertCommandHandler:         alerting_application.NewAcknowledgeAlertCommandHandler(alertLifecycle),
+		SnoozeAlertCommandHandler:              alerting_application.NewSnoozeAlertCommandHandler(alertLifecycle),
+		ResolveAlertCommandHandler:             alerting_application.NewResolveAlertCommandHandler(alertLifecycle),
+		PutAlertEscalationPolicyCommandHandler: alerting_application.NewPutAlertEscalationPolicyCommandHandler(escalationPolicyRepository),
+		FindAlertQueryHandler:                  alerting_application.NewFindAlertQueryHandler(alertRepository, commonServices.TimeProvider),
+		SearchAlertsQueryHandler:               alerting_application.NewSearchAlertsQueryHandler(alertRepository, commonServices.TimeProvider),
+		SearchAlertAuditTrailQueryHandler:      alerting_application.NewSearchAlertAuditTrailQueryHandler(alertRepository, auditTrail),
+		FindAlertEscalationPolicyQueryHandler:  alerting_application.NewFindAlertEscalationPolicyQueryHandler(escalationPolicies),
+	}
+
+	registerAlertingBusesHandlers(commonServices, alertingServices)
+	registerAlertingEventSubscribers(commonServices, alertingServices)
+	httpServices.TenantResourceGuard.Guard(
+		"alertId",
+		func(id string) error { return alerting_domain.NewAlertNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			alert, err := alertRepository.Find(ctx, id)
+
+			return alert != nil, err
+		},
+	)
+	httpServices.TenantResourceGuard.Guard(
+		"alertRuleId",
+		func(id string) error { return alerting_domain.NewAlertRuleNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			rule, err := ruleRepository.Find(ctx, id)
+
+			return rule != nil, err
+		},
+	)
+	registerAlertingRoutes(commonServices, httpServices)
+
+	return alertingServices
+}
+
+func registerAlertingBusesHandlers(commonServices *CommonServices, alertingServices *AlertingServices) {
+	registerCommandOrPanic(commonServices.CommandBus, &alerting_application.CreateAlertRuleCommand{}, alertingServices.CreateAlertRuleCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &alerting_application.UpdateAlertRuleCommand{}, alertingServices.UpdateAlertRuleCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &alerting_application.DeleteAlertRuleCommand{}, alertingServices.DeleteAlertRuleCommandHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &alerting_application.FindAlertRuleQuery{}, alertingServices.FindAlertRuleQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &alerting_application.SearchAlertRulesQuery{}, alertingServices.SearchAlertRulesQueryHandler)
+
+	registerCommandOrPanic(commonServices.CommandBus, &alerting_application.AcknowledgeAlertCommand{}, alertingServices.AcknowledgeAlertCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &alerting_application.SnoozeAlertCommand{}, alertingServices.SnoozeAlertCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &alerting_application.ResolveAlertCommand{}, alertingServices.ResolveAlertCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &alerting_application.PutAlertEscalationPolicyCommand{}, alertingServices.PutAlertEscalationPolicyCommandHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &alerting_application.FindAlertQuery{}, alertingServices.FindAlertQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &alerting_application.SearchAlertsQuery{}, alertingServices.SearchAlertsQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &alerting_application.SearchAlertAuditTrailQuery{}, alertingServices.SearchAlertAuditTrailQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &alerting_application.FindAlertEscalationPolicyQuery{}, alertingServices.FindAlertEscalationPolicyQueryHandler)
+}
+
+func registerAlertingEventSubscribers(commonServices *CommonServices, alertingServices *AlertingServices) {
+	commonServices.EventBus.Subscribe(
+		telemetry_domain.ReadingIngestedEventName,
+		alerting_application.NewReadingIngestedEventHandler(alertingServices.AlertRuleEngine),
+	)
+
+	pestEventReportedEventHandler := alerting_application.NewPestEventReportedEventHandler(alertingServices.AlertRuleEngine)
+	for _, eventName := range pestcontrol_domain.PestEventNames {
+		commonServices.EventBus.Subscribe(eventName, pestEventReportedEventHandler)
+	}
+
+	alertRuleTransitionEventHandler := alerting_application.NewAlertRuleTransitionEventHandler(alertingServices.AlertLifecycle)
+	commonServices.EventBus.Subscribe(alerting_domain.AlertRaisedEventName, alertRuleTransitionEventHandler)
+	commonServices.EventBus.Subscribe(alerting_domain.AlertClearedEventName, alertRuleTransitionEventHandler)
+}
+
+func registerAlertingRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	createAlertRuleJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "alerting", createAlertRuleJsonSchemaFileName),
+	)
+	updateAlertRuleJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "alerting", updateAlertRuleJsonSchemaFileName),
+	)
+
+	httpServices.Router.Get(
+		"/alert-rules",
+		alerting_http.NewGetAlertRulesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/alert-rules",
+		alerting_http.NewCreateAlertRuleController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(createAlertRuleJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/alert-rules/{alertRuleId}",
+		alerting_http.NewGetAlertRuleController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Patch(
+		"/alert-rules/{alertRuleId}",
+		alerting_http.NewUpdateAlertRuleController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(updateAlertRuleJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Delete(
+		"/alert-rules/{alertRuleId}",
+		alerting_http.NewDeleteAlertRuleController(commonServices.CommandBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	alertTransitionJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "alerting", alertTransitionJsonSchemaFileName),
+	)
+	snoozeAlertJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "alerting", snoozeAlertJsonSchemaFileName),
+	)
+	putAlertEscalationPolicyJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "alerting", putAlertEscalationPolicySchemaFileName),
+	)
+
+	httpServices.Router.Get(
+		"/alerts",
+		alerting_http.NewGetAlertsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/alerts/{alertId}",
+		alerting_http.NewGetAlertController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/alerts/{alertId}/acknowledge",
+		alerting_http.NewAcknowledgeAlertController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(alertTransitionJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Post(
+		"/alerts/{alertId}/snooze",
+		alerting_http.NewSnoozeAlertController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(snoozeAlertJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Post(
+		"/alerts/{alertId}/resolve",
+		alerting_http.NewResolveAlertController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(alertTransitionJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/alerts/{alertId}/audit-trail",
+		alerting_http.NewGetAlertAuditTrailController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/alert-escalation-policies/{tenantId}",
+		alerting_http.NewGetAlertEscalationPolicyController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Put(
+		"/alert-escalation-policies/{tenantId}",
+		alerting_http.NewPutAlertEscalationPolicyController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(putAlertEscalationPolicyJsonSchemaValidator.Middleware)...,
+	)
+}
@@ -10,10 +10,12 @@ import (
 
 	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
 	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
 	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
 	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
 	amf_json_schema "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-schema"
 	amf_logger "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
 	amf_observability "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/observability"
 	amf_redis "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/redis"
 	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
@@ -35,11 +37,13 @@ type CommonServices struct {
 	DatabaseConnectionPool amf_sqldb.ConnectionPool
 	DatabaseMigrator       amf_sqldb.Migrator
 	DistributedMutex       amf_sync.MutexService
+	ObjectStorage          amf_object_storage.ObjectStorage
 	UlidProvider           amf_utils.UlidProvider
 	UuidProvider           amf_utils.UuidProvider
 	TimeProvider           amf_utils.DateTimeProvider
 	CommandBus             *amf_command_bus.CommandBus
 	QueryBus               *amf_query_bus.QueryBus
+	EventBus               *amf_event_bus.EventBus
 }
 
 func InitCommonServices(ctx context.Context) *CommonServices {
@@ -54,7 +58,9 @@ func InitCommonServices(ctx context.Context) *CommonServices {
 	timeProvider := amf_utils.NewSystemTimeProvider()
 	commandBus := amf_command_bus.InitCommandBus(logger, redisMutexService)
 	queryBus := amf_query_bus.InitQueryBus(logger)
+	eventBus := amf_event_bus.NewEventBus()
 	databasePool := initPgsqlDatabasePool(ctx, config, environment)
+	objectStorage := initObjectStorage(config)
 	databaseMigrator := amf_sqldb.NewSQLDatabaseMigrator(
 		databasePool.Writer(),
 		config.MigrationsPath,
@@ -87,11 +93,13 @@ func InitCommonServices(ctx context.Context) *CommonServices {
 		DatabaseConnectionPool: databasePool,
 		DatabaseMigrator:       databaseMigrator,
 		DistributedMutex:       redisMutexService,
+		ObjectStorage:          objectStorage,
 		UlidProvider:           ulidProvider,
 		UuidProvider:           uuidProvider,
 		TimeProvider:           timeProvider,
 		CommandBus:             commandBus,
 		QueryBus:               queryBus,
+		EventBus:               eventBus,
 	}
 }
 
@@ -191,3 +199,28 @@ func databaseMigrationFunc(ctx context.Context, common *CommonServices) func() (
 		return migrationsExecuted, nil
 	}
 }
+
+// initObjectStorage falls back to the local filesystem when no S3 compatible endpoint
+// is configured.
+func initObjectStorage(cfg configs.Config) amf_object_storage.ObjectStorage {
+	if cfg.ObjectStorageEndpoint == "" {
+		return amf_object_storage.NewFilesystemObjectStorage(
+			cfg.ObjectStoragePath,
+			amf_object_storage.WithPresignedUrls(cfg.ObjectStoragePresignBaseUrl, cfg.ObjectStoragePresignSecret),
+			amf_object_storage.WithMaxUploadSize(cfg.GlueBoardImageMaxSize),
+		)
+	}
+
+	objectStorage, err := amf_object_storage.NewMinioObjectStorage(
+		cfg.ObjectStorageEndpoint,
+		cfg.ObjectStorageAccessKey,
+		cfg.ObjectStorageSecretKey,
+		cfg.ObjectStorageUseSSL,
+		cfg.ObjectStorageBucket,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return objectStorage
+}
@@ -0,0 +1,99 @@
+package di
+
+import (
+	"time"
+
+	connectivity_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/application"
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+	connectivity_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/infra"
+	connectivity_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/infra/http"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+type ConnectivityServices struct {
+	ConnectivityTracker               *connectivity_application.ConnectivityTracker
+	OfflineDeviceSweeper              *connectivity_application.OfflineDeviceSweeper
+	GetDeviceConnectivityQueryHandler *connectivity_application.GetDeviceConnectivityQueryHandler
+	GetSiteConnectivityQueryHandler   *connectivity_application.GetSiteConnectivityQueryHandler
+}
+
+func InitConnectivityServices(commonServices *CommonServices, httpServices *HttpServices) *ConnectivityServices {
+	directory := connectivity_infra.NewPostgresDeviceDirectory(commonServices.DatabaseConnectionPool)
+	lastSeenRepository := connectivity_infra.NewRedisLastSeenRepository(commonServices.RedisClient)
+	policy := connectivity_domain.NewConnectivityPolicy(
+		time.Duration(commonServices.Config.ConnectivityDefaultReportingInterval)*time.Second,
+		commonServices.Config.ConnectivityMissedReports,
+	)
+
+	connectivityServices := &ConnectivityServices{
+		ConnectivityTracker: connectivity_application.NewConnectivityTracker(
+			directory,
+			lastSeenRepository,
+			policy,
+			commonServices.EventBus,
+		),
+		OfflineDeviceSweeper: connectivity_application.NewOfflineDeviceSweeper(
+			directory,
+			lastSeenRepository,
+			policy,
+			commonServices.EventBus,
+			commonServices.DistributedMutex,
+			commonServices.TimeProvider,
+		),
+		GetDeviceConnectivityQueryHandler: connectivity_application.NewGetDeviceConnectivityQueryHandler(
+			directory,
+			lastSeenRepository,
+			policy,
+			commonServices.TimeProvider,
+		),
+		GetSiteConnectivityQueryHandler: connectivity_application.NewGetSiteConnectivityQueryHandler(
+			directory,
+			lastSeenRepository,
+			policy,
+			commonServices.TimeProvider,
+		),
+	}
+
+	registerConnectivityQueryHandlers(commonServices, connectivityServices)
+	registerConnectivityEventSubscribers(commonServices, connectivityServices)
+	registerConnectivityRoutes(commonServices, httpServices)
+
+	return connectivityServices
+}
+
+func registerConnectivityQueryHandlers(commonServices *CommonServices, connectivityServices *ConnectivityServices) {
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&connectivity_application.GetDeviceConnectivityQuery{},
+		connectivityServices.GetDeviceConnectivityQueryHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&connectivity_application.GetSiteConnectivityQuery{},
+		connectivityServices.GetSiteConnectivityQueryHandler,
+	)
+}
+
+func registerConnectivityEventSubscribers(commonServices *CommonServices, connectivityServices *ConnectivityServices) {
+	commonServices.EventBus.Subscribe(
+		telemetry_domain.ReadingIngestedEventName,
+		connectivity_application.NewReadingIngestedEventHandler(
+			connectivityServices.ConnectivityTracker,
+			commonServices.TimeProvider,
+		),
+	)
+}
+
+func registerConnectivityRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	httpServices.Router.Get(
+		"/devices/{deviceId}/connectivity",
+		connectivity_http.NewGetDeviceConnectivityController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/sites/{siteId}/connectivity",
+		connectivity_http.NewGetSiteConnectivityController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+}
@@ -3,6 +3,11 @@ package di
 import (
 	"context"
 	"log/slog"
+	"sync"
+	"time"
+
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
 )
 
 type DataIngestorDi struct {
@@ -10,6 +15,18 @@ type DataIngestorDi struct {
 	HttpServices             *HttpServices
 	SystemServices           *SystemServices
 	DynamicParameterServices *DynamicParameterServices
+	TelemetryServices        *TelemetryServices
+	AlertingServices         *AlertingServices
+	ConnectivityServices     *ConnectivityServices
+	MaintenanceServices      *MaintenanceServices
+	FirmwareServices         *FirmwareServices
+	DownlinkServices         *DownlinkServices
+	NotificationServices     *NotificationServices
+	PestControlServices      *PestControlServices
+	StreamingServices        *StreamingServices
+	ExportsServices          *ExportsServices
+	ReportsServices          *ReportsServices
+	TenancyServices          *TenancyServices
 }
 
 func InitDataIngestorDi(ctx context.Context) *DataIngestorDi {
@@ -17,12 +34,36 @@ func InitDataIngestorDi(ctx context.Context) *DataIngestorDi {
 	httpServices := InitHttpServices(commonServices)
 	systemServices := InitSystemServices(commonServices, httpServices)
 	dynamicParameterServices := InitDynamicParameterServices(commonServices, httpServices)
+	telemetryServices := InitTelemetryServices(commonServices, httpServices)
+	alertingServices := InitAlertingServices(commonServices, httpServices)
+	connectivityServices := InitConnectivityServices(commonServices, httpServices)
+	maintenanceServices := InitMaintenanceServices(commonServices, httpServices)
+	firmwareServices := InitFirmwareServices(commonServices, httpServices)
+	downlinkServices := InitDownlinkServices(commonServices, httpServices)
+	notificationServices := InitNotificationServices(commonServices, httpServices, maintenanceServices)
+	pestControlServices := InitPestControlServices(commonServices, httpServices)
+	streamingServices := InitStreamingServices(commonServices, httpServices)
+	exportsServices := InitExportsServices(commonServices, httpServices)
+	reportsServices := InitReportsServices(commonServices, httpServices)
+	tenancyServices := InitTenancyServices(commonServices, httpServices)
 
 	return &DataIngestorDi{
 		CommonServices:           commonServices,
 		HttpServices:             httpServices,
 		SystemServices:           systemServices,
 		DynamicParameterServices: dynamicParameterServices,
+		TelemetryServices:        telemetryServices,
+		AlertingServices:         alertingServices,
+		ConnectivityServices:     connectivityServices,
+		MaintenanceServices:      maintenanceServices,
+		FirmwareServices:         firmwareServices,
+		DownlinkServices:         downlinkServices,
+		NotificationServices:     notificationServices,
+		PestControlServices:      pestControlServices,
+		StreamingServices:        streamingServices,
+		ExportsServices:          exportsServices,
+		ReportsServices:          reportsServices,
+		TenancyServices:          tenancyServices,
 	}
 }
 
@@ -34,6 +75,63 @@ func (iod *DataIngestorDi) RunDatabaseMigrations(ctx context.Context) {
 	}
 }
 
+func (iod *DataIngestorDi) StartDynamicParametersConfigWatcher(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.DynamicParametersReloadInterval, iod.DynamicParameterServices.DynamicParametersConfigWatcher.Check)
+}
+
+func (iod *DataIngestorDi) StartDynamicParameterScheduler(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.DynamicParametersSchedulerInterval, iod.DynamicParameterServices.DynamicParameterScheduler.Run)
+}
+
+func (iod *DataIngestorDi) StartOfflineDeviceSweeper(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.ConnectivitySweeperInterval, iod.ConnectivityServices.OfflineDeviceSweeper.Run)
+}
+
+func (iod *DataIngestorDi) StartAlertEscalator(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.AlertEscalatorInterval, iod.AlertingServices.AlertEscalator.Run)
+}
+
+func (iod *DataIngestorDi) StartWebhookDeliveryWorker(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.WebhookDeliveryInterval, iod.NotificationServices.WebhookDeliveryWorker.Run)
+}
+
+func (iod *DataIngestorDi) StartMaintenanceWindowCloser(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.MaintenanceCloserInterval, iod.MaintenanceServices.MaintenanceWindowCloser.Run)
+}
+
+func (iod *DataIngestorDi) StartFirmwareCampaignProgressor(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.FirmwareCampaignProgressorInterval, iod.FirmwareServices.FirmwareCampaignProgressor.Run)
+}
+
+func (iod *DataIngestorDi) StartExportJobWorker(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.ExportWorkerInterval, iod.ExportsServices.ExportJobWorker.Run)
+}
+
+func (iod *DataIngestorDi) StartMonthlySiteReportScheduler(ctx context.Context, wg *sync.WaitGroup) {
+	iod.startIntervalWorker(ctx, wg, iod.CommonServices.Config.ReportSchedulerInterval, iod.ReportsServices.MonthlySiteReportScheduler.Run)
+}
+
+// startIntervalWorker runs fn every intervalSeconds for every tenant until ctx is done.
+func (iod *DataIngestorDi) startIntervalWorker(ctx context.Context, wg *sync.WaitGroup, intervalSeconds int, fn amf_utils.ExecutorFunc) {
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+
+	wg.Add(1)
+	go func() {
+		defer ticker.Stop()
+		amf_utils.IntervalExecutor(amf_tenancy.WithAllTenants(ctx), fn, iod.CommonServices.Logger, ticker, wg)
+	}()
+}
+
+func (iod *DataIngestorDi) StartStreamHub(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := iod.StreamingServices.StreamHub.Run(amf_tenancy.WithAllTenants(ctx)); err != nil && ctx.Err() == nil {
+			iod.CommonServices.Logger.Error(ctx, "stream hub stopped", slog.String("error", err.Error()))
+		}
+	}()
+}
+
 func (iod *DataIngestorDi) ErrorShutdown(ctx context.Context, cancel context.CancelFunc, err error) {
 	defer cancel()
 	if err == nil {
@@ -0,0 +1,113 @@
+package di
+
+import (
+	"fmt"
+	"time"
+
+	downlinks_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/application"
+	downlinks_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/infra"
+	downlinks_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/infra/http"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const (
+	enqueueDownlinkJsonSchemaFileName     = "enqueue-downlink.schema.json"
+	acknowledgeDownlinkJsonSchemaFileName = "acknowledge-downlink.schema.json"
+)
+
+type DownlinkServices struct {
+	EnqueueDownlinkCommandHandler      *downlinks_application.EnqueueDownlinkCommandHandler
+	MarkDownlinksSentCommandHandler    *downlinks_application.MarkDownlinksSentCommandHandler
+	AcknowledgeDownlinkCommandHandler  *downlinks_application.AcknowledgeDownlinkCommandHandler
+	FindPendingDownlinksQueryHandler   *downlinks_application.FindPendingDownlinksQueryHandler
+	FindDownlinkCommandQueryHandler    *downlinks_application.FindDownlinkCommandQueryHandler
+	SearchDownlinkCommandsQueryHandler *downlinks_application.SearchDownlinkCommandsQueryHandler
+}
+
+func InitDownlinkServices(commonServices *CommonServices, httpServices *HttpServices) *DownlinkServices {
+	downlinkRepository := downlinks_infra.NewPostgresDownlinkCommandRepository(commonServices.DatabaseConnectionPool)
+
+	downlinkServices := &DownlinkServices{
+		EnqueueDownlinkCommandHandler: downlinks_application.NewEnqueueDownlinkCommandHandler(
+			downlinkRepository,
+			time.Duration(commonServices.Config.DownlinkDefaultTtl)*time.Second,
+			commonServices.TimeProvider,
+		),
+		MarkDownlinksSentCommandHandler: downlinks_application.NewMarkDownlinksSentCommandHandler(
+			downlinkRepository,
+			commonServices.TimeProvider,
+		),
+		AcknowledgeDownlinkCommandHandler: downlinks_application.NewAcknowledgeDownlinkCommandHandler(
+			downlinkRepository,
+			commonServices.TimeProvider,
+		),
+		FindPendingDownlinksQueryHandler: downlinks_application.NewFindPendingDownlinksQueryHandler(
+			downlinkRepository,
+			commonServices.Config.DownlinkMaxPerUplink,
+			commonServices.TimeProvider,
+		),
+		FindDownlinkCommandQueryHandler: downlinks_application.NewFindDownlinkCommandQueryHandler(
+			downlinkRepository,
+			commonServices.TimeProvider,
+		),
+		SearchDownlinkCommandsQueryHandler: downlinks_application.NewSearchDownlinkCommandsQueryHandler(
+			downlinkRepository,
+			commonServices.TimeProvider,
+		),
+	}
+
+	registerDownlinkBusesHandlers(commonServices, downlinkServices)
+	registerDownlinkRoutes(commonServices, httpServices)
+
+	return downlinkServices
+}
+
+func registerDownlinkBusesHandlers(commonServices *CommonServices, downlinkServices *DownlinkServices) {
+	registerCommandOrPanic(commonServices.CommandBus, &downlinks_application.EnqueueDownlinkCommand{}, downlinkServices.EnqueueDownlinkCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &downlinks_application.MarkDownlinksSentCommand{}, downlinkServices.MarkDownlinksSentCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &downlinks_application.AcknowledgeDownlinkCommand{}, downlinkServices.AcknowledgeDownlinkCommandHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &downlinks_application.FindPendingDownlinksQuery{}, downlinkServices.FindPendingDownlinksQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &downlinks_application.FindDownlinkCommandQuery{}, downlinkServices.FindDownlinkCommandQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &downlinks_application.SearchDownlinkCommandsQuery{}, downlinkServices.SearchDownlinkCommandsQueryHandler)
+}
+
+func registerDownlinkRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	enqueueDownlinkJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "downlinks", enqueueDownlinkJsonSchemaFileName),
+	)
+	acknowledgeDownlinkJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "downlinks", acknowledgeDownlinkJsonSchemaFileName),
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/downlinks",
+		downlinks_http.NewGetDownlinksController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/devices/{deviceId}/downlinks",
+		downlinks_http.NewEnqueueDownlinkController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(enqueueDownlinkJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/downlinks/{commandId}",
+		downlinks_http.NewGetDownlinkController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/devices/{deviceId}/downlinks/{commandId}/ack",
+		downlinks_http.NewAcknowledgeDownlinkController(commonServices.CommandBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.DeviceScoped(acknowledgeDownlinkJsonSchemaValidator.Middleware)...,
+	)
+}
@@ -2,26 +2,66 @@ package di
 
 import (
 	"fmt"
+	"time"
 
 	amf_dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
 	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
 )
 
-const changeDynamicParameterJsonSchemaFileName = "change-dynamic-parameter.schema.json"
+const (
+	changeDynamicParameterJsonSchemaFileName          = "change-dynamic-parameter.schema.json"
+	importDynamicParameterOverridesJsonSchemaFileName = "import-dynamic-parameter-overrides.schema.json"
+)
 
 type DynamicParameterServices struct {
-	DynamicParameterRetriever *amf_dynamic_parameter.DynamicParameterRetriever
-	StaticApiKeyStorage       []amf_http_server.StaticApiKey
+	DynamicParameterRetriever      *amf_dynamic_parameter.DynamicParameterRetriever
+	DynamicParametersConfigWatcher *amf_dynamic_parameter.DynamicParametersConfigWatcher
+	DynamicParameterScheduler      *amf_dynamic_parameter.DynamicParameterScheduler
+	StaticApiKeyStorage            []amf_http_server.StaticApiKey
 }
 
 func InitDynamicParameterServices(commonServices *CommonServices, httpServices *HttpServices) *DynamicParameterServices {
 	repository := amf_dynamic_parameter.NewRedisDynamicParameterRepository(commonServices.RedisClient)
+	scheduleRepository := amf_dynamic_parameter.NewRedisDynamicParameterScheduleRepository(commonServices.RedisClient)
+	changeRequestRepository := amf_dynamic_parameter.NewRedisDynamicParameterChangeRequestRepository(commonServices.RedisClient)
+	auditLog := amf_dynamic_parameter.NewRedisDynamicParameterAuditLog(commonServices.RedisClient)
+	approvalPolicy := amf_dynamic_parameter.NewDynamicParameterApprovalPolicyFromCommaSeparatedString(
+		time.Duration(commonServices.Config.DynamicParametersChangeRequestTTL)*time.Second,
+		commonServices.Config.DynamicParametersSensitive,
+	)
 	retriever := amf_dynamic_parameter.NewDynamicParameterRetrieverFromConfigFile(repository, commonServices.Config.DynamicParametersFilePath)
+	configWatcher, err := amf_dynamic_parameter.NewDynamicParametersConfigWatcher(
+		commonServices.Config.DynamicParametersFilePath,
+		retriever,
+		commonServices.Logger,
+		commonServices.Observability.Meter,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	scheduler := amf_dynamic_parameter.NewDynamicParameterScheduler(
+		retriever,
+		repository,
+		scheduleRepository,
+		changeRequestRepository,
+		auditLog,
+		commonServices.DistributedMutex,
+		commonServices.UlidProvider,
+		commonServices.TimeProvider,
+		commonServices.Logger,
+	)
 
 	amf_dynamic_parameter.RegisterDynamicParameterBusesOperations(
 		commonServices.UlidProvider,
+		commonServices.TimeProvider,
 		retriever,
 		repository,
+		repository,
+		scheduleRepository,
+		changeRequestRepository,
+		auditLog,
+		approvalPolicy,
 		commonServices.CommandBus,
 		commonServices.QueryBus,
 	)
@@ -29,8 +69,10 @@ func InitDynamicParameterServices(commonServices *CommonServices, httpServices *
 	staticApiKeys := amf_http_server.StaticApiKeysFromPipedString(commonServices.Config.DynamicParametersApiKeys)
 
 	dynamicParametersServices := &DynamicParameterServices{
-		DynamicParameterRetriever: retriever,
-		StaticApiKeyStorage:       staticApiKeys,
+		DynamicParameterRetriever:      retriever,
+		DynamicParametersConfigWatcher: configWatcher,
+		DynamicParameterScheduler:      scheduler,
+		StaticApiKeyStorage:            staticApiKeys,
 	}
 
 	registerDynamicParameterRoutes(dynamicParametersServices, commonServices, httpServices)
@@ -59,22 +101,102 @@ func registerDynamicParameterRoutes(
 		),
 	)
 
+	importDynamicParameterOverridesJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf(
+			"%s/%s/%s",
+			commonServices.Config.JsonSchemaBasePath,
+			"dynamic-parameters",
+			importDynamicParameterOverridesJsonSchemaFileName,
+		),
+	)
+
 	httpServices.Router.Get(
 		"/system/parameter/{parameterName}",
 		amf_dynamic_parameter.HandleGetDynamicParameter(
 			commonServices.QueryBus,
 			httpServices.JsonApiResponseMiddleware,
 		),
-		staticApiKeysMiddleware.Middleware,
+		httpServices.SystemScoped(staticApiKeysMiddleware.Middleware)...,
 	)
 
 	httpServices.Router.Put(
 		"/system/parameter/{parameterName}",
 		amf_dynamic_parameter.HandleChangeDynamicParameter(
 			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.SystemScoped(
+			staticApiKeysMiddleware.Middleware,
+			changeDynamicParameterJsonSchemaValidator.Middleware,
+		)...,
+	)
+
+	httpServices.Router.Get(
+		"/system/parameter/{parameterName}/change-requests",
+		amf_dynamic_parameter.HandleGetDynamicParameterChangeRequests(
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.SystemScoped(staticApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/system/parameter/{parameterName}/change-requests/{changeRequestId}",
+		amf_dynamic_parameter.HandleGetDynamicParameterChangeRequest(
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.SystemScoped(staticApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Post(
+		"/system/parameter/{parameterName}/change-requests/{changeRequestId}/approve",
+		amf_dynamic_parameter.HandleApproveDynamicParameterChangeRequest(
+			commonServices.CommandBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.SystemScoped(staticApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Post(
+		"/system/parameter/{parameterName}/change-requests/{changeRequestId}/reject",
+		amf_dynamic_parameter.HandleRejectDynamicParameterChangeRequest(
+			commonServices.CommandBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.SystemScoped(staticApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/system/parameter/{parameterName}/audit-log",
+		amf_dynamic_parameter.HandleGetDynamicParameterAuditLog(
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.SystemScoped(staticApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/system/parameter-overrides",
+		amf_dynamic_parameter.HandleExportDynamicParameterOverrides(
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.SystemScoped(staticApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Post(
+		"/system/parameter-overrides/import",
+		amf_dynamic_parameter.HandleImportDynamicParameterOverrides(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
 			httpServices.JsonApiResponseMiddleware,
 		),
-		staticApiKeysMiddleware.Middleware,
-		changeDynamicParameterJsonSchemaValidator.Middleware,
+		httpServices.SystemScoped(
+			staticApiKeysMiddleware.Middleware,
+			importDynamicParameterOverridesJsonSchemaValidator.Middleware,
+		)...,
 	)
 }
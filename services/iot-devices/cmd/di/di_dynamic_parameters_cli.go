@@ -0,0 +1,19 @@
+package di
+
+import "context"
+
+type DynamicParametersCliDi struct {
+	CommonServices           *CommonServices
+	DynamicParameterServices *DynamicParameterServices
+}
+
+func InitDynamicParametersCliDi(ctx context.Context) *DynamicParametersCliDi {
+	commonServices := InitCommonServices(ctx)
+	httpServices := InitHttpServices(commonServices)
+	dynamicParameterServices := InitDynamicParameterServices(commonServices, httpServices)
+
+	return &DynamicParametersCliDi{
+		CommonServices:           commonServices,
+		DynamicParameterServices: dynamicParameterServices,
+	}
+}
@@ -0,0 +1,118 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	exports_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/application"
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+	exports_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/infra"
+	exports_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/infra/http"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const requestTrapActivityExportJsonSchemaFileName = "request-trap-activity-export.schema.json"
+
+type ExportsServices struct {
+	TrapActivityExporter                    *exports_application.TrapActivityExporter
+	RequestTrapActivityExportCommandHandler *exports_application.RequestTrapActivityExportCommandHandler
+	FindExportJobQueryHandler               *exports_application.FindExportJobQueryHandler
+	ExportJobWorker                         *exports_application.ExportJobWorker
+}
+
+func InitExportsServices(commonServices *CommonServices, httpServices *HttpServices) *ExportsServices {
+	trapActivityReader := exports_infra.NewPostgresTrapActivityReader(
+		commonServices.DatabaseConnectionPool,
+		commonServices.Config.ExportFetchSize,
+	)
+	exportJobRepository := exports_infra.NewPostgresExportJobRepository(commonServices.DatabaseConnectionPool)
+	exportFileStorage := exports_infra.NewObjectStorageExportFileStorage(commonServices.ObjectStorage)
+	trapActivityExporter := exports_application.NewTrapActivityExporter(trapActivityReader)
+
+	exportsServices := &ExportsServices{
+		TrapActivityExporter: trapActivityExporter,
+		RequestTrapActivityExportCommandHandler: exports_application.NewRequestTrapActivityExportCommandHandler(
+			exportJobRepository,
+			commonServices.TimeProvider,
+		),
+		FindExportJobQueryHandler: exports_application.NewFindExportJobQueryHandler(
+			exportJobRepository,
+			exportFileStorage,
+			time.Duration(commonServices.Config.ExportDownloadUrlTtl)*time.Second,
+			commonServices.TimeProvider,
+		),
+		ExportJobWorker: exports_application.NewExportJobWorker(
+			exportJobRepository,
+			trapActivityExporter,
+			exportFileStorage,
+			commonServices.DistributedMutex,
+			commonServices.TimeProvider,
+			commonServices.Config.ExportWorkerBatchSize,
+			time.Duration(commonServices.Config.ExportJobStaleAfter)*time.Second,
+		),
+	}
+
+	registerExportsBusesHandlers(commonServices, exportsServices)
+	httpServices.TenantResourceGuard.Guard(
+		"exportId",
+		func(id string) error { return exports_domain.NewExportJobNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			job, err := exportJobRepository.Find(ctx, id)
+
+			return job != nil, err
+		},
+	)
+	registerExportsRoutes(commonServices, httpServices, exportsServices)
+
+	return exportsServices
+}
+
+func registerExportsBusesHandlers(commonServices *CommonServices, exportsServices *ExportsServices) {
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&exports_application.RequestTrapActivityExportCommand{},
+		exportsServices.RequestTrapActivityExportCommandHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&exports_application.FindExportJobQuery{},
+		exportsServices.FindExportJobQueryHandler,
+	)
+}
+
+func registerExportsRoutes(commonServices *CommonServices, httpServices *HttpServices, exportsServices *ExportsServices) {
+	requestTrapActivityExportJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "exports", requestTrapActivityExportJsonSchemaFileName),
+	)
+
+	httpServices.Router.Get(
+		"/sites/{siteId}/trap-activity",
+		exports_http.NewExportTrapActivityController(
+			exportsServices.TrapActivityExporter,
+			time.Duration(commonServices.Config.ExportStreamMaxPeriod)*time.Second,
+			commonServices.Logger,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/sites/{siteId}/trap-activity/exports",
+		exports_http.NewRequestTrapActivityExportController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(requestTrapActivityExportJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/exports/{exportId}",
+		exports_http.NewGetExportJobController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+}
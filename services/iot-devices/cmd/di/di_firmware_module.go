@@ -0,0 +1,252 @@
+package di
+
+import (
+	"context"
+	"fmt"
+
+	firmware_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/application"
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+	firmware_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/infra"
+	firmware_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/infra/http"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const (
+	createFirmwareCampaignJsonSchemaFileName = "create-firmware-campaign.schema.json"
+	haltFirmwareCampaignJsonSchemaFileName   = "halt-firmware-campaign.schema.json"
+	resumeFirmwareCampaignJsonSchemaFileName = "resume-firmware-campaign.schema.json"
+	reportFirmwareUpdateJsonSchemaFileName   = "report-firmware-update.schema.json"
+)
+
+type FirmwareServices struct {
+	FirmwareCampaignProgressor                *firmware_application.FirmwareCampaignProgressor
+	UploadFirmwareImageCommandHandler         *firmware_application.UploadFirmwareImageCommandHandler
+	CreateFirmwareCampaignCommandHandler      *firmware_application.CreateFirmwareCampaignCommandHandler
+	HaltFirmwareCampaignCommandHandler        *firmware_application.HaltFirmwareCampaignCommandHandler
+	ResumeFirmwareCampaignCommandHandler      *firmware_application.ResumeFirmwareCampaignCommandHandler
+	ReportFirmwareUpdateCommandHandler        *firmware_application.ReportFirmwareUpdateCommandHandler
+	FindFirmwareImageQueryHandler             *firmware_application.FindFirmwareImageQueryHandler
+	SearchFirmwareImagesQueryHandler          *firmware_application.SearchFirmwareImagesQueryHandler
+	FindFirmwareCampaignQueryHandler          *firmware_application.FindFirmwareCampaignQueryHandler
+	SearchFirmwareCampaignsQueryHandler       *firmware_application.SearchFirmwareCampaignsQueryHandler
+	SearchFirmwareCampaignDevicesQueryHandler *firmware_application.SearchFirmwareCampaignDevicesQueryHandler
+	FindDeviceFirmwareUpdateQueryHandler      *firmware_application.FindDeviceFirmwareUpdateQueryHandler
+	GetFirmwareChunkQueryHandler              *firmware_application.GetFirmwareChunkQueryHandler
+}
+
+func InitFirmwareServices(commonServices *CommonServices, httpServices *HttpServices) *FirmwareServices {
+	imageRepository := firmware_infra.NewPostgresFirmwareImageRepository(commonServices.DatabaseConnectionPool)
+	campaignRepository := firmware_infra.NewPostgresFirmwareCampaignRepository(commonServices.DatabaseConnectionPool)
+	updateRepository := firmware_infra.NewPostgresFirmwareUpdateRepository(commonServices.DatabaseConnectionPool)
+
+	signatureVerifier, err := firmware_infra.NewEd25519FirmwareSignatureVerifier(commonServices.Config.FirmwareSigningPublicKey)
+	if err != nil {
+		panic(err)
+	}
+
+	firmwareServices := &FirmwareServices{
+		FirmwareCampaignProgressor: firmware_application.NewFirmwareCampaignProgressor(
+			campaignRepository,
+			updateRepository,
+			commonServices.EventBus,
+			commonServices.DistributedMutex,
+			commonServices.TimeProvider,
+		),
+		UploadFirmwareImageCommandHandler: firmware_application.NewUploadFirmwareImageCommandHandler(
+			imageRepository,
+			commonServices.ObjectStorage,
+			signatureVerifier,
+			commonServices.Config.FirmwareChunkSize,
+			commonServices.TimeProvider,
+		),
+		CreateFirmwareCampaignCommandHandler: firmware_application.NewCreateFirmwareCampaignCommandHandler(
+			imageRepository,
+			campaignRepository,
+			updateRepository,
+			firmware_infra.NewPostgresFirmwareDeviceCatalog(commonServices.DatabaseConnectionPool),
+			commonServices.TimeProvider,
+		),
+		HaltFirmwareCampaignCommandHandler: firmware_application.NewHaltFirmwareCampaignCommandHandler(
+			campaignRepository,
+			commonServices.EventBus,
+			commonServices.DistributedMutex,
+			commonServices.TimeProvider,
+		),
+		ResumeFirmwareCampaignCommandHandler: firmware_application.NewResumeFirmwareCampaignCommandHandler(
+			campaignRepository,
+			commonServices.DistributedMutex,
+			commonServices.TimeProvider,
+		),
+		ReportFirmwareUpdateCommandHandler: firmware_application.NewReportFirmwareUpdateCommandHandler(
+			updateRepository,
+			commonServices.TimeProvider,
+		),
+		FindFirmwareImageQueryHandler:    firmware_application.NewFindFirmwareImageQueryHandler(imageRepository),
+		SearchFirmwareImagesQueryHandler: firmware_application.NewSearchFirmwareImagesQueryHandler(imageRepository),
+		FindFirmwareCampaignQueryHandler: firmware_application.NewFindFirmwareCampaignQueryHandler(campaignRepository),
+		SearchFirmwareCampaignsQueryHandler: firmware_application.NewSearchFirmwareCampaignsQueryHandler(
+			campaignRepository,
+		),
+		SearchFirmwareCampaignDevicesQueryHandler: firmware_application.NewSearchFirmwareCampaignDevicesQueryHandler(
+			campaignRepository,
+			updateRepository,
+		),
+		FindDeviceFirmwareUpdateQueryHandler: firmware_application.NewFindDeviceFirmwareUpdateQueryHandler(
+			imageRepository,
+			updateRepository,
+		),
+		GetFirmwareChunkQueryHandler: firmware_application.NewGetFirmwareChunkQueryHandler(
+			imageRepository,
+			updateRepository,
+			commonServices.ObjectStorage,
+		),
+	}
+
+	registerFirmwareBusesHandlers(commonServices, firmwareServices)
+	httpServices.TenantResourceGuard.Guard(
+		"campaignId",
+		func(id string) error { return firmware_domain.NewFirmwareCampaignNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			campaign, err := campaignRepository.Find(ctx, id)
+
+			return campaign != nil, err
+		},
+	)
+	registerFirmwareRoutes(commonServices, httpServices)
+
+	return firmwareServices
+}
+
+func registerFirmwareBusesHandlers(commonServices *CommonServices, firmwareServices *FirmwareServices) {
+	registerCommandOrPanic(commonServices.CommandBus, &firmware_application.UploadFirmwareImageCommand{}, firmwareServices.UploadFirmwareImageCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &firmware_application.CreateFirmwareCampaignCommand{}, firmwareServices.CreateFirmwareCampaignCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &firmware_application.HaltFirmwareCampaignCommand{}, firmwareServices.HaltFirmwareCampaignCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &firmware_application.ResumeFirmwareCampaignCommand{}, firmwareServices.ResumeFirmwareCampaignCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &firmware_application.ReportFirmwareUpdateCommand{}, firmwareServices.ReportFirmwareUpdateCommandHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &firmware_application.FindFirmwareImageQuery{}, firmwareServices.FindFirmwareImageQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &firmware_application.SearchFirmwareImagesQuery{}, firmwareServices.SearchFirmwareImagesQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &firmware_application.FindFirmwareCampaignQuery{}, firmwareServices.FindFirmwareCampaignQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &firmware_application.SearchFirmwareCampaignsQuery{}, firmwareServices.SearchFirmwareCampaignsQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &firmware_application.SearchFirmwareCampaignDevicesQuery{}, firmwareServices.SearchFirmwareCampaignDevicesQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &firmware_application.FindDeviceFirmwareUpdateQuery{}, firmwareServices.FindDeviceFirmwareUpdateQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &firmware_application.GetFirmwareChunkQuery{}, firmwareServices.GetFirmwareChunkQueryHandler)
+}
+
+// registerFirmwareRoutes leaves the images to the api key holders, while devices only
+// get to download their update and report how it went.
+func registerFirmwareRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	firmwareImagesApiKeysMiddleware := amf_http_server.NewApiKeyValidationMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		amf_http_server.WithLogger(commonServices.Logger),
+		amf_http_server.WithKeysByOwner(amf_http_server.StaticApiKeysFromPipedString(commonServices.Config.FirmwareImagesApiKeys)...),
+	)
+
+	createFirmwareCampaignJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "firmware", createFirmwareCampaignJsonSchemaFileName),
+	)
+	haltFirmwareCampaignJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "firmware", haltFirmwareCampaignJsonSchemaFileName),
+	)
+	resumeFirmwareCampaignJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "firmware", resumeFirmwareCampaignJsonSchemaFileName),
+	)
+	reportFirmwareUpdateJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "firmware", reportFirmwareUpdateJsonSchemaFileName),
+	)
+
+	httpServices.Router.Get(
+		"/firmware-images",
+		firmware_http.NewGetFirmwareImagesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.SystemScoped(firmwareImagesApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Post(
+		"/firmware-images",
+		firmware_http.NewUploadFirmwareImageController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			commonServices.Config.FirmwareMaxSize,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.SystemScoped(firmwareImagesApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/firmware-images/{firmwareId}",
+		firmware_http.NewGetFirmwareImageController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.SystemScoped(firmwareImagesApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/firmware-campaigns",
+		firmware_http.NewGetFirmwareCampaignsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/firmware-campaigns",
+		firmware_http.NewCreateFirmwareCampaignController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(createFirmwareCampaignJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/firmware-campaigns/{campaignId}",
+		firmware_http.NewGetFirmwareCampaignController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/firmware-campaigns/{campaignId}/halt",
+		firmware_http.NewHaltFirmwareCampaignController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(haltFirmwareCampaignJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Post(
+		"/firmware-campaigns/{campaignId}/resume",
+		firmware_http.NewResumeFirmwareCampaignController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(resumeFirmwareCampaignJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/firmware-campaigns/{campaignId}/devices",
+		firmware_http.NewGetFirmwareCampaignDevicesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/firmware",
+		firmware_http.NewGetDeviceFirmwareController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.DeviceScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/firmware/{firmwareId}/chunks/{index:[0-9]+}",
+		firmware_http.NewGetFirmwareChunkController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.DeviceScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/devices/{deviceId}/firmware/{firmwareId}/report",
+		firmware_http.NewReportFirmwareUpdateController(commonServices.CommandBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.DeviceScoped(reportFirmwareUpdateJsonSchemaValidator.Middleware)...,
+	)
+}
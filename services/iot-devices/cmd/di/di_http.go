@@ -1,23 +1,100 @@
 package di
 
 import (
+	"net/http"
+
 	"github.com/AntonioMartinezFernandez/services/iot-devices/configs"
 
+	tenancy_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/infra"
+	tenancy_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/infra/http"
+
 	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
 	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
 	amf_observability "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/observability"
 )
 
 type HttpServices struct {
 	Router                    *amf_http_server.Router
 	JsonApiResponseMiddleware *amf_json_api.JsonApiResponseMiddleware
+	TenantApiKeysMiddleware   *amf_http_server.ApiKeyValidationMiddleware
+	TenantMiddleware          *amf_http_server.TenantMiddleware
+	TenantResourceGuard       *tenancy_http.TenantResourceGuard
+	DeviceKeysMiddleware      *amf_http_server.DeviceKeyValidationMiddleware
 }
 
 func InitHttpServices(commonServices *CommonServices) *HttpServices {
-	return &HttpServices{
+	jsonApiResponseMiddleware := amf_json_api.NewJsonApiResponseMiddleware(commonServices.Logger)
+	httpServices := &HttpServices{
 		Router:                    newRouter(commonServices.Config, commonServices),
-		JsonApiResponseMiddleware: amf_json_api.NewJsonApiResponseMiddleware(commonServices.Logger),
+		JsonApiResponseMiddleware: jsonApiResponseMiddleware,
+		// The keys of the tenants are owned by the tenant they give access to
+		TenantApiKeysMiddleware: amf_http_server.NewApiKeyValidationMiddleware(
+			jsonApiResponseMiddleware,
+			amf_http_server.WithHeaderName(amf_http_server.HeaderTenantApiKey),
+			amf_http_server.WithLogger(commonServices.Logger),
+			amf_http_server.WithKeysByOwner(amf_http_server.StaticApiKeysFromPipedString(commonServices.Config.TenantApiKeys)...),
+		),
+		TenantMiddleware: amf_http_server.NewTenantMiddleware(jsonApiResponseMiddleware),
+		TenantResourceGuard: tenancy_http.NewTenantResourceGuard(
+			tenancy_infra.NewPostgresCustomerRepository(commonServices.DatabaseConnectionPool),
+			tenancy_infra.NewPostgresSiteRepository(commonServices.DatabaseConnectionPool),
+			tenancy_infra.NewPostgresZoneRepository(commonServices.DatabaseConnectionPool),
+			tenancy_infra.NewPostgresDeviceRepository(commonServices.DatabaseConnectionPool),
+			jsonApiResponseMiddleware,
+		),
+		DeviceKeysMiddleware: amf_http_server.NewDeviceKeyValidationMiddleware(
+			jsonApiResponseMiddleware,
+			commonServices.Config.DeviceKeysSecret,
+		),
 	}
+
+	registerObjectStorageRoutes(commonServices, httpServices)
+
+	return httpServices
+}
+
+// TenantScoped scopes a route to the tenant whose api key authenticated the request,
+// refusing the resources in its path that belong to another one.
+func (hs *HttpServices) TenantScoped(middlewares ...amf_http_server.Middleware) []amf_http_server.Middleware {
+	return append([]amf_http_server.Middleware{
+		hs.TenantApiKeysMiddleware.Middleware,
+		hs.TenantMiddleware.Middleware,
+		hs.TenantResourceGuard.Middleware,
+	}, middlewares...)
+}
+
+// SystemScoped opens a route to every tenant, for the routes serving the system rather
+// than a tenant. They authenticate the callers on their own, like the operators with
+// their api keys.
+func (hs *HttpServices) SystemScoped(middlewares ...amf_http_server.Middleware) []amf_http_server.Middleware {
+	return append([]amf_http_server.Middleware{amf_http_server.AllTenantsMiddleware}, middlewares...)
+}
+
+// DeviceScoped opens a route of a device to the device itself, authenticated by the key
+// derived for the device in its path.
+func (hs *HttpServices) DeviceScoped(middlewares ...amf_http_server.Middleware) []amf_http_server.Middleware {
+	return hs.SystemScoped(append([]amf_http_server.Middleware{hs.DeviceKeysMiddleware.Middleware}, middlewares...)...)
+}
+
+// registerObjectStorageRoutes serves the presigned urls of the filesystem storage, as it
+// has no server of its own.
+func registerObjectStorageRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	filesystemStorage, ok := commonServices.ObjectStorage.(*amf_object_storage.FilesystemObjectStorage)
+	if !ok || commonServices.Config.ObjectStoragePresignBaseUrl == "" {
+		return
+	}
+
+	basePath, err := filesystemStorage.PresignedUrlBasePath()
+	if err != nil {
+		panic(err)
+	}
+
+	httpServices.Router.Route(
+		[]string{http.MethodGet, http.MethodPut},
+		basePath+"/{key:.+}",
+		filesystemStorage.PresignedUrlHandler(),
+	)
 }
 
 func newRouter(config configs.Config, commonServices *CommonServices) *amf_http_server.Router {
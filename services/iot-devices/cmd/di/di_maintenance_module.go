@@ -0,0 +1,150 @@
+package di
+
+import (
+	"context"
+	"fmt"
+
+	maintenance_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/application"
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+	maintenance_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/infra"
+	maintenance_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/infra/http"
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const createMaintenanceWindowJsonSchemaFileName = "create-maintenance-window.schema.json"
+
+type MaintenanceServices struct {
+	MaintenanceSuppressor                 *maintenance_application.MaintenanceSuppressor
+	SuppressedEventRecorder               *maintenance_application.SuppressedEventRecorder
+	MaintenanceWindowCloser               *maintenance_application.MaintenanceWindowCloser
+	CreateMaintenanceWindowCommandHandler *maintenance_application.CreateMaintenanceWindowCommandHandler
+	EndMaintenanceWindowCommandHandler    *maintenance_application.EndMaintenanceWindowCommandHandler
+	FindMaintenanceWindowQueryHandler     *maintenance_application.FindMaintenanceWindowQueryHandler
+	SearchMaintenanceWindowsQueryHandler  *maintenance_application.SearchMaintenanceWindowsQueryHandler
+	SearchSuppressedEventsQueryHandler    *maintenance_application.SearchSuppressedEventsQueryHandler
+}
+
+func InitMaintenanceServices(commonServices *CommonServices, httpServices *HttpServices) *MaintenanceServices {
+	windowRepository := maintenance_infra.NewPostgresMaintenanceWindowRepository(commonServices.DatabaseConnectionPool)
+	suppressedEventRepository := maintenance_infra.NewPostgresSuppressedEventRepository(commonServices.DatabaseConnectionPool)
+
+	suppressor := maintenance_application.NewMaintenanceSuppressor(
+		windowRepository,
+		maintenance_infra.NewPostgresDeviceLocator(commonServices.DatabaseConnectionPool),
+		commonServices.TimeProvider,
+	)
+	closer := maintenance_application.NewMaintenanceWindowCloser(
+		windowRepository,
+		suppressedEventRepository,
+		commonServices.EventBus,
+		commonServices.DistributedMutex,
+		commonServices.TimeProvider,
+	)
+
+	maintenanceServices := &MaintenanceServices{
+		MaintenanceSuppressor: suppressor,
+		SuppressedEventRecorder: maintenance_application.NewSuppressedEventRecorder(
+			suppressor,
+			suppressedEventRepository,
+			commonServices.UlidProvider,
+			commonServices.TimeProvider,
+		),
+		MaintenanceWindowCloser: closer,
+		CreateMaintenanceWindowCommandHandler: maintenance_application.NewCreateMaintenanceWindowCommandHandler(
+			windowRepository,
+			commonServices.TimeProvider,
+		),
+		EndMaintenanceWindowCommandHandler: maintenance_application.NewEndMaintenanceWindowCommandHandler(closer),
+		FindMaintenanceWindowQueryHandler: maintenance_application.NewFindMaintenanceWindowQueryHandler(
+			windowRepository,
+			commonServices.TimeProvider,
+		),
+		SearchMaintenanceWindowsQueryHandler: maintenance_application.NewSearchMaintenanceWindowsQueryHandler(
+			windowRepository,
+			commonServices.TimeProvider,
+		),
+		SearchSuppressedEventsQueryHandler: maintenance_application.NewSearchSuppressedEventsQueryHandler(
+			windowRepository,
+			suppressedEventRepository,
+		),
+	}
+
+	registerMaintenanceBusesHandlers(commonServices, maintenanceServices)
+	registerMaintenanceEventSubscribers(commonServices, maintenanceServices)
+	httpServices.TenantResourceGuard.Guard(
+		"windowId",
+		func(id string) error { return maintenance_domain.NewMaintenanceWindowNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			window, err := windowRepository.Find(ctx, id)
+
+			return window != nil, err
+		},
+	)
+	registerMaintenanceRoutes(commonServices, httpServices)
+
+	return maintenanceServices
+}
+
+func registerMaintenanceBusesHandlers(commonServices *CommonServices, maintenanceServices *MaintenanceServices) {
+	registerCommandOrPanic(commonServices.CommandBus, &maintenance_application.CreateMaintenanceWindowCommand{}, maintenanceServices.CreateMaintenanceWindowCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &maintenance_application.EndMaintenanceWindowCommand{}, maintenanceServices.EndMaintenanceWindowCommandHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &maintenance_application.FindMaintenanceWindowQuery{}, maintenanceServices.FindMaintenanceWindowQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &maintenance_application.SearchMaintenanceWindowsQuery{}, maintenanceServices.SearchMaintenanceWindowsQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &maintenance_application.SearchSuppressedEventsQuery{}, maintenanceServices.SearchSuppressedEventsQueryHandler)
+}
+
+// registerMaintenanceEventSubscribers records every event the notification channels
+// could suppress, which are the ones customers can receive through webhooks.
+func registerMaintenanceEventSubscribers(commonServices *CommonServices, maintenanceServices *MaintenanceServices) {
+	for _, eventType := range notifications_application.WebhookEventTypes {
+		commonServices.EventBus.Subscribe(eventType, maintenanceServices.SuppressedEventRecorder)
+	}
+}
+
+func registerMaintenanceRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	createMaintenanceWindowJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "maintenance", createMaintenanceWindowJsonSchemaFileName),
+	)
+
+	httpServices.Router.Get(
+		"/maintenance-windows",
+		maintenance_http.NewGetMaintenanceWindowsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/maintenance-windows",
+		maintenance_http.NewCreateMaintenanceWindowController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(createMaintenanceWindowJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/maintenance-windows/{windowId}",
+		maintenance_http.NewGetMaintenanceWindowController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/maintenance-windows/{windowId}/end",
+		maintenance_http.NewEndMaintenanceWindowController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/maintenance-windows/{windowId}/suppressed-events",
+		maintenance_http.NewGetSuppressedEventsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+}
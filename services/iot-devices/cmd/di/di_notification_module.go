@@ -0,0 +1,274 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+	notifications_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/infra"
+	notifications_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/infra/http"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_retry "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/retry"
+)
+
+const (
+	createWebhookSubscriptionJsonSchemaFileName  = "create-webhook-subscription.schema.json"
+	putNotificationPreferencesJsonSchemaFileName = "put-notification-preferences.schema.json"
+)
+
+type NotificationServices struct {
+	WebhookDispatcher                        *notifications_application.WebhookDispatcher
+	WebhookDeliveryWorker                    *notifications_application.WebhookDeliveryWorker
+	CreateWebhookSubscriptionCommandHandler  *notifications_application.CreateWebhookSubscriptionCommandHandler
+	DeleteWebhookSubscriptionCommandHandler  *notifications_application.DeleteWebhookSubscriptionCommandHandler
+	RedeliverWebhookCommandHandler           *notifications_application.RedeliverWebhookCommandHandler
+	FindWebhookSubscriptionQueryHandler      *notifications_application.FindWebhookSubscriptionQueryHandler
+	SearchWebhookSubscriptionsQueryHandler   *notifications_application.SearchWebhookSubscriptionsQueryHandler
+	SearchWebhookDeliveriesQueryHandler      *notifications_application.SearchWebhookDeliveriesQueryHandler
+	FindWebhookDeliveryQueryHandler          *notifications_application.FindWebhookDeliveryQueryHandler
+	AlertNotifier                            *notifications_application.AlertNotifier
+	PutNotificationPreferencesCommandHandler *notifications_application.PutNotificationPreferencesCommandHandler
+	FindNotificationPreferencesQueryHandler  *notifications_application.FindNotificationPreferencesQueryHandler
+}
+
+func InitNotificationServices(
+	commonServices *CommonServices,
+	httpServices *HttpServices,
+	maintenanceServices *MaintenanceServices,
+) *NotificationServices {
+	subscriptionRepository := notifications_infra.NewPostgresWebhookSubscriptionRepository(commonServices.DatabaseConnectionPool)
+	deliveryRepository := notifications_infra.NewPostgresWebhookDeliveryRepository(commonServices.DatabaseConnectionPool)
+	preferencesRepository := notifications_infra.NewPostgresUserNotificationPreferencesRepository(commonServices.DatabaseConnectionPool)
+
+	renderer, err := notifications_infra.NewTemplateNotificationRenderer()
+	if err != nil {
+		panic(err)
+	}
+
+	dispatcher := notifications_application.NewWebhookDispatcher(
+		subscriptionRepository,
+		deliveryRepository,
+		notifications_infra.NewHttpWebhookSender(time.Duration(commonServices.Config.WebhookTimeout)*time.Second),
+		amf_retry.RetryConfig{
+			MaxRetries:          commonServices.Config.WebhookMaxRetries,
+			InitialInterval:     time.Duration(commonServices.Config.WebhookRetryInitialInterval) * time.Millisecond,
+			MaxInterval:         30 * time.Second,
+			Multiplier:          2,
+			RandomizationFactor: 0.2,
+			Logger:              commonServices.Logger,
+		},
+		commonServices.UlidProvider,
+		commonServices.TimeProvider,
+	)
+
+	notificationServices := &NotificationServices{
+		WebhookDispatcher: dispatcher,
+		WebhookDeliveryWorker: notifications_application.NewWebhookDeliveryWorker(
+			deliveryRepository,
+			dispatcher,
+			commonServices.DistributedMutex,
+			commonServices.TimeProvider,
+			commonServices.Config.WebhookDeliveryBatchSize,
+			time.Duration(commonServices.Config.WebhookDeliveryStaleAfter)*time.Second,
+		),
+		CreateWebhookSubscriptionCommandHandler: notifications_application.NewCreateWebhookSubscriptionCommandHandler(
+			subscriptionRepository,
+			commonServices.TimeProvider,
+		),
+		DeleteWebhookSubscriptionCommandHandler: notifications_application.NewDeleteWebhookSubscriptionCommandHandler(subscriptionRepository),
+		RedeliverWebhookCommandHandler: notifications_application.NewRedeliverWebhookCommandHandler(
+			deliveryRepository,
+			dispatcher,
+			commonServices.DistributedMutex,
+			commonServices.TimeProvider,
+			time.Duration(commonServices.Config.WebhookDeliveryStaleAfter)*time.Second,
+		),
+		FindWebhookSubscriptionQueryHandler:    notifications_application.NewFindWebhookSubscriptionQueryHandler(subscriptionRepository),
+		SearchWebhookSubscriptionsQueryHandler: notifications_application.NewSearchWebhookSubscriptionsQueryHandler(subscriptionRepository),
+		SearchWebhookDeliveriesQueryHandler: notifications_application.NewSearchWebhookDeliveriesQueryHandler(
+			subscriptionRepository,
+			deliveryRepository,
+		),
+		FindWebhookDeliveryQueryHandler: notifications_application.NewFindWebhookDeliveryQueryHandler(deliveryRepository),
+		AlertNotifier: notifications_application.NewAlertNotifier(
+			preferencesRepository,
+			renderer,
+			maintenanceServices.MaintenanceSuppressor,
+			commonServices.TimeProvider,
+			notificationChannels(commonServices)...,
+		),
+		PutNotificationPreferencesCommandHandler: notifications_application.NewPutNotificationPreferencesCommandHandler(preferencesRepository),
+		FindNotificationPreferencesQueryHandler:  notifications_application.NewFindNotificationPreferencesQueryHandler(preferencesRepository),
+	}
+
+	registerNotificationBusesHandlers(commonServices, notificationServices)
+	registerNotificationEventSubscribers(commonServices, notificationServices, maintenanceServices, subscriptionRepository, deliveryRepository)
+	httpServices.TenantResourceGuard.Guard(
+		"subscriptionId",
+		func(id string) error { return notifications_domain.NewWebhookSubscriptionNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			subscription, err := subscriptionRepository.Find(ctx, id)
+
+			return subscription != nil, err
+		},
+	)
+	httpServices.TenantResourceGuard.Guard(
+		"deliveryId",
+		func(id string) error { return notifications_domain.NewWebhookDeliveryNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			delivery, err := deliveryRepository.Find(ctx, id)
+
+			return delivery != nil, err
+		},
+	)
+	registerNotificationRoutes(commonServices, httpServices)
+
+	return notificationServices
+}
+
+// notificationChannels are the channels with a configured provider, users choosing
+// an unconfigured one are not notified through it.
+func notificationChannels(commonServices *CommonServices) []notifications_domain.NotificationChannel {
+	config := commonServices.Config
+	timeout := time.Duration(config.NotificationTimeout) * time.Second
+
+	channels := make([]notifications_domain.NotificationChannel, 0)
+	if config.NotificationSmtpHost != "" {
+		channels = append(channels, notifications_infra.NewSmtpEmailChannel(
+			config.NotificationSmtpHost,
+			config.NotificationSmtpPort,
+			config.NotificationSmtpUsername,
+			config.NotificationSmtpPassword,
+			config.NotificationEmailFrom,
+			timeout,
+		))
+	}
+	if config.NotificationSmsProviderUrl != "" {
+		channels = append(channels, notifications_infra.NewSmsChannel(notifications_infra.NewHttpSmsProvider(
+			config.NotificationSmsProviderUrl,
+			config.NotificationSmsProviderToken,
+			config.NotificationSmsFrom,
+			timeout,
+		)))
+	}
+
+	return channels
+}
+
+func registerNotificationBusesHandlers(commonServices *CommonServices, notificationServices *NotificationServices) {
+	registerCommandOrPanic(commonServices.CommandBus, &notifications_application.CreateWebhookSubscriptionCommand{}, notificationServices.CreateWebhookSubscriptionCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &notifications_application.DeleteWebhookSubscriptionCommand{}, notificationServices.DeleteWebhookSubscriptionCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &notifications_application.RedeliverWebhookCommand{}, notificationServices.RedeliverWebhookCommandHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &notifications_application.FindWebhookSubscriptionQuery{}, notificationServices.FindWebhookSubscriptionQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &notifications_application.SearchWebhookSubscriptionsQuery{}, notificationServices.SearchWebhookSubscriptionsQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &notifications_application.SearchWebhookDeliveriesQuery{}, notificationServices.SearchWebhookDeliveriesQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &notifications_application.FindWebhookDeliveryQuery{}, notificationServices.FindWebhookDeliveryQueryHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &notifications_application.PutNotificationPreferencesCommand{}, notificationServices.PutNotificationPreferencesCommandHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &notifications_application.FindNotificationPreferencesQuery{}, notificationServices.FindNotificationPreferencesQueryHandler)
+}
+
+func registerNotificationEventSubscribers(
+	commonServices *CommonServices,
+	notificationServices *NotificationServices,
+	maintenanceServices *MaintenanceServices,
+	subscriptionRepository *notifications_infra.PostgresWebhookSubscriptionRepository,
+	deliveryRepository *notifications_infra.PostgresWebhookDeliveryRepository,
+) {
+	webhookEventHandler := notifications_application.NewWebhookEventHandler(
+		subscriptionRepository,
+		deliveryRepository,
+		maintenanceServices.MaintenanceSuppressor,
+		commonServices.UlidProvider,
+		commonServices.TimeProvider,
+	)
+
+	for _, eventType := range notifications_application.WebhookEventTypes {
+		commonServices.EventBus.Subscribe(eventType, webhookEventHandler)
+	}
+
+	for _, eventType := range notifications_application.AlertNotificationEventTypes {
+		commonServices.EventBus.Subscribe(eventType, notificationServices.AlertNotifier)
+	}
+}
+
+func registerNotificationRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	createWebhookSubscriptionJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "notifications", createWebhookSubscriptionJsonSchemaFileName),
+	)
+
+	putNotificationPreferencesJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "notifications", putNotificationPreferencesJsonSchemaFileName),
+	)
+
+	httpServices.Router.Get(
+		"/webhook-subscriptions",
+		notifications_http.NewGetWebhookSubscriptionsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/webhook-subscriptions",
+		notifications_http.NewCreateWebhookSubscriptionController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(createWebhookSubscriptionJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/webhook-subscriptions/{subscriptionId}",
+		notifications_http.NewGetWebhookSubscriptionController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Delete(
+		"/webhook-subscriptions/{subscriptionId}",
+		notifications_http.NewDeleteWebhookSubscriptionController(commonServices.CommandBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/webhook-subscriptions/{subscriptionId}/deliveries",
+		notifications_http.NewGetWebhookDeliveriesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/webhook-deliveries/{deliveryId}",
+		notifications_http.NewGetWebhookDeliveryController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/webhook-deliveries/{deliveryId}/redeliver",
+		notifications_http.NewRedeliverWebhookController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/users/{userId}/notification-preferences",
+		notifications_http.NewGetNotificationPreferencesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Put(
+		"/users/{userId}/notification-preferences",
+		notifications_http.NewPutNotificationPreferencesController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(putNotificationPreferencesJsonSchemaValidator.Middleware)...,
+	)
+}
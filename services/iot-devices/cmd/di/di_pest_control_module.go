@@ -0,0 +1,213 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pestcontrol_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/application"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	pestcontrol_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/infra"
+	pestcontrol_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/infra/http"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const (
+	requestGlueBoardImageUploadJsonSchemaFileName  = "request-glue-board-image-upload.schema.json"
+	notifyGlueBoardImageUploadedJsonSchemaFileName = "notify-glue-board-image-uploaded.schema.json"
+	recordServiceVisitJsonSchemaFileName           = "record-service-visit.schema.json"
+)
+
+type PestControlServices struct {
+	RequestGlueBoardImageUploadCommandHandler *pestcontrol_application.RequestGlueBoardImageUploadCommandHandler
+	ProcessGlueBoardImageCommandHandler       *pestcontrol_application.ProcessGlueBoardImageCommandHandler
+	FindGlueBoardImageUploadQueryHandler      *pestcontrol_application.FindGlueBoardImageUploadQueryHandler
+	FindGlueBoardImageQueryHandler            *pestcontrol_application.FindGlueBoardImageQueryHandler
+	SearchDeviceGlueBoardImagesQueryHandler   *pestcontrol_application.SearchDeviceGlueBoardImagesQueryHandler
+	RecordServiceVisitCommandHandler          *pestcontrol_application.RecordServiceVisitCommandHandler
+	FindServiceVisitQueryHandler              *pestcontrol_application.FindServiceVisitQueryHandler
+	SearchSiteServiceVisitsQueryHandler       *pestcontrol_application.SearchSiteServiceVisitsQueryHandler
+}
+
+func InitPestControlServices(commonServices *CommonServices, httpServices *HttpServices) *PestControlServices {
+	imageRepository := pestcontrol_infra.NewPostgresGlueBoardImageRepository(commonServices.DatabaseConnectionPool)
+	imageStorage := pestcontrol_infra.NewObjectStorageGlueBoardImageStorage(commonServices.ObjectStorage)
+	imageProcessor := pestcontrol_infra.NewStdlibGlueBoardImageProcessor(
+		commonServices.Config.GlueBoardImageMaxSize,
+		commonServices.Config.GlueBoardImageMaxPixels,
+		commonServices.Config.GlueBoardImageThumbnailSize,
+	)
+	downloadUrlTtl := time.Duration(commonServices.Config.GlueBoardImageDownloadUrlTtl) * time.Second
+	serviceVisitRepository := pestcontrol_infra.NewPostgresServiceVisitRepository(commonServices.DatabaseConnectionPool)
+
+	pestControlServices := &PestControlServices{
+		RequestGlueBoardImageUploadCommandHandler: pestcontrol_application.NewRequestGlueBoardImageUploadCommandHandler(
+			imageRepository,
+			commonServices.TimeProvider,
+		),
+		ProcessGlueBoardImageCommandHandler: pestcontrol_application.NewProcessGlueBoardImageCommandHandler(
+			imageRepository,
+			imageStorage,
+			imageProcessor,
+			commonServices.EventBus,
+			commonServices.TimeProvider,
+		),
+		FindGlueBoardImageUploadQueryHandler: pestcontrol_application.NewFindGlueBoardImageUploadQueryHandler(
+			imageRepository,
+			imageStorage,
+			time.Duration(commonServices.Config.GlueBoardImageUploadUrlTtl)*time.Second,
+			commonServices.TimeProvider,
+		),
+		FindGlueBoardImageQueryHandler: pestcontrol_application.NewFindGlueBoardImageQueryHandler(
+			imageRepository,
+			imageStorage,
+			downloadUrlTtl,
+			commonServices.TimeProvider,
+		),
+		SearchDeviceGlueBoardImagesQueryHandler: pestcontrol_application.NewSearchDeviceGlueBoardImagesQueryHandler(
+			imageRepository,
+			imageStorage,
+			downloadUrlTtl,
+			commonServices.TimeProvider,
+		),
+		RecordServiceVisitCommandHandler: pestcontrol_application.NewRecordServiceVisitCommandHandler(serviceVisitRepository),
+		FindServiceVisitQueryHandler:     pestcontrol_application.NewFindServiceVisitQueryHandler(serviceVisitRepository),
+		SearchSiteServiceVisitsQueryHandler: pestcontrol_application.NewSearchSiteServiceVisitsQueryHandler(
+			serviceVisitRepository,
+			commonServices.TimeProvider,
+		),
+	}
+
+	registerPestControlBusesHandlers(commonServices, pestControlServices)
+	httpServices.TenantResourceGuard.Guard(
+		"imageId",
+		func(id string) error { return pestcontrol_domain.NewGlueBoardImageNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			image, err := imageRepository.Find(ctx, id)
+
+			return image != nil, err
+		},
+	)
+	registerPestControlRoutes(commonServices, httpServices)
+
+	return pestControlServices
+}
+
+func registerPestControlBusesHandlers(commonServices *CommonServices, pestControlServices *PestControlServices) {
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&pestcontrol_application.RequestGlueBoardImageUploadCommand{},
+		pestControlServices.RequestGlueBoardImageUploadCommandHandler,
+	)
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&pestcontrol_application.ProcessGlueBoardImageCommand{},
+		pestControlServices.ProcessGlueBoardImageCommandHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&pestcontrol_application.FindGlueBoardImageUploadQuery{},
+		pestControlServices.FindGlueBoardImageUploadQueryHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&pestcontrol_application.FindGlueBoardImageQuery{},
+		pestControlServices.FindGlueBoardImageQueryHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&pestcontrol_application.SearchDeviceGlueBoardImagesQuery{},
+		pestControlServices.SearchDeviceGlueBoardImagesQueryHandler,
+	)
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&pestcontrol_application.RecordServiceVisitCommand{},
+		pestControlServices.RecordServiceVisitCommandHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&pestcontrol_application.FindServiceVisitQuery{},
+		pestControlServices.FindServiceVisitQueryHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&pestcontrol_application.SearchSiteServiceVisitsQuery{},
+		pestControlServices.SearchSiteServiceVisitsQueryHandler,
+	)
+}
+
+// registerPestControlRoutes leaves the photos themselves to the api key holders, while
+// devices only get to upload theirs, authenticated by their device key.
+func registerPestControlRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	glueBoardImagesApiKeysMiddleware := amf_http_server.NewApiKeyValidationMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		amf_http_server.WithLogger(commonServices.Logger),
+		amf_http_server.WithKeysByOwner(amf_http_server.StaticApiKeysFromPipedString(commonServices.Config.GlueBoardImagesApiKeys)...),
+	)
+
+	requestGlueBoardImageUploadJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "pest-control", requestGlueBoardImageUploadJsonSchemaFileName),
+	)
+	notifyGlueBoardImageUploadedJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "pest-control", notifyGlueBoardImageUploadedJsonSchemaFileName),
+	)
+	recordServiceVisitJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "pest-control", recordServiceVisitJsonSchemaFileName),
+	)
+
+	httpServices.Router.Post(
+		"/devices/{deviceId}/glue-board-images",
+		pestcontrol_http.NewRequestGlueBoardImageUploadController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.DeviceScoped(requestGlueBoardImageUploadJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/glue-board-images/{imageId}/upload",
+		pestcontrol_http.NewGetGlueBoardImageUploadController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.DeviceScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/devices/{deviceId}/glue-board-images/{imageId}/uploaded",
+		pestcontrol_http.NewNotifyGlueBoardImageUploadedController(commonServices.CommandBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.DeviceScoped(notifyGlueBoardImageUploadedJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/glue-board-images",
+		pestcontrol_http.NewGetDeviceGlueBoardImagesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped(glueBoardImagesApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/glue-board-images/{imageId}",
+		pestcontrol_http.NewGetGlueBoardImageController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped(glueBoardImagesApiKeysMiddleware.Middleware)...,
+	)
+
+	httpServices.Router.Post(
+		"/sites/{siteId}/service-visits",
+		pestcontrol_http.NewRecordServiceVisitController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(recordServiceVisitJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/sites/{siteId}/service-visits",
+		pestcontrol_http.NewGetSiteServiceVisitsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+}
@@ -0,0 +1,19 @@
+package di
+
+import "context"
+
+type ReplayCliDi struct {
+	CommonServices    *CommonServices
+	TelemetryServices *TelemetryServices
+}
+
+func InitReplayCliDi(ctx context.Context) *ReplayCliDi {
+	commonServices := InitCommonServices(ctx)
+	httpServices := InitHttpServices(commonServices)
+	telemetryServices := InitTelemetryServices(commonServices, httpServices)
+
+	return &ReplayCliDi{
+		CommonServices:    commonServices,
+		TelemetryServices: telemetryServices,
+	}
+}
@@ -0,0 +1,142 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	connectivity_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/infra"
+	pestcontrol_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/infra"
+	reports_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/application"
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+	reports_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/infra"
+	reports_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/infra/http"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const generateSiteReportJsonSchemaFileName = "generate-site-report.schema.json"
+
+type ReportsServices struct {
+	GenerateSiteReportCommandHandler *reports_application.GenerateSiteReportCommandHandler
+	FindSiteReportQueryHandler       *reports_application.FindSiteReportQueryHandler
+	SearchSiteReportsQueryHandler    *reports_application.SearchSiteReportsQueryHandler
+	MonthlySiteReportScheduler       *reports_application.MonthlySiteReportScheduler
+}
+
+func InitReportsServices(commonServices *CommonServices, httpServices *HttpServices) *ReportsServices {
+	location, err := time.LoadLocation(commonServices.Config.ReportTimezone)
+	if err != nil {
+		panic(err)
+	}
+
+	htmlRenderer, err := reports_infra.NewHtmlSiteReportRenderer()
+	if err != nil {
+		panic(err)
+	}
+
+	source := reports_infra.NewPostgresSiteReportSource(commonServices.DatabaseConnectionPool)
+	reportRepository := reports_infra.NewPostgresSiteReportRepository(commonServices.DatabaseConnectionPool)
+	reportStorage := reports_infra.NewObjectStorageSiteReportStorage(commonServices.ObjectStorage)
+	downloadUrlTtl := time.Duration(commonServices.Config.ReportDownloadUrlTtl) * time.Second
+	generator := reports_application.NewSiteReportGenerator(
+		source,
+		connectivity_infra.NewRedisLastSeenRepository(commonServices.RedisClient),
+		pestcontrol_infra.NewPostgresServiceVisitRepository(commonServices.DatabaseConnectionPool),
+		reportRepository,
+		[]reports_domain.SiteReportRenderer{htmlRenderer, reports_infra.NewPdfSiteReportRenderer()},
+		reportStorage,
+		commonServices.DistributedMutex,
+		commonServices.TimeProvider,
+		location,
+	)
+
+	reportsServices := &ReportsServices{
+		GenerateSiteReportCommandHandler: reports_application.NewGenerateSiteReportCommandHandler(
+			generator,
+			commonServices.TimeProvider,
+			location,
+		),
+		FindSiteReportQueryHandler: reports_application.NewFindSiteReportQueryHandler(
+			reportRepository,
+			reportStorage,
+			downloadUrlTtl,
+			commonServices.TimeProvider,
+		),
+		SearchSiteReportsQueryHandler: reports_application.NewSearchSiteReportsQueryHandler(
+			reportRepository,
+			reportStorage,
+			downloadUrlTtl,
+			commonServices.TimeProvider,
+		),
+		MonthlySiteReportScheduler: reports_application.NewMonthlySiteReportScheduler(
+			source,
+			generator,
+			commonServices.UlidProvider,
+			commonServices.TimeProvider,
+			location,
+		),
+	}
+
+	registerReportsBusesHandlers(commonServices, reportsServices)
+	httpServices.TenantResourceGuard.Guard(
+		"reportId",
+		func(id string) error { return reports_domain.NewSiteReportNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			report, err := reportRepository.Find(ctx, id)
+
+			return report != nil, err
+		},
+	)
+	registerReportsRoutes(commonServices, httpServices)
+
+	return reportsServices
+}
+
+func registerReportsBusesHandlers(commonServices *CommonServices, reportsServices *ReportsServices) {
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&reports_application.GenerateSiteReportCommand{},
+		reportsServices.GenerateSiteReportCommandHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&reports_application.FindSiteReportQuery{},
+		reportsServices.FindSiteReportQueryHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&reports_application.SearchSiteReportsQuery{},
+		reportsServices.SearchSiteReportsQueryHandler,
+	)
+}
+
+func registerReportsRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	generateSiteReportJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "reports", generateSiteReportJsonSchemaFileName),
+	)
+
+	httpServices.Router.Post(
+		"/sites/{siteId}/reports",
+		reports_http.NewGenerateSiteReportController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(generateSiteReportJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/sites/{siteId}/reports",
+		reports_http.NewGetSiteReportsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/reports/{reportId}",
+		reports_http.NewGetSiteReportController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+}
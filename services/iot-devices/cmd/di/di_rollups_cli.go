@@ -0,0 +1,19 @@
+package di
+
+import "context"
+
+type RollupsCliDi struct {
+	CommonServices    *CommonServices
+	TelemetryServices *TelemetryServices
+}
+
+func InitRollupsCliDi(ctx context.Context) *RollupsCliDi {
+	commonServices := InitCommonServices(ctx)
+	httpServices := InitHttpServices(commonServices)
+	telemetryServices := InitTelemetryServices(commonServices, httpServices)
+
+	return &RollupsCliDi{
+		CommonServices:    commonServices,
+		TelemetryServices: telemetryServices,
+	}
+}
@@ -0,0 +1,70 @@
+package di
+
+import (
+	"time"
+
+	connectivity_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/infra"
+	streaming_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/application"
+	streaming_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/infra"
+	streaming_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/infra/http"
+)
+
+type StreamingServices struct {
+	StreamEventRecorder *streaming_application.StreamEventRecorder
+	StreamHub           *streaming_application.StreamHub
+}
+
+func InitStreamingServices(commonServices *CommonServices, httpServices *HttpServices) *StreamingServices {
+	directory := connectivity_infra.NewPostgresDeviceDirectory(commonServices.DatabaseConnectionPool)
+	streamEventLog := streaming_infra.NewRedisStreamEventLog(commonServices.RedisClient, commonServices.Config.StreamEventsRetention)
+	streamEventBroadcaster := streaming_infra.NewRedisStreamEventBroadcaster(commonServices.RedisClient)
+
+	streamingServices := &StreamingServices{
+		StreamEventRecorder: streaming_application.NewStreamEventRecorder(
+			directory,
+			streamEventLog,
+			streamEventBroadcaster,
+			commonServices.TimeProvider,
+		),
+		StreamHub: streaming_application.NewStreamHub(
+			streamEventLog,
+			streamEventBroadcaster,
+			commonServices.Config.StreamClientBuffer,
+			commonServices.Config.StreamReplayLimit,
+		),
+	}
+
+	registerStreamingEventSubscribers(commonServices, streamingServices)
+	registerStreamingRoutes(commonServices, httpServices, streamingServices)
+
+	return streamingServices
+}
+
+func registerStreamingEventSubscribers(commonServices *CommonServices, streamingServices *StreamingServices) {
+	for _, eventName := range streaming_application.StreamEventTypes {
+		commonServices.EventBus.Subscribe(eventName, streamingServices.StreamEventRecorder)
+	}
+}
+
+func registerStreamingRoutes(commonServices *CommonServices, httpServices *HttpServices, streamingServices *StreamingServices) {
+	heartbeatInterval := time.Duration(commonServices.Config.StreamHeartbeatInterval) * time.Second
+
+	httpServices.Router.Get(
+		"/stream/events",
+		streaming_http.NewStreamEventsController(
+			streamingServices.StreamHub,
+			heartbeatInterval,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped()...,
+	)
+	httpServices.Router.Get(
+		"/stream/events/ws",
+		streaming_http.NewStreamEventsWebSocketController(
+			streamingServices.StreamHub,
+			heartbeatInterval,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped()...,
+	)
+}
@@ -43,5 +43,6 @@ func registerSystemRoutes(commonServices *CommonServices, httpServices *HttpServ
 			commonServices.QueryBus,
 			httpServices.JsonApiResponseMiddleware,
 		),
+		httpServices.SystemScoped()...,
 	)
 }
@@ -0,0 +1,268 @@
+package di
+
+import (
+	"fmt"
+	"time"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/infra"
+	telemetry_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/infra/http"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const (
+	ingestReadingJsonSchemaFileName = "ingest-reading.schema.json"
+	ingestBacklogJsonSchemaFileName = "ingest-backlog.schema.json"
+)
+
+type TelemetryServices struct {
+	IngestReadingCommandHandler            *telemetry_application.IngestReadingCommandHandler
+	ArchiveUplinkFrameCommandHandler       *telemetry_application.ArchiveUplinkFrameCommandHandler
+	UplinkReplayer                         *telemetry_application.UplinkReplayer
+	UplinkDeduplicator                     *telemetry_application.UplinkDeduplicator
+	FindUplinkDuplicatesQueryHandler       *telemetry_application.FindUplinkDuplicatesQueryHandler
+	IngestBacklogCommandHandler            *telemetry_application.IngestBacklogCommandHandler
+	FindUplinkBacklogQueryHandler          *telemetry_application.FindUplinkBacklogQueryHandler
+	SyncDeviceClockCommandHandler          *telemetry_application.SyncDeviceClockCommandHandler
+	FindDeviceClockQueryHandler            *telemetry_application.FindDeviceClockQueryHandler
+	SearchDriftingDeviceClocksQueryHandler *telemetry_application.SearchDriftingDeviceClocksQueryHandler
+	RebuildTelemetryRollupsCommandHandler  *telemetry_application.RebuildTelemetryRollupsCommandHandler
+	SearchTelemetrySeriesQueryHandler      *telemetry_application.SearchTelemetrySeriesQueryHandler
+	SearchReadingsQueryHandler             *telemetry_application.SearchReadingsQueryHandler
+}
+
+func InitTelemetryServices(commonServices *CommonServices, httpServices *HttpServices) *TelemetryServices {
+	readingRepository := telemetry_infra.NewPostgresReadingRepository(commonServices.DatabaseConnectionPool)
+	uplinkArchive := telemetry_infra.NewObjectStorageUplinkArchive(commonServices.ObjectStorage)
+	uplinkDecoder := telemetry_infra.NewJsonApiUplinkDecoder()
+	uplinkSequenceRegistry := telemetry_infra.NewRedisUplinkSequenceRegistry(
+		commonServices.RedisClient,
+		time.Duration(commonServices.Config.UplinkDedupeTtl)*time.Second,
+	)
+	deviceClockRepository := telemetry_infra.NewPostgresDeviceClockRepository(commonServices.DatabaseConnectionPool)
+	uplinkBacklogRepository := telemetry_infra.NewRedisUplinkBacklogRepository(commonServices.RedisClient)
+	rollupRepository := telemetry_infra.NewPostgresRollupRepository(commonServices.DatabaseConnectionPool)
+	if err := telemetry_infra.RegisterUplinkBacklogGauges(commonServices.Observability.Meter, uplinkBacklogRepository); err != nil {
+		panic(err)
+	}
+
+	telemetryServices := &TelemetryServices{
+		IngestReadingCommandHandler: telemetry_application.NewIngestReadingCommandHandler(
+			readingRepository,
+			deviceClockRepository,
+			commonServices.EventBus,
+			time.Duration(commonServices.Config.TelemetryBackfillAfter)*time.Second,
+			commonServices.TimeProvider,
+		),
+		ArchiveUplinkFrameCommandHandler: telemetry_application.NewArchiveUplinkFrameCommandHandler(uplinkArchive),
+		UplinkReplayer:                   telemetry_application.NewUplinkReplayer(uplinkArchive, uplinkDecoder, readingRepository, rollupRepository),
+		UplinkDeduplicator:               telemetry_application.NewUplinkDeduplicator(uplinkSequenceRegistry, commonServices.TimeProvider),
+		FindUplinkDuplicatesQueryHandler: telemetry_application.NewFindUplinkDuplicatesQueryHandler(uplinkSequenceRegistry),
+		IngestBacklogCommandHandler: telemetry_application.NewIngestBacklogCommandHandler(
+			readingRepository,
+			deviceClockRepository,
+			uplinkBacklogRepository,
+			commonServices.EventBus,
+			commonServices.TimeProvider,
+		),
+		FindUplinkBacklogQueryHandler: telemetry_application.NewFindUplinkBacklogQueryHandler(uplinkBacklogRepository),
+		SyncDeviceClockCommandHandler: telemetry_application.NewSyncDeviceClockCommandHandler(
+			deviceClockRepository,
+			commonServices.EventBus,
+			time.Duration(commonServices.Config.ClockDriftThreshold)*time.Second,
+			time.Duration(commonServices.Config.ClockSyncMaxRoundTrip)*time.Second,
+			commonServices.TimeProvider,
+		),
+		FindDeviceClockQueryHandler:            telemetry_application.NewFindDeviceClockQueryHandler(deviceClockRepository),
+		SearchDriftingDeviceClocksQueryHandler: telemetry_application.NewSearchDriftingDeviceClocksQueryHandler(deviceClockRepository),
+		RebuildTelemetryRollupsCommandHandler:  telemetry_application.NewRebuildTelemetryRollupsCommandHandler(rollupRepository),
+		SearchTelemetrySeriesQueryHandler: telemetry_application.NewSearchTelemetrySeriesQueryHandler(
+			rollupRepository,
+			commonServices.TimeProvider,
+		),
+		SearchReadingsQueryHandler: telemetry_application.NewSearchReadingsQueryHandler(readingRepository),
+	}
+
+	registerTelemetryBusesHandlers(commonServices, telemetryServices)
+	registerTelemetryEventSubscribers(commonServices, rollupRepository)
+	registerTelemetryRoutes(commonServices, httpServices, telemetryServices, uplinkDecoder)
+
+	return telemetryServices
+}
+
+func registerTelemetryBusesHandlers(commonServices *CommonServices, telemetryServices *TelemetryServices) {
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&telemetry_application.IngestReadingCommand{},
+		telemetryServices.IngestReadingCommandHandler,
+	)
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&telemetry_application.ArchiveUplinkFrameCommand{},
+		telemetryServices.ArchiveUplinkFrameCommandHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&telemetry_application.FindUplinkDuplicatesQuery{},
+		telemetryServices.FindUplinkDuplicatesQueryHandler,
+	)
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&telemetry_application.IngestBacklogCommand{},
+		telemetryServices.IngestBacklogCommandHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&telemetry_application.FindUplinkBacklogQuery{},
+		telemetryServices.FindUplinkBacklogQueryHandler,
+	)
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&telemetry_application.SyncDeviceClockCommand{},
+		telemetryServices.SyncDeviceClockCommandHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&telemetry_application.FindDeviceClockQuery{},
+		telemetryServices.FindDeviceClockQueryHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&telemetry_application.SearchDriftingDeviceClocksQuery{},
+		telemetryServices.SearchDriftingDeviceClocksQueryHandler,
+	)
+	registerCommandOrPanic(
+		commonServices.CommandBus,
+		&telemetry_application.RebuildTelemetryRollupsCommand{},
+		telemetryServices.RebuildTelemetryRollupsCommandHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&telemetry_application.SearchTelemetrySeriesQuery{},
+		telemetryServices.SearchTelemetrySeriesQueryHandler,
+	)
+	registerQueryOrPanic(
+		commonServices.QueryBus,
+		&telemetry_application.SearchReadingsQuery{},
+		telemetryServices.SearchReadingsQueryHandler,
+	)
+}
+
+func registerTelemetryEventSubscribers(commonServices *CommonServices, rollupRepository telemetry_domain.RollupRepository) {
+	commonServices.EventBus.Subscribe(
+		telemetry_domain.ReadingIngestedEventName,
+		telemetry_application.NewReadingIngestedEventHandler(rollupRepository),
+	)
+	commonServices.EventBus.Subscribe(
+		telemetry_domain.ReadingsBackfilledEventName,
+		telemetry_application.NewReadingsBackfilledEventHandler(rollupRepository),
+	)
+}
+
+func registerTelemetryRoutes(
+	commonServices *CommonServices,
+	httpServices *HttpServices,
+	telemetryServices *TelemetryServices,
+	uplinkDecoder telemetry_domain.UplinkDecoder,
+) {
+	uplinkDeduplicationMiddleware := telemetry_http.NewUplinkDeduplicationMiddleware(
+		telemetryServices.UplinkDeduplicator,
+		httpServices.JsonApiResponseMiddleware,
+	)
+	uplinkArchiveMiddleware := telemetry_http.NewUplinkArchiveMiddleware(
+		commonServices.CommandBus,
+		commonServices.UlidProvider,
+		commonServices.TimeProvider,
+		httpServices.JsonApiResponseMiddleware,
+	)
+
+	ingestReadingJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "telemetry", ingestReadingJsonSchemaFileName),
+	)
+	ingestBacklogJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "telemetry", ingestBacklogJsonSchemaFileName),
+	)
+
+	httpServices.Router.Post(
+		"/devices/{deviceId}/uplinks",
+		telemetry_http.NewIngestReadingController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			uplinkDecoder,
+			commonServices.TimeProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.DeviceScoped(
+			uplinkDeduplicationMiddleware,
+			uplinkArchiveMiddleware,
+			ingestReadingJsonSchemaValidator.Middleware,
+		)...,
+	)
+
+	httpServices.Router.Post(
+		"/devices/{deviceId}/uplinks/backlog",
+		telemetry_http.NewIngestBacklogController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			uplinkDecoder,
+			commonServices.TimeProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.DeviceScoped(
+			uplinkArchiveMiddleware,
+			ingestBacklogJsonSchemaValidator.Middleware,
+		)...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/uplinks/backlog",
+		telemetry_http.NewGetUplinkBacklogController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/uplinks/duplicates",
+		telemetry_http.NewGetUplinkDuplicatesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/clock",
+		telemetry_http.NewGetDeviceClockController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/telemetry",
+		telemetry_http.NewGetDeviceTelemetryController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/sites/{siteId}/telemetry",
+		telemetry_http.NewGetSiteTelemetryController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}/readings",
+		telemetry_http.NewGetDeviceReadingsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/sites/{siteId}/readings",
+		telemetry_http.NewGetSiteReadingsController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/device-clocks/drifting",
+		telemetry_http.NewSearchDriftingDeviceClocksController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+}
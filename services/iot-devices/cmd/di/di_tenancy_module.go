@@ -0,0 +1,196 @@
+package di
+
+import (
+	"fmt"
+
+	tenancy_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/application"
+	tenancy_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/infra"
+	tenancy_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/infra/http"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+)
+
+const (
+	createCustomerJsonSchemaFileName = "create-customer.schema.json"
+	createSiteJsonSchemaFileName     = "create-site.schema.json"
+	createZoneJsonSchemaFileName     = "create-zone.schema.json"
+	assignDeviceJsonSchemaFileName   = "assign-device.schema.json"
+)
+
+type TenancyServices struct {
+	CreateCustomerCommandHandler     *tenancy_application.CreateCustomerCommandHandler
+	CreateSiteCommandHandler         *tenancy_application.CreateSiteCommandHandler
+	CreateZoneCommandHandler         *tenancy_application.CreateZoneCommandHandler
+	AssignDeviceToZoneCommandHandler *tenancy_application.AssignDeviceToZoneCommandHandler
+	FindCustomerQueryHandler         *tenancy_application.FindCustomerQueryHandler
+	SearchCustomersQueryHandler      *tenancy_application.SearchCustomersQueryHandler
+	FindSiteQueryHandler             *tenancy_application.FindSiteQueryHandler
+	SearchCustomerSitesQueryHandler  *tenancy_application.SearchCustomerSitesQueryHandler
+	FindZoneQueryHandler             *tenancy_application.FindZoneQueryHandler
+	SearchSiteZonesQueryHandler      *tenancy_application.SearchSiteZonesQueryHandler
+	FindDeviceQueryHandler           *tenancy_application.FindDeviceQueryHandler
+	SearchZoneDevicesQueryHandler    *tenancy_application.SearchZoneDevicesQueryHandler
+}
+
+func InitTenancyServices(commonServices *CommonServices, httpServices *HttpServices) *TenancyServices {
+	customerRepository := tenancy_infra.NewPostgresCustomerRepository(commonServices.DatabaseConnectionPool)
+	siteRepository := tenancy_infra.NewPostgresSiteRepository(commonServices.DatabaseConnectionPool)
+	zoneRepository := tenancy_infra.NewPostgresZoneRepository(commonServices.DatabaseConnectionPool)
+	deviceRepository := tenancy_infra.NewPostgresDeviceRepository(commonServices.DatabaseConnectionPool)
+
+	tenancyServices := &TenancyServices{
+		CreateCustomerCommandHandler: tenancy_application.NewCreateCustomerCommandHandler(
+			customerRepository,
+			commonServices.TimeProvider,
+		),
+		CreateSiteCommandHandler: tenancy_application.NewCreateSiteCommandHandler(
+			customerRepository,
+			siteRepository,
+			commonServices.TimeProvider,
+		),
+		CreateZoneCommandHandler: tenancy_application.NewCreateZoneCommandHandler(
+			siteRepository,
+			zoneRepository,
+			commonServices.TimeProvider,
+		),
+		AssignDeviceToZoneCommandHandler: tenancy_application.NewAssignDeviceToZoneCommandHandler(zoneRepository, deviceRepository),
+		FindCustomerQueryHandler:         tenancy_application.NewFindCustomerQueryHandler(customerRepository),
+		SearchCustomersQueryHandler:      tenancy_application.NewSearchCustomersQueryHandler(customerRepository),
+		FindSiteQueryHandler:             tenancy_application.NewFindSiteQueryHandler(siteRepository),
+		SearchCustomerSitesQueryHandler:  tenancy_application.NewSearchCustomerSitesQueryHandler(siteRepository),
+		FindZoneQueryHandler:             tenancy_application.NewFindZoneQueryHandler(zoneRepository),
+		SearchSiteZonesQueryHandler:      tenancy_application.NewSearchSiteZonesQueryHandler(zoneRepository),
+		FindDeviceQueryHandler:           tenancy_application.NewFindDeviceQueryHandler(deviceRepository),
+		SearchZoneDevicesQueryHandler:    tenancy_application.NewSearchZoneDevicesQueryHandler(deviceRepository),
+	}
+
+	registerTenancyBusesHandlers(commonServices, tenancyServices)
+	registerTenancyRoutes(commonServices, httpServices)
+
+	return tenancyServices
+}
+
+func registerTenancyBusesHandlers(commonServices *CommonServices, tenancyServices *TenancyServices) {
+	registerCommandOrPanic(commonServices.CommandBus, &tenancy_application.CreateCustomerCommand{}, tenancyServices.CreateCustomerCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &tenancy_application.CreateSiteCommand{}, tenancyServices.CreateSiteCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &tenancy_application.CreateZoneCommand{}, tenancyServices.CreateZoneCommandHandler)
+	registerCommandOrPanic(commonServices.CommandBus, &tenancy_application.AssignDeviceToZoneCommand{}, tenancyServices.AssignDeviceToZoneCommandHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &tenancy_application.FindCustomerQuery{}, tenancyServices.FindCustomerQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &tenancy_application.SearchCustomersQuery{}, tenancyServices.SearchCustomersQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &tenancy_application.FindSiteQuery{}, tenancyServices.FindSiteQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &tenancy_application.SearchCustomerSitesQuery{}, tenancyServices.SearchCustomerSitesQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &tenancy_application.FindZoneQuery{}, tenancyServices.FindZoneQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &tenancy_application.SearchSiteZonesQuery{}, tenancyServices.SearchSiteZonesQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &tenancy_application.FindDeviceQuery{}, tenancyServices.FindDeviceQueryHandler)
+	registerQueryOrPanic(commonServices.QueryBus, &tenancy_application.SearchZoneDevicesQuery{}, tenancyServices.SearchZoneDevicesQueryHandler)
+}
+
+func registerTenancyRoutes(commonServices *CommonServices, httpServices *HttpServices) {
+	createCustomerJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "tenancy", createCustomerJsonSchemaFileName),
+	)
+	createSiteJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "tenancy", createSiteJsonSchemaFileName),
+	)
+	createZoneJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "tenancy", createZoneJsonSchemaFileName),
+	)
+	assignDeviceJsonSchemaValidator := amf_http_server.NewRequestValidatorMiddleware(
+		httpServices.JsonApiResponseMiddleware,
+		fmt.Sprintf("%s/%s/%s", commonServices.Config.JsonSchemaBasePath, "tenancy", assignDeviceJsonSchemaFileName),
+	)
+
+	httpServices.Router.Get(
+		"/customers",
+		tenancy_http.NewGetCustomersController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/customers",
+		tenancy_http.NewCreateCustomerController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(createCustomerJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/customers/{customerId}",
+		tenancy_http.NewGetCustomerController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/customers/{customerId}/sites",
+		tenancy_http.NewGetCustomerSitesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/customers/{customerId}/sites",
+		tenancy_http.NewCreateSiteController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(createSiteJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/sites/{siteId}",
+		tenancy_http.NewGetSiteController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/sites/{siteId}/zones",
+		tenancy_http.NewGetSiteZonesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/sites/{siteId}/zones",
+		tenancy_http.NewCreateZoneController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			commonServices.UlidProvider,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(createZoneJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/zones/{zoneId}",
+		tenancy_http.NewGetZoneController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Get(
+		"/zones/{zoneId}/devices",
+		tenancy_http.NewGetZoneDevicesController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+
+	httpServices.Router.Post(
+		"/zones/{zoneId}/devices",
+		tenancy_http.NewAssignDeviceToZoneController(
+			commonServices.CommandBus,
+			commonServices.QueryBus,
+			httpServices.JsonApiResponseMiddleware,
+		),
+		httpServices.TenantScoped(assignDeviceJsonSchemaValidator.Middleware)...,
+	)
+
+	httpServices.Router.Get(
+		"/devices/{deviceId}",
+		tenancy_http.NewGetDeviceController(commonServices.QueryBus, httpServices.JsonApiResponseMiddleware),
+		httpServices.TenantScoped()...,
+	)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/jsonapi"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/cmd/di"
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+)
+
+const usage = `usage:
+  dynamic-parameters export [-output file]
+  dynamic-parameters import -input file [-dry-run] [-actor name]`
+
+func main() {
+	if len(os.Args) < 2 {
+		exitWithError(fmt.Errorf("missing command\n%s", usage))
+	}
+
+	ctx, cancel := di.RootContext()
+	defer cancel()
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(ctx, os.Args[2:])
+	case "import":
+		err = runImport(ctx, os.Args[2:])
+	default:
+		err = fmt.Errorf("unknown command %q\n%s", os.Args[1], usage)
+	}
+
+	if err != nil {
+		exitWithError(err)
+	}
+}
+
+func runExport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	output := flags.String("output", "", "file to write the overrides to, stdout when empty")
+	_ = flags.Parse(args)
+
+	cliDi := di.InitDynamicParametersCliDi(ctx)
+	response, err := cliDi.CommonServices.QueryBus.Ask(ctx, &dynamic_parameter.ExportDynamicParameterOverridesQuery{})
+	if err != nil {
+		return err
+	}
+
+	var document bytes.Buffer
+	if err := jsonapi.MarshalPayload(&document, response); err != nil {
+		return err
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, document.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteString("\n")
+
+	if *output == "" {
+		_, err = io.Copy(os.Stdout, &indented)
+		return err
+	}
+
+	return os.WriteFile(*output, indented.Bytes(), 0o644)
+}
+
+func runImport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	input := flags.String("input", "", "file with the exported overrides")
+	dryRun := flags.Bool("dry-run", false, "only show the changes the import would make")
+	actor := flags.String("actor", os.Getenv("USER"), "name recorded in the audit log")
+	_ = flags.Parse(args)
+
+	if *input == "" {
+		return fmt.Errorf("missing -input\n%s", usage)
+	}
+
+	raw, err := os.ReadFile(*input)
+	if err != nil {
+		return err
+	}
+
+	overrides, err := dynamic_parameter.ParseDynamicParameterOverridesDocument(raw)
+	if err != nil {
+		return err
+	}
+
+	cliDi := di.InitDynamicParametersCliDi(ctx)
+	plan, err := cliDi.CommonServices.QueryBus.Ask(ctx, &dynamic_parameter.PlanDynamicParameterOverridesImportQuery{Overrides: overrides})
+	if err != nil {
+		return err
+	}
+
+	changes := plan.(*dynamic_parameter.DynamicParameterOverridesImportResponse).Changes
+	printChanges(changes)
+
+	if *dryRun || len(changes) == 0 {
+		return nil
+	}
+
+	if err := cliDi.CommonServices.CommandBus.Dispatch(ctx, &dynamic_parameter.ImportDynamicParameterOverridesCommand{
+		Overrides:  overrides,
+		ImportedBy: *actor,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d changes applied\n", len(changes))
+
+	return nil
+}
+
+func printChanges(changes []dynamic_parameter.DynamicParameterOverrideChangeResponse) {
+	if len(changes) == 0 {
+		fmt.Println("no changes")
+		return
+	}
+
+	for _, change := range changes {
+		switch dynamic_parameter.OverrideChangeKind(change.Kind) {
+		case dynamic_parameter.AddedOverride:
+			fmt.Printf("+ %s: %s\n", change.Name, encode(change.Imported))
+		case dynamic_parameter.RemovedOverride:
+			fmt.Printf("- %s: %s\n", change.Name, encode(change.Current))
+		default:
+			fmt.Printf("~ %s: %s -> %s\n", change.Name, encode(change.Current), encode(change.Imported))
+		}
+	}
+}
+
+func encode(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(encoded)
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+
+	if invalid, ok := err.(*dynamic_parameter.InvalidDynamicParameterOverrides); ok {
+		for name, reason := range invalid.ExtraItems() {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", name, reason)
+		}
+	}
+
+	os.Exit(1)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/cmd/di"
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const usage = `usage:
+  replay -device id -from time [-to time] [-dry-run]
+
+times are RFC3339, and -to defaults to now`
+
+func main() {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	deviceID := flags.String("device", "", "device whose archived uplinks are replayed")
+	rawFrom := flags.String("from", "", "first receipt time to replay, inclusive")
+	rawTo := flags.String("to", "", "last receipt time to replay, exclusive")
+	dryRun := flags.Bool("dry-run", false, "only show the changes the replay would make")
+	_ = flags.Parse(os.Args[1:])
+
+	if *deviceID == "" || *rawFrom == "" {
+		exitWithError(fmt.Errorf("missing -device or -from\n%s", usage))
+	}
+
+	from, err := time.Parse(time.RFC3339, *rawFrom)
+	if err != nil {
+		exitWithError(fmt.Errorf("-from must be a RFC3339 time\n%s", usage))
+	}
+
+	to := time.Now()
+	if *rawTo != "" {
+		if to, err = time.Parse(time.RFC3339, *rawTo); err != nil {
+			exitWithError(fmt.Errorf("-to must be a RFC3339 time\n%s", usage))
+		}
+	}
+
+	ctx, cancel := di.RootContext()
+	defer cancel()
+	// Operators replay the uplinks of the devices of any tenant
+	ctx = amf_tenancy.WithAllTenants(ctx)
+
+	replayCliDi := di.InitReplayCliDi(ctx)
+
+	outcomes := map[telemetry_application.UplinkReplayOutcome]int{}
+	err = replayCliDi.TelemetryServices.UplinkReplayer.Replay(ctx, *deviceID, from, to, *dryRun, func(result telemetry_application.UplinkReplayResult) error {
+		outcomes[result.Outcome]++
+		printResult(result)
+		return nil
+	})
+	if err != nil {
+		exitWithError(err)
+	}
+
+	summary := fmt.Sprintf(
+		"%d created, %d updated, %d unchanged, %d undecodable",
+		outcomes[telemetry_application.CreatedUplinkReplay],
+		outcomes[telemetry_application.UpdatedUplinkReplay],
+		outcomes[telemetry_application.UnchangedUplinkReplay],
+		outcomes[telemetry_application.UndecodableUplinkReplay],
+	)
+	if *dryRun {
+		summary += " (dry run, nothing written)"
+	}
+	fmt.Println(summary)
+}
+
+func printResult(result telemetry_application.UplinkReplayResult) {
+	frame := fmt.Sprintf("%s %s", result.Frame.ID, result.Frame.ReceivedAt.UTC().Format(time.RFC3339))
+	// Backlog frames carry many readings, each with its own id
+	if result.Replayed != nil && result.Replayed.ID != result.Frame.ID {
+		frame = fmt.Sprintf("%s reading %s", frame, result.Replayed.ID)
+	}
+
+	switch result.Outcome {
+	case telemetry_application.CreatedUplinkReplay:
+		fmt.Printf("+ %s: %s\n", frame, describe(*result.Replayed))
+	case telemetry_application.UpdatedUplinkReplay:
+		fmt.Printf("~ %s\n", frame)
+		for _, change := range changes(*result.Current, *result.Replayed) {
+			fmt.Printf("    %s\n", change)
+		}
+	case telemetry_application.UndecodableUplinkReplay:
+		fmt.Printf("! %s: %s\n", frame, describeError(result.Err))
+	}
+}
+
+func describe(reading telemetry_domain.Reading) string {
+	names := sortedMetricNames(reading.Metrics, nil)
+	metrics := make([]string, 0, len(names))
+	for _, name := range names {
+		metrics = append(metrics, fmt.Sprintf("%s=%v", name, reading.Metrics[name]))
+	}
+
+	return fmt.Sprintf("recorded_at=%s %s", reading.RecordedAt.UTC().Format(time.RFC3339), strings.Join(metrics, " "))
+}
+
+func changes(current telemetry_domain.Reading, replayed telemetry_domain.Reading) []string {
+	changes := []string{}
+	if !current.RecordedAt.Equal(replayed.RecordedAt) {
+		changes = append(changes, fmt.Sprintf(
+			"recorded_at: %s -> %s",
+			current.RecordedAt.UTC().Format(time.RFC3339),
+			replayed.RecordedAt.UTC().Format(time.RFC3339),
+		))
+	}
+
+	for _, name := range sortedMetricNames(current.Metrics, replayed.Metrics) {
+		currentValue, inCurrent := current.Metrics[name]
+		replayedValue, inReplayed := replayed.Metrics[name]
+		switch {
+		case !inCurrent:
+			changes = append(changes, fmt.Sprintf("+ %s: %v", name, replayedValue))
+		case !inReplayed:
+			changes = append(changes, fmt.Sprintf("- %s: %v", name, currentValue))
+		case currentValue != replayedValue:
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", name, currentValue, replayedValue))
+		}
+	}
+
+	return changes
+}
+
+func sortedMetricNames(metrics map[string]float64, others map[string]float64) []string {
+	seen := map[string]struct{}{}
+	for name := range metrics {
+		seen[name] = struct{}{}
+	}
+	for name := range others {
+		seen[name] = struct{}{}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func describeError(err error) string {
+	if invalid, ok := err.(*telemetry_domain.InvalidReading); ok {
+		return fmt.Sprintf("%s (%v)", invalid.Error(), invalid.ExtraItems()["field"])
+	}
+
+	return err.Error()
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/cmd/di"
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const usage = `usage:
+  rollups -from time [-to time] [-device id]
+
+times are RFC3339, -to defaults to now, and every device is rebuilt without -device`
+
+func main() {
+	flags := flag.NewFlagSet("rollups", flag.ExitOnError)
+	deviceID := flags.String("device", "", "device whose rollups are rebuilt")
+	rawFrom := flags.String("from", "", "first reading time to rebuild")
+	rawTo := flags.String("to", "", "last reading time to rebuild")
+	_ = flags.Parse(os.Args[1:])
+
+	if *rawFrom == "" {
+		exitWithError(fmt.Errorf("missing -from\n%s", usage))
+	}
+
+	from, err := time.Parse(time.RFC3339, *rawFrom)
+	if err != nil {
+		exitWithError(fmt.Errorf("-from must be a RFC3339 time\n%s", usage))
+	}
+
+	to := time.Now()
+	if *rawTo != "" {
+		if to, err = time.Parse(time.RFC3339, *rawTo); err != nil {
+			exitWithError(fmt.Errorf("-to must be a RFC3339 time\n%s", usage))
+		}
+	}
+
+	ctx, cancel := di.RootContext()
+	defer cancel()
+	// Operators rebuild the rollups of the devices of any tenant
+	ctx = amf_tenancy.WithAllTenants(ctx)
+
+	rollupsCliDi := di.InitRollupsCliDi(ctx)
+
+	command := &telemetry_application.RebuildTelemetryRollupsCommand{DeviceID: *deviceID, From: from, To: to}
+	if err := rollupsCliDi.CommonServices.CommandBus.Dispatch(ctx, command); err != nil {
+		exitWithError(err)
+	}
+
+	firstDay, end := telemetry_domain.RollupDaysCovering(from, to)
+	fmt.Printf(
+		"rebuilt %d days of rollups, %s to %s\n",
+		int(end.Sub(firstDay).Hours()/24),
+		firstDay.Format(time.DateOnly),
+		end.Add(-24*time.Hour).Format(time.DateOnly),
+	)
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
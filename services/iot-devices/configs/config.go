@@ -20,6 +20,9 @@ type Config struct {
 	HttpReadTimeout  int    `env:"HTTP_READ_TIMEOUT"`
 	HttpWriteTimeout int    `env:"HTTP_WRITE_TIMEOUT"`
 
+	TenantApiKeys    string `env:"TENANT_API_KEYS"`
+	DeviceKeysSecret string `env:"DEVICE_KEYS_SECRET"`
+
 	PgsqlHost       string `env:"PGSQL_HOST"`
 	PgsqlHostReader string `env:"PGSQL_HOST_READER"`
 	PgsqlUser       string `env:"PGSQL_USER"`
@@ -31,11 +34,88 @@ type Config struct {
 	RedisHost string `env:"REDIS_HOST"`
 	RedisPort int    `env:"REDIS_PORT"`
 
+	ObjectStorageEndpoint       string `env:"OBJECT_STORAGE_ENDPOINT"`
+	ObjectStorageAccessKey      string `env:"OBJECT_STORAGE_ACCESS_KEY"`
+	ObjectStorageSecretKey      string `env:"OBJECT_STORAGE_SECRET_KEY"`
+	ObjectStorageUseSSL         bool   `env:"OBJECT_STORAGE_USE_SSL, default=false"`
+	ObjectStorageBucket         string `env:"OBJECT_STORAGE_BUCKET, default=spcd-bucket"`
+	ObjectStoragePath           string `env:"OBJECT_STORAGE_PATH, default=./data/objects"`
+	ObjectStoragePresignBaseUrl string `env:"OBJECT_STORAGE_PRESIGN_BASE_URL"`
+	ObjectStoragePresignSecret  string `env:"OBJECT_STORAGE_PRESIGN_SECRET"`
+
 	OtelGrpcHost string `env:"OTEL_GRPC_HOST"`
 	OtelGrpcPort string `env:"OTEL_GRPC_PORT"`
 
-	DynamicParametersFilePath string `env:"DYNAMIC_PARAMETERS_FILE_PATH"`
-	DynamicParametersApiKeys  string `env:"DYNAMIC_PARAMETERS_API_KEYS"`
+	DynamicParametersFilePath          string `env:"DYNAMIC_PARAMETERS_FILE_PATH"`
+	DynamicParametersApiKeys           string `env:"DYNAMIC_PARAMETERS_API_KEYS"`
+	DynamicParametersReloadInterval    int    `env:"DYNAMIC_PARAMETERS_RELOAD_INTERVAL, default=10"`
+	DynamicParametersSchedulerInterval int    `env:"DYNAMIC_PARAMETERS_SCHEDULER_INTERVAL, default=5"`
+	DynamicParametersSensitive         string `env:"DYNAMIC_PARAMETERS_SENSITIVE"`
+	DynamicParametersChangeRequestTTL  int    `env:"DYNAMIC_PARAMETERS_CHANGE_REQUEST_TTL, default=86400"`
+
+	UplinkDedupeTtl        int `env:"UPLINK_DEDUPE_TTL, default=300"`
+	TelemetryBackfillAfter int `env:"TELEMETRY_BACKFILL_AFTER, default=900"`
+	ClockDriftThreshold    int `env:"CLOCK_DRIFT_THRESHOLD, default=120"`
+	ClockSyncMaxRoundTrip  int `env:"CLOCK_SYNC_MAX_ROUND_TRIP, default=30"`
+
+	ConnectivityDefaultReportingInterval int `env:"CONNECTIVITY_DEFAULT_REPORTING_INTERVAL, default=3600"`
+	ConnectivityMissedReports            int `env:"CONNECTIVITY_MISSED_REPORTS, default=2"`
+	ConnectivitySweeperInterval          int `env:"CONNECTIVITY_SWEEPER_INTERVAL, default=60"`
+
+	AlertEscalationAfter      int    `env:"ALERT_ESCALATION_AFTER, default=1800"`
+	AlertEscalationSupervisor string `env:"ALERT_ESCALATION_SUPERVISOR"`
+	AlertEscalatorInterval    int    `env:"ALERT_ESCALATOR_INTERVAL, default=60"`
+
+	WebhookTimeout              int `env:"WEBHOOK_TIMEOUT, default=10"`
+	WebhookMaxRetries           int `env:"WEBHOOK_MAX_RETRIES, default=3"`
+	WebhookRetryInitialInterval int `env:"WEBHOOK_RETRY_INITIAL_INTERVAL, default=500"`
+	WebhookDeliveryInterval     int `env:"WEBHOOK_DELIVERY_INTERVAL, default=5"`
+	WebhookDeliveryBatchSize    int `env:"WEBHOOK_DELIVERY_BATCH_SIZE, default=50"`
+	WebhookDeliveryStaleAfter   int `env:"WEBHOOK_DELIVERY_STALE_AFTER, default=300"`
+
+	MaintenanceCloserInterval int `env:"MAINTENANCE_CLOSER_INTERVAL, default=60"`
+
+	DownlinkMaxPerUplink int `env:"DOWNLINK_MAX_PER_UPLINK, default=5"`
+	DownlinkDefaultTtl   int `env:"DOWNLINK_DEFAULT_TTL, default=86400"`
+
+	FirmwareSigningPublicKey           string `env:"FIRMWARE_SIGNING_PUBLIC_KEY"`
+	FirmwareChunkSize                  int64  `env:"FIRMWARE_CHUNK_SIZE, default=65536"`
+	FirmwareMaxSize                    int64  `env:"FIRMWARE_MAX_SIZE, default=33554432"`
+	FirmwareCampaignProgressorInterval int    `env:"FIRMWARE_CAMPAIGN_PROGRESSOR_INTERVAL, default=60"`
+	FirmwareImagesApiKeys              string `env:"FIRMWARE_IMAGES_API_KEYS"`
+
+	NotificationTimeout          int    `env:"NOTIFICATION_TIMEOUT, default=10"`
+	NotificationSmtpHost         string `env:"NOTIFICATION_SMTP_HOST"`
+	NotificationSmtpPort         int    `env:"NOTIFICATION_SMTP_PORT, default=587"`
+	NotificationSmtpUsername     string `env:"NOTIFICATION_SMTP_USERNAME"`
+	NotificationSmtpPassword     string `env:"NOTIFICATION_SMTP_PASSWORD"`
+	NotificationEmailFrom        string `env:"NOTIFICATION_EMAIL_FROM"`
+	NotificationSmsProviderUrl   string `env:"NOTIFICATION_SMS_PROVIDER_URL"`
+	NotificationSmsProviderToken string `env:"NOTIFICATION_SMS_PROVIDER_TOKEN"`
+	NotificationSmsFrom          string `env:"NOTIFICATION_SMS_FROM"`
+
+	GlueBoardImageUploadUrlTtl   int    `env:"GLUE_BOARD_IMAGE_UPLOAD_URL_TTL, default=900"`
+	GlueBoardImageDownloadUrlTtl int    `env:"GLUE_BOARD_IMAGE_DOWNLOAD_URL_TTL, default=300"`
+	GlueBoardImageMaxSize        int64  `env:"GLUE_BOARD_IMAGE_MAX_SIZE, default=10485760"`
+	GlueBoardImageMaxPixels      int    `env:"GLUE_BOARD_IMAGE_MAX_PIXELS, default=40000000"`
+	GlueBoardImageThumbnailSize  int    `env:"GLUE_BOARD_IMAGE_THUMBNAIL_SIZE, default=320"`
+	GlueBoardImagesApiKeys       string `env:"GLUE_BOARD_IMAGES_API_KEYS"`
+
+	StreamEventsRetention   int64 `env:"STREAM_EVENTS_RETENTION, default=10000"`
+	StreamHeartbeatInterval int   `env:"STREAM_HEARTBEAT_INTERVAL, default=15"`
+	StreamClientBuffer      int   `env:"STREAM_CLIENT_BUFFER, default=256"`
+	StreamReplayLimit       int   `env:"STREAM_REPLAY_LIMIT, default=1000"`
+
+	ExportStreamMaxPeriod int `env:"EXPORT_STREAM_MAX_PERIOD, default=2678400"`
+	ExportFetchSize       int `env:"EXPORT_FETCH_SIZE, default=1000"`
+	ExportDownloadUrlTtl  int `env:"EXPORT_DOWNLOAD_URL_TTL, default=900"`
+	ExportWorkerInterval  int `env:"EXPORT_WORKER_INTERVAL, default=10"`
+	ExportWorkerBatchSize int `env:"EXPORT_WORKER_BATCH_SIZE, default=5"`
+	ExportJobStaleAfter   int `env:"EXPORT_JOB_STALE_AFTER, default=3600"`
+
+	ReportTimezone          string `env:"REPORT_TIMEZONE, default=UTC"`
+	ReportSchedulerInterval int    `env:"REPORT_SCHEDULER_INTERVAL, default=3600"`
+	ReportDownloadUrlTtl    int    `env:"REPORT_DOWNLOAD_URL_TTL, default=900"`
 }
 
 func LoadEnvConfig() Config {
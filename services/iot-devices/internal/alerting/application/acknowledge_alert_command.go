@@ -0,0 +1,12 @@
+package alerting_application
+
+const AcknowledgeAlertCommandName = "AcknowledgeAlertCommand"
+
+type AcknowledgeAlertCommand struct {
+	ID string
+	By string
+}
+
+func (c AcknowledgeAlertCommand) Type() string {
+	return AcknowledgeAlertCommandName
+}
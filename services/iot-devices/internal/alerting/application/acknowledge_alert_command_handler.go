@@ -0,0 +1,24 @@
+package alerting_application
+
+import (
+	"context"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type AcknowledgeAlertCommandHandler struct {
+	lifecycle *AlertLifecycle
+}
+
+func NewAcknowledgeAlertCommandHandler(lifecycle *AlertLifecycle) *AcknowledgeAlertCommandHandler {
+	return &AcknowledgeAlertCommandHandler{lifecycle: lifecycle}
+}
+
+func (h AcknowledgeAlertCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*AcknowledgeAlertCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	return h.lifecycle.Acknowledge(ctx, cmd.ID, cmd.By)
+}
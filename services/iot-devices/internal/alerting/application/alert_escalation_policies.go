@@ -0,0 +1,35 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+)
+
+// AlertEscalationPolicies falls back to the default policy for the tenants without one.
+type AlertEscalationPolicies struct {
+	repository    alerting_domain.AlertEscalationPolicyRepository
+	defaultPolicy alerting_domain.AlertEscalationPolicy
+}
+
+func NewAlertEscalationPolicies(
+	repository alerting_domain.AlertEscalationPolicyRepository,
+	defaultPolicy alerting_domain.AlertEscalationPolicy,
+) *AlertEscalationPolicies {
+	return &AlertEscalationPolicies{repository: repository, defaultPolicy: defaultPolicy}
+}
+
+func (aep *AlertEscalationPolicies) For(ctx context.Context, tenantID string) (alerting_domain.AlertEscalationPolicy, error) {
+	policy, err := aep.repository.Find(ctx, tenantID)
+	if err != nil {
+		return alerting_domain.AlertEscalationPolicy{}, err
+	}
+
+	if policy == nil {
+		defaultPolicy := aep.defaultPolicy
+		defaultPolicy.TenantID = tenantID
+		return defaultPolicy, nil
+	}
+
+	return *policy, nil
+}
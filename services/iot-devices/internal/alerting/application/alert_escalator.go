@@ -0,0 +1,77 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const alertEscalatorMutexKey = "alert_escalator"
+
+// AlertEscalator escalates to the supervisor of the tenant the alerts nobody acknowledged
+// in time. Runs are serialized across replicas through the distributed mutex.
+type AlertEscalator struct {
+	alerts       alerting_domain.AlertRepository
+	policies     *AlertEscalationPolicies
+	lifecycle    *AlertLifecycle
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewAlertEscalator(
+	alerts alerting_domain.AlertRepository,
+	policies *AlertEscalationPolicies,
+	lifecycle *AlertLifecycle,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+) *AlertEscalator {
+	return &AlertEscalator{
+		alerts:       alerts,
+		policies:     policies,
+		lifecycle:    lifecycle,
+		mutex:        mutex,
+		timeProvider: timeProvider,
+	}
+}
+
+// Run escalates the alerts left unacknowledged for too long, holding a lock so only one
+// replica notifies them.
+func (ae *AlertEscalator) Run(ctx context.Context) error {
+	_, err := ae.mutex.Mutex(ctx, alertEscalatorMutexKey, func() (interface{}, error) {
+		return nil, ae.escalate(ctx)
+	})
+
+	return err
+}
+
+func (ae *AlertEscalator) escalate(ctx context.Context) error {
+	alerts, err := ae.alerts.SearchPendingEscalation(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := ae.timeProvider.Now()
+	policies := make(map[string]alerting_domain.AlertEscalationPolicy)
+	for _, alert := range alerts {
+		policy, known := policies[alert.TenantID]
+		if !known {
+			if policy, err = ae.policies.For(ctx, alert.TenantID); err != nil {
+				return err
+			}
+			policies[alert.TenantID] = policy
+		}
+
+		if !alert.NeedsEscalation(policy, now) {
+			continue
+		}
+
+		if err := ae.lifecycle.Escalate(ctx, alert.ID, policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package alerting_application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const (
+	alertMutexKeyPrefix         = "alert:"
+	activeAlertMutexKeyTemplate = "active_alert:%s:%s"
+)
+
+// AlertLifecycle moves alerts between states. Every change of an alert is done holding
+// its distributed lock, recorded in its audit trail and announced on the event bus.
+type AlertLifecycle struct {
+	alerts       alerting_domain.AlertRepository
+	auditTrail   alerting_domain.AlertAuditTrail
+	eventBus     amf_event_bus.Bus
+	mutex        amf_sync.MutexService
+	ulidProvider amf_utils.UlidProvider
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewAlertLifecycle(
+	alerts alerting_domain.AlertRepository,
+	auditTrail alerting_domain.AlertAuditTrail,
+	eventBus amf_event_bus.Bus,
+	mutex amf_sync.MutexService,
+	ulidProvider amf_utils.UlidProvider,
+	timeProvider amf_utils.DateTimeProvider,
+) *AlertLifecycle {
+	return &AlertLifecycle{
+		alerts:       alerts,
+		auditTrail:   auditTrail,
+		eventBus:     eventBus,
+		mutex:        mutex,
+		ulidProvider: ulidProvider,
+		timeProvider: timeProvider,
+	}
+}
+
+// Trigger opens an alert for the rule and device, or counts one more occurrence of
+// the one still active.
+func (al *AlertLifecycle) Trigger(ctx context.Context, tenantID string, ruleID string, ruleName string, deviceID string, value float64, at time.Time) error {
+	opened, err := al.mutex.Mutex(ctx, fmt.Sprintf(activeAlertMutexKeyTemplate, ruleID, deviceID), func() (interface{}, error) {
+		active, err := al.alerts.FindActive(ctx, ruleID, deviceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if active != nil {
+			return nil, al.change(ctx, active.ID, alerting_domain.RetriggeredAlertAuditAction, alerting_domain.SystemAlertAuditActor, "",
+				func(alert alerting_domain.Alert, _ time.Time) (alerting_domain.Alert, amf_bus.Event, error) {
+					return alert.Retrigger(value, at), nil, nil
+				},
+			)
+		}
+
+		alert := alerting_domain.NewAlert(al.ulidProvider.New().String(), tenantID, ruleID, ruleName, deviceID, value, at)
+		if err := al.alerts.Save(ctx, alert); err != nil {
+			return nil, err
+		}
+
+		if err := al.record(ctx, alert.ID, alerting_domain.OpenedAlertAuditAction, alerting_domain.SystemAlertAuditActor, ""); err != nil {
+			return nil, err
+		}
+
+		return alerting_domain.NewAlertOpened(alert), nil
+	})
+
+	al.publish(opened)
+
+	return err
+}
+
+// Clear notes that the rule stopped matching. The alert stays active until somebody resolves it.
+func (al *AlertLifecycle) Clear(ctx context.Context, ruleID string, deviceID string, at time.Time) error {
+	active, err := al.alerts.FindActive(ctx, ruleID, deviceID)
+	if err != nil || active == nil {
+		return err
+	}
+
+	return al.change(ctx, active.ID, alerting_domain.ClearedAlertAuditAction, alerting_domain.SystemAlertAuditActor, "",
+		func(alert alerting_domain.Alert, _ time.Time) (alerting_domain.Alert, amf_bus.Event, error) {
+			return alert.Clear(at), nil, nil
+		},
+	)
+}
+
+func (al *AlertLifecycle) Acknowledge(ctx context.Context, id string, by string) error {
+	return al.change(ctx, id, alerting_domain.AcknowledgedAlertAuditAction, by, "",
+		func(alert alerting_domain.Alert, now time.Time) (alerting_domain.Alert, amf_bus.Event, error) {
+			acknowledged, err := alert.Acknowledge(by, now)
+			return acknowledged, alerting_domain.NewAlertAcknowledged(acknowledged), err
+		},
+	)
+}
+
+func (al *AlertLifecycle) Snooze(ctx context.Context, id string, by string, until time.Time) error {
+	return al.change(ctx, id, alerting_domain.SnoozedAlertAuditAction, by, "until "+until.Format(time.RFC3339),
+		func(alert alerting_domain.Alert, now time.Time) (alerting_domain.Alert, amf_bus.Event, error) {
+			snoozed, err := alert.Snooze(until, now)
+			return snoozed, nil, err
+		},
+	)
+}
+
+func (al *AlertLifecycle) Resolve(ctx context.Context, id string, by string) error {
+	return al.change(ctx, id, alerting_domain.ResolvedAlertAuditAction, by, "",
+		func(alert alerting_domain.Alert, now time.Time) (alerting_domain.Alert, amf_bus.Event, error) {
+			resolved, err := alert.Resolve(by, now)
+			return resolved, alerting_domain.NewAlertResolved(resolved), err
+		},
+	)
+}
+
+// Escalate escalates the alert unless it no longer needs it by the time its lock is held.
+func (al *AlertLifecycle) Escalate(ctx context.Context, id string, policy alerting_domain.AlertEscalationPolicy) error {
+	return al.change(ctx, id, alerting_domain.EscalatedAlertAuditAction, alerting_domain.SystemAlertAuditActor, "to "+policy.Supervisor,
+		func(alert alerting_domain.Alert, now time.Time) (alerting_domain.Alert, amf_bus.Event, error) {
+			if !alert.NeedsEscalation(policy, now) {
+				return alert, nil, errAlertUnchanged
+			}
+
+			escalated := alert.Escalate(policy, now)
+			return escalated, alerting_domain.NewAlertEscalated(escalated), nil
+		},
+	)
+}
+
+type alertChange func(alert alerting_domain.Alert, now time.Time) (alerting_domain.Alert, amf_bus.Event, error)
+
+var errAlertUnchanged = fmt.Errorf("alert unchanged")
+
+func (al *AlertLifecycle) change(
+	ctx context.Context,
+	id string,
+	action alerting_domain.AlertAuditAction,
+	actor string,
+	details string,
+	changeFn alertChange,
+) error {
+	announcement, err := al.mutex.Mutex(ctx, alertMutexKeyPrefix+id, func() (interface{}, error) {
+		alert, err := al.alerts.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if alert == nil {
+			return nil, alerting_domain.NewAlertNotExists(id)
+		}
+
+		changed, event, err := changeFn(*alert, al.timeProvider.Now())
+		if err == errAlertUnchanged {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := al.alerts.Save(ctx, changed); err != nil {
+			return nil, err
+		}
+
+		if err := al.record(ctx, id, action, actor, details); err != nil {
+			return nil, err
+		}
+
+		return event, nil
+	})
+
+	al.publish(announcement)
+
+	return err
+}
+
+// publish announces the changes once the locks are released, so slow subscribers never
+// hold them.
+func (al *AlertLifecycle) publish(event interface{}) {
+	if event, ok := event.(amf_bus.Event); ok {
+		al.eventBus.Publish(event)
+	}
+}
+
+func (al *AlertLifecycle) record(ctx context.Context, alertID string, action alerting_domain.AlertAuditAction, actor string, details string) error {
+	return al.auditTrail.Record(ctx, alerting_domain.NewAlertAuditEntry(
+		al.ulidProvider.New().String(),
+		alertID,
+		action,
+		actor,
+		details,
+		al.timeProvider.Now(),
+	))
+}
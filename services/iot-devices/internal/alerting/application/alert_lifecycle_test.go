@@ -0,0 +1,155 @@
+package alerting_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	alerting_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/application"
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	alerting_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain/mocks"
+
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestAlertLifecycle(t *testing.T) {
+	ctx := context.Background()
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+
+	auditEntry := func(alertID string, action alerting_domain.AlertAuditAction, actor string, details string) alerting_domain.AlertAuditEntry {
+		return alerting_domain.NewAlertAuditEntry(ulidProvider.New().String(), alertID, action, actor, details, now)
+	}
+
+	t.Run("should open an alert for the tenant of the rule the first time it is raised", func(t *testing.T) {
+		alerts := alerting_domain_mocks.NewAlertRepository(t)
+		auditTrail := alerting_domain_mocks.NewAlertAuditTrail(t)
+		eventBus := amf_event_bus.NewEventBus()
+		collector := newEventCollector(eventBus, alerting_domain.AlertOpenedEventName)
+		expected := alerting_domain.NewAlert(ulidProvider.New().String(), "tenant-1", "rule-1", "Low battery", "device-1", 2300, now)
+
+		alerts.On("FindActive", ctx, "rule-1", "device-1").Return(nil, nil).Once()
+		alerts.On("Save", ctx, expected).Return(nil).Once()
+		auditTrail.On("Record", ctx, auditEntry(expected.ID, alerting_domain.OpenedAlertAuditAction, alerting_domain.SystemAlertAuditActor, "")).
+			Return(nil).Once()
+
+		lifecycle := alerting_application.NewAlertLifecycle(alerts, auditTrail, eventBus, inProcessMutex{}, ulidProvider, timeProvider)
+		err := lifecycle.Trigger(ctx, "tenant-1", "rule-1", "Low battery", "device-1", 2300, now)
+
+		assert.NoError(t, err)
+		event := collector.next(t)
+		assert.Equal(t, alerting_domain.AlertOpenedEventName, event.Name())
+		assert.Equal(t, expected.ID, event.Data()["alert_id"])
+	})
+
+	t.Run("should count one more occurrence while the alert is active", func(t *testing.T) {
+		alerts := alerting_domain_mocks.NewAlertRepository(t)
+		auditTrail := alerting_domain_mocks.NewAlertAuditTrail(t)
+		active := alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, now.Add(-time.Hour))
+
+		alerts.On("FindActive", ctx, "rule-1", "device-1").Return(&active, nil).Once()
+		alerts.On("Find", ctx, active.ID).Return(&active, nil).Once()
+		alerts.On("Save", ctx, active.Retrigger(2200, now)).Return(nil).Once()
+		auditTrail.On("Record", ctx, auditEntry(active.ID, alerting_domain.RetriggeredAlertAuditAction, alerting_domain.SystemAlertAuditActor, "")).
+			Return(nil).Once()
+
+		lifecycle := alerting_application.NewAlertLifecycle(alerts, auditTrail, amf_event_bus.NewEventBus(), inProcessMutex{}, ulidProvider, timeProvider)
+		err := lifecycle.Trigger(ctx, "tenant-1", "rule-1", "Low battery", "device-1", 2200, now)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should acknowledge an open alert", func(t *testing.T) {
+		alerts := alerting_domain_mocks.NewAlertRepository(t)
+		auditTrail := alerting_domain_mocks.NewAlertAuditTrail(t)
+		eventBus := amf_event_bus.NewEventBus()
+		collector := newEventCollector(eventBus, alerting_domain.AlertAcknowledgedEventName)
+		open := alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, now.Add(-time.Hour))
+
+		alerts.On("Find", ctx, open.ID).Return(&open, nil).Once()
+		alerts.On("Save", ctx, mock.MatchedBy(func(alert alerting_domain.Alert) bool {
+			return alert.Status == alerting_domain.AcknowledgedAlert && alert.AcknowledgedBy == "alice"
+		})).Return(nil).Once()
+		auditTrail.On("Record", ctx, auditEntry(open.ID, alerting_domain.AcknowledgedAlertAuditAction, "alice", "")).Return(nil).Once()
+
+		lifecycle := alerting_application.NewAlertLifecycle(alerts, auditTrail, eventBus, inProcessMutex{}, ulidProvider, timeProvider)
+		err := lifecycle.Acknowledge(ctx, open.ID, "alice")
+
+		assert.NoError(t, err)
+		assert.Equal(t, alerting_domain.AlertAcknowledgedEventName, collector.next(t).Name())
+	})
+
+	t.Run("should refuse to acknowledge a resolved alert", func(t *testing.T) {
+		alerts := alerting_domain_mocks.NewAlertRepository(t)
+		auditTrail := alerting_domain_mocks.NewAlertAuditTrail(t)
+		resolved, _ := alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, now).Resolve("bob", now)
+
+		alerts.On("Find", ctx, resolved.ID).Return(&resolved, nil).Once()
+
+		lifecycle := alerting_application.NewAlertLifecycle(alerts, auditTrail, amf_event_bus.NewEventBus(), inProcessMutex{}, ulidProvider, timeProvider)
+		err := lifecycle.Acknowledge(ctx, resolved.ID, "alice")
+
+		assert.IsType(t, &alerting_domain.AlertTransitionNotAllowed{}, err)
+	})
+
+	t.Run("should fail on unknown alerts", func(t *testing.T) {
+		alerts := alerting_domain_mocks.NewAlertRepository(t)
+		auditTrail := alerting_domain_mocks.NewAlertAuditTrail(t)
+
+		alerts.On("Find", ctx, "missing").Return(nil, nil).Once()
+
+		lifecycle := alerting_application.NewAlertLifecycle(alerts, auditTrail, amf_event_bus.NewEventBus(), inProcessMutex{}, ulidProvider, timeProvider)
+		err := lifecycle.Resolve(ctx, "missing", "alice")
+
+		assert.IsType(t, &alerting_domain.AlertNotExists{}, err)
+	})
+}
+
+func TestAlertEscalator(t *testing.T) {
+	ctx := context.Background()
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+	defaultPolicy := alerting_domain.AlertEscalationPolicy{EscalateAfter: 30 * time.Minute, Supervisor: "supervisor@example.com"}
+
+	t.Run("should escalate the alerts nobody acknowledged in time", func(t *testing.T) {
+		alerts := alerting_domain_mocks.NewAlertRepository(t)
+		auditTrail := alerting_domain_mocks.NewAlertAuditTrail(t)
+		policies := alerting_domain_mocks.NewAlertEscalationPolicyRepository(t)
+		eventBus := amf_event_bus.NewEventBus()
+		collector := newEventCollector(eventBus, alerting_domain.AlertEscalatedEventName)
+		overdue := alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, now.Add(-time.Hour))
+		recent := alerting_domain.NewAlert("alert-2", "", "rule-1", "Low battery", "device-2", 2300, now.Add(-time.Minute))
+
+		alerts.On("SearchPendingEscalation", ctx).Return([]alerting_domain.Alert{overdue, recent}, nil).Once()
+		policies.On("Find", ctx, "").Return(nil, nil).Once()
+		alerts.On("Find", ctx, overdue.ID).Return(&overdue, nil).Once()
+		alerts.On("Save", ctx, overdue.Escalate(defaultPolicy, now)).Return(nil).Once()
+		auditTrail.On("Record", ctx, alerting_domain.NewAlertAuditEntry(
+			ulidProvider.New().String(),
+			overdue.ID,
+			alerting_domain.EscalatedAlertAuditAction,
+			alerting_domain.SystemAlertAuditActor,
+			"to "+defaultPolicy.Supervisor,
+			now,
+		)).Return(nil).Once()
+
+		lifecycle := alerting_application.NewAlertLifecycle(alerts, auditTrail, eventBus, inProcessMutex{}, ulidProvider, timeProvider)
+		escalator := alerting_application.NewAlertEscalator(
+			alerts,
+			alerting_application.NewAlertEscalationPolicies(policies, defaultPolicy),
+			lifecycle,
+			inProcessMutex{},
+			timeProvider,
+		)
+		err := escalator.Run(ctx)
+
+		assert.NoError(t, err)
+		event := collector.next(t)
+		assert.Equal(t, overdue.ID, event.Data()["alert_id"])
+		assert.Equal(t, defaultPolicy.Supervisor, event.Data()["escalated_to"])
+	})
+}
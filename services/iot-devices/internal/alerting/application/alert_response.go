@@ -0,0 +1,91 @@
+package alerting_application
+
+import (
+	"time"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+)
+
+type AlertResponse struct {
+	ID              string  `jsonapi:"primary,alerts"`
+	RuleID          string  `jsonapi:"attr,rule_id"`
+	RuleName        string  `jsonapi:"attr,rule_name"`
+	DeviceID        string  `jsonapi:"attr,device_id"`
+	Status          string  `jsonapi:"attr,status"`
+	Value           float64 `jsonapi:"attr,value"`
+	Occurrences     int     `jsonapi:"attr,occurrences"`
+	RaisedAt        string  `jsonapi:"attr,raised_at"`
+	LastTriggeredAt string  `jsonapi:"attr,last_triggered_at"`
+	ClearedAt       string  `jsonapi:"attr,cleared_at,omitempty"`
+	AcknowledgedBy  string  `jsonapi:"attr,acknowledged_by,omitempty"`
+	AcknowledgedAt  string  `jsonapi:"attr,acknowledged_at,omitempty"`
+	SnoozedUntil    string  `jsonapi:"attr,snoozed_until,omitempty"`
+	ResolvedBy      string  `jsonapi:"attr,resolved_by,omitempty"`
+	ResolvedAt      string  `jsonapi:"attr,resolved_at,omitempty"`
+	EscalatedTo     string  `jsonapi:"attr,escalated_to,omitempty"`
+	EscalatedAt     string  `jsonapi:"attr,escalated_at,omitempty"`
+}
+
+func NewAlertResponse(alert alerting_domain.Alert, now time.Time) *AlertResponse {
+	return &AlertResponse{
+		ID:              alert.ID,
+		RuleID:          alert.RuleID,
+		RuleName:        alert.RuleName,
+		DeviceID:        alert.DeviceID,
+		Status:          alert.StatusAt(now).Value(),
+		Value:           alert.Value,
+		Occurrences:     alert.Occurrences,
+		RaisedAt:        alert.RaisedAt.Format(time.RFC3339),
+		LastTriggeredAt: alert.LastTriggeredAt.Format(time.RFC3339),
+		ClearedAt:       optionalRFC3339(alert.ClearedAt),
+		AcknowledgedBy:  alert.AcknowledgedBy,
+		AcknowledgedAt:  optionalRFC3339(alert.AcknowledgedAt),
+		SnoozedUntil:    optionalRFC3339(alert.SnoozedUntil),
+		ResolvedBy:      alert.ResolvedBy,
+		ResolvedAt:      optionalRFC3339(alert.ResolvedAt),
+		EscalatedTo:     alert.EscalatedTo,
+		EscalatedAt:     optionalRFC3339(alert.EscalatedAt),
+	}
+}
+
+type AlertAuditEntryResponse struct {
+	ID      string `jsonapi:"primary,alert_audit_entries"`
+	AlertID string `jsonapi:"attr,alert_id"`
+	Action  string `jsonapi:"attr,action"`
+	Actor   string `jsonapi:"attr,actor"`
+	Details string `jsonapi:"attr,details,omitempty"`
+	At      string `jsonapi:"attr,at"`
+}
+
+func NewAlertAuditEntryResponse(entry alerting_domain.AlertAuditEntry) *AlertAuditEntryResponse {
+	return &AlertAuditEntryResponse{
+		ID:      entry.ID,
+		AlertID: entry.AlertID,
+		Action:  string(entry.Action),
+		Actor:   entry.Actor,
+		Details: entry.Details,
+		At:      entry.At.Format(time.RFC3339),
+	}
+}
+
+type AlertEscalationPolicyResponse struct {
+	TenantID             string `jsonapi:"primary,alert_escalation_policies"`
+	EscalateAfterSeconds int64  `jsonapi:"attr,escalate_after_seconds"`
+	Supervisor           string `jsonapi:"attr,supervisor"`
+}
+
+func NewAlertEscalationPolicyResponse(policy alerting_domain.AlertEscalationPolicy) *AlertEscalationPolicyResponse {
+	return &AlertEscalationPolicyResponse{
+		TenantID:             policy.TenantID,
+		EscalateAfterSeconds: int64(policy.EscalateAfter.Seconds()),
+		Supervisor:           policy.Supervisor,
+	}
+}
+
+func optionalRFC3339(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.Format(time.RFC3339)
+}
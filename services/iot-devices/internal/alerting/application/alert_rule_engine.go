@@ -0,0 +1,92 @@
+package alerting_application
+
+import (
+	"context"
+	"time"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+)
+
+const alertRuleEngineMutexKeyPrefix = "alert_rules:"
+
+// AlertRuleEngine evaluates the enabled rules of the tenant of a device against each of
+// its readings. The evaluation of a device is serialized across replicas so its rule
+// states are never updated concurrently.
+type AlertRuleEngine struct {
+	rules    alerting_domain.AlertRuleRepository
+	states   alerting_domain.AlertRuleStateRepository
+	eventBus amf_event_bus.Bus
+	mutex    amf_sync.MutexService
+}
+
+func NewAlertRuleEngine(
+	rules alerting_domain.AlertRuleRepository,
+	states alerting_domain.AlertRuleStateRepository,
+	eventBus amf_event_bus.Bus,
+	mutex amf_sync.MutexService,
+) *AlertRuleEngine {
+	return &AlertRuleEngine{
+		rules:    rules,
+		states:   states,
+		eventBus: eventBus,
+		mutex:    mutex,
+	}
+}
+
+func (e *AlertRuleEngine) Evaluate(ctx context.Context, deviceID string, recordedAt time.Time, metrics map[string]float64) error {
+	events, err := e.mutex.Mutex(ctx, alertRuleEngineMutexKeyPrefix+deviceID, func() (interface{}, error) {
+		return e.evaluate(ctx, deviceID, recordedAt, metrics)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events.([]amf_bus.Event) {
+		e.eventBus.Publish(event)
+	}
+
+	return nil
+}
+
+func (e *AlertRuleEngine) evaluate(
+	ctx context.Context,
+	deviceID string,
+	recordedAt time.Time,
+	metrics map[string]float64,
+) ([]amf_bus.Event, error) {
+	rules, err := e.rules.SearchEnabledFor(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]amf_bus.Event, 0)
+	for _, rule := range rules {
+		value, measured := metrics[rule.Condition.Metric]
+		if !rule.AppliesTo(deviceID) || !measured {
+			continue
+		}
+
+		state, err := e.states.Find(ctx, rule.ID, deviceID)
+		if err != nil {
+			return nil, err
+		}
+
+		newState, transition := rule.Evaluate(state, value, recordedAt)
+		if err := e.states.Save(ctx, newState); err != nil {
+			return nil, err
+		}
+
+		switch transition {
+		case alerting_domain.AlertRaisedTransition:
+			events = append(events, alerting_domain.NewAlertRaised(rule, deviceID, value, recordedAt))
+		case alerting_domain.AlertClearedTransition:
+			events = append(events, alerting_domain.NewAlertCleared(rule, deviceID, value, recordedAt))
+		}
+	}
+
+	return events, nil
+}
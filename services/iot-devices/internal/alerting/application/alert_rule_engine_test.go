@@ -0,0 +1,172 @@
+package alerting_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alerting_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/application"
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	alerting_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain/mocks"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain/mocks"
+	telemetry_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/infra"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type inProcessMutex struct{}
+
+func (m inProcessMutex) Mutex(_ context.Context, _ string, fn func() (interface{}, error)) (interface{}, error) {
+	return fn()
+}
+
+type eventCollector struct {
+	events chan amf_bus.Event
+}
+
+func newEventCollector(eventBus *amf_event_bus.EventBus, topics ...string) *eventCollector {
+	collector := &eventCollector{events: make(chan amf_bus.Event, 10)}
+	for _, topic := range topics {
+		eventBus.Subscribe(topic, collector)
+	}
+
+	return collector
+}
+
+func (c *eventCollector) Handle(event amf_bus.Event) error {
+	c.events <- event
+	return nil
+}
+
+func (c *eventCollector) next(t *testing.T) amf_bus.Event {
+	select {
+	case event := <-c.events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+		return nil
+	}
+}
+
+func TestAlertRuleEngine(t *testing.T) {
+	ctx, now := context.Background(), time.Now()
+
+	lowBattery, err := alerting_domain.NewAlertRule(amf_utils.NewUlid().String(), "tenant-1", "Low battery", "battery_mv < 2400", "", true, now)
+	require.NoError(t, err)
+	otherDevice, err := alerting_domain.NewAlertRule(amf_utils.NewUlid().String(), "tenant-1", "Trap fired", "catch_count delta > 0", "device-2", true, now)
+	require.NoError(t, err)
+
+	t.Run("should raise alerts of the rules matching the reading", func(t *testing.T) {
+		rules := alerting_domain_mocks.NewAlertRuleRepository(t)
+		states := alerting_domain_mocks.NewAlertRuleStateRepository(t)
+		eventBus := amf_event_bus.NewEventBus()
+		collector := newEventCollector(eventBus, alerting_domain.AlertRaisedEventName, alerting_domain.AlertClearedEventName)
+
+		rules.On("SearchEnabledFor", ctx, "device-1").Return([]alerting_domain.AlertRule{lowBattery, otherDevice}, nil).Once()
+		states.On("Find", ctx, lowBattery.ID, "device-1").Return(alerting_domain.NewAlertRuleState(lowBattery.ID, "device-1"), nil).Once()
+
+		expectedState, _ := lowBattery.Evaluate(alerting_domain.NewAlertRuleState(lowBattery.ID, "device-1"), 2300, now)
+		states.On("Save", ctx, expectedState).Return(nil).Once()
+
+		engine := alerting_application.NewAlertRuleEngine(rules, states, eventBus, inProcessMutex{})
+		err := engine.Evaluate(ctx, "device-1", now, map[string]float64{"battery_mv": 2300, "catch_count": 1})
+
+		assert.NoError(t, err)
+		event := collector.next(t)
+		assert.Equal(t, alerting_domain.AlertRaisedEventName, event.Name())
+		assert.Equal(t, lowBattery.ID, event.Data()["rule_id"])
+		assert.Equal(t, "tenant-1", event.Data()["tenant_id"])
+		assert.Equal(t, "device-1", event.Data()["device_id"])
+		assert.Equal(t, float64(2300), event.Data()["value"])
+	})
+
+	t.Run("should clear raised alerts once the reading stops matching", func(t *testing.T) {
+		rules := alerting_domain_mocks.NewAlertRuleRepository(t)
+		states := alerting_domain_mocks.NewAlertRuleStateRepository(t)
+		eventBus := amf_event_bus.NewEventBus()
+		collector := newEventCollector(eventBus, alerting_domain.AlertRaisedEventName, alerting_domain.AlertClearedEventName)
+
+		raisedState, _ := lowBattery.Evaluate(alerting_domain.NewAlertRuleState(lowBattery.ID, "device-1"), 2300, now)
+		clearedState, _ := lowBattery.Evaluate(raisedState, 2600, now.Add(time.Minute))
+
+		rules.On("SearchEnabledFor", ctx, "device-1").Return([]alerting_domain.AlertRule{lowBattery}, nil).Once()
+		states.On("Find", ctx, lowBattery.ID, "device-1").Return(raisedState, nil).Once()
+		states.On("Save", ctx, clearedState).Return(nil).Once()
+
+		engine := alerting_application.NewAlertRuleEngine(rules, states, eventBus, inProcessMutex{})
+		err := engine.Evaluate(ctx, "device-1", now.Add(time.Minute), map[string]float64{"battery_mv": 2600})
+
+		assert.NoError(t, err)
+		assert.Equal(t, alerting_domain.AlertClearedEventName, collector.next(t).Name())
+	})
+}
+
+func TestReadingIngestedEventHandler(t *testing.T) {
+	t.Run("should not evaluate backfilled readings", func(t *testing.T) {
+		reading, err := telemetry_domain.NewReading(amf_utils.NewUlid().String(), "device-1", time.Now().Add(-time.Hour), time.Now(), map[string]float64{"battery_mv": 2300})
+		require.NoError(t, err)
+
+		engine := alerting_application.NewAlertRuleEngine(
+			alerting_domain_mocks.NewAlertRuleRepository(t),
+			alerting_domain_mocks.NewAlertRuleStateRepository(t),
+			amf_event_bus.NewEventBus(),
+			inProcessMutex{},
+		)
+		handler := alerting_application.NewReadingIngestedEventHandler(engine)
+
+		assert.NoError(t, handler.Handle(telemetry_domain.NewReadingIngested(reading.AsBackfilled())))
+	})
+}
+
+func TestPestEventUplinkRaisesAlert(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	tampered, err := alerting_domain.NewAlertRule(amf_utils.NewUlid().String(), "tenant-1", "Trap tampered", "tamper > 0", "", true, timeProvider.Now())
+	require.NoError(t, err)
+
+	frame, err := telemetry_domain.NewUplinkFrame(amf_utils.NewUlid().String(), "trap-1", timeProvider.Now(), []byte(`{"data":{"attributes":{
+		"recorded_at":"`+timeProvider.Now().Format(time.RFC3339)+`",
+		"device_kind":"snap_trap",
+		"events":[{"type":"tamper","occurred_at":"`+timeProvider.Now().Add(-time.Minute).Format(time.RFC3339)+`","tamper":"moved"}]
+	}}}`), nil)
+	require.NoError(t, err)
+
+	readings := telemetry_domain_mocks.NewReadingRepository(t)
+	clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+	rules := alerting_domain_mocks.NewAlertRuleRepository(t)
+	states := alerting_domain_mocks.NewAlertRuleStateRepository(t)
+	readings.On("Save", ctx, mock.Anything).Return(nil).Once()
+	clocks.On("Find", ctx, "trap-1").Return(nil, nil).Once()
+	rules.On("SearchEnabledFor", mock.Anything, "trap-1").Return([]alerting_domain.AlertRule{tampered}, nil).Once()
+	states.On("Find", mock.Anything, tampered.ID, "trap-1").Return(alerting_domain.NewAlertRuleState(tampered.ID, "trap-1"), nil).Once()
+	states.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+
+	eventBus := amf_event_bus.NewEventBus()
+	engine := alerting_application.NewAlertRuleEngine(rules, states, eventBus, inProcessMutex{})
+	eventBus.Subscribe(telemetry_domain.ReadingIngestedEventName, alerting_application.NewReadingIngestedEventHandler(engine))
+	for _, eventName := range pestcontrol_domain.PestEventNames {
+		eventBus.Subscribe(eventName, alerting_application.NewPestEventReportedEventHandler(engine))
+	}
+	collector := newEventCollector(eventBus, alerting_domain.AlertRaisedEventName)
+
+	decoded, err := telemetry_infra.NewJsonApiUplinkDecoder().Decode(frame)
+	require.NoError(t, err)
+	command := telemetry_application.NewIngestReadingCommand(decoded[0].ID, decoded[0].DeviceID, decoded[0].RecordedAt, decoded[0].Metrics)
+	command.PestEvents = decoded[0].PestEvents
+	handler := telemetry_application.NewIngestReadingCommandHandler(readings, clocks, eventBus, time.Hour, timeProvider)
+	require.NoError(t, handler.Handle(ctx, command))
+
+	event := collector.next(t)
+	assert.Equal(t, tampered.ID, event.Data()["rule_id"])
+	assert.Equal(t, "trap-1", event.Data()["device_id"])
+	assert.Equal(t, float64(1), event.Data()["value"])
+}
@@ -0,0 +1,29 @@
+package alerting_application
+
+import (
+	"time"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+)
+
+type AlertRuleResponse struct {
+	ID         string `jsonapi:"primary,alert_rules"`
+	Name       string `jsonapi:"attr,name"`
+	Expression string `jsonapi:"attr,expression"`
+	DeviceID   string `jsonapi:"attr,device_id,omitempty"`
+	Enabled    bool   `jsonapi:"attr,enabled"`
+	CreatedAt  string `jsonapi:"attr,created_at"`
+	UpdatedAt  string `jsonapi:"attr,updated_at"`
+}
+
+func NewAlertRuleResponse(rule alerting_domain.AlertRule) *AlertRuleResponse {
+	return &AlertRuleResponse{
+		ID:         rule.ID,
+		Name:       rule.Name,
+		Expression: rule.Expression,
+		DeviceID:   rule.DeviceID,
+		Enabled:    rule.Enabled,
+		CreatedAt:  rule.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  rule.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,42 @@
+package alerting_application
+
+import (
+	"context"
+	"time"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+// AlertRuleTransitionEventHandler turns the alerts raised and cleared by the rule engine
+// into alerts with a lifecycle.
+type AlertRuleTransitionEventHandler struct {
+	lifecycle *AlertLifecycle
+}
+
+func NewAlertRuleTransitionEventHandler(lifecycle *AlertLifecycle) *AlertRuleTransitionEventHandler {
+	return &AlertRuleTransitionEventHandler{lifecycle: lifecycle}
+}
+
+func (h AlertRuleTransitionEventHandler) Handle(event amf_bus.Event) error {
+	data := event.Data()
+
+	tenantID, _ := data["tenant_id"].(string)
+	ruleID, _ := data["rule_id"].(string)
+	ruleName, _ := data["rule_name"].(string)
+	deviceID, _ := data["device_id"].(string)
+	value, _ := data["value"].(float64)
+	at, _ := data["at"].(time.Time)
+
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	switch event.Name() {
+	case alerting_domain.AlertRaisedEventName:
+		return h.lifecycle.Trigger(ctx, tenantID, ruleID, ruleName, deviceID, value, at)
+	case alerting_domain.AlertClearedEventName:
+		return h.lifecycle.Clear(ctx, ruleID, deviceID, at)
+	}
+
+	return amf_bus.NewInvalidDto("invalid alert rule transition event")
+}
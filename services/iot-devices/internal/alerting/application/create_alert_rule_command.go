@@ -0,0 +1,16 @@
+package alerting_application
+
+const CreateAlertRuleCommandName = "CreateAlertRuleCommand"
+
+type CreateAlertRuleCommand struct {
+	ID         string
+	TenantID   string
+	Name       string
+	Expression string
+	DeviceID   string
+	Enabled    bool
+}
+
+func (c CreateAlertRuleCommand) Type() string {
+	return CreateAlertRuleCommandName
+}
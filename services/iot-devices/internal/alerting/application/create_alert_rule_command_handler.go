@@ -0,0 +1,36 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type CreateAlertRuleCommandHandler struct {
+	repository   alerting_domain.AlertRuleRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewCreateAlertRuleCommandHandler(
+	repository alerting_domain.AlertRuleRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *CreateAlertRuleCommandHandler {
+	return &CreateAlertRuleCommandHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h CreateAlertRuleCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*CreateAlertRuleCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	rule, err := alerting_domain.NewAlertRule(cmd.ID, cmd.TenantID, cmd.Name, cmd.Expression, cmd.DeviceID, cmd.Enabled, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, rule)
+}
@@ -0,0 +1,11 @@
+package alerting_application
+
+const DeleteAlertRuleCommandName = "DeleteAlertRuleCommand"
+
+type DeleteAlertRuleCommand struct {
+	ID string
+}
+
+func (c DeleteAlertRuleCommand) Type() string {
+	return DeleteAlertRuleCommandName
+}
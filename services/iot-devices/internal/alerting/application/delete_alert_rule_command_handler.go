@@ -0,0 +1,42 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type DeleteAlertRuleCommandHandler struct {
+	repository alerting_domain.AlertRuleRepository
+	states     alerting_domain.AlertRuleStateRepository
+}
+
+func NewDeleteAlertRuleCommandHandler(
+	repository alerting_domain.AlertRuleRepository,
+	states alerting_domain.AlertRuleStateRepository,
+) *DeleteAlertRuleCommandHandler {
+	return &DeleteAlertRuleCommandHandler{repository: repository, states: states}
+}
+
+func (h DeleteAlertRuleCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*DeleteAlertRuleCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	rule, err := h.repository.Find(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		return alerting_domain.NewAlertRuleNotExists(cmd.ID)
+	}
+
+	if err := h.repository.Delete(ctx, rule.ID); err != nil {
+		return err
+	}
+
+	return h.states.DeleteForRule(ctx, rule.ID)
+}
@@ -0,0 +1,11 @@
+package alerting_application
+
+const FindAlertEscalationPolicyQueryName = "FindAlertEscalationPolicyQuery"
+
+type FindAlertEscalationPolicyQuery struct {
+	TenantID string
+}
+
+func (q FindAlertEscalationPolicyQuery) Type() string {
+	return FindAlertEscalationPolicyQueryName
+}
@@ -0,0 +1,29 @@
+package alerting_application
+
+import (
+	"context"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindAlertEscalationPolicyQueryHandler struct {
+	policies *AlertEscalationPolicies
+}
+
+func NewFindAlertEscalationPolicyQueryHandler(policies *AlertEscalationPolicies) *FindAlertEscalationPolicyQueryHandler {
+	return &FindAlertEscalationPolicyQueryHandler{policies: policies}
+}
+
+func (h FindAlertEscalationPolicyQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindAlertEscalationPolicyQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	policy, err := h.policies.For(ctx, q.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAlertEscalationPolicyResponse(policy), nil
+}
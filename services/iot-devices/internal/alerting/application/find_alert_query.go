@@ -0,0 +1,11 @@
+package alerting_application
+
+const FindAlertQueryName = "FindAlertQuery"
+
+type FindAlertQuery struct {
+	ID string
+}
+
+func (q FindAlertQuery) Type() string {
+	return FindAlertQueryName
+}
@@ -0,0 +1,36 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type FindAlertQueryHandler struct {
+	repository   alerting_domain.AlertRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewFindAlertQueryHandler(repository alerting_domain.AlertRepository, timeProvider amf_utils.DateTimeProvider) *FindAlertQueryHandler {
+	return &FindAlertQueryHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h FindAlertQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindAlertQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	alert, err := h.repository.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if alert == nil {
+		return nil, alerting_domain.NewAlertNotExists(q.ID)
+	}
+
+	return NewAlertResponse(*alert, h.timeProvider.Now()), nil
+}
@@ -0,0 +1,11 @@
+package alerting_application
+
+const FindAlertRuleQueryName = "FindAlertRuleQuery"
+
+type FindAlertRuleQuery struct {
+	ID string
+}
+
+func (q FindAlertRuleQuery) Type() string {
+	return FindAlertRuleQueryName
+}
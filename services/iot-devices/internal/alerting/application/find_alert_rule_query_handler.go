@@ -0,0 +1,34 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindAlertRuleQueryHandler struct {
+	repository alerting_domain.AlertRuleRepository
+}
+
+func NewFindAlertRuleQueryHandler(repository alerting_domain.AlertRuleRepository) *FindAlertRuleQueryHandler {
+	return &FindAlertRuleQueryHandler{repository: repository}
+}
+
+func (h FindAlertRuleQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindAlertRuleQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	rule, err := h.repository.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, alerting_domain.NewAlertRuleNotExists(q.ID)
+	}
+
+	return NewAlertRuleResponse(*rule), nil
+}
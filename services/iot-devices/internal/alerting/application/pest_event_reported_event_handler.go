@@ -0,0 +1,33 @@
+package alerting_application
+
+import (
+	"context"
+	"time"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+// PestEventReportedEventHandler feeds the alert rule engine with the metrics every pest
+// event stands for, at the time the event occurred.
+type PestEventReportedEventHandler struct {
+	engine *AlertRuleEngine
+}
+
+func NewPestEventReportedEventHandler(engine *AlertRuleEngine) *PestEventReportedEventHandler {
+	return &PestEventReportedEventHandler{engine: engine}
+}
+
+func (h PestEventReportedEventHandler) Handle(event amf_bus.Event) error {
+	data := event.Data()
+
+	deviceID, _ := data["device_id"].(string)
+	rawOccurredAt, _ := data["occurred_at"].(string)
+	occurredAt, err := time.Parse(time.RFC3339, rawOccurredAt)
+	metrics, ok := data["metrics"].(map[string]float64)
+	if err != nil || !ok {
+		return amf_bus.NewInvalidDto("invalid pest event reported")
+	}
+
+	return h.engine.Evaluate(amf_tenancy.WithAllTenants(context.Background()), deviceID, occurredAt, metrics)
+}
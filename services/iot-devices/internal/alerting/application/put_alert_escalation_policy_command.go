@@ -0,0 +1,15 @@
+package alerting_application
+
+import "time"
+
+const PutAlertEscalationPolicyCommandName = "PutAlertEscalationPolicyCommand"
+
+type PutAlertEscalationPolicyCommand struct {
+	TenantID      string
+	EscalateAfter time.Duration
+	Supervisor    string
+}
+
+func (c PutAlertEscalationPolicyCommand) Type() string {
+	return PutAlertEscalationPolicyCommandName
+}
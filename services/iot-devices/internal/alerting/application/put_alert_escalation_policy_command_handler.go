@@ -0,0 +1,33 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type PutAlertEscalationPolicyCommandHandler struct {
+	repository alerting_domain.AlertEscalationPolicyRepository
+}
+
+func NewPutAlertEscalationPolicyCommandHandler(
+	repository alerting_domain.AlertEscalationPolicyRepository,
+) *PutAlertEscalationPolicyCommandHandler {
+	return &PutAlertEscalationPolicyCommandHandler{repository: repository}
+}
+
+func (h PutAlertEscalationPolicyCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*PutAlertEscalationPolicyCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	policy, err := alerting_domain.NewAlertEscalationPolicy(cmd.TenantID, cmd.EscalateAfter, cmd.Supervisor)
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, policy)
+}
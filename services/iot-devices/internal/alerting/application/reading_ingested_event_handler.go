@@ -0,0 +1,48 @@
+package alerting_application
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+// ReadingIngestedEventHandler feeds the alert rule engine with every ingested reading but
+// the backfilled ones, which are too old to say anything about the device right now. The
+// pest metrics are left to the pest events they come from.
+type ReadingIngestedEventHandler struct {
+	engine *AlertRuleEngine
+}
+
+func NewReadingIngestedEventHandler(engine *AlertRuleEngine) *ReadingIngestedEventHandler {
+	return &ReadingIngestedEventHandler{engine: engine}
+}
+
+func (h ReadingIngestedEventHandler) Handle(event amf_bus.Event) error {
+	data := event.Data()
+	if backfilled, _ := data["backfilled"].(bool); backfilled {
+		return nil
+	}
+
+	deviceID, _ := data["device_id"].(string)
+	recordedAt, _ := data["recorded_at"].(time.Time)
+	metrics, ok := data["metrics"].(map[string]float64)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid reading ingested event")
+	}
+
+	metrics = maps.Clone(metrics)
+	maps.DeleteFunc(metrics, func(name string, _ float64) bool {
+		return slices.Contains(pestcontrol_domain.PestMetrics, name)
+	})
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	return h.engine.Evaluate(amf_tenancy.WithAllTenants(context.Background()), deviceID, recordedAt, metrics)
+}
@@ -0,0 +1,12 @@
+package alerting_application
+
+const ResolveAlertCommandName = "ResolveAlertCommand"
+
+type ResolveAlertCommand struct {
+	ID string
+	By string
+}
+
+func (c ResolveAlertCommand) Type() string {
+	return ResolveAlertCommandName
+}
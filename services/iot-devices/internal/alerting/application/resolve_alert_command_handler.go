@@ -0,0 +1,24 @@
+package alerting_application
+
+import (
+	"context"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type ResolveAlertCommandHandler struct {
+	lifecycle *AlertLifecycle
+}
+
+func NewResolveAlertCommandHandler(lifecycle *AlertLifecycle) *ResolveAlertCommandHandler {
+	return &ResolveAlertCommandHandler{lifecycle: lifecycle}
+}
+
+func (h ResolveAlertCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*ResolveAlertCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	return h.lifecycle.Resolve(ctx, cmd.ID, cmd.By)
+}
@@ -0,0 +1,11 @@
+package alerting_application
+
+const SearchAlertAuditTrailQueryName = "SearchAlertAuditTrailQuery"
+
+type SearchAlertAuditTrailQuery struct {
+	AlertID string
+}
+
+func (q SearchAlertAuditTrailQuery) Type() string {
+	return SearchAlertAuditTrailQueryName
+}
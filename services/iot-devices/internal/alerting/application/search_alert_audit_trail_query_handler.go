@@ -0,0 +1,48 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchAlertAuditTrailQueryHandler struct {
+	alerts     alerting_domain.AlertRepository
+	auditTrail alerting_domain.AlertAuditTrail
+}
+
+func NewSearchAlertAuditTrailQueryHandler(
+	alerts alerting_domain.AlertRepository,
+	auditTrail alerting_domain.AlertAuditTrail,
+) *SearchAlertAuditTrailQueryHandler {
+	return &SearchAlertAuditTrailQueryHandler{alerts: alerts, auditTrail: auditTrail}
+}
+
+func (h SearchAlertAuditTrailQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchAlertAuditTrailQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	alert, err := h.alerts.Find(ctx, q.AlertID)
+	if err != nil {
+		return nil, err
+	}
+	if alert == nil {
+		return nil, alerting_domain.NewAlertNotExists(q.AlertID)
+	}
+
+	entries, err := h.auditTrail.SearchByAlert(ctx, q.AlertID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*AlertAuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, NewAlertAuditEntryResponse(entry))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,9 @@
+package alerting_application
+
+const SearchAlertRulesQueryName = "SearchAlertRulesQuery"
+
+type SearchAlertRulesQuery struct{}
+
+func (q SearchAlertRulesQuery) Type() string {
+	return SearchAlertRulesQueryName
+}
@@ -0,0 +1,35 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchAlertRulesQueryHandler struct {
+	repository alerting_domain.AlertRuleRepository
+}
+
+func NewSearchAlertRulesQueryHandler(repository alerting_domain.AlertRuleRepository) *SearchAlertRulesQueryHandler {
+	return &SearchAlertRulesQueryHandler{repository: repository}
+}
+
+func (h SearchAlertRulesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	if _, ok := query.(*SearchAlertRulesQuery); !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	rules, err := h.repository.SearchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*AlertRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		response = append(response, NewAlertRuleResponse(rule))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,13 @@
+package alerting_application
+
+const SearchAlertsQueryName = "SearchAlertsQuery"
+
+type SearchAlertsQuery struct {
+	Status   string
+	DeviceID string
+	RuleID   string
+}
+
+func (q SearchAlertsQuery) Type() string {
+	return SearchAlertsQueryName
+}
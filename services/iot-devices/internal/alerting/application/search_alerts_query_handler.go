@@ -0,0 +1,43 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type SearchAlertsQueryHandler struct {
+	repository   alerting_domain.AlertRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewSearchAlertsQueryHandler(repository alerting_domain.AlertRepository, timeProvider amf_utils.DateTimeProvider) *SearchAlertsQueryHandler {
+	return &SearchAlertsQueryHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h SearchAlertsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchAlertsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	alerts, err := h.repository.Search(ctx, alerting_domain.AlertFilter{
+		Status:   alerting_domain.AlertStatus(q.Status),
+		DeviceID: q.DeviceID,
+		RuleID:   q.RuleID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.timeProvider.Now()
+	response := make([]*AlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		response = append(response, NewAlertResponse(alert, now))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,15 @@
+package alerting_application
+
+import "time"
+
+const SnoozeAlertCommandName = "SnoozeAlertCommand"
+
+type SnoozeAlertCommand struct {
+	ID    string
+	By    string
+	Until time.Time
+}
+
+func (c SnoozeAlertCommand) Type() string {
+	return SnoozeAlertCommandName
+}
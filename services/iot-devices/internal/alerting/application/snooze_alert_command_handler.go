@@ -0,0 +1,24 @@
+package alerting_application
+
+import (
+	"context"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SnoozeAlertCommandHandler struct {
+	lifecycle *AlertLifecycle
+}
+
+func NewSnoozeAlertCommandHandler(lifecycle *AlertLifecycle) *SnoozeAlertCommandHandler {
+	return &SnoozeAlertCommandHandler{lifecycle: lifecycle}
+}
+
+func (h SnoozeAlertCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*SnoozeAlertCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	return h.lifecycle.Snooze(ctx, cmd.ID, cmd.By, cmd.Until)
+}
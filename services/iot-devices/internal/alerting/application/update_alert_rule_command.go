@@ -0,0 +1,16 @@
+package alerting_application
+
+const UpdateAlertRuleCommandName = "UpdateAlertRuleCommand"
+
+// UpdateAlertRuleCommand only changes the attributes that are set.
+type UpdateAlertRuleCommand struct {
+	ID         string
+	Name       *string
+	Expression *string
+	DeviceID   *string
+	Enabled    *bool
+}
+
+func (c UpdateAlertRuleCommand) Type() string {
+	return UpdateAlertRuleCommandName
+}
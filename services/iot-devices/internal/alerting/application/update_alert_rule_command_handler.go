@@ -0,0 +1,70 @@
+package alerting_application
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type UpdateAlertRuleCommandHandler struct {
+	repository   alerting_domain.AlertRuleRepository
+	states       alerting_domain.AlertRuleStateRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewUpdateAlertRuleCommandHandler(
+	repository alerting_domain.AlertRuleRepository,
+	states alerting_domain.AlertRuleStateRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *UpdateAlertRuleCommandHandler {
+	return &UpdateAlertRuleCommandHandler{repository: repository, states: states, timeProvider: timeProvider}
+}
+
+// Handle forgets the device states of the rule when its condition changes, as they
+// were built evaluating a different condition.
+func (h UpdateAlertRuleCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*UpdateAlertRuleCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	rule, err := h.repository.Find(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+	if rule == nil {
+		return alerting_domain.NewAlertRuleNotExists(cmd.ID)
+	}
+
+	updated, err := rule.Update(
+		valueOrDefault(cmd.Name, rule.Name),
+		valueOrDefault(cmd.Expression, rule.Expression),
+		valueOrDefault(cmd.DeviceID, rule.DeviceID),
+		valueOrDefault(cmd.Enabled, rule.Enabled),
+		h.timeProvider.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := h.repository.Save(ctx, updated); err != nil {
+		return err
+	}
+
+	if updated.Expression == rule.Expression {
+		return nil
+	}
+
+	return h.states.DeleteForRule(ctx, rule.ID)
+}
+
+func valueOrDefault[T any](value *T, defaultValue T) T {
+	if value == nil {
+		return defaultValue
+	}
+
+	return *value
+}
@@ -0,0 +1,145 @@
+package alerting_domain
+
+import "time"
+
+type AlertStatus string
+
+const (
+	OpenAlert         AlertStatus = "open"
+	AcknowledgedAlert AlertStatus = "acknowledged"
+	SnoozedAlert      AlertStatus = "snoozed"
+	ResolvedAlert     AlertStatus = "resolved"
+)
+
+func (as AlertStatus) Value() string {
+	return string(as)
+}
+
+// Alert is raised the first time a rule matches the readings of a device and stays
+// active until somebody resolves it. Later triggers of the same rule and device are
+// counted as occurrences of the active alert instead of raising new ones.
+type Alert struct {
+	ID              string
+	TenantID        string
+	RuleID          string
+	RuleName        string
+	DeviceID        string
+	Status          AlertStatus
+	Value           float64
+	Occurrences     int
+	RaisedAt        time.Time
+	LastTriggeredAt time.Time
+	ClearedAt       *time.Time
+	AcknowledgedBy  string
+	AcknowledgedAt  *time.Time
+	SnoozedUntil    *time.Time
+	ResolvedBy      string
+	ResolvedAt      *time.Time
+	EscalatedTo     string
+	EscalatedAt     *time.Time
+}
+
+func NewAlert(id string, tenantID string, ruleID string, ruleName string, deviceID string, value float64, at time.Time) Alert {
+	return Alert{
+		ID:              id,
+		TenantID:        tenantID,
+		RuleID:          ruleID,
+		RuleName:        ruleName,
+		DeviceID:        deviceID,
+		Status:          OpenAlert,
+		Value:           value,
+		Occurrences:     1,
+		RaisedAt:        at,
+		LastTriggeredAt: at,
+	}
+}
+
+// StatusAt reports snoozed alerts past their snooze as open again.
+func (a Alert) StatusAt(now time.Time) AlertStatus {
+	if a.Status == SnoozedAlert && a.SnoozedUntil != nil && !now.Before(*a.SnoozedUntil) {
+		return OpenAlert
+	}
+
+	return a.Status
+}
+
+func (a Alert) Retrigger(value float64, at time.Time) Alert {
+	a.Value = value
+	a.Occurrences++
+	a.LastTriggeredAt = at
+	a.ClearedAt = nil
+
+	return a
+}
+
+func (a Alert) Clear(at time.Time) Alert {
+	a.ClearedAt = &at
+
+	return a
+}
+
+func (a Alert) Acknowledge(by string, now time.Time) (Alert, error) {
+	if status := a.StatusAt(now); status != OpenAlert && status != SnoozedAlert {
+		return a, NewAlertTransitionNotAllowed(a.ID, status, AcknowledgedAlert)
+	}
+
+	a.Status = AcknowledgedAlert
+	a.AcknowledgedBy = by
+	a.AcknowledgedAt = &now
+	a.SnoozedUntil = nil
+
+	return a, nil
+}
+
+func (a Alert) Snooze(until time.Time, now time.Time) (Alert, error) {
+	if status := a.StatusAt(now); status == ResolvedAlert {
+		return a, NewAlertTransitionNotAllowed(a.ID, status, SnoozedAlert)
+	}
+
+	if !until.After(now) {
+		return a, NewInvalidAlertSnooze(a.ID, until)
+	}
+
+	a.Status = SnoozedAlert
+	a.SnoozedUntil = &until
+
+	return a, nil
+}
+
+func (a Alert) Resolve(by string, now time.Time) (Alert, error) {
+	if a.Status == ResolvedAlert {
+		return a, NewAlertTransitionNotAllowed(a.ID, a.Status, ResolvedAlert)
+	}
+
+	a.Status = ResolvedAlert
+	a.ResolvedBy = by
+	a.ResolvedAt = &now
+	a.SnoozedUntil = nil
+
+	return a, nil
+}
+
+// EscalationDueAt is when an alert nobody acknowledged has to be escalated. Snoozing an
+// alert postpones its escalation until the snooze is over.
+func (a Alert) EscalationDueAt(policy AlertEscalationPolicy) time.Time {
+	since := a.RaisedAt
+	if a.SnoozedUntil != nil && a.SnoozedUntil.After(since) {
+		since = *a.SnoozedUntil
+	}
+
+	return since.Add(policy.EscalateAfter)
+}
+
+func (a Alert) NeedsEscalation(policy AlertEscalationPolicy, now time.Time) bool {
+	return a.EscalatedAt == nil &&
+		a.StatusAt(now) == OpenAlert &&
+		policy.Supervisor != "" &&
+		!now.Before(a.EscalationDueAt(policy))
+}
+
+func (a Alert) Escalate(policy AlertEscalationPolicy, now time.Time) Alert {
+	a.EscalatedTo = policy.Supervisor
+	a.EscalatedAt = &now
+
+	return a
+}
@@ -0,0 +1,38 @@
+package alerting_domain
+
+import (
+	"context"
+	"time"
+)
+
+type AlertAuditAction string
+
+const (
+	OpenedAlertAuditAction       AlertAuditAction = "opened"
+	RetriggeredAlertAuditAction  AlertAuditAction = "retriggered"
+	ClearedAlertAuditAction      AlertAuditAction = "cleared"
+	AcknowledgedAlertAuditAction AlertAuditAction = "acknowledged"
+	SnoozedAlertAuditAction      AlertAuditAction = "snoozed"
+	ResolvedAlertAuditAction     AlertAuditAction = "resolved"
+	EscalatedAlertAuditAction    AlertAuditAction = "escalated"
+)
+
+const SystemAlertAuditActor = "system"
+
+type AlertAuditEntry struct {
+	ID      string
+	AlertID string
+	Action  AlertAuditAction
+	Actor   string
+	Details string
+	At      time.Time
+}
+
+func NewAlertAuditEntry(id string, alertID string, action AlertAuditAction, actor string, details string, at time.Time) AlertAuditEntry {
+	return AlertAuditEntry{ID: id, AlertID: alertID, Action: action, Actor: actor, Details: details, At: at}
+}
+
+type AlertAuditTrail interface {
+	Record(ctx context.Context, entry AlertAuditEntry) error
+	SearchByAlert(ctx context.Context, alertID string) ([]AlertAuditEntry, error)
+}
@@ -0,0 +1,147 @@
+package alerting_domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ConditionOperator string
+
+const (
+	LessThan           ConditionOperator = "<"
+	LessThanOrEqual    ConditionOperator = "<="
+	GreaterThan        ConditionOperator = ">"
+	GreaterThanOrEqual ConditionOperator = ">="
+	Equal              ConditionOperator = "=="
+	NotEqual           ConditionOperator = "!="
+	Outside            ConditionOperator = "outside"
+	Inside             ConditionOperator = "inside"
+)
+
+const (
+	deltaKeyword   = "delta"
+	forKeyword     = "for"
+	rangeSeparator = ".."
+)
+
+// AlertCondition is the parsed form of a rule expression:
+//
+//	<metric> [delta] <|<=|>|>=|==|!= <threshold> [for <duration>]
+//	<metric> [delta] outside|inside <min>..<max> [for <duration>]
+//
+// Delta conditions compare the difference with the previous reading of the device
+// instead of the reading itself, and duration conditions only match once they have
+// been matching for the whole duration.
+type AlertCondition struct {
+	Metric    string
+	Delta     bool
+	Operator  ConditionOperator
+	Threshold float64
+	Min       float64
+	Max       float64
+	For       time.Duration
+}
+
+func ParseAlertCondition(expression string) (AlertCondition, error) {
+	tokens := strings.Fields(expression)
+	if len(tokens) < 3 {
+		return AlertCondition{}, fmt.Errorf("expression must be <metric> [delta] <operator> <value> [for <duration>]")
+	}
+
+	condition := AlertCondition{Metric: tokens[0]}
+	position := 1
+
+	if tokens[position] == deltaKeyword {
+		condition.Delta = true
+		position++
+	}
+
+	if position+2 > len(tokens) {
+		return AlertCondition{}, fmt.Errorf("expression is missing the operator or its value")
+	}
+
+	operator, operand := ConditionOperator(tokens[position]), tokens[position+1]
+	switch operator {
+	case LessThan, LessThanOrEqual, GreaterThan, GreaterThanOrEqual, Equal, NotEqual:
+		threshold, err := strconv.ParseFloat(operand, 64)
+		if err != nil {
+			return AlertCondition{}, fmt.Errorf("%s is not a number", operand)
+		}
+		condition.Threshold = threshold
+	case Outside, Inside:
+		min, max, err := parseRange(operand)
+		if err != nil {
+			return AlertCondition{}, err
+		}
+		condition.Min, condition.Max = min, max
+	default:
+		return AlertCondition{}, fmt.Errorf("%s is not a valid operator", operator)
+	}
+	condition.Operator = operator
+	position += 2
+
+	if position == len(tokens) {
+		return condition, nil
+	}
+
+	if tokens[position] != forKeyword || position+2 != len(tokens) {
+		return AlertCondition{}, fmt.Errorf("unexpected %s, only a trailing for <duration> is allowed", strings.Join(tokens[position:], " "))
+	}
+
+	duration, err := time.ParseDuration(tokens[position+1])
+	if err != nil || duration <= 0 {
+		return AlertCondition{}, fmt.Errorf("%s is not a valid duration", tokens[position+1])
+	}
+	condition.For = duration
+
+	return condition, nil
+}
+
+func parseRange(operand string) (float64, float64, error) {
+	bounds := strings.Split(operand, rangeSeparator)
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("%s is not a <min>..<max> range", operand)
+	}
+
+	min, minErr := strconv.ParseFloat(bounds[0], 64)
+	max, maxErr := strconv.ParseFloat(bounds[1], 64)
+	if minErr != nil || maxErr != nil || min > max {
+		return 0, 0, fmt.Errorf("%s is not a <min>..<max> range", operand)
+	}
+
+	return min, max, nil
+}
+
+// Matches reports whether a reading value satisfies the condition. Delta conditions
+// never match the first reading of a device, as there is nothing to compare it with.
+func (ac AlertCondition) Matches(value float64, previous *float64) bool {
+	if ac.Delta {
+		if previous == nil {
+			return false
+		}
+		value -= *previous
+	}
+
+	switch ac.Operator {
+	case LessThan:
+		return value < ac.Threshold
+	case LessThanOrEqual:
+		return value <= ac.Threshold
+	case GreaterThan:
+		return value > ac.Threshold
+	case GreaterThanOrEqual:
+		return value >= ac.Threshold
+	case Equal:
+		return value == ac.Threshold
+	case NotEqual:
+		return value != ac.Threshold
+	case Outside:
+		return value < ac.Min || value > ac.Max
+	case Inside:
+		return value >= ac.Min && value <= ac.Max
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+package alerting_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+)
+
+func TestParseAlertCondition(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   alerting_domain.AlertCondition
+	}{
+		{
+			expression: "battery_mv < 2400",
+			expected:   alerting_domain.AlertCondition{Metric: "battery_mv", Operator: alerting_domain.LessThan, Threshold: 2400},
+		},
+		{
+			expression: "catch_count delta > 0",
+			expected:   alerting_domain.AlertCondition{Metric: "catch_count", Delta: true, Operator: alerting_domain.GreaterThan},
+		},
+		{
+			expression: "temperature outside 2..8 for 30m",
+			expected: alerting_domain.AlertCondition{
+				Metric:   "temperature",
+				Operator: alerting_domain.Outside,
+				Min:      2,
+				Max:      8,
+				For:      30 * time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			condition, err := alerting_domain.ParseAlertCondition(tt.expression)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, condition)
+		})
+	}
+}
+
+func TestParseInvalidAlertCondition(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"battery_mv <",
+		"battery_mv ~ 2400",
+		"battery_mv < low",
+		"temperature outside 8..2",
+		"temperature outside 2-8",
+		"temperature outside 2..8 for",
+		"temperature outside 2..8 during 30m",
+		"temperature outside 2..8 for 30m now",
+		"temperature outside 2..8 for -30m",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := alerting_domain.ParseAlertCondition(expression)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestAlertConditionMatches(t *testing.T) {
+	previous := float64(3)
+
+	t.Run("compares the value with the threshold", func(t *testing.T) {
+		condition, _ := alerting_domain.ParseAlertCondition("battery_mv < 2400")
+
+		assert.True(t, condition.Matches(2399, nil))
+		assert.False(t, condition.Matches(2400, nil))
+	})
+
+	t.Run("compares the difference with the previous value", func(t *testing.T) {
+		condition, _ := alerting_domain.ParseAlertCondition("catch_count delta > 0")
+
+		assert.False(t, condition.Matches(4, nil))
+		assert.True(t, condition.Matches(4, &previous))
+		assert.False(t, condition.Matches(3, &previous))
+	})
+
+	t.Run("checks the value against the range", func(t *testing.T) {
+		outside, _ := alerting_domain.ParseAlertCondition("temperature outside 2..8")
+		inside, _ := alerting_domain.ParseAlertCondition("temperature inside 2..8")
+
+		assert.True(t, outside.Matches(8.5, nil))
+		assert.False(t, outside.Matches(8, nil))
+		assert.True(t, inside.Matches(2, nil))
+		assert.False(t, inside.Matches(1.9, nil))
+	})
+}
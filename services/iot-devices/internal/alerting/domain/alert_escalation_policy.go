@@ -0,0 +1,32 @@
+package alerting_domain
+
+import (
+	"context"
+	"time"
+)
+
+// AlertEscalationPolicy tells who to escalate the alerts of a tenant to when nobody
+// acknowledges them in time.
+type AlertEscalationPolicy struct {
+	TenantID      string
+	EscalateAfter time.Duration
+	Supervisor    string
+}
+
+func NewAlertEscalationPolicy(tenantID string, escalateAfter time.Duration, supervisor string) (AlertEscalationPolicy, error) {
+	if escalateAfter <= 0 {
+		return AlertEscalationPolicy{}, NewInvalidAlertEscalationPolicy(tenantID, "escalate_after", "must be positive")
+	}
+
+	if supervisor == "" {
+		return AlertEscalationPolicy{}, NewInvalidAlertEscalationPolicy(tenantID, "supervisor", "must be a non empty string")
+	}
+
+	return AlertEscalationPolicy{TenantID: tenantID, EscalateAfter: escalateAfter, Supervisor: supervisor}, nil
+}
+
+type AlertEscalationPolicyRepository interface {
+	Save(ctx context.Context, policy AlertEscalationPolicy) error
+	// Find returns nil when the tenant has no policy of its own
+	Find(ctx context.Context, tenantID string) (*AlertEscalationPolicy, error)
+}
@@ -0,0 +1,56 @@
+package alerting_domain
+
+import "time"
+
+const (
+	AlertRaisedEventName  = "alerting.alert_raised"
+	AlertClearedEventName = "alerting.alert_cleared"
+)
+
+type AlertRaised struct {
+	alertEvent
+}
+
+func NewAlertRaised(rule AlertRule, deviceID string, value float64, at time.Time) AlertRaised {
+	return AlertRaised{alertEvent{rule: rule, deviceID: deviceID, value: value, at: at}}
+}
+
+func (ar AlertRaised) Name() string {
+	return AlertRaisedEventName
+}
+
+type AlertCleared struct {
+	alertEvent
+}
+
+func NewAlertCleared(rule AlertRule, deviceID string, value float64, at time.Time) AlertCleared {
+	return AlertCleared{alertEvent{rule: rule, deviceID: deviceID, value: value, at: at}}
+}
+
+func (ac AlertCleared) Name() string {
+	return AlertClearedEventName
+}
+
+type alertEvent struct {
+	rule     AlertRule
+	deviceID string
+	value    float64
+	at       time.Time
+}
+
+func (ae alertEvent) Type() string {
+	return "domain_event"
+}
+
+func (ae alertEvent) Data() map[string]interface{} {
+	return map[string]interface{}{
+		"tenant_id":  ae.rule.TenantID,
+		"rule_id":    ae.rule.ID,
+		"rule_name":  ae.rule.Name,
+		"expression": ae.rule.Expression,
+		"metric":     ae.rule.Condition.Metric,
+		"device_id":  ae.deviceID,
+		"value":      ae.value,
+		"at":         ae.at,
+	}
+}
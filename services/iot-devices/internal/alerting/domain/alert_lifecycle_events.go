@@ -0,0 +1,53 @@
+package alerting_domain
+
+const (
+	AlertOpenedEventName       = "alerting.alert_opened"
+	AlertAcknowledgedEventName = "alerting.alert_acknowledged"
+	AlertResolvedEventName     = "alerting.alert_resolved"
+	AlertEscalatedEventName    = "alerting.alert_escalated"
+)
+
+// AlertLifecycleEvent announces a change in the life of an alert. Notifications hang
+// from these events rather than from every trigger of the rules.
+type AlertLifecycleEvent struct {
+	name  string
+	alert Alert
+}
+
+func NewAlertOpened(alert Alert) AlertLifecycleEvent {
+	return AlertLifecycleEvent{name: AlertOpenedEventName, alert: alert}
+}
+
+func NewAlertAcknowledged(alert Alert) AlertLifecycleEvent {
+	return AlertLifecycleEvent{name: AlertAcknowledgedEventName, alert: alert}
+}
+
+func NewAlertResolved(alert Alert) AlertLifecycleEvent {
+	return AlertLifecycleEvent{name: AlertResolvedEventName, alert: alert}
+}
+
+func NewAlertEscalated(alert Alert) AlertLifecycleEvent {
+	return AlertLifecycleEvent{name: AlertEscalatedEventName, alert: alert}
+}
+
+func (ale AlertLifecycleEvent) Name() string {
+	return ale.name
+}
+
+func (ale AlertLifecycleEvent) Type() string {
+	return "domain_event"
+}
+
+func (ale AlertLifecycleEvent) Data() map[string]interface{} {
+	return map[string]interface{}{
+		"alert_id":     ale.alert.ID,
+		"tenant_id":    ale.alert.TenantID,
+		"rule_id":      ale.alert.RuleID,
+		"rule_name":    ale.alert.RuleName,
+		"device_id":    ale.alert.DeviceID,
+		"status":       ale.alert.Status.Value(),
+		"value":        ale.alert.Value,
+		"raised_at":    ale.alert.RaisedAt,
+		"escalated_to": ale.alert.EscalatedTo,
+	}
+}
@@ -0,0 +1,24 @@
+package alerting_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const alertNotExistsErrorMessage = "Alert not exists"
+
+type AlertNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ane AlertNotExists) Error() string {
+	return alertNotExistsErrorMessage
+}
+
+func (ane AlertNotExists) ExtraItems() map[string]interface{} {
+	return ane.items
+}
+
+func NewAlertNotExists(id string) *AlertNotExists {
+	return &AlertNotExists{items: map[string]interface{}{"id": id}}
+}
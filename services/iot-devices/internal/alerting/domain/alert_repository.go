@@ -0,0 +1,19 @@
+package alerting_domain
+
+import "context"
+
+type AlertFilter struct {
+	Status   AlertStatus
+	DeviceID string
+	RuleID   string
+}
+
+type AlertRepository interface {
+	Save(ctx context.Context, alert Alert) error
+	// Find returns nil when the alert does not exist
+	Find(ctx context.Context, id string) (*Alert, error)
+	// FindActive returns the alert of the rule and device that is not resolved yet, if any
+	FindActive(ctx context.Context, ruleID string, deviceID string) (*Alert, error)
+	Search(ctx context.Context, filter AlertFilter) ([]Alert, error)
+	SearchPendingEscalation(ctx context.Context) ([]Alert, error)
+}
@@ -0,0 +1,111 @@
+package alerting_domain
+
+import (
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	ruleNameMaxLength     = 100
+	ruleDeviceIdMaxLength = 50
+)
+
+type AlertTransition int
+
+const (
+	NoAlertTransition AlertTransition = iota
+	AlertRaisedTransition
+	AlertClearedTransition
+)
+
+// AlertRule raises an alert for a device when its readings match the condition, and
+// clears it once they stop matching. Rules without a device apply to every device of
+// their tenant.
+type AlertRule struct {
+	ID         string
+	TenantID   string
+	Name       string
+	Expression string
+	Condition  AlertCondition
+	DeviceID   string
+	Enabled    bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewAlertRule(id string, tenantID string, name string, expression string, deviceID string, enabled bool, now time.Time) (AlertRule, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidAlertRule(id, "id", "must be a ULID")); err != nil {
+		return AlertRule{}, err
+	}
+
+	rule := AlertRule{ID: id, TenantID: tenantID, CreatedAt: now}
+
+	return rule.Update(name, expression, deviceID, enabled, now)
+}
+
+func (ar AlertRule) Update(name string, expression string, deviceID string, enabled bool, now time.Time) (AlertRule, error) {
+	nameValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(ruleNameMaxLength),
+	)
+	if err := nameValidator.Validate(name, NewInvalidAlertRule(ar.ID, "name", "must be a non empty string")); err != nil {
+		return ar, err
+	}
+
+	deviceIdValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(ruleDeviceIdMaxLength))
+	if err := deviceIdValidator.Validate(deviceID, NewInvalidAlertRule(ar.ID, "device_id", "is too long")); err != nil {
+		return ar, err
+	}
+
+	condition, err := ParseAlertCondition(expression)
+	if err != nil {
+		return ar, NewInvalidAlertRule(ar.ID, "expression", err.Error())
+	}
+
+	ar.Name = name
+	ar.Expression = expression
+	ar.Condition = condition
+	ar.DeviceID = deviceID
+	ar.Enabled = enabled
+	ar.UpdatedAt = now
+
+	return ar, nil
+}
+
+func (ar AlertRule) AppliesTo(deviceID string) bool {
+	return ar.Enabled && (ar.DeviceID == "" || ar.DeviceID == deviceID)
+}
+
+// Evaluate moves the state of the rule for a device with a new reading value. Readings
+// older than the last evaluated one leave the state untouched.
+func (ar AlertRule) Evaluate(state AlertRuleState, value float64, at time.Time) (AlertRuleState, AlertTransition) {
+	if state.LastEvaluatedAt != nil && at.Before(*state.LastEvaluatedAt) {
+		return state, NoAlertTransition
+	}
+
+	matches := ar.Condition.Matches(value, state.LastValue)
+	state.LastValue = &value
+	state.LastEvaluatedAt = &at
+
+	if !matches {
+		state.MatchingSince = nil
+		if state.Raised {
+			state.Raised = false
+			return state, AlertClearedTransition
+		}
+
+		return state, NoAlertTransition
+	}
+
+	if state.MatchingSince == nil {
+		state.MatchingSince = &at
+	}
+
+	if !state.Raised && at.Sub(*state.MatchingSince) >= ar.Condition.For {
+		state.Raised = true
+		return state, AlertRaisedTransition
+	}
+
+	return state, NoAlertTransition
+}
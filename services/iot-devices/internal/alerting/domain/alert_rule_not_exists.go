@@ -0,0 +1,24 @@
+package alerting_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const alertRuleNotExistsErrorMessage = "Alert rule not exists"
+
+type AlertRuleNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (rne AlertRuleNotExists) Error() string {
+	return alertRuleNotExistsErrorMessage
+}
+
+func (rne AlertRuleNotExists) ExtraItems() map[string]interface{} {
+	return rne.items
+}
+
+func NewAlertRuleNotExists(id string) *AlertRuleNotExists {
+	return &AlertRuleNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,20 @@
+package alerting_domain
+
+import "context"
+
+type AlertRuleRepository interface {
+	Save(ctx context.Context, rule AlertRule) error
+	// Find returns nil when the rule does not exist
+	Find(ctx context.Context, id string) (*AlertRule, error)
+	Delete(ctx context.Context, id string) error
+	SearchAll(ctx context.Context) ([]AlertRule, error)
+	// SearchEnabledFor returns the enabled rules of the tenant of the device
+	SearchEnabledFor(ctx context.Context, deviceID string) ([]AlertRule, error)
+}
+
+type AlertRuleStateRepository interface {
+	// Find returns an empty state when the rule has not been evaluated for the device yet
+	Find(ctx context.Context, ruleID string, deviceID string) (AlertRuleState, error)
+	Save(ctx context.Context, state AlertRuleState) error
+	DeleteForRule(ctx context.Context, ruleID string) error
+}
@@ -0,0 +1,17 @@
+package alerting_domain
+
+import "time"
+
+// AlertRuleState is what a rule remembers about a device between readings.
+type AlertRuleState struct {
+	RuleID          string     `json:"rule_id"`
+	DeviceID        string     `json:"device_id"`
+	Raised          bool       `json:"raised"`
+	MatchingSince   *time.Time `json:"matching_since"`
+	LastValue       *float64   `json:"last_value"`
+	LastEvaluatedAt *time.Time `json:"last_evaluated_at"`
+}
+
+func NewAlertRuleState(ruleID string, deviceID string) AlertRuleState {
+	return AlertRuleState{RuleID: ruleID, DeviceID: deviceID}
+}
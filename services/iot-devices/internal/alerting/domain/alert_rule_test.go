@@ -0,0 +1,120 @@
+package alerting_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestNewAlertRule(t *testing.T) {
+	id, now := amf_utils.NewUlid().String(), time.Now()
+
+	t.Run("should parse the expression", func(t *testing.T) {
+		rule, err := alerting_domain.NewAlertRule(id, "tenant-1", "Low battery", "battery_mv < 2400", "", true, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "battery_mv", rule.Condition.Metric)
+		assert.True(t, rule.AppliesTo("any-device"))
+	})
+
+	t.Run("should reject an invalid expression", func(t *testing.T) {
+		_, err := alerting_domain.NewAlertRule(id, "tenant-1", "Low battery", "battery_mv is low", "", true, now)
+
+		assert.IsType(t, &alerting_domain.InvalidAlertRule{}, err)
+	})
+
+	t.Run("should reject an empty name", func(t *testing.T) {
+		_, err := alerting_domain.NewAlertRule(id, "tenant-1", "", "battery_mv < 2400", "", true, now)
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+
+	t.Run("should only apply to its device while enabled", func(t *testing.T) {
+		rule, err := alerting_domain.NewAlertRule(id, "tenant-1", "Low battery", "battery_mv < 2400", "device-1", true, now)
+		require.NoError(t, err)
+
+		assert.True(t, rule.AppliesTo("device-1"))
+		assert.False(t, rule.AppliesTo("device-2"))
+
+		disabled, err := rule.Update(rule.Name, rule.Expression, rule.DeviceID, false, now)
+		require.NoError(t, err)
+
+		assert.False(t, disabled.AppliesTo("device-1"))
+	})
+}
+
+func TestAlertRuleEvaluate(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should raise and clear right away without duration", func(t *testing.T) {
+		rule := mustAlertRule(t, "battery_mv < 2400")
+		state := alerting_domain.NewAlertRuleState(rule.ID, "device-1")
+
+		state, transition := rule.Evaluate(state, 2300, now)
+		assert.Equal(t, alerting_domain.AlertRaisedTransition, transition)
+
+		state, transition = rule.Evaluate(state, 2200, now.Add(time.Minute))
+		assert.Equal(t, alerting_domain.NoAlertTransition, transition)
+
+		_, transition = rule.Evaluate(state, 2500, now.Add(2*time.Minute))
+		assert.Equal(t, alerting_domain.AlertClearedTransition, transition)
+	})
+
+	t.Run("should raise once the condition matched for the whole duration", func(t *testing.T) {
+		rule := mustAlertRule(t, "temperature outside 2..8 for 30m")
+		state := alerting_domain.NewAlertRuleState(rule.ID, "device-1")
+
+		state, transition := rule.Evaluate(state, 9, now)
+		assert.Equal(t, alerting_domain.NoAlertTransition, transition)
+
+		state, transition = rule.Evaluate(state, 9, now.Add(20*time.Minute))
+		assert.Equal(t, alerting_domain.NoAlertTransition, transition)
+
+		state, transition = rule.Evaluate(state, 5, now.Add(25*time.Minute))
+		assert.Equal(t, alerting_domain.NoAlertTransition, transition)
+		assert.Nil(t, state.MatchingSince)
+
+		state, _ = rule.Evaluate(state, 10, now.Add(30*time.Minute))
+		_, transition = rule.Evaluate(state, 10, now.Add(60*time.Minute))
+		assert.Equal(t, alerting_domain.AlertRaisedTransition, transition)
+	})
+
+	t.Run("should compare delta conditions with the previous reading", func(t *testing.T) {
+		rule := mustAlertRule(t, "catch_count delta > 0")
+		state := alerting_domain.NewAlertRuleState(rule.ID, "device-1")
+
+		state, transition := rule.Evaluate(state, 3, now)
+		assert.Equal(t, alerting_domain.NoAlertTransition, transition)
+
+		state, transition = rule.Evaluate(state, 4, now.Add(time.Minute))
+		assert.Equal(t, alerting_domain.AlertRaisedTransition, transition)
+
+		_, transition = rule.Evaluate(state, 4, now.Add(2*time.Minute))
+		assert.Equal(t, alerting_domain.AlertClearedTransition, transition)
+	})
+
+	t.Run("should ignore readings older than the last evaluated one", func(t *testing.T) {
+		rule := mustAlertRule(t, "battery_mv < 2400")
+		state := alerting_domain.NewAlertRuleState(rule.ID, "device-1")
+
+		state, _ = rule.Evaluate(state, 2500, now)
+		newState, transition := rule.Evaluate(state, 2300, now.Add(-time.Minute))
+
+		assert.Equal(t, alerting_domain.NoAlertTransition, transition)
+		assert.Equal(t, state, newState)
+	})
+}
+
+func mustAlertRule(t *testing.T, expression string) alerting_domain.AlertRule {
+	rule, err := alerting_domain.NewAlertRule(amf_utils.NewUlid().String(), "tenant-1", "rule", expression, "", true, time.Now())
+	require.NoError(t, err)
+
+	return rule
+}
@@ -0,0 +1,82 @@
+package alerting_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+)
+
+func TestAlertLifecycle(t *testing.T) {
+	raisedAt := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	alert := alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, raisedAt)
+
+	t.Run("should count retriggers as occurrences of the same alert", func(t *testing.T) {
+		retriggered := alert.Clear(raisedAt.Add(time.Minute)).Retrigger(2200, raisedAt.Add(time.Hour))
+
+		assert.Equal(t, 2, retriggered.Occurrences)
+		assert.Equal(t, 2200.0, retriggered.Value)
+		assert.Nil(t, retriggered.ClearedAt)
+		assert.Equal(t, alerting_domain.OpenAlert, retriggered.Status)
+	})
+
+	t.Run("should report an expired snooze as open", func(t *testing.T) {
+		snoozed, err := alert.Snooze(raisedAt.Add(time.Hour), raisedAt)
+		require.NoError(t, err)
+
+		assert.Equal(t, alerting_domain.SnoozedAlert, snoozed.StatusAt(raisedAt.Add(time.Minute)))
+		assert.Equal(t, alerting_domain.OpenAlert, snoozed.StatusAt(raisedAt.Add(time.Hour)))
+	})
+
+	t.Run("should reject a snooze that is not in the future", func(t *testing.T) {
+		_, err := alert.Snooze(raisedAt, raisedAt)
+
+		assert.IsType(t, &alerting_domain.InvalidAlertSnooze{}, err)
+	})
+
+	t.Run("should not acknowledge or resolve a resolved alert", func(t *testing.T) {
+		resolved, err := alert.Resolve("alice", raisedAt)
+		require.NoError(t, err)
+
+		_, err = resolved.Acknowledge("bob", raisedAt)
+		assert.IsType(t, &alerting_domain.AlertTransitionNotAllowed{}, err)
+
+		_, err = resolved.Resolve("bob", raisedAt)
+		assert.IsType(t, &alerting_domain.AlertTransitionNotAllowed{}, err)
+	})
+}
+
+func TestAlertEscalation(t *testing.T) {
+	raisedAt := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	alert := alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, raisedAt)
+	policy := alerting_domain.AlertEscalationPolicy{EscalateAfter: 30 * time.Minute, Supervisor: "supervisor@example.com"}
+
+	t.Run("should escalate open alerts once the policy delay is over", func(t *testing.T) {
+		assert.False(t, alert.NeedsEscalation(policy, raisedAt.Add(29*time.Minute)))
+		assert.True(t, alert.NeedsEscalation(policy, raisedAt.Add(30*time.Minute)))
+	})
+
+	t.Run("should not escalate acknowledged or already escalated alerts", func(t *testing.T) {
+		acknowledged, err := alert.Acknowledge("alice", raisedAt.Add(time.Minute))
+		require.NoError(t, err)
+		escalated := alert.Escalate(policy, raisedAt.Add(time.Hour))
+
+		assert.False(t, acknowledged.NeedsEscalation(policy, raisedAt.Add(time.Hour)))
+		assert.False(t, escalated.NeedsEscalation(policy, raisedAt.Add(2*time.Hour)))
+	})
+
+	t.Run("should postpone escalation until the snooze is over", func(t *testing.T) {
+		snoozed, err := alert.Snooze(raisedAt.Add(time.Hour), raisedAt.Add(time.Minute))
+		require.NoError(t, err)
+
+		assert.False(t, snoozed.NeedsEscalation(policy, raisedAt.Add(80*time.Minute)))
+		assert.True(t, snoozed.NeedsEscalation(policy, raisedAt.Add(90*time.Minute)))
+	})
+
+	t.Run("should not escalate without a supervisor", func(t *testing.T) {
+		assert.False(t, alert.NeedsEscalation(alerting_domain.AlertEscalationPolicy{EscalateAfter: time.Minute}, raisedAt.Add(time.Hour)))
+	})
+}
@@ -0,0 +1,24 @@
+package alerting_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const alertTransitionNotAllowedErrorMessage = "Alert transition not allowed"
+
+type AlertTransitionNotAllowed struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (atna AlertTransitionNotAllowed) Error() string {
+	return alertTransitionNotAllowedErrorMessage
+}
+
+func (atna AlertTransitionNotAllowed) ExtraItems() map[string]interface{} {
+	return atna.items
+}
+
+func NewAlertTransitionNotAllowed(id string, from AlertStatus, to AlertStatus) *AlertTransitionNotAllowed {
+	return &AlertTransitionNotAllowed{items: map[string]interface{}{"id": id, "from": from.Value(), "to": to.Value()}}
+}
@@ -0,0 +1,24 @@
+package alerting_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidAlertEscalationPolicyErrorMessage = "Invalid alert escalation policy"
+
+type InvalidAlertEscalationPolicy struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (iaep InvalidAlertEscalationPolicy) Error() string {
+	return invalidAlertEscalationPolicyErrorMessage
+}
+
+func (iaep InvalidAlertEscalationPolicy) ExtraItems() map[string]interface{} {
+	return iaep.items
+}
+
+func NewInvalidAlertEscalationPolicy(tenantID string, field string, reason string) *InvalidAlertEscalationPolicy {
+	return &InvalidAlertEscalationPolicy{items: map[string]interface{}{"tenant_id": tenantID, "field": field, "reason": reason}}
+}
@@ -0,0 +1,24 @@
+package alerting_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidAlertRuleErrorMessage = "Invalid alert rule"
+
+type InvalidAlertRule struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (iar InvalidAlertRule) Error() string {
+	return invalidAlertRuleErrorMessage
+}
+
+func (iar InvalidAlertRule) ExtraItems() map[string]interface{} {
+	return iar.items
+}
+
+func NewInvalidAlertRule(id string, field string, reason string) *InvalidAlertRule {
+	return &InvalidAlertRule{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
@@ -0,0 +1,26 @@
+package alerting_domain
+
+import (
+	"time"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidAlertSnoozeErrorMessage = "Invalid alert snooze"
+
+type InvalidAlertSnooze struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ias InvalidAlertSnooze) Error() string {
+	return invalidAlertSnoozeErrorMessage
+}
+
+func (ias InvalidAlertSnooze) ExtraItems() map[string]interface{} {
+	return ias.items
+}
+
+func NewInvalidAlertSnooze(id string, until time.Time) *InvalidAlertSnooze {
+	return &InvalidAlertSnooze{items: map[string]interface{}{"id": id, "until": until.Format(time.RFC3339), "reason": "must be in the future"}}
+}
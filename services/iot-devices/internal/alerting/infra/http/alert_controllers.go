@@ -0,0 +1,229 @@
+package alerting_http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	alerting_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/application"
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+)
+
+func NewGetAlertsController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filters := r.URL.Query()
+		query := &alerting_application.SearchAlertsQuery{
+			Status:   filters.Get("filter[status]"),
+			DeviceID: filters.Get("filter[device_id]"),
+			RuleID:   filters.Get("filter[rule_id]"),
+		}
+
+		queryResponse, err := queryBus.Ask(r.Context(), query)
+		if err != nil {
+			writeAlertError(w, r, jarm, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	}
+}
+
+func NewGetAlertController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeAlert(w, r, queryBus, jarm, mux.Vars(r)["alertId"])
+	}
+}
+
+func NewAcknowledgeAlertController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return newAlertTransitionController(commandBus, queryBus, jarm, func(id string, requestParams map[string]interface{}) (amf_bus.Dto, error) {
+		return &alerting_application.AcknowledgeAlertCommand{ID: id, By: stringAttribute(requestParams, "actor")}, nil
+	})
+}
+
+func NewSnoozeAlertController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return newAlertTransitionController(commandBus, queryBus, jarm, func(id string, requestParams map[string]interface{}) (amf_bus.Dto, error) {
+		until, err := time.Parse(time.RFC3339, stringAttribute(requestParams, "until"))
+		if err != nil {
+			return nil, err
+		}
+
+		return &alerting_application.SnoozeAlertCommand{ID: id, By: stringAttribute(requestParams, "actor"), Until: until}, nil
+	})
+}
+
+func NewResolveAlertController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return newAlertTransitionController(commandBus, queryBus, jarm, func(id string, requestParams map[string]interface{}) (amf_bus.Dto, error) {
+		return &alerting_application.ResolveAlertCommand{ID: id, By: stringAttribute(requestParams, "actor")}, nil
+	})
+}
+
+func NewGetAlertAuditTrailController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &alerting_application.SearchAlertAuditTrailQuery{AlertID: mux.Vars(r)["alertId"]}
+
+		queryResponse, err := queryBus.Ask(r.Context(), query)
+		if err != nil {
+			writeAlertError(w, r, jarm, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	}
+}
+
+func NewGetAlertEscalationPolicyController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeAlertEscalationPolicy(w, r, queryBus, jarm, mux.Vars(r)["tenantId"])
+	}
+}
+
+func NewPutAlertEscalationPolicyController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		command := &alerting_application.PutAlertEscalationPolicyCommand{
+			TenantID:      mux.Vars(r)["tenantId"],
+			EscalateAfter: time.Duration(numberAttribute(requestParams, "escalate_after_seconds")) * time.Second,
+			Supervisor:    stringAttribute(requestParams, "supervisor"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeAlertError(w, r, jarm, err)
+			return
+		}
+
+		writeAlertEscalationPolicy(w, r, queryBus, jarm, command.TenantID)
+	}
+}
+
+// newAlertTransitionController builds the controllers that move an alert through its
+// lifecycle and answer with the alert as it is afterwards.
+func newAlertTransitionController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	commandFrom func(id string, requestParams map[string]interface{}) (amf_bus.Dto, error),
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		id := mux.Vars(r)["alertId"]
+		command, err := commandFrom(id, requestParams)
+		if err != nil {
+			ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequest(err.Error())
+			jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeAlertError(w, r, jarm, err)
+			return
+		}
+
+		writeAlert(w, r, queryBus, jarm, id)
+	}
+}
+
+func writeAlert(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	id string,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), &alerting_application.FindAlertQuery{ID: id})
+	if err != nil {
+		writeAlertError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+}
+
+func writeAlertEscalationPolicy(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	tenantID string,
+) {
+	query := &alerting_application.FindAlertEscalationPolicyQuery{TenantID: tenantID}
+
+	queryResponse, err := queryBus.Ask(r.Context(), query)
+	if err != nil {
+		writeAlertError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+}
+
+func writeAlertError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *alerting_domain.AlertNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *alerting_domain.AlertTransitionNotAllowed:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewConflictWithDetails(
+			err.Error(),
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusConflict, err)
+	case *alerting_domain.InvalidAlertSnooze:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *alerting_domain.InvalidAlertEscalationPolicy:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		writeInternalServerError(w, r, jarm, err)
+	}
+}
@@ -0,0 +1,201 @@
+package alerting_http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	alerting_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/application"
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func NewCreateAlertRuleController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		command := &alerting_application.CreateAlertRuleCommand{
+			ID:         ulidProvider.New().String(),
+			TenantID:   tenantID,
+			Name:       stringAttribute(requestParams, "name"),
+			Expression: stringAttribute(requestParams, "expression"),
+			DeviceID:   stringAttribute(requestParams, "device_id"),
+			Enabled:    amf_utils.Val(boolAttribute(requestParams, "enabled", amf_utils.Ptr(true))),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeAlertRuleError(w, r, jarm, err)
+			return
+		}
+
+		writeAlertRule(w, r, queryBus, jarm, command.ID, http.StatusCreated)
+	}
+}
+
+func NewUpdateAlertRuleController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		command := &alerting_application.UpdateAlertRuleCommand{
+			ID:         mux.Vars(r)["alertRuleId"],
+			Name:       optionalStringAttribute(requestParams, "name"),
+			Expression: optionalStringAttribute(requestParams, "expression"),
+			DeviceID:   optionalStringAttribute(requestParams, "device_id"),
+			Enabled:    boolAttribute(requestParams, "enabled", nil),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeAlertRuleError(w, r, jarm, err)
+			return
+		}
+
+		writeAlertRule(w, r, queryBus, jarm, command.ID, http.StatusOK)
+	}
+}
+
+func NewDeleteAlertRuleController(
+	commandBus amf_command_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		command := &alerting_application.DeleteAlertRuleCommand{ID: mux.Vars(r)["alertRuleId"]}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeAlertRuleError(w, r, jarm, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, nil, http.StatusNoContent)
+	}
+}
+
+func NewGetAlertRuleController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeAlertRule(w, r, queryBus, jarm, mux.Vars(r)["alertRuleId"], http.StatusOK)
+	}
+}
+
+func NewGetAlertRulesController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		queryResponse, err := queryBus.Ask(r.Context(), &alerting_application.SearchAlertRulesQuery{})
+		if err != nil {
+			writeAlertRuleError(w, r, jarm, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	}
+}
+
+func writeAlertRule(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	id string,
+	statusCode int,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), &alerting_application.FindAlertRuleQuery{ID: id})
+	if err != nil {
+		writeAlertRuleError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, statusCode)
+}
+
+func writeAlertRuleError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *alerting_domain.AlertRuleNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *alerting_domain.InvalidAlertRule:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		writeInternalServerError(w, r, jarm, err)
+	}
+}
+
+func writeInternalServerError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+	jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+}
+
+func stringAttribute(requestParams map[string]interface{}, attribute string) string {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, "").(string)
+
+	return value
+}
+
+func optionalStringAttribute(requestParams map[string]interface{}, attribute string) *string {
+	value, ok := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, nil).(string)
+	if !ok {
+		return nil
+	}
+
+	return &value
+}
+
+func numberAttribute(requestParams map[string]interface{}, attribute string) float64 {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, float64(0)).(float64)
+
+	return value
+}
+
+func boolAttribute(requestParams map[string]interface{}, attribute string, defaultValue *bool) *bool {
+	value, ok := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, nil).(bool)
+	if !ok {
+		return defaultValue
+	}
+
+	return &value
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
@@ -0,0 +1,72 @@
+package alerting_infra
+
+import (
+	"context"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	recordAlertAuditEntryQuery = `
+INSERT INTO alert_audit_entries (id, alert_id, action, actor, details, at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (id) DO NOTHING`
+	searchAlertAuditEntriesQuery = `
+SELECT id, alert_id, action, actor, details, at
+FROM alert_audit_entries
+WHERE alert_id = $1
+  AND ($2::VARCHAR IS NULL OR alert_id IN (SELECT id FROM alerts WHERE tenant_id = $2))
+ORDER BY at, id`
+)
+
+type PostgresAlertAuditTrail struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresAlertAuditTrail(connectionPool amf_sqldb.ConnectionPool) *PostgresAlertAuditTrail {
+	return &PostgresAlertAuditTrail{connectionPool: connectionPool}
+}
+
+func (t *PostgresAlertAuditTrail) Record(ctx context.Context, entry alerting_domain.AlertAuditEntry) error {
+	_, err := t.connectionPool.Writer().ExecContext(
+		ctx,
+		recordAlertAuditEntryQuery,
+		entry.ID,
+		entry.AlertID,
+		string(entry.Action),
+		entry.Actor,
+		entry.Details,
+		entry.At.UTC(),
+	)
+
+	return err
+}
+
+func (t *PostgresAlertAuditTrail) SearchByAlert(ctx context.Context, alertID string) ([]alerting_domain.AlertAuditEntry, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := t.connectionPool.Reader().QueryContext(ctx, searchAlertAuditEntriesQuery, alertID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	entries := make([]alerting_domain.AlertAuditEntry, 0)
+	for rows.Next() {
+		var entry alerting_domain.AlertAuditEntry
+		var action string
+		if err := rows.Scan(&entry.ID, &entry.AlertID, &action, &entry.Actor, &entry.Details, &entry.At); err != nil {
+			return nil, err
+		}
+		entry.Action = alerting_domain.AlertAuditAction(action)
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,72 @@
+package alerting_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	upsertAlertEscalationPolicyQuery = `
+INSERT INTO alert_escalation_policies (tenant_id, escalate_after_seconds, supervisor)
+VALUES ($1, $2, $3)
+ON CONFLICT (tenant_id) DO UPDATE SET
+    escalate_after_seconds = EXCLUDED.escalate_after_seconds,
+    supervisor = EXCLUDED.supervisor`
+	findAlertEscalationPolicyQuery = `
+SELECT tenant_id, escalate_after_seconds, supervisor FROM alert_escalation_policies
+WHERE tenant_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+)
+
+type PostgresAlertEscalationPolicyRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresAlertEscalationPolicyRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresAlertEscalationPolicyRepository {
+	return &PostgresAlertEscalationPolicyRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresAlertEscalationPolicyRepository) Save(ctx context.Context, policy alerting_domain.AlertEscalationPolicy) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, policy.TenantID); err != nil {
+		return err
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertAlertEscalationPolicyQuery,
+		policy.TenantID,
+		int64(policy.EscalateAfter.Seconds()),
+		policy.Supervisor,
+	)
+
+	return err
+}
+
+func (r *PostgresAlertEscalationPolicyRepository) Find(ctx context.Context, tenantID string) (*alerting_domain.AlertEscalationPolicy, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy alerting_domain.AlertEscalationPolicy
+	var escalateAfterSeconds int64
+
+	err = r.connectionPool.Reader().
+		QueryRowContext(ctx, findAlertEscalationPolicyQuery, tenantID, scope).
+		Scan(&policy.TenantID, &escalateAfterSeconds, &policy.Supervisor)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	policy.EscalateAfter = time.Duration(escalateAfterSeconds) * time.Second
+
+	return &policy, nil
+}
@@ -0,0 +1,236 @@
+package alerting_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	alertColumns = `id, tenant_id, rule_id, rule_name, device_id, status, value, occurrences, raised_at, last_triggered_at,
+cleared_at, acknowledged_by, acknowledged_at, snoozed_until, resolved_by, resolved_at, escalated_to, escalated_at`
+
+	upsertAlertQuery = `
+INSERT INTO alerts (` + alertColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+ON CONFLICT (id) DO UPDATE SET
+    status = EXCLUDED.status,
+    value = EXCLUDED.value,
+    occurrences = EXCLUDED.occurrences,
+    last_triggered_at = EXCLUDED.last_triggered_at,
+    cleared_at = EXCLUDED.cleared_at,
+    acknowledged_by = EXCLUDED.acknowledged_by,
+    acknowledged_at = EXCLUDED.acknowledged_at,
+    snoozed_until = EXCLUDED.snoozed_until,
+    resolved_by = EXCLUDED.resolved_by,
+    resolved_at = EXCLUDED.resolved_at,
+    escalated_to = EXCLUDED.escalated_to,
+    escalated_at = EXCLUDED.escalated_at
+WHERE alerts.tenant_id = EXCLUDED.tenant_id`
+	findAlertQuery = `SELECT ` + alertColumns + ` FROM alerts
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	findActiveAlertQuery = `SELECT ` + alertColumns + ` FROM alerts
+WHERE rule_id = $1 AND device_id = $2 AND status <> 'resolved' AND ($3::VARCHAR IS NULL OR tenant_id = $3)`
+	searchAlertsQuery = `SELECT ` + alertColumns + ` FROM alerts
+WHERE ($1::VARCHAR IS NULL OR tenant_id = $1)`
+
+	searchAlertsPendingEscalationQuery = `SELECT ` + alertColumns + ` FROM alerts
+WHERE status IN ('open', 'snoozed') AND escalated_at IS NULL AND ($1::VARCHAR IS NULL OR tenant_id = $1)
+ORDER BY raised_at, id`
+)
+
+type PostgresAlertRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresAlertRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresAlertRepository {
+	return &PostgresAlertRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresAlertRepository) Save(ctx context.Context, alert alerting_domain.Alert) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, alert.TenantID); err != nil {
+		return err
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertAlertQuery,
+		alert.ID,
+		alert.TenantID,
+		alert.RuleID,
+		alert.RuleName,
+		alert.DeviceID,
+		alert.Status.Value(),
+		alert.Value,
+		alert.Occurrences,
+		alert.RaisedAt.UTC(),
+		alert.LastTriggeredAt.UTC(),
+		nullTime(alert.ClearedAt),
+		nullString(alert.AcknowledgedBy),
+		nullTime(alert.AcknowledgedAt),
+		nullTime(alert.SnoozedUntil),
+		nullString(alert.ResolvedBy),
+		nullTime(alert.ResolvedAt),
+		nullString(alert.EscalatedTo),
+		nullTime(alert.EscalatedAt),
+	)
+
+	return err
+}
+
+func (r *PostgresAlertRepository) Find(ctx context.Context, id string) (*alerting_domain.Alert, error) {
+	return r.find(ctx, findAlertQuery, id)
+}
+
+func (r *PostgresAlertRepository) FindActive(ctx context.Context, ruleID string, deviceID string) (*alerting_domain.Alert, error) {
+	return r.find(ctx, findActiveAlertQuery, ruleID, deviceID)
+}
+
+func (r *PostgresAlertRepository) Search(ctx context.Context, filter alerting_domain.AlertFilter) ([]alerting_domain.Alert, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := make([]string, 0, 3)
+	args := []any{scope}
+	addCondition := func(column string, value string) {
+		if value == "" {
+			return
+		}
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	addCondition("status", filter.Status.Value())
+	addCondition("device_id", filter.DeviceID)
+	addCondition("rule_id", filter.RuleID)
+
+	query := searchAlertsQuery
+	if len(conditions) > 0 {
+		query += ` AND ` + strings.Join(conditions, ` AND `)
+	}
+
+	return r.query(ctx, query+` ORDER BY raised_at DESC, id`, args...)
+}
+
+func (r *PostgresAlertRepository) SearchPendingEscalation(ctx context.Context) ([]alerting_domain.Alert, error) {
+	return r.search(ctx, searchAlertsPendingEscalationQuery)
+}
+
+// find binds the scope after the args of the query.
+func (r *PostgresAlertRepository) find(ctx context.Context, query string, args ...any) (*alerting_domain.Alert, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alert, err := scanAlert(r.connectionPool.Reader().QueryRowContext(ctx, query, append(args, scope)...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
+
+func (r *PostgresAlertRepository) search(ctx context.Context, query string, args ...any) ([]alerting_domain.Alert, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.query(ctx, query, append(args, scope)...)
+}
+
+func (r *PostgresAlertRepository) query(ctx context.Context, query string, args ...any) ([]alerting_domain.Alert, error) {
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	alerts := make([]alerting_domain.Alert, 0)
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, rows.Err()
+}
+
+func scanAlert(row rowScanner) (alerting_domain.Alert, error) {
+	var alert alerting_domain.Alert
+	var status string
+	var acknowledgedBy, resolvedBy, escalatedTo sql.NullString
+	var clearedAt, acknowledgedAt, snoozedUntil, resolvedAt, escalatedAt sql.NullTime
+
+	err := row.Scan(
+		&alert.ID,
+		&alert.TenantID,
+		&alert.RuleID,
+		&alert.RuleName,
+		&alert.DeviceID,
+		&status,
+		&alert.Value,
+		&alert.Occurrences,
+		&alert.RaisedAt,
+		&alert.LastTriggeredAt,
+		&clearedAt,
+		&acknowledgedBy,
+		&acknowledgedAt,
+		&snoozedUntil,
+		&resolvedBy,
+		&resolvedAt,
+		&escalatedTo,
+		&escalatedAt,
+	)
+	if err != nil {
+		return alerting_domain.Alert{}, err
+	}
+
+	alert.Status = alerting_domain.AlertStatus(status)
+	alert.ClearedAt = timePointer(clearedAt)
+	alert.AcknowledgedBy = acknowledgedBy.String
+	alert.AcknowledgedAt = timePointer(acknowledgedAt)
+	alert.SnoozedUntil = timePointer(snoozedUntil)
+	alert.ResolvedBy = resolvedBy.String
+	alert.ResolvedAt = timePointer(resolvedAt)
+	alert.EscalatedTo = escalatedTo.String
+	alert.EscalatedAt = timePointer(escalatedAt)
+
+	return alert, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: value.UTC(), Valid: true}
+}
+
+func timePointer(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Time
+}
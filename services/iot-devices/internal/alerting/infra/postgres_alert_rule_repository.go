@@ -0,0 +1,156 @@
+package alerting_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	alertRuleColumns = `id, tenant_id, name, expression, device_id, enabled, created_at, updated_at`
+
+	upsertAlertRuleQuery = `
+INSERT INTO alert_rules (` + alertRuleColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (id) DO UPDATE SET
+    name = EXCLUDED.name,
+    expression = EXCLUDED.expression,
+    device_id = EXCLUDED.device_id,
+    enabled = EXCLUDED.enabled,
+    updated_at = EXCLUDED.updated_at
+WHERE alert_rules.tenant_id = EXCLUDED.tenant_id`
+	findAlertRuleQuery = `
+SELECT ` + alertRuleColumns + ` FROM alert_rules
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	deleteAlertRuleQuery = `
+DELETE FROM alert_rules
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchAllAlertRulesQuery = `
+SELECT ` + alertRuleColumns + ` FROM alert_rules
+WHERE ($1::VARCHAR IS NULL OR tenant_id = $1)
+ORDER BY created_at, id`
+	// Devices not assigned yet only match the rules without tenant
+	searchEnabledAlertRulesForDeviceQuery = `
+SELECT ` + alertRuleColumns + ` FROM alert_rules
+WHERE enabled
+  AND tenant_id = COALESCE((SELECT tenant_id FROM spcd_iot_devices WHERE id = $1), '')
+  AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY created_at, id`
+)
+
+type PostgresAlertRuleRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresAlertRuleRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresAlertRuleRepository {
+	return &PostgresAlertRuleRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresAlertRuleRepository) Save(ctx context.Context, rule alerting_domain.AlertRule) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, rule.TenantID); err != nil {
+		return err
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertAlertRuleQuery,
+		rule.ID,
+		rule.TenantID,
+		rule.Name,
+		rule.Expression,
+		sql.NullString{String: rule.DeviceID, Valid: rule.DeviceID != ""},
+		rule.Enabled,
+		rule.CreatedAt.UTC(),
+		rule.UpdatedAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresAlertRuleRepository) Find(ctx context.Context, id string) (*alerting_domain.AlertRule, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := scanAlertRule(r.connectionPool.Reader().QueryRowContext(ctx, findAlertRuleQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (r *PostgresAlertRuleRepository) Delete(ctx context.Context, id string) error {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.connectionPool.Writer().ExecContext(ctx, deleteAlertRuleQuery, id, scope)
+
+	return err
+}
+
+func (r *PostgresAlertRuleRepository) SearchAll(ctx context.Context) ([]alerting_domain.AlertRule, error) {
+	return r.search(ctx, searchAllAlertRulesQuery)
+}
+
+func (r *PostgresAlertRuleRepository) SearchEnabledFor(ctx context.Context, deviceID string) ([]alerting_domain.AlertRule, error) {
+	return r.search(ctx, searchEnabledAlertRulesForDeviceQuery, deviceID)
+}
+
+func (r *PostgresAlertRuleRepository) search(ctx context.Context, query string, args ...any) ([]alerting_domain.AlertRule, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, query, append(args, scope)...)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	rules := make([]alerting_domain.AlertRule, 0)
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAlertRule(row rowScanner) (alerting_domain.AlertRule, error) {
+	var rule alerting_domain.AlertRule
+	var deviceID sql.NullString
+
+	err := row.Scan(&rule.ID, &rule.TenantID, &rule.Name, &rule.Expression, &deviceID, &rule.Enabled, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return alerting_domain.AlertRule{}, err
+	}
+	rule.DeviceID = deviceID.String
+
+	condition, err := alerting_domain.ParseAlertCondition(rule.Expression)
+	if err != nil {
+		return alerting_domain.AlertRule{}, err
+	}
+	rule.Condition = condition
+
+	return rule, nil
+}
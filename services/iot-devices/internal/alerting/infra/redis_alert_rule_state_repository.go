@@ -0,0 +1,52 @@
+package alerting_infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+)
+
+const alertRuleStateKeyPrefix = "alert_rule_state:"
+
+// RedisAlertRuleStateRepository keeps a hash per rule with the state of every device.
+type RedisAlertRuleStateRepository struct {
+	redisClient *redis.Client
+}
+
+func NewRedisAlertRuleStateRepository(redisClient *redis.Client) *RedisAlertRuleStateRepository {
+	return &RedisAlertRuleStateRepository{redisClient: redisClient}
+}
+
+func (r *RedisAlertRuleStateRepository) Find(ctx context.Context, ruleID string, deviceID string) (alerting_domain.AlertRuleState, error) {
+	raw, err := r.redisClient.HGet(ctx, alertRuleStateKeyPrefix+ruleID, deviceID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return alerting_domain.NewAlertRuleState(ruleID, deviceID), nil
+	}
+	if err != nil {
+		return alerting_domain.AlertRuleState{}, err
+	}
+
+	var state alerting_domain.AlertRuleState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return alerting_domain.AlertRuleState{}, err
+	}
+
+	return state, nil
+}
+
+func (r *RedisAlertRuleStateRepository) Save(ctx context.Context, state alerting_domain.AlertRuleState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.HSet(ctx, alertRuleStateKeyPrefix+state.RuleID, state.DeviceID, raw).Err()
+}
+
+func (r *RedisAlertRuleStateRepository) DeleteForRule(ctx context.Context, ruleID string) error {
+	return r.redisClient.Del(ctx, alertRuleStateKeyPrefix+ruleID).Err()
+}
@@ -0,0 +1,49 @@
+package connectivity_application
+
+import (
+	"context"
+	"time"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+)
+
+// ConnectivityTracker records the uplinks of the devices and announces the devices the
+// sweeper marked as offline coming back.
+type ConnectivityTracker struct {
+	directory connectivity_domain.DeviceDirectory
+	lastSeen  connectivity_domain.LastSeenRepository
+	policy    connectivity_domain.ConnectivityPolicy
+	eventBus  amf_event_bus.Bus
+}
+
+func NewConnectivityTracker(
+	directory connectivity_domain.DeviceDirectory,
+	lastSeen connectivity_domain.LastSeenRepository,
+	policy connectivity_domain.ConnectivityPolicy,
+	eventBus amf_event_bus.Bus,
+) *ConnectivityTracker {
+	return &ConnectivityTracker{
+		directory: directory,
+		lastSeen:  lastSeen,
+		policy:    policy,
+		eventBus:  eventBus,
+	}
+}
+
+func (ct *ConnectivityTracker) Seen(ctx context.Context, deviceID string, at time.Time) error {
+	wasOffline, err := ct.lastSeen.Touch(ctx, deviceID, at)
+	if err != nil || !wasOffline {
+		return err
+	}
+
+	profile, err := ct.directory.Find(ctx, deviceID)
+	if err != nil {
+		return err
+	}
+
+	ct.eventBus.Publish(connectivity_domain.NewDeviceCameBackOnline(ct.policy.ProfileOf(deviceID, profile), at))
+
+	return nil
+}
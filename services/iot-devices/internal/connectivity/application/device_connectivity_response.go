@@ -0,0 +1,44 @@
+package connectivity_application
+
+import (
+	"time"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+)
+
+type DeviceConnectivityResponse struct {
+	DeviceID                 string `jsonapi:"primary,device_connectivity"`
+	Model                    string `jsonapi:"attr,model,omitempty"`
+	SiteID                   string `jsonapi:"attr,site_id,omitempty"`
+	Status                   string `jsonapi:"attr,status"`
+	LastSeenAt               string `jsonapi:"attr,last_seen_at,omitempty"`
+	ReportingIntervalSeconds int64  `jsonapi:"attr,reporting_interval_seconds"`
+}
+
+func newDeviceConnectivityResponse(
+	profile connectivity_domain.DeviceProfile,
+	status connectivity_domain.ConnectivityStatus,
+	lastSeenAt *time.Time,
+) *DeviceConnectivityResponse {
+	response := &DeviceConnectivityResponse{
+		DeviceID:                 profile.DeviceID,
+		Model:                    profile.Model,
+		SiteID:                   profile.SiteID,
+		Status:                   status.Value(),
+		ReportingIntervalSeconds: int64(profile.ReportingInterval.Seconds()),
+	}
+
+	if lastSeenAt != nil {
+		response.LastSeenAt = lastSeenAt.Format(time.RFC3339)
+	}
+
+	return response
+}
+
+type SiteConnectivityResponse struct {
+	SiteID  string                   `jsonapi:"primary,site_connectivity"`
+	Online  int                      `jsonapi:"attr,online"`
+	Offline int                      `jsonapi:"attr,offline"`
+	Unknown int                      `jsonapi:"attr,unknown"`
+	Devices []map[string]interface{} `jsonapi:"attr,devices"`
+}
@@ -0,0 +1,11 @@
+package connectivity_application
+
+const GetDeviceConnectivityQueryName = "GetDeviceConnectivityQuery"
+
+type GetDeviceConnectivityQuery struct {
+	DeviceID string
+}
+
+func (q GetDeviceConnectivityQuery) Type() string {
+	return GetDeviceConnectivityQueryName
+}
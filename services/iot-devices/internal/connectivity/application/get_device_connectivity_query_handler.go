@@ -0,0 +1,56 @@
+package connectivity_application
+
+import (
+	"context"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type GetDeviceConnectivityQueryHandler struct {
+	directory    connectivity_domain.DeviceDirectory
+	lastSeen     connectivity_domain.LastSeenRepository
+	policy       connectivity_domain.ConnectivityPolicy
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewGetDeviceConnectivityQueryHandler(
+	directory connectivity_domain.DeviceDirectory,
+	lastSeen connectivity_domain.LastSeenRepository,
+	policy connectivity_domain.ConnectivityPolicy,
+	timeProvider amf_utils.DateTimeProvider,
+) *GetDeviceConnectivityQueryHandler {
+	return &GetDeviceConnectivityQueryHandler{
+		directory:    directory,
+		lastSeen:     lastSeen,
+		policy:       policy,
+		timeProvider: timeProvider,
+	}
+}
+
+func (h GetDeviceConnectivityQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*GetDeviceConnectivityQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	registered, err := h.directory.Find(ctx, q.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSeenAt, err := h.lastSeen.Find(ctx, q.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if registered == nil && lastSeenAt == nil {
+		return nil, connectivity_domain.NewDeviceNotExists(q.DeviceID)
+	}
+
+	profile := h.policy.ProfileOf(q.DeviceID, registered)
+
+	return newDeviceConnectivityResponse(profile, h.policy.StatusAt(profile, lastSeenAt, h.timeProvider.Now()), lastSeenAt), nil
+}
@@ -0,0 +1,11 @@
+package connectivity_application
+
+const GetSiteConnectivityQueryName = "GetSiteConnectivityQuery"
+
+type GetSiteConnectivityQuery struct {
+	SiteID string
+}
+
+func (q GetSiteConnectivityQuery) Type() string {
+	return GetSiteConnectivityQueryName
+}
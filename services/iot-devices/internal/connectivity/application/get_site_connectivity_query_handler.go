@@ -0,0 +1,85 @@
+package connectivity_application
+
+import (
+	"context"
+	"time"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type GetSiteConnectivityQueryHandler struct {
+	directory    connectivity_domain.DeviceDirectory
+	lastSeen     connectivity_domain.LastSeenRepository
+	policy       connectivity_domain.ConnectivityPolicy
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewGetSiteConnectivityQueryHandler(
+	directory connectivity_domain.DeviceDirectory,
+	lastSeen connectivity_domain.LastSeenRepository,
+	policy connectivity_domain.ConnectivityPolicy,
+	timeProvider amf_utils.DateTimeProvider,
+) *GetSiteConnectivityQueryHandler {
+	return &GetSiteConnectivityQueryHandler{
+		directory:    directory,
+		lastSeen:     lastSeen,
+		policy:       policy,
+		timeProvider: timeProvider,
+	}
+}
+
+func (h GetSiteConnectivityQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*GetSiteConnectivityQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	profiles, err := h.directory.SearchBySite(ctx, q.SiteID)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceIDs := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		deviceIDs = append(deviceIDs, profile.DeviceID)
+	}
+
+	lastSeen, err := h.lastSeen.SearchByDevices(ctx, deviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.timeProvider.Now()
+	response := &SiteConnectivityResponse{SiteID: q.SiteID, Devices: make([]map[string]interface{}, 0, len(profiles))}
+	for _, registered := range profiles {
+		profile := h.policy.ProfileOf(registered.DeviceID, &registered)
+
+		var lastSeenAt *time.Time
+		if seenAt, seen := lastSeen[profile.DeviceID]; seen {
+			lastSeenAt = &seenAt
+		}
+
+		status := h.policy.StatusAt(profile, lastSeenAt, now)
+		switch status {
+		case connectivity_domain.OnlineStatus:
+			response.Online++
+		case connectivity_domain.OfflineStatus:
+			response.Offline++
+		default:
+			response.Unknown++
+		}
+
+		device := newDeviceConnectivityResponse(profile, status, lastSeenAt)
+		response.Devices = append(response.Devices, map[string]interface{}{
+			"device_id":    device.DeviceID,
+			"model":        device.Model,
+			"status":       device.Status,
+			"last_seen_at": device.LastSeenAt,
+		})
+	}
+
+	return response, nil
+}
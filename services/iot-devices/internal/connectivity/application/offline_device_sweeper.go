@@ -0,0 +1,125 @@
+package connectivity_application
+
+import (
+	"context"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const offlineDeviceSweeperMutexKey = "connectivity_offline_device_sweeper"
+
+// OfflineDeviceSweeper marks as offline the devices that missed their reports. Runs are
+// serialized across replicas through the distributed mutex, so every device goes
+// offline only once.
+type OfflineDeviceSweeper struct {
+	directory    connectivity_domain.DeviceDirectory
+	lastSeen     connectivity_domain.LastSeenRepository
+	policy       connectivity_domain.ConnectivityPolicy
+	eventBus     amf_event_bus.Bus
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewOfflineDeviceSweeper(
+	directory connectivity_domain.DeviceDirectory,
+	lastSeen connectivity_domain.LastSeenRepository,
+	policy connectivity_domain.ConnectivityPolicy,
+	eventBus amf_event_bus.Bus,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+) *OfflineDeviceSweeper {
+	return &OfflineDeviceSweeper{
+		directory:    directory,
+		lastSeen:     lastSeen,
+		policy:       policy,
+		eventBus:     eventBus,
+		mutex:        mutex,
+		timeProvider: timeProvider,
+	}
+}
+
+// Run marks offline the devices not seen in time, publishing the events once the lock is
+// released.
+func (s *OfflineDeviceSweeper) Run(ctx context.Context) error {
+	events, err := s.mutex.Mutex(ctx, offlineDeviceSweeperMutexKey, func() (interface{}, error) {
+		return s.sweep(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events.([]amf_bus.Event) {
+		s.eventBus.Publish(event)
+	}
+
+	return nil
+}
+
+// sweep only loads the devices seen before the earliest time any of them can go offline, and
+// marks each one while its last uplink is still older than its own cutoff, so an uplink
+// arriving in the meantime keeps it online.
+func (s *OfflineDeviceSweeper) sweep(ctx context.Context) ([]amf_bus.Event, error) {
+	shortestReportingInterval, err := s.directory.ShortestReportingInterval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.timeProvider.Now()
+	lastSeen, err := s.lastSeen.SearchSeenBefore(ctx, s.policy.OfflineCutoff(now, shortestReportingInterval))
+	if err != nil {
+		return nil, err
+	}
+
+	deviceIDs := make([]string, 0, len(lastSeen))
+	for deviceID := range lastSeen {
+		deviceIDs = append(deviceIDs, deviceID)
+	}
+
+	profiles, err := s.profilesOf(ctx, deviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]amf_bus.Event, 0)
+	for _, deviceID := range deviceIDs {
+		seenAt := lastSeen[deviceID]
+		profile := s.policy.ProfileOf(deviceID, profiles[deviceID])
+		if s.policy.StatusAt(profile, &seenAt, now) != connectivity_domain.OfflineStatus {
+			continue
+		}
+
+		wentOffline, err := s.lastSeen.MarkOffline(ctx, deviceID, now.Add(-s.policy.OfflineAfter(profile)))
+		if err != nil {
+			return nil, err
+		}
+
+		if wentOffline {
+			events = append(events, connectivity_domain.NewDeviceWentOffline(profile, seenAt, now))
+		}
+	}
+
+	return events, nil
+}
+
+func (s *OfflineDeviceSweeper) profilesOf(ctx context.Context, deviceIDs []string) (map[string]*connectivity_domain.DeviceProfile, error) {
+	profiles := make(map[string]*connectivity_domain.DeviceProfile, len(deviceIDs))
+	if len(deviceIDs) == 0 {
+		return profiles, nil
+	}
+
+	registered, err := s.directory.SearchByIDs(ctx, deviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range registered {
+		profiles[registered[i].DeviceID] = &registered[i]
+	}
+
+	return profiles, nil
+}
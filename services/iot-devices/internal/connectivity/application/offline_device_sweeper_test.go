@@ -0,0 +1,106 @@
+package connectivity_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	connectivity_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/application"
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+	connectivity_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain/mocks"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type inProcessMutex struct{}
+
+func (m inProcessMutex) Mutex(_ context.Context, _ string, fn func() (interface{}, error)) (interface{}, error) {
+	return fn()
+}
+
+type eventRecorder chan amf_bus.Event
+
+func (r eventRecorder) Handle(event amf_bus.Event) error {
+	r <- event
+	return nil
+}
+
+func (r eventRecorder) next(t *testing.T) amf_bus.Event {
+	select {
+	case event := <-r:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+		return nil
+	}
+}
+
+func TestOfflineDeviceSweeper(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+	policy := connectivity_domain.NewConnectivityPolicy(time.Hour, 2)
+
+	t.Run("should mark as offline the devices that missed their reports", func(t *testing.T) {
+		directory := connectivity_domain_mocks.NewDeviceDirectory(t)
+		lastSeen := connectivity_domain_mocks.NewLastSeenRepository(t)
+		eventBus, recorder := amf_event_bus.NewEventBus(), make(eventRecorder, 2)
+		eventBus.Subscribe(connectivity_domain.DeviceWentOfflineEventName, recorder)
+
+		fastReporter := connectivity_domain.DeviceProfile{DeviceID: "fast", SiteID: "site-1", ReportingInterval: 10 * time.Minute}
+		directory.On("ShortestReportingInterval", ctx).Return(10*time.Minute, nil).Once()
+		lastSeen.On("SearchSeenBefore", ctx, now.Add(-20*time.Minute)).Return(map[string]time.Time{
+			"fast":         now.Add(-30 * time.Minute),
+			"unregistered": now.Add(-30 * time.Minute),
+			"already":      now.Add(-5 * time.Hour),
+		}, nil).Once()
+		directory.On("SearchByIDs", ctx, mock.Anything).Return([]connectivity_domain.DeviceProfile{fastReporter}, nil).Once()
+		lastSeen.On("MarkOffline", ctx, "fast", now.Add(-20*time.Minute)).Return(true, nil).Once()
+		lastSeen.On("MarkOffline", ctx, "already", now.Add(-2*time.Hour)).Return(false, nil).Once()
+
+		sweeper := connectivity_application.NewOfflineDeviceSweeper(directory, lastSeen, policy, eventBus, inProcessMutex{}, timeProvider)
+		err := sweeper.Run(ctx)
+
+		assert.NoError(t, err)
+		event := recorder.next(t)
+		assert.Equal(t, "fast", event.Data()["device_id"])
+		assert.Equal(t, "site-1", event.Data()["site_id"])
+		assert.Len(t, recorder, 0)
+	})
+}
+
+func TestConnectivityTracker(t *testing.T) {
+	ctx, now := context.Background(), time.Now()
+	policy := connectivity_domain.NewConnectivityPolicy(time.Hour, 2)
+
+	t.Run("should announce devices coming back online", func(t *testing.T) {
+		directory := connectivity_domain_mocks.NewDeviceDirectory(t)
+		lastSeen := connectivity_domain_mocks.NewLastSeenRepository(t)
+		eventBus, recorder := amf_event_bus.NewEventBus(), make(eventRecorder, 1)
+		eventBus.Subscribe(connectivity_domain.DeviceCameBackOnlineEventName, recorder)
+
+		lastSeen.On("Touch", ctx, "device-1", now).Return(true, nil).Once()
+		directory.On("Find", ctx, "device-1").Return(nil, nil).Once()
+
+		err := connectivity_application.NewConnectivityTracker(directory, lastSeen, policy, eventBus).Seen(ctx, "device-1", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "device-1", recorder.next(t).Data()["device_id"])
+	})
+
+	t.Run("should only record the uplink of online devices", func(t *testing.T) {
+		directory := connectivity_domain_mocks.NewDeviceDirectory(t)
+		lastSeen := connectivity_domain_mocks.NewLastSeenRepository(t)
+
+		lastSeen.On("Touch", ctx, "device-1", now).Return(false, nil).Once()
+
+		err := connectivity_application.NewConnectivityTracker(directory, lastSeen, policy, amf_event_bus.NewEventBus()).Seen(ctx, "device-1", now)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,28 @@
+package connectivity_application
+
+import (
+	"context"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// ReadingIngestedEventHandler records every uplink of a device as the last time it was seen.
+type ReadingIngestedEventHandler struct {
+	tracker      *ConnectivityTracker
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewReadingIngestedEventHandler(tracker *ConnectivityTracker, timeProvider amf_utils.DateTimeProvider) *ReadingIngestedEventHandler {
+	return &ReadingIngestedEventHandler{tracker: tracker, timeProvider: timeProvider}
+}
+
+func (h ReadingIngestedEventHandler) Handle(event amf_bus.Event) error {
+	deviceID, ok := event.Data()["device_id"].(string)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid reading ingested event")
+	}
+
+	return h.tracker.Seen(amf_tenancy.WithAllTenants(context.Background()), deviceID, h.timeProvider.Now())
+}
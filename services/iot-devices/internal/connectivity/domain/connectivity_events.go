@@ -0,0 +1,52 @@
+package connectivity_domain
+
+import "time"
+
+const (
+	DeviceWentOfflineEventName    = "connectivity.device_went_offline"
+	DeviceCameBackOnlineEventName = "connectivity.device_came_back_online"
+)
+
+type DeviceWentOffline struct {
+	connectivityEvent
+}
+
+func NewDeviceWentOffline(profile DeviceProfile, lastSeenAt time.Time, at time.Time) DeviceWentOffline {
+	return DeviceWentOffline{connectivityEvent{profile: profile, lastSeenAt: lastSeenAt, at: at}}
+}
+
+func (dwo DeviceWentOffline) Name() string {
+	return DeviceWentOfflineEventName
+}
+
+type DeviceCameBackOnline struct {
+	connectivityEvent
+}
+
+func NewDeviceCameBackOnline(profile DeviceProfile, at time.Time) DeviceCameBackOnline {
+	return DeviceCameBackOnline{connectivityEvent{profile: profile, lastSeenAt: at, at: at}}
+}
+
+func (dcbo DeviceCameBackOnline) Name() string {
+	return DeviceCameBackOnlineEventName
+}
+
+type connectivityEvent struct {
+	profile    DeviceProfile
+	lastSeenAt time.Time
+	at         time.Time
+}
+
+func (ce connectivityEvent) Type() string {
+	return "domain_event"
+}
+
+func (ce connectivityEvent) Data() map[string]interface{} {
+	return map[string]interface{}{
+		"device_id":    ce.profile.DeviceID,
+		"model":        ce.profile.Model,
+		"site_id":      ce.profile.SiteID,
+		"last_seen_at": ce.lastSeenAt,
+		"at":           ce.at,
+	}
+}
@@ -0,0 +1,70 @@
+package connectivity_domain
+
+import "time"
+
+type ConnectivityStatus string
+
+const (
+	OnlineStatus  ConnectivityStatus = "online"
+	OfflineStatus ConnectivityStatus = "offline"
+	UnknownStatus ConnectivityStatus = "unknown"
+)
+
+func (cs ConnectivityStatus) Value() string {
+	return string(cs)
+}
+
+// ConnectivityPolicy considers a device offline once it misses a number of reports in a
+// row. Devices that are not registered, or whose model has no reporting interval, are
+// expected to report at the default interval.
+type ConnectivityPolicy struct {
+	defaultReportingInterval time.Duration
+	missedReports            int
+}
+
+func NewConnectivityPolicy(defaultReportingInterval time.Duration, missedReports int) ConnectivityPolicy {
+	if missedReports < 1 {
+		missedReports = 1
+	}
+
+	return ConnectivityPolicy{defaultReportingInterval: defaultReportingInterval, missedReports: missedReports}
+}
+
+func (cp ConnectivityPolicy) ProfileOf(deviceID string, profile *DeviceProfile) DeviceProfile {
+	if profile == nil {
+		profile = &DeviceProfile{DeviceID: deviceID}
+	}
+
+	if profile.ReportingInterval <= 0 {
+		profile.ReportingInterval = cp.defaultReportingInterval
+	}
+
+	return *profile
+}
+
+func (cp ConnectivityPolicy) OfflineAfter(profile DeviceProfile) time.Duration {
+	return profile.ReportingInterval * time.Duration(cp.missedReports)
+}
+
+// OfflineCutoff is the time before which the devices reporting at the shortest interval, or at
+// the default one when it is shorter, have to be last seen to be offline at the given time.
+func (cp ConnectivityPolicy) OfflineCutoff(now time.Time, shortestReportingInterval time.Duration) time.Time {
+	interval := cp.defaultReportingInterval
+	if shortestReportingInterval > 0 && shortestReportingInterval < interval {
+		interval = shortestReportingInterval
+	}
+
+	return now.Add(-cp.OfflineAfter(DeviceProfile{ReportingInterval: interval}))
+}
+
+func (cp ConnectivityPolicy) StatusAt(profile DeviceProfile, lastSeenAt *time.Time, now time.Time) ConnectivityStatus {
+	if lastSeenAt == nil {
+		return UnknownStatus
+	}
+
+	if now.Sub(*lastSeenAt) > cp.OfflineAfter(profile) {
+		return OfflineStatus
+	}
+
+	return OnlineStatus
+}
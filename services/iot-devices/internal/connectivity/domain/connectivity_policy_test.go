@@ -0,0 +1,43 @@
+package connectivity_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+)
+
+func TestConnectivityPolicy(t *testing.T) {
+	now := time.Now()
+	policy := connectivity_domain.NewConnectivityPolicy(time.Hour, 2)
+
+	t.Run("should use the default reporting interval for unknown devices", func(t *testing.T) {
+		profile := policy.ProfileOf("device-1", nil)
+
+		assert.Equal(t, "device-1", profile.DeviceID)
+		assert.Equal(t, 2*time.Hour, policy.OfflineAfter(profile))
+	})
+
+	t.Run("should use the reporting interval of the device model", func(t *testing.T) {
+		profile := policy.ProfileOf("device-1", &connectivity_domain.DeviceProfile{DeviceID: "device-1", ReportingInterval: 5 * time.Minute})
+
+		assert.Equal(t, 10*time.Minute, policy.OfflineAfter(profile))
+	})
+
+	t.Run("should tell the status from the last time the device was seen", func(t *testing.T) {
+		profile := policy.ProfileOf("device-1", nil)
+		recently, longAgo := now.Add(-90*time.Minute), now.Add(-3*time.Hour)
+
+		assert.Equal(t, connectivity_domain.UnknownStatus, policy.StatusAt(profile, nil, now))
+		assert.Equal(t, connectivity_domain.OnlineStatus, policy.StatusAt(profile, &recently, now))
+		assert.Equal(t, connectivity_domain.OfflineStatus, policy.StatusAt(profile, &longAgo, now))
+	})
+
+	t.Run("should cut off at the shortest reporting interval of the models or the default one", func(t *testing.T) {
+		assert.Equal(t, now.Add(-10*time.Minute), policy.OfflineCutoff(now, 5*time.Minute))
+		assert.Equal(t, now.Add(-2*time.Hour), policy.OfflineCutoff(now, 3*time.Hour))
+		assert.Equal(t, now.Add(-2*time.Hour), policy.OfflineCutoff(now, 0))
+	})
+}
@@ -0,0 +1,24 @@
+package connectivity_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const deviceNotExistsErrorMessage = "Device not exists"
+
+type DeviceNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (dne DeviceNotExists) Error() string {
+	return deviceNotExistsErrorMessage
+}
+
+func (dne DeviceNotExists) ExtraItems() map[string]interface{} {
+	return dne.items
+}
+
+func NewDeviceNotExists(deviceID string) *DeviceNotExists {
+	return &DeviceNotExists{items: map[string]interface{}{"device_id": deviceID}}
+}
@@ -0,0 +1,25 @@
+package connectivity_domain
+
+import (
+	"context"
+	"time"
+)
+
+// DeviceProfile is what connectivity needs to know about a registered device: the
+// site it is installed at and how often its model is expected to report.
+type DeviceProfile struct {
+	DeviceID          string
+	TenantID          string
+	Model             string
+	SiteID            string
+	ReportingInterval time.Duration
+}
+
+type DeviceDirectory interface {
+	// Find returns nil when the device is not registered
+	Find(ctx context.Context, deviceID string) (*DeviceProfile, error)
+	SearchByIDs(ctx context.Context, deviceIDs []string) ([]DeviceProfile, error)
+	SearchBySite(ctx context.Context, siteID string) ([]DeviceProfile, error)
+	// ShortestReportingInterval returns zero when no model has a reporting interval
+	ShortestReportingInterval(ctx context.Context) (time.Duration, error)
+}
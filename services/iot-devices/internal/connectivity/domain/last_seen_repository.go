@@ -0,0 +1,17 @@
+package connectivity_domain
+
+import (
+	"context"
+	"time"
+)
+
+type LastSeenRepository interface {
+	// Touch records an uplink of the device and reports whether it was marked as offline
+	Touch(ctx context.Context, deviceID string, at time.Time) (bool, error)
+	// MarkOffline marks the device as offline while its last uplink is still before the given
+	// time, and reports whether it was not already marked as offline
+	MarkOffline(ctx context.Context, deviceID string, seenBefore time.Time) (bool, error)
+	Find(ctx context.Context, deviceID string) (*time.Time, error)
+	SearchSeenBefore(ctx context.Context, before time.Time) (map[string]time.Time, error)
+	SearchByDevices(ctx context.Context, deviceIDs []string) (map[string]time.Time, error)
+}
@@ -0,0 +1,56 @@
+package connectivity_http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	connectivity_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/application"
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+)
+
+func NewGetDeviceConnectivityController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &connectivity_application.GetDeviceConnectivityQuery{DeviceID: mux.Vars(r)["deviceId"]}
+		writeConnectivity(w, r, queryBus, jarm, query)
+	}
+}
+
+func NewGetSiteConnectivityController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &connectivity_application.GetSiteConnectivityQuery{SiteID: mux.Vars(r)["siteId"]}
+		writeConnectivity(w, r, queryBus, jarm, query)
+	}
+}
+
+func writeConnectivity(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query amf_bus.Dto,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), query)
+
+	switch err.(type) {
+	case nil:
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	case *connectivity_domain.DeviceNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	default:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+	}
+}
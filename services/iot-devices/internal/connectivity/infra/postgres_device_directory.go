@@ -0,0 +1,114 @@
+package connectivity_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	deviceProfileSelect = `
+SELECT d.id, d.tenant_id, COALESCE(d.model, ''), COALESCE(d.site_id, ''), COALESCE(m.reporting_interval_seconds, 0)
+FROM spcd_iot_devices d
+LEFT JOIN device_models m ON m.name = d.model`
+
+	findDeviceProfileQuery = deviceProfileSelect + `
+WHERE d.id = $1 AND ($2::VARCHAR IS NULL OR d.tenant_id = $2)`
+	searchDeviceProfilesByIDsQuery = deviceProfileSelect + `
+WHERE d.id = ANY($1) AND ($2::VARCHAR IS NULL OR d.tenant_id = $2)`
+	searchDeviceProfilesBySite = deviceProfileSelect + `
+WHERE d.site_id = $1 AND ($2::VARCHAR IS NULL OR d.tenant_id = $2)
+ORDER BY d.id`
+	shortestReportingIntervalQuery = `
+SELECT COALESCE(MIN(reporting_interval_seconds), 0) FROM device_models WHERE reporting_interval_seconds > 0`
+)
+
+type PostgresDeviceDirectory struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresDeviceDirectory(connectionPool amf_sqldb.ConnectionPool) *PostgresDeviceDirectory {
+	return &PostgresDeviceDirectory{connectionPool: connectionPool}
+}
+
+func (d *PostgresDeviceDirectory) Find(ctx context.Context, deviceID string) (*connectivity_domain.DeviceProfile, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := scanDeviceProfile(d.connectionPool.Reader().QueryRowContext(ctx, findDeviceProfileQuery, deviceID, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (d *PostgresDeviceDirectory) SearchByIDs(ctx context.Context, deviceIDs []string) ([]connectivity_domain.DeviceProfile, error) {
+	return d.search(ctx, searchDeviceProfilesByIDsQuery, pq.Array(deviceIDs))
+}
+
+func (d *PostgresDeviceDirectory) SearchBySite(ctx context.Context, siteID string) ([]connectivity_domain.DeviceProfile, error) {
+	return d.search(ctx, searchDeviceProfilesBySite, siteID)
+}
+
+func (d *PostgresDeviceDirectory) ShortestReportingInterval(ctx context.Context) (time.Duration, error) {
+	var reportingIntervalSeconds int64
+	if err := d.connectionPool.Reader().QueryRowContext(ctx, shortestReportingIntervalQuery).Scan(&reportingIntervalSeconds); err != nil {
+		return 0, err
+	}
+
+	return time.Duration(reportingIntervalSeconds) * time.Second, nil
+}
+
+func (d *PostgresDeviceDirectory) search(ctx context.Context, query string, args ...any) ([]connectivity_domain.DeviceProfile, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.connectionPool.Reader().QueryContext(ctx, query, append(args, scope)...)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	profiles := make([]connectivity_domain.DeviceProfile, 0)
+	for rows.Next() {
+		profile, err := scanDeviceProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDeviceProfile(row rowScanner) (connectivity_domain.DeviceProfile, error) {
+	var profile connectivity_domain.DeviceProfile
+	var reportingIntervalSeconds int64
+
+	if err := row.Scan(&profile.DeviceID, &profile.TenantID, &profile.Model, &profile.SiteID, &reportingIntervalSeconds); err != nil {
+		return connectivity_domain.DeviceProfile{}, err
+	}
+	profile.ReportingInterval = time.Duration(reportingIntervalSeconds) * time.Second
+
+	return profile, nil
+}
@@ -0,0 +1,111 @@
+package connectivity_infra
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	lastSeenKey       = "connectivity:last_seen"
+	offlineDevicesKey = "connectivity:offline"
+)
+
+// markOfflineScript only marks the device while its last uplink is older than the time given
+var markOfflineScript = redis.NewScript(`
+local seenAt = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not seenAt or tonumber(seenAt) >= tonumber(ARGV[2]) then
+	return 0
+end
+return redis.call('SADD', KEYS[2], ARGV[1])
+`)
+
+// RedisLastSeenRepository keeps the last uplink of every device in a sorted set scored
+// by its unix time in milliseconds, and the devices marked as offline in a set.
+type RedisLastSeenRepository struct {
+	redisClient *redis.Client
+}
+
+func NewRedisLastSeenRepository(redisClient *redis.Client) *RedisLastSeenRepository {
+	return &RedisLastSeenRepository{redisClient: redisClient}
+}
+
+func (r *RedisLastSeenRepository) Touch(ctx context.Context, deviceID string, at time.Time) (bool, error) {
+	var removed *redis.IntCmd
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAddGT(ctx, lastSeenKey, redis.Z{Score: float64(at.UnixMilli()), Member: deviceID})
+		removed = pipe.SRem(ctx, offlineDevicesKey, deviceID)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return removed.Val() > 0, nil
+}
+
+func (r *RedisLastSeenRepository) MarkOffline(ctx context.Context, deviceID string, seenBefore time.Time) (bool, error) {
+	added, err := markOfflineScript.Run(
+		ctx,
+		r.redisClient,
+		[]string{lastSeenKey, offlineDevicesKey},
+		deviceID,
+		seenBefore.UnixMilli(),
+	).Int64()
+
+	return added > 0, err
+}
+
+func (r *RedisLastSeenRepository) Find(ctx context.Context, deviceID string) (*time.Time, error) {
+	score, err := r.redisClient.ZScore(ctx, lastSeenKey, deviceID).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lastSeenAt := time.UnixMilli(int64(score))
+
+	return &lastSeenAt, nil
+}
+
+func (r *RedisLastSeenRepository) SearchSeenBefore(ctx context.Context, before time.Time) (map[string]time.Time, error) {
+	members, err := r.redisClient.ZRangeByScoreWithScores(ctx, lastSeenKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(before.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	lastSeen := make(map[string]time.Time, len(members))
+	for _, member := range members {
+		lastSeen[member.Member.(string)] = time.UnixMilli(int64(member.Score))
+	}
+
+	return lastSeen, nil
+}
+
+func (r *RedisLastSeenRepository) SearchByDevices(ctx context.Context, deviceIDs []string) (map[string]time.Time, error) {
+	lastSeen := make(map[string]time.Time, len(deviceIDs))
+	if len(deviceIDs) == 0 {
+		return lastSeen, nil
+	}
+
+	scores, err := r.redisClient.ZMScore(ctx, lastSeenKey, deviceIDs...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, deviceID := range deviceIDs {
+		// ZMSCORE answers 0 for the devices never seen
+		if scores[i] > 0 {
+			lastSeen[deviceID] = time.UnixMilli(int64(scores[i]))
+		}
+	}
+
+	return lastSeen, nil
+}
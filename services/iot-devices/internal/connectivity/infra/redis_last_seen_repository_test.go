@@ -0,0 +1,107 @@
+package connectivity_infra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	connectivity_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/infra"
+)
+
+type RedisLastSeenRepositoryTestSuite struct {
+	suite.Suite
+	miniRedis  *miniredis.Miniredis
+	repository *connectivity_infra.RedisLastSeenRepository
+	ctx        context.Context
+}
+
+func (suite *RedisLastSeenRepositoryTestSuite) SetupTest() {
+	miniRedis, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.miniRedis = miniRedis
+	suite.repository = connectivity_infra.NewRedisLastSeenRepository(redis.NewClient(&redis.Options{Addr: miniRedis.Addr()}))
+	suite.ctx = context.Background()
+}
+
+func (suite *RedisLastSeenRepositoryTestSuite) TearDownTest() {
+	suite.miniRedis.Close()
+}
+
+func (suite *RedisLastSeenRepositoryTestSuite) TestTouchKeepsTheLatestUplink() {
+	now := time.Now().Truncate(time.Millisecond)
+
+	_, err := suite.repository.Touch(suite.ctx, "device-1", now)
+	suite.Require().NoError(err)
+	_, err = suite.repository.Touch(suite.ctx, "device-1", now.Add(-time.Minute))
+	suite.Require().NoError(err)
+
+	lastSeenAt, err := suite.repository.Find(suite.ctx, "device-1")
+	suite.Require().NoError(err)
+	suite.True(now.Equal(*lastSeenAt))
+
+	lastSeen, err := suite.repository.SearchByDevices(suite.ctx, []string{"device-1", "device-2"})
+	suite.Require().NoError(err)
+	suite.Len(lastSeen, 1)
+	suite.True(now.Equal(lastSeen["device-1"]))
+}
+
+func (suite *RedisLastSeenRepositoryTestSuite) TestOfflineDevicesComeBackWithTheirNextUplink() {
+	now := time.Now()
+
+	wasOffline, err := suite.repository.Touch(suite.ctx, "device-1", now)
+	suite.Require().NoError(err)
+	suite.False(wasOffline)
+
+	wentOffline, err := suite.repository.MarkOffline(suite.ctx, "device-1", now.Add(time.Minute))
+	suite.Require().NoError(err)
+	suite.True(wentOffline)
+
+	wentOffline, err = suite.repository.MarkOffline(suite.ctx, "device-1", now.Add(time.Minute))
+	suite.Require().NoError(err)
+	suite.False(wentOffline)
+
+	wasOffline, err = suite.repository.Touch(suite.ctx, "device-1", now.Add(time.Hour))
+	suite.Require().NoError(err)
+	suite.True(wasOffline)
+}
+
+func (suite *RedisLastSeenRepositoryTestSuite) TestOnlyDevicesStillSeenBeforeTheCutoffGoOffline() {
+	now := time.Now().Truncate(time.Millisecond)
+
+	_, err := suite.repository.Touch(suite.ctx, "device-1", now.Add(-time.Hour))
+	suite.Require().NoError(err)
+	_, err = suite.repository.Touch(suite.ctx, "device-2", now)
+	suite.Require().NoError(err)
+
+	lastSeen, err := suite.repository.SearchSeenBefore(suite.ctx, now)
+	suite.Require().NoError(err)
+	suite.Len(lastSeen, 1)
+	suite.True(now.Add(-time.Hour).Equal(lastSeen["device-1"]))
+
+	// device-1 reports again between the search and its marking
+	_, err = suite.repository.Touch(suite.ctx, "device-1", now)
+	suite.Require().NoError(err)
+
+	wentOffline, err := suite.repository.MarkOffline(suite.ctx, "device-1", now.Add(-time.Minute))
+	suite.Require().NoError(err)
+	suite.False(wentOffline)
+
+	wentOffline, err = suite.repository.MarkOffline(suite.ctx, "device-3", now)
+	suite.Require().NoError(err)
+	suite.False(wentOffline)
+}
+
+func (suite *RedisLastSeenRepositoryTestSuite) TestFindUnseenDevice() {
+	lastSeenAt, err := suite.repository.Find(suite.ctx, "device-1")
+
+	suite.NoError(err)
+	suite.Nil(lastSeenAt)
+}
+
+func TestRedisLastSeenRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisLastSeenRepositoryTestSuite))
+}
@@ -0,0 +1,18 @@
+package downlinks_application
+
+const AcknowledgeDownlinkCommandName = "AcknowledgeDownlinkCommand"
+
+type AcknowledgeDownlinkCommand struct {
+	DeviceID string
+	ID       string
+	Status   string
+	Result   string
+}
+
+func (c AcknowledgeDownlinkCommand) Type() string {
+	return AcknowledgeDownlinkCommandName
+}
+
+func (c AcknowledgeDownlinkCommand) BlockingKey() string {
+	return downlinkQueueBlockingKey(c.DeviceID)
+}
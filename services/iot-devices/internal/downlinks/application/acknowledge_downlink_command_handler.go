@@ -0,0 +1,44 @@
+package downlinks_application
+
+import (
+	"context"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type AcknowledgeDownlinkCommandHandler struct {
+	repository   downlinks_domain.DownlinkCommandRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewAcknowledgeDownlinkCommandHandler(
+	repository downlinks_domain.DownlinkCommandRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *AcknowledgeDownlinkCommandHandler {
+	return &AcknowledgeDownlinkCommandHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h AcknowledgeDownlinkCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*AcknowledgeDownlinkCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	downlink, err := h.repository.Find(ctx, cmd.DeviceID, cmd.ID)
+	if err != nil {
+		return err
+	}
+	if downlink == nil {
+		return downlinks_domain.NewDownlinkCommandNotExists(cmd.DeviceID, cmd.ID)
+	}
+
+	acknowledged, err := downlink.Acknowledge(downlinks_domain.DownlinkCommandStatus(cmd.Status), cmd.Result, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, acknowledged)
+}
@@ -0,0 +1,71 @@
+package downlinks_application
+
+import (
+	"time"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+)
+
+type DownlinkCommandResponse struct {
+	ID          string                 `jsonapi:"primary,downlink_commands"`
+	TenantID    string                 `jsonapi:"attr,tenant_id"`
+	DeviceID    string                 `jsonapi:"attr,device_id"`
+	CommandType string                 `jsonapi:"attr,type"`
+	Params      map[string]interface{} `jsonapi:"attr,params,omitempty"`
+	Priority    int                    `jsonapi:"attr,priority"`
+	DedupeKey   string                 `jsonapi:"attr,dedupe_key"`
+	Status      string                 `jsonapi:"attr,status"`
+	Attempts    int                    `jsonapi:"attr,attempts"`
+	Result      string                 `jsonapi:"attr,result,omitempty"`
+	QueuedAt    string                 `jsonapi:"attr,queued_at"`
+	ExpiresAt   string                 `jsonapi:"attr,expires_at"`
+	SentAt      string                 `jsonapi:"attr,sent_at,omitempty"`
+	DeliveredAt string                 `jsonapi:"attr,delivered_at,omitempty"`
+	CompletedAt string                 `jsonapi:"attr,completed_at,omitempty"`
+}
+
+func NewDownlinkCommandResponse(command downlinks_domain.DownlinkCommand, now time.Time) *DownlinkCommandResponse {
+	return &DownlinkCommandResponse{
+		ID:          command.ID,
+		TenantID:    command.TenantID,
+		DeviceID:    command.DeviceID,
+		CommandType: command.Type.Value(),
+		Params:      command.Params,
+		Priority:    command.Priority,
+		DedupeKey:   command.DedupeKey,
+		Status:      command.StatusAt(now).Value(),
+		Attempts:    command.Attempts,
+		Result:      command.Result,
+		QueuedAt:    command.QueuedAt.Format(time.RFC3339),
+		ExpiresAt:   command.ExpiresAt.Format(time.RFC3339),
+		SentAt:      formatOptionalTime(command.SentAt),
+		DeliveredAt: formatOptionalTime(command.DeliveredAt),
+		CompletedAt: formatOptionalTime(command.CompletedAt),
+	}
+}
+
+// DownlinkResponse is the command as the device receives it along the response to
+// its uplink.
+type DownlinkResponse struct {
+	ID          string                 `jsonapi:"primary,downlinks"`
+	CommandType string                 `jsonapi:"attr,type"`
+	Params      map[string]interface{} `jsonapi:"attr,params,omitempty"`
+	ExpiresAt   string                 `jsonapi:"attr,expires_at"`
+}
+
+func NewDownlinkResponse(command downlinks_domain.DownlinkCommand) *DownlinkResponse {
+	return &DownlinkResponse{
+		ID:          command.ID,
+		CommandType: command.Type.Value(),
+		Params:      command.Params,
+		ExpiresAt:   command.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+func formatOptionalTime(at *time.Time) string {
+	if at == nil {
+		return ""
+	}
+
+	return at.Format(time.RFC3339)
+}
@@ -0,0 +1,85 @@
+package downlinks_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	downlinks_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/application"
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+	downlinks_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain/mocks"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestDownlinkQueue(t *testing.T) {
+	ctx := context.Background()
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+	queued := func(t *testing.T, id string) downlinks_domain.DownlinkCommand {
+		command, err := downlinks_domain.NewDownlinkCommand(id, "", "device-1", downlinks_domain.PingDownlinkCommand, nil, 5, "", now.Add(time.Hour), now)
+		require.NoError(t, err)
+		return command
+	}
+
+	t.Run("should supersede the pending duplicates when enqueueing", func(t *testing.T) {
+		repository := downlinks_domain_mocks.NewDownlinkCommandRepository(t)
+		previous := queued(t, amf_utils.NewUlid().String())
+		id := ulidProvider.New().String()
+
+		repository.On("SearchPendingByDedupeKey", ctx, "device-1", "ping", now).Return([]downlinks_domain.DownlinkCommand{previous}, nil).Once()
+		repository.On("Save", ctx, mock.MatchedBy(func(command downlinks_domain.DownlinkCommand) bool {
+			return command.ID == previous.ID && command.Status == downlinks_domain.SupersededDownlinkCommand
+		})).Return(nil).Once()
+		repository.On("Save", ctx, mock.MatchedBy(func(command downlinks_domain.DownlinkCommand) bool {
+			return command.ID == id && command.ExpiresAt.Equal(now.Add(time.Minute))
+		})).Return(nil).Once()
+
+		handler := downlinks_application.NewEnqueueDownlinkCommandHandler(repository, time.Minute, timeProvider)
+		require.NoError(t, handler.Handle(ctx, &downlinks_application.EnqueueDownlinkCommand{
+			ID:          id,
+			DeviceID:    "device-1",
+			CommandType: "ping",
+			Priority:    5,
+		}))
+	})
+
+	t.Run("should only mark the pending commands as sent", func(t *testing.T) {
+		repository := downlinks_domain_mocks.NewDownlinkCommandRepository(t)
+		pending := queued(t, amf_utils.NewUlid().String())
+		superseded, err := queued(t, amf_utils.NewUlid().String()).Supersede(now)
+		require.NoError(t, err)
+
+		repository.On("Find", ctx, "device-1", pending.ID).Return(&pending, nil).Once()
+		repository.On("Find", ctx, "device-1", superseded.ID).Return(&superseded, nil).Once()
+		repository.On("Save", ctx, mock.MatchedBy(func(command downlinks_domain.DownlinkCommand) bool {
+			return command.ID == pending.ID && command.Status == downlinks_domain.SentDownlinkCommand
+		})).Return(nil).Once()
+
+		handler := downlinks_application.NewMarkDownlinksSentCommandHandler(repository, timeProvider)
+		require.NoError(t, handler.Handle(ctx, &downlinks_application.MarkDownlinksSentCommand{
+			DeviceID: "device-1",
+			IDs:      []string{pending.ID, superseded.ID},
+		}))
+	})
+
+	t.Run("should fail acknowledging unknown commands", func(t *testing.T) {
+		repository := downlinks_domain_mocks.NewDownlinkCommandRepository(t)
+		id := amf_utils.NewUlid().String()
+
+		repository.On("Find", ctx, "device-1", id).Return(nil, nil).Once()
+
+		handler := downlinks_application.NewAcknowledgeDownlinkCommandHandler(repository, timeProvider)
+		err := handler.Handle(ctx, &downlinks_application.AcknowledgeDownlinkCommand{
+			DeviceID: "device-1",
+			ID:       id,
+			Status:   "executed",
+		})
+
+		assert.IsType(t, &downlinks_domain.DownlinkCommandNotExists{}, err)
+	})
+}
@@ -0,0 +1,32 @@
+package downlinks_application
+
+import "time"
+
+const EnqueueDownlinkCommandName = "EnqueueDownlinkCommand"
+
+// EnqueueDownlinkCommand expires at ExpiresAt, or Ttl after it is queued when it has
+// none, falling back to the default time to live.
+type EnqueueDownlinkCommand struct {
+	ID          string
+	TenantID    string
+	DeviceID    string
+	CommandType string
+	Params      map[string]interface{}
+	Priority    int
+	DedupeKey   string
+	ExpiresAt   time.Time
+	Ttl         time.Duration
+}
+
+func (c EnqueueDownlinkCommand) Type() string {
+	return EnqueueDownlinkCommandName
+}
+
+// BlockingKey serializes the changes to the queue of the device.
+func (c EnqueueDownlinkCommand) BlockingKey() string {
+	return downlinkQueueBlockingKey(c.DeviceID)
+}
+
+func downlinkQueueBlockingKey(deviceID string) string {
+	return "downlink_queue:" + deviceID
+}
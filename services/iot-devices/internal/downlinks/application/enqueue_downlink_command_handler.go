@@ -0,0 +1,75 @@
+package downlinks_application
+
+import (
+	"context"
+	"time"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type EnqueueDownlinkCommandHandler struct {
+	repository   downlinks_domain.DownlinkCommandRepository
+	defaultTtl   time.Duration
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewEnqueueDownlinkCommandHandler(
+	repository downlinks_domain.DownlinkCommandRepository,
+	defaultTtl time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *EnqueueDownlinkCommandHandler {
+	return &EnqueueDownlinkCommandHandler{repository: repository, defaultTtl: defaultTtl, timeProvider: timeProvider}
+}
+
+// Handle supersedes the pending commands of the device with the same dedupe key, so
+// the device only runs the latest one.
+func (h EnqueueDownlinkCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*EnqueueDownlinkCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	now := h.timeProvider.Now()
+	expiresAt := cmd.ExpiresAt
+	if expiresAt.IsZero() {
+		ttl := cmd.Ttl
+		if ttl <= 0 {
+			ttl = h.defaultTtl
+		}
+		expiresAt = now.Add(ttl)
+	}
+
+	queued, err := downlinks_domain.NewDownlinkCommand(
+		cmd.ID,
+		cmd.TenantID,
+		cmd.DeviceID,
+		downlinks_domain.DownlinkCommandType(cmd.CommandType),
+		cmd.Params,
+		cmd.Priority,
+		cmd.DedupeKey,
+		expiresAt,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	duplicates, err := h.repository.SearchPendingByDedupeKey(ctx, queued.DeviceID, queued.DedupeKey, now)
+	if err != nil {
+		return err
+	}
+	for _, duplicate := range duplicates {
+		superseded, err := duplicate.Supersede(now)
+		if err != nil {
+			return err
+		}
+		if err := h.repository.Save(ctx, superseded); err != nil {
+			return err
+		}
+	}
+
+	return h.repository.Save(ctx, queued)
+}
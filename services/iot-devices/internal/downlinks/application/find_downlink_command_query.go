@@ -0,0 +1,12 @@
+package downlinks_application
+
+const FindDownlinkCommandQueryName = "FindDownlinkCommandQuery"
+
+type FindDownlinkCommandQuery struct {
+	DeviceID string
+	ID       string
+}
+
+func (q FindDownlinkCommandQuery) Type() string {
+	return FindDownlinkCommandQueryName
+}
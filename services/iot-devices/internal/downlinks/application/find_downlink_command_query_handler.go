@@ -0,0 +1,39 @@
+package downlinks_application
+
+import (
+	"context"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type FindDownlinkCommandQueryHandler struct {
+	repository   downlinks_domain.DownlinkCommandRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewFindDownlinkCommandQueryHandler(
+	repository downlinks_domain.DownlinkCommandRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *FindDownlinkCommandQueryHandler {
+	return &FindDownlinkCommandQueryHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h FindDownlinkCommandQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindDownlinkCommandQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	downlink, err := h.repository.Find(ctx, q.DeviceID, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if downlink == nil {
+		return nil, downlinks_domain.NewDownlinkCommandNotExists(q.DeviceID, q.ID)
+	}
+
+	return NewDownlinkCommandResponse(*downlink, h.timeProvider.Now()), nil
+}
@@ -0,0 +1,11 @@
+package downlinks_application
+
+const FindPendingDownlinksQueryName = "FindPendingDownlinksQuery"
+
+type FindPendingDownlinksQuery struct {
+	DeviceID string
+}
+
+func (q FindPendingDownlinksQuery) Type() string {
+	return FindPendingDownlinksQueryName
+}
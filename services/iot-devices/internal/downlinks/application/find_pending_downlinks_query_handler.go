@@ -0,0 +1,45 @@
+package downlinks_application
+
+import (
+	"context"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type FindPendingDownlinksQueryHandler struct {
+	repository   downlinks_domain.DownlinkCommandRepository
+	maxPerUplink int
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewFindPendingDownlinksQueryHandler(
+	repository downlinks_domain.DownlinkCommandRepository,
+	maxPerUplink int,
+	timeProvider amf_utils.DateTimeProvider,
+) *FindPendingDownlinksQueryHandler {
+	return &FindPendingDownlinksQueryHandler{repository: repository, maxPerUplink: maxPerUplink, timeProvider: timeProvider}
+}
+
+// Handle returns the commands that fit in the response to an uplink, the most urgent
+// first.
+func (h FindPendingDownlinksQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindPendingDownlinksQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	pending, err := h.repository.SearchPending(ctx, q.DeviceID, h.timeProvider.Now(), h.maxPerUplink)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*DownlinkResponse, 0, len(pending))
+	for _, downlink := range pending {
+		response = append(response, NewDownlinkResponse(downlink))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,16 @@
+package downlinks_application
+
+const MarkDownlinksSentCommandName = "MarkDownlinksSentCommand"
+
+type MarkDownlinksSentCommand struct {
+	DeviceID string
+	IDs      []string
+}
+
+func (c MarkDownlinksSentCommand) Type() string {
+	return MarkDownlinksSentCommandName
+}
+
+func (c MarkDownlinksSentCommand) BlockingKey() string {
+	return downlinkQueueBlockingKey(c.DeviceID)
+}
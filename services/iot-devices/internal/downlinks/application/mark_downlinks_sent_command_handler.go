@@ -0,0 +1,52 @@
+package downlinks_application
+
+import (
+	"context"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type MarkDownlinksSentCommandHandler struct {
+	repository   downlinks_domain.DownlinkCommandRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewMarkDownlinksSentCommandHandler(
+	repository downlinks_domain.DownlinkCommandRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *MarkDownlinksSentCommandHandler {
+	return &MarkDownlinksSentCommandHandler{repository: repository, timeProvider: timeProvider}
+}
+
+// Handle skips the commands that stopped being pending since they were read, such as
+// the ones superseded meanwhile.
+func (h MarkDownlinksSentCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*MarkDownlinksSentCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	now := h.timeProvider.Now()
+	for _, id := range cmd.IDs {
+		downlink, err := h.repository.Find(ctx, cmd.DeviceID, id)
+		if err != nil {
+			return err
+		}
+		if downlink == nil || !downlink.Pending(now) {
+			continue
+		}
+
+		sent, err := downlink.Send(now)
+		if err != nil {
+			return err
+		}
+		if err := h.repository.Save(ctx, sent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
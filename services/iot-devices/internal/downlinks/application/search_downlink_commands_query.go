@@ -0,0 +1,12 @@
+package downlinks_application
+
+const SearchDownlinkCommandsQueryName = "SearchDownlinkCommandsQuery"
+
+type SearchDownlinkCommandsQuery struct {
+	DeviceID string
+	Status   string
+}
+
+func (q SearchDownlinkCommandsQuery) Type() string {
+	return SearchDownlinkCommandsQueryName
+}
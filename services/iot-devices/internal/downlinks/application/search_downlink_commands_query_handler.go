@@ -0,0 +1,48 @@
+package downlinks_application
+
+import (
+	"context"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const downlinkQueueStateLimit = 100
+
+type SearchDownlinkCommandsQueryHandler struct {
+	repository   downlinks_domain.DownlinkCommandRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewSearchDownlinkCommandsQueryHandler(
+	repository downlinks_domain.DownlinkCommandRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *SearchDownlinkCommandsQueryHandler {
+	return &SearchDownlinkCommandsQueryHandler{repository: repository, timeProvider: timeProvider}
+}
+
+// Handle shows the state of the queue of the device through its latest commands.
+func (h SearchDownlinkCommandsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchDownlinkCommandsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	downlinks, err := h.repository.SearchByDevice(ctx, q.DeviceID, downlinkQueueStateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.timeProvider.Now()
+	response := make([]*DownlinkCommandResponse, 0, len(downlinks))
+	for _, downlink := range downlinks {
+		if q.Status != "" && downlink.StatusAt(now).Value() != q.Status {
+			continue
+		}
+		response = append(response, NewDownlinkCommandResponse(downlink, now))
+	}
+
+	return response, nil
+}
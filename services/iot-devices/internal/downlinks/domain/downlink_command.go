@@ -0,0 +1,233 @@
+package downlinks_domain
+
+import (
+	"context"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	downlinkTenantIdMaxLength  = 50
+	downlinkDeviceIdMaxLength  = 50
+	downlinkDedupeKeyMaxLength = 100
+	downlinkResultMaxLength    = 255
+
+	MinDownlinkPriority = 0
+	MaxDownlinkPriority = 9
+)
+
+type DownlinkCommandType string
+
+const (
+	RebootDownlinkCommand      DownlinkCommandType = "reboot"
+	SetIntervalDownlinkCommand DownlinkCommandType = "set_interval"
+	PingDownlinkCommand        DownlinkCommandType = "ping"
+	RequestLogsDownlinkCommand DownlinkCommandType = "request_logs"
+)
+
+func (dct DownlinkCommandType) Value() string {
+	return string(dct)
+}
+
+var downlinkCommandTypes = map[string]struct{}{
+	RebootDownlinkCommand.Value():      {},
+	SetIntervalDownlinkCommand.Value(): {},
+	PingDownlinkCommand.Value():        {},
+	RequestLogsDownlinkCommand.Value(): {},
+}
+
+type DownlinkCommandStatus string
+
+const (
+	QueuedDownlinkCommand     DownlinkCommandStatus = "queued"
+	SentDownlinkCommand       DownlinkCommandStatus = "sent"
+	DeliveredDownlinkCommand  DownlinkCommandStatus = "delivered"
+	ExecutedDownlinkCommand   DownlinkCommandStatus = "executed"
+	FailedDownlinkCommand     DownlinkCommandStatus = "failed"
+	ExpiredDownlinkCommand    DownlinkCommandStatus = "expired"
+	SupersededDownlinkCommand DownlinkCommandStatus = "superseded"
+)
+
+func (dcs DownlinkCommandStatus) Value() string {
+	return string(dcs)
+}
+
+// DownlinkCommand waits in the queue of a sleepy device until it uplinks, as it only
+// listens right after. It is sent back in the response to the uplink, and sent again
+// on the next ones until the device acknowledges it got it or the command expires.
+type DownlinkCommand struct {
+	ID          string
+	TenantID    string
+	DeviceID    string
+	Type        DownlinkCommandType
+	Params      map[string]interface{}
+	Priority    int
+	DedupeKey   string
+	Status      DownlinkCommandStatus
+	Attempts    int
+	Result      string
+	QueuedAt    time.Time
+	ExpiresAt   time.Time
+	SentAt      *time.Time
+	DeliveredAt *time.Time
+	CompletedAt *time.Time
+}
+
+// NewDownlinkCommand queues a command. Commands sharing the dedupe key replace each
+// other, which defaults to the type so a device only gets the latest interval set.
+func NewDownlinkCommand(
+	id string,
+	tenantID string,
+	deviceID string,
+	commandType DownlinkCommandType,
+	params map[string]interface{},
+	priority int,
+	dedupeKey string,
+	expiresAt time.Time,
+	now time.Time,
+) (DownlinkCommand, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidDownlinkCommand(id, "id", "must be a ULID")); err != nil {
+		return DownlinkCommand{}, err
+	}
+
+	tenantIdValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(downlinkTenantIdMaxLength))
+	if err := tenantIdValidator.Validate(tenantID, NewInvalidDownlinkCommand(id, "tenant_id", "is too long")); err != nil {
+		return DownlinkCommand{}, err
+	}
+
+	deviceIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(downlinkDeviceIdMaxLength),
+	)
+	if err := deviceIdValidator.Validate(deviceID, NewInvalidDownlinkCommand(id, "device_id", "must be a non empty string")); err != nil {
+		return DownlinkCommand{}, err
+	}
+
+	typeValidator := domain_validation.NewDomainValidator(domain_validation.In(downlinkCommandTypes))
+	if err := typeValidator.Validate(commandType.Value(), NewInvalidDownlinkCommand(id, "type", "is not a known command")); err != nil {
+		return DownlinkCommand{}, err
+	}
+
+	if commandType == SetIntervalDownlinkCommand {
+		if interval, ok := params["interval_seconds"].(float64); !ok || interval <= 0 {
+			return DownlinkCommand{}, NewInvalidDownlinkCommand(id, "params", "set_interval requires a positive interval_seconds")
+		}
+	}
+
+	if priority < MinDownlinkPriority || priority > MaxDownlinkPriority {
+		return DownlinkCommand{}, NewInvalidDownlinkCommand(id, "priority", "must be between 0 and 9")
+	}
+
+	if dedupeKey == "" {
+		dedupeKey = commandType.Value()
+	}
+	dedupeKeyValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(downlinkDedupeKeyMaxLength))
+	if err := dedupeKeyValidator.Validate(dedupeKey, NewInvalidDownlinkCommand(id, "dedupe_key", "is too long")); err != nil {
+		return DownlinkCommand{}, err
+	}
+
+	if !expiresAt.After(now) {
+		return DownlinkCommand{}, NewInvalidDownlinkCommand(id, "expires_at", "must be in the future")
+	}
+
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	return DownlinkCommand{
+		ID:        id,
+		TenantID:  tenantID,
+		DeviceID:  deviceID,
+		Type:      commandType,
+		Params:    params,
+		Priority:  priority,
+		DedupeKey: dedupeKey,
+		Status:    QueuedDownlinkCommand,
+		QueuedAt:  now,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// StatusAt reports the commands never delivered before their expiry as expired.
+func (dc DownlinkCommand) StatusAt(now time.Time) DownlinkCommandStatus {
+	if (dc.Status == QueuedDownlinkCommand || dc.Status == SentDownlinkCommand) && !now.Before(dc.ExpiresAt) {
+		return ExpiredDownlinkCommand
+	}
+
+	return dc.Status
+}
+
+// Pending tells whether the command still has to be sent to the device.
+func (dc DownlinkCommand) Pending(now time.Time) bool {
+	status := dc.StatusAt(now)
+
+	return status == QueuedDownlinkCommand || status == SentDownlinkCommand
+}
+
+func (dc DownlinkCommand) Send(now time.Time) (DownlinkCommand, error) {
+	if !dc.Pending(now) {
+		return DownlinkCommand{}, NewDownlinkCommandTransitionNotAllowed(dc.ID, dc.StatusAt(now), SentDownlinkCommand)
+	}
+
+	dc.Status = SentDownlinkCommand
+	dc.Attempts++
+	dc.SentAt = &now
+
+	return dc, nil
+}
+
+// Supersede drops a pending command replaced by a newer one with the same dedupe key.
+func (dc DownlinkCommand) Supersede(now time.Time) (DownlinkCommand, error) {
+	if !dc.Pending(now) {
+		return DownlinkCommand{}, NewDownlinkCommandTransitionNotAllowed(dc.ID, dc.StatusAt(now), SupersededDownlinkCommand)
+	}
+
+	dc.Status = SupersededDownlinkCommand
+	dc.CompletedAt = &now
+
+	return dc, nil
+}
+
+// Acknowledge records the acks of the device: delivered once it got the command, and
+// executed or failed once it ran it. Devices may skip the delivery ack.
+func (dc DownlinkCommand) Acknowledge(status DownlinkCommandStatus, result string, now time.Time) (DownlinkCommand, error) {
+	allowed := false
+	switch status {
+	case DeliveredDownlinkCommand:
+		allowed = dc.Status == SentDownlinkCommand
+	case ExecutedDownlinkCommand, FailedDownlinkCommand:
+		allowed = dc.Status == SentDownlinkCommand || dc.Status == DeliveredDownlinkCommand
+	}
+	if !allowed {
+		return DownlinkCommand{}, NewDownlinkCommandTransitionNotAllowed(dc.ID, dc.Status, status)
+	}
+
+	resultValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(downlinkResultMaxLength))
+	if err := resultValidator.Validate(result, NewInvalidDownlinkCommand(dc.ID, "result", "is too long")); err != nil {
+		return DownlinkCommand{}, err
+	}
+
+	if dc.DeliveredAt == nil {
+		dc.DeliveredAt = &now
+	}
+	if status != DeliveredDownlinkCommand {
+		dc.CompletedAt = &now
+		dc.Result = result
+	}
+	dc.Status = status
+
+	return dc, nil
+}
+
+type DownlinkCommandRepository interface {
+	Save(ctx context.Context, command DownlinkCommand) error
+	// Find returns nil when the device has no such command
+	Find(ctx context.Context, deviceID string, id string) (*DownlinkCommand, error)
+	// SearchPending returns the commands still to send at the time, by priority and
+	// then by age
+	SearchPending(ctx context.Context, deviceID string, at time.Time, limit int) ([]DownlinkCommand, error)
+	SearchPendingByDedupeKey(ctx context.Context, deviceID string, dedupeKey string, at time.Time) ([]DownlinkCommand, error)
+	// SearchByDevice returns the latest commands of the device, newest first
+	SearchByDevice(ctx context.Context, deviceID string, limit int) ([]DownlinkCommand, error)
+}
@@ -0,0 +1,24 @@
+package downlinks_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const downlinkCommandNotExistsErrorMessage = "Downlink command not exists"
+
+type DownlinkCommandNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (dcne DownlinkCommandNotExists) Error() string {
+	return downlinkCommandNotExistsErrorMessage
+}
+
+func (dcne DownlinkCommandNotExists) ExtraItems() map[string]interface{} {
+	return dcne.items
+}
+
+func NewDownlinkCommandNotExists(deviceID string, id string) *DownlinkCommandNotExists {
+	return &DownlinkCommandNotExists{items: map[string]interface{}{"device_id": deviceID, "id": id}}
+}
@@ -0,0 +1,77 @@
+package downlinks_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestDownlinkCommand(t *testing.T) {
+	id, now := amf_utils.NewUlid().String(), time.Now()
+	newCommand := func(t *testing.T) downlinks_domain.DownlinkCommand {
+		command, err := downlinks_domain.NewDownlinkCommand(id, "", "device-1", downlinks_domain.RebootDownlinkCommand, nil, 5, "", now.Add(time.Hour), now)
+		require.NoError(t, err)
+		return command
+	}
+
+	t.Run("should reject set interval commands without interval", func(t *testing.T) {
+		_, err := downlinks_domain.NewDownlinkCommand(id, "", "device-1", downlinks_domain.SetIntervalDownlinkCommand, nil, 5, "", now.Add(time.Hour), now)
+
+		assert.IsType(t, &downlinks_domain.InvalidDownlinkCommand{}, err)
+	})
+
+	t.Run("should reject priorities out of range", func(t *testing.T) {
+		_, err := downlinks_domain.NewDownlinkCommand(id, "", "device-1", downlinks_domain.PingDownlinkCommand, nil, 10, "", now.Add(time.Hour), now)
+
+		assert.IsType(t, &downlinks_domain.InvalidDownlinkCommand{}, err)
+	})
+
+	t.Run("should dedupe by type by default", func(t *testing.T) {
+		assert.Equal(t, "reboot", newCommand(t).DedupeKey)
+	})
+
+	t.Run("should expire the commands not delivered in time", func(t *testing.T) {
+		command := newCommand(t)
+
+		assert.True(t, command.Pending(now))
+		assert.Equal(t, downlinks_domain.ExpiredDownlinkCommand, command.StatusAt(now.Add(time.Hour)))
+		assert.False(t, command.Pending(now.Add(time.Hour)))
+	})
+
+	t.Run("should track the delivery and execution acks", func(t *testing.T) {
+		sent, err := newCommand(t).Send(now)
+		require.NoError(t, err)
+		delivered, err := sent.Acknowledge(downlinks_domain.DeliveredDownlinkCommand, "", now)
+		require.NoError(t, err)
+		executed, err := delivered.Acknowledge(downlinks_domain.ExecutedDownlinkCommand, "rebooted", now)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, executed.Attempts)
+		assert.Equal(t, downlinks_domain.ExecutedDownlinkCommand, executed.Status)
+		assert.Equal(t, "rebooted", executed.Result)
+		assert.NotNil(t, executed.CompletedAt)
+	})
+
+	t.Run("should not acknowledge commands never sent", func(t *testing.T) {
+		_, err := newCommand(t).Acknowledge(downlinks_domain.ExecutedDownlinkCommand, "", now)
+
+		assert.IsType(t, &downlinks_domain.DownlinkCommandTransitionNotAllowed{}, err)
+	})
+
+	t.Run("should not supersede finished commands", func(t *testing.T) {
+		sent, err := newCommand(t).Send(now)
+		require.NoError(t, err)
+		failed, err := sent.Acknowledge(downlinks_domain.FailedDownlinkCommand, "busy", now)
+		require.NoError(t, err)
+
+		_, err = failed.Supersede(now)
+
+		assert.IsType(t, &downlinks_domain.DownlinkCommandTransitionNotAllowed{}, err)
+	})
+}
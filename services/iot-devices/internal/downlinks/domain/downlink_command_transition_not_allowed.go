@@ -0,0 +1,24 @@
+package downlinks_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const downlinkCommandTransitionNotAllowedErrorMessage = "Downlink command transition not allowed"
+
+type DownlinkCommandTransitionNotAllowed struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (dctna DownlinkCommandTransitionNotAllowed) Error() string {
+	return downlinkCommandTransitionNotAllowedErrorMessage
+}
+
+func (dctna DownlinkCommandTransitionNotAllowed) ExtraItems() map[string]interface{} {
+	return dctna.items
+}
+
+func NewDownlinkCommandTransitionNotAllowed(id string, from DownlinkCommandStatus, to DownlinkCommandStatus) *DownlinkCommandTransitionNotAllowed {
+	return &DownlinkCommandTransitionNotAllowed{items: map[string]interface{}{"id": id, "from": from.Value(), "to": to.Value()}}
+}
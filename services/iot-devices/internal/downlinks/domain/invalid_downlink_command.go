@@ -0,0 +1,24 @@
+package downlinks_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidDownlinkCommandErrorMessage = "Invalid downlink command"
+
+type InvalidDownlinkCommand struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (idc InvalidDownlinkCommand) Error() string {
+	return invalidDownlinkCommandErrorMessage
+}
+
+func (idc InvalidDownlinkCommand) ExtraItems() map[string]interface{} {
+	return idc.items
+}
+
+func NewInvalidDownlinkCommand(id string, field string, reason string) *InvalidDownlinkCommand {
+	return &InvalidDownlinkCommand{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
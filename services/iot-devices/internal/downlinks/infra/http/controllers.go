@@ -0,0 +1,198 @@
+package downlinks_http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	downlinks_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/application"
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func NewEnqueueDownlinkController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		id := ulidProvider.New().String()
+		expiresAt, err := timeAttribute(requestParams, "expires_at")
+		if err != nil {
+			writeDownlinkError(w, r, jarm, downlinks_domain.NewInvalidDownlinkCommand(id, "expires_at", "must be a RFC3339 date"))
+			return
+		}
+
+		params, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", "params"}, requestParams, nil).(map[string]interface{})
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		command := &downlinks_application.EnqueueDownlinkCommand{
+			ID:          id,
+			TenantID:    tenantID,
+			DeviceID:    mux.Vars(r)["deviceId"],
+			CommandType: stringAttribute(requestParams, "type"),
+			Params:      params,
+			Priority:    int(numberAttribute(requestParams, "priority")),
+			DedupeKey:   stringAttribute(requestParams, "dedupe_key"),
+			ExpiresAt:   expiresAt,
+			Ttl:         time.Duration(numberAttribute(requestParams, "ttl_seconds")) * time.Second,
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeDownlinkError(w, r, jarm, err)
+			return
+		}
+
+		query := &downlinks_application.FindDownlinkCommandQuery{DeviceID: command.DeviceID, ID: id}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusCreated)
+	}
+}
+
+func NewGetDownlinksController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &downlinks_application.SearchDownlinkCommandsQuery{
+			DeviceID: mux.Vars(r)["deviceId"],
+			Status:   r.URL.Query().Get("filter[status]"),
+		}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetDownlinkController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := &downlinks_application.FindDownlinkCommandQuery{DeviceID: vars["deviceId"], ID: vars["commandId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+// NewAcknowledgeDownlinkController lets the devices acknowledge a command out of their
+// uplinks.
+func NewAcknowledgeDownlinkController(
+	commandBus amf_command_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		vars := mux.Vars(r)
+		command := &downlinks_application.AcknowledgeDownlinkCommand{
+			DeviceID: vars["deviceId"],
+			ID:       vars["commandId"],
+			Status:   stringAttribute(requestParams, "status"),
+			Result:   stringAttribute(requestParams, "result"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeDownlinkError(w, r, jarm, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, nil, http.StatusNoContent)
+	}
+}
+
+func writeQueryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query amf_bus.Dto,
+	statusCode int,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), query)
+	if err != nil {
+		writeDownlinkError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, statusCode)
+}
+
+func writeDownlinkError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *downlinks_domain.DownlinkCommandNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *downlinks_domain.DownlinkCommandTransitionNotAllowed:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewConflictWithDetails(
+			err.Error(),
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusConflict, err)
+	case *downlinks_domain.InvalidDownlinkCommand:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		writeInternalServerError(w, r, jarm, err)
+	}
+}
+
+func writeInternalServerError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+	jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+}
+
+func stringAttribute(requestParams map[string]interface{}, attribute string) string {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, "").(string)
+
+	return value
+}
+
+func numberAttribute(requestParams map[string]interface{}, attribute string) float64 {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, float64(0)).(float64)
+
+	return value
+}
+
+// timeAttribute returns the zero time when the attribute is missing.
+func timeAttribute(requestParams map[string]interface{}, attribute string) (time.Time, error) {
+	value := stringAttribute(requestParams, attribute)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
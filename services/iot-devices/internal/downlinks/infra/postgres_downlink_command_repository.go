@@ -0,0 +1,227 @@
+package downlinks_infra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	downlinkCommandColumns = `id, tenant_id, device_id, type, params, priority, dedupe_key, status, attempts, result,
+    queued_at, expires_at, sent_at, delivered_at, completed_at`
+
+	upsertDownlinkCommandQuery = `
+INSERT INTO downlink_commands (` + downlinkCommandColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+ON CONFLICT (id) DO UPDATE SET
+    status = EXCLUDED.status,
+    attempts = EXCLUDED.attempts,
+    result = EXCLUDED.result,
+    sent_at = EXCLUDED.sent_at,
+    delivered_at = EXCLUDED.delivered_at,
+    completed_at = EXCLUDED.completed_at
+WHERE downlink_commands.tenant_id = EXCLUDED.tenant_id`
+	findDownlinkCommandQuery = `
+SELECT ` + downlinkCommandColumns + ` FROM downlink_commands
+WHERE device_id = $1 AND id = $2 AND ($3::VARCHAR IS NULL OR tenant_id = $3)`
+	searchPendingDownlinkCommandsQuery = `
+SELECT ` + downlinkCommandColumns + ` FROM downlink_commands
+WHERE device_id = $1 AND status IN ('queued', 'sent') AND expires_at > $2 AND ($4::VARCHAR IS NULL OR tenant_id = $4)
+ORDER BY priority DESC, queued_at, id
+LIMIT $3`
+	searchPendingDownlinkCommandsByDedupeKeyQuery = `
+SELECT ` + downlinkCommandColumns + ` FROM downlink_commands
+WHERE device_id = $1 AND dedupe_key = $2 AND status IN ('queued', 'sent') AND expires_at > $3
+  AND ($4::VARCHAR IS NULL OR tenant_id = $4)`
+	searchDownlinkCommandsByDeviceQuery = `
+SELECT ` + downlinkCommandColumns + ` FROM downlink_commands
+WHERE device_id = $1 AND ($3::VARCHAR IS NULL OR tenant_id = $3)
+ORDER BY queued_at DESC, id DESC
+LIMIT $2`
+)
+
+type PostgresDownlinkCommandRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresDownlinkCommandRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresDownlinkCommandRepository {
+	return &PostgresDownlinkCommandRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresDownlinkCommandRepository) Save(ctx context.Context, command downlinks_domain.DownlinkCommand) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, command.TenantID); err != nil {
+		return err
+	}
+
+	params, err := json.Marshal(command.Params)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertDownlinkCommandQuery,
+		command.ID,
+		command.TenantID,
+		command.DeviceID,
+		command.Type.Value(),
+		string(params),
+		command.Priority,
+		command.DedupeKey,
+		command.Status.Value(),
+		command.Attempts,
+		command.Result,
+		command.QueuedAt.UTC(),
+		command.ExpiresAt.UTC(),
+		nullTime(command.SentAt),
+		nullTime(command.DeliveredAt),
+		nullTime(command.CompletedAt),
+	)
+
+	return err
+}
+
+// Find reads from the writer, as the queue changes on every uplink of the device.
+func (r *PostgresDownlinkCommandRepository) Find(
+	ctx context.Context,
+	deviceID string,
+	id string,
+) (*downlinks_domain.DownlinkCommand, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := scanDownlinkCommand(r.connectionPool.Writer().QueryRowContext(ctx, findDownlinkCommandQuery, deviceID, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &command, nil
+}
+
+func (r *PostgresDownlinkCommandRepository) SearchPending(
+	ctx context.Context,
+	deviceID string,
+	at time.Time,
+	limit int,
+) ([]downlinks_domain.DownlinkCommand, error) {
+	return r.search(ctx, r.connectionPool.Writer(), searchPendingDownlinkCommandsQuery, deviceID, at.UTC(), limit)
+}
+
+func (r *PostgresDownlinkCommandRepository) SearchPendingByDedupeKey(
+	ctx context.Context,
+	deviceID string,
+	dedupeKey string,
+	at time.Time,
+) ([]downlinks_domain.DownlinkCommand, error) {
+	return r.search(ctx, r.connectionPool.Writer(), searchPendingDownlinkCommandsByDedupeKeyQuery, deviceID, dedupeKey, at.UTC())
+}
+
+func (r *PostgresDownlinkCommandRepository) SearchByDevice(
+	ctx context.Context,
+	deviceID string,
+	limit int,
+) ([]downlinks_domain.DownlinkCommand, error) {
+	return r.search(ctx, r.connectionPool.Reader(), searchDownlinkCommandsByDeviceQuery, deviceID, limit)
+}
+
+func (r *PostgresDownlinkCommandRepository) search(
+	ctx context.Context,
+	db *sql.DB,
+	query string,
+	args ...any,
+) ([]downlinks_domain.DownlinkCommand, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, append(args, scope)...)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	commands := make([]downlinks_domain.DownlinkCommand, 0)
+	for rows.Next() {
+		command, err := scanDownlinkCommand(rows)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, command)
+	}
+
+	return commands, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDownlinkCommand(row rowScanner) (downlinks_domain.DownlinkCommand, error) {
+	var (
+		command                          downlinks_domain.DownlinkCommand
+		commandType, status              string
+		params                           []byte
+		sentAt, deliveredAt, completedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&command.ID,
+		&command.TenantID,
+		&command.DeviceID,
+		&commandType,
+		&params,
+		&command.Priority,
+		&command.DedupeKey,
+		&status,
+		&command.Attempts,
+		&command.Result,
+		&command.QueuedAt,
+		&command.ExpiresAt,
+		&sentAt,
+		&deliveredAt,
+		&completedAt,
+	)
+	if err != nil {
+		return downlinks_domain.DownlinkCommand{}, err
+	}
+
+	if err := json.Unmarshal(params, &command.Params); err != nil {
+		return downlinks_domain.DownlinkCommand{}, err
+	}
+	command.Type = downlinks_domain.DownlinkCommandType(commandType)
+	command.Status = downlinks_domain.DownlinkCommandStatus(status)
+	command.SentAt = timeFrom(sentAt)
+	command.DeliveredAt = timeFrom(deliveredAt)
+	command.CompletedAt = timeFrom(completedAt)
+
+	return command, nil
+}
+
+func nullTime(at *time.Time) sql.NullTime {
+	if at == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: at.UTC(), Valid: true}
+}
+
+func timeFrom(at sql.NullTime) *time.Time {
+	if !at.Valid {
+		return nil
+	}
+
+	return &at.Time
+}
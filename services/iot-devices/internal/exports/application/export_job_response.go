@@ -0,0 +1,52 @@
+package exports_application
+
+import (
+	"time"
+
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+)
+
+type ExportJobResponse struct {
+	ID                   string   `jsonapi:"primary,export_jobs"`
+	SiteID               string   `jsonapi:"attr,site_id"`
+	From                 string   `jsonapi:"attr,from"`
+	To                   string   `jsonapi:"attr,to"`
+	Format               string   `jsonapi:"attr,format"`
+	Columns              []string `jsonapi:"attr,columns"`
+	Timezone             string   `jsonapi:"attr,timezone"`
+	Status               string   `jsonapi:"attr,status"`
+	Rows                 int      `jsonapi:"attr,rows"`
+	FailureReason        string   `jsonapi:"attr,failure_reason,omitempty"`
+	FileName             string   `jsonapi:"attr,file_name"`
+	DownloadUrl          string   `jsonapi:"attr,download_url,omitempty"`
+	DownloadUrlExpiresAt string   `jsonapi:"attr,download_url_expires_at,omitempty"`
+	RequestedAt          string   `jsonapi:"attr,requested_at"`
+	StartedAt            string   `jsonapi:"attr,started_at,omitempty"`
+	CompletedAt          string   `jsonapi:"attr,completed_at,omitempty"`
+}
+
+func NewExportJobResponse(job exports_domain.ExportJob) *ExportJobResponse {
+	response := &ExportJobResponse{
+		ID:            job.ID,
+		SiteID:        job.Spec.SiteID,
+		From:          job.Spec.From.Format(time.RFC3339),
+		To:            job.Spec.To.Format(time.RFC3339),
+		Format:        job.Spec.Format.Value(),
+		Columns:       job.Spec.Columns,
+		Timezone:      job.Spec.Timezone,
+		Status:        job.Status.Value(),
+		Rows:          job.Rows,
+		FailureReason: job.FailureReason,
+		FileName:      job.Spec.FileName(),
+		RequestedAt:   job.RequestedAt.Format(time.RFC3339),
+	}
+
+	if job.StartedAt != nil {
+		response.StartedAt = job.StartedAt.Format(time.RFC3339)
+	}
+	if job.CompletedAt != nil {
+		response.CompletedAt = job.CompletedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
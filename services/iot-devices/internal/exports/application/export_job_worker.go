@@ -0,0 +1,136 @@
+package exports_application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const exportJobMutexKeyPrefix = "export_job:"
+
+// ExportJobWorker runs the export jobs, streaming each file to the storage as it is
+// written. Exports outlast the locks, so jobs are claimed under the lock by marking them
+// as running and run after releasing it. Jobs running for longer than staleAfter are
+// taken for abandoned by a dead worker and claimed again, so exports are given up once
+// they take that long, before another worker can run them too.
+type ExportJobWorker struct {
+	jobs         exports_domain.ExportJobRepository
+	exporter     *TrapActivityExporter
+	storage      exports_domain.ExportFileStorage
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+	batchSize    int
+	staleAfter   time.Duration
+}
+
+func NewExportJobWorker(
+	jobs exports_domain.ExportJobRepository,
+	exporter *TrapActivityExporter,
+	storage exports_domain.ExportFileStorage,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+	batchSize int,
+	staleAfter time.Duration,
+) *ExportJobWorker {
+	return &ExportJobWorker{
+		jobs:         jobs,
+		exporter:     exporter,
+		storage:      storage,
+		mutex:        mutex,
+		timeProvider: timeProvider,
+		batchSize:    batchSize,
+		staleAfter:   staleAfter,
+	}
+}
+
+// Run claims and runs a batch of the pending and abandoned jobs, one after the other.
+func (w *ExportJobWorker) Run(ctx context.Context) error {
+	jobs, err := w.jobs.SearchClaimable(ctx, w.timeProvider.Now().Add(-w.staleAfter), w.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		claimed, err := w.claim(ctx, job.ID)
+		if err != nil {
+			return err
+		}
+		if claimed == nil {
+			continue
+		}
+
+		if err := w.run(ctx, *claimed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *ExportJobWorker) claim(ctx context.Context, id string) (*exports_domain.ExportJob, error) {
+	claimed, err := w.mutex.Mutex(ctx, exportJobMutexKeyPrefix+id, func() (interface{}, error) {
+		job, err := w.jobs.Find(ctx, id)
+		if err != nil || job == nil || !job.Claimable(w.timeProvider.Now().Add(-w.staleAfter)) {
+			return nil, err
+		}
+
+		started := job.Started(w.timeProvider.Now())
+		if err := w.jobs.Save(ctx, started); err != nil {
+			return nil, err
+		}
+
+		return &started, nil
+	})
+	if err != nil || claimed == nil {
+		return nil, err
+	}
+
+	return claimed.(*exports_domain.ExportJob), nil
+}
+
+type exportResult struct {
+	rows int
+	err  error
+}
+
+// run records on the job why it failed, so only failing to save the job is an error for
+// the worker. Jobs interrupted by a shutdown are left running, to be claimed again.
+func (w *ExportJobWorker) run(ctx context.Context, job exports_domain.ExportJob) error {
+	exportCtx, cancel := context.WithTimeout(ctx, w.staleAfter)
+	defer cancel()
+
+	content, writer := io.Pipe()
+	exported := make(chan exportResult, 1)
+	go func() {
+		rows, err := w.exporter.Export(exportCtx, job.Spec, writer)
+		exported <- exportResult{rows: rows, err: err}
+		writer.CloseWithError(err)
+	}()
+
+	err := w.storage.Put(exportCtx, job.FileKey(), content, job.Spec.Format.ContentType())
+	// Unblocks the exporter when the storage gave up before reading everything
+	content.CloseWithError(err)
+	result := <-exported
+	if result.err != nil {
+		err = result.err
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if errors.Is(exportCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("export took longer than %s", w.staleAfter)
+	}
+	if err != nil {
+		return w.jobs.Save(ctx, job.Failed(err.Error(), w.timeProvider.Now()))
+	}
+
+	return w.jobs.Save(ctx, job.Completed(result.rows, w.timeProvider.Now()))
+}
@@ -0,0 +1,148 @@
+package exports_application_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	exports_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/application"
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+	exports_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain/mocks"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type inProcessMutex struct{}
+
+func (m inProcessMutex) Mutex(_ context.Context, _ string, fn func() (interface{}, error)) (interface{}, error) {
+	return fn()
+}
+
+func TestExportJobWorker(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	spec, err := exports_domain.NewExportSpec("site-1", from, from.AddDate(0, 1, 0), "csv", []string{"device_id", "trap_triggered"}, "")
+	require.NoError(t, err)
+	pending, err := exports_domain.NewExportJob(amf_utils.NewUlid().String(), spec, timeProvider.Now())
+	require.NoError(t, err)
+	staleAfter := time.Hour
+
+	streamRecords := func(reader *exports_domain_mocks.TrapActivityReader, err error) {
+		reader.On("Stream", mock.Anything, "site-1", spec.From, spec.To, mock.Anything).
+			Run(func(args mock.Arguments) {
+				handle := args.Get(4).(func(exports_domain.TrapActivityRecord) error)
+				_ = handle(exports_domain.TrapActivityRecord{DeviceID: "trap-1", Metrics: map[string]float64{"trap_triggered": 1}})
+				_ = handle(exports_domain.TrapActivityRecord{DeviceID: "trap-2", Metrics: map[string]float64{"trap_triggered": 0}})
+			}).
+			Return(err).
+			Once()
+	}
+	newWorker := func(
+		jobs exports_domain.ExportJobRepository,
+		reader exports_domain.TrapActivityReader,
+		storage exports_domain.ExportFileStorage,
+	) *exports_application.ExportJobWorker {
+		exporter := exports_application.NewTrapActivityExporter(reader)
+		return exports_application.NewExportJobWorker(jobs, exporter, storage, inProcessMutex{}, timeProvider, 10, staleAfter)
+	}
+	savedAs := func(status exports_domain.ExportJobStatus, check func(exports_domain.ExportJob) bool) interface{} {
+		return mock.MatchedBy(func(job exports_domain.ExportJob) bool {
+			return job.ID == pending.ID && job.Status == status && check(job)
+		})
+	}
+	anyJob := func(exports_domain.ExportJob) bool { return true }
+
+	t.Run("should write the file of the pending jobs to the storage", func(t *testing.T) {
+		jobs := exports_domain_mocks.NewExportJobRepository(t)
+		jobs.On("SearchClaimable", ctx, timeProvider.Now().Add(-staleAfter), 10).Return([]exports_domain.ExportJob{pending}, nil).Once()
+		jobs.On("Find", ctx, pending.ID).Return(&pending, nil).Once()
+		jobs.On("Save", ctx, savedAs(exports_domain.RunningExportJob, anyJob)).Return(nil).Once()
+		jobs.On("Save", ctx, savedAs(exports_domain.CompletedExportJob, func(job exports_domain.ExportJob) bool {
+			return job.Rows == 2
+		})).Return(nil).Once()
+		reader := exports_domain_mocks.NewTrapActivityReader(t)
+		streamRecords(reader, nil)
+
+		var written []byte
+		storage := exports_domain_mocks.NewExportFileStorage(t)
+		storage.On("Put", mock.Anything, pending.FileKey(), mock.Anything, "text/csv; charset=utf-8").
+			Run(func(args mock.Arguments) { written, _ = io.ReadAll(args.Get(2).(io.Reader)) }).
+			Return(nil).
+			Once()
+
+		require.NoError(t, newWorker(jobs, reader, storage).Run(ctx))
+		assert.Equal(t, "device_id,trap_triggered\ntrap-1,1\ntrap-2,0\n", string(written))
+	})
+
+	t.Run("should record why the export failed on the job", func(t *testing.T) {
+		jobs := exports_domain_mocks.NewExportJobRepository(t)
+		jobs.On("SearchClaimable", ctx, mock.Anything, 10).Return([]exports_domain.ExportJob{pending}, nil).Once()
+		jobs.On("Find", ctx, pending.ID).Return(&pending, nil).Once()
+		jobs.On("Save", ctx, savedAs(exports_domain.RunningExportJob, anyJob)).Return(nil).Once()
+		jobs.On("Save", ctx, savedAs(exports_domain.FailedExportJob, func(job exports_domain.ExportJob) bool {
+			return job.FailureReason == "replica went away"
+		})).Return(nil).Once()
+		reader := exports_domain_mocks.NewTrapActivityReader(t)
+		streamRecords(reader, errors.New("replica went away"))
+		storage := exports_domain_mocks.NewExportFileStorage(t)
+		storage.On("Put", mock.Anything, pending.FileKey(), mock.Anything, mock.Anything).
+			Return(func(_ context.Context, _ string, content io.Reader, _ string) error {
+				_, err := io.ReadAll(content)
+				return err
+			}).
+			Once()
+
+		require.NoError(t, newWorker(jobs, reader, storage).Run(ctx))
+	})
+
+	t.Run("should give up the exports running for longer than a worker is taken for dead", func(t *testing.T) {
+		jobs := exports_domain_mocks.NewExportJobRepository(t)
+		jobs.On("SearchClaimable", ctx, mock.Anything, 10).Return([]exports_domain.ExportJob{pending}, nil).Once()
+		jobs.On("Find", ctx, pending.ID).Return(&pending, nil).Once()
+		jobs.On("Save", ctx, savedAs(exports_domain.RunningExportJob, anyJob)).Return(nil).Once()
+		jobs.On("Save", ctx, savedAs(exports_domain.FailedExportJob, func(job exports_domain.ExportJob) bool {
+			return job.FailureReason == "export took longer than 10ms"
+		})).Return(nil).Once()
+		reader := exports_domain_mocks.NewTrapActivityReader(t)
+		reader.On("Stream", mock.Anything, "site-1", spec.From, spec.To, mock.Anything).
+			Return(func(ctx context.Context, _ string, _ time.Time, _ time.Time, _ func(exports_domain.TrapActivityRecord) error) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}).
+			Once()
+		storage := exports_domain_mocks.NewExportFileStorage(t)
+		storage.On("Put", mock.Anything, pending.FileKey(), mock.Anything, mock.Anything).
+			Return(func(_ context.Context, _ string, content io.Reader, _ string) error {
+				_, err := io.ReadAll(content)
+				return err
+			}).
+			Once()
+
+		exporter := exports_application.NewTrapActivityExporter(reader)
+		worker := exports_application.NewExportJobWorker(jobs, exporter, storage, inProcessMutex{}, timeProvider, 10, 10*time.Millisecond)
+		require.NoError(t, worker.Run(ctx))
+	})
+
+	t.Run("should leave the jobs another worker claimed meanwhile", func(t *testing.T) {
+		running := pending.Started(timeProvider.Now())
+		jobs := exports_domain_mocks.NewExportJobRepository(t)
+		jobs.On("SearchClaimable", ctx, mock.Anything, 10).Return([]exports_domain.ExportJob{pending}, nil).Once()
+		jobs.On("Find", ctx, pending.ID).Return(&running, nil).Once()
+
+		require.NoError(t, newWorker(jobs, exports_domain_mocks.NewTrapActivityReader(t), exports_domain_mocks.NewExportFileStorage(t)).Run(ctx))
+	})
+
+	t.Run("should claim again the jobs whose worker died", func(t *testing.T) {
+		abandoned := pending.Started(timeProvider.Now().Add(-2 * staleAfter))
+
+		assert.True(t, abandoned.Claimable(timeProvider.Now().Add(-staleAfter)))
+		assert.False(t, pending.Started(timeProvider.Now()).Claimable(timeProvider.Now().Add(-staleAfter)))
+	})
+}
@@ -0,0 +1,11 @@
+package exports_application
+
+const FindExportJobQueryName = "FindExportJobQuery"
+
+type FindExportJobQuery struct {
+	ID string
+}
+
+func (q FindExportJobQuery) Type() string {
+	return FindExportJobQueryName
+}
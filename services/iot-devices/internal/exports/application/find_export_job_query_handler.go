@@ -0,0 +1,63 @@
+package exports_application
+
+import (
+	"context"
+	"time"
+
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// FindExportJobQueryHandler hands out the url to download the file of the completed
+// jobs, which only works for a while.
+type FindExportJobQueryHandler struct {
+	repository     exports_domain.ExportJobRepository
+	storage        exports_domain.ExportFileStorage
+	downloadUrlTtl time.Duration
+	timeProvider   amf_utils.DateTimeProvider
+}
+
+func NewFindExportJobQueryHandler(
+	repository exports_domain.ExportJobRepository,
+	storage exports_domain.ExportFileStorage,
+	downloadUrlTtl time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *FindExportJobQueryHandler {
+	return &FindExportJobQueryHandler{
+		repository:     repository,
+		storage:        storage,
+		downloadUrlTtl: downloadUrlTtl,
+		timeProvider:   timeProvider,
+	}
+}
+
+func (h FindExportJobQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindExportJobQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	job, err := h.repository.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, exports_domain.NewExportJobNotExists(q.ID)
+	}
+
+	response := NewExportJobResponse(*job)
+	if job.Status != exports_domain.CompletedExportJob {
+		return response, nil
+	}
+
+	downloadUrl, err := h.storage.DownloadUrl(ctx, job.FileKey(), h.downloadUrlTtl)
+	if err != nil {
+		return nil, err
+	}
+	response.DownloadUrl = downloadUrl
+	response.DownloadUrlExpiresAt = h.timeProvider.Now().Add(h.downloadUrlTtl).Format(time.RFC3339)
+
+	return response, nil
+}
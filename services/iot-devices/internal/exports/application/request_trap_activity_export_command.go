@@ -0,0 +1,19 @@
+package exports_application
+
+import "time"
+
+const RequestTrapActivityExportCommandName = "RequestTrapActivityExportCommand"
+
+type RequestTrapActivityExportCommand struct {
+	ID       string
+	SiteID   string
+	From     time.Time
+	To       time.Time
+	Format   string
+	Columns  []string
+	Timezone string
+}
+
+func (c RequestTrapActivityExportCommand) Type() string {
+	return RequestTrapActivityExportCommandName
+}
@@ -0,0 +1,41 @@
+package exports_application
+
+import (
+	"context"
+
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type RequestTrapActivityExportCommandHandler struct {
+	repository   exports_domain.ExportJobRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewRequestTrapActivityExportCommandHandler(
+	repository exports_domain.ExportJobRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *RequestTrapActivityExportCommandHandler {
+	return &RequestTrapActivityExportCommandHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h RequestTrapActivityExportCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*RequestTrapActivityExportCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	spec, err := exports_domain.NewExportSpec(cmd.SiteID, cmd.From, cmd.To, cmd.Format, cmd.Columns, cmd.Timezone)
+	if err != nil {
+		return err
+	}
+
+	job, err := exports_domain.NewExportJob(cmd.ID, spec, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, job)
+}
@@ -0,0 +1,37 @@
+package exports_application
+
+import (
+	"context"
+	"io"
+
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+)
+
+// TrapActivityExporter writes the trap activity of a site as it is read, both for the
+// exports streamed to the client and for the files of the export jobs.
+type TrapActivityExporter struct {
+	reader exports_domain.TrapActivityReader
+}
+
+func NewTrapActivityExporter(reader exports_domain.TrapActivityReader) *TrapActivityExporter {
+	return &TrapActivityExporter{reader: reader}
+}
+
+// Export returns the number of records written.
+func (e *TrapActivityExporter) Export(ctx context.Context, spec exports_domain.ExportSpec, w io.Writer) (int, error) {
+	encoder, err := exports_domain.NewExportEncoder(spec, w)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	err = e.reader.Stream(ctx, spec.SiteID, spec.From, spec.To, func(record exports_domain.TrapActivityRecord) error {
+		rows++
+		return encoder.Encode(record)
+	})
+	if err != nil {
+		return rows, err
+	}
+
+	return rows, encoder.Close()
+}
@@ -0,0 +1,109 @@
+package exports_domain
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportEncoder writes the records as they come, so exports take the same memory
+// whatever their size.
+type ExportEncoder interface {
+	Encode(record TrapActivityRecord) error
+	// Close writes whatever is left buffered, without closing the writer
+	Close() error
+}
+
+// NewExportEncoder writes the header right away for the formats that have one.
+func NewExportEncoder(spec ExportSpec, w io.Writer) (ExportEncoder, error) {
+	if spec.Format == NdjsonExport {
+		return &ndjsonExportEncoder{spec: spec, location: spec.Location(), writer: bufio.NewWriter(w)}, nil
+	}
+
+	encoder := &csvExportEncoder{spec: spec, location: spec.Location(), writer: csv.NewWriter(w)}
+	if err := encoder.writer.Write(spec.Columns); err != nil {
+		return nil, err
+	}
+
+	return encoder, nil
+}
+
+type csvExportEncoder struct {
+	spec     ExportSpec
+	location *time.Location
+	writer   *csv.Writer
+}
+
+func (e *csvExportEncoder) Encode(record TrapActivityRecord) error {
+	row := make([]string, len(e.spec.Columns))
+	for i, column := range e.spec.Columns {
+		switch value := record.value(column, e.location).(type) {
+		case string:
+			row[i] = value
+		case bool:
+			row[i] = strconv.FormatBool(value)
+		case float64:
+			row[i] = strconv.FormatFloat(value, 'f', -1, 64)
+		}
+	}
+
+	return e.writer.Write(row)
+}
+
+func (e *csvExportEncoder) Close() error {
+	e.writer.Flush()
+
+	return e.writer.Error()
+}
+
+// ndjsonExportEncoder writes a JSON object per line, with the columns in the order asked
+// for and null for the metrics a record does not carry.
+type ndjsonExportEncoder struct {
+	spec     ExportSpec
+	location *time.Location
+	writer   *bufio.Writer
+}
+
+func (e *ndjsonExportEncoder) Encode(record TrapActivityRecord) error {
+	if err := e.writer.WriteByte('{'); err != nil {
+		return err
+	}
+
+	for i, column := range e.spec.Columns {
+		if i > 0 {
+			if err := e.writer.WriteByte(','); err != nil {
+				return err
+			}
+		}
+
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(record.value(column, e.location))
+		if err != nil {
+			return err
+		}
+
+		if _, err := e.writer.Write(key); err != nil {
+			return err
+		}
+		if err := e.writer.WriteByte(':'); err != nil {
+			return err
+		}
+		if _, err := e.writer.Write(value); err != nil {
+			return err
+		}
+	}
+
+	_, err := e.writer.WriteString("}\n")
+
+	return err
+}
+
+func (e *ndjsonExportEncoder) Close() error {
+	return e.writer.Flush()
+}
@@ -0,0 +1,47 @@
+package exports_domain
+
+import (
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+type ExportFormat string
+
+const (
+	CsvExport    ExportFormat = "csv"
+	NdjsonExport ExportFormat = "ndjson"
+)
+
+var exportFormats = map[string]struct{}{
+	CsvExport.Value():    {},
+	NdjsonExport.Value(): {},
+}
+
+// NewExportFormat defaults to csv, which is what auditors open.
+func NewExportFormat(value string) (ExportFormat, error) {
+	if value == "" {
+		return CsvExport, nil
+	}
+
+	formatValidator := domain_validation.NewDomainValidator(domain_validation.In(exportFormats))
+	if err := formatValidator.Validate(value, NewInvalidExport("format", "must be csv or ndjson")); err != nil {
+		return "", err
+	}
+
+	return ExportFormat(value), nil
+}
+
+func (ef ExportFormat) Value() string {
+	return string(ef)
+}
+
+func (ef ExportFormat) ContentType() string {
+	if ef == NdjsonExport {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+func (ef ExportFormat) Extension() string {
+	return ef.Value()
+}
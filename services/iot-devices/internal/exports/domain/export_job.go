@@ -0,0 +1,106 @@
+package exports_domain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const exportJobReasonMaxLength = 255
+
+type ExportJobStatus string
+
+const (
+	PendingExportJob   ExportJobStatus = "pending"
+	RunningExportJob   ExportJobStatus = "running"
+	CompletedExportJob ExportJobStatus = "completed"
+	FailedExportJob    ExportJobStatus = "failed"
+)
+
+func (ejs ExportJobStatus) Value() string {
+	return string(ejs)
+}
+
+// ExportJob is an export too large to stream while the client waits. A worker writes its
+// file to the storage, where the client downloads it from once completed.
+type ExportJob struct {
+	ID            string
+	Spec          ExportSpec
+	Status        ExportJobStatus
+	Rows          int
+	FailureReason string
+	RequestedAt   time.Time
+	StartedAt     *time.Time
+	CompletedAt   *time.Time
+}
+
+func NewExportJob(id string, spec ExportSpec, now time.Time) (ExportJob, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidExport("id", "must be a ULID")); err != nil {
+		return ExportJob{}, err
+	}
+
+	return ExportJob{ID: id, Spec: spec, Status: PendingExportJob, RequestedAt: now}, nil
+}
+
+func (ej ExportJob) FileKey() string {
+	return fmt.Sprintf("exports/%s/%s", ej.ID, url.PathEscape(ej.Spec.FileName()))
+}
+
+// Claimable tells whether a worker may run the job: it is pending, or the worker running
+// it started before staleBefore and is taken for dead.
+func (ej ExportJob) Claimable(staleBefore time.Time) bool {
+	if ej.Status == PendingExportJob {
+		return true
+	}
+
+	return ej.Status == RunningExportJob && ej.StartedAt != nil && ej.StartedAt.Before(staleBefore)
+}
+
+func (ej ExportJob) Started(now time.Time) ExportJob {
+	ej.Status = RunningExportJob
+	ej.StartedAt = &now
+
+	return ej
+}
+
+func (ej ExportJob) Completed(rows int, now time.Time) ExportJob {
+	ej.Status = CompletedExportJob
+	ej.Rows = rows
+	ej.FailureReason = ""
+	ej.CompletedAt = &now
+
+	return ej
+}
+
+func (ej ExportJob) Failed(reason string, now time.Time) ExportJob {
+	if len(reason) > exportJobReasonMaxLength {
+		reason = reason[:exportJobReasonMaxLength]
+	}
+
+	ej.Status = FailedExportJob
+	ej.FailureReason = reason
+	ej.CompletedAt = &now
+
+	return ej
+}
+
+type ExportJobRepository interface {
+	Save(ctx context.Context, job ExportJob) error
+	// Find returns nil when there is no job for the id
+	Find(ctx context.Context, id string) (*ExportJob, error)
+	// SearchClaimable returns the pending jobs and the running ones started before
+	// staleBefore, oldest first
+	SearchClaimable(ctx context.Context, staleBefore time.Time, limit int) ([]ExportJob, error)
+}
+
+// ExportFileStorage keeps the files of the export jobs and hands out the time limited
+// urls to download them.
+type ExportFileStorage interface {
+	// Put reads the content until EOF, without knowing its size in advance
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	DownloadUrl(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,24 @@
+package exports_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const exportJobNotExistsErrorMessage = "Export job not exists"
+
+type ExportJobNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ejne ExportJobNotExists) Error() string {
+	return exportJobNotExistsErrorMessage
+}
+
+func (ejne ExportJobNotExists) ExtraItems() map[string]interface{} {
+	return ejne.items
+}
+
+func NewExportJobNotExists(id string) *ExportJobNotExists {
+	return &ExportJobNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,107 @@
+package exports_domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	_ "time/tzdata"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const exportSiteIdMaxLength = 50
+
+// ExportSpec is what to export: the trap activity of a site over a period, in a format,
+// with the columns asked for and the times told in the timezone of the site.
+type ExportSpec struct {
+	SiteID   string
+	From     time.Time
+	To       time.Time
+	Format   ExportFormat
+	Columns  []string
+	Timezone string
+}
+
+// NewExportSpec defaults to every column, in csv and with UTC times.
+func NewExportSpec(
+	siteID string,
+	from time.Time,
+	to time.Time,
+	format string,
+	columns []string,
+	timezone string,
+) (ExportSpec, error) {
+	siteIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(exportSiteIdMaxLength),
+	)
+	if err := siteIdValidator.Validate(siteID, NewInvalidExport("site_id", "must be a non empty string")); err != nil {
+		return ExportSpec{}, err
+	}
+
+	if from.IsZero() {
+		return ExportSpec{}, NewInvalidExport("from", "is required")
+	}
+	if to.IsZero() {
+		return ExportSpec{}, NewInvalidExport("to", "is required")
+	}
+	if !from.Before(to) {
+		return ExportSpec{}, NewInvalidExport("to", "must be after from")
+	}
+
+	exportFormat, err := NewExportFormat(format)
+	if err != nil {
+		return ExportSpec{}, err
+	}
+
+	if len(columns) == 0 {
+		columns = slices.Clone(TrapActivityColumns)
+	}
+	columnValidator := domain_validation.NewDomainValidator(domain_validation.In(trapActivityColumns))
+	for i, column := range columns {
+		if err := columnValidator.Validate(column, NewInvalidExport("columns", column+" is not a known column")); err != nil {
+			return ExportSpec{}, err
+		}
+		if slices.Contains(columns[:i], column) {
+			return ExportSpec{}, NewInvalidExport("columns", column+" is repeated")
+		}
+	}
+
+	if timezone == "" {
+		timezone = time.UTC.String()
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return ExportSpec{}, NewInvalidExport("timezone", "must be an IANA timezone")
+	}
+
+	return ExportSpec{
+		SiteID:   siteID,
+		From:     from,
+		To:       to,
+		Format:   exportFormat,
+		Columns:  columns,
+		Timezone: timezone,
+	}, nil
+}
+
+func (es ExportSpec) Location() *time.Location {
+	location, err := time.LoadLocation(es.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// FileName tells the site and the local days the export covers.
+func (es ExportSpec) FileName() string {
+	location := es.Location()
+
+	return fmt.Sprintf(
+		"trap-activity-%s-%s-%s.%s",
+		es.SiteID,
+		es.From.In(location).Format("20060102"),
+		es.To.In(location).Format("20060102"),
+		es.Format.Extension(),
+	)
+}
@@ -0,0 +1,111 @@
+package exports_domain_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+func TestNewExportSpec(t *testing.T) {
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("should default to every column in csv with UTC times", func(t *testing.T) {
+		spec, err := exports_domain.NewExportSpec("site-1", from, to, "", nil, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, exports_domain.CsvExport, spec.Format)
+		assert.Equal(t, exports_domain.TrapActivityColumns, spec.Columns)
+		assert.Equal(t, "UTC", spec.Timezone)
+		assert.Equal(t, "trap-activity-site-1-20260901-20261001.csv", spec.FileName())
+	})
+
+	t.Run("should name the file after the local days of the site", func(t *testing.T) {
+		spec, err := exports_domain.NewExportSpec("site-1", from.Add(-2*time.Hour), to.Add(-2*time.Hour), "ndjson", nil, "Europe/Madrid")
+
+		require.NoError(t, err)
+		assert.Equal(t, "trap-activity-site-1-20260901-20261001.ndjson", spec.FileName())
+	})
+
+	for name, spec := range map[string]struct {
+		from     time.Time
+		to       time.Time
+		format   string
+		columns  []string
+		timezone string
+		err      error
+	}{
+		"an empty period":     {from: to, to: from, err: &exports_domain.InvalidExport{}},
+		"an unknown format":   {from: from, to: to, format: "xlsx", err: &domain_validation.DomainValidationError{}},
+		"an unknown column":   {from: from, to: to, columns: []string{"recorded_at", "password"}, err: &domain_validation.DomainValidationError{}},
+		"a repeated column":   {from: from, to: to, columns: []string{"device_id", "device_id"}, err: &exports_domain.InvalidExport{}},
+		"an unknown timezone": {from: from, to: to, timezone: "Mars/Olympus", err: &exports_domain.InvalidExport{}},
+	} {
+		t.Run("should refuse "+name, func(t *testing.T) {
+			_, err := exports_domain.NewExportSpec("site-1", spec.from, spec.to, spec.format, spec.columns, spec.timezone)
+
+			assert.IsType(t, spec.err, err)
+		})
+	}
+}
+
+func TestExportEncoder(t *testing.T) {
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	records := []exports_domain.TrapActivityRecord{
+		{
+			ID:          "01JAAAAAAAAAAAAAAAAAAAAAAA",
+			DeviceID:    "trap-1",
+			DeviceModel: "snap-v2",
+			RecordedAt:  from.Add(8 * time.Hour),
+			ReceivedAt:  from.Add(8*time.Hour + time.Second),
+			Metrics:     map[string]float64{"trap_triggered": 1},
+		},
+		{
+			ID:         "01JBBBBBBBBBBBBBBBBBBBBBBB",
+			DeviceID:   "bait-1",
+			RecordedAt: from.Add(9 * time.Hour),
+			ReceivedAt: from.Add(30 * time.Hour),
+			Backfilled: true,
+			Metrics:    map[string]float64{"bait_consumed_g": 2.5},
+		},
+	}
+	encode := func(t *testing.T, spec exports_domain.ExportSpec) string {
+		var output bytes.Buffer
+		encoder, err := exports_domain.NewExportEncoder(spec, &output)
+		require.NoError(t, err)
+		for _, record := range records {
+			require.NoError(t, encoder.Encode(record))
+		}
+		require.NoError(t, encoder.Close())
+
+		return output.String()
+	}
+	columns := []string{"recorded_at", "device_id", "backfilled", "trap_triggered", "bait_consumed_g"}
+
+	t.Run("should write the asked columns in csv with the times of the site", func(t *testing.T) {
+		spec, err := exports_domain.NewExportSpec("site-1", from, from.AddDate(0, 0, 2), "csv", columns, "Europe/Madrid")
+		require.NoError(t, err)
+
+		assert.Equal(t, "recorded_at,device_id,backfilled,trap_triggered,bait_consumed_g\n"+
+			"2026-09-01T10:00:00+02:00,trap-1,false,1,\n"+
+			"2026-09-01T11:00:00+02:00,bait-1,true,,2.5\n", encode(t, spec))
+	})
+
+	t.Run("should write a json object per line with the missing metrics as null", func(t *testing.T) {
+		spec, err := exports_domain.NewExportSpec("site-1", from, from.AddDate(0, 0, 2), "ndjson", columns, "")
+		require.NoError(t, err)
+
+		assert.Equal(t,
+			`{"recorded_at":"2026-09-01T08:00:00Z","device_id":"trap-1","backfilled":false,"trap_triggered":1,"bait_consumed_g":null}`+"\n"+
+				`{"recorded_at":"2026-09-01T09:00:00Z","device_id":"bait-1","backfilled":true,"trap_triggered":null,"bait_consumed_g":2.5}`+"\n",
+			encode(t, spec),
+		)
+	})
+}
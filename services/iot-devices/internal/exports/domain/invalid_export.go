@@ -0,0 +1,24 @@
+package exports_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidExportErrorMessage = "Invalid export"
+
+type InvalidExport struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ie InvalidExport) Error() string {
+	return invalidExportErrorMessage
+}
+
+func (ie InvalidExport) ExtraItems() map[string]interface{} {
+	return ie.items
+}
+
+func NewInvalidExport(field string, reason string) *InvalidExport {
+	return &InvalidExport{items: map[string]interface{}{"field": field, "reason": reason}}
+}
@@ -0,0 +1,66 @@
+package exports_domain
+
+import (
+	"context"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+)
+
+// TrapActivityColumns are the columns of the trap activity logs, in the order they are
+// exported by default: the reading each pest event arrived in and its pest metrics.
+var TrapActivityColumns = append(
+	[]string{"id", "recorded_at", "received_at", "device_id", "device_model", "backfilled"},
+	pestcontrol_domain.PestMetrics...,
+)
+
+var trapActivityColumns = func() map[string]struct{} {
+	columns := make(map[string]struct{}, len(TrapActivityColumns))
+	for _, column := range TrapActivityColumns {
+		columns[column] = struct{}{}
+	}
+
+	return columns
+}()
+
+// TrapActivityRecord is a reading of a pest control device of the site, carrying at least
+// one of the pest metrics.
+type TrapActivityRecord struct {
+	ID          string
+	DeviceID    string
+	DeviceModel string
+	RecordedAt  time.Time
+	ReceivedAt  time.Time
+	Backfilled  bool
+	Metrics     map[string]float64
+}
+
+// value returns nil for the metrics the record does not carry.
+func (tar TrapActivityRecord) value(column string, location *time.Location) interface{} {
+	switch column {
+	case "id":
+		return tar.ID
+	case "recorded_at":
+		return tar.RecordedAt.In(location).Format(time.RFC3339)
+	case "received_at":
+		return tar.ReceivedAt.In(location).Format(time.RFC3339)
+	case "device_id":
+		return tar.DeviceID
+	case "device_model":
+		return tar.DeviceModel
+	case "backfilled":
+		return tar.Backfilled
+	}
+
+	if metric, ok := tar.Metrics[column]; ok {
+		return metric
+	}
+
+	return nil
+}
+
+type TrapActivityReader interface {
+	// Stream hands the records of the devices of the site recorded in [from, to) to
+	// handle one at a time, oldest first, stopping at the first error handle returns
+	Stream(ctx context.Context, siteID string, from time.Time, to time.Time, handle func(TrapActivityRecord) error) error
+}
@@ -0,0 +1,246 @@
+package exports_http
+
+import (
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	exports_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/application"
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_logger "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const (
+	// Once streaming, failures can no longer change the status code, so the trailers tell
+	// whether the export is complete and how many records it has
+	ExportStatusTrailer = "X-Export-Status"
+	ExportRowsTrailer   = "X-Export-Rows"
+
+	completeExportStatus   = "complete"
+	incompleteExportStatus = "incomplete"
+)
+
+// NewExportTrapActivityController streams the trap activity of the site while it is read.
+// Periods longer than maxStreamedPeriod are refused, to be requested as export jobs.
+func NewExportTrapActivityController(
+	exporter *exports_application.TrapActivityExporter,
+	maxStreamedPeriod time.Duration,
+	logger amf_logger.Logger,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		spec, err := exportSpecFrom(
+			mux.Vars(r)["siteId"],
+			params.Get("filter[from]"),
+			params.Get("filter[to]"),
+			params.Get("format"),
+			columnsFrom(params.Get("fields[trap_activity]")),
+			params.Get("timezone"),
+		)
+		if err == nil && spec.To.Sub(spec.From) > maxStreamedPeriod {
+			err = exports_domain.NewInvalidExport(
+				"to",
+				fmt.Sprintf("periods longer than %s must be requested as export jobs", maxStreamedPeriod),
+			)
+		}
+		if err != nil {
+			writeExportError(w, r, jarm, err)
+			return
+		}
+
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", spec.Format.ContentType())
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": spec.FileName()}))
+		w.Header().Set("Trailer", ExportStatusTrailer+", "+ExportRowsTrailer)
+		w.WriteHeader(http.StatusOK)
+
+		rows, err := exporter.Export(r.Context(), spec, w)
+		w.Header().Set(ExportRowsTrailer, strconv.Itoa(rows))
+		if err != nil {
+			w.Header().Set(ExportStatusTrailer, incompleteExportStatus)
+			logger.Error(
+				r.Context(),
+				"trap activity export interrupted",
+				slog.String("site_id", spec.SiteID),
+				slog.Int("rows", rows),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+		w.Header().Set(ExportStatusTrailer, completeExportStatus)
+	}
+}
+
+func NewRequestTrapActivityExportController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		from, to, err := exportPeriodFrom(stringAttribute(requestParams, "from"), stringAttribute(requestParams, "to"))
+		if err != nil {
+			writeExportError(w, r, jarm, err)
+			return
+		}
+
+		command := &exports_application.RequestTrapActivityExportCommand{
+			ID:       ulidProvider.New().String(),
+			SiteID:   mux.Vars(r)["siteId"],
+			From:     from,
+			To:       to,
+			Format:   stringAttribute(requestParams, "format"),
+			Columns:  stringsAttribute(requestParams, "columns"),
+			Timezone: stringAttribute(requestParams, "timezone"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeExportError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &exports_application.FindExportJobQuery{ID: command.ID}, http.StatusAccepted)
+	}
+}
+
+func NewGetExportJobController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &exports_application.FindExportJobQuery{ID: mux.Vars(r)["exportId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func exportSpecFrom(
+	siteID string,
+	from string,
+	to string,
+	format string,
+	columns []string,
+	timezone string,
+) (exports_domain.ExportSpec, error) {
+	fromTime, toTime, err := exportPeriodFrom(from, to)
+	if err != nil {
+		return exports_domain.ExportSpec{}, err
+	}
+
+	return exports_domain.NewExportSpec(siteID, fromTime, toTime, format, columns, timezone)
+}
+
+// exportPeriodFrom parses the period, which is required.
+func exportPeriodFrom(from string, to string) (time.Time, time.Time, error) {
+	fromTime, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, exports_domain.NewInvalidExport("from", "must be a RFC3339 date")
+	}
+
+	toTime, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, exports_domain.NewInvalidExport("to", "must be a RFC3339 date")
+	}
+
+	return fromTime, toTime, nil
+}
+
+func columnsFrom(fields string) []string {
+	if fields == "" {
+		return nil
+	}
+
+	return strings.Split(fields, ",")
+}
+
+func writeQueryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query amf_bus.Dto,
+	statusCode int,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), query)
+	if err != nil {
+		writeExportError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, statusCode)
+}
+
+func writeExportError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *exports_domain.ExportJobNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *exports_domain.InvalidExport:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		writeInternalServerError(w, r, jarm, err)
+	}
+}
+
+func writeInternalServerError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+	jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+}
+
+func stringAttribute(requestParams map[string]interface{}, attribute string) string {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, "").(string)
+
+	return value
+}
+
+func stringsAttribute(requestParams map[string]interface{}, attribute string) []string {
+	values, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, nil).([]interface{})
+
+	strings := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			strings = append(strings, str)
+		}
+	}
+
+	return strings
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
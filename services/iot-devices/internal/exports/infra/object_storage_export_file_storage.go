@@ -0,0 +1,27 @@
+package exports_infra
+
+import (
+	"context"
+	"io"
+	"time"
+
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+)
+
+// ObjectStorageExportFileStorage keeps the export files in the object storage, which signs
+// the urls to download them.
+type ObjectStorageExportFileStorage struct {
+	storage amf_object_storage.ObjectStorage
+}
+
+func NewObjectStorageExportFileStorage(storage amf_object_storage.ObjectStorage) *ObjectStorageExportFileStorage {
+	return &ObjectStorageExportFileStorage{storage: storage}
+}
+
+func (s *ObjectStorageExportFileStorage) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	return s.storage.Put(ctx, key, content, -1, contentType)
+}
+
+func (s *ObjectStorageExportFileStorage) DownloadUrl(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.storage.PresignGet(ctx, key, expiry)
+}
@@ -0,0 +1,167 @@
+package exports_infra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	exportJobColumns = `id, site_id, from_at, to_at, format, columns, timezone, status, row_count, failure_reason, requested_at, started_at, completed_at`
+
+	upsertExportJobQuery = `
+INSERT INTO export_jobs (` + exportJobColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (id) DO UPDATE SET
+    status = EXCLUDED.status,
+    row_count = EXCLUDED.row_count,
+    failure_reason = EXCLUDED.failure_reason,
+    started_at = EXCLUDED.started_at,
+    completed_at = EXCLUDED.completed_at`
+	findExportJobQuery = `SELECT ` + exportJobColumns + ` FROM export_jobs
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR site_id IN (SELECT id FROM sites WHERE tenant_id = $2))`
+	searchClaimableExportJobQuery = `SELECT ` + exportJobColumns + ` FROM export_jobs
+WHERE (status = 'pending' OR (status = 'running' AND started_at < $1))
+  AND ($3::VARCHAR IS NULL OR site_id IN (SELECT id FROM sites WHERE tenant_id = $3))
+ORDER BY requested_at, id
+LIMIT $2`
+)
+
+type PostgresExportJobRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresExportJobRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresExportJobRepository {
+	return &PostgresExportJobRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresExportJobRepository) Save(ctx context.Context, job exports_domain.ExportJob) error {
+	columns, err := json.Marshal(job.Spec.Columns)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertExportJobQuery,
+		job.ID,
+		job.Spec.SiteID,
+		job.Spec.From.UTC(),
+		job.Spec.To.UTC(),
+		job.Spec.Format.Value(),
+		string(columns),
+		job.Spec.Timezone,
+		job.Status.Value(),
+		job.Rows,
+		job.FailureReason,
+		job.RequestedAt.UTC(),
+		nullTime(job.StartedAt),
+		nullTime(job.CompletedAt),
+	)
+
+	return err
+}
+
+// Find and SearchClaimable read from the writer, as a lagging replica would show the jobs
+// already claimed as still pending and let another worker run them too.
+func (r *PostgresExportJobRepository) Find(ctx context.Context, id string) (*exports_domain.ExportJob, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := scanExportJob(r.connectionPool.Writer().QueryRowContext(ctx, findExportJobQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *PostgresExportJobRepository) SearchClaimable(
+	ctx context.Context,
+	staleBefore time.Time,
+	limit int,
+) ([]exports_domain.ExportJob, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Writer().QueryContext(ctx, searchClaimableExportJobQuery, staleBefore.UTC(), limit, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	jobs := make([]exports_domain.ExportJob, 0)
+	for rows.Next() {
+		job, err := scanExportJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, rows.Err()
+}
+
+func scanExportJob(row rowScanner) (exports_domain.ExportJob, error) {
+	var (
+		job                    exports_domain.ExportJob
+		format, status         string
+		columns                []byte
+		startedAt, completedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&job.ID,
+		&job.Spec.SiteID,
+		&job.Spec.From,
+		&job.Spec.To,
+		&format,
+		&columns,
+		&job.Spec.Timezone,
+		&status,
+		&job.Rows,
+		&job.FailureReason,
+		&job.RequestedAt,
+		&startedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return exports_domain.ExportJob{}, err
+	}
+
+	if err := json.Unmarshal(columns, &job.Spec.Columns); err != nil {
+		return exports_domain.ExportJob{}, err
+	}
+	job.Spec.Format = exports_domain.ExportFormat(format)
+	job.Status = exports_domain.ExportJobStatus(status)
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+
+	return job, nil
+}
+
+func nullTime(at *time.Time) sql.NullTime {
+	if at == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: at.UTC(), Valid: true}
+}
@@ -0,0 +1,131 @@
+package exports_infra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	declareTrapActivityCursorQuery = `
+DECLARE trap_activity_cursor NO SCROLL CURSOR FOR
+SELECT r.id, r.device_id, COALESCE(d.model, ''), r.recorded_at, r.received_at, r.backfilled, r.metrics
+FROM telemetry_readings r
+JOIN spcd_iot_devices d ON d.id = r.device_id
+WHERE d.site_id = $1 AND r.recorded_at >= $2 AND r.recorded_at < $3 AND r.metrics ?| $4
+  AND ($5::VARCHAR IS NULL OR d.tenant_id = $5)
+ORDER BY r.recorded_at, r.id`
+	fetchTrapActivityQuery = `FETCH FORWARD %d FROM trap_activity_cursor`
+)
+
+// PostgresTrapActivityReader reads the records through a server side cursor on the
+// readers, fetchSize at a time, so neither the database nor the service ever hold the
+// whole period.
+type PostgresTrapActivityReader struct {
+	connectionPool amf_sqldb.ConnectionPool
+	fetchSize      int
+}
+
+func NewPostgresTrapActivityReader(connectionPool amf_sqldb.ConnectionPool, fetchSize int) *PostgresTrapActivityReader {
+	return &PostgresTrapActivityReader{connectionPool: connectionPool, fetchSize: fetchSize}
+}
+
+func (r *PostgresTrapActivityReader) Stream(
+	ctx context.Context,
+	siteID string,
+	from time.Time,
+	to time.Time,
+	handle func(exports_domain.TrapActivityRecord) error,
+) error {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Cursors only live within a transaction, and rolling it back closes the cursor
+	tx, err := r.connectionPool.Reader().BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, declareTrapActivityCursorQuery, siteID, from.UTC(), to.UTC(), pq.Array(pestcontrol_domain.PestMetrics), scope)
+	if err != nil {
+		return err
+	}
+
+	for {
+		fetched, err := r.fetch(ctx, tx, handle)
+		if err != nil {
+			return err
+		}
+		if fetched < r.fetchSize {
+			return nil
+		}
+	}
+}
+
+func (r *PostgresTrapActivityReader) fetch(
+	ctx context.Context,
+	tx *sql.Tx,
+	handle func(exports_domain.TrapActivityRecord) error,
+) (int, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(fetchTrapActivityQuery, r.fetchSize))
+	if err != nil {
+		return 0, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	fetched := 0
+	for rows.Next() {
+		record, err := scanTrapActivityRecord(rows)
+		if err != nil {
+			return fetched, err
+		}
+		if err := handle(record); err != nil {
+			return fetched, err
+		}
+		fetched++
+	}
+
+	return fetched, rows.Err()
+}
+
+func scanTrapActivityRecord(row rowScanner) (exports_domain.TrapActivityRecord, error) {
+	var (
+		record  exports_domain.TrapActivityRecord
+		metrics []byte
+	)
+	err := row.Scan(
+		&record.ID,
+		&record.DeviceID,
+		&record.DeviceModel,
+		&record.RecordedAt,
+		&record.ReceivedAt,
+		&record.Backfilled,
+		&metrics,
+	)
+	if err != nil {
+		return exports_domain.TrapActivityRecord{}, err
+	}
+
+	if err := json.Unmarshal(metrics, &record.Metrics); err != nil {
+		return exports_domain.TrapActivityRecord{}, err
+	}
+
+	return record, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
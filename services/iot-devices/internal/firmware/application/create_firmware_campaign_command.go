@@ -0,0 +1,20 @@
+package firmware_application
+
+const CreateFirmwareCampaignCommandName = "CreateFirmwareCampaignCommand"
+
+// CreateFirmwareCampaignCommand targets the devices matching the site, model and
+// device list given, skipping the ones the firmware is not compatible with.
+type CreateFirmwareCampaignCommand struct {
+	ID               string
+	TenantID         string
+	FirmwareID       string
+	SiteID           string
+	Model            string
+	DeviceIDs        []string
+	Stages           []int
+	FailureThreshold float64
+}
+
+func (c CreateFirmwareCampaignCommand) Type() string {
+	return CreateFirmwareCampaignCommandName
+}
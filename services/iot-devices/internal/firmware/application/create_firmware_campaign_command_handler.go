@@ -0,0 +1,97 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type CreateFirmwareCampaignCommandHandler struct {
+	images       firmware_domain.FirmwareImageRepository
+	campaigns    firmware_domain.FirmwareCampaignRepository
+	updates      firmware_domain.FirmwareUpdateRepository
+	catalog      firmware_domain.FirmwareDeviceCatalog
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewCreateFirmwareCampaignCommandHandler(
+	images firmware_domain.FirmwareImageRepository,
+	campaigns firmware_domain.FirmwareCampaignRepository,
+	updates firmware_domain.FirmwareUpdateRepository,
+	catalog firmware_domain.FirmwareDeviceCatalog,
+	timeProvider amf_utils.DateTimeProvider,
+) *CreateFirmwareCampaignCommandHandler {
+	return &CreateFirmwareCampaignCommandHandler{
+		images:       images,
+		campaigns:    campaigns,
+		updates:      updates,
+		catalog:      catalog,
+		timeProvider: timeProvider,
+	}
+}
+
+func (h CreateFirmwareCampaignCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*CreateFirmwareCampaignCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	image, err := h.images.Find(ctx, cmd.FirmwareID)
+	if err != nil {
+		return err
+	}
+	if image == nil {
+		return firmware_domain.NewFirmwareImageNotExists(cmd.FirmwareID)
+	}
+
+	now := h.timeProvider.Now()
+	campaign, err := firmware_domain.NewFirmwareCampaign(
+		cmd.ID,
+		cmd.TenantID,
+		cmd.FirmwareID,
+		firmware_domain.FirmwareCohort{SiteID: cmd.SiteID, Model: cmd.Model, DeviceIDs: cmd.DeviceIDs},
+		cmd.Stages,
+		cmd.FailureThreshold,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	targets, err := h.catalog.SearchCohort(ctx, campaign.Cohort)
+	if err != nil {
+		return err
+	}
+
+	deviceIDs := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if image.CompatibleWith(target.Model) {
+			deviceIDs = append(deviceIDs, target.DeviceID)
+		}
+	}
+	if len(deviceIDs) == 0 {
+		return firmware_domain.NewInvalidFirmwareCampaign(cmd.ID, "cohort", "has no device compatible with the firmware")
+	}
+
+	updates := make([]firmware_domain.FirmwareUpdate, 0, len(deviceIDs))
+	for deviceID, stage := range firmware_domain.AssignFirmwareStages(campaign, deviceIDs) {
+		updates = append(updates, firmware_domain.FirmwareUpdate{
+			CampaignID: campaign.ID,
+			DeviceID:   deviceID,
+			FirmwareID: campaign.FirmwareID,
+			Stage:      stage,
+			Status:     firmware_domain.PendingFirmwareUpdate,
+			UpdatedAt:  now,
+		})
+	}
+
+	// The updates go first, a campaign is never running without its devices
+	if err := h.updates.SaveAll(ctx, updates); err != nil {
+		return err
+	}
+
+	return h.campaigns.Save(ctx, campaign)
+}
@@ -0,0 +1,11 @@
+package firmware_application
+
+const FindDeviceFirmwareUpdateQueryName = "FindDeviceFirmwareUpdateQuery"
+
+type FindDeviceFirmwareUpdateQuery struct {
+	DeviceID string
+}
+
+func (q FindDeviceFirmwareUpdateQuery) Type() string {
+	return FindDeviceFirmwareUpdateQueryName
+}
@@ -0,0 +1,44 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindDeviceFirmwareUpdateQueryHandler struct {
+	images  firmware_domain.FirmwareImageRepository
+	updates firmware_domain.FirmwareUpdateRepository
+}
+
+func NewFindDeviceFirmwareUpdateQueryHandler(
+	images firmware_domain.FirmwareImageRepository,
+	updates firmware_domain.FirmwareUpdateRepository,
+) *FindDeviceFirmwareUpdateQueryHandler {
+	return &FindDeviceFirmwareUpdateQueryHandler{images: images, updates: updates}
+}
+
+// Handle returns nil when no update is released for the device.
+func (h FindDeviceFirmwareUpdateQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindDeviceFirmwareUpdateQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	update, err := h.updates.FindReleased(ctx, q.DeviceID)
+	if err != nil || update == nil {
+		return nil, err
+	}
+
+	image, err := h.images.Find(ctx, update.FirmwareID)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, firmware_domain.NewFirmwareImageNotExists(update.FirmwareID)
+	}
+
+	return NewDeviceFirmwareUpdateResponse(*update, *image), nil
+}
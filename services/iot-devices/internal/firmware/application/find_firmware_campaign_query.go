@@ -0,0 +1,11 @@
+package firmware_application
+
+const FindFirmwareCampaignQueryName = "FindFirmwareCampaignQuery"
+
+type FindFirmwareCampaignQuery struct {
+	ID string
+}
+
+func (q FindFirmwareCampaignQuery) Type() string {
+	return FindFirmwareCampaignQueryName
+}
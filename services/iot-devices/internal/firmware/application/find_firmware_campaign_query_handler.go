@@ -0,0 +1,31 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindFirmwareCampaignQueryHandler struct {
+	repository firmware_domain.FirmwareCampaignRepository
+}
+
+func NewFindFirmwareCampaignQueryHandler(repository firmware_domain.FirmwareCampaignRepository) *FindFirmwareCampaignQueryHandler {
+	return &FindFirmwareCampaignQueryHandler{repository: repository}
+}
+
+func (h FindFirmwareCampaignQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindFirmwareCampaignQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	campaign, err := findFirmwareCampaign(ctx, h.repository, q.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFirmwareCampaignResponse(campaign), nil
+}
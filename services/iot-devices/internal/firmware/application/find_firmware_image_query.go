@@ -0,0 +1,11 @@
+package firmware_application
+
+const FindFirmwareImageQueryName = "FindFirmwareImageQuery"
+
+type FindFirmwareImageQuery struct {
+	ID string
+}
+
+func (q FindFirmwareImageQuery) Type() string {
+	return FindFirmwareImageQueryName
+}
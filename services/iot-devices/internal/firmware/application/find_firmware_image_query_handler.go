@@ -0,0 +1,34 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindFirmwareImageQueryHandler struct {
+	repository firmware_domain.FirmwareImageRepository
+}
+
+func NewFindFirmwareImageQueryHandler(repository firmware_domain.FirmwareImageRepository) *FindFirmwareImageQueryHandler {
+	return &FindFirmwareImageQueryHandler{repository: repository}
+}
+
+func (h FindFirmwareImageQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindFirmwareImageQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	image, err := h.repository.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, firmware_domain.NewFirmwareImageNotExists(q.ID)
+	}
+
+	return NewFirmwareImageResponse(*image), nil
+}
@@ -0,0 +1,115 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const firmwareCampaignMutexKeyPrefix = "firmware_campaign:"
+
+// FirmwareCampaignProgressor moves the running campaigns forward: it halts the ones
+// whose released updates fail too often and releases the next stage of the others
+// once their released devices finished updating.
+type FirmwareCampaignProgressor struct {
+	campaigns    firmware_domain.FirmwareCampaignRepository
+	updates      firmware_domain.FirmwareUpdateRepository
+	eventBus     amf_event_bus.Bus
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewFirmwareCampaignProgressor(
+	campaigns firmware_domain.FirmwareCampaignRepository,
+	updates firmware_domain.FirmwareUpdateRepository,
+	eventBus amf_event_bus.Bus,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+) *FirmwareCampaignProgressor {
+	return &FirmwareCampaignProgressor{
+		campaigns:    campaigns,
+		updates:      updates,
+		eventBus:     eventBus,
+		mutex:        mutex,
+		timeProvider: timeProvider,
+	}
+}
+
+// Run releases the next stage of the running campaigns, or halts the ones failing.
+func (fcp *FirmwareCampaignProgressor) Run(ctx context.Context) error {
+	running, err := fcp.campaigns.SearchRunning(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range running {
+		if err := fcp.progress(ctx, campaign.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// progress holds the lock of the campaign, which is shared with the halt and resume
+// commands, and skips the campaigns halted meanwhile.
+func (fcp *FirmwareCampaignProgressor) progress(ctx context.Context, id string) error {
+	progressed, err := fcp.mutex.Mutex(ctx, firmwareCampaignMutexKeyPrefix+id, func() (interface{}, error) {
+		campaign, err := findFirmwareCampaign(ctx, fcp.campaigns, id)
+		if err != nil {
+			return nil, err
+		}
+		if campaign.Status != firmware_domain.RunningFirmwareCampaign {
+			return nil, nil
+		}
+
+		stats, err := fcp.updates.Stats(ctx, campaign.ID, campaign.CurrentStage)
+		if err != nil {
+			return nil, err
+		}
+
+		progressed, changed := campaign.Progress(stats, fcp.timeProvider.Now())
+		if !changed {
+			return nil, nil
+		}
+
+		return &progressed, fcp.campaigns.Save(ctx, progressed)
+	})
+	if err != nil {
+		return err
+	}
+
+	campaign, ok := progressed.(*firmware_domain.FirmwareCampaign)
+	if !ok || campaign == nil {
+		return nil
+	}
+
+	switch campaign.Status {
+	case firmware_domain.HaltedFirmwareCampaign:
+		fcp.eventBus.Publish(firmware_domain.NewFirmwareCampaignHalted(*campaign))
+	case firmware_domain.CompletedFirmwareCampaign:
+		fcp.eventBus.Publish(firmware_domain.NewFirmwareCampaignCompleted(*campaign))
+	}
+
+	return nil
+}
+
+func findFirmwareCampaign(
+	ctx context.Context,
+	repository firmware_domain.FirmwareCampaignRepository,
+	id string,
+) (firmware_domain.FirmwareCampaign, error) {
+	campaign, err := repository.Find(ctx, id)
+	if err != nil {
+		return firmware_domain.FirmwareCampaign{}, err
+	}
+	if campaign == nil {
+		return firmware_domain.FirmwareCampaign{}, firmware_domain.NewFirmwareCampaignNotExists(id)
+	}
+
+	return *campaign, nil
+}
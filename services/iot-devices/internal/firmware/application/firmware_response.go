@@ -0,0 +1,121 @@
+package firmware_application
+
+import (
+	"time"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+)
+
+type FirmwareImageResponse struct {
+	ID         string   `jsonapi:"primary,firmware_images"`
+	Version    string   `jsonapi:"attr,version"`
+	Models     []string `jsonapi:"attr,models"`
+	Size       int64    `jsonapi:"attr,size"`
+	Checksum   string   `jsonapi:"attr,checksum"`
+	ChunkSize  int64    `jsonapi:"attr,chunk_size"`
+	Chunks     int      `jsonapi:"attr,chunks"`
+	UploadedAt string   `jsonapi:"attr,uploaded_at"`
+}
+
+func NewFirmwareImageResponse(image firmware_domain.FirmwareImage) *FirmwareImageResponse {
+	return &FirmwareImageResponse{
+		ID:         image.ID,
+		Version:    image.Version,
+		Models:     image.Models,
+		Size:       image.Size,
+		Checksum:   image.Checksum,
+		ChunkSize:  image.ChunkSize,
+		Chunks:     image.ChunkCount(),
+		UploadedAt: image.UploadedAt.Format(time.RFC3339),
+	}
+}
+
+type FirmwareCampaignResponse struct {
+	ID               string   `jsonapi:"primary,firmware_campaigns"`
+	TenantID         string   `jsonapi:"attr,tenant_id"`
+	FirmwareID       string   `jsonapi:"attr,firmware_id"`
+	SiteID           string   `jsonapi:"attr,site_id,omitempty"`
+	Model            string   `jsonapi:"attr,model,omitempty"`
+	DeviceIDs        []string `jsonapi:"attr,device_ids,omitempty"`
+	Stages           []int    `jsonapi:"attr,stages"`
+	CurrentStage     int      `jsonapi:"attr,current_stage"`
+	FailureThreshold float64  `jsonapi:"attr,failure_threshold"`
+	Status           string   `jsonapi:"attr,status"`
+	HaltReason       string   `jsonapi:"attr,halt_reason,omitempty"`
+	CreatedAt        string   `jsonapi:"attr,created_at"`
+	UpdatedAt        string   `jsonapi:"attr,updated_at"`
+}
+
+func NewFirmwareCampaignResponse(campaign firmware_domain.FirmwareCampaign) *FirmwareCampaignResponse {
+	return &FirmwareCampaignResponse{
+		ID:               campaign.ID,
+		TenantID:         campaign.TenantID,
+		FirmwareID:       campaign.FirmwareID,
+		SiteID:           campaign.Cohort.SiteID,
+		Model:            campaign.Cohort.Model,
+		DeviceIDs:        campaign.Cohort.DeviceIDs,
+		Stages:           campaign.Stages,
+		CurrentStage:     campaign.CurrentStage,
+		FailureThreshold: campaign.FailureThreshold,
+		Status:           campaign.Status.Value(),
+		HaltReason:       campaign.HaltReason,
+		CreatedAt:        campaign.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        campaign.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type FirmwareUpdateResponse struct {
+	ID         string `jsonapi:"primary,firmware_updates"`
+	CampaignID string `jsonapi:"attr,campaign_id"`
+	FirmwareID string `jsonapi:"attr,firmware_id"`
+	Stage      int    `jsonapi:"attr,stage"`
+	Status     string `jsonapi:"attr,status"`
+	Error      string `jsonapi:"attr,error,omitempty"`
+	UpdatedAt  string `jsonapi:"attr,updated_at"`
+}
+
+func NewFirmwareUpdateResponse(update firmware_domain.FirmwareUpdate) *FirmwareUpdateResponse {
+	return &FirmwareUpdateResponse{
+		ID:         update.DeviceID,
+		CampaignID: update.CampaignID,
+		FirmwareID: update.FirmwareID,
+		Stage:      update.Stage,
+		Status:     update.Status.Value(),
+		Error:      update.Error,
+		UpdatedAt:  update.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// DeviceFirmwareUpdateResponse is what a device needs to download an image: the
+// number of chunks, the checksum of each of them and the one of the whole image.
+type DeviceFirmwareUpdateResponse struct {
+	ID             string   `jsonapi:"primary,firmware_offers"`
+	CampaignID     string   `jsonapi:"attr,campaign_id"`
+	Version        string   `jsonapi:"attr,version"`
+	Size           int64    `jsonapi:"attr,size"`
+	Checksum       string   `jsonapi:"attr,checksum"`
+	ChunkSize      int64    `jsonapi:"attr,chunk_size"`
+	ChunkChecksums []string `jsonapi:"attr,chunk_checksums"`
+	Status         string   `jsonapi:"attr,status"`
+}
+
+func NewDeviceFirmwareUpdateResponse(update firmware_domain.FirmwareUpdate, image firmware_domain.FirmwareImage) *DeviceFirmwareUpdateResponse {
+	return &DeviceFirmwareUpdateResponse{
+		ID:             image.ID,
+		CampaignID:     update.CampaignID,
+		Version:        image.Version,
+		Size:           image.Size,
+		Checksum:       image.Checksum,
+		ChunkSize:      image.ChunkSize,
+		ChunkChecksums: image.ChunkChecksums,
+		Status:         update.Status.Value(),
+	}
+}
+
+type FirmwareChunkResponse struct {
+	FirmwareID string
+	Index      int
+	Offset     int64
+	Checksum   string
+	Content    []byte
+}
@@ -0,0 +1,227 @@
+package firmware_application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	firmware_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/application"
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+	firmware_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain/mocks"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type inProcessMutex struct{}
+
+func (m inProcessMutex) Mutex(_ context.Context, _ string, fn func() (interface{}, error)) (interface{}, error) {
+	return fn()
+}
+
+type eventRecorder chan amf_bus.Event
+
+func (r eventRecorder) Handle(event amf_bus.Event) error {
+	r <- event
+	return nil
+}
+
+func (r eventRecorder) next(t *testing.T) amf_bus.Event {
+	select {
+	case event := <-r:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+		return nil
+	}
+}
+
+func TestUploadAndDownloadFirmware(t *testing.T) {
+	ctx := context.Background()
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	storage := amf_object_storage.NewFilesystemObjectStorage(t.TempDir())
+	content, signature := []byte("firmware-image-content"), []byte("signature")
+
+	t.Run("should store signed images and serve them in checksummed chunks", func(t *testing.T) {
+		images := firmware_domain_mocks.NewFirmwareImageRepository(t)
+		updates := firmware_domain_mocks.NewFirmwareUpdateRepository(t)
+		verifier := firmware_domain_mocks.NewFirmwareSignatureVerifier(t)
+		id := ulidProvider.New().String()
+
+		var saved firmware_domain.FirmwareImage
+		verifier.On("Verify", content, signature).Return(nil).Once()
+		images.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(firmware_domain.FirmwareImage)
+		}).Return(nil).Once()
+
+		uploader := firmware_application.NewUploadFirmwareImageCommandHandler(images, storage, verifier, 8, timeProvider)
+		require.NoError(t, uploader.Handle(ctx, &firmware_application.UploadFirmwareImageCommand{
+			ID:        id,
+			Version:   "1.2.0",
+			Models:    []string{"trap-v2"},
+			Content:   content,
+			Signature: signature,
+		}))
+		assert.Equal(t, 3, saved.ChunkCount())
+
+		updates.On("FindReleasedOf", ctx, "device-1", id).Return(&firmware_domain.FirmwareUpdate{
+			CampaignID: "campaign-1",
+			DeviceID:   "device-1",
+			FirmwareID: id,
+			Status:     firmware_domain.DownloadingFirmwareUpdate,
+		}, nil).Once()
+		images.On("Find", ctx, id).Return(&saved, nil).Once()
+
+		chunks := firmware_application.NewGetFirmwareChunkQueryHandler(images, updates, storage)
+		response, err := chunks.Handle(ctx, &firmware_application.GetFirmwareChunkQuery{DeviceID: "device-1", FirmwareID: id, Index: 2})
+
+		require.NoError(t, err)
+		chunk := response.(*firmware_application.FirmwareChunkResponse)
+		assert.Equal(t, []byte("ontent"), chunk.Content)
+		assert.Equal(t, firmware_domain.FirmwareChecksum([]byte("ontent")), chunk.Checksum)
+		assert.Equal(t, int64(16), chunk.Offset)
+	})
+
+	t.Run("should reject images with an invalid signature", func(t *testing.T) {
+		verifier := firmware_domain_mocks.NewFirmwareSignatureVerifier(t)
+		verifier.On("Verify", content, signature).Return(errors.New("does not match the content")).Once()
+
+		uploader := firmware_application.NewUploadFirmwareImageCommandHandler(
+			firmware_domain_mocks.NewFirmwareImageRepository(t), storage, verifier, 8, timeProvider,
+		)
+		err := uploader.Handle(ctx, &firmware_application.UploadFirmwareImageCommand{
+			ID:        ulidProvider.New().String(),
+			Version:   "1.2.0",
+			Models:    []string{"trap-v2"},
+			Content:   content,
+			Signature: signature,
+		})
+
+		assert.IsType(t, &firmware_domain.InvalidFirmwareImage{}, err)
+	})
+
+	t.Run("should not serve chunks to devices the firmware was not released to", func(t *testing.T) {
+		updates := firmware_domain_mocks.NewFirmwareUpdateRepository(t)
+		updates.On("FindReleasedOf", ctx, "device-2", "firmware-1").Return(nil, nil).Once()
+
+		chunks := firmware_application.NewGetFirmwareChunkQueryHandler(firmware_domain_mocks.NewFirmwareImageRepository(t), updates, storage)
+		_, err := chunks.Handle(ctx, &firmware_application.GetFirmwareChunkQuery{DeviceID: "device-2", FirmwareID: "firmware-1"})
+
+		assert.IsType(t, &firmware_domain.FirmwareUpdateNotExists{}, err)
+	})
+}
+
+func TestCreateFirmwareCampaign(t *testing.T) {
+	ctx := context.Background()
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+
+	t.Run("should only target the devices compatible with the firmware", func(t *testing.T) {
+		images := firmware_domain_mocks.NewFirmwareImageRepository(t)
+		campaigns := firmware_domain_mocks.NewFirmwareCampaignRepository(t)
+		updates := firmware_domain_mocks.NewFirmwareUpdateRepository(t)
+		catalog := firmware_domain_mocks.NewFirmwareDeviceCatalog(t)
+		cohort := firmware_domain.FirmwareCohort{SiteID: "site-1"}
+
+		images.On("Find", ctx, "firmware-1").Return(&firmware_domain.FirmwareImage{ID: "firmware-1", Models: []string{"trap-v2"}}, nil).Once()
+		catalog.On("SearchCohort", ctx, mock.MatchedBy(func(searched firmware_domain.FirmwareCohort) bool {
+			return searched.SiteID == cohort.SiteID
+		})).Return([]firmware_domain.FirmwareTarget{
+			{DeviceID: "device-1", Model: "trap-v2"},
+			{DeviceID: "device-2", Model: "trap-v1"},
+		}, nil).Once()
+		updates.On("SaveAll", ctx, mock.MatchedBy(func(saved []firmware_domain.FirmwareUpdate) bool {
+			return len(saved) == 1 && saved[0].DeviceID == "device-1" && saved[0].Status == firmware_domain.PendingFirmwareUpdate
+		})).Return(nil).Once()
+		campaigns.On("Save", ctx, mock.Anything).Return(nil).Once()
+
+		handler := firmware_application.NewCreateFirmwareCampaignCommandHandler(images, campaigns, updates, catalog, timeProvider)
+
+		require.NoError(t, handler.Handle(ctx, &firmware_application.CreateFirmwareCampaignCommand{
+			ID:               ulidProvider.New().String(),
+			FirmwareID:       "firmware-1",
+			SiteID:           cohort.SiteID,
+			Stages:           []int{50, 100},
+			FailureThreshold: 0.1,
+		}))
+	})
+}
+
+func TestFirmwareCampaignProgressor(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	running := firmware_domain.FirmwareCampaign{
+		ID:               "campaign-1",
+		FirmwareID:       "firmware-1",
+		Stages:           []int{10, 100},
+		FailureThreshold: 0.2,
+		Status:           firmware_domain.RunningFirmwareCampaign,
+	}
+
+	t.Run("should halt the campaigns failing too often", func(t *testing.T) {
+		campaigns := firmware_domain_mocks.NewFirmwareCampaignRepository(t)
+		updates := firmware_domain_mocks.NewFirmwareUpdateRepository(t)
+		eventBus := amf_event_bus.NewEventBus()
+		haltedEvents := make(eventRecorder, 1)
+		eventBus.Subscribe(firmware_domain.FirmwareCampaignHaltedEventName, haltedEvents)
+
+		campaigns.On("SearchRunning", ctx).Return([]firmware_domain.FirmwareCampaign{running}, nil).Once()
+		campaigns.On("Find", ctx, running.ID).Return(&running, nil).Once()
+		updates.On("Stats", ctx, running.ID, 0).Return(firmware_domain.FirmwareRolloutStats{Released: 10, Failed: 3}, nil).Once()
+		campaigns.On("Save", ctx, mock.MatchedBy(func(saved firmware_domain.FirmwareCampaign) bool {
+			return saved.Status == firmware_domain.HaltedFirmwareCampaign
+		})).Return(nil).Once()
+
+		progressor := firmware_application.NewFirmwareCampaignProgressor(campaigns, updates, eventBus, inProcessMutex{}, timeProvider)
+
+		require.NoError(t, progressor.Run(ctx))
+		assert.Equal(t, running.ID, haltedEvents.next(t).Data()["campaign_id"])
+	})
+
+	t.Run("should release the next stage once the released devices finished", func(t *testing.T) {
+		campaigns := firmware_domain_mocks.NewFirmwareCampaignRepository(t)
+		updates := firmware_domain_mocks.NewFirmwareUpdateRepository(t)
+
+		campaigns.On("SearchRunning", ctx).Return([]firmware_domain.FirmwareCampaign{running}, nil).Once()
+		campaigns.On("Find", ctx, running.ID).Return(&running, nil).Once()
+		updates.On("Stats", ctx, running.ID, 0).Return(firmware_domain.FirmwareRolloutStats{Released: 10, Installed: 9, Failed: 1}, nil).Once()
+		campaigns.On("Save", ctx, mock.MatchedBy(func(saved firmware_domain.FirmwareCampaign) bool {
+			return saved.Status == firmware_domain.RunningFirmwareCampaign && saved.CurrentStage == 1
+		})).Return(nil).Once()
+
+		progressor := firmware_application.NewFirmwareCampaignProgressor(
+			campaigns, updates, amf_event_bus.NewEventBus(), inProcessMutex{}, timeProvider,
+		)
+
+		require.NoError(t, progressor.Run(ctx))
+	})
+}
+
+func TestReportFirmwareUpdate(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+
+	t.Run("should refuse reports on finished updates", func(t *testing.T) {
+		updates := firmware_domain_mocks.NewFirmwareUpdateRepository(t)
+		updates.On("FindReleasedOf", ctx, "device-1", "firmware-1").Return(&firmware_domain.FirmwareUpdate{
+			DeviceID:   "device-1",
+			FirmwareID: "firmware-1",
+			Status:     firmware_domain.InstalledFirmwareUpdate,
+		}, nil).Once()
+
+		handler := firmware_application.NewReportFirmwareUpdateCommandHandler(updates, timeProvider)
+		err := handler.Handle(ctx, &firmware_application.ReportFirmwareUpdateCommand{
+			DeviceID:   "device-1",
+			FirmwareID: "firmware-1",
+			Status:     firmware_domain.FailedFirmwareUpdate.Value(),
+		})
+
+		assert.IsType(t, &firmware_domain.FirmwareUpdateTransitionNotAllowed{}, err)
+	})
+}
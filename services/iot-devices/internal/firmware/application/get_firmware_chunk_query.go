@@ -0,0 +1,13 @@
+package firmware_application
+
+const GetFirmwareChunkQueryName = "GetFirmwareChunkQuery"
+
+type GetFirmwareChunkQuery struct {
+	DeviceID   string
+	FirmwareID string
+	Index      int
+}
+
+func (q GetFirmwareChunkQuery) Type() string {
+	return GetFirmwareChunkQueryName
+}
@@ -0,0 +1,79 @@
+package firmware_application
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+)
+
+type GetFirmwareChunkQueryHandler struct {
+	images  firmware_domain.FirmwareImageRepository
+	updates firmware_domain.FirmwareUpdateRepository
+	storage amf_object_storage.ObjectStorage
+}
+
+func NewGetFirmwareChunkQueryHandler(
+	images firmware_domain.FirmwareImageRepository,
+	updates firmware_domain.FirmwareUpdateRepository,
+	storage amf_object_storage.ObjectStorage,
+) *GetFirmwareChunkQueryHandler {
+	return &GetFirmwareChunkQueryHandler{images: images, updates: updates, storage: storage}
+}
+
+// Handle serves the chunk only to the devices the firmware was released to. Chunks are
+// read one by one from the storage so a device resumes an interrupted download from
+// the first chunk it is missing, and each one is checked before leaving the service.
+func (h GetFirmwareChunkQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*GetFirmwareChunkQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	update, err := h.updates.FindReleasedOf(ctx, q.DeviceID, q.FirmwareID)
+	if err != nil {
+		return nil, err
+	}
+	if update == nil {
+		return nil, firmware_domain.NewFirmwareUpdateNotExists(q.DeviceID, q.FirmwareID)
+	}
+
+	image, err := h.images.Find(ctx, q.FirmwareID)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, firmware_domain.NewFirmwareImageNotExists(q.FirmwareID)
+	}
+
+	chunk, err := image.Chunk(q.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := h.storage.GetRange(ctx, image.StorageKey, chunk.Offset, chunk.Length)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if checksum := firmware_domain.FirmwareChecksum(content); checksum != chunk.Checksum {
+		return nil, fmt.Errorf("chunk %d of firmware %s is corrupted in the storage", chunk.Index, image.ID)
+	}
+
+	return &FirmwareChunkResponse{
+		FirmwareID: image.ID,
+		Index:      chunk.Index,
+		Offset:     chunk.Offset,
+		Checksum:   chunk.Checksum,
+		Content:    content,
+	}, nil
+}
@@ -0,0 +1,12 @@
+package firmware_application
+
+const HaltFirmwareCampaignCommandName = "HaltFirmwareCampaignCommand"
+
+type HaltFirmwareCampaignCommand struct {
+	ID     string
+	Reason string
+}
+
+func (c HaltFirmwareCampaignCommand) Type() string {
+	return HaltFirmwareCampaignCommandName
+}
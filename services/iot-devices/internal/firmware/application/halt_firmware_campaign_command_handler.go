@@ -0,0 +1,61 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type HaltFirmwareCampaignCommandHandler struct {
+	repository   firmware_domain.FirmwareCampaignRepository
+	eventBus     amf_event_bus.Bus
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewHaltFirmwareCampaignCommandHandler(
+	repository firmware_domain.FirmwareCampaignRepository,
+	eventBus amf_event_bus.Bus,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+) *HaltFirmwareCampaignCommandHandler {
+	return &HaltFirmwareCampaignCommandHandler{
+		repository:   repository,
+		eventBus:     eventBus,
+		mutex:        mutex,
+		timeProvider: timeProvider,
+	}
+}
+
+func (h HaltFirmwareCampaignCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*HaltFirmwareCampaignCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	halted, err := h.mutex.Mutex(ctx, firmwareCampaignMutexKeyPrefix+cmd.ID, func() (interface{}, error) {
+		campaign, err := findFirmwareCampaign(ctx, h.repository, cmd.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		halted, err := campaign.Halt(cmd.Reason, h.timeProvider.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return halted, h.repository.Save(ctx, halted)
+	})
+	if err != nil {
+		return err
+	}
+
+	h.eventBus.Publish(firmware_domain.NewFirmwareCampaignHalted(halted.(firmware_domain.FirmwareCampaign)))
+
+	return nil
+}
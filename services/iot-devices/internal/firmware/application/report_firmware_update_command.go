@@ -0,0 +1,14 @@
+package firmware_application
+
+const ReportFirmwareUpdateCommandName = "ReportFirmwareUpdateCommand"
+
+type ReportFirmwareUpdateCommand struct {
+	DeviceID   string
+	FirmwareID string
+	Status     string
+	Error      string
+}
+
+func (c ReportFirmwareUpdateCommand) Type() string {
+	return ReportFirmwareUpdateCommandName
+}
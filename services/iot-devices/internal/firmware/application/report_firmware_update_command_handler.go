@@ -0,0 +1,44 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type ReportFirmwareUpdateCommandHandler struct {
+	repository   firmware_domain.FirmwareUpdateRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewReportFirmwareUpdateCommandHandler(
+	repository firmware_domain.FirmwareUpdateRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *ReportFirmwareUpdateCommandHandler {
+	return &ReportFirmwareUpdateCommandHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h ReportFirmwareUpdateCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*ReportFirmwareUpdateCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	update, err := h.repository.FindReleasedOf(ctx, cmd.DeviceID, cmd.FirmwareID)
+	if err != nil {
+		return err
+	}
+	if update == nil {
+		return firmware_domain.NewFirmwareUpdateNotExists(cmd.DeviceID, cmd.FirmwareID)
+	}
+
+	reported, err := update.Report(firmware_domain.FirmwareUpdateStatus(cmd.Status), cmd.Error, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, reported)
+}
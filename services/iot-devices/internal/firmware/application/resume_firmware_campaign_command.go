@@ -0,0 +1,14 @@
+package firmware_application
+
+const ResumeFirmwareCampaignCommandName = "ResumeFirmwareCampaignCommand"
+
+// ResumeFirmwareCampaignCommand keeps the failure threshold of the campaign when
+// FailureThreshold is zero.
+type ResumeFirmwareCampaignCommand struct {
+	ID               string
+	FailureThreshold float64
+}
+
+func (c ResumeFirmwareCampaignCommand) Type() string {
+	return ResumeFirmwareCampaignCommandName
+}
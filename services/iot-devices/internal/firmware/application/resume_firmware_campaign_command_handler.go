@@ -0,0 +1,48 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type ResumeFirmwareCampaignCommandHandler struct {
+	repository   firmware_domain.FirmwareCampaignRepository
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewResumeFirmwareCampaignCommandHandler(
+	repository firmware_domain.FirmwareCampaignRepository,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+) *ResumeFirmwareCampaignCommandHandler {
+	return &ResumeFirmwareCampaignCommandHandler{repository: repository, mutex: mutex, timeProvider: timeProvider}
+}
+
+func (h ResumeFirmwareCampaignCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*ResumeFirmwareCampaignCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	_, err := h.mutex.Mutex(ctx, firmwareCampaignMutexKeyPrefix+cmd.ID, func() (interface{}, error) {
+		campaign, err := findFirmwareCampaign(ctx, h.repository, cmd.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		resumed, err := campaign.Resume(cmd.FailureThreshold, h.timeProvider.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, h.repository.Save(ctx, resumed)
+	})
+
+	return err
+}
@@ -0,0 +1,11 @@
+package firmware_application
+
+const SearchFirmwareCampaignDevicesQueryName = "SearchFirmwareCampaignDevicesQuery"
+
+type SearchFirmwareCampaignDevicesQuery struct {
+	CampaignID string
+}
+
+func (q SearchFirmwareCampaignDevicesQuery) Type() string {
+	return SearchFirmwareCampaignDevicesQueryName
+}
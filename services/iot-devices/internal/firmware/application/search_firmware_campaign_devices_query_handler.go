@@ -0,0 +1,44 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchFirmwareCampaignDevicesQueryHandler struct {
+	campaigns firmware_domain.FirmwareCampaignRepository
+	updates   firmware_domain.FirmwareUpdateRepository
+}
+
+func NewSearchFirmwareCampaignDevicesQueryHandler(
+	campaigns firmware_domain.FirmwareCampaignRepository,
+	updates firmware_domain.FirmwareUpdateRepository,
+) *SearchFirmwareCampaignDevicesQueryHandler {
+	return &SearchFirmwareCampaignDevicesQueryHandler{campaigns: campaigns, updates: updates}
+}
+
+func (h SearchFirmwareCampaignDevicesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchFirmwareCampaignDevicesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	if _, err := findFirmwareCampaign(ctx, h.campaigns, q.CampaignID); err != nil {
+		return nil, err
+	}
+
+	updates, err := h.updates.SearchByCampaign(ctx, q.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*FirmwareUpdateResponse, 0, len(updates))
+	for _, update := range updates {
+		response = append(response, NewFirmwareUpdateResponse(update))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,12 @@
+package firmware_application
+
+const SearchFirmwareCampaignsQueryName = "SearchFirmwareCampaignsQuery"
+
+type SearchFirmwareCampaignsQuery struct {
+	TenantID string
+	Status   string
+}
+
+func (q SearchFirmwareCampaignsQuery) Type() string {
+	return SearchFirmwareCampaignsQueryName
+}
@@ -0,0 +1,39 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchFirmwareCampaignsQueryHandler struct {
+	repository firmware_domain.FirmwareCampaignRepository
+}
+
+func NewSearchFirmwareCampaignsQueryHandler(repository firmware_domain.FirmwareCampaignRepository) *SearchFirmwareCampaignsQueryHandler {
+	return &SearchFirmwareCampaignsQueryHandler{repository: repository}
+}
+
+func (h SearchFirmwareCampaignsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchFirmwareCampaignsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	campaigns, err := h.repository.SearchByTenant(ctx, q.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*FirmwareCampaignResponse, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		if q.Status != "" && campaign.Status.Value() != q.Status {
+			continue
+		}
+		response = append(response, NewFirmwareCampaignResponse(campaign))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,11 @@
+package firmware_application
+
+const SearchFirmwareImagesQueryName = "SearchFirmwareImagesQuery"
+
+type SearchFirmwareImagesQuery struct {
+	Model string
+}
+
+func (q SearchFirmwareImagesQuery) Type() string {
+	return SearchFirmwareImagesQueryName
+}
@@ -0,0 +1,39 @@
+package firmware_application
+
+import (
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchFirmwareImagesQueryHandler struct {
+	repository firmware_domain.FirmwareImageRepository
+}
+
+func NewSearchFirmwareImagesQueryHandler(repository firmware_domain.FirmwareImageRepository) *SearchFirmwareImagesQueryHandler {
+	return &SearchFirmwareImagesQueryHandler{repository: repository}
+}
+
+func (h SearchFirmwareImagesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchFirmwareImagesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	images, err := h.repository.SearchAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*FirmwareImageResponse, 0, len(images))
+	for _, image := range images {
+		if q.Model != "" && !image.CompatibleWith(q.Model) {
+			continue
+		}
+		response = append(response, NewFirmwareImageResponse(image))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,17 @@
+package firmware_application
+
+const UploadFirmwareImageCommandName = "UploadFirmwareImageCommand"
+
+// UploadFirmwareImageCommand carries the whole image, which is bounded by the
+// maximum firmware size accepted by the API.
+type UploadFirmwareImageCommand struct {
+	ID        string
+	Version   string
+	Models    []string
+	Content   []byte
+	Signature []byte
+}
+
+func (c UploadFirmwareImageCommand) Type() string {
+	return UploadFirmwareImageCommandName
+}
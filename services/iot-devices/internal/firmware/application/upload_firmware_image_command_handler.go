@@ -0,0 +1,70 @@
+package firmware_application
+
+import (
+	"bytes"
+	"context"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const firmwareContentType = "application/octet-stream"
+
+type UploadFirmwareImageCommandHandler struct {
+	repository   firmware_domain.FirmwareImageRepository
+	storage      amf_object_storage.ObjectStorage
+	verifier     firmware_domain.FirmwareSignatureVerifier
+	chunkSize    int64
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewUploadFirmwareImageCommandHandler(
+	repository firmware_domain.FirmwareImageRepository,
+	storage amf_object_storage.ObjectStorage,
+	verifier firmware_domain.FirmwareSignatureVerifier,
+	chunkSize int64,
+	timeProvider amf_utils.DateTimeProvider,
+) *UploadFirmwareImageCommandHandler {
+	return &UploadFirmwareImageCommandHandler{
+		repository:   repository,
+		storage:      storage,
+		verifier:     verifier,
+		chunkSize:    chunkSize,
+		timeProvider: timeProvider,
+	}
+}
+
+// Handle stores the image before recording it, so a recorded image can always be
+// downloaded. An image whose signature does not match is rejected.
+func (h UploadFirmwareImageCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*UploadFirmwareImageCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	image, err := firmware_domain.NewFirmwareImage(
+		cmd.ID,
+		cmd.Version,
+		cmd.Models,
+		cmd.Content,
+		cmd.Signature,
+		h.chunkSize,
+		h.timeProvider.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := h.verifier.Verify(cmd.Content, cmd.Signature); err != nil {
+		return firmware_domain.NewInvalidFirmwareImage(cmd.ID, "signature", err.Error())
+	}
+
+	if err := h.storage.Put(ctx, image.StorageKey, bytes.NewReader(cmd.Content), image.Size, firmwareContentType); err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, image)
+}
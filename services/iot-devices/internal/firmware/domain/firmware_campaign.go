@@ -0,0 +1,222 @@
+package firmware_domain
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const firmwareCampaignTenantIdMaxLength = 50
+
+type FirmwareCampaignStatus string
+
+const (
+	RunningFirmwareCampaign   FirmwareCampaignStatus = "running"
+	HaltedFirmwareCampaign    FirmwareCampaignStatus = "halted"
+	CompletedFirmwareCampaign FirmwareCampaignStatus = "completed"
+)
+
+func (fcs FirmwareCampaignStatus) Value() string {
+	return string(fcs)
+}
+
+// FirmwareCohort selects the devices a campaign targets. Empty criteria match every
+// device.
+type FirmwareCohort struct {
+	SiteID    string
+	Model     string
+	DeviceIDs []string
+}
+
+// FirmwareRolloutStats count the updates released up to the current stage of a campaign.
+type FirmwareRolloutStats struct {
+	Released  int
+	Installed int
+	Failed    int
+}
+
+func (frs FirmwareRolloutStats) FailureRate() float64 {
+	if frs.Released == 0 {
+		return 0
+	}
+
+	return float64(frs.Failed) / float64(frs.Released)
+}
+
+// FirmwareCampaign rolls a firmware image out to a cohort of devices in stages. Stages
+// are the cumulative percentages of the cohort released, and the next one is released
+// once every device of the previous ones finished updating. The campaign halts by
+// itself when the share of failed updates crosses its threshold.
+type FirmwareCampaign struct {
+	ID               string
+	TenantID         string
+	FirmwareID       string
+	Cohort           FirmwareCohort
+	Stages           []int
+	CurrentStage     int
+	FailureThreshold float64
+	Status           FirmwareCampaignStatus
+	HaltReason       string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func NewFirmwareCampaign(
+	id string,
+	tenantID string,
+	firmwareID string,
+	cohort FirmwareCohort,
+	stages []int,
+	failureThreshold float64,
+	now time.Time,
+) (FirmwareCampaign, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidFirmwareCampaign(id, "id", "must be a ULID")); err != nil {
+		return FirmwareCampaign{}, err
+	}
+
+	tenantIdValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(firmwareCampaignTenantIdMaxLength))
+	if err := tenantIdValidator.Validate(tenantID, NewInvalidFirmwareCampaign(id, "tenant_id", "is too long")); err != nil {
+		return FirmwareCampaign{}, err
+	}
+
+	if len(stages) == 0 || stages[len(stages)-1] != 100 {
+		return FirmwareCampaign{}, NewInvalidFirmwareCampaign(id, "stages", "must end releasing 100 percent of the cohort")
+	}
+	for i, stage := range stages {
+		if stage <= 0 || (i > 0 && stage <= stages[i-1]) {
+			return FirmwareCampaign{}, NewInvalidFirmwareCampaign(id, "stages", "must be increasing percentages")
+		}
+	}
+
+	if failureThreshold <= 0 || failureThreshold > 1 {
+		return FirmwareCampaign{}, NewInvalidFirmwareCampaign(id, "failure_threshold", "must be a ratio between 0 and 1")
+	}
+
+	return FirmwareCampaign{
+		ID:               id,
+		TenantID:         tenantID,
+		FirmwareID:       firmwareID,
+		Cohort:           cohort,
+		Stages:           stages,
+		FailureThreshold: failureThreshold,
+		Status:           RunningFirmwareCampaign,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}, nil
+}
+
+// Progress halts the campaign when too many updates failed, or releases its next stage
+// when the released ones finished. It reports whether the campaign changed.
+func (fc FirmwareCampaign) Progress(stats FirmwareRolloutStats, now time.Time) (FirmwareCampaign, bool) {
+	if fc.Status != RunningFirmwareCampaign {
+		return fc, false
+	}
+
+	if stats.FailureRate() > fc.FailureThreshold {
+		fc.Status = HaltedFirmwareCampaign
+		fc.HaltReason = fmt.Sprintf("failure rate %.2f crossed the threshold %.2f", stats.FailureRate(), fc.FailureThreshold)
+		fc.UpdatedAt = now
+		return fc, true
+	}
+
+	if stats.Installed+stats.Failed < stats.Released {
+		return fc, false
+	}
+
+	if fc.CurrentStage == len(fc.Stages)-1 {
+		fc.Status = CompletedFirmwareCampaign
+	} else {
+		fc.CurrentStage++
+	}
+	fc.UpdatedAt = now
+
+	return fc, true
+}
+
+func (fc FirmwareCampaign) Halt(reason string, now time.Time) (FirmwareCampaign, error) {
+	if fc.Status != RunningFirmwareCampaign {
+		return FirmwareCampaign{}, NewFirmwareCampaignTransitionNotAllowed(fc.ID, fc.Status, HaltedFirmwareCampaign)
+	}
+
+	fc.Status = HaltedFirmwareCampaign
+	fc.HaltReason = reason
+	fc.UpdatedAt = now
+
+	return fc, nil
+}
+
+// Resume runs a halted campaign again, optionally tolerating more failures so it does
+// not halt again right away.
+func (fc FirmwareCampaign) Resume(failureThreshold float64, now time.Time) (FirmwareCampaign, error) {
+	if fc.Status != HaltedFirmwareCampaign {
+		return FirmwareCampaign{}, NewFirmwareCampaignTransitionNotAllowed(fc.ID, fc.Status, RunningFirmwareCampaign)
+	}
+	if failureThreshold < 0 || failureThreshold > 1 {
+		return FirmwareCampaign{}, NewInvalidFirmwareCampaign(fc.ID, "failure_threshold", "must be a ratio between 0 and 1")
+	}
+
+	if failureThreshold > 0 {
+		fc.FailureThreshold = failureThreshold
+	}
+	fc.Status = RunningFirmwareCampaign
+	fc.HaltReason = ""
+	fc.UpdatedAt = now
+
+	return fc, nil
+}
+
+// AssignFirmwareStages spreads the devices over the stages of the campaign. Devices are
+// shuffled by a hash of the campaign and the device, so every campaign releases its
+// first stages to a different, but stable, sample of the cohort.
+func AssignFirmwareStages(campaign FirmwareCampaign, deviceIDs []string) map[string]int {
+	shuffled := slices.Clone(deviceIDs)
+	slices.SortFunc(shuffled, func(a, b string) int {
+		if order := compareUint64(stageHash(campaign.ID, a), stageHash(campaign.ID, b)); order != 0 {
+			return order
+		}
+		return strings.Compare(a, b)
+	})
+
+	stages := make(map[string]int, len(shuffled))
+	stage := 0
+	for i, deviceID := range shuffled {
+		for i >= int(math.Ceil(float64(campaign.Stages[stage])*float64(len(shuffled))/100)) {
+			stage++
+		}
+		stages[deviceID] = stage
+	}
+
+	return stages
+}
+
+func stageHash(campaignID string, deviceID string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(campaignID + "/" + deviceID))
+
+	return hash.Sum64()
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type FirmwareCampaignRepository interface {
+	Save(ctx context.Context, campaign FirmwareCampaign) error
+	// Find returns nil when the campaign does not exist
+	Find(ctx context.Context, id string) (*FirmwareCampaign, error)
+	SearchByTenant(ctx context.Context, tenantID string) ([]FirmwareCampaign, error)
+	SearchRunning(ctx context.Context) ([]FirmwareCampaign, error)
+}
@@ -0,0 +1,38 @@
+package firmware_domain
+
+const (
+	FirmwareCampaignHaltedEventName    = "firmware.campaign_halted"
+	FirmwareCampaignCompletedEventName = "firmware.campaign_completed"
+)
+
+type FirmwareCampaignEvent struct {
+	name     string
+	campaign FirmwareCampaign
+}
+
+func NewFirmwareCampaignHalted(campaign FirmwareCampaign) FirmwareCampaignEvent {
+	return FirmwareCampaignEvent{name: FirmwareCampaignHaltedEventName, campaign: campaign}
+}
+
+func NewFirmwareCampaignCompleted(campaign FirmwareCampaign) FirmwareCampaignEvent {
+	return FirmwareCampaignEvent{name: FirmwareCampaignCompletedEventName, campaign: campaign}
+}
+
+func (fce FirmwareCampaignEvent) Name() string {
+	return fce.name
+}
+
+func (fce FirmwareCampaignEvent) Type() string {
+	return "domain_event"
+}
+
+func (fce FirmwareCampaignEvent) Data() map[string]interface{} {
+	return map[string]interface{}{
+		"campaign_id":   fce.campaign.ID,
+		"tenant_id":     fce.campaign.TenantID,
+		"firmware_id":   fce.campaign.FirmwareID,
+		"status":        fce.campaign.Status.Value(),
+		"current_stage": fce.campaign.CurrentStage,
+		"halt_reason":   fce.campaign.HaltReason,
+	}
+}
@@ -0,0 +1,24 @@
+package firmware_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const firmwareCampaignNotExistsErrorMessage = "Firmware campaign not exists"
+
+type FirmwareCampaignNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (fcne FirmwareCampaignNotExists) Error() string {
+	return firmwareCampaignNotExistsErrorMessage
+}
+
+func (fcne FirmwareCampaignNotExists) ExtraItems() map[string]interface{} {
+	return fcne.items
+}
+
+func NewFirmwareCampaignNotExists(id string) *FirmwareCampaignNotExists {
+	return &FirmwareCampaignNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,105 @@
+package firmware_domain_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestFirmwareCampaign(t *testing.T) {
+	id, now := amf_utils.NewUlid().String(), time.Now()
+	newCampaign := func(t *testing.T) firmware_domain.FirmwareCampaign {
+		campaign, err := firmware_domain.NewFirmwareCampaign(id, "", "firmware-1", firmware_domain.FirmwareCohort{}, []int{10, 50, 100}, 0.2, now)
+		require.NoError(t, err)
+		return campaign
+	}
+
+	t.Run("should reject stages not releasing the whole cohort", func(t *testing.T) {
+		_, err := firmware_domain.NewFirmwareCampaign(id, "", "firmware-1", firmware_domain.FirmwareCohort{}, []int{10, 50}, 0.2, now)
+
+		assert.IsType(t, &firmware_domain.InvalidFirmwareCampaign{}, err)
+	})
+
+	t.Run("should reject decreasing stages", func(t *testing.T) {
+		_, err := firmware_domain.NewFirmwareCampaign(id, "", "firmware-1", firmware_domain.FirmwareCohort{}, []int{50, 10, 100}, 0.2, now)
+
+		assert.IsType(t, &firmware_domain.InvalidFirmwareCampaign{}, err)
+	})
+
+	t.Run("should wait for the released devices to finish", func(t *testing.T) {
+		_, changed := newCampaign(t).Progress(firmware_domain.FirmwareRolloutStats{Released: 10, Installed: 9}, now)
+
+		assert.False(t, changed)
+	})
+
+	t.Run("should release the next stage once the released devices finished", func(t *testing.T) {
+		progressed, changed := newCampaign(t).Progress(firmware_domain.FirmwareRolloutStats{Released: 10, Installed: 9, Failed: 1}, now)
+
+		assert.True(t, changed)
+		assert.Equal(t, 1, progressed.CurrentStage)
+		assert.Equal(t, firmware_domain.RunningFirmwareCampaign, progressed.Status)
+	})
+
+	t.Run("should complete after the last stage", func(t *testing.T) {
+		campaign := newCampaign(t)
+		campaign.CurrentStage = 2
+
+		progressed, changed := campaign.Progress(firmware_domain.FirmwareRolloutStats{Released: 100, Installed: 100}, now)
+
+		assert.True(t, changed)
+		assert.Equal(t, firmware_domain.CompletedFirmwareCampaign, progressed.Status)
+	})
+
+	t.Run("should halt when the failure rate crosses the threshold", func(t *testing.T) {
+		progressed, changed := newCampaign(t).Progress(firmware_domain.FirmwareRolloutStats{Released: 10, Installed: 2, Failed: 3}, now)
+
+		assert.True(t, changed)
+		assert.Equal(t, firmware_domain.HaltedFirmwareCampaign, progressed.Status)
+		assert.NotEmpty(t, progressed.HaltReason)
+
+		_, changed = progressed.Progress(firmware_domain.FirmwareRolloutStats{Released: 10, Installed: 7, Failed: 3}, now)
+		assert.False(t, changed)
+	})
+
+	t.Run("should resume halted campaigns only", func(t *testing.T) {
+		_, err := newCampaign(t).Resume(0, now)
+		assert.IsType(t, &firmware_domain.FirmwareCampaignTransitionNotAllowed{}, err)
+
+		halted, err := newCampaign(t).Halt("bad batch", now)
+		require.NoError(t, err)
+
+		resumed, err := halted.Resume(0.5, now)
+		require.NoError(t, err)
+		assert.Equal(t, firmware_domain.RunningFirmwareCampaign, resumed.Status)
+		assert.Equal(t, 0.5, resumed.FailureThreshold)
+		assert.Empty(t, resumed.HaltReason)
+	})
+}
+
+func TestAssignFirmwareStages(t *testing.T) {
+	campaign, err := firmware_domain.NewFirmwareCampaign(
+		amf_utils.NewUlid().String(), "", "firmware-1", firmware_domain.FirmwareCohort{}, []int{10, 50, 100}, 0.2, time.Now(),
+	)
+	require.NoError(t, err)
+
+	deviceIDs := make([]string, 0, 20)
+	for i := range 20 {
+		deviceIDs = append(deviceIDs, fmt.Sprintf("device-%02d", i))
+	}
+
+	stages := firmware_domain.AssignFirmwareStages(campaign, deviceIDs)
+
+	perStage := map[int]int{}
+	for _, stage := range stages {
+		perStage[stage]++
+	}
+	assert.Equal(t, map[int]int{0: 2, 1: 8, 2: 10}, perStage)
+	assert.Equal(t, stages, firmware_domain.AssignFirmwareStages(campaign, deviceIDs))
+}
@@ -0,0 +1,24 @@
+package firmware_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const firmwareCampaignTransitionNotAllowedErrorMessage = "Firmware campaign transition not allowed"
+
+type FirmwareCampaignTransitionNotAllowed struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (fctna FirmwareCampaignTransitionNotAllowed) Error() string {
+	return firmwareCampaignTransitionNotAllowedErrorMessage
+}
+
+func (fctna FirmwareCampaignTransitionNotAllowed) ExtraItems() map[string]interface{} {
+	return fctna.items
+}
+
+func NewFirmwareCampaignTransitionNotAllowed(id string, from FirmwareCampaignStatus, to FirmwareCampaignStatus) *FirmwareCampaignTransitionNotAllowed {
+	return &FirmwareCampaignTransitionNotAllowed{items: map[string]interface{}{"id": id, "from": from.Value(), "to": to.Value()}}
+}
@@ -0,0 +1,24 @@
+package firmware_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const firmwareChunkOutOfRangeErrorMessage = "Firmware chunk out of range"
+
+type FirmwareChunkOutOfRange struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (fcoor FirmwareChunkOutOfRange) Error() string {
+	return firmwareChunkOutOfRangeErrorMessage
+}
+
+func (fcoor FirmwareChunkOutOfRange) ExtraItems() map[string]interface{} {
+	return fcoor.items
+}
+
+func NewFirmwareChunkOutOfRange(firmwareID string, index int) *FirmwareChunkOutOfRange {
+	return &FirmwareChunkOutOfRange{items: map[string]interface{}{"firmware_id": firmwareID, "index": index}}
+}
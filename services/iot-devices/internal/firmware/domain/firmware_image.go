@@ -0,0 +1,151 @@
+package firmware_domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	firmwareVersionMaxLength = 50
+	firmwareModelMaxLength   = 50
+)
+
+// FirmwareImage is a signed firmware binary kept in the object storage. Devices
+// download it in chunks, checking each of them against its checksum, and only the
+// hardware models it is compatible with are offered it.
+type FirmwareImage struct {
+	ID             string
+	Version        string
+	Models         []string
+	Size           int64
+	Checksum       string
+	Signature      []byte
+	ChunkSize      int64
+	ChunkChecksums []string
+	StorageKey     string
+	UploadedAt     time.Time
+}
+
+func NewFirmwareImage(
+	id string,
+	version string,
+	models []string,
+	content []byte,
+	signature []byte,
+	chunkSize int64,
+	now time.Time,
+) (FirmwareImage, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidFirmwareImage(id, "id", "must be a ULID")); err != nil {
+		return FirmwareImage{}, err
+	}
+
+	versionValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(firmwareVersionMaxLength),
+	)
+	if err := versionValidator.Validate(version, NewInvalidFirmwareImage(id, "version", "must be a non empty string")); err != nil {
+		return FirmwareImage{}, err
+	}
+
+	if len(models) == 0 {
+		return FirmwareImage{}, NewInvalidFirmwareImage(id, "models", "must list the compatible hardware models")
+	}
+	modelValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(firmwareModelMaxLength),
+	)
+	for _, model := range models {
+		if err := modelValidator.Validate(model, NewInvalidFirmwareImage(id, "models", "must be non empty strings")); err != nil {
+			return FirmwareImage{}, err
+		}
+	}
+
+	if len(content) == 0 {
+		return FirmwareImage{}, NewInvalidFirmwareImage(id, "content", "must not be empty")
+	}
+	if len(signature) == 0 {
+		return FirmwareImage{}, NewInvalidFirmwareImage(id, "signature", "is required")
+	}
+	if chunkSize <= 0 {
+		return FirmwareImage{}, NewInvalidFirmwareImage(id, "chunk_size", "must be positive")
+	}
+
+	chunkChecksums := make([]string, 0, (int64(len(content))+chunkSize-1)/chunkSize)
+	for offset := int64(0); offset < int64(len(content)); offset += chunkSize {
+		chunkChecksums = append(chunkChecksums, FirmwareChecksum(content[offset:min(offset+chunkSize, int64(len(content)))]))
+	}
+
+	return FirmwareImage{
+		ID:             id,
+		Version:        version,
+		Models:         models,
+		Size:           int64(len(content)),
+		Checksum:       FirmwareChecksum(content),
+		Signature:      signature,
+		ChunkSize:      chunkSize,
+		ChunkChecksums: chunkChecksums,
+		StorageKey:     "firmware/" + id + ".bin",
+		UploadedAt:     now,
+	}, nil
+}
+
+func (fi FirmwareImage) CompatibleWith(model string) bool {
+	for _, compatible := range fi.Models {
+		if compatible == model {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (fi FirmwareImage) ChunkCount() int {
+	return len(fi.ChunkChecksums)
+}
+
+// Chunk locates the chunk in the image, the last one being shorter when the size is
+// not a multiple of the chunk size.
+func (fi FirmwareImage) Chunk(index int) (FirmwareChunk, error) {
+	if index < 0 || index >= fi.ChunkCount() {
+		return FirmwareChunk{}, NewFirmwareChunkOutOfRange(fi.ID, index)
+	}
+
+	offset := int64(index) * fi.ChunkSize
+
+	return FirmwareChunk{
+		Index:    index,
+		Offset:   offset,
+		Length:   min(fi.ChunkSize, fi.Size-offset),
+		Checksum: fi.ChunkChecksums[index],
+	}, nil
+}
+
+type FirmwareChunk struct {
+	Index    int
+	Offset   int64
+	Length   int64
+	Checksum string
+}
+
+// FirmwareChecksum is the hex encoded SHA-256 of the content.
+func FirmwareChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+type FirmwareImageRepository interface {
+	Save(ctx context.Context, image FirmwareImage) error
+	// Find returns nil when the image does not exist
+	Find(ctx context.Context, id string) (*FirmwareImage, error)
+	SearchAll(ctx context.Context) ([]FirmwareImage, error)
+}
+
+// FirmwareSignatureVerifier checks the images were signed by the firmware team.
+type FirmwareSignatureVerifier interface {
+	Verify(content []byte, signature []byte) error
+}
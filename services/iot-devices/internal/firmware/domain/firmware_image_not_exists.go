@@ -0,0 +1,24 @@
+package firmware_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const firmwareImageNotExistsErrorMessage = "Firmware image not exists"
+
+type FirmwareImageNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (fine FirmwareImageNotExists) Error() string {
+	return firmwareImageNotExistsErrorMessage
+}
+
+func (fine FirmwareImageNotExists) ExtraItems() map[string]interface{} {
+	return fine.items
+}
+
+func NewFirmwareImageNotExists(id string) *FirmwareImageNotExists {
+	return &FirmwareImageNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,60 @@
+package firmware_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestFirmwareImage(t *testing.T) {
+	id, now := amf_utils.NewUlid().String(), time.Now()
+	content := []byte("0123456789")
+
+	t.Run("should split the image in checksummed chunks", func(t *testing.T) {
+		image, err := firmware_domain.NewFirmwareImage(id, "1.2.0", []string{"trap-v2"}, content, []byte("signature"), 4, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), image.Size)
+		assert.Equal(t, firmware_domain.FirmwareChecksum(content), image.Checksum)
+		assert.Equal(t, "firmware/"+id+".bin", image.StorageKey)
+		assert.Equal(t, []string{
+			firmware_domain.FirmwareChecksum([]byte("0123")),
+			firmware_domain.FirmwareChecksum([]byte("4567")),
+			firmware_domain.FirmwareChecksum([]byte("89")),
+		}, image.ChunkChecksums)
+
+		last, err := image.Chunk(2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(8), last.Offset)
+		assert.Equal(t, int64(2), last.Length)
+
+		_, err = image.Chunk(3)
+		assert.IsType(t, &firmware_domain.FirmwareChunkOutOfRange{}, err)
+	})
+
+	t.Run("should only be compatible with its models", func(t *testing.T) {
+		image, err := firmware_domain.NewFirmwareImage(id, "1.2.0", []string{"trap-v2", "trap-v3"}, content, []byte("signature"), 4, now)
+
+		require.NoError(t, err)
+		assert.True(t, image.CompatibleWith("trap-v3"))
+		assert.False(t, image.CompatibleWith("trap-v1"))
+	})
+
+	t.Run("should reject images without compatible models", func(t *testing.T) {
+		_, err := firmware_domain.NewFirmwareImage(id, "1.2.0", nil, content, []byte("signature"), 4, now)
+
+		assert.IsType(t, &firmware_domain.InvalidFirmwareImage{}, err)
+	})
+
+	t.Run("should reject unsigned images", func(t *testing.T) {
+		_, err := firmware_domain.NewFirmwareImage(id, "1.2.0", []string{"trap-v2"}, content, nil, 4, now)
+
+		assert.IsType(t, &firmware_domain.InvalidFirmwareImage{}, err)
+	})
+}
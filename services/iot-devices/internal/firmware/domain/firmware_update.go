@@ -0,0 +1,74 @@
+package firmware_domain
+
+import (
+	"context"
+	"time"
+)
+
+type FirmwareUpdateStatus string
+
+const (
+	PendingFirmwareUpdate     FirmwareUpdateStatus = "pending"
+	DownloadingFirmwareUpdate FirmwareUpdateStatus = "downloading"
+	InstalledFirmwareUpdate   FirmwareUpdateStatus = "installed"
+	FailedFirmwareUpdate      FirmwareUpdateStatus = "failed"
+)
+
+func (fus FirmwareUpdateStatus) Value() string {
+	return string(fus)
+}
+
+func (fus FirmwareUpdateStatus) Finished() bool {
+	return fus == InstalledFirmwareUpdate || fus == FailedFirmwareUpdate
+}
+
+// FirmwareUpdate follows a device of a campaign, from the moment its stage is released
+// until the device reports it installed the image or failed to.
+type FirmwareUpdate struct {
+	CampaignID string
+	DeviceID   string
+	FirmwareID string
+	Stage      int
+	Status     FirmwareUpdateStatus
+	Error      string
+	UpdatedAt  time.Time
+}
+
+func (fu FirmwareUpdate) Report(status FirmwareUpdateStatus, errorMessage string, at time.Time) (FirmwareUpdate, error) {
+	if fu.Status.Finished() || (status != DownloadingFirmwareUpdate && !status.Finished()) {
+		return FirmwareUpdate{}, NewFirmwareUpdateTransitionNotAllowed(fu.DeviceID, fu.FirmwareID, fu.Status, status)
+	}
+
+	fu.Status = status
+	fu.Error = ""
+	if status == FailedFirmwareUpdate {
+		fu.Error = errorMessage
+	}
+	fu.UpdatedAt = at
+
+	return fu, nil
+}
+
+type FirmwareUpdateRepository interface {
+	SaveAll(ctx context.Context, updates []FirmwareUpdate) error
+	Save(ctx context.Context, update FirmwareUpdate) error
+	// FindReleased returns the unfinished update of the device released by a running
+	// campaign, nil when there is none
+	FindReleased(ctx context.Context, deviceID string) (*FirmwareUpdate, error)
+	// FindReleasedOf returns the update of the device to the firmware released by a
+	// running campaign, finished or not, nil when there is none
+	FindReleasedOf(ctx context.Context, deviceID string, firmwareID string) (*FirmwareUpdate, error)
+	SearchByCampaign(ctx context.Context, campaignID string) ([]FirmwareUpdate, error)
+	// Stats counts the updates of the campaign released up to the stage
+	Stats(ctx context.Context, campaignID string, stage int) (FirmwareRolloutStats, error)
+}
+
+// FirmwareTarget is a device a campaign can target.
+type FirmwareTarget struct {
+	DeviceID string
+	Model    string
+}
+
+type FirmwareDeviceCatalog interface {
+	SearchCohort(ctx context.Context, cohort FirmwareCohort) ([]FirmwareTarget, error)
+}
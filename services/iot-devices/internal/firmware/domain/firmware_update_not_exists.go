@@ -0,0 +1,24 @@
+package firmware_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const firmwareUpdateNotExistsErrorMessage = "Firmware update not exists"
+
+type FirmwareUpdateNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (fune FirmwareUpdateNotExists) Error() string {
+	return firmwareUpdateNotExistsErrorMessage
+}
+
+func (fune FirmwareUpdateNotExists) ExtraItems() map[string]interface{} {
+	return fune.items
+}
+
+func NewFirmwareUpdateNotExists(deviceID string, firmwareID string) *FirmwareUpdateNotExists {
+	return &FirmwareUpdateNotExists{items: map[string]interface{}{"device_id": deviceID, "firmware_id": firmwareID}}
+}
@@ -0,0 +1,24 @@
+package firmware_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const firmwareUpdateTransitionNotAllowedErrorMessage = "Firmware update transition not allowed"
+
+type FirmwareUpdateTransitionNotAllowed struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (futna FirmwareUpdateTransitionNotAllowed) Error() string {
+	return firmwareUpdateTransitionNotAllowedErrorMessage
+}
+
+func (futna FirmwareUpdateTransitionNotAllowed) ExtraItems() map[string]interface{} {
+	return futna.items
+}
+
+func NewFirmwareUpdateTransitionNotAllowed(deviceID string, firmwareID string, from FirmwareUpdateStatus, to FirmwareUpdateStatus) *FirmwareUpdateTransitionNotAllowed {
+	return &FirmwareUpdateTransitionNotAllowed{items: map[string]interface{}{"device_id": deviceID, "firmware_id": firmwareID, "from": from.Value(), "to": to.Value()}}
+}
@@ -0,0 +1,24 @@
+package firmware_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidFirmwareCampaignErrorMessage = "Invalid firmware campaign"
+
+type InvalidFirmwareCampaign struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ifc InvalidFirmwareCampaign) Error() string {
+	return invalidFirmwareCampaignErrorMessage
+}
+
+func (ifc InvalidFirmwareCampaign) ExtraItems() map[string]interface{} {
+	return ifc.items
+}
+
+func NewInvalidFirmwareCampaign(id string, field string, reason string) *InvalidFirmwareCampaign {
+	return &InvalidFirmwareCampaign{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
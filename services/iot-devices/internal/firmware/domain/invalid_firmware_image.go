@@ -0,0 +1,24 @@
+package firmware_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidFirmwareImageErrorMessage = "Invalid firmware image"
+
+type InvalidFirmwareImage struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ifi InvalidFirmwareImage) Error() string {
+	return invalidFirmwareImageErrorMessage
+}
+
+func (ifi InvalidFirmwareImage) ExtraItems() map[string]interface{} {
+	return ifi.items
+}
+
+func NewInvalidFirmwareImage(id string, field string, reason string) *InvalidFirmwareImage {
+	return &InvalidFirmwareImage{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
@@ -0,0 +1,46 @@
+package firmware_infra
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// Ed25519FirmwareSignatureVerifier checks the Ed25519 signature of the SHA-256 digest
+// of the image, which is what the release pipeline signs.
+type Ed25519FirmwareSignatureVerifier struct {
+	publicKey ed25519.PublicKey
+}
+
+// NewEd25519FirmwareSignatureVerifier takes the base64 encoded public key. Without a
+// key every image is rejected, so environments without firmware releases still start.
+func NewEd25519FirmwareSignatureVerifier(encodedPublicKey string) (*Ed25519FirmwareSignatureVerifier, error) {
+	if encodedPublicKey == "" {
+		return &Ed25519FirmwareSignatureVerifier{}, nil
+	}
+
+	publicKey, err := base64.StdEncoding.DecodeString(encodedPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid firmware signing public key: %w", err)
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid firmware signing public key: expected %d bytes, got %d", ed25519.PublicKeySize, len(publicKey))
+	}
+
+	return &Ed25519FirmwareSignatureVerifier{publicKey: publicKey}, nil
+}
+
+func (v *Ed25519FirmwareSignatureVerifier) Verify(content []byte, signature []byte) error {
+	if v.publicKey == nil {
+		return errors.New("cannot be verified, no signing key is configured")
+	}
+
+	digest := sha256.Sum256(content)
+	if !ed25519.Verify(v.publicKey, digest[:], signature) {
+		return errors.New("does not match the content")
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package firmware_infra_test
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	firmware_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/infra"
+)
+
+func TestEd25519FirmwareSignatureVerifier(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	content := []byte("firmware-image-content")
+	digest := sha256.Sum256(content)
+	signature := ed25519.Sign(privateKey, digest[:])
+
+	verifier, err := firmware_infra.NewEd25519FirmwareSignatureVerifier(base64.StdEncoding.EncodeToString(publicKey))
+	require.NoError(t, err)
+
+	t.Run("should accept images signed by the release key", func(t *testing.T) {
+		assert.NoError(t, verifier.Verify(content, signature))
+	})
+
+	t.Run("should reject tampered images", func(t *testing.T) {
+		assert.Error(t, verifier.Verify([]byte("firmware-image-tampered"), signature))
+	})
+
+	t.Run("should reject every image without a signing key", func(t *testing.T) {
+		unconfigured, err := firmware_infra.NewEd25519FirmwareSignatureVerifier("")
+		require.NoError(t, err)
+
+		assert.Error(t, unconfigured.Verify(content, signature))
+	})
+
+	t.Run("should refuse malformed keys", func(t *testing.T) {
+		_, err := firmware_infra.NewEd25519FirmwareSignatureVerifier(base64.StdEncoding.EncodeToString([]byte("short")))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,455 @@
+package firmware_http
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	firmware_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/application"
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const (
+	// multipartOverhead leaves room for the form fields sent along the image
+	multipartOverhead = 1 << 20
+
+	ChunkChecksumHeader = "X-Chunk-Checksum"
+	ChunkOffsetHeader   = "X-Chunk-Offset"
+)
+
+// NewUploadFirmwareImageController takes a multipart form with the version, the
+// compatible models (repeated or comma separated), the base64 encoded signature and
+// the image itself in the file field.
+func NewUploadFirmwareImageController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	maxSize int64,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := ulidProvider.New().String()
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+		if err := r.ParseMultipartForm(multipartOverhead); err != nil {
+			writeFirmwareError(w, r, jarm, firmware_domain.NewInvalidFirmwareImage(id, "file", fmt.Sprintf("must be a multipart upload of at most %d bytes", maxSize)))
+			return
+		}
+		defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+		signature, err := base64.StdEncoding.DecodeString(r.FormValue("signature"))
+		if err != nil {
+			writeFirmwareError(w, r, jarm, firmware_domain.NewInvalidFirmwareImage(id, "signature", "must be base64 encoded"))
+			return
+		}
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			writeFirmwareError(w, r, jarm, firmware_domain.NewInvalidFirmwareImage(id, "file", "is required"))
+			return
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+		if int64(len(content)) > maxSize {
+			writeFirmwareError(w, r, jarm, firmware_domain.NewInvalidFirmwareImage(id, "file", fmt.Sprintf("must be at most %d bytes", maxSize)))
+			return
+		}
+
+		command := &firmware_application.UploadFirmwareImageCommand{
+			ID:        id,
+			Version:   r.FormValue("version"),
+			Models:    formListValue(r, "models"),
+			Content:   content,
+			Signature: signature,
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeFirmwareError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &firmware_application.FindFirmwareImageQuery{ID: id}, http.StatusCreated)
+	}
+}
+
+func NewGetFirmwareImageController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &firmware_application.FindFirmwareImageQuery{ID: mux.Vars(r)["firmwareId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetFirmwareImagesController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &firmware_application.SearchFirmwareImagesQuery{Model: r.URL.Query().Get("filter[model]")}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewCreateFirmwareCampaignController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		command := &firmware_application.CreateFirmwareCampaignCommand{
+			ID:               ulidProvider.New().String(),
+			TenantID:         tenantID,
+			FirmwareID:       stringAttribute(requestParams, "firmware_id"),
+			SiteID:           stringAttribute(requestParams, "site_id"),
+			Model:            stringAttribute(requestParams, "model"),
+			DeviceIDs:        stringListAttribute(requestParams, "device_ids"),
+			Stages:           intListAttribute(requestParams, "stages"),
+			FailureThreshold: numberAttribute(requestParams, "failure_threshold"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeFirmwareError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &firmware_application.FindFirmwareCampaignQuery{ID: command.ID}, http.StatusCreated)
+	}
+}
+
+func NewHaltFirmwareCampaignController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		command := &firmware_application.HaltFirmwareCampaignCommand{
+			ID:     mux.Vars(r)["campaignId"],
+			Reason: stringAttribute(requestParams, "reason"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeFirmwareError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &firmware_application.FindFirmwareCampaignQuery{ID: command.ID}, http.StatusOK)
+	}
+}
+
+func NewResumeFirmwareCampaignController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		command := &firmware_application.ResumeFirmwareCampaignCommand{
+			ID:               mux.Vars(r)["campaignId"],
+			FailureThreshold: numberAttribute(requestParams, "failure_threshold"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeFirmwareError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &firmware_application.FindFirmwareCampaignQuery{ID: command.ID}, http.StatusOK)
+	}
+}
+
+func NewGetFirmwareCampaignController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &firmware_application.FindFirmwareCampaignQuery{ID: mux.Vars(r)["campaignId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetFirmwareCampaignsController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filters := r.URL.Query()
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		query := &firmware_application.SearchFirmwareCampaignsQuery{
+			TenantID: tenantID,
+			Status:   filters.Get("filter[status]"),
+		}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetFirmwareCampaignDevicesController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &firmware_application.SearchFirmwareCampaignDevicesQuery{CampaignID: mux.Vars(r)["campaignId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+// NewGetDeviceFirmwareController answers with no content when there is no update
+// released for the device.
+func NewGetDeviceFirmwareController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &firmware_application.FindDeviceFirmwareUpdateQuery{DeviceID: mux.Vars(r)["deviceId"]}
+
+		queryResponse, err := queryBus.Ask(r.Context(), query)
+		if err != nil {
+			writeFirmwareError(w, r, jarm, err)
+			return
+		}
+		if queryResponse == nil {
+			jarm.WriteResponse(r.Context(), w, nil, http.StatusNoContent)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	}
+}
+
+// NewGetFirmwareChunkController sends the raw bytes of the chunk, with its checksum in
+// a header so the device can check it before writing it to flash.
+func NewGetFirmwareChunkController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		firmwareID := vars["firmwareId"]
+
+		index, err := strconv.Atoi(vars["index"])
+		if err != nil {
+			writeFirmwareError(w, r, jarm, firmware_domain.NewFirmwareChunkOutOfRange(firmwareID, -1))
+			return
+		}
+
+		queryResponse, err := queryBus.Ask(r.Context(), &firmware_application.GetFirmwareChunkQuery{
+			DeviceID:   vars["deviceId"],
+			FirmwareID: firmwareID,
+			Index:      index,
+		})
+		if err != nil {
+			writeFirmwareError(w, r, jarm, err)
+			return
+		}
+
+		chunk, ok := queryResponse.(*firmware_application.FirmwareChunkResponse)
+		if !ok {
+			writeInternalServerError(w, r, jarm, errors.New("unexpected firmware chunk response"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(len(chunk.Content)))
+		w.Header().Set(ChunkChecksumHeader, chunk.Checksum)
+		w.Header().Set(ChunkOffsetHeader, strconv.FormatInt(chunk.Offset, 10))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(chunk.Content)
+	}
+}
+
+func NewReportFirmwareUpdateController(
+	commandBus amf_command_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		vars := mux.Vars(r)
+		command := &firmware_application.ReportFirmwareUpdateCommand{
+			DeviceID:   vars["deviceId"],
+			FirmwareID: vars["firmwareId"],
+			Status:     stringAttribute(requestParams, "status"),
+			Error:      stringAttribute(requestParams, "error"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeFirmwareError(w, r, jarm, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, nil, http.StatusNoContent)
+	}
+}
+
+func writeQueryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query amf_bus.Dto,
+	statusCode int,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), query)
+	if err != nil {
+		writeFirmwareError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, statusCode)
+}
+
+func writeFirmwareError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *firmware_domain.FirmwareImageNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *firmware_domain.FirmwareCampaignNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *firmware_domain.FirmwareUpdateNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *firmware_domain.FirmwareChunkOutOfRange:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *firmware_domain.FirmwareCampaignTransitionNotAllowed:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewConflictWithDetails(
+			err.Error(),
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusConflict, err)
+	case *firmware_domain.FirmwareUpdateTransitionNotAllowed:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewConflictWithDetails(
+			err.Error(),
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusConflict, err)
+	case *firmware_domain.InvalidFirmwareImage:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *firmware_domain.InvalidFirmwareCampaign:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		writeInternalServerError(w, r, jarm, err)
+	}
+}
+
+func writeInternalServerError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+	jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+}
+
+func formListValue(r *http.Request, field string) []string {
+	values := make([]string, 0)
+	for _, rawValue := range r.MultipartForm.Value[field] {
+		for _, value := range strings.Split(rawValue, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+func stringAttribute(requestParams map[string]interface{}, attribute string) string {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, "").(string)
+
+	return value
+}
+
+func numberAttribute(requestParams map[string]interface{}, attribute string) float64 {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, float64(0)).(float64)
+
+	return value
+}
+
+func stringListAttribute(requestParams map[string]interface{}, attribute string) []string {
+	rawValues, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, nil).([]interface{})
+
+	values := make([]string, 0, len(rawValues))
+	for _, rawValue := range rawValues {
+		if value, ok := rawValue.(string); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func intListAttribute(requestParams map[string]interface{}, attribute string) []int {
+	rawValues, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, nil).([]interface{})
+
+	values := make([]int, 0, len(rawValues))
+	for _, rawValue := range rawValues {
+		if value, ok := rawValue.(float64); ok {
+			values = append(values, int(value))
+		}
+	}
+
+	return values
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
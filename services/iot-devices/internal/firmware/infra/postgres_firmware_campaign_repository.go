@@ -0,0 +1,166 @@
+package firmware_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	firmwareCampaignColumns = `id, tenant_id, firmware_id, cohort_site_id, cohort_model, cohort_device_ids, stages, current_stage,
+    failure_threshold, status, halt_reason, created_at, updated_at`
+
+	upsertFirmwareCampaignQuery = `
+INSERT INTO firmware_campaigns (` + firmwareCampaignColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (id) DO UPDATE SET
+    current_stage = EXCLUDED.current_stage,
+    failure_threshold = EXCLUDED.failure_threshold,
+    status = EXCLUDED.status,
+    halt_reason = EXCLUDED.halt_reason,
+    updated_at = EXCLUDED.updated_at
+WHERE firmware_campaigns.tenant_id = EXCLUDED.tenant_id`
+	findFirmwareCampaignQuery = `
+SELECT ` + firmwareCampaignColumns + ` FROM firmware_campaigns
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchFirmwareCampaignsByTenantQuery = `
+SELECT ` + firmwareCampaignColumns + ` FROM firmware_campaigns
+WHERE tenant_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY created_at DESC, id`
+	searchRunningFirmwareCampaignsQuery = `
+SELECT ` + firmwareCampaignColumns + ` FROM firmware_campaigns
+WHERE status = 'running' AND ($1::VARCHAR IS NULL OR tenant_id = $1)
+ORDER BY created_at, id`
+)
+
+type PostgresFirmwareCampaignRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresFirmwareCampaignRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresFirmwareCampaignRepository {
+	return &PostgresFirmwareCampaignRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresFirmwareCampaignRepository) Save(ctx context.Context, campaign firmware_domain.FirmwareCampaign) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, campaign.TenantID); err != nil {
+		return err
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertFirmwareCampaignQuery,
+		campaign.ID,
+		campaign.TenantID,
+		campaign.FirmwareID,
+		campaign.Cohort.SiteID,
+		campaign.Cohort.Model,
+		pq.Array(campaign.Cohort.DeviceIDs),
+		pq.Array(campaign.Stages),
+		campaign.CurrentStage,
+		campaign.FailureThreshold,
+		campaign.Status.Value(),
+		campaign.HaltReason,
+		campaign.CreatedAt.UTC(),
+		campaign.UpdatedAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresFirmwareCampaignRepository) Find(ctx context.Context, id string) (*firmware_domain.FirmwareCampaign, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign, err := scanFirmwareCampaign(r.connectionPool.Reader().QueryRowContext(ctx, findFirmwareCampaignQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaign, nil
+}
+
+func (r *PostgresFirmwareCampaignRepository) SearchByTenant(
+	ctx context.Context,
+	tenantID string,
+) ([]firmware_domain.FirmwareCampaign, error) {
+	return r.search(ctx, searchFirmwareCampaignsByTenantQuery, tenantID)
+}
+
+func (r *PostgresFirmwareCampaignRepository) SearchRunning(ctx context.Context) ([]firmware_domain.FirmwareCampaign, error) {
+	return r.search(ctx, searchRunningFirmwareCampaignsQuery)
+}
+
+func (r *PostgresFirmwareCampaignRepository) search(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]firmware_domain.FirmwareCampaign, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, query, append(args, scope)...)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	campaigns := make([]firmware_domain.FirmwareCampaign, 0)
+	for rows.Next() {
+		campaign, err := scanFirmwareCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, rows.Err()
+}
+
+func scanFirmwareCampaign(row rowScanner) (firmware_domain.FirmwareCampaign, error) {
+	var (
+		campaign firmware_domain.FirmwareCampaign
+		stages   pq.Int64Array
+		status   string
+	)
+
+	err := row.Scan(
+		&campaign.ID,
+		&campaign.TenantID,
+		&campaign.FirmwareID,
+		&campaign.Cohort.SiteID,
+		&campaign.Cohort.Model,
+		pq.Array(&campaign.Cohort.DeviceIDs),
+		&stages,
+		&campaign.CurrentStage,
+		&campaign.FailureThreshold,
+		&status,
+		&campaign.HaltReason,
+		&campaign.CreatedAt,
+		&campaign.UpdatedAt,
+	)
+	if err != nil {
+		return firmware_domain.FirmwareCampaign{}, err
+	}
+
+	campaign.Stages = make([]int, 0, len(stages))
+	for _, stage := range stages {
+		campaign.Stages = append(campaign.Stages, int(stage))
+	}
+	campaign.Status = firmware_domain.FirmwareCampaignStatus(status)
+
+	return campaign, nil
+}
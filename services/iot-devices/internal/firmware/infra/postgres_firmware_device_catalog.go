@@ -0,0 +1,60 @@
+package firmware_infra
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const searchFirmwareCohortQuery = `
+SELECT id, model FROM spcd_iot_devices
+WHERE ($1 = '' OR site_id = $1)
+    AND ($2 = '' OR model = $2)
+    AND (cardinality($3::text[]) = 0 OR id = ANY($3))
+    AND ($4::VARCHAR IS NULL OR tenant_id = $4)
+ORDER BY id`
+
+type PostgresFirmwareDeviceCatalog struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresFirmwareDeviceCatalog(connectionPool amf_sqldb.ConnectionPool) *PostgresFirmwareDeviceCatalog {
+	return &PostgresFirmwareDeviceCatalog{connectionPool: connectionPool}
+}
+
+func (c *PostgresFirmwareDeviceCatalog) SearchCohort(
+	ctx context.Context,
+	cohort firmware_domain.FirmwareCohort,
+) ([]firmware_domain.FirmwareTarget, error) {
+	deviceIDs := cohort.DeviceIDs
+	if deviceIDs == nil {
+		deviceIDs = []string{}
+	}
+
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.connectionPool.Reader().QueryContext(ctx, searchFirmwareCohortQuery, cohort.SiteID, cohort.Model, pq.Array(deviceIDs), scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	targets := make([]firmware_domain.FirmwareTarget, 0)
+	for rows.Next() {
+		var target firmware_domain.FirmwareTarget
+		if err := rows.Scan(&target.DeviceID, &target.Model); err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, rows.Err()
+}
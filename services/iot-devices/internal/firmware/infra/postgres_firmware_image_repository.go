@@ -0,0 +1,104 @@
+package firmware_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+)
+
+const (
+	firmwareImageColumns = `id, version, models, size, checksum, signature, chunk_size, chunk_checksums, storage_key, uploaded_at`
+
+	insertFirmwareImageQuery = `
+INSERT INTO firmware_images (` + firmwareImageColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	findFirmwareImageQuery       = `SELECT ` + firmwareImageColumns + ` FROM firmware_images WHERE id = $1`
+	searchAllFirmwareImagesQuery = `SELECT ` + firmwareImageColumns + ` FROM firmware_images ORDER BY uploaded_at DESC, id`
+)
+
+type PostgresFirmwareImageRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresFirmwareImageRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresFirmwareImageRepository {
+	return &PostgresFirmwareImageRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresFirmwareImageRepository) Save(ctx context.Context, image firmware_domain.FirmwareImage) error {
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		insertFirmwareImageQuery,
+		image.ID,
+		image.Version,
+		pq.Array(image.Models),
+		image.Size,
+		image.Checksum,
+		image.Signature,
+		image.ChunkSize,
+		pq.Array(image.ChunkChecksums),
+		image.StorageKey,
+		image.UploadedAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresFirmwareImageRepository) Find(ctx context.Context, id string) (*firmware_domain.FirmwareImage, error) {
+	image, err := scanFirmwareImage(r.connectionPool.Reader().QueryRowContext(ctx, findFirmwareImageQuery, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+func (r *PostgresFirmwareImageRepository) SearchAll(ctx context.Context) ([]firmware_domain.FirmwareImage, error) {
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchAllFirmwareImagesQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	images := make([]firmware_domain.FirmwareImage, 0)
+	for rows.Next() {
+		image, err := scanFirmwareImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	return images, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanFirmwareImage(row rowScanner) (firmware_domain.FirmwareImage, error) {
+	var image firmware_domain.FirmwareImage
+
+	err := row.Scan(
+		&image.ID,
+		&image.Version,
+		pq.Array(&image.Models),
+		&image.Size,
+		&image.Checksum,
+		&image.Signature,
+		&image.ChunkSize,
+		pq.Array(&image.ChunkChecksums),
+		&image.StorageKey,
+		&image.UploadedAt,
+	)
+
+	return image, err
+}
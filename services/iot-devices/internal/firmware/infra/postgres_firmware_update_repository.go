@@ -0,0 +1,202 @@
+package firmware_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	firmwareUpdateColumns = `u.campaign_id, u.device_id, u.firmware_id, u.stage, u.status, u.error, u.updated_at`
+
+	upsertFirmwareUpdateQuery = `
+INSERT INTO firmware_updates (campaign_id, device_id, firmware_id, stage, status, error, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (campaign_id, device_id) DO UPDATE SET
+    status = EXCLUDED.status,
+    error = EXCLUDED.error,
+    updated_at = EXCLUDED.updated_at`
+	// The released updates belong to a running campaign that reached their stage
+	findReleasedFirmwareUpdateQuery = `
+SELECT ` + firmwareUpdateColumns + ` FROM firmware_updates u
+JOIN firmware_campaigns c ON c.id = u.campaign_id
+WHERE u.device_id = $1 AND c.status = 'running' AND u.stage <= c.current_stage
+    AND u.status IN ('pending', 'downloading')
+    AND ($2::VARCHAR IS NULL OR c.tenant_id = $2)
+ORDER BY c.created_at DESC
+LIMIT 1`
+	findReleasedFirmwareUpdateOfQuery = `
+SELECT ` + firmwareUpdateColumns + ` FROM firmware_updates u
+JOIN firmware_campaigns c ON c.id = u.campaign_id
+WHERE u.device_id = $1 AND u.firmware_id = $2 AND c.status = 'running' AND u.stage <= c.current_stage
+    AND ($3::VARCHAR IS NULL OR c.tenant_id = $3)
+ORDER BY c.created_at DESC
+LIMIT 1`
+	searchFirmwareUpdatesByCampaignQuery = `
+SELECT ` + firmwareUpdateColumns + ` FROM firmware_updates u
+WHERE u.campaign_id = $1
+    AND ($2::VARCHAR IS NULL OR u.campaign_id IN (SELECT id FROM firmware_campaigns WHERE tenant_id = $2))
+ORDER BY u.stage, u.device_id`
+	firmwareRolloutStatsQuery = `
+SELECT COUNT(*),
+    COUNT(*) FILTER (WHERE status = 'installed'),
+    COUNT(*) FILTER (WHERE status = 'failed')
+FROM firmware_updates
+WHERE campaign_id = $1 AND stage <= $2
+    AND ($3::VARCHAR IS NULL OR campaign_id IN (SELECT id FROM firmware_campaigns WHERE tenant_id = $3))`
+)
+
+type PostgresFirmwareUpdateRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresFirmwareUpdateRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresFirmwareUpdateRepository {
+	return &PostgresFirmwareUpdateRepository{connectionPool: connectionPool}
+}
+
+// SaveAll saves the updates in a single transaction, so a campaign is never left with
+// part of its cohort.
+func (r *PostgresFirmwareUpdateRepository) SaveAll(ctx context.Context, updates []firmware_domain.FirmwareUpdate) error {
+	tx, err := r.connectionPool.Writer().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, upsertFirmwareUpdateQuery)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	defer amf_sqldb.CloseStmt(stmt)
+
+	for _, update := range updates {
+		if _, err := stmt.ExecContext(ctx, firmwareUpdateArgs(update)...); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresFirmwareUpdateRepository) Save(ctx context.Context, update firmware_domain.FirmwareUpdate) error {
+	_, err := r.connectionPool.Writer().ExecContext(ctx, upsertFirmwareUpdateQuery, firmwareUpdateArgs(update)...)
+
+	return err
+}
+
+func (r *PostgresFirmwareUpdateRepository) FindReleased(ctx context.Context, deviceID string) (*firmware_domain.FirmwareUpdate, error) {
+	return r.find(ctx, findReleasedFirmwareUpdateQuery, deviceID)
+}
+
+func (r *PostgresFirmwareUpdateRepository) FindReleasedOf(
+	ctx context.Context,
+	deviceID string,
+	firmwareID string,
+) (*firmware_domain.FirmwareUpdate, error) {
+	return r.find(ctx, findReleasedFirmwareUpdateOfQuery, deviceID, firmwareID)
+}
+
+func (r *PostgresFirmwareUpdateRepository) SearchByCampaign(
+	ctx context.Context,
+	campaignID string,
+) ([]firmware_domain.FirmwareUpdate, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchFirmwareUpdatesByCampaignQuery, campaignID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	updates := make([]firmware_domain.FirmwareUpdate, 0)
+	for rows.Next() {
+		update, err := scanFirmwareUpdate(rows)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+	}
+
+	return updates, rows.Err()
+}
+
+// Stats reads from the writer, the campaign progresses on the reports just saved.
+func (r *PostgresFirmwareUpdateRepository) Stats(
+	ctx context.Context,
+	campaignID string,
+	stage int,
+) (firmware_domain.FirmwareRolloutStats, error) {
+	var stats firmware_domain.FirmwareRolloutStats
+
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return stats, err
+	}
+
+	err = r.connectionPool.Writer().QueryRowContext(ctx, firmwareRolloutStatsQuery, campaignID, stage, scope).Scan(
+		&stats.Released,
+		&stats.Installed,
+		&stats.Failed,
+	)
+
+	return stats, err
+}
+
+// find binds the scope after the args of the query.
+func (r *PostgresFirmwareUpdateRepository) find(ctx context.Context, query string, args ...any) (*firmware_domain.FirmwareUpdate, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := scanFirmwareUpdate(r.connectionPool.Reader().QueryRowContext(ctx, query, append(args, scope)...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &update, nil
+}
+
+func firmwareUpdateArgs(update firmware_domain.FirmwareUpdate) []any {
+	return []any{
+		update.CampaignID,
+		update.DeviceID,
+		update.FirmwareID,
+		update.Stage,
+		update.Status.Value(),
+		update.Error,
+		update.UpdatedAt.UTC(),
+	}
+}
+
+func scanFirmwareUpdate(row rowScanner) (firmware_domain.FirmwareUpdate, error) {
+	var (
+		update firmware_domain.FirmwareUpdate
+		status string
+	)
+
+	err := row.Scan(
+		&update.CampaignID,
+		&update.DeviceID,
+		&update.FirmwareID,
+		&update.Stage,
+		&status,
+		&update.Error,
+		&update.UpdatedAt,
+	)
+	update.Status = firmware_domain.FirmwareUpdateStatus(status)
+
+	return update, err
+}
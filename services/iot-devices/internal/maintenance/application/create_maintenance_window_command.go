@@ -0,0 +1,22 @@
+package maintenance_application
+
+import "time"
+
+const CreateMaintenanceWindowCommandName = "CreateMaintenanceWindowCommand"
+
+// CreateMaintenanceWindowCommand schedules a window, or starts it right away when it has
+// no StartsAt. Its end is either EndsAt or Duration after its start.
+type CreateMaintenanceWindowCommand struct {
+	ID       string
+	TenantID string
+	Scope    string
+	ScopeID  string
+	Reason   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Duration time.Duration
+}
+
+func (c CreateMaintenanceWindowCommand) Type() string {
+	return CreateMaintenanceWindowCommandName
+}
@@ -0,0 +1,57 @@
+package maintenance_application
+
+import (
+	"context"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type CreateMaintenanceWindowCommandHandler struct {
+	repository   maintenance_domain.MaintenanceWindowRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewCreateMaintenanceWindowCommandHandler(
+	repository maintenance_domain.MaintenanceWindowRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *CreateMaintenanceWindowCommandHandler {
+	return &CreateMaintenanceWindowCommandHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h CreateMaintenanceWindowCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*CreateMaintenanceWindowCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	now := h.timeProvider.Now()
+	startsAt, endsAt := cmd.StartsAt, cmd.EndsAt
+	if startsAt.IsZero() {
+		startsAt = now
+	}
+	if endsAt.IsZero() {
+		if cmd.Duration <= 0 {
+			return maintenance_domain.NewInvalidMaintenanceWindow(cmd.ID, "ends_at", "either ends_at or duration_seconds is required")
+		}
+		endsAt = startsAt.Add(cmd.Duration)
+	}
+
+	window, err := maintenance_domain.NewMaintenanceWindow(
+		cmd.ID,
+		cmd.TenantID,
+		maintenance_domain.MaintenanceScope(cmd.Scope),
+		cmd.ScopeID,
+		cmd.Reason,
+		startsAt,
+		endsAt,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, window)
+}
@@ -0,0 +1,11 @@
+package maintenance_application
+
+const EndMaintenanceWindowCommandName = "EndMaintenanceWindowCommand"
+
+type EndMaintenanceWindowCommand struct {
+	ID string
+}
+
+func (c EndMaintenanceWindowCommand) Type() string {
+	return EndMaintenanceWindowCommandName
+}
@@ -0,0 +1,24 @@
+package maintenance_application
+
+import (
+	"context"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type EndMaintenanceWindowCommandHandler struct {
+	closer *MaintenanceWindowCloser
+}
+
+func NewEndMaintenanceWindowCommandHandler(closer *MaintenanceWindowCloser) *EndMaintenanceWindowCommandHandler {
+	return &EndMaintenanceWindowCommandHandler{closer: closer}
+}
+
+func (h EndMaintenanceWindowCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*EndMaintenanceWindowCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	return h.closer.Close(ctx, cmd.ID)
+}
@@ -0,0 +1,11 @@
+package maintenance_application
+
+const FindMaintenanceWindowQueryName = "FindMaintenanceWindowQuery"
+
+type FindMaintenanceWindowQuery struct {
+	ID string
+}
+
+func (q FindMaintenanceWindowQuery) Type() string {
+	return FindMaintenanceWindowQueryName
+}
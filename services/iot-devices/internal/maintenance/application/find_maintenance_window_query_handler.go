@@ -0,0 +1,39 @@
+package maintenance_application
+
+import (
+	"context"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type FindMaintenanceWindowQueryHandler struct {
+	repository   maintenance_domain.MaintenanceWindowRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewFindMaintenanceWindowQueryHandler(
+	repository maintenance_domain.MaintenanceWindowRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *FindMaintenanceWindowQueryHandler {
+	return &FindMaintenanceWindowQueryHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h FindMaintenanceWindowQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindMaintenanceWindowQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	window, err := h.repository.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if window == nil {
+		return nil, maintenance_domain.NewMaintenanceWindowNotExists(q.ID)
+	}
+
+	return NewMaintenanceWindowResponse(*window, h.timeProvider.Now()), nil
+}
@@ -0,0 +1,65 @@
+package maintenance_application
+
+import (
+	"context"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// MaintenanceSuppressor tells the notification channels whether the events of a device
+// must be silenced because a maintenance window covering it is running.
+type MaintenanceSuppressor struct {
+	windows      maintenance_domain.MaintenanceWindowRepository
+	locator      maintenance_domain.DeviceLocator
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewMaintenanceSuppressor(
+	windows maintenance_domain.MaintenanceWindowRepository,
+	locator maintenance_domain.DeviceLocator,
+	timeProvider amf_utils.DateTimeProvider,
+) *MaintenanceSuppressor {
+	return &MaintenanceSuppressor{windows: windows, locator: locator, timeProvider: timeProvider}
+}
+
+func (ms *MaintenanceSuppressor) Suppressed(ctx context.Context, tenantID string, deviceID string) (bool, error) {
+	windows, err := ms.ActiveWindowsFor(ctx, tenantID, deviceID)
+
+	return len(windows) > 0, err
+}
+
+// ActiveWindowsFor returns the running windows of the tenant covering the device.
+// Unregistered devices can still be covered by windows scoped to them.
+func (ms *MaintenanceSuppressor) ActiveWindowsFor(
+	ctx context.Context,
+	tenantID string,
+	deviceID string,
+) ([]maintenance_domain.MaintenanceWindow, error) {
+	if deviceID == "" {
+		return nil, nil
+	}
+
+	windows, err := ms.windows.SearchActive(ctx, tenantID, ms.timeProvider.Now())
+	if err != nil || len(windows) == 0 {
+		return nil, err
+	}
+
+	location, err := ms.locator.Locate(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		location = &maintenance_domain.DeviceLocation{DeviceID: deviceID}
+	}
+
+	covering := make([]maintenance_domain.MaintenanceWindow, 0, len(windows))
+	for _, window := range windows {
+		if window.Covers(*location) {
+			covering = append(covering, window)
+		}
+	}
+
+	return covering, nil
+}
@@ -0,0 +1,164 @@
+package maintenance_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	maintenance_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/application"
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+	maintenance_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain/mocks"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type inProcessMutex struct{}
+
+func (m inProcessMutex) Mutex(_ context.Context, _ string, fn func() (interface{}, error)) (interface{}, error) {
+	return fn()
+}
+
+type eventRecorder chan amf_bus.Event
+
+func (r eventRecorder) Handle(event amf_bus.Event) error {
+	r <- event
+	return nil
+}
+
+func (r eventRecorder) next(t *testing.T) amf_bus.Event {
+	select {
+	case event := <-r:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+		return nil
+	}
+}
+
+func TestMaintenanceSuppressor(t *testing.T) {
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+	siteWindow := maintenance_domain.MaintenanceWindow{
+		ID:       "window-1",
+		Scope:    maintenance_domain.SiteMaintenanceScope,
+		ScopeID:  "site-1",
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+	}
+
+	t.Run("should suppress the devices of the sites under maintenance", func(t *testing.T) {
+		windows := maintenance_domain_mocks.NewMaintenanceWindowRepository(t)
+		locator := maintenance_domain_mocks.NewDeviceLocator(t)
+
+		windows.On("SearchActive", ctx, "", now).Return([]maintenance_domain.MaintenanceWindow{siteWindow}, nil).Twice()
+		locator.On("Locate", ctx, "device-1").Return(&maintenance_domain.DeviceLocation{DeviceID: "device-1", SiteID: "site-1"}, nil).Once()
+		locator.On("Locate", ctx, "device-2").Return(&maintenance_domain.DeviceLocation{DeviceID: "device-2", SiteID: "site-2"}, nil).Once()
+
+		suppressor := maintenance_application.NewMaintenanceSuppressor(windows, locator, timeProvider)
+
+		suppressed, err := suppressor.Suppressed(ctx, "", "device-1")
+		assert.NoError(t, err)
+		assert.True(t, suppressed)
+
+		suppressed, err = suppressor.Suppressed(ctx, "", "device-2")
+		assert.NoError(t, err)
+		assert.False(t, suppressed)
+	})
+
+	t.Run("should never suppress events without a device", func(t *testing.T) {
+		suppressor := maintenance_application.NewMaintenanceSuppressor(
+			maintenance_domain_mocks.NewMaintenanceWindowRepository(t),
+			maintenance_domain_mocks.NewDeviceLocator(t),
+			timeProvider,
+		)
+
+		suppressed, err := suppressor.Suppressed(ctx, "", "")
+
+		assert.NoError(t, err)
+		assert.False(t, suppressed)
+	})
+
+	t.Run("should record the events of the devices under maintenance", func(t *testing.T) {
+		windows := maintenance_domain_mocks.NewMaintenanceWindowRepository(t)
+		locator := maintenance_domain_mocks.NewDeviceLocator(t)
+		events := maintenance_domain_mocks.NewSuppressedEventRepository(t)
+		event := alerting_domain.NewAlertOpened(alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, now))
+
+		windows.On("SearchActive", ctx, "", now).Return([]maintenance_domain.MaintenanceWindow{siteWindow}, nil).Once()
+		locator.On("Locate", ctx, "device-1").Return(&maintenance_domain.DeviceLocation{DeviceID: "device-1", SiteID: "site-1"}, nil).Once()
+		events.On("Save", ctx, mock.MatchedBy(func(suppressed maintenance_domain.SuppressedEvent) bool {
+			return suppressed.WindowID == siteWindow.ID &&
+				suppressed.EventType == alerting_domain.AlertOpenedEventName &&
+				suppressed.AlertID == "alert-1"
+		})).Return(nil).Once()
+
+		recorder := maintenance_application.NewSuppressedEventRecorder(
+			maintenance_application.NewMaintenanceSuppressor(windows, locator, timeProvider),
+			events,
+			ulidProvider,
+			timeProvider,
+		)
+
+		assert.NoError(t, recorder.Handle(event))
+	})
+}
+
+func TestMaintenanceWindowCloser(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+
+	t.Run("should close the expired windows announcing their summary", func(t *testing.T) {
+		windows := maintenance_domain_mocks.NewMaintenanceWindowRepository(t)
+		events := maintenance_domain_mocks.NewSuppressedEventRepository(t)
+		eventBus := amf_event_bus.NewEventBus()
+		closedEvents := make(eventRecorder, 1)
+		eventBus.Subscribe(maintenance_domain.MaintenanceWindowClosedEventName, closedEvents)
+		expired := maintenance_domain.MaintenanceWindow{
+			ID:       "window-1",
+			Scope:    maintenance_domain.DeviceMaintenanceScope,
+			ScopeID:  "device-1",
+			StartsAt: now.Add(-2 * time.Hour),
+			EndsAt:   now.Add(-time.Minute),
+		}
+
+		windows.On("SearchExpired", ctx, now).Return([]maintenance_domain.MaintenanceWindow{expired}, nil).Once()
+		windows.On("Find", ctx, expired.ID).Return(&expired, nil).Once()
+		events.On("SearchByWindow", ctx, expired.ID).Return([]maintenance_domain.SuppressedEvent{
+			{EventType: alerting_domain.AlertOpenedEventName, DeviceID: "device-1", AlertID: "alert-1"},
+		}, nil).Once()
+		windows.On("Save", ctx, mock.MatchedBy(func(closed maintenance_domain.MaintenanceWindow) bool {
+			return closed.ClosedAt != nil && closed.EndsAt.Equal(expired.EndsAt) && closed.Summary.SuppressedEvents == 1
+		})).Return(nil).Once()
+
+		closer := maintenance_application.NewMaintenanceWindowCloser(windows, events, eventBus, inProcessMutex{}, timeProvider)
+
+		require.NoError(t, closer.Run(ctx))
+		event := closedEvents.next(t)
+		assert.Equal(t, expired.ID, event.Data()["window_id"])
+		assert.Equal(t, 1, event.Data()["suppressed"].(map[string]interface{})["events"])
+	})
+
+	t.Run("should refuse to end a closed window", func(t *testing.T) {
+		windows := maintenance_domain_mocks.NewMaintenanceWindowRepository(t)
+		events := maintenance_domain_mocks.NewSuppressedEventRepository(t)
+		closedAt := now.Add(-time.Minute)
+		closed := maintenance_domain.MaintenanceWindow{ID: "window-1", EndsAt: closedAt, ClosedAt: &closedAt}
+
+		windows.On("Find", ctx, closed.ID).Return(&closed, nil).Once()
+
+		closer := maintenance_application.NewMaintenanceWindowCloser(windows, events, amf_event_bus.NewEventBus(), inProcessMutex{}, timeProvider)
+		err := closer.Close(ctx, closed.ID)
+
+		assert.IsType(t, &maintenance_domain.MaintenanceWindowAlreadyClosed{}, err)
+	})
+}
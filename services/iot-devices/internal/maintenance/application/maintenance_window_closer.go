@@ -0,0 +1,101 @@
+package maintenance_application
+
+import (
+	"context"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const maintenanceWindowMutexKeyPrefix = "maintenance_window:"
+
+// MaintenanceWindowCloser closes the windows, summarizing the events they suppressed
+// and announcing the summary on the event bus. Run closes the windows that reached
+// their end, Close the ones ended by hand.
+type MaintenanceWindowCloser struct {
+	windows      maintenance_domain.MaintenanceWindowRepository
+	events       maintenance_domain.SuppressedEventRepository
+	eventBus     amf_event_bus.Bus
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewMaintenanceWindowCloser(
+	windows maintenance_domain.MaintenanceWindowRepository,
+	events maintenance_domain.SuppressedEventRepository,
+	eventBus amf_event_bus.Bus,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+) *MaintenanceWindowCloser {
+	return &MaintenanceWindowCloser{
+		windows:      windows,
+		events:       events,
+		eventBus:     eventBus,
+		mutex:        mutex,
+		timeProvider: timeProvider,
+	}
+}
+
+// Run closes the windows whose end has passed.
+func (mwc *MaintenanceWindowCloser) Run(ctx context.Context) error {
+	expired, err := mwc.windows.SearchExpired(ctx, mwc.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, window := range expired {
+		if err := mwc.close(ctx, window.ID, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (mwc *MaintenanceWindowCloser) Close(ctx context.Context, id string) error {
+	return mwc.close(ctx, id, false)
+}
+
+// close holds the lock of the window, so a window ended by hand while the closer
+// runs is summarized only once. Expired windows closed meanwhile are skipped.
+func (mwc *MaintenanceWindowCloser) close(ctx context.Context, id string, expired bool) error {
+	closed, err := mwc.mutex.Mutex(ctx, maintenanceWindowMutexKeyPrefix+id, func() (interface{}, error) {
+		window, err := mwc.windows.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if window == nil {
+			return nil, maintenance_domain.NewMaintenanceWindowNotExists(id)
+		}
+		if window.ClosedAt != nil && expired {
+			return nil, nil
+		}
+		if window.ClosedAt != nil {
+			return nil, maintenance_domain.NewMaintenanceWindowAlreadyClosed(id)
+		}
+
+		events, err := mwc.events.SearchByWindow(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		closed, err := window.Close(events, mwc.timeProvider.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return &closed, mwc.windows.Save(ctx, closed)
+	})
+	if err != nil {
+		return err
+	}
+
+	if window, ok := closed.(*maintenance_domain.MaintenanceWindow); ok && window != nil {
+		mwc.eventBus.Publish(maintenance_domain.NewMaintenanceWindowClosed(*window))
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package maintenance_application
+
+import (
+	"time"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+)
+
+type MaintenanceWindowResponse struct {
+	ID                string         `jsonapi:"primary,maintenance_windows"`
+	TenantID          string         `jsonapi:"attr,tenant_id"`
+	Scope             string         `jsonapi:"attr,scope"`
+	ScopeID           string         `jsonapi:"attr,scope_id"`
+	Reason            string         `jsonapi:"attr,reason,omitempty"`
+	Status            string         `jsonapi:"attr,status"`
+	StartsAt          string         `jsonapi:"attr,starts_at"`
+	EndsAt            string         `jsonapi:"attr,ends_at"`
+	CreatedAt         string         `jsonapi:"attr,created_at"`
+	ClosedAt          string         `jsonapi:"attr,closed_at,omitempty"`
+	SuppressedEvents  int            `jsonapi:"attr,suppressed_events"`
+	SuppressedByType  map[string]int `jsonapi:"attr,suppressed_by_event_type,omitempty"`
+	SuppressedDevices []string       `jsonapi:"attr,suppressed_devices,omitempty"`
+	SuppressedAlerts  []string       `jsonapi:"attr,suppressed_alerts,omitempty"`
+}
+
+// NewMaintenanceWindowResponse includes the summary of the suppressed events once the
+// window is closed.
+func NewMaintenanceWindowResponse(window maintenance_domain.MaintenanceWindow, now time.Time) *MaintenanceWindowResponse {
+	response := &MaintenanceWindowResponse{
+		ID:        window.ID,
+		TenantID:  window.TenantID,
+		Scope:     window.Scope.Value(),
+		ScopeID:   window.ScopeID,
+		Reason:    window.Reason,
+		Status:    window.StatusAt(now).Value(),
+		StartsAt:  window.StartsAt.Format(time.RFC3339),
+		EndsAt:    window.EndsAt.Format(time.RFC3339),
+		CreatedAt: window.CreatedAt.Format(time.RFC3339),
+	}
+
+	if window.ClosedAt != nil {
+		response.ClosedAt = window.ClosedAt.Format(time.RFC3339)
+	}
+	if window.Summary != nil {
+		response.SuppressedEvents = window.Summary.SuppressedEvents
+		response.SuppressedByType = window.Summary.ByEventType
+		response.SuppressedDevices = window.Summary.Devices
+		response.SuppressedAlerts = window.Summary.Alerts
+	}
+
+	return response
+}
+
+type SuppressedEventResponse struct {
+	ID         string `jsonapi:"primary,suppressed_events"`
+	WindowID   string `jsonapi:"attr,window_id"`
+	EventType  string `jsonapi:"attr,event_type"`
+	DeviceID   string `jsonapi:"attr,device_id"`
+	AlertID    string `jsonapi:"attr,alert_id,omitempty"`
+	OccurredAt string `jsonapi:"attr,occurred_at"`
+}
+
+func NewSuppressedEventResponse(event maintenance_domain.SuppressedEvent) *SuppressedEventResponse {
+	return &SuppressedEventResponse{
+		ID:         event.ID,
+		WindowID:   event.WindowID,
+		EventType:  event.EventType,
+		DeviceID:   event.DeviceID,
+		AlertID:    event.AlertID,
+		OccurredAt: event.OccurredAt.Format(time.RFC3339),
+	}
+}
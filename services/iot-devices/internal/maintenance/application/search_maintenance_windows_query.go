@@ -0,0 +1,12 @@
+package maintenance_application
+
+const SearchMaintenanceWindowsQueryName = "SearchMaintenanceWindowsQuery"
+
+type SearchMaintenanceWindowsQuery struct {
+	TenantID string
+	Status   string
+}
+
+func (q SearchMaintenanceWindowsQuery) Type() string {
+	return SearchMaintenanceWindowsQueryName
+}
@@ -0,0 +1,45 @@
+package maintenance_application
+
+import (
+	"context"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type SearchMaintenanceWindowsQueryHandler struct {
+	repository   maintenance_domain.MaintenanceWindowRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewSearchMaintenanceWindowsQueryHandler(
+	repository maintenance_domain.MaintenanceWindowRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *SearchMaintenanceWindowsQueryHandler {
+	return &SearchMaintenanceWindowsQueryHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h SearchMaintenanceWindowsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchMaintenanceWindowsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	windows, err := h.repository.SearchByTenant(ctx, q.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.timeProvider.Now()
+	response := make([]*MaintenanceWindowResponse, 0, len(windows))
+	for _, window := range windows {
+		if q.Status != "" && window.StatusAt(now).Value() != q.Status {
+			continue
+		}
+		response = append(response, NewMaintenanceWindowResponse(window, now))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,11 @@
+package maintenance_application
+
+const SearchSuppressedEventsQueryName = "SearchSuppressedEventsQuery"
+
+type SearchSuppressedEventsQuery struct {
+	WindowID string
+}
+
+func (q SearchSuppressedEventsQuery) Type() string {
+	return SearchSuppressedEventsQueryName
+}
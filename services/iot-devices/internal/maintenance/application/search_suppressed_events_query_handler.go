@@ -0,0 +1,48 @@
+package maintenance_application
+
+import (
+	"context"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchSuppressedEventsQueryHandler struct {
+	windows maintenance_domain.MaintenanceWindowRepository
+	events  maintenance_domain.SuppressedEventRepository
+}
+
+func NewSearchSuppressedEventsQueryHandler(
+	windows maintenance_domain.MaintenanceWindowRepository,
+	events maintenance_domain.SuppressedEventRepository,
+) *SearchSuppressedEventsQueryHandler {
+	return &SearchSuppressedEventsQueryHandler{windows: windows, events: events}
+}
+
+func (h SearchSuppressedEventsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchSuppressedEventsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	window, err := h.windows.Find(ctx, q.WindowID)
+	if err != nil {
+		return nil, err
+	}
+	if window == nil {
+		return nil, maintenance_domain.NewMaintenanceWindowNotExists(q.WindowID)
+	}
+
+	events, err := h.events.SearchByWindow(ctx, q.WindowID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*SuppressedEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, NewSuppressedEventResponse(event))
+	}
+
+	return response, nil
+}
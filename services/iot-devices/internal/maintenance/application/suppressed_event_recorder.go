@@ -0,0 +1,64 @@
+package maintenance_application
+
+import (
+	"context"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// SuppressedEventRecorder keeps, for every running window covering its device, the
+// events the notification channels silence, so the window can be summarized once
+// it closes.
+type SuppressedEventRecorder struct {
+	suppressor   *MaintenanceSuppressor
+	events       maintenance_domain.SuppressedEventRepository
+	ulidProvider amf_utils.UlidProvider
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewSuppressedEventRecorder(
+	suppressor *MaintenanceSuppressor,
+	events maintenance_domain.SuppressedEventRepository,
+	ulidProvider amf_utils.UlidProvider,
+	timeProvider amf_utils.DateTimeProvider,
+) *SuppressedEventRecorder {
+	return &SuppressedEventRecorder{
+		suppressor:   suppressor,
+		events:       events,
+		ulidProvider: ulidProvider,
+		timeProvider: timeProvider,
+	}
+}
+
+func (r *SuppressedEventRecorder) Handle(event amf_bus.Event) error {
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	data := event.Data()
+	tenantID, _ := data["tenant_id"].(string)
+	deviceID, _ := data["device_id"].(string)
+	alertID, _ := data["alert_id"].(string)
+
+	windows, err := r.suppressor.ActiveWindowsFor(ctx, tenantID, deviceID)
+	if err != nil {
+		return err
+	}
+
+	for _, window := range windows {
+		suppressed := maintenance_domain.SuppressedEvent{
+			ID:         r.ulidProvider.New().String(),
+			WindowID:   window.ID,
+			EventType:  event.Name(),
+			DeviceID:   deviceID,
+			AlertID:    alertID,
+			OccurredAt: r.timeProvider.Now(),
+		}
+		if err := r.events.Save(ctx, suppressed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package maintenance_domain
+
+import "context"
+
+// DeviceLocation places a device in the site and zone it is installed at.
+type DeviceLocation struct {
+	DeviceID string
+	SiteID   string
+	ZoneID   string
+}
+
+type DeviceLocator interface {
+	// Locate returns nil when the device is not registered
+	Locate(ctx context.Context, deviceID string) (*DeviceLocation, error)
+}
@@ -0,0 +1,24 @@
+package maintenance_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidMaintenanceWindowErrorMessage = "Invalid maintenance window"
+
+type InvalidMaintenanceWindow struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (imw InvalidMaintenanceWindow) Error() string {
+	return invalidMaintenanceWindowErrorMessage
+}
+
+func (imw InvalidMaintenanceWindow) ExtraItems() map[string]interface{} {
+	return imw.items
+}
+
+func NewInvalidMaintenanceWindow(id string, field string, reason string) *InvalidMaintenanceWindow {
+	return &InvalidMaintenanceWindow{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
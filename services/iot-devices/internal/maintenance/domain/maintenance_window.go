@@ -0,0 +1,194 @@
+package maintenance_domain
+
+import (
+	"context"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	maintenanceTenantIdMaxLength = 50
+	maintenanceScopeIdMaxLength  = 50
+	maintenanceReasonMaxLength   = 255
+)
+
+type MaintenanceScope string
+
+const (
+	SiteMaintenanceScope   MaintenanceScope = "site"
+	ZoneMaintenanceScope   MaintenanceScope = "zone"
+	DeviceMaintenanceScope MaintenanceScope = "device"
+)
+
+func (ms MaintenanceScope) Value() string {
+	return string(ms)
+}
+
+type MaintenanceWindowStatus string
+
+const (
+	ScheduledMaintenanceWindow MaintenanceWindowStatus = "scheduled"
+	ActiveMaintenanceWindow    MaintenanceWindowStatus = "active"
+	ClosedMaintenanceWindow    MaintenanceWindowStatus = "closed"
+)
+
+func (mws MaintenanceWindowStatus) Value() string {
+	return string(mws)
+}
+
+// MaintenanceWindow silences the notifications of the devices of a site, a zone or a
+// single device while technicians service them. Alerts are still recorded, and the
+// events that would have been notified are kept to summarize them once it closes.
+type MaintenanceWindow struct {
+	ID        string
+	TenantID  string
+	Scope     MaintenanceScope
+	ScopeID   string
+	Reason    string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	CreatedAt time.Time
+	ClosedAt  *time.Time
+	Summary   *MaintenanceSummary
+}
+
+// MaintenanceSummary counts the events suppressed during a window.
+type MaintenanceSummary struct {
+	SuppressedEvents int
+	ByEventType      map[string]int
+	Devices          []string
+	Alerts           []string
+}
+
+func NewMaintenanceWindow(
+	id string,
+	tenantID string,
+	scope MaintenanceScope,
+	scopeID string,
+	reason string,
+	startsAt time.Time,
+	endsAt time.Time,
+	now time.Time,
+) (MaintenanceWindow, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidMaintenanceWindow(id, "id", "must be a ULID")); err != nil {
+		return MaintenanceWindow{}, err
+	}
+
+	tenantIdValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(maintenanceTenantIdMaxLength))
+	if err := tenantIdValidator.Validate(tenantID, NewInvalidMaintenanceWindow(id, "tenant_id", "is too long")); err != nil {
+		return MaintenanceWindow{}, err
+	}
+
+	if scope != SiteMaintenanceScope && scope != ZoneMaintenanceScope && scope != DeviceMaintenanceScope {
+		return MaintenanceWindow{}, NewInvalidMaintenanceWindow(id, "scope", "must be site, zone or device")
+	}
+
+	scopeIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(maintenanceScopeIdMaxLength),
+	)
+	if err := scopeIdValidator.Validate(scopeID, NewInvalidMaintenanceWindow(id, "scope_id", "must be a non empty string")); err != nil {
+		return MaintenanceWindow{}, err
+	}
+
+	reasonValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(maintenanceReasonMaxLength))
+	if err := reasonValidator.Validate(reason, NewInvalidMaintenanceWindow(id, "reason", "is too long")); err != nil {
+		return MaintenanceWindow{}, err
+	}
+
+	if !endsAt.After(startsAt) {
+		return MaintenanceWindow{}, NewInvalidMaintenanceWindow(id, "ends_at", "must be after starts_at")
+	}
+	if !endsAt.After(now) {
+		return MaintenanceWindow{}, NewInvalidMaintenanceWindow(id, "ends_at", "must be in the future")
+	}
+
+	return MaintenanceWindow{
+		ID:        id,
+		TenantID:  tenantID,
+		Scope:     scope,
+		ScopeID:   scopeID,
+		Reason:    reason,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func (mw MaintenanceWindow) StatusAt(now time.Time) MaintenanceWindowStatus {
+	switch {
+	case mw.ClosedAt != nil || !now.Before(mw.EndsAt):
+		return ClosedMaintenanceWindow
+	case now.Before(mw.StartsAt):
+		return ScheduledMaintenanceWindow
+	default:
+		return ActiveMaintenanceWindow
+	}
+}
+
+// Covers tells whether the device is inside the scope of the window.
+func (mw MaintenanceWindow) Covers(location DeviceLocation) bool {
+	switch mw.Scope {
+	case SiteMaintenanceScope:
+		return location.SiteID != "" && location.SiteID == mw.ScopeID
+	case ZoneMaintenanceScope:
+		return location.ZoneID != "" && location.ZoneID == mw.ScopeID
+	case DeviceMaintenanceScope:
+		return location.DeviceID == mw.ScopeID
+	default:
+		return false
+	}
+}
+
+// Close ends the window with the summary of its suppressed events. Windows closed
+// before their end are shortened, so they stop suppressing right away.
+func (mw MaintenanceWindow) Close(events []SuppressedEvent, at time.Time) (MaintenanceWindow, error) {
+	if mw.ClosedAt != nil {
+		return MaintenanceWindow{}, NewMaintenanceWindowAlreadyClosed(mw.ID)
+	}
+
+	if at.Before(mw.EndsAt) {
+		mw.EndsAt = at
+	}
+	mw.ClosedAt = &at
+	mw.Summary = summarize(events)
+
+	return mw, nil
+}
+
+func summarize(events []SuppressedEvent) *MaintenanceSummary {
+	summary := &MaintenanceSummary{
+		ByEventType: make(map[string]int),
+		Devices:     make([]string, 0),
+		Alerts:      make([]string, 0),
+	}
+
+	devices, alerts := make(map[string]bool), make(map[string]bool)
+	for _, event := range events {
+		summary.SuppressedEvents++
+		summary.ByEventType[event.EventType]++
+
+		if event.DeviceID != "" && !devices[event.DeviceID] {
+			devices[event.DeviceID] = true
+			summary.Devices = append(summary.Devices, event.DeviceID)
+		}
+		if event.AlertID != "" && !alerts[event.AlertID] {
+			alerts[event.AlertID] = true
+			summary.Alerts = append(summary.Alerts, event.AlertID)
+		}
+	}
+
+	return summary
+}
+
+type MaintenanceWindowRepository interface {
+	Save(ctx context.Context, window MaintenanceWindow) error
+	// Find returns nil when the window does not exist
+	Find(ctx context.Context, id string) (*MaintenanceWindow, error)
+	// SearchActive returns the windows of the tenant not closed and running at the time
+	SearchActive(ctx context.Context, tenantID string, at time.Time) ([]MaintenanceWindow, error)
+	// SearchExpired returns the windows ended before the time but not closed yet
+	SearchExpired(ctx context.Context, at time.Time) ([]MaintenanceWindow, error)
+	SearchByTenant(ctx context.Context, tenantID string) ([]MaintenanceWindow, error)
+}
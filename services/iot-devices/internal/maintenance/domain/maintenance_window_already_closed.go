@@ -0,0 +1,24 @@
+package maintenance_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const maintenanceWindowAlreadyClosedErrorMessage = "Maintenance window already closed"
+
+type MaintenanceWindowAlreadyClosed struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (mwac MaintenanceWindowAlreadyClosed) Error() string {
+	return maintenanceWindowAlreadyClosedErrorMessage
+}
+
+func (mwac MaintenanceWindowAlreadyClosed) ExtraItems() map[string]interface{} {
+	return mwac.items
+}
+
+func NewMaintenanceWindowAlreadyClosed(id string) *MaintenanceWindowAlreadyClosed {
+	return &MaintenanceWindowAlreadyClosed{items: map[string]interface{}{"id": id}}
+}
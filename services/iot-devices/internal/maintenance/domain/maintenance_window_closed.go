@@ -0,0 +1,46 @@
+package maintenance_domain
+
+const MaintenanceWindowClosedEventName = "maintenance.window_closed"
+
+type MaintenanceWindowClosed struct {
+	window MaintenanceWindow
+}
+
+func NewMaintenanceWindowClosed(window MaintenanceWindow) MaintenanceWindowClosed {
+	return MaintenanceWindowClosed{window: window}
+}
+
+func (mwc MaintenanceWindowClosed) Name() string {
+	return MaintenanceWindowClosedEventName
+}
+
+func (mwc MaintenanceWindowClosed) Type() string {
+	return "domain_event"
+}
+
+func (mwc MaintenanceWindowClosed) Data() map[string]interface{} {
+	data := map[string]interface{}{
+		"window_id":  mwc.window.ID,
+		"tenant_id":  mwc.window.TenantID,
+		"scope":      mwc.window.Scope.Value(),
+		"scope_id":   mwc.window.ScopeID,
+		"reason":     mwc.window.Reason,
+		"starts_at":  mwc.window.StartsAt,
+		"ends_at":    mwc.window.EndsAt,
+		"suppressed": map[string]interface{}{},
+	}
+
+	if mwc.window.ClosedAt != nil {
+		data["closed_at"] = *mwc.window.ClosedAt
+	}
+	if summary := mwc.window.Summary; summary != nil {
+		data["suppressed"] = map[string]interface{}{
+			"events":        summary.SuppressedEvents,
+			"by_event_type": summary.ByEventType,
+			"devices":       summary.Devices,
+			"alerts":        summary.Alerts,
+		}
+	}
+
+	return data
+}
@@ -0,0 +1,24 @@
+package maintenance_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const maintenanceWindowNotExistsErrorMessage = "Maintenance window not exists"
+
+type MaintenanceWindowNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (mwne MaintenanceWindowNotExists) Error() string {
+	return maintenanceWindowNotExistsErrorMessage
+}
+
+func (mwne MaintenanceWindowNotExists) ExtraItems() map[string]interface{} {
+	return mwne.items
+}
+
+func NewMaintenanceWindowNotExists(id string) *MaintenanceWindowNotExists {
+	return &MaintenanceWindowNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,79 @@
+package maintenance_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	id, now := amf_utils.NewUlid().String(), time.Now()
+
+	t.Run("should be scheduled, active and closed as time goes by", func(t *testing.T) {
+		window, err := maintenance_domain.NewMaintenanceWindow(
+			id, "", maintenance_domain.SiteMaintenanceScope, "site-1", "trap replacement", now.Add(time.Hour), now.Add(2*time.Hour), now,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, maintenance_domain.ScheduledMaintenanceWindow, window.StatusAt(now))
+		assert.Equal(t, maintenance_domain.ActiveMaintenanceWindow, window.StatusAt(now.Add(time.Hour)))
+		assert.Equal(t, maintenance_domain.ClosedMaintenanceWindow, window.StatusAt(now.Add(2*time.Hour)))
+	})
+
+	t.Run("should reject windows ending before they start", func(t *testing.T) {
+		_, err := maintenance_domain.NewMaintenanceWindow(
+			id, "", maintenance_domain.DeviceMaintenanceScope, "device-1", "", now, now.Add(-time.Minute), now,
+		)
+
+		assert.IsType(t, &maintenance_domain.InvalidMaintenanceWindow{}, err)
+	})
+
+	t.Run("should reject unknown scopes", func(t *testing.T) {
+		_, err := maintenance_domain.NewMaintenanceWindow(id, "", "building", "b-1", "", now, now.Add(time.Hour), now)
+
+		assert.IsType(t, &maintenance_domain.InvalidMaintenanceWindow{}, err)
+	})
+
+	t.Run("should cover the devices of its scope", func(t *testing.T) {
+		device := maintenance_domain.DeviceLocation{DeviceID: "device-1", SiteID: "site-1", ZoneID: "zone-1"}
+		window := func(scope maintenance_domain.MaintenanceScope, scopeID string) maintenance_domain.MaintenanceWindow {
+			return maintenance_domain.MaintenanceWindow{Scope: scope, ScopeID: scopeID}
+		}
+
+		assert.True(t, window(maintenance_domain.SiteMaintenanceScope, "site-1").Covers(device))
+		assert.True(t, window(maintenance_domain.ZoneMaintenanceScope, "zone-1").Covers(device))
+		assert.True(t, window(maintenance_domain.DeviceMaintenanceScope, "device-1").Covers(device))
+		assert.False(t, window(maintenance_domain.SiteMaintenanceScope, "site-2").Covers(device))
+		assert.False(t, window(maintenance_domain.ZoneMaintenanceScope, "").Covers(maintenance_domain.DeviceLocation{DeviceID: "device-2"}))
+	})
+
+	t.Run("should summarize the suppressed events when closed early", func(t *testing.T) {
+		window, err := maintenance_domain.NewMaintenanceWindow(
+			id, "", maintenance_domain.SiteMaintenanceScope, "site-1", "", now, now.Add(2*time.Hour), now,
+		)
+		require.NoError(t, err)
+
+		closed, err := window.Close([]maintenance_domain.SuppressedEvent{
+			{EventType: "alerting.alert_opened", DeviceID: "device-1", AlertID: "alert-1"},
+			{EventType: "alerting.alert_escalated", DeviceID: "device-1", AlertID: "alert-1"},
+			{EventType: "alerting.alert_opened", DeviceID: "device-2", AlertID: "alert-2"},
+		}, now.Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Hour), closed.EndsAt)
+		assert.Equal(t, maintenance_domain.ClosedMaintenanceWindow, closed.StatusAt(now.Add(time.Minute)))
+		assert.Equal(t, 3, closed.Summary.SuppressedEvents)
+		assert.Equal(t, map[string]int{"alerting.alert_opened": 2, "alerting.alert_escalated": 1}, closed.Summary.ByEventType)
+		assert.Equal(t, []string{"device-1", "device-2"}, closed.Summary.Devices)
+		assert.Equal(t, []string{"alert-1", "alert-2"}, closed.Summary.Alerts)
+
+		_, err = closed.Close(nil, now.Add(time.Hour))
+		assert.IsType(t, &maintenance_domain.MaintenanceWindowAlreadyClosed{}, err)
+	})
+}
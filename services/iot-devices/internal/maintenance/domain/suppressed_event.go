@@ -0,0 +1,22 @@
+package maintenance_domain
+
+import (
+	"context"
+	"time"
+)
+
+// SuppressedEvent is an event of a device that was not notified because the device
+// was under maintenance.
+type SuppressedEvent struct {
+	ID         string
+	WindowID   string
+	EventType  string
+	DeviceID   string
+	AlertID    string
+	OccurredAt time.Time
+}
+
+type SuppressedEventRepository interface {
+	Save(ctx context.Context, event SuppressedEvent) error
+	SearchByWindow(ctx context.Context, windowID string) ([]SuppressedEvent, error)
+}
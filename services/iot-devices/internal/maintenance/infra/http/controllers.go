@@ -0,0 +1,198 @@
+package maintenance_http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	maintenance_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/application"
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func NewCreateMaintenanceWindowController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		id := ulidProvider.New().String()
+		startsAt, err := timeAttribute(requestParams, "starts_at")
+		if err != nil {
+			writeMaintenanceError(w, r, jarm, maintenance_domain.NewInvalidMaintenanceWindow(id, "starts_at", "must be a RFC3339 date"))
+			return
+		}
+		endsAt, err := timeAttribute(requestParams, "ends_at")
+		if err != nil {
+			writeMaintenanceError(w, r, jarm, maintenance_domain.NewInvalidMaintenanceWindow(id, "ends_at", "must be a RFC3339 date"))
+			return
+		}
+
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		command := &maintenance_application.CreateMaintenanceWindowCommand{
+			ID:       id,
+			TenantID: tenantID,
+			Scope:    stringAttribute(requestParams, "scope"),
+			ScopeID:  stringAttribute(requestParams, "scope_id"),
+			Reason:   stringAttribute(requestParams, "reason"),
+			StartsAt: startsAt,
+			EndsAt:   endsAt,
+			Duration: time.Duration(numberAttribute(requestParams, "duration_seconds")) * time.Second,
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeMaintenanceError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &maintenance_application.FindMaintenanceWindowQuery{ID: id}, http.StatusCreated)
+	}
+}
+
+func NewEndMaintenanceWindowController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		command := &maintenance_application.EndMaintenanceWindowCommand{ID: mux.Vars(r)["windowId"]}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeMaintenanceError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &maintenance_application.FindMaintenanceWindowQuery{ID: command.ID}, http.StatusOK)
+	}
+}
+
+func NewGetMaintenanceWindowController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &maintenance_application.FindMaintenanceWindowQuery{ID: mux.Vars(r)["windowId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetMaintenanceWindowsController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filters := r.URL.Query()
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		query := &maintenance_application.SearchMaintenanceWindowsQuery{
+			TenantID: tenantID,
+			Status:   filters.Get("filter[status]"),
+		}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetSuppressedEventsController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &maintenance_application.SearchSuppressedEventsQuery{WindowID: mux.Vars(r)["windowId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func writeQueryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query amf_bus.Dto,
+	statusCode int,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), query)
+	if err != nil {
+		writeMaintenanceError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, statusCode)
+}
+
+func writeMaintenanceError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *maintenance_domain.MaintenanceWindowNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *maintenance_domain.MaintenanceWindowAlreadyClosed:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewConflictWithDetails(
+			err.Error(),
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusConflict, err)
+	case *maintenance_domain.InvalidMaintenanceWindow:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		writeInternalServerError(w, r, jarm, err)
+	}
+}
+
+func writeInternalServerError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+	jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+}
+
+func stringAttribute(requestParams map[string]interface{}, attribute string) string {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, "").(string)
+
+	return value
+}
+
+func numberAttribute(requestParams map[string]interface{}, attribute string) float64 {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, float64(0)).(float64)
+
+	return value
+}
+
+// timeAttribute returns the zero time when the attribute is missing.
+func timeAttribute(requestParams map[string]interface{}, attribute string) (time.Time, error) {
+	value := stringAttribute(requestParams, attribute)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
@@ -0,0 +1,46 @@
+package maintenance_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const locateDeviceQuery = `
+SELECT id, COALESCE(site_id, ''), COALESCE(zone_id, '') FROM spcd_iot_devices
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+
+type PostgresDeviceLocator struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresDeviceLocator(connectionPool amf_sqldb.ConnectionPool) *PostgresDeviceLocator {
+	return &PostgresDeviceLocator{connectionPool: connectionPool}
+}
+
+func (l *PostgresDeviceLocator) Locate(ctx context.Context, deviceID string) (*maintenance_domain.DeviceLocation, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var location maintenance_domain.DeviceLocation
+	err = l.connectionPool.Reader().QueryRowContext(ctx, locateDeviceQuery, deviceID, scope).Scan(
+		&location.DeviceID,
+		&location.SiteID,
+		&location.ZoneID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &location, nil
+}
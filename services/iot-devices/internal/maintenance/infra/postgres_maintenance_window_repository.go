@@ -0,0 +1,202 @@
+package maintenance_infra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	maintenanceWindowColumns = `id, tenant_id, scope, scope_id, reason, starts_at, ends_at, created_at, closed_at, summary`
+
+	upsertMaintenanceWindowQuery = `
+INSERT INTO maintenance_windows (` + maintenanceWindowColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (id) DO UPDATE SET
+    ends_at = EXCLUDED.ends_at,
+    closed_at = EXCLUDED.closed_at,
+    summary = EXCLUDED.summary
+WHERE maintenance_windows.tenant_id = EXCLUDED.tenant_id`
+	findMaintenanceWindowQuery = `
+SELECT ` + maintenanceWindowColumns + ` FROM maintenance_windows
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchActiveMaintenanceWindowsQuery = `
+SELECT ` + maintenanceWindowColumns + ` FROM maintenance_windows
+WHERE tenant_id = $1 AND closed_at IS NULL AND starts_at <= $2 AND ends_at > $2
+    AND ($3::VARCHAR IS NULL OR tenant_id = $3)`
+	searchExpiredMaintenanceWindowsQuery = `
+SELECT ` + maintenanceWindowColumns + ` FROM maintenance_windows
+WHERE closed_at IS NULL AND ends_at <= $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY ends_at`
+	searchMaintenanceWindowsByTenantQuery = `
+SELECT ` + maintenanceWindowColumns + ` FROM maintenance_windows
+WHERE tenant_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY starts_at DESC, id`
+)
+
+type maintenanceSummaryRecord struct {
+	SuppressedEvents int            `json:"suppressed_events"`
+	ByEventType      map[string]int `json:"by_event_type"`
+	Devices          []string       `json:"devices"`
+	Alerts           []string       `json:"alerts"`
+}
+
+type PostgresMaintenanceWindowRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresMaintenanceWindowRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresMaintenanceWindowRepository {
+	return &PostgresMaintenanceWindowRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresMaintenanceWindowRepository) Save(ctx context.Context, window maintenance_domain.MaintenanceWindow) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, window.TenantID); err != nil {
+		return err
+	}
+
+	var closedAt sql.NullTime
+	if window.ClosedAt != nil {
+		closedAt = sql.NullTime{Time: window.ClosedAt.UTC(), Valid: true}
+	}
+
+	var summary sql.NullString
+	if window.Summary != nil {
+		encoded, err := json.Marshal(maintenanceSummaryRecord(*window.Summary))
+		if err != nil {
+			return err
+		}
+		summary = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertMaintenanceWindowQuery,
+		window.ID,
+		window.TenantID,
+		window.Scope.Value(),
+		window.ScopeID,
+		window.Reason,
+		window.StartsAt.UTC(),
+		window.EndsAt.UTC(),
+		window.CreatedAt.UTC(),
+		closedAt,
+		summary,
+	)
+
+	return err
+}
+
+func (r *PostgresMaintenanceWindowRepository) Find(ctx context.Context, id string) (*maintenance_domain.MaintenanceWindow, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := scanMaintenanceWindow(r.connectionPool.Reader().QueryRowContext(ctx, findMaintenanceWindowQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &window, nil
+}
+
+func (r *PostgresMaintenanceWindowRepository) SearchActive(
+	ctx context.Context,
+	tenantID string,
+	at time.Time,
+) ([]maintenance_domain.MaintenanceWindow, error) {
+	return r.search(ctx, searchActiveMaintenanceWindowsQuery, tenantID, at.UTC())
+}
+
+func (r *PostgresMaintenanceWindowRepository) SearchExpired(ctx context.Context, at time.Time) ([]maintenance_domain.MaintenanceWindow, error) {
+	return r.search(ctx, searchExpiredMaintenanceWindowsQuery, at.UTC())
+}
+
+func (r *PostgresMaintenanceWindowRepository) SearchByTenant(
+	ctx context.Context,
+	tenantID string,
+) ([]maintenance_domain.MaintenanceWindow, error) {
+	return r.search(ctx, searchMaintenanceWindowsByTenantQuery, tenantID)
+}
+
+func (r *PostgresMaintenanceWindowRepository) search(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]maintenance_domain.MaintenanceWindow, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, query, append(args, scope)...)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	windows := make([]maintenance_domain.MaintenanceWindow, 0)
+	for rows.Next() {
+		window, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+
+	return windows, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMaintenanceWindow(row rowScanner) (maintenance_domain.MaintenanceWindow, error) {
+	var (
+		window   maintenance_domain.MaintenanceWindow
+		scope    string
+		closedAt sql.NullTime
+		summary  sql.NullString
+	)
+
+	err := row.Scan(
+		&window.ID,
+		&window.TenantID,
+		&scope,
+		&window.ScopeID,
+		&window.Reason,
+		&window.StartsAt,
+		&window.EndsAt,
+		&window.CreatedAt,
+		&closedAt,
+		&summary,
+	)
+	if err != nil {
+		return maintenance_domain.MaintenanceWindow{}, err
+	}
+
+	window.Scope = maintenance_domain.MaintenanceScope(scope)
+	if closedAt.Valid {
+		window.ClosedAt = &closedAt.Time
+	}
+	if summary.Valid {
+		var record maintenanceSummaryRecord
+		if err := json.Unmarshal([]byte(summary.String), &record); err != nil {
+			return maintenance_domain.MaintenanceWindow{}, err
+		}
+		decoded := maintenance_domain.MaintenanceSummary(record)
+		window.Summary = &decoded
+	}
+
+	return window, nil
+}
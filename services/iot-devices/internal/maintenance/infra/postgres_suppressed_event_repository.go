@@ -0,0 +1,73 @@
+package maintenance_infra
+
+import (
+	"context"
+
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	suppressedEventColumns = `id, window_id, event_type, device_id, alert_id, occurred_at`
+
+	insertSuppressedEventQuery = `
+INSERT INTO maintenance_suppressed_events (` + suppressedEventColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6)`
+	searchSuppressedEventsByWindowQuery = `
+SELECT ` + suppressedEventColumns + ` FROM maintenance_suppressed_events
+WHERE window_id = $1
+    AND ($2::VARCHAR IS NULL OR window_id IN (SELECT id FROM maintenance_windows WHERE tenant_id = $2))
+ORDER BY occurred_at, id`
+)
+
+type PostgresSuppressedEventRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresSuppressedEventRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresSuppressedEventRepository {
+	return &PostgresSuppressedEventRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresSuppressedEventRepository) Save(ctx context.Context, event maintenance_domain.SuppressedEvent) error {
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		insertSuppressedEventQuery,
+		event.ID,
+		event.WindowID,
+		event.EventType,
+		event.DeviceID,
+		event.AlertID,
+		event.OccurredAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresSuppressedEventRepository) SearchByWindow(
+	ctx context.Context,
+	windowID string,
+) ([]maintenance_domain.SuppressedEvent, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchSuppressedEventsByWindowQuery, windowID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	events := make([]maintenance_domain.SuppressedEvent, 0)
+	for rows.Next() {
+		var event maintenance_domain.SuppressedEvent
+		if err := rows.Scan(&event.ID, &event.WindowID, &event.EventType, &event.DeviceID, &event.AlertID, &event.OccurredAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
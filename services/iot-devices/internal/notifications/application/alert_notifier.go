@@ -0,0 +1,116 @@
+package notifications_application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// AlertNotificationEventTypes are the alert events users are notified of through
+// their channels.
+var AlertNotificationEventTypes = []string{
+	alerting_domain.AlertOpenedEventName,
+	alerting_domain.AlertAcknowledgedEventName,
+	alerting_domain.AlertResolvedEventName,
+	alerting_domain.AlertEscalatedEventName,
+}
+
+// AlertNotifier notifies the users of the tenant of an alert event through the
+// channels they chose, in their language, unless they are in their quiet hours or the
+// events of the device are suppressed. A failing channel does not prevent the rest of
+// notifications from being sent.
+type AlertNotifier struct {
+	preferences  notifications_domain.UserNotificationPreferencesRepository
+	renderer     notifications_domain.NotificationRenderer
+	suppressor   notifications_domain.NotificationSuppressor
+	channels     map[notifications_domain.NotificationChannelName]notifications_domain.NotificationChannel
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewAlertNotifier(
+	preferences notifications_domain.UserNotificationPreferencesRepository,
+	renderer notifications_domain.NotificationRenderer,
+	suppressor notifications_domain.NotificationSuppressor,
+	timeProvider amf_utils.DateTimeProvider,
+	channels ...notifications_domain.NotificationChannel,
+) *AlertNotifier {
+	notifier := &AlertNotifier{
+		preferences:  preferences,
+		renderer:     renderer,
+		suppressor:   suppressor,
+		channels:     make(map[notifications_domain.NotificationChannelName]notifications_domain.NotificationChannel, len(channels)),
+		timeProvider: timeProvider,
+	}
+
+	for _, channel := range channels {
+		notifier.channels[channel.Name()] = channel
+	}
+
+	return notifier
+}
+
+func (an *AlertNotifier) Handle(event amf_bus.Event) error {
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	data := event.Data()
+	tenantID, _ := data["tenant_id"].(string)
+	deviceID, _ := data["device_id"].(string)
+
+	suppressed, err := an.suppressor.Suppressed(ctx, tenantID, deviceID)
+	if err != nil || suppressed {
+		return err
+	}
+
+	users, err := an.preferences.SearchByTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	now := an.timeProvider.Now()
+	var errs []error
+	for _, user := range users {
+		if !user.Wants(event.Name()) || user.QuietAt(now) {
+			continue
+		}
+
+		if err := an.notify(ctx, user, event.Name(), data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (an *AlertNotifier) notify(
+	ctx context.Context,
+	user notifications_domain.UserNotificationPreferences,
+	eventType string,
+	data map[string]interface{},
+) error {
+	content, err := an.renderer.Render(eventType, user.Language, user.Location(), data)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, channelName := range user.Channels {
+		channel, configured := an.channels[channelName]
+		address := user.AddressFor(channelName)
+		if !configured || address == "" {
+			continue
+		}
+
+		message := notifications_domain.NotificationMessage{To: address, Subject: content.Subject, Text: content.Text, HTML: content.HTML}
+		if err := channel.Send(ctx, message); err != nil {
+			errs = append(errs, fmt.Errorf("notifying %s by %s: %w", user.UserID, channelName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
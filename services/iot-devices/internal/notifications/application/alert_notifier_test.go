@@ -0,0 +1,138 @@
+package notifications_application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+	notifications_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain/mocks"
+
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestAlertNotifier(t *testing.T) {
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	event := alerting_domain.NewAlertOpened(alerting_domain.Alert{
+		ID:       "alert-1",
+		TenantID: "tenant-1",
+		RuleName: "High temperature",
+		DeviceID: "device-1",
+		RaisedAt: timeProvider.Now(),
+	})
+	content := notifications_domain.NotificationContent{Subject: "subject", Text: "text", HTML: "<p>html</p>"}
+
+	spanishUser := notifications_domain.UserNotificationPreferences{
+		UserID:   "user-1",
+		TenantID: "tenant-1",
+		Language: notifications_domain.SpanishNotificationLanguage,
+		Timezone: "Europe/Madrid",
+		Email:    "user-1@example.com",
+		Phone:    "+34600000000",
+		Channels: []notifications_domain.NotificationChannelName{
+			notifications_domain.EmailNotificationChannel,
+			notifications_domain.SmsNotificationChannel,
+		},
+	}
+
+	t.Run("should notify the users of the tenant through each of their channels", func(t *testing.T) {
+		preferences := notifications_domain_mocks.NewUserNotificationPreferencesRepository(t)
+		renderer := notifications_domain_mocks.NewNotificationRenderer(t)
+		suppressor := notSuppressing(t)
+		email, sms := channelMock(t, notifications_domain.EmailNotificationChannel), channelMock(t, notifications_domain.SmsNotificationChannel)
+
+		preferences.On("SearchByTenant", ctx, "tenant-1").Return([]notifications_domain.UserNotificationPreferences{spanishUser}, nil).Once()
+		renderer.On("Render", alerting_domain.AlertOpenedEventName, notifications_domain.SpanishNotificationLanguage, spanishUser.Location(), event.Data()).
+			Return(content, nil).Once()
+		email.On("Send", ctx, notifications_domain.NotificationMessage{To: "user-1@example.com", Subject: "subject", Text: "text", HTML: "<p>html</p>"}).
+			Return(nil).Once()
+		sms.On("Send", ctx, notifications_domain.NotificationMessage{To: "+34600000000", Subject: "subject", Text: "text", HTML: "<p>html</p>"}).
+			Return(nil).Once()
+
+		notifier := notifications_application.NewAlertNotifier(preferences, renderer, suppressor, timeProvider, email, sms)
+
+		assert.NoError(t, notifier.Handle(event))
+	})
+
+	t.Run("should skip users in quiet hours, not wanting the event or without the channel configured", func(t *testing.T) {
+		preferences := notifications_domain_mocks.NewUserNotificationPreferencesRepository(t)
+		renderer := notifications_domain_mocks.NewNotificationRenderer(t)
+		suppressor := notSuppressing(t)
+		email := channelMock(t, notifications_domain.EmailNotificationChannel)
+
+		local := timeProvider.Now().In(spanishUser.Location())
+		sleeping := spanishUser
+		sleeping.UserID = "user-2"
+		sleeping.QuietHours = &notifications_domain.QuietHours{
+			From: local.Add(-time.Hour).Format("15:04"),
+			To:   local.Add(time.Hour).Format("15:04"),
+		}
+		uninterested := spanishUser
+		uninterested.UserID = "user-3"
+		uninterested.EventTypes = []string{alerting_domain.AlertResolvedEventName}
+		smsOnly := spanishUser
+		smsOnly.UserID = "user-4"
+		smsOnly.Channels = []notifications_domain.NotificationChannelName{notifications_domain.SmsNotificationChannel}
+
+		preferences.On("SearchByTenant", ctx, "tenant-1").
+			Return([]notifications_domain.UserNotificationPreferences{sleeping, uninterested, smsOnly}, nil).Once()
+		renderer.On("Render", alerting_domain.AlertOpenedEventName, mock.Anything, mock.Anything, mock.Anything).Return(content, nil).Once()
+
+		notifier := notifications_application.NewAlertNotifier(preferences, renderer, suppressor, timeProvider, email)
+
+		assert.NoError(t, notifier.Handle(event))
+	})
+
+	t.Run("should not notify the events of suppressed devices", func(t *testing.T) {
+		preferences := notifications_domain_mocks.NewUserNotificationPreferencesRepository(t)
+		renderer := notifications_domain_mocks.NewNotificationRenderer(t)
+		suppressor := notifications_domain_mocks.NewNotificationSuppressor(t)
+		email := notifications_domain_mocks.NewNotificationChannel(t)
+		email.On("Name").Return(notifications_domain.EmailNotificationChannel).Once()
+
+		suppressor.On("Suppressed", ctx, "tenant-1", "device-1").Return(true, nil).Once()
+
+		notifier := notifications_application.NewAlertNotifier(preferences, renderer, suppressor, timeProvider, email)
+
+		assert.NoError(t, notifier.Handle(event))
+	})
+
+	t.Run("should keep notifying through the other channels when one fails", func(t *testing.T) {
+		preferences := notifications_domain_mocks.NewUserNotificationPreferencesRepository(t)
+		renderer := notifications_domain_mocks.NewNotificationRenderer(t)
+		suppressor := notSuppressing(t)
+		email, sms := channelMock(t, notifications_domain.EmailNotificationChannel), channelMock(t, notifications_domain.SmsNotificationChannel)
+		smtpErr := errors.New("smtp unavailable")
+
+		preferences.On("SearchByTenant", ctx, "tenant-1").Return([]notifications_domain.UserNotificationPreferences{spanishUser}, nil).Once()
+		renderer.On("Render", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(content, nil).Once()
+		email.On("Send", ctx, mock.Anything).Return(smtpErr).Once()
+		sms.On("Send", ctx, mock.Anything).Return(nil).Once()
+
+		notifier := notifications_application.NewAlertNotifier(preferences, renderer, suppressor, timeProvider, email, sms)
+
+		assert.ErrorIs(t, notifier.Handle(event), smtpErr)
+	})
+}
+
+func notSuppressing(t *testing.T) *notifications_domain_mocks.NotificationSuppressor {
+	suppressor := notifications_domain_mocks.NewNotificationSuppressor(t)
+	suppressor.On("Suppressed", mock.Anything, "tenant-1", "device-1").Return(false, nil).Once()
+
+	return suppressor
+}
+
+func channelMock(t *testing.T, name notifications_domain.NotificationChannelName) *notifications_domain_mocks.NotificationChannel {
+	channel := notifications_domain_mocks.NewNotificationChannel(t)
+	channel.On("Name").Return(name).Once()
+
+	return channel
+}
@@ -0,0 +1,15 @@
+package notifications_application
+
+const CreateWebhookSubscriptionCommandName = "CreateWebhookSubscriptionCommand"
+
+type CreateWebhookSubscriptionCommand struct {
+	ID         string
+	TenantID   string
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+func (c CreateWebhookSubscriptionCommand) Type() string {
+	return CreateWebhookSubscriptionCommandName
+}
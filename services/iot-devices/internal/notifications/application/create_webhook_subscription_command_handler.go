@@ -0,0 +1,50 @@
+package notifications_application
+
+import (
+	"context"
+	"slices"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type CreateWebhookSubscriptionCommandHandler struct {
+	repository   notifications_domain.WebhookSubscriptionRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewCreateWebhookSubscriptionCommandHandler(
+	repository notifications_domain.WebhookSubscriptionRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *CreateWebhookSubscriptionCommandHandler {
+	return &CreateWebhookSubscriptionCommandHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h CreateWebhookSubscriptionCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*CreateWebhookSubscriptionCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	for _, eventType := range cmd.EventTypes {
+		if !slices.Contains(WebhookEventTypes, eventType) {
+			return notifications_domain.NewInvalidWebhookSubscription(cmd.ID, "event_types", "unknown event type "+eventType)
+		}
+	}
+
+	subscription, err := notifications_domain.NewWebhookSubscription(
+		cmd.ID,
+		cmd.TenantID,
+		cmd.URL,
+		cmd.Secret,
+		cmd.EventTypes,
+		h.timeProvider.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, subscription)
+}
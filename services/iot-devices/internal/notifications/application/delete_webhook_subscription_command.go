@@ -0,0 +1,11 @@
+package notifications_application
+
+const DeleteWebhookSubscriptionCommandName = "DeleteWebhookSubscriptionCommand"
+
+type DeleteWebhookSubscriptionCommand struct {
+	ID string
+}
+
+func (c DeleteWebhookSubscriptionCommand) Type() string {
+	return DeleteWebhookSubscriptionCommandName
+}
@@ -0,0 +1,36 @@
+package notifications_application
+
+import (
+	"context"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type DeleteWebhookSubscriptionCommandHandler struct {
+	repository notifications_domain.WebhookSubscriptionRepository
+}
+
+func NewDeleteWebhookSubscriptionCommandHandler(
+	repository notifications_domain.WebhookSubscriptionRepository,
+) *DeleteWebhookSubscriptionCommandHandler {
+	return &DeleteWebhookSubscriptionCommandHandler{repository: repository}
+}
+
+func (h DeleteWebhookSubscriptionCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*DeleteWebhookSubscriptionCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	subscription, err := h.repository.Find(ctx, cmd.ID)
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		return notifications_domain.NewWebhookSubscriptionNotExists(cmd.ID)
+	}
+
+	return h.repository.Delete(ctx, cmd.ID)
+}
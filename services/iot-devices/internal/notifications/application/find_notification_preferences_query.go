@@ -0,0 +1,11 @@
+package notifications_application
+
+const FindNotificationPreferencesQueryName = "FindNotificationPreferencesQuery"
+
+type FindNotificationPreferencesQuery struct {
+	UserID string
+}
+
+func (q FindNotificationPreferencesQuery) Type() string {
+	return FindNotificationPreferencesQueryName
+}
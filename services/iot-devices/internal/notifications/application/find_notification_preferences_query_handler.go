@@ -0,0 +1,36 @@
+package notifications_application
+
+import (
+	"context"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindNotificationPreferencesQueryHandler struct {
+	repository notifications_domain.UserNotificationPreferencesRepository
+}
+
+func NewFindNotificationPreferencesQueryHandler(
+	repository notifications_domain.UserNotificationPreferencesRepository,
+) *FindNotificationPreferencesQueryHandler {
+	return &FindNotificationPreferencesQueryHandler{repository: repository}
+}
+
+func (h FindNotificationPreferencesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindNotificationPreferencesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	preferences, err := h.repository.Find(ctx, q.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if preferences == nil {
+		return nil, notifications_domain.NewNotificationPreferencesNotExists(q.UserID)
+	}
+
+	return NewNotificationPreferencesResponse(*preferences), nil
+}
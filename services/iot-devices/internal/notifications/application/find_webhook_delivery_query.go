@@ -0,0 +1,11 @@
+package notifications_application
+
+const FindWebhookDeliveryQueryName = "FindWebhookDeliveryQuery"
+
+type FindWebhookDeliveryQuery struct {
+	ID string
+}
+
+func (q FindWebhookDeliveryQuery) Type() string {
+	return FindWebhookDeliveryQueryName
+}
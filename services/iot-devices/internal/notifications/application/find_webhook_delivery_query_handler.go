@@ -0,0 +1,40 @@
+package notifications_application
+
+import (
+	"context"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+// FindWebhookDeliveryQueryHandler answers with the delivery along with all its attempts.
+type FindWebhookDeliveryQueryHandler struct {
+	deliveries notifications_domain.WebhookDeliveryRepository
+}
+
+func NewFindWebhookDeliveryQueryHandler(deliveries notifications_domain.WebhookDeliveryRepository) *FindWebhookDeliveryQueryHandler {
+	return &FindWebhookDeliveryQueryHandler{deliveries: deliveries}
+}
+
+func (h FindWebhookDeliveryQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindWebhookDeliveryQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	delivery, err := h.deliveries.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, notifications_domain.NewWebhookDeliveryNotExists(q.ID)
+	}
+
+	attempts, err := h.deliveries.SearchAttempts(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWebhookDeliveryResponse(*delivery, attempts), nil
+}
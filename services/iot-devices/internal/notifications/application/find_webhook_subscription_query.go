@@ -0,0 +1,11 @@
+package notifications_application
+
+const FindWebhookSubscriptionQueryName = "FindWebhookSubscriptionQuery"
+
+type FindWebhookSubscriptionQuery struct {
+	ID string
+}
+
+func (q FindWebhookSubscriptionQuery) Type() string {
+	return FindWebhookSubscriptionQueryName
+}
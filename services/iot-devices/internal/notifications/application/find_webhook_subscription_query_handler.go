@@ -0,0 +1,36 @@
+package notifications_application
+
+import (
+	"context"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindWebhookSubscriptionQueryHandler struct {
+	repository notifications_domain.WebhookSubscriptionRepository
+}
+
+func NewFindWebhookSubscriptionQueryHandler(
+	repository notifications_domain.WebhookSubscriptionRepository,
+) *FindWebhookSubscriptionQueryHandler {
+	return &FindWebhookSubscriptionQueryHandler{repository: repository}
+}
+
+func (h FindWebhookSubscriptionQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindWebhookSubscriptionQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	subscription, err := h.repository.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, notifications_domain.NewWebhookSubscriptionNotExists(q.ID)
+	}
+
+	return NewWebhookSubscriptionResponse(*subscription), nil
+}
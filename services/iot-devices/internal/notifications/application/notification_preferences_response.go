@@ -0,0 +1,42 @@
+package notifications_application
+
+import (
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+)
+
+type NotificationPreferencesResponse struct {
+	UserID         string   `jsonapi:"primary,notification_preferences"`
+	TenantID       string   `jsonapi:"attr,tenant_id"`
+	Language       string   `jsonapi:"attr,language"`
+	Timezone       string   `jsonapi:"attr,timezone"`
+	Email          string   `jsonapi:"attr,email,omitempty"`
+	Phone          string   `jsonapi:"attr,phone,omitempty"`
+	Channels       []string `jsonapi:"attr,channels"`
+	EventTypes     []string `jsonapi:"attr,event_types"`
+	QuietHoursFrom string   `jsonapi:"attr,quiet_hours_from,omitempty"`
+	QuietHoursTo   string   `jsonapi:"attr,quiet_hours_to,omitempty"`
+}
+
+func NewNotificationPreferencesResponse(preferences notifications_domain.UserNotificationPreferences) *NotificationPreferencesResponse {
+	response := &NotificationPreferencesResponse{
+		UserID:     preferences.UserID,
+		TenantID:   preferences.TenantID,
+		Language:   preferences.Language.Value(),
+		Timezone:   preferences.Timezone,
+		Email:      preferences.Email,
+		Phone:      preferences.Phone,
+		Channels:   make([]string, 0, len(preferences.Channels)),
+		EventTypes: append([]string{}, preferences.EventTypes...),
+	}
+
+	for _, channel := range preferences.Channels {
+		response.Channels = append(response.Channels, channel.Value())
+	}
+
+	if preferences.QuietHours != nil {
+		response.QuietHoursFrom = preferences.QuietHours.From
+		response.QuietHoursTo = preferences.QuietHours.To
+	}
+
+	return response
+}
@@ -0,0 +1,20 @@
+package notifications_application
+
+const PutNotificationPreferencesCommandName = "PutNotificationPreferencesCommand"
+
+type PutNotificationPreferencesCommand struct {
+	UserID         string
+	TenantID       string
+	Language       string
+	Timezone       string
+	Email          string
+	Phone          string
+	Channels       []string
+	EventTypes     []string
+	QuietHoursFrom string
+	QuietHoursTo   string
+}
+
+func (c PutNotificationPreferencesCommand) Type() string {
+	return PutNotificationPreferencesCommandName
+}
@@ -0,0 +1,60 @@
+package notifications_application
+
+import (
+	"context"
+	"slices"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type PutNotificationPreferencesCommandHandler struct {
+	repository notifications_domain.UserNotificationPreferencesRepository
+}
+
+func NewPutNotificationPreferencesCommandHandler(
+	repository notifications_domain.UserNotificationPreferencesRepository,
+) *PutNotificationPreferencesCommandHandler {
+	return &PutNotificationPreferencesCommandHandler{repository: repository}
+}
+
+func (h PutNotificationPreferencesCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*PutNotificationPreferencesCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	for _, eventType := range cmd.EventTypes {
+		if !slices.Contains(AlertNotificationEventTypes, eventType) {
+			return notifications_domain.NewInvalidNotificationPreferences(cmd.UserID, "event_types", "unknown event type "+eventType)
+		}
+	}
+
+	channels := make([]notifications_domain.NotificationChannelName, 0, len(cmd.Channels))
+	for _, channel := range cmd.Channels {
+		channels = append(channels, notifications_domain.NotificationChannelName(channel))
+	}
+
+	var quietHours *notifications_domain.QuietHours
+	if cmd.QuietHoursFrom != "" || cmd.QuietHoursTo != "" {
+		quietHours = &notifications_domain.QuietHours{From: cmd.QuietHoursFrom, To: cmd.QuietHoursTo}
+	}
+
+	preferences, err := notifications_domain.NewUserNotificationPreferences(
+		cmd.UserID,
+		cmd.TenantID,
+		notifications_domain.NotificationLanguage(cmd.Language),
+		cmd.Timezone,
+		cmd.Email,
+		cmd.Phone,
+		channels,
+		cmd.EventTypes,
+		quietHours,
+	)
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, preferences)
+}
@@ -0,0 +1,11 @@
+package notifications_application
+
+const RedeliverWebhookCommandName = "RedeliverWebhookCommand"
+
+type RedeliverWebhookCommand struct {
+	DeliveryID string
+}
+
+func (c RedeliverWebhookCommand) Type() string {
+	return RedeliverWebhookCommandName
+}
@@ -0,0 +1,67 @@
+package notifications_application
+
+import (
+	"context"
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// RedeliverWebhookCommandHandler sends a delivery again right away, whatever its status.
+// It claims the delivery like the worker does, so the worker leaves it alone meanwhile.
+type RedeliverWebhookCommandHandler struct {
+	deliveries   notifications_domain.WebhookDeliveryRepository
+	dispatcher   *WebhookDispatcher
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+	staleAfter   time.Duration
+}
+
+func NewRedeliverWebhookCommandHandler(
+	deliveries notifications_domain.WebhookDeliveryRepository,
+	dispatcher *WebhookDispatcher,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+	staleAfter time.Duration,
+) *RedeliverWebhookCommandHandler {
+	return &RedeliverWebhookCommandHandler{
+		deliveries:   deliveries,
+		dispatcher:   dispatcher,
+		mutex:        mutex,
+		timeProvider: timeProvider,
+		staleAfter:   staleAfter,
+	}
+}
+
+func (h RedeliverWebhookCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*RedeliverWebhookCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	claimed, err := claimWebhookDelivery(ctx, h.mutex, cmd.DeliveryID, func() (*notifications_domain.WebhookDelivery, error) {
+		delivery, err := h.deliveries.Find(ctx, cmd.DeliveryID)
+		if err != nil {
+			return nil, err
+		}
+		if delivery == nil {
+			return nil, notifications_domain.NewWebhookDeliveryNotExists(cmd.DeliveryID)
+		}
+
+		claimed := delivery.Claimed(h.timeProvider.Now())
+		return &claimed, h.deliveries.Save(ctx, claimed)
+	})
+	if err != nil {
+		return err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, h.staleAfter)
+	defer cancel()
+
+	_, err = h.dispatcher.Deliver(sendCtx, *claimed)
+	return err
+}
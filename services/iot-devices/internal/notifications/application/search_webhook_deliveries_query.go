@@ -0,0 +1,11 @@
+package notifications_application
+
+const SearchWebhookDeliveriesQueryName = "SearchWebhookDeliveriesQuery"
+
+type SearchWebhookDeliveriesQuery struct {
+	SubscriptionID string
+}
+
+func (q SearchWebhookDeliveriesQuery) Type() string {
+	return SearchWebhookDeliveriesQueryName
+}
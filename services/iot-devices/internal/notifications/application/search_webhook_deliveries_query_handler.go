@@ -0,0 +1,51 @@
+package notifications_application
+
+import (
+	"context"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+const webhookDeliveryLogLimit = 100
+
+// SearchWebhookDeliveriesQueryHandler answers with the latest deliveries of a subscription.
+type SearchWebhookDeliveriesQueryHandler struct {
+	subscriptions notifications_domain.WebhookSubscriptionRepository
+	deliveries    notifications_domain.WebhookDeliveryRepository
+}
+
+func NewSearchWebhookDeliveriesQueryHandler(
+	subscriptions notifications_domain.WebhookSubscriptionRepository,
+	deliveries notifications_domain.WebhookDeliveryRepository,
+) *SearchWebhookDeliveriesQueryHandler {
+	return &SearchWebhookDeliveriesQueryHandler{subscriptions: subscriptions, deliveries: deliveries}
+}
+
+func (h SearchWebhookDeliveriesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchWebhookDeliveriesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	subscription, err := h.subscriptions.Find(ctx, q.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, notifications_domain.NewWebhookSubscriptionNotExists(q.SubscriptionID)
+	}
+
+	deliveries, err := h.deliveries.SearchBySubscription(ctx, q.SubscriptionID, webhookDeliveryLogLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, NewWebhookDeliveryResponse(delivery, nil))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,11 @@
+package notifications_application
+
+const SearchWebhookSubscriptionsQueryName = "SearchWebhookSubscriptionsQuery"
+
+type SearchWebhookSubscriptionsQuery struct {
+	TenantID string
+}
+
+func (q SearchWebhookSubscriptionsQuery) Type() string {
+	return SearchWebhookSubscriptionsQueryName
+}
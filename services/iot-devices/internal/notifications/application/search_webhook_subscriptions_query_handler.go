@@ -0,0 +1,38 @@
+package notifications_application
+
+import (
+	"context"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchWebhookSubscriptionsQueryHandler struct {
+	repository notifications_domain.WebhookSubscriptionRepository
+}
+
+func NewSearchWebhookSubscriptionsQueryHandler(
+	repository notifications_domain.WebhookSubscriptionRepository,
+) *SearchWebhookSubscriptionsQueryHandler {
+	return &SearchWebhookSubscriptionsQueryHandler{repository: repository}
+}
+
+func (h SearchWebhookSubscriptionsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchWebhookSubscriptionsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	subscriptions, err := h.repository.SearchByTenant(ctx, q.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		response = append(response, NewWebhookSubscriptionResponse(subscription))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,119 @@
+package notifications_application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const webhookDeliveryMutexKeyPrefix = "webhook_delivery:"
+
+// WebhookDeliveryWorker sends the pending deliveries. Retrying a delivery outlasts the
+// locks, so deliveries are claimed under the lock by marking them as sending and sent
+// after releasing it. Deliveries sending for longer than staleAfter are taken for
+// abandoned by a dead worker and claimed again, so sending is given up once it takes
+// that long, before another worker can send them too.
+type WebhookDeliveryWorker struct {
+	deliveries   notifications_domain.WebhookDeliveryRepository
+	dispatcher   *WebhookDispatcher
+	mutex        amf_sync.MutexService
+	timeProvider amf_utils.DateTimeProvider
+	batchSize    int
+	staleAfter   time.Duration
+}
+
+func NewWebhookDeliveryWorker(
+	deliveries notifications_domain.WebhookDeliveryRepository,
+	dispatcher *WebhookDispatcher,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+	batchSize int,
+	staleAfter time.Duration,
+) *WebhookDeliveryWorker {
+	return &WebhookDeliveryWorker{
+		deliveries:   deliveries,
+		dispatcher:   dispatcher,
+		mutex:        mutex,
+		timeProvider: timeProvider,
+		batchSize:    batchSize,
+		staleAfter:   staleAfter,
+	}
+}
+
+// Run claims and sends a batch of the pending and abandoned deliveries.
+func (w *WebhookDeliveryWorker) Run(ctx context.Context) error {
+	deliveries, err := w.deliveries.SearchClaimable(ctx, w.timeProvider.Now().Add(-w.staleAfter), w.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		claimed, err := w.claim(ctx, delivery.ID)
+		if err != nil {
+			return err
+		}
+		if claimed == nil {
+			continue
+		}
+
+		if err := w.send(ctx, *claimed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *WebhookDeliveryWorker) claim(ctx context.Context, id string) (*notifications_domain.WebhookDelivery, error) {
+	return claimWebhookDelivery(ctx, w.mutex, id, func() (*notifications_domain.WebhookDelivery, error) {
+		delivery, err := w.deliveries.Find(ctx, id)
+		if err != nil || delivery == nil || !delivery.Claimable(w.timeProvider.Now().Add(-w.staleAfter)) {
+			return nil, err
+		}
+
+		claimed := delivery.Claimed(w.timeProvider.Now())
+		return &claimed, w.deliveries.Save(ctx, claimed)
+	})
+}
+
+// send leaves the deliveries it gives up on sending, to be claimed again now their
+// claim is stale.
+func (w *WebhookDeliveryWorker) send(ctx context.Context, delivery notifications_domain.WebhookDelivery) error {
+	sendCtx, cancel := context.WithTimeout(ctx, w.staleAfter)
+	defer cancel()
+
+	_, err := w.dispatcher.Deliver(sendCtx, delivery)
+	if ctx.Err() == nil && errors.Is(sendCtx.Err(), context.DeadlineExceeded) {
+		return nil
+	}
+
+	return err
+}
+
+// claimWebhookDelivery runs claim under the lock of the delivery, returning nil when
+// there was nothing to claim.
+func claimWebhookDelivery(
+	ctx context.Context,
+	mutex amf_sync.MutexService,
+	id string,
+	claim func() (*notifications_domain.WebhookDelivery, error),
+) (*notifications_domain.WebhookDelivery, error) {
+	claimed, err := mutex.Mutex(ctx, webhookDeliveryMutexKeyPrefix+id, func() (interface{}, error) {
+		delivery, err := claim()
+		if err != nil || delivery == nil {
+			return nil, err
+		}
+
+		return delivery, nil
+	})
+	if err != nil || claimed == nil {
+		return nil, err
+	}
+
+	return claimed.(*notifications_domain.WebhookDelivery), nil
+}
@@ -0,0 +1,120 @@
+package notifications_application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_retry "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/retry"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type failedWebhookAttempt struct {
+	attempt notifications_domain.WebhookDeliveryAttempt
+}
+
+func (fwa *failedWebhookAttempt) Error() string {
+	if fwa.attempt.Error != "" {
+		return fwa.attempt.Error
+	}
+
+	return fmt.Sprintf("webhook answered with status code %d", fwa.attempt.StatusCode)
+}
+
+// WebhookDispatcher sends a delivery to its subscription, retrying with backoff while
+// the receiver may still accept it. Every attempt is recorded.
+type WebhookDispatcher struct {
+	subscriptions notifications_domain.WebhookSubscriptionRepository
+	deliveries    notifications_domain.WebhookDeliveryRepository
+	sender        notifications_domain.WebhookSender
+	retryConfig   amf_retry.RetryConfig
+	ulidProvider  amf_utils.UlidProvider
+	timeProvider  amf_utils.DateTimeProvider
+}
+
+func NewWebhookDispatcher(
+	subscriptions notifications_domain.WebhookSubscriptionRepository,
+	deliveries notifications_domain.WebhookDeliveryRepository,
+	sender notifications_domain.WebhookSender,
+	retryConfig amf_retry.RetryConfig,
+	ulidProvider amf_utils.UlidProvider,
+	timeProvider amf_utils.DateTimeProvider,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		sender:        sender,
+		retryConfig:   retryConfig,
+		ulidProvider:  ulidProvider,
+		timeProvider:  timeProvider,
+	}
+}
+
+// Deliver returns the delivery as it is after its attempts. Receivers refusing it do
+// not make it fail, only errors storing the attempts do.
+func (wd *WebhookDispatcher) Deliver(
+	ctx context.Context,
+	delivery notifications_domain.WebhookDelivery,
+) (notifications_domain.WebhookDelivery, error) {
+	subscription, err := wd.subscriptions.Find(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return delivery, err
+	}
+	if subscription == nil {
+		return delivery, notifications_domain.NewWebhookSubscriptionNotExists(delivery.SubscriptionID)
+	}
+
+	var recordErr error
+	retryConfig := wd.retryConfig
+	retryConfig.OnRetryScapeHook = func(_ int, _ time.Duration, err error) bool {
+		var failed *failedWebhookAttempt
+		return !errors.As(err, &failed) || !failed.attempt.Retryable()
+	}
+
+	_, _ = amf_retry.RetryBackoff(ctx, retryConfig, func() (interface{}, error) {
+		attempt := wd.attempt(ctx, *subscription, delivery)
+		if recordErr = wd.deliveries.RecordAttempt(ctx, attempt); recordErr != nil {
+			return nil, recordErr
+		}
+
+		delivery = delivery.Attempted(attempt)
+		if !attempt.Succeeded() {
+			return nil, &failedWebhookAttempt{attempt: attempt}
+		}
+
+		return nil, nil
+	})
+	if recordErr != nil {
+		return delivery, recordErr
+	}
+
+	return delivery, wd.deliveries.Save(ctx, delivery)
+}
+
+func (wd *WebhookDispatcher) attempt(
+	ctx context.Context,
+	subscription notifications_domain.WebhookSubscription,
+	delivery notifications_domain.WebhookDelivery,
+) notifications_domain.WebhookDeliveryAttempt {
+	sentAt := wd.timeProvider.Now()
+	response, err := wd.sender.Send(ctx, notifications_domain.WebhookRequest{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		DeliveryID: delivery.ID,
+		EventType:  delivery.EventType,
+		Payload:    delivery.Payload,
+		SentAt:     sentAt,
+	})
+
+	return notifications_domain.NewWebhookDeliveryAttempt(
+		wd.ulidProvider.New().String(),
+		delivery.ID,
+		response.StatusCode,
+		response.Latency,
+		err,
+		sentAt,
+	)
+}
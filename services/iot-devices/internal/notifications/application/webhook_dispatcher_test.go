@@ -0,0 +1,230 @@
+package notifications_application_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+	notifications_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain/mocks"
+
+	amf_retry "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/retry"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+var retryConfig = amf_retry.RetryConfig{MaxRetries: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1}
+
+func TestWebhookDispatcher(t *testing.T) {
+	ctx := context.Background()
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	subscription, err := notifications_domain.NewWebhookSubscription(
+		ulidProvider.New().String(),
+		"tenant-1",
+		"https://example.com/hooks",
+		"0123456789abcdef",
+		nil,
+		timeProvider.Now(),
+	)
+	require.NoError(t, err)
+	delivery := notifications_domain.NewWebhookDelivery("delivery-1", subscription.ID, "alerting.alert_opened", []byte(`{}`), timeProvider.Now())
+
+	t.Run("should retry server errors until the webhook accepts the delivery", func(t *testing.T) {
+		subscriptions := notifications_domain_mocks.NewWebhookSubscriptionRepository(t)
+		deliveries := notifications_domain_mocks.NewWebhookDeliveryRepository(t)
+		sender := notifications_domain_mocks.NewWebhookSender(t)
+
+		subscriptions.On("Find", ctx, subscription.ID).Return(&subscription, nil).Once()
+		sender.On("Send", ctx, mock.Anything).Return(notifications_domain.WebhookResponse{StatusCode: 503}, nil).Once()
+		sender.On("Send", ctx, mock.Anything).Return(notifications_domain.WebhookResponse{StatusCode: 204}, nil).Once()
+		deliveries.On("RecordAttempt", ctx, mock.Anything).Return(nil).Twice()
+		deliveries.On("Save", ctx, mock.MatchedBy(func(saved notifications_domain.WebhookDelivery) bool {
+			return saved.Status == notifications_domain.SucceededWebhookDelivery && saved.Attempts == 2
+		})).Return(nil).Once()
+
+		dispatcher := notifications_application.NewWebhookDispatcher(subscriptions, deliveries, sender, retryConfig, ulidProvider, timeProvider)
+		delivered, err := dispatcher.Deliver(ctx, delivery)
+
+		assert.NoError(t, err)
+		assert.Equal(t, notifications_domain.SucceededWebhookDelivery, delivered.Status)
+	})
+
+	t.Run("should give up right away when the webhook refuses the delivery", func(t *testing.T) {
+		subscriptions := notifications_domain_mocks.NewWebhookSubscriptionRepository(t)
+		deliveries := notifications_domain_mocks.NewWebhookDeliveryRepository(t)
+		sender := notifications_domain_mocks.NewWebhookSender(t)
+
+		subscriptions.On("Find", ctx, subscription.ID).Return(&subscription, nil).Once()
+		sender.On("Send", ctx, mock.Anything).Return(notifications_domain.WebhookResponse{StatusCode: 410}, nil).Once()
+		deliveries.On("RecordAttempt", ctx, mock.MatchedBy(func(attempt notifications_domain.WebhookDeliveryAttempt) bool {
+			return attempt.StatusCode == 410
+		})).Return(nil).Once()
+		deliveries.On("Save", ctx, mock.Anything).Return(nil).Once()
+
+		dispatcher := notifications_application.NewWebhookDispatcher(subscriptions, deliveries, sender, retryConfig, ulidProvider, timeProvider)
+		delivered, err := dispatcher.Deliver(ctx, delivery)
+
+		assert.NoError(t, err)
+		assert.Equal(t, notifications_domain.FailedWebhookDelivery, delivered.Status)
+		assert.Equal(t, 1, delivered.Attempts)
+	})
+}
+
+// lockTrackingMutex runs the functions in process, telling whether one holds the lock.
+type lockTrackingMutex struct {
+	locked bool
+}
+
+func (m *lockTrackingMutex) Mutex(_ context.Context, _ string, fn func() (interface{}, error)) (interface{}, error) {
+	m.locked = true
+	defer func() { m.locked = false }()
+
+	return fn()
+}
+
+func TestWebhookDeliveryWorker(t *testing.T) {
+	ctx := context.Background()
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	staleAfter := 5 * time.Minute
+	subscription, err := notifications_domain.NewWebhookSubscription(
+		ulidProvider.New().String(),
+		"tenant-1",
+		"https://example.com/hooks",
+		"0123456789abcdef",
+		nil,
+		timeProvider.Now(),
+	)
+	require.NoError(t, err)
+	pending := notifications_domain.NewWebhookDelivery("delivery-1", subscription.ID, "alerting.alert_opened", []byte(`{}`), timeProvider.Now())
+
+	t.Run("should claim the delivery under the lock and send it after releasing it", func(t *testing.T) {
+		subscriptions := notifications_domain_mocks.NewWebhookSubscriptionRepository(t)
+		deliveries := notifications_domain_mocks.NewWebhookDeliveryRepository(t)
+		sender := notifications_domain_mocks.NewWebhookSender(t)
+		mutex := &lockTrackingMutex{}
+
+		deliveries.On("SearchClaimable", ctx, timeProvider.Now().Add(-staleAfter), 10).
+			Return([]notifications_domain.WebhookDelivery{pending}, nil).Once()
+		deliveries.On("Find", ctx, pending.ID).Return(&pending, nil).Once()
+		claim := deliveries.On("Save", ctx, mock.MatchedBy(func(saved notifications_domain.WebhookDelivery) bool {
+			return saved.Status == notifications_domain.SendingWebhookDelivery && saved.ClaimedAt.Equal(timeProvider.Now())
+		})).Return(nil).Once()
+		subscriptions.On("Find", mock.Anything, subscription.ID).Return(&subscription, nil).Once()
+		sender.On("Send", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+			assert.False(t, mutex.locked)
+		}).Return(notifications_domain.WebhookResponse{StatusCode: 204}, nil).Once().NotBefore(claim)
+		deliveries.On("RecordAttempt", mock.Anything, mock.Anything).Return(nil).Once()
+		deliveries.On("Save", mock.Anything, mock.MatchedBy(func(saved notifications_domain.WebhookDelivery) bool {
+			return saved.Status == notifications_domain.SucceededWebhookDelivery
+		})).Return(nil).Once()
+
+		dispatcher := notifications_application.NewWebhookDispatcher(subscriptions, deliveries, sender, retryConfig, ulidProvider, timeProvider)
+		worker := notifications_application.NewWebhookDeliveryWorker(deliveries, dispatcher, mutex, timeProvider, 10, staleAfter)
+
+		assert.NoError(t, worker.Run(ctx))
+	})
+
+	t.Run("should leave the deliveries another worker claimed meanwhile", func(t *testing.T) {
+		subscriptions := notifications_domain_mocks.NewWebhookSubscriptionRepository(t)
+		deliveries := notifications_domain_mocks.NewWebhookDeliveryRepository(t)
+		sender := notifications_domain_mocks.NewWebhookSender(t)
+		claimed := pending.Claimed(timeProvider.Now().Add(-time.Minute))
+
+		deliveries.On("SearchClaimable", ctx, mock.Anything, 10).Return([]notifications_domain.WebhookDelivery{pending}, nil).Once()
+		deliveries.On("Find", ctx, pending.ID).Return(&claimed, nil).Once()
+
+		dispatcher := notifications_application.NewWebhookDispatcher(subscriptions, deliveries, sender, retryConfig, ulidProvider, timeProvider)
+		worker := notifications_application.NewWebhookDeliveryWorker(deliveries, dispatcher, &lockTrackingMutex{}, timeProvider, 10, staleAfter)
+
+		assert.NoError(t, worker.Run(ctx))
+	})
+
+	t.Run("should claim again the deliveries whose claim is stale", func(t *testing.T) {
+		subscriptions := notifications_domain_mocks.NewWebhookSubscriptionRepository(t)
+		deliveries := notifications_domain_mocks.NewWebhookDeliveryRepository(t)
+		sender := notifications_domain_mocks.NewWebhookSender(t)
+		abandoned := pending.Claimed(timeProvider.Now().Add(-staleAfter - time.Second))
+
+		deliveries.On("SearchClaimable", ctx, mock.Anything, 10).Return([]notifications_domain.WebhookDelivery{abandoned}, nil).Once()
+		deliveries.On("Find", ctx, pending.ID).Return(&abandoned, nil).Once()
+		deliveries.On("Save", ctx, mock.MatchedBy(func(saved notifications_domain.WebhookDelivery) bool {
+			return saved.Status == notifications_domain.SendingWebhookDelivery && saved.ClaimedAt.Equal(timeProvider.Now())
+		})).Return(nil).Once()
+		subscriptions.On("Find", mock.Anything, subscription.ID).Return(&subscription, nil).Once()
+		sender.On("Send", mock.Anything, mock.Anything).Return(notifications_domain.WebhookResponse{StatusCode: 410}, nil).Once()
+		deliveries.On("RecordAttempt", mock.Anything, mock.Anything).Return(nil).Once()
+		deliveries.On("Save", mock.Anything, mock.MatchedBy(func(saved notifications_domain.WebhookDelivery) bool {
+			return saved.Status == notifications_domain.FailedWebhookDelivery
+		})).Return(nil).Once()
+
+		dispatcher := notifications_application.NewWebhookDispatcher(subscriptions, deliveries, sender, retryConfig, ulidProvider, timeProvider)
+		worker := notifications_application.NewWebhookDeliveryWorker(deliveries, dispatcher, &lockTrackingMutex{}, timeProvider, 10, staleAfter)
+
+		assert.NoError(t, worker.Run(ctx))
+	})
+}
+
+func TestWebhookEventHandler(t *testing.T) {
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	newSubscription := func(eventTypes ...string) notifications_domain.WebhookSubscription {
+		subscription, err := notifications_domain.NewWebhookSubscription(
+			amf_utils.NewUlid().String(),
+			"",
+			"https://example.com/hooks",
+			"0123456789abcdef",
+			eventTypes,
+			timeProvider.Now(),
+		)
+		require.NoError(t, err)
+
+		return subscription
+	}
+
+	t.Run("should queue a delivery for every subscription accepting the event", func(t *testing.T) {
+		subscriptions := notifications_domain_mocks.NewWebhookSubscriptionRepository(t)
+		deliveries := notifications_domain_mocks.NewWebhookDeliveryRepository(t)
+		suppressor := notifications_domain_mocks.NewNotificationSuppressor(t)
+		everything := newSubscription()
+		resolutions := newSubscription(alerting_domain.AlertResolvedEventName)
+		event := alerting_domain.NewAlertOpened(
+			alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, timeProvider.Now()),
+		)
+
+		suppressor.On("Suppressed", ctx, "", "device-1").Return(false, nil).Once()
+		subscriptions.On("SearchByTenant", ctx, "").Return([]notifications_domain.WebhookSubscription{everything, resolutions}, nil).Once()
+		deliveries.On("Save", ctx, mock.MatchedBy(func(delivery notifications_domain.WebhookDelivery) bool {
+			var payload map[string]interface{}
+			return delivery.SubscriptionID == everything.ID &&
+				delivery.Status == notifications_domain.PendingWebhookDelivery &&
+				json.Unmarshal(delivery.Payload, &payload) == nil &&
+				payload["type"] == alerting_domain.AlertOpenedEventName
+		})).Return(nil).Once()
+
+		handler := notifications_application.NewWebhookEventHandler(subscriptions, deliveries, suppressor, ulidProvider, timeProvider)
+
+		assert.NoError(t, handler.Handle(event))
+	})
+
+	t.Run("should not queue deliveries for the events of suppressed devices", func(t *testing.T) {
+		subscriptions := notifications_domain_mocks.NewWebhookSubscriptionRepository(t)
+		deliveries := notifications_domain_mocks.NewWebhookDeliveryRepository(t)
+		suppressor := notifications_domain_mocks.NewNotificationSuppressor(t)
+		event := alerting_domain.NewAlertOpened(
+			alerting_domain.NewAlert("alert-1", "", "rule-1", "Low battery", "device-1", 2300, timeProvider.Now()),
+		)
+
+		suppressor.On("Suppressed", ctx, "", "device-1").Return(true, nil).Once()
+
+		handler := notifications_application.NewWebhookEventHandler(subscriptions, deliveries, suppressor, ulidProvider, timeProvider)
+
+		assert.NoError(t, handler.Handle(event))
+	})
+}
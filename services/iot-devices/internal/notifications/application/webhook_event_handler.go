@@ -0,0 +1,83 @@
+package notifications_application
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type webhookPayload struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// WebhookEventHandler queues a delivery of the event for every subscription of its
+// tenant that accepts it, unless the events of its device are suppressed. Deliveries
+// are sent by the WebhookDeliveryWorker, so slow receivers never hold the event bus.
+type WebhookEventHandler struct {
+	subscriptions notifications_domain.WebhookSubscriptionRepository
+	deliveries    notifications_domain.WebhookDeliveryRepository
+	suppressor    notifications_domain.NotificationSuppressor
+	ulidProvider  amf_utils.UlidProvider
+	timeProvider  amf_utils.DateTimeProvider
+}
+
+func NewWebhookEventHandler(
+	subscriptions notifications_domain.WebhookSubscriptionRepository,
+	deliveries notifications_domain.WebhookDeliveryRepository,
+	suppressor notifications_domain.NotificationSuppressor,
+	ulidProvider amf_utils.UlidProvider,
+	timeProvider amf_utils.DateTimeProvider,
+) *WebhookEventHandler {
+	return &WebhookEventHandler{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		suppressor:    suppressor,
+		ulidProvider:  ulidProvider,
+		timeProvider:  timeProvider,
+	}
+}
+
+func (h *WebhookEventHandler) Handle(event amf_bus.Event) error {
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	data := event.Data()
+	tenantID, _ := data["tenant_id"].(string)
+	deviceID, _ := data["device_id"].(string)
+
+	suppressed, err := h.suppressor.Suppressed(ctx, tenantID, deviceID)
+	if err != nil || suppressed {
+		return err
+	}
+
+	subscriptions, err := h.subscriptions.SearchByTenant(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	now := h.timeProvider.Now()
+	payload, err := json.Marshal(webhookPayload{ID: h.ulidProvider.New().String(), Type: event.Name(), CreatedAt: now, Data: data})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(event.Name()) {
+			continue
+		}
+
+		delivery := notifications_domain.NewWebhookDelivery(h.ulidProvider.New().String(), subscription.ID, event.Name(), payload, now)
+		if err := h.deliveries.Save(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package notifications_application
+
+import (
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+)
+
+// WebhookEventTypes are the events customers can subscribe their webhooks to.
+var WebhookEventTypes = []string{
+	alerting_domain.AlertOpenedEventName,
+	alerting_domain.AlertAcknowledgedEventName,
+	alerting_domain.AlertResolvedEventName,
+	alerting_domain.AlertEscalatedEventName,
+	connectivity_domain.DeviceWentOfflineEventName,
+	connectivity_domain.DeviceCameBackOnlineEventName,
+	maintenance_domain.MaintenanceWindowClosedEventName,
+	firmware_domain.FirmwareCampaignHaltedEventName,
+	firmware_domain.FirmwareCampaignCompletedEventName,
+}
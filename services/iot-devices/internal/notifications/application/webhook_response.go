@@ -0,0 +1,74 @@
+package notifications_application
+
+import (
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+)
+
+type WebhookSubscriptionResponse struct {
+	ID         string   `jsonapi:"primary,webhook_subscriptions"`
+	TenantID   string   `jsonapi:"attr,tenant_id"`
+	URL        string   `jsonapi:"attr,url"`
+	EventTypes []string `jsonapi:"attr,event_types"`
+	Enabled    bool     `jsonapi:"attr,enabled"`
+	CreatedAt  string   `jsonapi:"attr,created_at"`
+}
+
+// NewWebhookSubscriptionResponse never exposes the secret of the subscription.
+func NewWebhookSubscriptionResponse(subscription notifications_domain.WebhookSubscription) *WebhookSubscriptionResponse {
+	eventTypes := subscription.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+
+	return &WebhookSubscriptionResponse{
+		ID:         subscription.ID,
+		TenantID:   subscription.TenantID,
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+		Enabled:    subscription.Enabled,
+		CreatedAt:  subscription.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string                   `jsonapi:"primary,webhook_deliveries"`
+	SubscriptionID string                   `jsonapi:"attr,subscription_id"`
+	EventType      string                   `jsonapi:"attr,event_type"`
+	Status         string                   `jsonapi:"attr,status"`
+	Attempts       int                      `jsonapi:"attr,attempts"`
+	CreatedAt      string                   `jsonapi:"attr,created_at"`
+	LastAttemptAt  string                   `jsonapi:"attr,last_attempt_at,omitempty"`
+	AttemptLog     []map[string]interface{} `jsonapi:"attr,attempt_log,omitempty"`
+}
+
+func NewWebhookDeliveryResponse(
+	delivery notifications_domain.WebhookDelivery,
+	attempts []notifications_domain.WebhookDeliveryAttempt,
+) *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status.Value(),
+		Attempts:       delivery.Attempts,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+
+	if delivery.LastAttemptAt != nil {
+		response.LastAttemptAt = delivery.LastAttemptAt.Format(time.RFC3339)
+	}
+
+	for _, attempt := range attempts {
+		response.AttemptLog = append(response.AttemptLog, map[string]interface{}{
+			"id":          attempt.ID,
+			"status_code": attempt.StatusCode,
+			"latency_ms":  attempt.Latency.Milliseconds(),
+			"error":       attempt.Error,
+			"at":          attempt.At.Format(time.RFC3339),
+		})
+	}
+
+	return response
+}
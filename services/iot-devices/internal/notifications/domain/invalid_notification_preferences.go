@@ -0,0 +1,24 @@
+package notifications_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidNotificationPreferencesErrorMessage = "Invalid notification preferences"
+
+type InvalidNotificationPreferences struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (inp InvalidNotificationPreferences) Error() string {
+	return invalidNotificationPreferencesErrorMessage
+}
+
+func (inp InvalidNotificationPreferences) ExtraItems() map[string]interface{} {
+	return inp.items
+}
+
+func NewInvalidNotificationPreferences(userID string, field string, reason string) *InvalidNotificationPreferences {
+	return &InvalidNotificationPreferences{items: map[string]interface{}{"user_id": userID, "field": field, "reason": reason}}
+}
@@ -0,0 +1,24 @@
+package notifications_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidWebhookSubscriptionErrorMessage = "Invalid webhook subscription"
+
+type InvalidWebhookSubscription struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (iws InvalidWebhookSubscription) Error() string {
+	return invalidWebhookSubscriptionErrorMessage
+}
+
+func (iws InvalidWebhookSubscription) ExtraItems() map[string]interface{} {
+	return iws.items
+}
+
+func NewInvalidWebhookSubscription(id string, field string, reason string) *InvalidWebhookSubscription {
+	return &InvalidWebhookSubscription{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
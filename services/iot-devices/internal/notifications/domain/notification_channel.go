@@ -0,0 +1,35 @@
+package notifications_domain
+
+import "context"
+
+type NotificationChannelName string
+
+const (
+	EmailNotificationChannel NotificationChannelName = "email"
+	SmsNotificationChannel   NotificationChannelName = "sms"
+)
+
+func (ncn NotificationChannelName) Value() string {
+	return string(ncn)
+}
+
+// NotificationMessage is a rendered notification addressed to the recipient of a
+// channel: an email address or a phone number. Channels without rich content ignore
+// the HTML body.
+type NotificationMessage struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type NotificationChannel interface {
+	Name() NotificationChannelName
+	Send(ctx context.Context, message NotificationMessage) error
+}
+
+// SmsProvider is the gateway the SMS channel relies on, so providers can be swapped
+// without touching the channel.
+type SmsProvider interface {
+	Send(ctx context.Context, to string, text string) error
+}
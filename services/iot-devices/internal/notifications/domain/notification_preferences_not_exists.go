@@ -0,0 +1,24 @@
+package notifications_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const notificationPreferencesNotExistsErrorMessage = "Notification preferences not exists"
+
+type NotificationPreferencesNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (npne NotificationPreferencesNotExists) Error() string {
+	return notificationPreferencesNotExistsErrorMessage
+}
+
+func (npne NotificationPreferencesNotExists) ExtraItems() map[string]interface{} {
+	return npne.items
+}
+
+func NewNotificationPreferencesNotExists(userID string) *NotificationPreferencesNotExists {
+	return &NotificationPreferencesNotExists{items: map[string]interface{}{"user_id": userID}}
+}
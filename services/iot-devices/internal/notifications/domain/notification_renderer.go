@@ -0,0 +1,25 @@
+package notifications_domain
+
+import "time"
+
+type NotificationLanguage string
+
+const (
+	SpanishNotificationLanguage NotificationLanguage = "es"
+	EnglishNotificationLanguage NotificationLanguage = "en"
+)
+
+func (nl NotificationLanguage) Value() string {
+	return string(nl)
+}
+
+type NotificationContent struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type NotificationRenderer interface {
+	// Render localizes the event for the language, showing its dates in the location
+	Render(eventType string, language NotificationLanguage, location *time.Location, data map[string]interface{}) (NotificationContent, error)
+}
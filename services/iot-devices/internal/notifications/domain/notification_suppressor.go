@@ -0,0 +1,9 @@
+package notifications_domain
+
+import "context"
+
+// NotificationSuppressor silences the events of the devices that must not be notified
+// for the time being, like the ones under maintenance.
+type NotificationSuppressor interface {
+	Suppressed(ctx context.Context, tenantID string, deviceID string) (bool, error)
+}
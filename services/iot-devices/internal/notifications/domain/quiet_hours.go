@@ -0,0 +1,38 @@
+package notifications_domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuietHours is a daily time range, in the time zone of the user, during which they do
+// not want to be notified. Ranges ending before they start span midnight.
+type QuietHours struct {
+	From string
+	To   string
+}
+
+func (qh QuietHours) Contains(at time.Time, location *time.Location) bool {
+	from, fromErr := minutesOfDay(qh.From)
+	to, toErr := minutesOfDay(qh.To)
+	if fromErr != nil || toErr != nil || from == to {
+		return false
+	}
+
+	local := at.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	if from < to {
+		return minute >= from && minute < to
+	}
+
+	return minute >= from || minute < to
+}
+
+func minutesOfDay(clock string) (int, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a HH:MM time", clock)
+	}
+
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
@@ -0,0 +1,138 @@
+package notifications_domain
+
+import (
+	"context"
+	"net/mail"
+	"regexp"
+	"slices"
+	"time"
+	_ "time/tzdata"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	preferencesUserIdMaxLength   = 50
+	preferencesTenantIdMaxLength = 50
+)
+
+var e164PhoneNumber = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// UserNotificationPreferences tell through which channels, in which language and when
+// a user wants to be notified. Preferences without event types get every alert event.
+type UserNotificationPreferences struct {
+	UserID     string
+	TenantID   string
+	Language   NotificationLanguage
+	Timezone   string
+	Email      string
+	Phone      string
+	Channels   []NotificationChannelName
+	EventTypes []string
+	QuietHours *QuietHours
+}
+
+func NewUserNotificationPreferences(
+	userID string,
+	tenantID string,
+	language NotificationLanguage,
+	timezone string,
+	email string,
+	phone string,
+	channels []NotificationChannelName,
+	eventTypes []string,
+	quietHours *QuietHours,
+) (UserNotificationPreferences, error) {
+	userIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(preferencesUserIdMaxLength),
+	)
+	if err := userIdValidator.Validate(userID, NewInvalidNotificationPreferences(userID, "user_id", "must be a non empty string")); err != nil {
+		return UserNotificationPreferences{}, err
+	}
+
+	tenantIdValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(preferencesTenantIdMaxLength))
+	if err := tenantIdValidator.Validate(tenantID, NewInvalidNotificationPreferences(userID, "tenant_id", "is too long")); err != nil {
+		return UserNotificationPreferences{}, err
+	}
+
+	if language != SpanishNotificationLanguage && language != EnglishNotificationLanguage {
+		return UserNotificationPreferences{}, NewInvalidNotificationPreferences(userID, "language", "must be es or en")
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		return UserNotificationPreferences{}, NewInvalidNotificationPreferences(userID, "timezone", "must be an IANA time zone")
+	}
+
+	for _, channel := range channels {
+		switch channel {
+		case EmailNotificationChannel:
+			if _, err := mail.ParseAddress(email); err != nil {
+				return UserNotificationPreferences{}, NewInvalidNotificationPreferences(userID, "email", "must be an email address")
+			}
+		case SmsNotificationChannel:
+			if !e164PhoneNumber.MatchString(phone) {
+				return UserNotificationPreferences{}, NewInvalidNotificationPreferences(userID, "phone", "must be an E.164 phone number")
+			}
+		default:
+			return UserNotificationPreferences{}, NewInvalidNotificationPreferences(userID, "channels", "unknown channel "+channel.Value())
+		}
+	}
+
+	if quietHours != nil {
+		if _, err := minutesOfDay(quietHours.From); err != nil {
+			return UserNotificationPreferences{}, NewInvalidNotificationPreferences(userID, "quiet_hours.from", err.Error())
+		}
+		if _, err := minutesOfDay(quietHours.To); err != nil {
+			return UserNotificationPreferences{}, NewInvalidNotificationPreferences(userID, "quiet_hours.to", err.Error())
+		}
+	}
+
+	return UserNotificationPreferences{
+		UserID:     userID,
+		TenantID:   tenantID,
+		Language:   language,
+		Timezone:   timezone,
+		Email:      email,
+		Phone:      phone,
+		Channels:   channels,
+		EventTypes: eventTypes,
+		QuietHours: quietHours,
+	}, nil
+}
+
+func (unp UserNotificationPreferences) Location() *time.Location {
+	location, err := time.LoadLocation(unp.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+func (unp UserNotificationPreferences) Wants(eventType string) bool {
+	return len(unp.EventTypes) == 0 || slices.Contains(unp.EventTypes, eventType)
+}
+
+func (unp UserNotificationPreferences) QuietAt(at time.Time) bool {
+	return unp.QuietHours != nil && unp.QuietHours.Contains(at, unp.Location())
+}
+
+// AddressFor is the recipient of the user in the channel, empty when the user has none.
+func (unp UserNotificationPreferences) AddressFor(channel NotificationChannelName) string {
+	switch channel {
+	case EmailNotificationChannel:
+		return unp.Email
+	case SmsNotificationChannel:
+		return unp.Phone
+	default:
+		return ""
+	}
+}
+
+type UserNotificationPreferencesRepository interface {
+	Save(ctx context.Context, preferences UserNotificationPreferences) error
+	// Find returns nil when the user has no preferences
+	Find(ctx context.Context, userID string) (*UserNotificationPreferences, error)
+	SearchByTenant(ctx context.Context, tenantID string) ([]UserNotificationPreferences, error)
+}
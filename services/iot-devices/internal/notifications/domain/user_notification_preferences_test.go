@@ -0,0 +1,91 @@
+package notifications_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+)
+
+func TestNewUserNotificationPreferences(t *testing.T) {
+	email := []notifications_domain.NotificationChannelName{notifications_domain.EmailNotificationChannel}
+	sms := []notifications_domain.NotificationChannelName{notifications_domain.SmsNotificationChannel}
+
+	t.Run("should accept valid preferences", func(t *testing.T) {
+		preferences, err := notifications_domain.NewUserNotificationPreferences(
+			"user-1",
+			"tenant-1",
+			notifications_domain.SpanishNotificationLanguage,
+			"Europe/Madrid",
+			"user@example.com",
+			"+34600000000",
+			append(email, sms...),
+			[]string{"alerting.alert_opened"},
+			&notifications_domain.QuietHours{From: "22:00", To: "07:00"},
+		)
+
+		require.NoError(t, err)
+		assert.True(t, preferences.Wants("alerting.alert_opened"))
+		assert.False(t, preferences.Wants("alerting.alert_resolved"))
+		assert.Equal(t, "+34600000000", preferences.AddressFor(notifications_domain.SmsNotificationChannel))
+	})
+
+	invalidCases := map[string]struct {
+		language   notifications_domain.NotificationLanguage
+		timezone   string
+		email      string
+		phone      string
+		channels   []notifications_domain.NotificationChannelName
+		quietHours *notifications_domain.QuietHours
+	}{
+		"unknown language":               {language: "fr", timezone: "UTC"},
+		"unknown time zone":              {language: "en", timezone: "Mars/Olympus"},
+		"email channel without address":  {language: "en", timezone: "UTC", channels: email},
+		"sms channel with a local phone": {language: "en", timezone: "UTC", phone: "600000000", channels: sms},
+		"unknown channel":                {language: "en", timezone: "UTC", channels: []notifications_domain.NotificationChannelName{"pigeon"}},
+		"malformed quiet hours":          {language: "en", timezone: "UTC", quietHours: &notifications_domain.QuietHours{From: "25:00", To: "07:00"}},
+	}
+
+	for name, invalidCase := range invalidCases {
+		t.Run("should reject "+name, func(t *testing.T) {
+			_, err := notifications_domain.NewUserNotificationPreferences(
+				"user-1",
+				"tenant-1",
+				invalidCase.language,
+				invalidCase.timezone,
+				invalidCase.email,
+				invalidCase.phone,
+				invalidCase.channels,
+				nil,
+				invalidCase.quietHours,
+			)
+
+			assert.IsType(t, &notifications_domain.InvalidNotificationPreferences{}, err)
+		})
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	t.Run("should span midnight when ending before they start", func(t *testing.T) {
+		quietHours := notifications_domain.QuietHours{From: "22:00", To: "07:00"}
+
+		assert.True(t, quietHours.Contains(time.Date(2026, 1, 10, 23, 30, 0, 0, madrid), madrid))
+		assert.True(t, quietHours.Contains(time.Date(2026, 1, 10, 6, 59, 0, 0, madrid), madrid))
+		assert.False(t, quietHours.Contains(time.Date(2026, 1, 10, 7, 0, 0, 0, madrid), madrid))
+		assert.False(t, quietHours.Contains(time.Date(2026, 1, 10, 12, 0, 0, 0, madrid), madrid))
+	})
+
+	t.Run("should be checked in the time zone of the user", func(t *testing.T) {
+		quietHours := notifications_domain.QuietHours{From: "09:00", To: "17:00"}
+
+		// 08:30 UTC is 09:30 in Madrid during winter
+		assert.True(t, quietHours.Contains(time.Date(2026, 1, 10, 8, 30, 0, 0, time.UTC), madrid))
+		assert.False(t, quietHours.Contains(time.Date(2026, 1, 10, 8, 30, 0, 0, time.UTC), time.UTC))
+	})
+}
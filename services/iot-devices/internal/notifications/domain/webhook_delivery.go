@@ -0,0 +1,125 @@
+package notifications_domain
+
+import (
+	"context"
+	"time"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	PendingWebhookDelivery   WebhookDeliveryStatus = "pending"
+	SendingWebhookDelivery   WebhookDeliveryStatus = "sending"
+	SucceededWebhookDelivery WebhookDeliveryStatus = "succeeded"
+	FailedWebhookDelivery    WebhookDeliveryStatus = "failed"
+)
+
+func (wds WebhookDeliveryStatus) Value() string {
+	return string(wds)
+}
+
+// WebhookDelivery is an event to push to a subscription. Its payload is serialized
+// once so every attempt, redeliveries included, sends the very same bytes.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventType      string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	CreatedAt      time.Time
+	LastAttemptAt  *time.Time
+	ClaimedAt      *time.Time
+}
+
+func NewWebhookDelivery(id string, subscriptionID string, eventType string, payload []byte, now time.Time) WebhookDelivery {
+	return WebhookDelivery{
+		ID:             id,
+		SubscriptionID: subscriptionID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         PendingWebhookDelivery,
+		CreatedAt:      now,
+	}
+}
+
+// Claimable tells whether a worker may send the delivery: it is pending, or the worker
+// sending it claimed it before staleBefore and is taken for dead.
+func (wd WebhookDelivery) Claimable(staleBefore time.Time) bool {
+	if wd.Status == PendingWebhookDelivery {
+		return true
+	}
+
+	return wd.Status == SendingWebhookDelivery && wd.ClaimedAt != nil && wd.ClaimedAt.Before(staleBefore)
+}
+
+func (wd WebhookDelivery) Claimed(now time.Time) WebhookDelivery {
+	wd.Status = SendingWebhookDelivery
+	wd.ClaimedAt = &now
+
+	return wd
+}
+
+func (wd WebhookDelivery) Attempted(attempt WebhookDeliveryAttempt) WebhookDelivery {
+	wd.Attempts++
+	wd.LastAttemptAt = &attempt.At
+	wd.Status = FailedWebhookDelivery
+	if attempt.Succeeded() {
+		wd.Status = SucceededWebhookDelivery
+	}
+
+	return wd
+}
+
+// WebhookDeliveryAttempt records a request sent for a delivery. Attempts that did not
+// get any response have no status code but the error.
+type WebhookDeliveryAttempt struct {
+	ID         string
+	DeliveryID string
+	StatusCode int
+	Latency    time.Duration
+	Error      string
+	At         time.Time
+}
+
+func NewWebhookDeliveryAttempt(
+	id string,
+	deliveryID string,
+	statusCode int,
+	latency time.Duration,
+	err error,
+	at time.Time,
+) WebhookDeliveryAttempt {
+	attempt := WebhookDeliveryAttempt{ID: id, DeliveryID: deliveryID, StatusCode: statusCode, Latency: latency, At: at}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	return attempt
+}
+
+func (wda WebhookDeliveryAttempt) Succeeded() bool {
+	return wda.Error == "" && wda.StatusCode >= 200 && wda.StatusCode < 300
+}
+
+// Retryable tells whether sending the delivery again could get a different answer.
+// Client errors other than timeouts and throttling will not.
+func (wda WebhookDeliveryAttempt) Retryable() bool {
+	if wda.Error != "" || wda.StatusCode >= 500 {
+		return true
+	}
+
+	return wda.StatusCode == 408 || wda.StatusCode == 429
+}
+
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, delivery WebhookDelivery) error
+	// Find returns nil when the delivery does not exist
+	Find(ctx context.Context, id string) (*WebhookDelivery, error)
+	// SearchClaimable returns the pending deliveries and the ones claimed before
+	// staleBefore and still sending, oldest first
+	SearchClaimable(ctx context.Context, staleBefore time.Time, limit int) ([]WebhookDelivery, error)
+	SearchBySubscription(ctx context.Context, subscriptionID string, limit int) ([]WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, attempt WebhookDeliveryAttempt) error
+	SearchAttempts(ctx context.Context, deliveryID string) ([]WebhookDeliveryAttempt, error)
+}
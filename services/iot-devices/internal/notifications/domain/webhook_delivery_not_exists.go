@@ -0,0 +1,24 @@
+package notifications_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const webhookDeliveryNotExistsErrorMessage = "Webhook delivery not exists"
+
+type WebhookDeliveryNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (wdne WebhookDeliveryNotExists) Error() string {
+	return webhookDeliveryNotExistsErrorMessage
+}
+
+func (wdne WebhookDeliveryNotExists) ExtraItems() map[string]interface{} {
+	return wdne.items
+}
+
+func NewWebhookDeliveryNotExists(id string) *WebhookDeliveryNotExists {
+	return &WebhookDeliveryNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,48 @@
+package notifications_domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventType  string
+	Payload    []byte
+	SentAt     time.Time
+}
+
+type WebhookResponse struct {
+	StatusCode int
+	Latency    time.Duration
+}
+
+type WebhookSender interface {
+	// Send returns an error only when no response was received
+	Send(ctx context.Context, request WebhookRequest) (WebhookResponse, error)
+}
+
+// SignWebhookPayload signs the timestamp along with the payload so receivers can
+// reject replayed requests. Receivers compute the same HMAC and compare it with the
+// X-Webhook-Signature header.
+func SignWebhookPayload(secret string, sentAt time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(sentAt.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,68 @@
+package notifications_domain
+
+import (
+	"net/url"
+	"slices"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	webhookTenantIdMaxLength = 50
+	webhookUrlMaxLength      = 2048
+	webhookSecretMinLength   = 16
+)
+
+// WebhookSubscription pushes the events of a tenant to an URL of their own. Subscriptions
+// without event types receive every event of the tenant.
+type WebhookSubscription struct {
+	ID         string
+	TenantID   string
+	URL        string
+	Secret     string
+	EventTypes []string
+	Enabled    bool
+	CreatedAt  time.Time
+}
+
+func NewWebhookSubscription(
+	id string,
+	tenantID string,
+	rawURL string,
+	secret string,
+	eventTypes []string,
+	now time.Time,
+) (WebhookSubscription, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidWebhookSubscription(id, "id", "must be a ULID")); err != nil {
+		return WebhookSubscription{}, err
+	}
+
+	tenantIdValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(webhookTenantIdMaxLength))
+	if err := tenantIdValidator.Validate(tenantID, NewInvalidWebhookSubscription(id, "tenant_id", "is too long")); err != nil {
+		return WebhookSubscription{}, err
+	}
+
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" || len(rawURL) > webhookUrlMaxLength {
+		return WebhookSubscription{}, NewInvalidWebhookSubscription(id, "url", "must be an absolute http or https URL")
+	}
+
+	if len(secret) < webhookSecretMinLength {
+		return WebhookSubscription{}, NewInvalidWebhookSubscription(id, "secret", "must be at least 16 characters long")
+	}
+
+	return WebhookSubscription{
+		ID:         id,
+		TenantID:   tenantID,
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Enabled:    true,
+		CreatedAt:  now,
+	}, nil
+}
+
+func (ws WebhookSubscription) Accepts(eventType string) bool {
+	return ws.Enabled && (len(ws.EventTypes) == 0 || slices.Contains(ws.EventTypes, eventType))
+}
@@ -0,0 +1,24 @@
+package notifications_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const webhookSubscriptionNotExistsErrorMessage = "Webhook subscription not exists"
+
+type WebhookSubscriptionNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (wsne WebhookSubscriptionNotExists) Error() string {
+	return webhookSubscriptionNotExistsErrorMessage
+}
+
+func (wsne WebhookSubscriptionNotExists) ExtraItems() map[string]interface{} {
+	return wsne.items
+}
+
+func NewWebhookSubscriptionNotExists(id string) *WebhookSubscriptionNotExists {
+	return &WebhookSubscriptionNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,11 @@
+package notifications_domain
+
+import "context"
+
+type WebhookSubscriptionRepository interface {
+	Save(ctx context.Context, subscription WebhookSubscription) error
+	// Find returns nil when the subscription does not exist
+	Find(ctx context.Context, id string) (*WebhookSubscription, error)
+	Delete(ctx context.Context, id string) error
+	SearchByTenant(ctx context.Context, tenantID string) ([]WebhookSubscription, error)
+}
@@ -0,0 +1,75 @@
+package notifications_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	id, now := amf_utils.NewUlid().String(), time.Now()
+	secret := "0123456789abcdef"
+
+	t.Run("should accept every event without event types", func(t *testing.T) {
+		subscription, err := notifications_domain.NewWebhookSubscription(id, "tenant-1", "https://example.com/hooks", secret, nil, now)
+
+		require.NoError(t, err)
+		assert.True(t, subscription.Accepts("alerting.alert_opened"))
+	})
+
+	t.Run("should only accept its event types", func(t *testing.T) {
+		subscription, err := notifications_domain.NewWebhookSubscription(
+			id,
+			"tenant-1",
+			"https://example.com/hooks",
+			secret,
+			[]string{"alerting.alert_opened"},
+			now,
+		)
+
+		require.NoError(t, err)
+		assert.True(t, subscription.Accepts("alerting.alert_opened"))
+		assert.False(t, subscription.Accepts("alerting.alert_resolved"))
+	})
+
+	t.Run("should reject non http URLs", func(t *testing.T) {
+		_, err := notifications_domain.NewWebhookSubscription(id, "tenant-1", "ftp://example.com/hooks", secret, nil, now)
+
+		assert.IsType(t, &notifications_domain.InvalidWebhookSubscription{}, err)
+	})
+
+	t.Run("should reject short secrets", func(t *testing.T) {
+		_, err := notifications_domain.NewWebhookSubscription(id, "tenant-1", "https://example.com/hooks", "secret", nil, now)
+
+		assert.IsType(t, &notifications_domain.InvalidWebhookSubscription{}, err)
+	})
+}
+
+func TestWebhookDeliveryAttempt(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should only retry server errors, timeouts and throttling", func(t *testing.T) {
+		for statusCode, retryable := range map[int]bool{500: true, 503: true, 408: true, 429: true, 400: false, 404: false, 410: false} {
+			attempt := notifications_domain.NewWebhookDeliveryAttempt("attempt-1", "delivery-1", statusCode, time.Millisecond, nil, now)
+
+			assert.Equal(t, retryable, attempt.Retryable(), "status code %d", statusCode)
+		}
+	})
+
+	t.Run("should mark the delivery with the outcome of its last attempt", func(t *testing.T) {
+		delivery := notifications_domain.NewWebhookDelivery("delivery-1", "subscription-1", "alerting.alert_opened", []byte(`{}`), now)
+
+		failed := delivery.Attempted(notifications_domain.NewWebhookDeliveryAttempt("attempt-1", delivery.ID, 0, 0, assert.AnError, now))
+		succeeded := failed.Attempted(notifications_domain.NewWebhookDeliveryAttempt("attempt-2", delivery.ID, 204, 0, nil, now))
+
+		assert.Equal(t, notifications_domain.FailedWebhookDelivery, failed.Status)
+		assert.Equal(t, notifications_domain.SucceededWebhookDelivery, succeeded.Status)
+		assert.Equal(t, 2, succeeded.Attempts)
+	})
+}
@@ -0,0 +1,71 @@
+package notifications_infra
+
+import (
+	"context"
+	"sync"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+)
+
+// FakeNotificationChannel keeps the messages instead of sending them, so tests can
+// check what would have been sent.
+type FakeNotificationChannel struct {
+	name     notifications_domain.NotificationChannelName
+	mu       sync.Mutex
+	messages []notifications_domain.NotificationMessage
+}
+
+func NewFakeNotificationChannel(name notifications_domain.NotificationChannelName) *FakeNotificationChannel {
+	return &FakeNotificationChannel{name: name}
+}
+
+func (c *FakeNotificationChannel) Name() notifications_domain.NotificationChannelName {
+	return c.name
+}
+
+func (c *FakeNotificationChannel) Send(_ context.Context, message notifications_domain.NotificationMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = append(c.messages, message)
+
+	return nil
+}
+
+func (c *FakeNotificationChannel) Sent() []notifications_domain.NotificationMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]notifications_domain.NotificationMessage{}, c.messages...)
+}
+
+type FakeSms struct {
+	To   string
+	Text string
+}
+
+// FakeSmsProvider keeps the SMS instead of sending them.
+type FakeSmsProvider struct {
+	mu   sync.Mutex
+	sent []FakeSms
+}
+
+func NewFakeSmsProvider() *FakeSmsProvider {
+	return &FakeSmsProvider{}
+}
+
+func (p *FakeSmsProvider) Send(_ context.Context, to string, text string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sent = append(p.sent, FakeSms{To: to, Text: text})
+
+	return nil
+}
+
+func (p *FakeSmsProvider) Sent() []FakeSms {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]FakeSms{}, p.sent...)
+}
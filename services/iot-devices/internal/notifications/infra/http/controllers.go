@@ -0,0 +1,202 @@
+package notifications_http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func NewCreateWebhookSubscriptionController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		command := &notifications_application.CreateWebhookSubscriptionCommand{
+			ID:         ulidProvider.New().String(),
+			TenantID:   tenantID,
+			URL:        stringAttribute(requestParams, "url"),
+			Secret:     stringAttribute(requestParams, "secret"),
+			EventTypes: stringListAttribute(requestParams, "event_types"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeNotificationError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &notifications_application.FindWebhookSubscriptionQuery{ID: command.ID}, http.StatusCreated)
+	}
+}
+
+func NewDeleteWebhookSubscriptionController(
+	commandBus amf_command_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		command := &notifications_application.DeleteWebhookSubscriptionCommand{ID: mux.Vars(r)["subscriptionId"]}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeNotificationError(w, r, jarm, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, nil, http.StatusNoContent)
+	}
+}
+
+func NewGetWebhookSubscriptionController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &notifications_application.FindWebhookSubscriptionQuery{ID: mux.Vars(r)["subscriptionId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetWebhookSubscriptionsController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		query := &notifications_application.SearchWebhookSubscriptionsQuery{TenantID: tenantID}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetWebhookDeliveriesController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &notifications_application.SearchWebhookDeliveriesQuery{SubscriptionID: mux.Vars(r)["subscriptionId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetWebhookDeliveryController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &notifications_application.FindWebhookDeliveryQuery{ID: mux.Vars(r)["deliveryId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewRedeliverWebhookController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		command := &notifications_application.RedeliverWebhookCommand{DeliveryID: mux.Vars(r)["deliveryId"]}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeNotificationError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &notifications_application.FindWebhookDeliveryQuery{ID: command.DeliveryID}, http.StatusOK)
+	}
+}
+
+func writeQueryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query amf_bus.Dto,
+	statusCode int,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), query)
+	if err != nil {
+		writeNotificationError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, statusCode)
+}
+
+func writeNotificationError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *notifications_domain.WebhookSubscriptionNotExists,
+		*notifications_domain.WebhookDeliveryNotExists,
+		*notifications_domain.NotificationPreferencesNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *notifications_domain.InvalidWebhookSubscription:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *notifications_domain.InvalidNotificationPreferences:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		writeInternalServerError(w, r, jarm, err)
+	}
+}
+
+func writeInternalServerError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+	jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+}
+
+func stringAttribute(requestParams map[string]interface{}, attribute string) string {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, "").(string)
+
+	return value
+}
+
+func stringListAttribute(requestParams map[string]interface{}, attribute string) []string {
+	rawValues, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, nil).([]interface{})
+
+	values := make([]string, 0, len(rawValues))
+	for _, rawValue := range rawValues {
+		if value, ok := rawValue.(string); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
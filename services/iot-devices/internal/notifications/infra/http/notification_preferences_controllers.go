@@ -0,0 +1,60 @@
+package notifications_http
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+func NewGetNotificationPreferencesController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &notifications_application.FindNotificationPreferencesQuery{UserID: mux.Vars(r)["userId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewPutNotificationPreferencesController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+		command := &notifications_application.PutNotificationPreferencesCommand{
+			UserID:         mux.Vars(r)["userId"],
+			TenantID:       tenantID,
+			Language:       stringAttribute(requestParams, "language"),
+			Timezone:       stringAttribute(requestParams, "timezone"),
+			Email:          stringAttribute(requestParams, "email"),
+			Phone:          stringAttribute(requestParams, "phone"),
+			Channels:       stringListAttribute(requestParams, "channels"),
+			EventTypes:     stringListAttribute(requestParams, "event_types"),
+			QuietHoursFrom: stringAttribute(requestParams, "quiet_hours_from"),
+			QuietHoursTo:   stringAttribute(requestParams, "quiet_hours_to"),
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writeNotificationError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &notifications_application.FindNotificationPreferencesQuery{UserID: command.UserID}, http.StatusOK)
+	}
+}
@@ -0,0 +1,125 @@
+package notifications_infra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+)
+
+// HttpWebhookSender posts the webhooks to public addresses only. The address is checked
+// once the host is resolved, right before dialing it, so neither a DNS answer nor a
+// redirect can point a webhook at the loopback, link-local, private or shared networks.
+type HttpWebhookSender struct {
+	client                 *http.Client
+	privateNetworksAllowed bool
+}
+
+// nonPublicNetworks are the networks the net.IP checks leave out: "this network", which
+// some systems route to the host itself, and the carrier-grade NAT shared addresses.
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+}
+
+type HttpWebhookSenderOpt func(sender *HttpWebhookSender)
+
+// WithPrivateNetworksAllowed lets the webhooks reach any address, e.g. the local servers of the tests.
+func WithPrivateNetworksAllowed() HttpWebhookSenderOpt {
+	return func(sender *HttpWebhookSender) {
+		sender.privateNetworksAllowed = true
+	}
+}
+
+func NewHttpWebhookSender(timeout time.Duration, opts ...HttpWebhookSenderOpt) *HttpWebhookSender {
+	sender := &HttpWebhookSender{}
+	for _, opt := range opts {
+		opt(sender)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, Control: sender.controlDial}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the webhook, leaving its address unchecked
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	sender.client = &http.Client{Timeout: timeout, Transport: transport}
+
+	return sender
+}
+
+func (s *HttpWebhookSender) Send(
+	ctx context.Context,
+	request notifications_domain.WebhookRequest,
+) (notifications_domain.WebhookResponse, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Payload))
+	if err != nil {
+		return notifications_domain.WebhookResponse{}, err
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set(notifications_domain.WebhookEventHeader, request.EventType)
+	httpRequest.Header.Set(notifications_domain.WebhookDeliveryHeader, request.DeliveryID)
+	httpRequest.Header.Set(notifications_domain.WebhookTimestampHeader, strconv.FormatInt(request.SentAt.Unix(), 10))
+	httpRequest.Header.Set(
+		notifications_domain.WebhookSignatureHeader,
+		notifications_domain.SignWebhookPayload(request.Secret, request.SentAt, request.Payload),
+	)
+
+	startedAt := time.Now()
+	httpResponse, err := s.client.Do(httpRequest)
+	latency := time.Since(startedAt)
+	if err != nil {
+		return notifications_domain.WebhookResponse{Latency: latency}, err
+	}
+	defer httpResponse.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(httpResponse.Body, 1<<20))
+
+	return notifications_domain.WebhookResponse{StatusCode: httpResponse.StatusCode, Latency: latency}, nil
+}
+
+func (s *HttpWebhookSender) controlDial(_ string, address string, _ syscall.RawConn) error {
+	if s.privateNetworksAllowed {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || inNonPublicNetwork(ip) {
+		return fmt.Errorf("webhook address %s is not public", host)
+	}
+
+	return nil
+}
+
+func inNonPublicNetwork(ip net.IP) bool {
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
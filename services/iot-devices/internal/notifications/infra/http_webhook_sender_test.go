@@ -0,0 +1,159 @@
+package notifications_infra_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+	notifications_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain/mocks"
+	notifications_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/infra"
+
+	amf_retry "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/retry"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const webhookSecret = "0123456789abcdef"
+
+func TestHttpWebhookSender(t *testing.T) {
+	ctx := context.Background()
+	sentAt := time.Now()
+	payload := []byte(`{"type":"alerting.alert_opened"}`)
+
+	t.Run("should sign the payload along with the timestamp", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			assert.Equal(t, payload, body)
+			assert.Equal(t, "alerting.alert_opened", r.Header.Get(notifications_domain.WebhookEventHeader))
+			assert.Equal(t, "delivery-1", r.Header.Get(notifications_domain.WebhookDeliveryHeader))
+			assert.Equal(
+				t,
+				notifications_domain.SignWebhookPayload(webhookSecret, sentAt, body),
+				r.Header.Get(notifications_domain.WebhookSignatureHeader),
+			)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		response, err := notifications_infra.NewHttpWebhookSender(time.Second, notifications_infra.WithPrivateNetworksAllowed()).Send(ctx, notifications_domain.WebhookRequest{
+			URL:        server.URL,
+			Secret:     webhookSecret,
+			DeliveryID: "delivery-1",
+			EventType:  "alerting.alert_opened",
+			Payload:    payload,
+			SentAt:     sentAt,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, response.StatusCode)
+	})
+
+	t.Run("should fail when the webhook does not answer in time", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		_, err := notifications_infra.NewHttpWebhookSender(10*time.Millisecond, notifications_infra.WithPrivateNetworksAllowed()).Send(ctx, notifications_domain.WebhookRequest{
+			URL:     server.URL,
+			Secret:  webhookSecret,
+			Payload: payload,
+			SentAt:  sentAt,
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should refuse to reach the loopback, link-local, private or shared networks", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+		}))
+		defer server.Close()
+
+		sender := notifications_infra.NewHttpWebhookSender(100 * time.Millisecond)
+		for _, url := range []string{
+			server.URL,
+			"http://169.254.169.254/latest/meta-data",
+			"http://10.0.0.1",
+			"http://[::1]:8080",
+			"http://100.64.0.1",
+			"http://100.127.255.254",
+			"http://0.0.0.1",
+			"http://[::ffff:100.64.0.1]",
+		} {
+			_, err := sender.Send(ctx, notifications_domain.WebhookRequest{
+				URL:     url,
+				Secret:  webhookSecret,
+				Payload: payload,
+				SentAt:  sentAt,
+			})
+
+			assert.ErrorContains(t, err, "is not public", url)
+		}
+		assert.Equal(t, int32(0), requests.Load())
+	})
+}
+
+func TestDeliverWebhooksOverHttp(t *testing.T) {
+	ctx := context.Background()
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	subscription, err := notifications_domain.NewWebhookSubscription(
+		ulidProvider.New().String(),
+		"tenant-1",
+		server.URL,
+		webhookSecret,
+		nil,
+		timeProvider.Now(),
+	)
+	require.NoError(t, err)
+
+	subscriptions := notifications_domain_mocks.NewWebhookSubscriptionRepository(t)
+	deliveries := notifications_domain_mocks.NewWebhookDeliveryRepository(t)
+	subscriptions.On("Find", ctx, subscription.ID).Return(&subscription, nil).Once()
+	deliveries.On("RecordAttempt", ctx, mock.MatchedBy(func(attempt notifications_domain.WebhookDeliveryAttempt) bool {
+		return attempt.StatusCode == http.StatusBadGateway
+	})).Return(nil).Twice()
+	deliveries.On("RecordAttempt", ctx, mock.MatchedBy(func(attempt notifications_domain.WebhookDeliveryAttempt) bool {
+		return attempt.StatusCode == http.StatusOK
+	})).Return(nil).Once()
+	deliveries.On("Save", ctx, mock.Anything).Return(nil).Once()
+
+	dispatcher := notifications_application.NewWebhookDispatcher(
+		subscriptions,
+		deliveries,
+		notifications_infra.NewHttpWebhookSender(time.Second, notifications_infra.WithPrivateNetworksAllowed()),
+		amf_retry.RetryConfig{MaxRetries: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond, Multiplier: 1},
+		ulidProvider,
+		timeProvider,
+	)
+	delivery := notifications_domain.NewWebhookDelivery("delivery-1", subscription.ID, "alerting.alert_opened", []byte(`{}`), timeProvider.Now())
+
+	delivered, err := dispatcher.Deliver(ctx, delivery)
+
+	assert.NoError(t, err)
+	assert.Equal(t, notifications_domain.SucceededWebhookDelivery, delivered.Status)
+	assert.Equal(t, 3, delivered.Attempts)
+	assert.Equal(t, int32(3), requests.Load())
+}
@@ -0,0 +1,200 @@
+package notifications_infra
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+)
+
+// SmtpEmailChannel sends the notifications as multipart emails carrying both the text
+// and the HTML bodies, upgrading the connection with STARTTLS when the server offers it.
+type SmtpEmailChannel struct {
+	host    string
+	address string
+	auth    smtp.Auth
+	from    string
+	timeout time.Duration
+}
+
+func NewSmtpEmailChannel(
+	host string,
+	port int,
+	username string,
+	password string,
+	from string,
+	timeout time.Duration,
+) *SmtpEmailChannel {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SmtpEmailChannel{
+		host:    host,
+		address: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth:    auth,
+		from:    from,
+		timeout: timeout,
+	}
+}
+
+func (c *SmtpEmailChannel) Name() notifications_domain.NotificationChannelName {
+	return notifications_domain.EmailNotificationChannel
+}
+
+func (c *SmtpEmailChannel) Send(_ context.Context, message notifications_domain.NotificationMessage) error {
+	body, err := c.compose(message)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", c.address, c.timeout)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, c.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.host}); err != nil {
+			return err
+		}
+	}
+	if c.auth != nil {
+		if err := client.Auth(c.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(c.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(message.To); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (c *SmtpEmailChannel) compose(message notifications_domain.NotificationMessage) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	fmt.Fprintf(&body, "From: %s\r\n", c.from)
+	fmt.Fprintf(&body, "To: %s\r\n", message.To)
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&body, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&body, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+
+	alternatives := []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", message.Text},
+		{"text/html; charset=utf-8", message.HTML},
+	}
+	for _, alternative := range alternatives {
+		if alternative.content == "" {
+			continue
+		}
+
+		part, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {alternative.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write([]byte(alternative.content)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return body.Bytes(), nil
+}
+
+// SmsChannel sends the text of the notifications through an SMS provider.
+type SmsChannel struct {
+	provider notifications_domain.SmsProvider
+}
+
+func NewSmsChannel(provider notifications_domain.SmsProvider) *SmsChannel {
+	return &SmsChannel{provider: provider}
+}
+
+func (c *SmsChannel) Name() notifications_domain.NotificationChannelName {
+	return notifications_domain.SmsNotificationChannel
+}
+
+func (c *SmsChannel) Send(ctx context.Context, message notifications_domain.NotificationMessage) error {
+	return c.provider.Send(ctx, message.To, message.Text)
+}
+
+// HttpSmsProvider posts the messages as JSON to an SMS gateway authenticated with a
+// bearer token.
+type HttpSmsProvider struct {
+	url    string
+	token  string
+	from   string
+	client *http.Client
+}
+
+func NewHttpSmsProvider(url string, token string, from string, timeout time.Duration) *HttpSmsProvider {
+	return &HttpSmsProvider{url: url, token: token, from: from, client: &http.Client{Timeout: timeout}}
+}
+
+func (p *HttpSmsProvider) Send(ctx context.Context, to string, text string) error {
+	payload, err := json.Marshal(map[string]string{"from": p.from, "to": to, "text": text})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+p.token)
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("sms provider answered with status code %d", response.StatusCode)
+	}
+
+	return nil
+}
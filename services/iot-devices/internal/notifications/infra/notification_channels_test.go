@@ -0,0 +1,97 @@
+package notifications_infra_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+	notifications_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain/mocks"
+	notifications_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/infra"
+
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestHttpSmsProvider(t *testing.T) {
+	t.Run("should post the message to the provider with its token", func(t *testing.T) {
+		var received map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		provider := notifications_infra.NewHttpSmsProvider(server.URL, "token", "IOT", time.Second)
+
+		require.NoError(t, provider.Send(context.Background(), "+34600000000", "hello"))
+		assert.Equal(t, map[string]string{"from": "IOT", "to": "+34600000000", "text": "hello"}, received)
+	})
+
+	t.Run("should fail when the provider refuses the message", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}))
+		defer server.Close()
+
+		provider := notifications_infra.NewHttpSmsProvider(server.URL, "token", "IOT", time.Second)
+
+		assert.Error(t, provider.Send(context.Background(), "+34600000000", "hello"))
+	})
+}
+
+func TestNotifyAlertsThroughChannels(t *testing.T) {
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	renderer, err := notifications_infra.NewTemplateNotificationRenderer()
+	require.NoError(t, err)
+
+	user := notifications_domain.UserNotificationPreferences{
+		UserID:   "user-1",
+		Language: notifications_domain.EnglishNotificationLanguage,
+		Timezone: "UTC",
+		Email:    "user-1@example.com",
+		Phone:    "+34600000000",
+		Channels: []notifications_domain.NotificationChannelName{
+			notifications_domain.EmailNotificationChannel,
+			notifications_domain.SmsNotificationChannel,
+		},
+	}
+	preferences := notifications_domain_mocks.NewUserNotificationPreferencesRepository(t)
+	preferences.On("SearchByTenant", ctx, "").Return([]notifications_domain.UserNotificationPreferences{user}, nil).Once()
+
+	suppressor := notifications_domain_mocks.NewNotificationSuppressor(t)
+	suppressor.On("Suppressed", ctx, "", "device-1").Return(false, nil).Once()
+
+	email := notifications_infra.NewFakeNotificationChannel(notifications_domain.EmailNotificationChannel)
+	smsProvider := notifications_infra.NewFakeSmsProvider()
+
+	notifier := notifications_application.NewAlertNotifier(
+		preferences,
+		renderer,
+		suppressor,
+		amf_utils.NewFixedTimeProvider(),
+		email,
+		notifications_infra.NewSmsChannel(smsProvider),
+	)
+
+	event := alerting_domain.NewAlertResolved(alerting_domain.Alert{ID: "alert-1", RuleName: "High temperature", DeviceID: "device-1"})
+	require.NoError(t, notifier.Handle(event))
+
+	require.Len(t, email.Sent(), 1)
+	assert.Equal(t, "user-1@example.com", email.Sent()[0].To)
+	assert.Contains(t, email.Sent()[0].Subject, "High temperature")
+	assert.NotEmpty(t, email.Sent()[0].HTML)
+
+	require.Len(t, smsProvider.Sent(), 1)
+	assert.Equal(t, "+34600000000", smsProvider.Sent()[0].To)
+	assert.Equal(t, email.Sent()[0].Text, smsProvider.Sent()[0].Text)
+}
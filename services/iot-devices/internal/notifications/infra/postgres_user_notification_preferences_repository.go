@@ -0,0 +1,168 @@
+package notifications_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	userNotificationPreferencesColumns = `user_id, tenant_id, language, timezone, email, phone, channels, event_types, quiet_hours_from, quiet_hours_to`
+
+	upsertUserNotificationPreferencesQuery = `
+INSERT INTO user_notification_preferences (` + userNotificationPreferencesColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (user_id) DO UPDATE SET
+    language = EXCLUDED.language,
+    timezone = EXCLUDED.timezone,
+    email = EXCLUDED.email,
+    phone = EXCLUDED.phone,
+    channels = EXCLUDED.channels,
+    event_types = EXCLUDED.event_types,
+    quiet_hours_from = EXCLUDED.quiet_hours_from,
+    quiet_hours_to = EXCLUDED.quiet_hours_to
+WHERE user_notification_preferences.tenant_id = EXCLUDED.tenant_id`
+	findUserNotificationPreferencesQuery = `SELECT ` + userNotificationPreferencesColumns + ` FROM user_notification_preferences
+WHERE user_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchUserNotificationPreferencesQuery = `SELECT ` + userNotificationPreferencesColumns + ` FROM user_notification_preferences
+WHERE tenant_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY user_id`
+)
+
+type PostgresUserNotificationPreferencesRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresUserNotificationPreferencesRepository(
+	connectionPool amf_sqldb.ConnectionPool,
+) *PostgresUserNotificationPreferencesRepository {
+	return &PostgresUserNotificationPreferencesRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresUserNotificationPreferencesRepository) Save(
+	ctx context.Context,
+	preferences notifications_domain.UserNotificationPreferences,
+) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, preferences.TenantID); err != nil {
+		return err
+	}
+
+	channels := make([]string, 0, len(preferences.Channels))
+	for _, channel := range preferences.Channels {
+		channels = append(channels, channel.Value())
+	}
+
+	var quietHoursFrom, quietHoursTo sql.NullString
+	if preferences.QuietHours != nil {
+		quietHoursFrom = sql.NullString{String: preferences.QuietHours.From, Valid: true}
+		quietHoursTo = sql.NullString{String: preferences.QuietHours.To, Valid: true}
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertUserNotificationPreferencesQuery,
+		preferences.UserID,
+		preferences.TenantID,
+		preferences.Language.Value(),
+		preferences.Timezone,
+		preferences.Email,
+		preferences.Phone,
+		pq.Array(channels),
+		pq.Array(preferences.EventTypes),
+		quietHoursFrom,
+		quietHoursTo,
+	)
+
+	return err
+}
+
+func (r *PostgresUserNotificationPreferencesRepository) Find(
+	ctx context.Context,
+	userID string,
+) (*notifications_domain.UserNotificationPreferences, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := scanUserNotificationPreferences(
+		r.connectionPool.Reader().QueryRowContext(ctx, findUserNotificationPreferencesQuery, userID, scope),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &preferences, nil
+}
+
+func (r *PostgresUserNotificationPreferencesRepository) SearchByTenant(
+	ctx context.Context,
+	tenantID string,
+) ([]notifications_domain.UserNotificationPreferences, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchUserNotificationPreferencesQuery, tenantID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	users := make([]notifications_domain.UserNotificationPreferences, 0)
+	for rows.Next() {
+		preferences, err := scanUserNotificationPreferences(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, preferences)
+	}
+
+	return users, rows.Err()
+}
+
+func scanUserNotificationPreferences(row rowScanner) (notifications_domain.UserNotificationPreferences, error) {
+	var (
+		preferences                  notifications_domain.UserNotificationPreferences
+		language                     string
+		channels                     []string
+		quietHoursFrom, quietHoursTo sql.NullString
+	)
+
+	err := row.Scan(
+		&preferences.UserID,
+		&preferences.TenantID,
+		&language,
+		&preferences.Timezone,
+		&preferences.Email,
+		&preferences.Phone,
+		pq.Array(&channels),
+		pq.Array(&preferences.EventTypes),
+		&quietHoursFrom,
+		&quietHoursTo,
+	)
+	if err != nil {
+		return notifications_domain.UserNotificationPreferences{}, err
+	}
+
+	preferences.Language = notifications_domain.NotificationLanguage(language)
+	for _, channel := range channels {
+		preferences.Channels = append(preferences.Channels, notifications_domain.NotificationChannelName(channel))
+	}
+	if quietHoursFrom.Valid && quietHoursTo.Valid {
+		preferences.QuietHours = &notifications_domain.QuietHours{From: quietHoursFrom.String, To: quietHoursTo.String}
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,228 @@
+package notifications_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	webhookDeliveryColumns = `id, subscription_id, event_type, payload, status, attempts, created_at, last_attempt_at, claimed_at`
+
+	upsertWebhookDeliveryQuery = `
+INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (id) DO UPDATE SET
+    status = EXCLUDED.status,
+    attempts = EXCLUDED.attempts,
+    last_attempt_at = EXCLUDED.last_attempt_at,
+    claimed_at = EXCLUDED.claimed_at`
+	findWebhookDeliveryQuery = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+WHERE id = $1
+  AND ($2::VARCHAR IS NULL OR subscription_id IN (SELECT id FROM webhook_subscriptions WHERE tenant_id = $2))`
+	searchClaimableWebhookDeliveries = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+WHERE (status = 'pending' OR (status = 'sending' AND claimed_at < $1))
+  AND ($3::VARCHAR IS NULL OR subscription_id IN (SELECT id FROM webhook_subscriptions WHERE tenant_id = $3))
+ORDER BY created_at, id
+LIMIT $2`
+	searchWebhookDeliveriesBySubscription = `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+WHERE subscription_id = $1
+  AND ($3::VARCHAR IS NULL OR subscription_id IN (SELECT id FROM webhook_subscriptions WHERE tenant_id = $3))
+ORDER BY created_at DESC, id DESC
+LIMIT $2`
+
+	recordWebhookDeliveryAttemptQuery = `
+INSERT INTO webhook_delivery_attempts (id, delivery_id, status_code, latency_ms, error, at)
+VALUES ($1, $2, $3, $4, $5, $6)`
+	searchWebhookDeliveryAttemptsQuery = `
+SELECT id, delivery_id, status_code, latency_ms, error, at
+FROM webhook_delivery_attempts
+WHERE delivery_id = $1
+  AND ($2::VARCHAR IS NULL OR delivery_id IN (
+      SELECT wd.id FROM webhook_deliveries wd
+      JOIN webhook_subscriptions ws ON ws.id = wd.subscription_id
+      WHERE ws.tenant_id = $2))
+ORDER BY at, id`
+)
+
+type PostgresWebhookDeliveryRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresWebhookDeliveryRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresWebhookDeliveryRepository {
+	return &PostgresWebhookDeliveryRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresWebhookDeliveryRepository) Save(ctx context.Context, delivery notifications_domain.WebhookDelivery) error {
+	var lastAttemptAt, claimedAt sql.NullTime
+	if delivery.LastAttemptAt != nil {
+		lastAttemptAt = sql.NullTime{Time: delivery.LastAttemptAt.UTC(), Valid: true}
+	}
+	if delivery.ClaimedAt != nil {
+		claimedAt = sql.NullTime{Time: delivery.ClaimedAt.UTC(), Valid: true}
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertWebhookDeliveryQuery,
+		delivery.ID,
+		delivery.SubscriptionID,
+		delivery.EventType,
+		string(delivery.Payload),
+		delivery.Status.Value(),
+		delivery.Attempts,
+		delivery.CreatedAt.UTC(),
+		lastAttemptAt,
+		claimedAt,
+	)
+
+	return err
+}
+
+// Find and SearchClaimable read from the writer, as a lagging replica would show the
+// deliveries already claimed as still pending and let another worker send them too.
+func (r *PostgresWebhookDeliveryRepository) Find(ctx context.Context, id string) (*notifications_domain.WebhookDelivery, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := scanWebhookDelivery(r.connectionPool.Writer().QueryRowContext(ctx, findWebhookDeliveryQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (r *PostgresWebhookDeliveryRepository) SearchClaimable(
+	ctx context.Context,
+	staleBefore time.Time,
+	limit int,
+) ([]notifications_domain.WebhookDelivery, error) {
+	return r.search(ctx, r.connectionPool.Writer(), searchClaimableWebhookDeliveries, staleBefore.UTC(), limit)
+}
+
+func (r *PostgresWebhookDeliveryRepository) SearchBySubscription(
+	ctx context.Context,
+	subscriptionID string,
+	limit int,
+) ([]notifications_domain.WebhookDelivery, error) {
+	return r.search(ctx, r.connectionPool.Reader(), searchWebhookDeliveriesBySubscription, subscriptionID, limit)
+}
+
+func (r *PostgresWebhookDeliveryRepository) RecordAttempt(ctx context.Context, attempt notifications_domain.WebhookDeliveryAttempt) error {
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		recordWebhookDeliveryAttemptQuery,
+		attempt.ID,
+		attempt.DeliveryID,
+		sql.NullInt64{Int64: int64(attempt.StatusCode), Valid: attempt.StatusCode != 0},
+		attempt.Latency.Milliseconds(),
+		attempt.Error,
+		attempt.At.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresWebhookDeliveryRepository) SearchAttempts(
+	ctx context.Context,
+	deliveryID string,
+) ([]notifications_domain.WebhookDeliveryAttempt, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchWebhookDeliveryAttemptsQuery, deliveryID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	attempts := make([]notifications_domain.WebhookDeliveryAttempt, 0)
+	for rows.Next() {
+		var attempt notifications_domain.WebhookDeliveryAttempt
+		var statusCode sql.NullInt64
+		var latencyMs int64
+		if err := rows.Scan(&attempt.ID, &attempt.DeliveryID, &statusCode, &latencyMs, &attempt.Error, &attempt.At); err != nil {
+			return nil, err
+		}
+		attempt.StatusCode = int(statusCode.Int64)
+		attempt.Latency = time.Duration(latencyMs) * time.Millisecond
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, rows.Err()
+}
+
+func (r *PostgresWebhookDeliveryRepository) search(
+	ctx context.Context,
+	db *sql.DB,
+	query string,
+	args ...any,
+) ([]notifications_domain.WebhookDelivery, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, append(args, scope)...)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	deliveries := make([]notifications_domain.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func scanWebhookDelivery(row rowScanner) (notifications_domain.WebhookDelivery, error) {
+	var delivery notifications_domain.WebhookDelivery
+	var payload, status string
+	var lastAttemptAt, claimedAt sql.NullTime
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventType,
+		&payload,
+		&status,
+		&delivery.Attempts,
+		&delivery.CreatedAt,
+		&lastAttemptAt,
+		&claimedAt,
+	)
+	if err != nil {
+		return notifications_domain.WebhookDelivery{}, err
+	}
+
+	delivery.Payload = []byte(payload)
+	delivery.Status = notifications_domain.WebhookDeliveryStatus(status)
+	if lastAttemptAt.Valid {
+		delivery.LastAttemptAt = &lastAttemptAt.Time
+	}
+	if claimedAt.Valid {
+		delivery.ClaimedAt = &claimedAt.Time
+	}
+
+	return delivery, nil
+}
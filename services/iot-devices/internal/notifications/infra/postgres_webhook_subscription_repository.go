@@ -0,0 +1,138 @@
+package notifications_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	webhookSubscriptionColumns = `id, tenant_id, url, secret, event_types, enabled, created_at`
+
+	upsertWebhookSubscriptionQuery = `
+INSERT INTO webhook_subscriptions (` + webhookSubscriptionColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (id) DO UPDATE SET
+    url = EXCLUDED.url,
+    secret = EXCLUDED.secret,
+    event_types = EXCLUDED.event_types,
+    enabled = EXCLUDED.enabled
+WHERE webhook_subscriptions.tenant_id = EXCLUDED.tenant_id`
+	findWebhookSubscriptionQuery = `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	deleteWebhookSubscriptionQuery = `DELETE FROM webhook_subscriptions
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchWebhookSubscriptionsQuery = `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions
+WHERE tenant_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY created_at, id`
+)
+
+type PostgresWebhookSubscriptionRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresWebhookSubscriptionRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresWebhookSubscriptionRepository {
+	return &PostgresWebhookSubscriptionRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresWebhookSubscriptionRepository) Save(ctx context.Context, subscription notifications_domain.WebhookSubscription) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, subscription.TenantID); err != nil {
+		return err
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertWebhookSubscriptionQuery,
+		subscription.ID,
+		subscription.TenantID,
+		subscription.URL,
+		subscription.Secret,
+		pq.Array(subscription.EventTypes),
+		subscription.Enabled,
+		subscription.CreatedAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresWebhookSubscriptionRepository) Find(ctx context.Context, id string) (*notifications_domain.WebhookSubscription, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := scanWebhookSubscription(r.connectionPool.Reader().QueryRowContext(ctx, findWebhookSubscriptionQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (r *PostgresWebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.connectionPool.Writer().ExecContext(ctx, deleteWebhookSubscriptionQuery, id, scope)
+
+	return err
+}
+
+func (r *PostgresWebhookSubscriptionRepository) SearchByTenant(
+	ctx context.Context,
+	tenantID string,
+) ([]notifications_domain.WebhookSubscription, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchWebhookSubscriptionsQuery, tenantID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	subscriptions := make([]notifications_domain.WebhookSubscription, 0)
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebhookSubscription(row rowScanner) (notifications_domain.WebhookSubscription, error) {
+	var subscription notifications_domain.WebhookSubscription
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.TenantID,
+		&subscription.URL,
+		&subscription.Secret,
+		pq.Array(&subscription.EventTypes),
+		&subscription.Enabled,
+		&subscription.CreatedAt,
+	)
+
+	return subscription, err
+}
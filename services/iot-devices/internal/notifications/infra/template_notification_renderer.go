@@ -0,0 +1,131 @@
+package notifications_infra
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	html_template "html/template"
+	"strings"
+	text_template "text/template"
+	"time"
+
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+)
+
+//go:embed templates
+var notificationTemplates embed.FS
+
+const (
+	fallbackNotificationLanguage = notifications_domain.EnglishNotificationLanguage
+	notificationDateTimeLayout   = "2006-01-02 15:04 MST"
+)
+
+// TemplateNotificationRenderer renders the embedded templates/<language>/<event>.txt.tmpl
+// and .html.tmpl files, where <event> is the event type without its context prefix.
+// Text templates define the "subject" and "text" blocks, HTML ones the "html" block.
+// Languages missing a template fall back to English.
+type TemplateNotificationRenderer struct {
+	texts map[string]*text_template.Template
+	htmls map[string]*html_template.Template
+}
+
+func NewTemplateNotificationRenderer() (*TemplateNotificationRenderer, error) {
+	renderer := &TemplateNotificationRenderer{
+		texts: make(map[string]*text_template.Template),
+		htmls: make(map[string]*html_template.Template),
+	}
+
+	languages, err := notificationTemplates.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+
+	placeholderFuncs := map[string]any{"datetime": func(time.Time) string { return "" }}
+	for _, language := range languages {
+		files, err := notificationTemplates.ReadDir("templates/" + language.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			path := "templates/" + language.Name() + "/" + file.Name()
+			switch {
+			case strings.HasSuffix(file.Name(), ".txt.tmpl"):
+				key := language.Name() + "/" + strings.TrimSuffix(file.Name(), ".txt.tmpl")
+				if renderer.texts[key], err = text_template.New(file.Name()).Funcs(placeholderFuncs).ParseFS(notificationTemplates, path); err != nil {
+					return nil, err
+				}
+			case strings.HasSuffix(file.Name(), ".html.tmpl"):
+				key := language.Name() + "/" + strings.TrimSuffix(file.Name(), ".html.tmpl")
+				if renderer.htmls[key], err = html_template.New(file.Name()).Funcs(placeholderFuncs).ParseFS(notificationTemplates, path); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return renderer, nil
+}
+
+func (r *TemplateNotificationRenderer) Render(
+	eventType string,
+	language notifications_domain.NotificationLanguage,
+	location *time.Location,
+	data map[string]interface{},
+) (notifications_domain.NotificationContent, error) {
+	name := eventType[strings.LastIndex(eventType, ".")+1:]
+	funcs := map[string]any{"datetime": func(at time.Time) string { return at.In(location).Format(notificationDateTimeLayout) }}
+
+	text, found := r.texts[language.Value()+"/"+name]
+	if !found {
+		text, found = r.texts[fallbackNotificationLanguage.Value()+"/"+name]
+	}
+	if !found {
+		return notifications_domain.NotificationContent{}, fmt.Errorf("no notification template for %s", eventType)
+	}
+
+	text, err := text.Clone()
+	if err != nil {
+		return notifications_domain.NotificationContent{}, err
+	}
+	text.Funcs(funcs)
+
+	var content notifications_domain.NotificationContent
+	if content.Subject, err = executeText(text, "subject", data); err != nil {
+		return notifications_domain.NotificationContent{}, err
+	}
+	if content.Text, err = executeText(text, "text", data); err != nil {
+		return notifications_domain.NotificationContent{}, err
+	}
+
+	html, found := r.htmls[language.Value()+"/"+name]
+	if !found {
+		html, found = r.htmls[fallbackNotificationLanguage.Value()+"/"+name]
+	}
+	if !found {
+		return content, nil
+	}
+
+	html, err = html.Clone()
+	if err != nil {
+		return notifications_domain.NotificationContent{}, err
+	}
+	html.Funcs(funcs)
+
+	var buffer bytes.Buffer
+	if err := html.ExecuteTemplate(&buffer, "html", data); err != nil {
+		return notifications_domain.NotificationContent{}, err
+	}
+	content.HTML = buffer.String()
+
+	return content, nil
+}
+
+func executeText(template *text_template.Template, name string, data map[string]interface{}) (string, error) {
+	var buffer bytes.Buffer
+	if err := template.ExecuteTemplate(&buffer, name, data); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,61 @@
+package notifications_infra_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	notifications_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/application"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+	notifications_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/infra"
+)
+
+func TestTemplateNotificationRenderer(t *testing.T) {
+	renderer, err := notifications_infra.NewTemplateNotificationRenderer()
+	require.NoError(t, err)
+
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	data := alerting_domain.NewAlertOpened(alerting_domain.Alert{
+		ID:       "alert-1",
+		RuleName: "Temperature <high>",
+		DeviceID: "device-1",
+		Value:    31.5,
+		RaisedAt: time.Date(2026, 1, 10, 8, 30, 0, 0, time.UTC),
+	}).Data()
+
+	t.Run("should render the message in the language and time zone of the user", func(t *testing.T) {
+		content, err := renderer.Render(alerting_domain.AlertOpenedEventName, notifications_domain.SpanishNotificationLanguage, madrid, data)
+
+		require.NoError(t, err)
+		assert.Equal(t, "[Alerta] Temperature <high> en el dispositivo device-1", content.Subject)
+		assert.Contains(t, content.Text, "2026-01-10 09:30 CET")
+		assert.Contains(t, content.HTML, "Temperature &lt;high&gt;")
+	})
+
+	t.Run("should render every alert notification event in every language", func(t *testing.T) {
+		for _, eventType := range notifications_application.AlertNotificationEventTypes {
+			for _, language := range []notifications_domain.NotificationLanguage{
+				notifications_domain.SpanishNotificationLanguage,
+				notifications_domain.EnglishNotificationLanguage,
+			} {
+				content, err := renderer.Render(eventType, language, time.UTC, data)
+
+				require.NoError(t, err, "%s in %s", eventType, language)
+				assert.NotEmpty(t, content.Subject)
+				assert.NotEmpty(t, content.Text)
+				assert.NotEmpty(t, content.HTML)
+			}
+		}
+	})
+
+	t.Run("should fail for events without templates", func(t *testing.T) {
+		_, err := renderer.Render("connectivity.device_offline", notifications_domain.EnglishNotificationLanguage, time.UTC, data)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,11 @@
+package pestcontrol_application
+
+const FindGlueBoardImageQueryName = "FindGlueBoardImageQuery"
+
+type FindGlueBoardImageQuery struct {
+	ID string
+}
+
+func (q FindGlueBoardImageQuery) Type() string {
+	return FindGlueBoardImageQueryName
+}
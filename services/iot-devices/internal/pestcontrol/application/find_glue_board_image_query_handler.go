@@ -0,0 +1,42 @@
+package pestcontrol_application
+
+import (
+	"context"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type FindGlueBoardImageQueryHandler struct {
+	repository pestcontrol_domain.GlueBoardImageRepository
+	urlSigner  *glueBoardImageUrlSigner
+}
+
+func NewFindGlueBoardImageQueryHandler(
+	repository pestcontrol_domain.GlueBoardImageRepository,
+	storage pestcontrol_domain.GlueBoardImageStorage,
+	downloadUrlTtl time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *FindGlueBoardImageQueryHandler {
+	return &FindGlueBoardImageQueryHandler{
+		repository: repository,
+		urlSigner:  &glueBoardImageUrlSigner{storage: storage, ttl: downloadUrlTtl, timeProvider: timeProvider},
+	}
+}
+
+func (h FindGlueBoardImageQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindGlueBoardImageQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	image, err := findDeviceGlueBoardImage(ctx, h.repository, q.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return h.urlSigner.response(ctx, image)
+}
@@ -0,0 +1,12 @@
+package pestcontrol_application
+
+const FindGlueBoardImageUploadQueryName = "FindGlueBoardImageUploadQuery"
+
+type FindGlueBoardImageUploadQuery struct {
+	ID       string
+	DeviceID string
+}
+
+func (q FindGlueBoardImageUploadQuery) Type() string {
+	return FindGlueBoardImageUploadQueryName
+}
@@ -0,0 +1,75 @@
+package pestcontrol_application
+
+import (
+	"context"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type FindGlueBoardImageUploadQueryHandler struct {
+	repository   pestcontrol_domain.GlueBoardImageRepository
+	storage      pestcontrol_domain.GlueBoardImageStorage
+	uploadUrlTtl time.Duration
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewFindGlueBoardImageUploadQueryHandler(
+	repository pestcontrol_domain.GlueBoardImageRepository,
+	storage pestcontrol_domain.GlueBoardImageStorage,
+	uploadUrlTtl time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *FindGlueBoardImageUploadQueryHandler {
+	return &FindGlueBoardImageUploadQueryHandler{
+		repository:   repository,
+		storage:      storage,
+		uploadUrlTtl: uploadUrlTtl,
+		timeProvider: timeProvider,
+	}
+}
+
+// Handle signs a new upload url each time, so devices failing to upload in time ask again.
+func (h FindGlueBoardImageUploadQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindGlueBoardImageUploadQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	image, err := findDeviceGlueBoardImage(ctx, h.repository, q.ID, q.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadUrl, err := h.storage.UploadUrl(ctx, image.OriginalKey(), h.uploadUrlTtl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GlueBoardImageUploadResponse{
+		ID:          image.ID,
+		ContentType: image.ContentType,
+		UploadUrl:   uploadUrl,
+		ExpiresAt:   h.timeProvider.Now().Add(h.uploadUrlTtl).Format(time.RFC3339),
+	}, nil
+}
+
+// findDeviceGlueBoardImage hides the images of other devices as if they did not exist.
+func findDeviceGlueBoardImage(
+	ctx context.Context,
+	repository pestcontrol_domain.GlueBoardImageRepository,
+	id string,
+	deviceID string,
+) (pestcontrol_domain.GlueBoardImage, error) {
+	image, err := repository.Find(ctx, id)
+	if err != nil {
+		return pestcontrol_domain.GlueBoardImage{}, err
+	}
+	if image == nil || (deviceID != "" && image.DeviceID != deviceID) {
+		return pestcontrol_domain.GlueBoardImage{}, pestcontrol_domain.NewGlueBoardImageNotExists(id)
+	}
+
+	return *image, nil
+}
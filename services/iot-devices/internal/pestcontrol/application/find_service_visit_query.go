@@ -0,0 +1,11 @@
+package pestcontrol_application
+
+const FindServiceVisitQueryName = "FindServiceVisitQuery"
+
+type FindServiceVisitQuery struct {
+	ID string
+}
+
+func (q FindServiceVisitQuery) Type() string {
+	return FindServiceVisitQueryName
+}
@@ -0,0 +1,34 @@
+package pestcontrol_application
+
+import (
+	"context"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindServiceVisitQueryHandler struct {
+	repository pestcontrol_domain.ServiceVisitRepository
+}
+
+func NewFindServiceVisitQueryHandler(repository pestcontrol_domain.ServiceVisitRepository) *FindServiceVisitQueryHandler {
+	return &FindServiceVisitQueryHandler{repository: repository}
+}
+
+func (h FindServiceVisitQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindServiceVisitQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	visit, err := h.repository.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if visit == nil {
+		return nil, pestcontrol_domain.NewServiceVisitNotExists(q.ID)
+	}
+
+	return NewServiceVisitResponse(*visit), nil
+}
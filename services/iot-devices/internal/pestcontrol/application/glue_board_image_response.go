@@ -0,0 +1,94 @@
+package pestcontrol_application
+
+import (
+	"context"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type GlueBoardImageUploadResponse struct {
+	ID          string `jsonapi:"primary,glue_board_image_uploads"`
+	ContentType string `jsonapi:"attr,content_type"`
+	UploadUrl   string `jsonapi:"attr,upload_url"`
+	ExpiresAt   string `jsonapi:"attr,expires_at"`
+}
+
+type GlueBoardImageResponse struct {
+	ID            string `jsonapi:"primary,glue_board_images"`
+	DeviceID      string `jsonapi:"attr,device_id"`
+	EventID       string `jsonapi:"attr,event_id,omitempty"`
+	Status        string `jsonapi:"attr,status"`
+	FailureReason string `jsonapi:"attr,failure_reason,omitempty"`
+	Format        string `jsonapi:"attr,format,omitempty"`
+	Width         int    `jsonapi:"attr,width,omitempty"`
+	Height        int    `jsonapi:"attr,height,omitempty"`
+	SizeBytes     int64  `jsonapi:"attr,size_bytes,omitempty"`
+	Checksum      string `jsonapi:"attr,checksum,omitempty"`
+	ImageUrl      string `jsonapi:"attr,image_url,omitempty"`
+	ThumbnailUrl  string `jsonapi:"attr,thumbnail_url,omitempty"`
+	UrlsExpireAt  string `jsonapi:"attr,urls_expire_at,omitempty"`
+	CapturedAt    string `jsonapi:"attr,captured_at,omitempty"`
+	RequestedAt   string `jsonapi:"attr,requested_at"`
+	ProcessedAt   string `jsonapi:"attr,processed_at,omitempty"`
+}
+
+func NewGlueBoardImageResponse(image pestcontrol_domain.GlueBoardImage) *GlueBoardImageResponse {
+	response := &GlueBoardImageResponse{
+		ID:            image.ID,
+		DeviceID:      image.DeviceID,
+		EventID:       image.EventID,
+		Status:        image.Status.Value(),
+		FailureReason: image.FailureReason,
+		Format:        image.Metadata.Format,
+		Width:         image.Metadata.Width,
+		Height:        image.Metadata.Height,
+		SizeBytes:     image.Metadata.SizeBytes,
+		Checksum:      image.Metadata.Checksum,
+		RequestedAt:   image.RequestedAt.Format(time.RFC3339),
+	}
+
+	if image.CapturedAt != nil {
+		response.CapturedAt = image.CapturedAt.Format(time.RFC3339)
+	}
+	if image.ProcessedAt != nil {
+		response.ProcessedAt = image.ProcessedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
+
+// glueBoardImageUrlSigner adds to the responses of processed images the urls to download
+// the photo and its thumbnail, which only work for a while.
+type glueBoardImageUrlSigner struct {
+	storage      pestcontrol_domain.GlueBoardImageStorage
+	ttl          time.Duration
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func (s *glueBoardImageUrlSigner) response(
+	ctx context.Context,
+	image pestcontrol_domain.GlueBoardImage,
+) (*GlueBoardImageResponse, error) {
+	response := NewGlueBoardImageResponse(image)
+	if image.Status != pestcontrol_domain.ProcessedGlueBoardImage {
+		return response, nil
+	}
+
+	imageUrl, err := s.storage.DownloadUrl(ctx, image.OriginalKey(), s.ttl)
+	if err != nil {
+		return nil, err
+	}
+	thumbnailUrl, err := s.storage.DownloadUrl(ctx, image.ThumbnailKey(), s.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	response.ImageUrl = imageUrl
+	response.ThumbnailUrl = thumbnailUrl
+	response.UrlsExpireAt = s.timeProvider.Now().Add(s.ttl).Format(time.RFC3339)
+
+	return response, nil
+}
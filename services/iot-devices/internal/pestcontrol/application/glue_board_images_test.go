@@ -0,0 +1,161 @@
+package pestcontrol_application_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pestcontrol_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/application"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	pestcontrol_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain/mocks"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type eventRecorder chan amf_bus.Event
+
+func (r eventRecorder) Handle(event amf_bus.Event) error {
+	r <- event
+	return nil
+}
+
+func TestProcessGlueBoardImageCommandHandler(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	pending, err := pestcontrol_domain.NewGlueBoardImage(amf_utils.NewUlid().String(), "light-1", "", "image/jpeg", timeProvider.Now())
+	require.NoError(t, err)
+	metadata := pestcontrol_domain.GlueBoardImageMetadata{Format: "jpeg", Width: 1600, Height: 1200, SizeBytes: 2048, Checksum: "abc"}
+
+	newHandler := func(
+		repository pestcontrol_domain.GlueBoardImageRepository,
+		storage pestcontrol_domain.GlueBoardImageStorage,
+		processor pestcontrol_domain.GlueBoardImageProcessor,
+		eventBus amf_event_bus.Bus,
+	) *pestcontrol_application.ProcessGlueBoardImageCommandHandler {
+		return pestcontrol_application.NewProcessGlueBoardImageCommandHandler(repository, storage, processor, eventBus, timeProvider)
+	}
+
+	t.Run("should store the thumbnail and record the metadata of the photo", func(t *testing.T) {
+		repository := pestcontrol_domain_mocks.NewGlueBoardImageRepository(t)
+		repository.On("Find", ctx, pending.ID).Return(&pending, nil).Once()
+		repository.On("Save", ctx, mock.MatchedBy(func(image pestcontrol_domain.GlueBoardImage) bool {
+			return image.Status == pestcontrol_domain.ProcessedGlueBoardImage && image.Metadata == metadata
+		})).Return(nil).Once()
+		storage := pestcontrol_domain_mocks.NewGlueBoardImageStorage(t)
+		storage.On("Open", ctx, pending.OriginalKey()).Return(io.NopCloser(strings.NewReader("photo")), nil).Once()
+		storage.On("Put", ctx, pending.ThumbnailKey(), []byte("thumbnail"), "image/jpeg").Return(nil).Once()
+		processor := pestcontrol_domain_mocks.NewGlueBoardImageProcessor(t)
+		processor.On("Process", mock.Anything).Return(metadata, []byte("thumbnail"), nil).Once()
+
+		eventBus, processed := amf_event_bus.NewEventBus(), make(eventRecorder, 1)
+		eventBus.Subscribe(pestcontrol_domain.GlueBoardImageProcessedEventName, processed)
+
+		err := newHandler(repository, storage, processor, eventBus).Handle(ctx, &pestcontrol_application.ProcessGlueBoardImageCommand{
+			ID:       pending.ID,
+			DeviceID: "light-1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1600, (<-processed).Data()["width"])
+	})
+
+	t.Run("should record the photos that can't be processed as failed", func(t *testing.T) {
+		repository := pestcontrol_domain_mocks.NewGlueBoardImageRepository(t)
+		repository.On("Find", ctx, pending.ID).Return(&pending, nil).Once()
+		repository.On("Save", ctx, mock.MatchedBy(func(image pestcontrol_domain.GlueBoardImage) bool {
+			return image.Status == pestcontrol_domain.FailedGlueBoardImage && image.FailureReason == "photo not decodable"
+		})).Return(nil).Once()
+		storage := pestcontrol_domain_mocks.NewGlueBoardImageStorage(t)
+		storage.On("Open", ctx, pending.OriginalKey()).Return(io.NopCloser(strings.NewReader("photo")), nil).Once()
+		processor := pestcontrol_domain_mocks.NewGlueBoardImageProcessor(t)
+		processor.On("Process", mock.Anything).Return(pestcontrol_domain.GlueBoardImageMetadata{}, nil, errors.New("photo not decodable")).Once()
+
+		err := newHandler(repository, storage, processor, amf_event_bus.NewEventBus()).Handle(ctx, &pestcontrol_application.ProcessGlueBoardImageCommand{
+			ID:       pending.ID,
+			DeviceID: "light-1",
+		})
+
+		assert.IsType(t, &pestcontrol_domain.InvalidGlueBoardImage{}, err)
+	})
+
+	t.Run("should ignore repeated notifications of processed photos", func(t *testing.T) {
+		processed, err := pending.Processed(metadata, nil, timeProvider.Now())
+		require.NoError(t, err)
+		repository := pestcontrol_domain_mocks.NewGlueBoardImageRepository(t)
+		repository.On("Find", ctx, pending.ID).Return(&processed, nil).Once()
+
+		err = newHandler(
+			repository,
+			pestcontrol_domain_mocks.NewGlueBoardImageStorage(t),
+			pestcontrol_domain_mocks.NewGlueBoardImageProcessor(t),
+			amf_event_bus.NewEventBus(),
+		).Handle(ctx, &pestcontrol_application.ProcessGlueBoardImageCommand{ID: pending.ID, DeviceID: "light-1"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should hide the photos of other devices", func(t *testing.T) {
+		repository := pestcontrol_domain_mocks.NewGlueBoardImageRepository(t)
+		repository.On("Find", ctx, pending.ID).Return(&pending, nil).Once()
+
+		err := newHandler(
+			repository,
+			pestcontrol_domain_mocks.NewGlueBoardImageStorage(t),
+			pestcontrol_domain_mocks.NewGlueBoardImageProcessor(t),
+			amf_event_bus.NewEventBus(),
+		).Handle(ctx, &pestcontrol_application.ProcessGlueBoardImageCommand{ID: pending.ID, DeviceID: "light-2"})
+
+		assert.IsType(t, &pestcontrol_domain.GlueBoardImageNotExists{}, err)
+	})
+}
+
+func TestFindGlueBoardImageQueryHandler(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	pending, err := pestcontrol_domain.NewGlueBoardImage(amf_utils.NewUlid().String(), "light-1", "", "image/png", timeProvider.Now())
+	require.NoError(t, err)
+	processed, err := pending.Processed(pestcontrol_domain.GlueBoardImageMetadata{Format: "png"}, nil, timeProvider.Now())
+	require.NoError(t, err)
+
+	t.Run("should sign time limited urls for the photo and its thumbnail", func(t *testing.T) {
+		repository := pestcontrol_domain_mocks.NewGlueBoardImageRepository(t)
+		repository.On("Find", ctx, processed.ID).Return(&processed, nil).Once()
+		storage := pestcontrol_domain_mocks.NewGlueBoardImageStorage(t)
+		storage.On("DownloadUrl", ctx, processed.OriginalKey(), 5*time.Minute).Return("https://storage/original", nil).Once()
+		storage.On("DownloadUrl", ctx, processed.ThumbnailKey(), 5*time.Minute).Return("https://storage/thumbnail", nil).Once()
+
+		handler := pestcontrol_application.NewFindGlueBoardImageQueryHandler(repository, storage, 5*time.Minute, timeProvider)
+		response, err := handler.Handle(ctx, &pestcontrol_application.FindGlueBoardImageQuery{ID: processed.ID})
+		require.NoError(t, err)
+
+		imageResponse := response.(*pestcontrol_application.GlueBoardImageResponse)
+		assert.Equal(t, "https://storage/original", imageResponse.ImageUrl)
+		assert.Equal(t, "https://storage/thumbnail", imageResponse.ThumbnailUrl)
+		assert.Equal(t, timeProvider.Now().Add(5*time.Minute).Format(time.RFC3339), imageResponse.UrlsExpireAt)
+	})
+
+	t.Run("should not sign urls for photos not processed yet", func(t *testing.T) {
+		repository := pestcontrol_domain_mocks.NewGlueBoardImageRepository(t)
+		repository.On("Find", ctx, pending.ID).Return(&pending, nil).Once()
+
+		handler := pestcontrol_application.NewFindGlueBoardImageQueryHandler(
+			repository,
+			pestcontrol_domain_mocks.NewGlueBoardImageStorage(t),
+			5*time.Minute,
+			timeProvider,
+		)
+		response, err := handler.Handle(ctx, &pestcontrol_application.FindGlueBoardImageQuery{ID: pending.ID})
+		require.NoError(t, err)
+
+		assert.Empty(t, response.(*pestcontrol_application.GlueBoardImageResponse).ImageUrl)
+	})
+}
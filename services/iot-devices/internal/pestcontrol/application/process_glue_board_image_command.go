@@ -0,0 +1,22 @@
+package pestcontrol_application
+
+import "time"
+
+const ProcessGlueBoardImageCommandName = "ProcessGlueBoardImageCommand"
+
+// ProcessGlueBoardImageCommand is the notification of a device that finished uploading
+// a photo, with the time it was taken if the device knows it.
+type ProcessGlueBoardImageCommand struct {
+	ID         string
+	DeviceID   string
+	CapturedAt *time.Time
+}
+
+func (c ProcessGlueBoardImageCommand) Type() string {
+	return ProcessGlueBoardImageCommandName
+}
+
+// BlockingKey serializes the notifications devices repeat when they miss the response.
+func (c ProcessGlueBoardImageCommand) BlockingKey() string {
+	return "glue_board_image:" + c.ID
+}
@@ -0,0 +1,85 @@
+package pestcontrol_application
+
+import (
+	"context"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const thumbnailContentType = "image/jpeg"
+
+type ProcessGlueBoardImageCommandHandler struct {
+	repository   pestcontrol_domain.GlueBoardImageRepository
+	storage      pestcontrol_domain.GlueBoardImageStorage
+	processor    pestcontrol_domain.GlueBoardImageProcessor
+	eventBus     amf_event_bus.Bus
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewProcessGlueBoardImageCommandHandler(
+	repository pestcontrol_domain.GlueBoardImageRepository,
+	storage pestcontrol_domain.GlueBoardImageStorage,
+	processor pestcontrol_domain.GlueBoardImageProcessor,
+	eventBus amf_event_bus.Bus,
+	timeProvider amf_utils.DateTimeProvider,
+) *ProcessGlueBoardImageCommandHandler {
+	return &ProcessGlueBoardImageCommandHandler{
+		repository:   repository,
+		storage:      storage,
+		processor:    processor,
+		eventBus:     eventBus,
+		timeProvider: timeProvider,
+	}
+}
+
+// Handle makes the thumbnail of the uploaded photo and records its metadata. Repeated
+// notifications of processed photos are ignored, and photos that can't be processed are
+// recorded as failed, so the device may upload them again.
+func (h ProcessGlueBoardImageCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*ProcessGlueBoardImageCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	image, err := findDeviceGlueBoardImage(ctx, h.repository, cmd.ID, cmd.DeviceID)
+	if err != nil {
+		return err
+	}
+	if image.Status == pestcontrol_domain.ProcessedGlueBoardImage {
+		return nil
+	}
+
+	photo, err := h.storage.Open(ctx, image.OriginalKey())
+	if err != nil {
+		return err
+	}
+	defer photo.Close()
+
+	metadata, thumbnail, err := h.processor.Process(photo)
+	if err != nil {
+		if saveErr := h.repository.Save(ctx, image.Failed(err.Error(), h.timeProvider.Now())); saveErr != nil {
+			return saveErr
+		}
+		return pestcontrol_domain.NewInvalidGlueBoardImage(image.ID, "photo", err.Error())
+	}
+
+	if err := h.storage.Put(ctx, image.ThumbnailKey(), thumbnail, thumbnailContentType); err != nil {
+		return err
+	}
+
+	processed, err := image.Processed(metadata, cmd.CapturedAt, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+	if err := h.repository.Save(ctx, processed); err != nil {
+		return err
+	}
+
+	h.eventBus.Publish(pestcontrol_domain.NewGlueBoardImageProcessed(processed))
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package pestcontrol_application
+
+import "time"
+
+const RecordServiceVisitCommandName = "RecordServiceVisitCommand"
+
+type RecordServiceVisitCommand struct {
+	ID         string
+	SiteID     string
+	Technician string
+	StartedAt  time.Time
+	EndedAt    time.Time
+	Notes      string
+}
+
+func (c RecordServiceVisitCommand) Type() string {
+	return RecordServiceVisitCommandName
+}
@@ -0,0 +1,31 @@
+package pestcontrol_application
+
+import (
+	"context"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type RecordServiceVisitCommandHandler struct {
+	repository pestcontrol_domain.ServiceVisitRepository
+}
+
+func NewRecordServiceVisitCommandHandler(repository pestcontrol_domain.ServiceVisitRepository) *RecordServiceVisitCommandHandler {
+	return &RecordServiceVisitCommandHandler{repository: repository}
+}
+
+func (h RecordServiceVisitCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*RecordServiceVisitCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	visit, err := pestcontrol_domain.NewServiceVisit(cmd.ID, cmd.SiteID, cmd.Technician, cmd.StartedAt, cmd.EndedAt, cmd.Notes)
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, visit)
+}
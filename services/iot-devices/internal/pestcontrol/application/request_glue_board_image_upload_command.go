@@ -0,0 +1,14 @@
+package pestcontrol_application
+
+const RequestGlueBoardImageUploadCommandName = "RequestGlueBoardImageUploadCommand"
+
+type RequestGlueBoardImageUploadCommand struct {
+	ID          string
+	DeviceID    string
+	EventID     string
+	ContentType string
+}
+
+func (c RequestGlueBoardImageUploadCommand) Type() string {
+	return RequestGlueBoardImageUploadCommandName
+}
@@ -0,0 +1,36 @@
+package pestcontrol_application
+
+import (
+	"context"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type RequestGlueBoardImageUploadCommandHandler struct {
+	repository   pestcontrol_domain.GlueBoardImageRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewRequestGlueBoardImageUploadCommandHandler(
+	repository pestcontrol_domain.GlueBoardImageRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *RequestGlueBoardImageUploadCommandHandler {
+	return &RequestGlueBoardImageUploadCommandHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h RequestGlueBoardImageUploadCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*RequestGlueBoardImageUploadCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	image, err := pestcontrol_domain.NewGlueBoardImage(cmd.ID, cmd.DeviceID, cmd.EventID, cmd.ContentType, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.repository.Save(ctx, image)
+}
@@ -0,0 +1,11 @@
+package pestcontrol_application
+
+const SearchDeviceGlueBoardImagesQueryName = "SearchDeviceGlueBoardImagesQuery"
+
+type SearchDeviceGlueBoardImagesQuery struct {
+	DeviceID string
+}
+
+func (q SearchDeviceGlueBoardImagesQuery) Type() string {
+	return SearchDeviceGlueBoardImagesQueryName
+}
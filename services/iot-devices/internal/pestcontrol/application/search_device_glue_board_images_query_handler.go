@@ -0,0 +1,51 @@
+package pestcontrol_application
+
+import (
+	"context"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type SearchDeviceGlueBoardImagesQueryHandler struct {
+	repository pestcontrol_domain.GlueBoardImageRepository
+	urlSigner  *glueBoardImageUrlSigner
+}
+
+func NewSearchDeviceGlueBoardImagesQueryHandler(
+	repository pestcontrol_domain.GlueBoardImageRepository,
+	storage pestcontrol_domain.GlueBoardImageStorage,
+	downloadUrlTtl time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *SearchDeviceGlueBoardImagesQueryHandler {
+	return &SearchDeviceGlueBoardImagesQueryHandler{
+		repository: repository,
+		urlSigner:  &glueBoardImageUrlSigner{storage: storage, ttl: downloadUrlTtl, timeProvider: timeProvider},
+	}
+}
+
+func (h SearchDeviceGlueBoardImagesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchDeviceGlueBoardImagesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	images, err := h.repository.SearchByDevice(ctx, q.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*GlueBoardImageResponse, 0, len(images))
+	for _, image := range images {
+		imageResponse, err := h.urlSigner.response(ctx, image)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, imageResponse)
+	}
+
+	return response, nil
+}
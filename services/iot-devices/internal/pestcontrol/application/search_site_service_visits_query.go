@@ -0,0 +1,16 @@
+package pestcontrol_application
+
+import "time"
+
+const SearchSiteServiceVisitsQueryName = "SearchSiteServiceVisitsQuery"
+
+// SearchSiteServiceVisitsQuery defaults to the visits of the last 30 days.
+type SearchSiteServiceVisitsQuery struct {
+	SiteID string
+	From   time.Time
+	To     time.Time
+}
+
+func (q SearchSiteServiceVisitsQuery) Type() string {
+	return SearchSiteServiceVisitsQueryName
+}
@@ -0,0 +1,53 @@
+package pestcontrol_application
+
+import (
+	"context"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const defaultServiceVisitsPeriod = 30 * 24 * time.Hour
+
+type SearchSiteServiceVisitsQueryHandler struct {
+	repository   pestcontrol_domain.ServiceVisitRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewSearchSiteServiceVisitsQueryHandler(
+	repository pestcontrol_domain.ServiceVisitRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *SearchSiteServiceVisitsQueryHandler {
+	return &SearchSiteServiceVisitsQueryHandler{repository: repository, timeProvider: timeProvider}
+}
+
+func (h SearchSiteServiceVisitsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchSiteServiceVisitsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	to := q.To
+	if to.IsZero() {
+		to = h.timeProvider.Now()
+	}
+	from := q.From
+	if from.IsZero() {
+		from = to.Add(-defaultServiceVisitsPeriod)
+	}
+
+	visits, err := h.repository.SearchBySite(ctx, q.SiteID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*ServiceVisitResponse, 0, len(visits))
+	for _, visit := range visits {
+		response = append(response, NewServiceVisitResponse(visit))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,27 @@
+package pestcontrol_application
+
+import (
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+)
+
+type ServiceVisitResponse struct {
+	ID         string `jsonapi:"primary,service_visits"`
+	SiteID     string `jsonapi:"attr,site_id"`
+	Technician string `jsonapi:"attr,technician"`
+	StartedAt  string `jsonapi:"attr,started_at"`
+	EndedAt    string `jsonapi:"attr,ended_at"`
+	Notes      string `jsonapi:"attr,notes,omitempty"`
+}
+
+func NewServiceVisitResponse(visit pestcontrol_domain.ServiceVisit) *ServiceVisitResponse {
+	return &ServiceVisitResponse{
+		ID:         visit.ID,
+		SiteID:     visit.SiteID,
+		Technician: visit.Technician,
+		StartedAt:  visit.StartedAt.Format(time.RFC3339),
+		EndedAt:    visit.EndedAt.Format(time.RFC3339),
+		Notes:      visit.Notes,
+	}
+}
@@ -0,0 +1,158 @@
+package pestcontrol_domain
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	glueBoardImageDeviceIdMaxLength = 50
+	glueBoardImageReasonMaxLength   = 255
+)
+
+type GlueBoardImageStatus string
+
+const (
+	PendingGlueBoardImage   GlueBoardImageStatus = "pending"
+	ProcessedGlueBoardImage GlueBoardImageStatus = "processed"
+	FailedGlueBoardImage    GlueBoardImageStatus = "failed"
+)
+
+func (gbis GlueBoardImageStatus) Value() string {
+	return string(gbis)
+}
+
+var glueBoardImageContentTypes = map[string]struct{}{
+	"image/jpeg": {},
+	"image/png":  {},
+}
+
+// GlueBoardImageMetadata is what processing the uploaded photo tells about it.
+type GlueBoardImageMetadata struct {
+	Format    string
+	Width     int
+	Height    int
+	SizeBytes int64
+	Checksum  string
+}
+
+// GlueBoardImage is a photo of the glue board of a camera equipped trap. The device asks
+// where to upload it, uploads it straight to the storage and notifies once done, which is
+// when the photo is processed. It is linked to the pest event the device reported along.
+type GlueBoardImage struct {
+	ID            string
+	DeviceID      string
+	EventID       string
+	ContentType   string
+	Status        GlueBoardImageStatus
+	Metadata      GlueBoardImageMetadata
+	FailureReason string
+	CapturedAt    *time.Time
+	RequestedAt   time.Time
+	ProcessedAt   *time.Time
+}
+
+func NewGlueBoardImage(
+	id string,
+	deviceID string,
+	eventID string,
+	contentType string,
+	now time.Time,
+) (GlueBoardImage, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidGlueBoardImage(id, "id", "must be a ULID")); err != nil {
+		return GlueBoardImage{}, err
+	}
+
+	deviceIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(glueBoardImageDeviceIdMaxLength),
+	)
+	if err := deviceIdValidator.Validate(deviceID, NewInvalidGlueBoardImage(id, "device_id", "must be a non empty string")); err != nil {
+		return GlueBoardImage{}, err
+	}
+
+	if eventID != "" {
+		eventIdValidator := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier())
+		if err := eventIdValidator.Validate(eventID, NewInvalidGlueBoardImage(id, "event_id", "must be a ULID")); err != nil {
+			return GlueBoardImage{}, err
+		}
+	}
+
+	contentTypeValidator := domain_validation.NewDomainValidator(domain_validation.In(glueBoardImageContentTypes))
+	if err := contentTypeValidator.Validate(contentType, NewInvalidGlueBoardImage(id, "content_type", "must be a jpeg or png image")); err != nil {
+		return GlueBoardImage{}, err
+	}
+
+	return GlueBoardImage{
+		ID:          id,
+		DeviceID:    deviceID,
+		EventID:     eventID,
+		ContentType: contentType,
+		Status:      PendingGlueBoardImage,
+		RequestedAt: now,
+	}, nil
+}
+
+// OriginalKey is where the device uploads the photo.
+func (gbi GlueBoardImage) OriginalKey() string {
+	return fmt.Sprintf("glue-boards/%s/%s/original", url.PathEscape(gbi.DeviceID), gbi.ID)
+}
+
+func (gbi GlueBoardImage) ThumbnailKey() string {
+	return fmt.Sprintf("glue-boards/%s/%s/thumbnail.jpg", url.PathEscape(gbi.DeviceID), gbi.ID)
+}
+
+// Processed records the metadata of the photo. Failed photos may be uploaded and
+// processed again.
+func (gbi GlueBoardImage) Processed(metadata GlueBoardImageMetadata, capturedAt *time.Time, now time.Time) (GlueBoardImage, error) {
+	if gbi.Status == ProcessedGlueBoardImage {
+		return GlueBoardImage{}, NewInvalidGlueBoardImage(gbi.ID, "status", "is already processed")
+	}
+
+	gbi.Status = ProcessedGlueBoardImage
+	gbi.Metadata = metadata
+	gbi.FailureReason = ""
+	gbi.CapturedAt = capturedAt
+	gbi.ProcessedAt = &now
+
+	return gbi, nil
+}
+
+func (gbi GlueBoardImage) Failed(reason string, now time.Time) GlueBoardImage {
+	if len(reason) > glueBoardImageReasonMaxLength {
+		reason = reason[:glueBoardImageReasonMaxLength]
+	}
+
+	gbi.Status = FailedGlueBoardImage
+	gbi.FailureReason = reason
+	gbi.ProcessedAt = &now
+
+	return gbi
+}
+
+type GlueBoardImageRepository interface {
+	Save(ctx context.Context, image GlueBoardImage) error
+	// Find returns nil when there is no image for the id
+	Find(ctx context.Context, id string) (*GlueBoardImage, error)
+	SearchByDevice(ctx context.Context, deviceID string) ([]GlueBoardImage, error)
+}
+
+// GlueBoardImageStorage keeps the photos and their thumbnails, and hands out the time
+// limited urls devices upload them to and users download them from.
+type GlueBoardImageStorage interface {
+	UploadUrl(ctx context.Context, key string, expiry time.Duration) (string, error)
+	DownloadUrl(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// Open returns a GlueBoardImageNotUploaded error when there is nothing at the key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+}
+
+// GlueBoardImageProcessor extracts the metadata of a photo and makes its thumbnail.
+type GlueBoardImageProcessor interface {
+	Process(photo io.Reader) (GlueBoardImageMetadata, []byte, error)
+}
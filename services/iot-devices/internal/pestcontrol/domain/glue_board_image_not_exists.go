@@ -0,0 +1,24 @@
+package pestcontrol_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const glueBoardImageNotExistsErrorMessage = "Glue board image not exists"
+
+type GlueBoardImageNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (gbine GlueBoardImageNotExists) Error() string {
+	return glueBoardImageNotExistsErrorMessage
+}
+
+func (gbine GlueBoardImageNotExists) ExtraItems() map[string]interface{} {
+	return gbine.items
+}
+
+func NewGlueBoardImageNotExists(id string) *GlueBoardImageNotExists {
+	return &GlueBoardImageNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,24 @@
+package pestcontrol_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const glueBoardImageNotUploadedErrorMessage = "Glue board image not uploaded"
+
+type GlueBoardImageNotUploaded struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (gbinu GlueBoardImageNotUploaded) Error() string {
+	return glueBoardImageNotUploadedErrorMessage
+}
+
+func (gbinu GlueBoardImageNotUploaded) ExtraItems() map[string]interface{} {
+	return gbinu.items
+}
+
+func NewGlueBoardImageNotUploaded(id string) *GlueBoardImageNotUploaded {
+	return &GlueBoardImageNotUploaded{items: map[string]interface{}{"id": id}}
+}
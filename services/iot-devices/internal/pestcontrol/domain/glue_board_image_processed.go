@@ -0,0 +1,38 @@
+package pestcontrol_domain
+
+import "time"
+
+const GlueBoardImageProcessedEventName = "pest_control.glue_board_image_processed"
+
+type GlueBoardImageProcessed struct {
+	image GlueBoardImage
+}
+
+func NewGlueBoardImageProcessed(image GlueBoardImage) GlueBoardImageProcessed {
+	return GlueBoardImageProcessed{image: image}
+}
+
+func (gbip GlueBoardImageProcessed) Name() string {
+	return GlueBoardImageProcessedEventName
+}
+
+func (gbip GlueBoardImageProcessed) Type() string {
+	return "domain_event"
+}
+
+func (gbip GlueBoardImageProcessed) Data() map[string]interface{} {
+	data := map[string]interface{}{
+		"id":        gbip.image.ID,
+		"device_id": gbip.image.DeviceID,
+		"event_id":  gbip.image.EventID,
+		"format":    gbip.image.Metadata.Format,
+		"width":     gbip.image.Metadata.Width,
+		"height":    gbip.image.Metadata.Height,
+	}
+
+	if gbip.image.CapturedAt != nil {
+		data["captured_at"] = gbip.image.CapturedAt.Format(time.RFC3339)
+	}
+
+	return data
+}
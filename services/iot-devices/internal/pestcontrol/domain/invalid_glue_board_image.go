@@ -0,0 +1,24 @@
+package pestcontrol_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidGlueBoardImageErrorMessage = "Invalid glue board image"
+
+type InvalidGlueBoardImage struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (igbi InvalidGlueBoardImage) Error() string {
+	return invalidGlueBoardImageErrorMessage
+}
+
+func (igbi InvalidGlueBoardImage) ExtraItems() map[string]interface{} {
+	return igbi.items
+}
+
+func NewInvalidGlueBoardImage(id string, field string, reason string) *InvalidGlueBoardImage {
+	return &InvalidGlueBoardImage{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
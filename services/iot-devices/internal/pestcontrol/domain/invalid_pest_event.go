@@ -0,0 +1,24 @@
+package pestcontrol_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidPestEventErrorMessage = "Invalid pest event"
+
+type InvalidPestEvent struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ipe InvalidPestEvent) Error() string {
+	return invalidPestEventErrorMessage
+}
+
+func (ipe InvalidPestEvent) ExtraItems() map[string]interface{} {
+	return ipe.items
+}
+
+func NewInvalidPestEvent(id string, field string, reason string) *InvalidPestEvent {
+	return &InvalidPestEvent{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
@@ -0,0 +1,24 @@
+package pestcontrol_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidServiceVisitErrorMessage = "Invalid service visit"
+
+type InvalidServiceVisit struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (isv InvalidServiceVisit) Error() string {
+	return invalidServiceVisitErrorMessage
+}
+
+func (isv InvalidServiceVisit) ExtraItems() map[string]interface{} {
+	return isv.items
+}
+
+func NewInvalidServiceVisit(id string, field string, reason string) *InvalidServiceVisit {
+	return &InvalidServiceVisit{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
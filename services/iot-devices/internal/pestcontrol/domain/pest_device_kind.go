@@ -0,0 +1,37 @@
+package pestcontrol_domain
+
+import "slices"
+
+type PestDeviceKind string
+
+const (
+	SnapTrapDevice         PestDeviceKind = "snap_trap"
+	BaitStationDevice      PestDeviceKind = "bait_station"
+	InsectLightTrapDevice  PestDeviceKind = "insect_light_trap"
+	PheromoneMonitorDevice PestDeviceKind = "pheromone_monitor"
+)
+
+func (pdk PestDeviceKind) Value() string {
+	return string(pdk)
+}
+
+var pestDeviceKinds = map[string]struct{}{
+	SnapTrapDevice.Value():         {},
+	BaitStationDevice.Value():      {},
+	InsectLightTrapDevice.Value():  {},
+	PheromoneMonitorDevice.Value(): {},
+}
+
+// pestDeviceKindEvents are the events each kind of device is able to report: snap traps
+// sense their bar, bait stations weigh their bait with a load cell, and light traps and
+// pheromone monitors catch insects on a glue board a camera or optical sensor looks at.
+var pestDeviceKindEvents = map[PestDeviceKind][]PestEventType{
+	SnapTrapDevice:         {TrapTriggeredEvent, TrapResetEvent, TamperEvent},
+	BaitStationDevice:      {BaitConsumedEvent, TamperEvent},
+	InsectLightTrapDevice:  {GlueBoardCoverageEvent, TamperEvent},
+	PheromoneMonitorDevice: {GlueBoardCoverageEvent, TamperEvent},
+}
+
+func (pdk PestDeviceKind) Reports(eventType PestEventType) bool {
+	return slices.Contains(pestDeviceKindEvents[pdk], eventType)
+}
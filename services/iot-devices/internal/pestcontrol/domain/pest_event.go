@@ -0,0 +1,225 @@
+package pestcontrol_domain
+
+import (
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	pestDeviceIdMaxLength = 50
+
+	// MinBaitConsumedGrams is the resolution of the load cells, so any lower weight is noise
+	MinBaitConsumedGrams = 0.1
+	MaxBaitConsumedGrams = 1000
+
+	MinGlueBoardCoverage = 0
+	MaxGlueBoardCoverage = 100
+)
+
+// The metrics pest events stand for, which trap activity logs are made of
+const (
+	TrapTriggeredMetric     = "trap_triggered"
+	BaitConsumedMetric      = "bait_consumed_g"
+	GlueBoardCoverageMetric = "glue_board_coverage_pct"
+	TamperMetric            = "tamper"
+)
+
+var PestMetrics = []string{TrapTriggeredMetric, BaitConsumedMetric, GlueBoardCoverageMetric, TamperMetric}
+
+type PestEventType string
+
+const (
+	TrapTriggeredEvent     PestEventType = "trap_triggered"
+	TrapResetEvent         PestEventType = "trap_reset"
+	BaitConsumedEvent      PestEventType = "bait_consumed"
+	GlueBoardCoverageEvent PestEventType = "glue_board_coverage"
+	TamperEvent            PestEventType = "tamper"
+)
+
+func (pet PestEventType) Value() string {
+	return string(pet)
+}
+
+var pestEventTypes = map[string]struct{}{
+	TrapTriggeredEvent.Value():     {},
+	TrapResetEvent.Value():         {},
+	BaitConsumedEvent.Value():      {},
+	GlueBoardCoverageEvent.Value(): {},
+	TamperEvent.Value():            {},
+}
+
+type TamperKind string
+
+const (
+	OpenedTamper  TamperKind = "opened"
+	MovedTamper   TamperKind = "moved"
+	RemovedTamper TamperKind = "removed"
+)
+
+func (tk TamperKind) Value() string {
+	return string(tk)
+}
+
+var tamperKinds = map[string]struct{}{
+	OpenedTamper.Value():  {},
+	MovedTamper.Value():   {},
+	RemovedTamper.Value(): {},
+}
+
+// PestEvent is what a pest control device reports. Only the fields of its type are set:
+// the grams of bait eaten since the previous weighing, the percentage of the glue board
+// covered with catches, or how the device was tampered with.
+type PestEvent struct {
+	ID                string
+	DeviceID          string
+	DeviceKind        PestDeviceKind
+	Type              PestEventType
+	OccurredAt        time.Time
+	BaitConsumedGrams float64
+	GlueBoardCoverage float64
+	Tamper            TamperKind
+}
+
+func NewTrapTriggered(id string, deviceID string, deviceKind PestDeviceKind, occurredAt time.Time) (PestEvent, error) {
+	return newPestEvent(id, deviceID, deviceKind, TrapTriggeredEvent, occurredAt)
+}
+
+func NewTrapReset(id string, deviceID string, deviceKind PestDeviceKind, occurredAt time.Time) (PestEvent, error) {
+	return newPestEvent(id, deviceID, deviceKind, TrapResetEvent, occurredAt)
+}
+
+func NewBaitConsumed(
+	id string,
+	deviceID string,
+	deviceKind PestDeviceKind,
+	occurredAt time.Time,
+	grams float64,
+) (PestEvent, error) {
+	event, err := newPestEvent(id, deviceID, deviceKind, BaitConsumedEvent, occurredAt)
+	if err != nil {
+		return PestEvent{}, err
+	}
+
+	gramsValidator := domain_validation.NewDomainValidator(domain_validation.Float64Range(MinBaitConsumedGrams, MaxBaitConsumedGrams))
+	if err := gramsValidator.Validate(grams, NewInvalidPestEvent(id, "bait_consumed_grams", "is out of the load cell range")); err != nil {
+		return PestEvent{}, err
+	}
+	event.BaitConsumedGrams = grams
+
+	return event, nil
+}
+
+func NewGlueBoardCoverage(
+	id string,
+	deviceID string,
+	deviceKind PestDeviceKind,
+	occurredAt time.Time,
+	coverage float64,
+) (PestEvent, error) {
+	event, err := newPestEvent(id, deviceID, deviceKind, GlueBoardCoverageEvent, occurredAt)
+	if err != nil {
+		return PestEvent{}, err
+	}
+
+	coverageValidator := domain_validation.NewDomainValidator(domain_validation.Float64Range(MinGlueBoardCoverage, MaxGlueBoardCoverage))
+	if err := coverageValidator.Validate(coverage, NewInvalidPestEvent(id, "glue_board_coverage", "must be a percentage")); err != nil {
+		return PestEvent{}, err
+	}
+	event.GlueBoardCoverage = coverage
+
+	return event, nil
+}
+
+func NewTamper(
+	id string,
+	deviceID string,
+	deviceKind PestDeviceKind,
+	occurredAt time.Time,
+	tamper TamperKind,
+) (PestEvent, error) {
+	event, err := newPestEvent(id, deviceID, deviceKind, TamperEvent, occurredAt)
+	if err != nil {
+		return PestEvent{}, err
+	}
+
+	tamperValidator := domain_validation.NewDomainValidator(domain_validation.In(tamperKinds))
+	if err := tamperValidator.Validate(tamper.Value(), NewInvalidPestEvent(id, "tamper", "is not a known tamper")); err != nil {
+		return PestEvent{}, err
+	}
+	event.Tamper = tamper
+
+	return event, nil
+}
+
+func newPestEvent(
+	id string,
+	deviceID string,
+	deviceKind PestDeviceKind,
+	eventType PestEventType,
+	occurredAt time.Time,
+) (PestEvent, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidPestEvent(id, "id", "must be a ULID")); err != nil {
+		return PestEvent{}, err
+	}
+
+	deviceIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(pestDeviceIdMaxLength),
+	)
+	if err := deviceIdValidator.Validate(deviceID, NewInvalidPestEvent(id, "device_id", "must be a non empty string")); err != nil {
+		return PestEvent{}, err
+	}
+
+	deviceKindValidator := domain_validation.NewDomainValidator(domain_validation.In(pestDeviceKinds))
+	if err := deviceKindValidator.Validate(deviceKind.Value(), NewInvalidPestEvent(id, "device_kind", "is not a known device kind")); err != nil {
+		return PestEvent{}, err
+	}
+
+	typeValidator := domain_validation.NewDomainValidator(domain_validation.In(pestEventTypes))
+	if err := typeValidator.Validate(eventType.Value(), NewInvalidPestEvent(id, "type", "is not a known event")); err != nil {
+		return PestEvent{}, err
+	}
+
+	if !deviceKind.Reports(eventType) {
+		return PestEvent{}, NewInvalidPestEvent(id, "type", "is not reported by "+deviceKind.Value()+" devices")
+	}
+
+	if occurredAt.IsZero() {
+		return PestEvent{}, NewInvalidPestEvent(id, "occurred_at", "is required")
+	}
+
+	return PestEvent{
+		ID:         id,
+		DeviceID:   deviceID,
+		DeviceKind: deviceKind,
+		Type:       eventType,
+		OccurredAt: occurredAt,
+	}, nil
+}
+
+// CorrectedBy shifts the time the event occurred, as the device clock told it, by the offset of the device clock.
+func (pe PestEvent) CorrectedBy(clockOffset time.Duration) PestEvent {
+	pe.OccurredAt = pe.OccurredAt.Add(clockOffset)
+
+	return pe
+}
+
+// Metrics are the readings the event stands for, which is what alert rules evaluate:
+// a reset clears the trigger and the tamper flag is raised on each tamper.
+func (pe PestEvent) Metrics() map[string]float64 {
+	switch pe.Type {
+	case TrapTriggeredEvent:
+		return map[string]float64{TrapTriggeredMetric: 1}
+	case TrapResetEvent:
+		return map[string]float64{TrapTriggeredMetric: 0}
+	case BaitConsumedEvent:
+		return map[string]float64{BaitConsumedMetric: pe.BaitConsumedGrams}
+	case GlueBoardCoverageEvent:
+		return map[string]float64{GlueBoardCoverageMetric: pe.GlueBoardCoverage}
+	case TamperEvent:
+		return map[string]float64{TamperMetric: 1}
+	default:
+		return map[string]float64{}
+	}
+}
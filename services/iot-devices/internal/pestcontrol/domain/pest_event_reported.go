@@ -0,0 +1,58 @@
+package pestcontrol_domain
+
+import "time"
+
+const pestEventNamePrefix = "pest_control."
+
+// PestEventName is the name every type of pest event is published with, so subscribers
+// only listen to the ones they care about.
+func PestEventName(eventType PestEventType) string {
+	return pestEventNamePrefix + eventType.Value()
+}
+
+// PestEventNames are the names of every type of pest event.
+var PestEventNames = []string{
+	PestEventName(TrapTriggeredEvent),
+	PestEventName(TrapResetEvent),
+	PestEventName(BaitConsumedEvent),
+	PestEventName(GlueBoardCoverageEvent),
+	PestEventName(TamperEvent),
+}
+
+type PestEventReported struct {
+	event PestEvent
+}
+
+func NewPestEventReported(event PestEvent) PestEventReported {
+	return PestEventReported{event: event}
+}
+
+func (per PestEventReported) Name() string {
+	return PestEventName(per.event.Type)
+}
+
+func (per PestEventReported) Type() string {
+	return "domain_event"
+}
+
+func (per PestEventReported) Data() map[string]interface{} {
+	data := map[string]interface{}{
+		"id":          per.event.ID,
+		"device_id":   per.event.DeviceID,
+		"device_kind": per.event.DeviceKind.Value(),
+		"type":        per.event.Type.Value(),
+		"occurred_at": per.event.OccurredAt.Format(time.RFC3339),
+		"metrics":     per.event.Metrics(),
+	}
+
+	switch per.event.Type {
+	case BaitConsumedEvent:
+		data["bait_consumed_grams"] = per.event.BaitConsumedGrams
+	case GlueBoardCoverageEvent:
+		data["glue_board_coverage"] = per.event.GlueBoardCoverage
+	case TamperEvent:
+		data["tamper"] = per.event.Tamper.Value()
+	}
+
+	return data
+}
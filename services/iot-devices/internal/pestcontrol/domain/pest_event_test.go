@@ -0,0 +1,71 @@
+package pestcontrol_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestPestEvent(t *testing.T) {
+	id, now := amf_utils.NewUlid().String(), time.Now()
+
+	t.Run("should stand for the readings alert rules evaluate", func(t *testing.T) {
+		triggered, err := pestcontrol_domain.NewTrapTriggered(id, "trap-1", pestcontrol_domain.SnapTrapDevice, now)
+		require.NoError(t, err)
+		reset, err := pestcontrol_domain.NewTrapReset(id, "trap-1", pestcontrol_domain.SnapTrapDevice, now)
+		require.NoError(t, err)
+		consumed, err := pestcontrol_domain.NewBaitConsumed(id, "station-1", pestcontrol_domain.BaitStationDevice, now, 12.5)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]float64{"trap_triggered": 1}, triggered.Metrics())
+		assert.Equal(t, map[string]float64{"trap_triggered": 0}, reset.Metrics())
+		assert.Equal(t, map[string]float64{"bait_consumed_g": 12.5}, consumed.Metrics())
+	})
+
+	t.Run("should reject the events the kind of device does not report", func(t *testing.T) {
+		_, err := pestcontrol_domain.NewTrapTriggered(id, "light-1", pestcontrol_domain.InsectLightTrapDevice, now)
+
+		assert.IsType(t, &pestcontrol_domain.InvalidPestEvent{}, err)
+	})
+
+	t.Run("should reject unknown device kinds", func(t *testing.T) {
+		_, err := pestcontrol_domain.NewTamper(id, "trap-1", pestcontrol_domain.PestDeviceKind("mouse"), now, pestcontrol_domain.OpenedTamper)
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+
+	t.Run("should reject bait weights below the load cell resolution", func(t *testing.T) {
+		_, err := pestcontrol_domain.NewBaitConsumed(id, "station-1", pestcontrol_domain.BaitStationDevice, now, 0.05)
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+
+	t.Run("should reject glue board coverages that are not percentages", func(t *testing.T) {
+		_, err := pestcontrol_domain.NewGlueBoardCoverage(id, "monitor-1", pestcontrol_domain.PheromoneMonitorDevice, now, 120)
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+
+	t.Run("should reject unknown tampers", func(t *testing.T) {
+		_, err := pestcontrol_domain.NewTamper(id, "trap-1", pestcontrol_domain.SnapTrapDevice, now, pestcontrol_domain.TamperKind("kicked"))
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+
+	t.Run("should be published by type", func(t *testing.T) {
+		tamper, err := pestcontrol_domain.NewTamper(id, "trap-1", pestcontrol_domain.SnapTrapDevice, now, pestcontrol_domain.MovedTamper)
+		require.NoError(t, err)
+
+		reported := pestcontrol_domain.NewPestEventReported(tamper)
+
+		assert.Equal(t, "pest_control.tamper", reported.Name())
+		assert.Equal(t, "moved", reported.Data()["tamper"])
+	})
+}
@@ -0,0 +1,87 @@
+package pestcontrol_domain
+
+import (
+	"context"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	serviceVisitSiteIdMaxLength     = 50
+	serviceVisitTechnicianMaxLength = 100
+	serviceVisitNotesMaxLength      = 2000
+)
+
+// ServiceVisit is a technician servicing the devices of a site on the spot: checking the
+// traps, refilling the bait and replacing the glue boards.
+type ServiceVisit struct {
+	ID         string
+	SiteID     string
+	Technician string
+	StartedAt  time.Time
+	EndedAt    time.Time
+	Notes      string
+}
+
+func NewServiceVisit(
+	id string,
+	siteID string,
+	technician string,
+	startedAt time.Time,
+	endedAt time.Time,
+	notes string,
+) (ServiceVisit, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidServiceVisit(id, "id", "must be a ULID")); err != nil {
+		return ServiceVisit{}, err
+	}
+
+	siteIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(serviceVisitSiteIdMaxLength),
+	)
+	if err := siteIdValidator.Validate(siteID, NewInvalidServiceVisit(id, "site_id", "must be a non empty string")); err != nil {
+		return ServiceVisit{}, err
+	}
+
+	technicianValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(serviceVisitTechnicianMaxLength),
+	)
+	if err := technicianValidator.Validate(technician, NewInvalidServiceVisit(id, "technician", "must be a non empty string")); err != nil {
+		return ServiceVisit{}, err
+	}
+
+	if startedAt.IsZero() {
+		return ServiceVisit{}, NewInvalidServiceVisit(id, "started_at", "is required")
+	}
+	if !endedAt.After(startedAt) {
+		return ServiceVisit{}, NewInvalidServiceVisit(id, "ended_at", "must be after started_at")
+	}
+
+	notesValidator := domain_validation.NewDomainValidator(domain_validation.MaxLength(serviceVisitNotesMaxLength))
+	if err := notesValidator.Validate(notes, NewInvalidServiceVisit(id, "notes", "is too long")); err != nil {
+		return ServiceVisit{}, err
+	}
+
+	return ServiceVisit{
+		ID:         id,
+		SiteID:     siteID,
+		Technician: technician,
+		StartedAt:  startedAt,
+		EndedAt:    endedAt,
+		Notes:      notes,
+	}, nil
+}
+
+func (sv ServiceVisit) Duration() time.Duration {
+	return sv.EndedAt.Sub(sv.StartedAt)
+}
+
+type ServiceVisitRepository interface {
+	Save(ctx context.Context, visit ServiceVisit) error
+	// Find returns nil when there is no visit for the id
+	Find(ctx context.Context, id string) (*ServiceVisit, error)
+	// SearchBySite returns the visits started in [from, to), oldest first
+	SearchBySite(ctx context.Context, siteID string, from time.Time, to time.Time) ([]ServiceVisit, error)
+}
@@ -0,0 +1,24 @@
+package pestcontrol_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const serviceVisitNotExistsErrorMessage = "Service visit not exists"
+
+type ServiceVisitNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (svne ServiceVisitNotExists) Error() string {
+	return serviceVisitNotExistsErrorMessage
+}
+
+func (svne ServiceVisitNotExists) ExtraItems() map[string]interface{} {
+	return svne.items
+}
+
+func NewServiceVisitNotExists(id string) *ServiceVisitNotExists {
+	return &ServiceVisitNotExists{items: map[string]interface{}{"id": id}}
+}
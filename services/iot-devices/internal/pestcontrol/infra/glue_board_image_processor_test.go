@@ -0,0 +1,61 @@
+package pestcontrol_infra_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pestcontrol_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/infra"
+)
+
+func TestStdlibGlueBoardImageProcessor(t *testing.T) {
+	processor := pestcontrol_infra.NewStdlibGlueBoardImageProcessor(1<<20, 400*200, 64)
+
+	photo := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			photo.Set(x, y, color.RGBA{R: 250, G: 230, B: 20, A: 255})
+		}
+	}
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, photo))
+
+	t.Run("should extract the metadata and shrink the photo keeping its aspect ratio", func(t *testing.T) {
+		metadata, thumbnail, err := processor.Process(bytes.NewReader(encoded.Bytes()))
+		require.NoError(t, err)
+
+		assert.Equal(t, "png", metadata.Format)
+		assert.Equal(t, 400, metadata.Width)
+		assert.Equal(t, 200, metadata.Height)
+		assert.Equal(t, int64(encoded.Len()), metadata.SizeBytes)
+		assert.Len(t, metadata.Checksum, 64)
+
+		decoded, err := jpeg.Decode(bytes.NewReader(thumbnail))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 64, 32), decoded.Bounds())
+	})
+
+	t.Run("should reject what is not a photo", func(t *testing.T) {
+		_, _, err := processor.Process(bytes.NewReader([]byte("not a photo")))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject photos over the max size", func(t *testing.T) {
+		_, _, err := pestcontrol_infra.NewStdlibGlueBoardImageProcessor(16, 400*200, 64).Process(bytes.NewReader(encoded.Bytes()))
+
+		assert.ErrorContains(t, err, "larger than")
+	})
+
+	t.Run("should reject photos over the max pixels before decoding them", func(t *testing.T) {
+		_, _, err := pestcontrol_infra.NewStdlibGlueBoardImageProcessor(1<<20, 400*200-1, 64).Process(bytes.NewReader(encoded.Bytes()))
+
+		assert.ErrorContains(t, err, "pixels")
+	})
+}
@@ -0,0 +1,254 @@
+package pestcontrol_http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	pestcontrol_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/application"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// NewRequestGlueBoardImageUploadController answers the device with the url to upload its
+// photo to, straight to the storage.
+func NewRequestGlueBoardImageUploadController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		command := &pestcontrol_application.RequestGlueBoardImageUploadCommand{
+			ID:          ulidProvider.New().String(),
+			DeviceID:    mux.Vars(r)["deviceId"],
+			EventID:     stringAttribute(requestParams, "event_id"),
+			ContentType: stringAttribute(requestParams, "content_type"),
+		}
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writePestControlError(w, r, jarm, err)
+			return
+		}
+
+		query := &pestcontrol_application.FindGlueBoardImageUploadQuery{ID: command.ID, DeviceID: command.DeviceID}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusCreated)
+	}
+}
+
+// NewGetGlueBoardImageUploadController signs a new upload url, for the devices that
+// failed to upload before the previous one expired.
+func NewGetGlueBoardImageUploadController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		query := &pestcontrol_application.FindGlueBoardImageUploadQuery{ID: vars["imageId"], DeviceID: vars["deviceId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+// NewNotifyGlueBoardImageUploadedController processes the photo the device just uploaded.
+func NewNotifyGlueBoardImageUploadedController(
+	commandBus amf_command_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		vars := mux.Vars(r)
+		command := &pestcontrol_application.ProcessGlueBoardImageCommand{ID: vars["imageId"], DeviceID: vars["deviceId"]}
+		if capturedAt := stringAttribute(requestParams, "captured_at"); capturedAt != "" {
+			parsed, err := time.Parse(time.RFC3339, capturedAt)
+			if err != nil {
+				writePestControlError(w, r, jarm, pestcontrol_domain.NewInvalidGlueBoardImage(command.ID, "captured_at", "must be a RFC3339 date"))
+				return
+			}
+			command.CapturedAt = &parsed
+		}
+
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writePestControlError(w, r, jarm, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, nil, http.StatusNoContent)
+	}
+}
+
+func NewGetGlueBoardImageController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &pestcontrol_application.FindGlueBoardImageQuery{ID: mux.Vars(r)["imageId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewGetDeviceGlueBoardImagesController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &pestcontrol_application.SearchDeviceGlueBoardImagesQuery{DeviceID: mux.Vars(r)["deviceId"]}
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func NewRecordServiceVisitController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			writeInternalServerError(w, r, jarm, err)
+			return
+		}
+
+		id := ulidProvider.New().String()
+		startedAt, err := time.Parse(time.RFC3339, stringAttribute(requestParams, "started_at"))
+		if err != nil {
+			writePestControlError(w, r, jarm, pestcontrol_domain.NewInvalidServiceVisit(id, "started_at", "must be a RFC3339 date"))
+			return
+		}
+		endedAt, err := time.Parse(time.RFC3339, stringAttribute(requestParams, "ended_at"))
+		if err != nil {
+			writePestControlError(w, r, jarm, pestcontrol_domain.NewInvalidServiceVisit(id, "ended_at", "must be a RFC3339 date"))
+			return
+		}
+
+		command := &pestcontrol_application.RecordServiceVisitCommand{
+			ID:         id,
+			SiteID:     mux.Vars(r)["siteId"],
+			Technician: stringAttribute(requestParams, "technician"),
+			StartedAt:  startedAt,
+			EndedAt:    endedAt,
+			Notes:      stringAttribute(requestParams, "notes"),
+		}
+		if err := commandBus.Dispatch(r.Context(), command); err != nil {
+			writePestControlError(w, r, jarm, err)
+			return
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, &pestcontrol_application.FindServiceVisitQuery{ID: id}, http.StatusCreated)
+	}
+}
+
+func NewGetSiteServiceVisitsController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filters := r.URL.Query()
+		query := &pestcontrol_application.SearchSiteServiceVisitsQuery{SiteID: mux.Vars(r)["siteId"]}
+
+		for field, at := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+			rawTime := filters.Get("filter[" + field + "]")
+			if rawTime == "" {
+				continue
+			}
+
+			var err error
+			if *at, err = time.Parse(time.RFC3339, rawTime); err != nil {
+				writePestControlError(w, r, jarm, pestcontrol_domain.NewInvalidServiceVisit("", field, "must be a RFC3339 date"))
+				return
+			}
+		}
+
+		writeQueryResponse(w, r, queryBus, jarm, query, http.StatusOK)
+	}
+}
+
+func writeQueryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query amf_bus.Dto,
+	statusCode int,
+) {
+	queryResponse, err := queryBus.Ask(r.Context(), query)
+	if err != nil {
+		writePestControlError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, statusCode)
+}
+
+func writePestControlError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *pestcontrol_domain.GlueBoardImageNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *pestcontrol_domain.GlueBoardImageNotUploaded:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewConflictWithDetails(
+			err.Error(),
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusConflict, err)
+	case *pestcontrol_domain.ServiceVisitNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	case *pestcontrol_domain.InvalidServiceVisit:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *pestcontrol_domain.InvalidGlueBoardImage:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		writeInternalServerError(w, r, jarm, err)
+	}
+}
+
+func writeInternalServerError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+	jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+}
+
+func stringAttribute(requestParams map[string]interface{}, attribute string) string {
+	value, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, "").(string)
+
+	return value
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
@@ -0,0 +1,44 @@
+package pestcontrol_infra
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+)
+
+// ObjectStorageGlueBoardImageStorage keeps the photos in the object storage, which signs
+// the urls devices upload them to and users download them from.
+type ObjectStorageGlueBoardImageStorage struct {
+	storage amf_object_storage.ObjectStorage
+}
+
+func NewObjectStorageGlueBoardImageStorage(storage amf_object_storage.ObjectStorage) *ObjectStorageGlueBoardImageStorage {
+	return &ObjectStorageGlueBoardImageStorage{storage: storage}
+}
+
+func (s *ObjectStorageGlueBoardImageStorage) UploadUrl(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.storage.PresignPut(ctx, key, expiry)
+}
+
+func (s *ObjectStorageGlueBoardImageStorage) DownloadUrl(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.storage.PresignGet(ctx, key, expiry)
+}
+
+func (s *ObjectStorageGlueBoardImageStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	photo, err := s.storage.Get(ctx, key)
+	if errors.Is(err, amf_object_storage.ErrObjectNotFound) {
+		return nil, pestcontrol_domain.NewGlueBoardImageNotUploaded(key)
+	}
+
+	return photo, err
+}
+
+func (s *ObjectStorageGlueBoardImageStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	return s.storage.Put(ctx, key, bytes.NewReader(content), int64(len(content)), contentType)
+}
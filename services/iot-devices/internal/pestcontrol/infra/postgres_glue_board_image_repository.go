@@ -0,0 +1,169 @@
+package pestcontrol_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	glueBoardImageColumns = `id, device_id, event_id, content_type, status, format, width, height, size_bytes, checksum,
+    failure_reason, captured_at, requested_at, processed_at`
+
+	upsertGlueBoardImageQuery = `
+INSERT INTO glue_board_images (` + glueBoardImageColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+ON CONFLICT (id) DO UPDATE SET
+    status = EXCLUDED.status,
+    format = EXCLUDED.format,
+    width = EXCLUDED.width,
+    height = EXCLUDED.height,
+    size_bytes = EXCLUDED.size_bytes,
+    checksum = EXCLUDED.checksum,
+    failure_reason = EXCLUDED.failure_reason,
+    captured_at = EXCLUDED.captured_at,
+    processed_at = EXCLUDED.processed_at`
+	findGlueBoardImageQuery = `
+SELECT ` + glueBoardImageColumns + ` FROM glue_board_images
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $2))`
+	searchGlueBoardImagesByDeviceQuery = `
+SELECT ` + glueBoardImageColumns + ` FROM glue_board_images
+WHERE device_id = $1 AND ($2::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $2))
+ORDER BY requested_at DESC, id`
+)
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+type PostgresGlueBoardImageRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresGlueBoardImageRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresGlueBoardImageRepository {
+	return &PostgresGlueBoardImageRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresGlueBoardImageRepository) Save(ctx context.Context, image pestcontrol_domain.GlueBoardImage) error {
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertGlueBoardImageQuery,
+		image.ID,
+		image.DeviceID,
+		image.EventID,
+		image.ContentType,
+		image.Status.Value(),
+		image.Metadata.Format,
+		image.Metadata.Width,
+		image.Metadata.Height,
+		image.Metadata.SizeBytes,
+		image.Metadata.Checksum,
+		image.FailureReason,
+		nullTime(image.CapturedAt),
+		image.RequestedAt.UTC(),
+		nullTime(image.ProcessedAt),
+	)
+
+	return err
+}
+
+// Find reads from the writer, as devices notify their uploads right after requesting them.
+func (r *PostgresGlueBoardImageRepository) Find(ctx context.Context, id string) (*pestcontrol_domain.GlueBoardImage, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := scanGlueBoardImage(r.connectionPool.Writer().QueryRowContext(ctx, findGlueBoardImageQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+func (r *PostgresGlueBoardImageRepository) SearchByDevice(
+	ctx context.Context,
+	deviceID string,
+) ([]pestcontrol_domain.GlueBoardImage, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchGlueBoardImagesByDeviceQuery, deviceID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	images := make([]pestcontrol_domain.GlueBoardImage, 0)
+	for rows.Next() {
+		image, err := scanGlueBoardImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	return images, rows.Err()
+}
+
+func scanGlueBoardImage(row rowScanner) (pestcontrol_domain.GlueBoardImage, error) {
+	var (
+		image                   pestcontrol_domain.GlueBoardImage
+		status                  string
+		capturedAt, processedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&image.ID,
+		&image.DeviceID,
+		&image.EventID,
+		&image.ContentType,
+		&status,
+		&image.Metadata.Format,
+		&image.Metadata.Width,
+		&image.Metadata.Height,
+		&image.Metadata.SizeBytes,
+		&image.Metadata.Checksum,
+		&image.FailureReason,
+		&capturedAt,
+		&image.RequestedAt,
+		&processedAt,
+	)
+	if err != nil {
+		return pestcontrol_domain.GlueBoardImage{}, err
+	}
+
+	image.Status = pestcontrol_domain.GlueBoardImageStatus(status)
+	image.CapturedAt = timeFrom(capturedAt)
+	image.ProcessedAt = timeFrom(processedAt)
+
+	return image, nil
+}
+
+func nullTime(at *time.Time) sql.NullTime {
+	if at == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: at.UTC(), Valid: true}
+}
+
+func timeFrom(at sql.NullTime) *time.Time {
+	if !at.Valid {
+		return nil
+	}
+
+	return &at.Time
+}
@@ -0,0 +1,105 @@
+package pestcontrol_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	serviceVisitColumns = `id, site_id, technician, started_at, ended_at, notes`
+
+	insertServiceVisitQuery = `
+INSERT INTO service_visits (` + serviceVisitColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6)`
+	findServiceVisitQuery = `
+SELECT ` + serviceVisitColumns + ` FROM service_visits
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR site_id IN (SELECT id FROM sites WHERE tenant_id = $2))`
+	searchServiceVisitsBySiteQuery = `
+SELECT ` + serviceVisitColumns + ` FROM service_visits
+WHERE site_id = $1 AND started_at >= $2 AND started_at < $3
+  AND ($4::VARCHAR IS NULL OR site_id IN (SELECT id FROM sites WHERE tenant_id = $4))
+ORDER BY started_at, id`
+)
+
+type PostgresServiceVisitRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresServiceVisitRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresServiceVisitRepository {
+	return &PostgresServiceVisitRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresServiceVisitRepository) Save(ctx context.Context, visit pestcontrol_domain.ServiceVisit) error {
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		insertServiceVisitQuery,
+		visit.ID,
+		visit.SiteID,
+		visit.Technician,
+		visit.StartedAt.UTC(),
+		visit.EndedAt.UTC(),
+		visit.Notes,
+	)
+
+	return err
+}
+
+func (r *PostgresServiceVisitRepository) Find(ctx context.Context, id string) (*pestcontrol_domain.ServiceVisit, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	visit, err := scanServiceVisit(r.connectionPool.Reader().QueryRowContext(ctx, findServiceVisitQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &visit, nil
+}
+
+func (r *PostgresServiceVisitRepository) SearchBySite(
+	ctx context.Context,
+	siteID string,
+	from time.Time,
+	to time.Time,
+) ([]pestcontrol_domain.ServiceVisit, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchServiceVisitsBySiteQuery, siteID, from.UTC(), to.UTC(), scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	visits := make([]pestcontrol_domain.ServiceVisit, 0)
+	for rows.Next() {
+		visit, err := scanServiceVisit(rows)
+		if err != nil {
+			return nil, err
+		}
+		visits = append(visits, visit)
+	}
+
+	return visits, rows.Err()
+}
+
+func scanServiceVisit(row rowScanner) (pestcontrol_domain.ServiceVisit, error) {
+	var visit pestcontrol_domain.ServiceVisit
+	err := row.Scan(&visit.ID, &visit.SiteID, &visit.Technician, &visit.StartedAt, &visit.EndedAt, &visit.Notes)
+
+	return visit, err
+}
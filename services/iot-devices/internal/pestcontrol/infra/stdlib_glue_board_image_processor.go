@@ -0,0 +1,112 @@
+package pestcontrol_infra
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+)
+
+const thumbnailJpegQuality = 80
+
+// StdlibGlueBoardImageProcessor decodes jpeg and png photos and shrinks them averaging the
+// pixels each thumbnail pixel covers, which keeps small insects visible better than just
+// picking one pixel. The dimensions are checked before decoding, as a small compressed
+// photo can still claim more pixels than fit in memory.
+type StdlibGlueBoardImageProcessor struct {
+	maxSize       int64
+	maxPixels     int
+	thumbnailSize int
+}
+
+func NewStdlibGlueBoardImageProcessor(maxSize int64, maxPixels int, thumbnailSize int) *StdlibGlueBoardImageProcessor {
+	return &StdlibGlueBoardImageProcessor{maxSize: maxSize, maxPixels: maxPixels, thumbnailSize: thumbnailSize}
+}
+
+func (p *StdlibGlueBoardImageProcessor) Process(photo io.Reader) (pestcontrol_domain.GlueBoardImageMetadata, []byte, error) {
+	content, err := io.ReadAll(io.LimitReader(photo, p.maxSize+1))
+	if err != nil {
+		return pestcontrol_domain.GlueBoardImageMetadata{}, nil, err
+	}
+	if int64(len(content)) > p.maxSize {
+		return pestcontrol_domain.GlueBoardImageMetadata{}, nil, fmt.Errorf("photo larger than %d bytes", p.maxSize)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return pestcontrol_domain.GlueBoardImageMetadata{}, nil, fmt.Errorf("photo not decodable: %w", err)
+	}
+	if int64(config.Width)*int64(config.Height) > int64(p.maxPixels) {
+		return pestcontrol_domain.GlueBoardImageMetadata{}, nil, fmt.Errorf("photo larger than %d pixels", p.maxPixels)
+	}
+
+	decoded, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return pestcontrol_domain.GlueBoardImageMetadata{}, nil, fmt.Errorf("photo not decodable: %w", err)
+	}
+
+	var thumbnail bytes.Buffer
+	if err := jpeg.Encode(&thumbnail, p.shrink(decoded), &jpeg.Options{Quality: thumbnailJpegQuality}); err != nil {
+		return pestcontrol_domain.GlueBoardImageMetadata{}, nil, err
+	}
+
+	checksum := sha256.Sum256(content)
+	bounds := decoded.Bounds()
+
+	return pestcontrol_domain.GlueBoardImageMetadata{
+		Format:    format,
+		Width:     bounds.Dx(),
+		Height:    bounds.Dy(),
+		SizeBytes: int64(len(content)),
+		Checksum:  hex.EncodeToString(checksum[:]),
+	}, thumbnail.Bytes(), nil
+}
+
+// shrink fits the photo in a square of the thumbnail size keeping its aspect ratio. Photos
+// already smaller are kept as they are, so every thumbnail pixel covers at least one.
+func (p *StdlibGlueBoardImageProcessor) shrink(photo image.Image) image.Image {
+	bounds := photo.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= p.thumbnailSize && height <= p.thumbnailSize {
+		return photo
+	}
+
+	thumbWidth, thumbHeight := p.thumbnailSize, height*p.thumbnailSize/width
+	if height > width {
+		thumbWidth, thumbHeight = width*p.thumbnailSize/height, p.thumbnailSize
+	}
+	thumbWidth, thumbHeight = max(thumbWidth, 1), max(thumbHeight, 1)
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		fromY, toY := bounds.Min.Y+y*height/thumbHeight, bounds.Min.Y+(y+1)*height/thumbHeight
+		for x := 0; x < thumbWidth; x++ {
+			fromX, toX := bounds.Min.X+x*width/thumbWidth, bounds.Min.X+(x+1)*width/thumbWidth
+
+			var r, g, b, a, pixels uint64
+			for sy := fromY; sy < toY; sy++ {
+				for sx := fromX; sx < toX; sx++ {
+					pr, pg, pb, pa := photo.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					pixels++
+				}
+			}
+
+			thumbnail.Set(x, y, color.RGBA64{
+				R: uint16(r / pixels),
+				G: uint16(g / pixels),
+				B: uint16(b / pixels),
+				A: uint16(a / pixels),
+			})
+		}
+	}
+
+	return thumbnail
+}
@@ -0,0 +1,11 @@
+package reports_application
+
+const FindSiteReportQueryName = "FindSiteReportQuery"
+
+type FindSiteReportQuery struct {
+	ID string
+}
+
+func (q FindSiteReportQuery) Type() string {
+	return FindSiteReportQueryName
+}
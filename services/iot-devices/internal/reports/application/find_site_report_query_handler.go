@@ -0,0 +1,45 @@
+package reports_application
+
+import (
+	"context"
+	"time"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type FindSiteReportQueryHandler struct {
+	repository reports_domain.SiteReportRepository
+	urlSigner  *siteReportUrlSigner
+}
+
+func NewFindSiteReportQueryHandler(
+	repository reports_domain.SiteReportRepository,
+	storage reports_domain.SiteReportStorage,
+	downloadUrlTtl time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *FindSiteReportQueryHandler {
+	return &FindSiteReportQueryHandler{
+		repository: repository,
+		urlSigner:  &siteReportUrlSigner{storage: storage, ttl: downloadUrlTtl, timeProvider: timeProvider},
+	}
+}
+
+func (h FindSiteReportQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindSiteReportQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	report, err := h.repository.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, reports_domain.NewSiteReportNotExists(q.ID)
+	}
+
+	return h.urlSigner.response(ctx, *report)
+}
@@ -0,0 +1,13 @@
+package reports_application
+
+const GenerateSiteReportCommandName = "GenerateSiteReportCommand"
+
+type GenerateSiteReportCommand struct {
+	ID     string
+	SiteID string
+	Month  string
+}
+
+func (c GenerateSiteReportCommand) Type() string {
+	return GenerateSiteReportCommandName
+}
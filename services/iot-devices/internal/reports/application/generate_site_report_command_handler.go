@@ -0,0 +1,46 @@
+package reports_application
+
+import (
+	"context"
+	"time"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// GenerateSiteReportCommandHandler makes a new version of a report on demand, e.g. after
+// a service visit was recorded late. The month in progress is reported up to now.
+type GenerateSiteReportCommandHandler struct {
+	generator    *SiteReportGenerator
+	timeProvider amf_utils.DateTimeProvider
+	location     *time.Location
+}
+
+func NewGenerateSiteReportCommandHandler(
+	generator *SiteReportGenerator,
+	timeProvider amf_utils.DateTimeProvider,
+	location *time.Location,
+) *GenerateSiteReportCommandHandler {
+	return &GenerateSiteReportCommandHandler{generator: generator, timeProvider: timeProvider, location: location}
+}
+
+func (h GenerateSiteReportCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*GenerateSiteReportCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	month, err := reports_domain.NewReportMonth(cmd.Month)
+	if err != nil {
+		return err
+	}
+	if from, _ := month.Period(h.location); from.After(h.timeProvider.Now()) {
+		return reports_domain.NewInvalidSiteReport("month", "must not be in the future")
+	}
+
+	_, err = h.generator.Generate(ctx, cmd.ID, cmd.SiteID, month)
+
+	return err
+}
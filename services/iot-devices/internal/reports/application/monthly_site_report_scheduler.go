@@ -0,0 +1,60 @@
+package reports_application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// MonthlySiteReportScheduler makes the report of the month just over for every site still
+// missing it. Running it more often than monthly only retries the sites that failed.
+type MonthlySiteReportScheduler struct {
+	source       reports_domain.SiteReportSource
+	generator    *SiteReportGenerator
+	ulidProvider amf_utils.UlidProvider
+	timeProvider amf_utils.DateTimeProvider
+	location     *time.Location
+}
+
+func NewMonthlySiteReportScheduler(
+	source reports_domain.SiteReportSource,
+	generator *SiteReportGenerator,
+	ulidProvider amf_utils.UlidProvider,
+	timeProvider amf_utils.DateTimeProvider,
+	location *time.Location,
+) *MonthlySiteReportScheduler {
+	return &MonthlySiteReportScheduler{
+		source:       source,
+		generator:    generator,
+		ulidProvider: ulidProvider,
+		timeProvider: timeProvider,
+		location:     location,
+	}
+}
+
+// Run generates the report of the previous month of every site missing it. A site failing
+// does not keep the others from getting their report.
+func (s *MonthlySiteReportScheduler) Run(ctx context.Context) error {
+	month := reports_domain.PreviousReportMonth(s.timeProvider.Now(), s.location)
+
+	sites, err := s.source.Sites(ctx)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+	for _, siteID := range sites {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if _, err := s.generator.GenerateMissing(ctx, s.ulidProvider.New().String(), siteID, month); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,185 @@
+package reports_application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	connectivity_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain/mocks"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	pestcontrol_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain/mocks"
+	reports_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/application"
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+	reports_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain/mocks"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type inProcessMutex struct{}
+
+func (m inProcessMutex) Mutex(_ context.Context, _ string, fn func() (interface{}, error)) (interface{}, error) {
+	return fn()
+}
+
+type reportsTestDoubles struct {
+	source        *reports_domain_mocks.SiteReportSource
+	lastSeen      *connectivity_domain_mocks.LastSeenRepository
+	serviceVisits *pestcontrol_domain_mocks.ServiceVisitRepository
+	reports       *reports_domain_mocks.SiteReportRepository
+	renderer      *reports_domain_mocks.SiteReportRenderer
+	storage       *reports_domain_mocks.SiteReportStorage
+}
+
+func newReportsTestDoubles(t *testing.T) reportsTestDoubles {
+	return reportsTestDoubles{
+		source:        reports_domain_mocks.NewSiteReportSource(t),
+		lastSeen:      connectivity_domain_mocks.NewLastSeenRepository(t),
+		serviceVisits: pestcontrol_domain_mocks.NewServiceVisitRepository(t),
+		reports:       reports_domain_mocks.NewSiteReportRepository(t),
+		renderer:      reports_domain_mocks.NewSiteReportRenderer(t),
+		storage:       reports_domain_mocks.NewSiteReportStorage(t),
+	}
+}
+
+func (d reportsTestDoubles) generator(timeProvider amf_utils.DateTimeProvider) *reports_application.SiteReportGenerator {
+	return reports_application.NewSiteReportGenerator(
+		d.source,
+		d.lastSeen,
+		d.serviceVisits,
+		d.reports,
+		[]reports_domain.SiteReportRenderer{d.renderer},
+		d.storage,
+		inProcessMutex{},
+		timeProvider,
+		time.UTC,
+	)
+}
+
+// expectContent makes the source tell of a device seen once, a day of activity, an
+// acknowledged alert and a service visit.
+func (d reportsTestDoubles) expectContent(siteID string, month reports_domain.ReportMonth) {
+	from, to := month.Period(time.UTC)
+	seenAt := from.Add(time.Hour)
+	acknowledgedAt := from.Add(30 * time.Minute)
+
+	d.source.On("Devices", mock.Anything, siteID, to).
+		Return([]reports_domain.ReportDevice{{ID: "trap-1", ZoneID: "kitchen"}, {ID: "trap-2"}}, nil).
+		Once()
+	d.lastSeen.On("SearchByDevices", mock.Anything, []string{"trap-1", "trap-2"}).
+		Return(map[string]time.Time{"trap-1": seenAt}, nil).
+		Once()
+	d.source.On("ZoneActivity", mock.Anything, siteID, from, to, time.UTC).
+		Return([]reports_domain.ZoneDayActivity{{ZoneID: "kitchen", Day: 2, Events: 4}}, nil).
+		Once()
+	d.source.On("Alerts", mock.Anything, siteID, from, to).
+		Return([]reports_domain.ReportAlert{{ID: "alert-1", RaisedAt: from, AcknowledgedAt: &acknowledgedAt}}, nil).
+		Once()
+	d.serviceVisits.On("SearchBySite", mock.Anything, siteID, from, to).
+		Return([]pestcontrol_domain.ServiceVisit{{ID: "visit-1", SiteID: siteID, Technician: "Ana", StartedAt: from, EndedAt: from.Add(time.Hour)}}, nil).
+		Once()
+}
+
+func TestSiteReportGenerator(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	month, err := reports_domain.NewReportMonth("2026-09")
+	require.NoError(t, err)
+	id := amf_utils.NewUlid().String()
+
+	t.Run("should store the rendered files as the next version of the report", func(t *testing.T) {
+		doubles := newReportsTestDoubles(t)
+		doubles.reports.On("LatestVersion", ctx, "site-1", month).Return(1, nil).Once()
+		doubles.expectContent("site-1", month)
+		doubles.renderer.On("Format").Return(reports_domain.PdfReport)
+		doubles.renderer.On("Render", mock.MatchedBy(func(content reports_domain.SiteReportContent) bool {
+			return content.Version == 2 &&
+				content.Devices[0].LastSeenAt != nil &&
+				content.Devices[1].LastSeenAt == nil &&
+				content.Activity.Zones[0].Events[1] == 4 &&
+				content.AlertSummary.AverageResponse == 30*time.Minute &&
+				content.ServiceVisits[0].Technician == "Ana"
+		})).Return([]byte("%PDF-1.3"), nil).Once()
+		doubles.storage.On("Put", ctx, "reports/site-1/2026-09/v2/report.pdf", []byte("%PDF-1.3"), "application/pdf").Return(nil).Once()
+		doubles.reports.On("Save", ctx, mock.MatchedBy(func(report reports_domain.SiteReport) bool {
+			return report.ID == id && report.Version == 2 && report.Timezone == "UTC"
+		})).Return(nil).Once()
+
+		report, err := doubles.generator(timeProvider).Generate(ctx, id, "site-1", month)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Version)
+		assert.Equal(t, timeProvider.Now(), report.GeneratedAt)
+	})
+
+	t.Run("should not save the report when a file could not be rendered", func(t *testing.T) {
+		doubles := newReportsTestDoubles(t)
+		doubles.reports.On("LatestVersion", ctx, "site-1", month).Return(0, nil).Once()
+		doubles.expectContent("site-1", month)
+		doubles.renderer.On("Render", mock.Anything).Return(nil, errors.New("font missing")).Once()
+
+		_, err := doubles.generator(timeProvider).Generate(ctx, id, "site-1", month)
+
+		assert.EqualError(t, err, "font missing")
+	})
+
+	t.Run("should leave alone the months already reported when generating the missing ones", func(t *testing.T) {
+		doubles := newReportsTestDoubles(t)
+		doubles.reports.On("LatestVersion", ctx, "site-1", month).Return(3, nil).Once()
+
+		generated, err := doubles.generator(timeProvider).GenerateMissing(ctx, id, "site-1", month)
+
+		require.NoError(t, err)
+		assert.False(t, generated)
+	})
+}
+
+func TestMonthlySiteReportScheduler(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	month := reports_domain.PreviousReportMonth(timeProvider.Now(), time.UTC)
+
+	doubles := newReportsTestDoubles(t)
+	doubles.source.On("Sites", ctx).Return([]string{"site-1", "site-2"}, nil).Once()
+	doubles.reports.On("LatestVersion", ctx, "site-1", month).Return(0, errors.New("replica went away")).Once()
+	doubles.reports.On("LatestVersion", ctx, "site-2", month).Return(0, nil).Once()
+	doubles.expectContent("site-2", month)
+	doubles.renderer.On("Format").Return(reports_domain.HtmlReport)
+	doubles.renderer.On("Render", mock.Anything).Return([]byte("<html></html>"), nil).Once()
+	doubles.storage.On("Put", ctx, "reports/site-2/"+month.String()+"/v1/report.html", mock.Anything, mock.Anything).Return(nil).Once()
+	doubles.reports.On("Save", ctx, mock.MatchedBy(func(report reports_domain.SiteReport) bool {
+		return report.SiteID == "site-2" && report.Version == 1
+	})).Return(nil).Once()
+
+	scheduler := reports_application.NewMonthlySiteReportScheduler(
+		doubles.source,
+		doubles.generator(timeProvider),
+		amf_utils.NewRandomUlidProvider(),
+		timeProvider,
+		time.UTC,
+	)
+
+	assert.EqualError(t, scheduler.Run(ctx), "replica went away")
+}
+
+func TestGenerateSiteReportCommandHandler(t *testing.T) {
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	nextMonth := timeProvider.Now().UTC().AddDate(0, 1, 0).Format("2006-01")
+	handler := reports_application.NewGenerateSiteReportCommandHandler(
+		newReportsTestDoubles(t).generator(timeProvider),
+		timeProvider,
+		time.UTC,
+	)
+
+	err := handler.Handle(context.Background(), &reports_application.GenerateSiteReportCommand{
+		ID:     amf_utils.NewUlid().String(),
+		SiteID: "site-1",
+		Month:  nextMonth,
+	})
+
+	assert.IsType(t, &reports_domain.InvalidSiteReport{}, err)
+}
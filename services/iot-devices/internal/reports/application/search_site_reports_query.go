@@ -0,0 +1,13 @@
+package reports_application
+
+const SearchSiteReportsQueryName = "SearchSiteReportsQuery"
+
+// SearchSiteReportsQuery returns the reports of every month when Month is empty.
+type SearchSiteReportsQuery struct {
+	SiteID string
+	Month  string
+}
+
+func (q SearchSiteReportsQuery) Type() string {
+	return SearchSiteReportsQueryName
+}
@@ -0,0 +1,60 @@
+package reports_application
+
+import (
+	"context"
+	"time"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type SearchSiteReportsQueryHandler struct {
+	repository reports_domain.SiteReportRepository
+	urlSigner  *siteReportUrlSigner
+}
+
+func NewSearchSiteReportsQueryHandler(
+	repository reports_domain.SiteReportRepository,
+	storage reports_domain.SiteReportStorage,
+	downloadUrlTtl time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *SearchSiteReportsQueryHandler {
+	return &SearchSiteReportsQueryHandler{
+		repository: repository,
+		urlSigner:  &siteReportUrlSigner{storage: storage, ttl: downloadUrlTtl, timeProvider: timeProvider},
+	}
+}
+
+func (h SearchSiteReportsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchSiteReportsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	var month *reports_domain.ReportMonth
+	if q.Month != "" {
+		reportMonth, err := reports_domain.NewReportMonth(q.Month)
+		if err != nil {
+			return nil, err
+		}
+		month = &reportMonth
+	}
+
+	reports, err := h.repository.SearchBySite(ctx, q.SiteID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*SiteReportResponse, 0, len(reports))
+	for _, report := range reports {
+		reportResponse, err := h.urlSigner.response(ctx, report)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, reportResponse)
+	}
+
+	return response, nil
+}
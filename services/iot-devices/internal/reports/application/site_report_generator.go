@@ -0,0 +1,193 @@
+package reports_application
+
+import (
+	"context"
+	"time"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+
+	amf_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const siteReportMutexKeyPrefix = "site_report:"
+
+// SiteReportGenerator gathers what happened on a site over a month, renders it in every
+// format and stores the files as a new version of the report. Generating a month runs
+// under a lock, so two generations never take the same version.
+type SiteReportGenerator struct {
+	source        reports_domain.SiteReportSource
+	lastSeen      connectivity_domain.LastSeenRepository
+	serviceVisits pestcontrol_domain.ServiceVisitRepository
+	reports       reports_domain.SiteReportRepository
+	renderers     []reports_domain.SiteReportRenderer
+	storage       reports_domain.SiteReportStorage
+	mutex         amf_sync.MutexService
+	timeProvider  amf_utils.DateTimeProvider
+	location      *time.Location
+}
+
+func NewSiteReportGenerator(
+	source reports_domain.SiteReportSource,
+	lastSeen connectivity_domain.LastSeenRepository,
+	serviceVisits pestcontrol_domain.ServiceVisitRepository,
+	reports reports_domain.SiteReportRepository,
+	renderers []reports_domain.SiteReportRenderer,
+	storage reports_domain.SiteReportStorage,
+	mutex amf_sync.MutexService,
+	timeProvider amf_utils.DateTimeProvider,
+	location *time.Location,
+) *SiteReportGenerator {
+	return &SiteReportGenerator{
+		source:        source,
+		lastSeen:      lastSeen,
+		serviceVisits: serviceVisits,
+		reports:       reports,
+		renderers:     renderers,
+		storage:       storage,
+		mutex:         mutex,
+		timeProvider:  timeProvider,
+		location:      location,
+	}
+}
+
+// Generate makes a new version of the report of the month, whatever versions it had.
+func (g *SiteReportGenerator) Generate(
+	ctx context.Context,
+	id string,
+	siteID string,
+	month reports_domain.ReportMonth,
+) (reports_domain.SiteReport, error) {
+	report, err := g.generate(ctx, id, siteID, month, false)
+	if err != nil {
+		return reports_domain.SiteReport{}, err
+	}
+
+	return *report, nil
+}
+
+// GenerateMissing makes the first version of the report of the month, and nothing when
+// it already has one. It tells whether it made it.
+func (g *SiteReportGenerator) GenerateMissing(
+	ctx context.Context,
+	id string,
+	siteID string,
+	month reports_domain.ReportMonth,
+) (bool, error) {
+	report, err := g.generate(ctx, id, siteID, month, true)
+
+	return report != nil, err
+}
+
+func (g *SiteReportGenerator) generate(
+	ctx context.Context,
+	id string,
+	siteID string,
+	month reports_domain.ReportMonth,
+	onlyMissing bool,
+) (*reports_domain.SiteReport, error) {
+	generated, err := g.mutex.Mutex(ctx, siteReportMutexKeyPrefix+siteID+":"+month.String(), func() (interface{}, error) {
+		latestVersion, err := g.reports.LatestVersion(ctx, siteID, month)
+		if err != nil || (onlyMissing && latestVersion > 0) {
+			return nil, err
+		}
+
+		report, err := reports_domain.NewSiteReport(id, siteID, month, latestVersion+1, g.location.String(), g.timeProvider.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := g.content(ctx, report)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, renderer := range g.renderers {
+			file, err := renderer.Render(content)
+			if err != nil {
+				return nil, err
+			}
+			if err := g.storage.Put(ctx, report.FileKey(renderer.Format()), file, renderer.Format().ContentType()); err != nil {
+				return nil, err
+			}
+		}
+
+		// Saved once every file is stored, so the listed reports can always be downloaded
+		if err := g.reports.Save(ctx, report); err != nil {
+			return nil, err
+		}
+
+		return &report, nil
+	})
+	if err != nil || generated == nil {
+		return nil, err
+	}
+
+	return generated.(*reports_domain.SiteReport), nil
+}
+
+func (g *SiteReportGenerator) content(
+	ctx context.Context,
+	report reports_domain.SiteReport,
+) (reports_domain.SiteReportContent, error) {
+	from, to := report.Month.Period(g.location)
+
+	devices, err := g.source.Devices(ctx, report.SiteID, to)
+	if err != nil {
+		return reports_domain.SiteReportContent{}, err
+	}
+
+	deviceIDs := make([]string, 0, len(devices))
+	for _, device := range devices {
+		deviceIDs = append(deviceIDs, device.ID)
+	}
+	lastSeen, err := g.lastSeen.SearchByDevices(ctx, deviceIDs)
+	if err != nil {
+		return reports_domain.SiteReportContent{}, err
+	}
+	for i, device := range devices {
+		if seenAt, ok := lastSeen[device.ID]; ok {
+			devices[i].LastSeenAt = &seenAt
+		}
+	}
+
+	activity, err := g.source.ZoneActivity(ctx, report.SiteID, from, to, g.location)
+	if err != nil {
+		return reports_domain.SiteReportContent{}, err
+	}
+
+	alerts, err := g.source.Alerts(ctx, report.SiteID, from, to)
+	if err != nil {
+		return reports_domain.SiteReportContent{}, err
+	}
+
+	visits, err := g.serviceVisits.SearchBySite(ctx, report.SiteID, from, to)
+	if err != nil {
+		return reports_domain.SiteReportContent{}, err
+	}
+	serviceVisits := make([]reports_domain.ReportServiceVisit, 0, len(visits))
+	for _, visit := range visits {
+		serviceVisits = append(serviceVisits, reports_domain.ReportServiceVisit{
+			ID:         visit.ID,
+			Technician: visit.Technician,
+			StartedAt:  visit.StartedAt,
+			EndedAt:    visit.EndedAt,
+			Notes:      visit.Notes,
+		})
+	}
+
+	return reports_domain.SiteReportContent{
+		SiteID:        report.SiteID,
+		Month:         report.Month,
+		Version:       report.Version,
+		Timezone:      report.Timezone,
+		GeneratedAt:   report.GeneratedAt,
+		Devices:       devices,
+		Activity:      reports_domain.NewZoneActivityHeatmap(report.Month, activity),
+		Alerts:        alerts,
+		AlertSummary:  reports_domain.SummarizeAlertResponses(alerts),
+		ServiceVisits: serviceVisits,
+	}, nil
+}
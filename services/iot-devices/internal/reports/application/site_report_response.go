@@ -0,0 +1,53 @@
+package reports_application
+
+import (
+	"context"
+	"time"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type SiteReportResponse struct {
+	ID                   string `jsonapi:"primary,site_reports"`
+	SiteID               string `jsonapi:"attr,site_id"`
+	Month                string `jsonapi:"attr,month"`
+	Version              int    `jsonapi:"attr,version"`
+	Timezone             string `jsonapi:"attr,timezone"`
+	GeneratedAt          string `jsonapi:"attr,generated_at"`
+	HtmlUrl              string `jsonapi:"attr,html_url"`
+	PdfUrl               string `jsonapi:"attr,pdf_url"`
+	DownloadUrlExpiresAt string `jsonapi:"attr,download_url_expires_at"`
+}
+
+// siteReportUrlSigner fills in the responses the urls to download the files of the
+// reports, which only work for a while.
+type siteReportUrlSigner struct {
+	storage      reports_domain.SiteReportStorage
+	ttl          time.Duration
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func (s *siteReportUrlSigner) response(ctx context.Context, report reports_domain.SiteReport) (*SiteReportResponse, error) {
+	htmlUrl, err := s.storage.DownloadUrl(ctx, report.FileKey(reports_domain.HtmlReport), s.ttl)
+	if err != nil {
+		return nil, err
+	}
+	pdfUrl, err := s.storage.DownloadUrl(ctx, report.FileKey(reports_domain.PdfReport), s.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SiteReportResponse{
+		ID:                   report.ID,
+		SiteID:               report.SiteID,
+		Month:                report.Month.String(),
+		Version:              report.Version,
+		Timezone:             report.Timezone,
+		GeneratedAt:          report.GeneratedAt.Format(time.RFC3339),
+		HtmlUrl:              htmlUrl,
+		PdfUrl:               pdfUrl,
+		DownloadUrlExpiresAt: s.timeProvider.Now().Add(s.ttl).Format(time.RFC3339),
+	}, nil
+}
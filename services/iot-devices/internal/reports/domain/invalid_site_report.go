@@ -0,0 +1,24 @@
+package reports_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidSiteReportErrorMessage = "Invalid site report"
+
+type InvalidSiteReport struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (isr InvalidSiteReport) Error() string {
+	return invalidSiteReportErrorMessage
+}
+
+func (isr InvalidSiteReport) ExtraItems() map[string]interface{} {
+	return isr.items
+}
+
+func NewInvalidSiteReport(field string, reason string) *InvalidSiteReport {
+	return &InvalidSiteReport{items: map[string]interface{}{"field": field, "reason": reason}}
+}
@@ -0,0 +1,27 @@
+package reports_domain
+
+type ReportFormat string
+
+const (
+	HtmlReport ReportFormat = "html"
+	PdfReport  ReportFormat = "pdf"
+)
+
+// ReportFormats are the formats every report is rendered in.
+var ReportFormats = []ReportFormat{HtmlReport, PdfReport}
+
+func (rf ReportFormat) Value() string {
+	return string(rf)
+}
+
+func (rf ReportFormat) ContentType() string {
+	if rf == PdfReport {
+		return "application/pdf"
+	}
+
+	return "text/html; charset=utf-8"
+}
+
+func (rf ReportFormat) Extension() string {
+	return rf.Value()
+}
@@ -0,0 +1,55 @@
+package reports_domain
+
+import (
+	"time"
+)
+
+const reportMonthLayout = "2006-01"
+
+// ReportMonth is the calendar month a site report covers, told in the timezone of the site.
+type ReportMonth struct {
+	year  int
+	month time.Month
+}
+
+func NewReportMonth(value string) (ReportMonth, error) {
+	at, err := time.Parse(reportMonthLayout, value)
+	if err != nil {
+		return ReportMonth{}, NewInvalidSiteReport("month", "must be a YYYY-MM month")
+	}
+
+	return ReportMonth{year: at.Year(), month: at.Month()}, nil
+}
+
+// PreviousReportMonth is the last month already over at now in the location.
+func PreviousReportMonth(now time.Time, location *time.Location) ReportMonth {
+	local := now.In(location)
+	previous := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, location).AddDate(0, -1, 0)
+
+	return ReportMonth{year: previous.Year(), month: previous.Month()}
+}
+
+func (rm ReportMonth) String() string {
+	return time.Date(rm.year, rm.month, 1, 0, 0, 0, 0, time.UTC).Format(reportMonthLayout)
+}
+
+// Period returns the instants the month starts and ends at in the location, the end excluded.
+func (rm ReportMonth) Period(location *time.Location) (time.Time, time.Time) {
+	from := time.Date(rm.year, rm.month, 1, 0, 0, 0, 0, location)
+
+	return from, from.AddDate(0, 1, 0)
+}
+
+// Days returns how many days the month has.
+func (rm ReportMonth) Days() int {
+	from, to := rm.Period(time.UTC)
+
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// Over tells whether the month has ended at now in the location, so its report is complete.
+func (rm ReportMonth) Over(now time.Time, location *time.Location) bool {
+	_, to := rm.Period(location)
+
+	return !now.Before(to)
+}
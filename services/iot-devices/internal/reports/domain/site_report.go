@@ -0,0 +1,93 @@
+package reports_domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+	_ "time/tzdata"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const siteReportSiteIdMaxLength = 50
+
+// SiteReport is a generated version of the monthly compliance report of a site. Generating
+// the report of a month again makes a new version, the previous ones are kept.
+type SiteReport struct {
+	ID          string
+	SiteID      string
+	Month       ReportMonth
+	Version     int
+	Timezone    string
+	GeneratedAt time.Time
+}
+
+func NewSiteReport(
+	id string,
+	siteID string,
+	month ReportMonth,
+	version int,
+	timezone string,
+	generatedAt time.Time,
+) (SiteReport, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidSiteReport("id", "must be a ULID")); err != nil {
+		return SiteReport{}, err
+	}
+
+	siteIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(siteReportSiteIdMaxLength),
+	)
+	if err := siteIdValidator.Validate(siteID, NewInvalidSiteReport("site_id", "must be a non empty string")); err != nil {
+		return SiteReport{}, err
+	}
+
+	if version < 1 {
+		return SiteReport{}, NewInvalidSiteReport("version", "must be greater than zero")
+	}
+
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		return SiteReport{}, NewInvalidSiteReport("timezone", "must be an IANA timezone")
+	}
+
+	return SiteReport{
+		ID:          id,
+		SiteID:      siteID,
+		Month:       month,
+		Version:     version,
+		Timezone:    timezone,
+		GeneratedAt: generatedAt,
+	}, nil
+}
+
+func (sr SiteReport) FileKey(format ReportFormat) string {
+	return fmt.Sprintf("reports/%s/%s/v%d/report.%s", sr.SiteID, sr.Month, sr.Version, format.Extension())
+}
+
+func (sr SiteReport) FileName(format ReportFormat) string {
+	return fmt.Sprintf("compliance-report-%s-%s-v%d.%s", sr.SiteID, sr.Month, sr.Version, format.Extension())
+}
+
+type SiteReportRepository interface {
+	Save(ctx context.Context, report SiteReport) error
+	// Find returns nil when there is no report for the id
+	Find(ctx context.Context, id string) (*SiteReport, error)
+	// SearchBySite returns the reports of the site, of every month when month is nil, the
+	// latest month and version first
+	SearchBySite(ctx context.Context, siteID string, month *ReportMonth) ([]SiteReport, error)
+	// LatestVersion returns zero when the month of the site has no report yet
+	LatestVersion(ctx context.Context, siteID string, month ReportMonth) (int, error)
+}
+
+// SiteReportRenderer renders the content of a report in its format, entirely in-process.
+type SiteReportRenderer interface {
+	Format() ReportFormat
+	Render(content SiteReportContent) ([]byte, error)
+}
+
+// SiteReportStorage keeps the rendered reports and hands out the time limited urls to
+// download them.
+type SiteReportStorage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	DownloadUrl(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
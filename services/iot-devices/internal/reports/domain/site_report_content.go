@@ -0,0 +1,184 @@
+package reports_domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// UnzonedDevices is the zone the heatmap puts the devices not placed in any zone under.
+const UnzonedDevices = "Unzoned"
+
+// SiteReportContent is what a report tells of a site over a month, ready to be rendered.
+type SiteReportContent struct {
+	SiteID        string
+	Month         ReportMonth
+	Version       int
+	Timezone      string
+	GeneratedAt   time.Time
+	Devices       []ReportDevice
+	Activity      ZoneActivityHeatmap
+	Alerts        []ReportAlert
+	AlertSummary  AlertResponseSummary
+	ServiceVisits []ReportServiceVisit
+}
+
+// Location is the timezone the times of the report are told in.
+func (src SiteReportContent) Location() *time.Location {
+	location, err := time.LoadLocation(src.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+type ReportDevice struct {
+	ID          string
+	Model       string
+	ZoneID      string
+	InstalledAt time.Time
+	LastSeenAt  *time.Time
+}
+
+// ZoneDayActivity is how many pest events the devices of a zone recorded on a local day of
+// the month, from 1 on.
+type ZoneDayActivity struct {
+	ZoneID string
+	Day    int
+	Events int
+}
+
+type ZoneActivityRow struct {
+	ZoneID string
+	Events []int
+	Total  int
+}
+
+// ZoneActivityHeatmap lays the pest events out a zone per row and a day of the month per
+// column. Max is the busiest cell, which the renderers shade the others against.
+type ZoneActivityHeatmap struct {
+	Days  int
+	Zones []ZoneActivityRow
+	Max   int
+}
+
+// NewZoneActivityHeatmap sorts the zones by id, with the unzoned devices last, and ignores
+// the activity out of the month.
+func NewZoneActivityHeatmap(month ReportMonth, activity []ZoneDayActivity) ZoneActivityHeatmap {
+	heatmap := ZoneActivityHeatmap{Days: month.Days(), Zones: make([]ZoneActivityRow, 0)}
+
+	rows := map[string]int{}
+	for _, dayActivity := range activity {
+		if dayActivity.Day < 1 || dayActivity.Day > heatmap.Days {
+			continue
+		}
+
+		zoneID := dayActivity.ZoneID
+		if zoneID == "" {
+			zoneID = UnzonedDevices
+		}
+		row, ok := rows[zoneID]
+		if !ok {
+			row = len(heatmap.Zones)
+			rows[zoneID] = row
+			heatmap.Zones = append(heatmap.Zones, ZoneActivityRow{ZoneID: zoneID, Events: make([]int, heatmap.Days)})
+		}
+
+		heatmap.Zones[row].Events[dayActivity.Day-1] += dayActivity.Events
+		heatmap.Zones[row].Total += dayActivity.Events
+		heatmap.Max = max(heatmap.Max, heatmap.Zones[row].Events[dayActivity.Day-1])
+	}
+
+	sort.Slice(heatmap.Zones, func(i, j int) bool {
+		if (heatmap.Zones[i].ZoneID == UnzonedDevices) != (heatmap.Zones[j].ZoneID == UnzonedDevices) {
+			return heatmap.Zones[j].ZoneID == UnzonedDevices
+		}
+		return heatmap.Zones[i].ZoneID < heatmap.Zones[j].ZoneID
+	})
+
+	return heatmap
+}
+
+// Intensity tells how busy a cell is against the busiest one, from 0 to 1.
+func (zah ZoneActivityHeatmap) Intensity(events int) float64 {
+	if zah.Max == 0 {
+		return 0
+	}
+
+	return float64(events) / float64(zah.Max)
+}
+
+type ReportAlert struct {
+	ID             string
+	RuleName       string
+	DeviceID       string
+	Status         string
+	Value          float64
+	RaisedAt       time.Time
+	AcknowledgedAt *time.Time
+	ResolvedAt     *time.Time
+}
+
+// ResponseTime is how long the alert waited to be acknowledged, false when it never was.
+func (ra ReportAlert) ResponseTime() (time.Duration, bool) {
+	if ra.AcknowledgedAt == nil {
+		return 0, false
+	}
+
+	return ra.AcknowledgedAt.Sub(ra.RaisedAt), true
+}
+
+type AlertResponseSummary struct {
+	Alerts          int
+	Acknowledged    int
+	Unacknowledged  int
+	AverageResponse time.Duration
+	LongestResponse time.Duration
+}
+
+func SummarizeAlertResponses(alerts []ReportAlert) AlertResponseSummary {
+	summary := AlertResponseSummary{Alerts: len(alerts)}
+
+	var total time.Duration
+	for _, alert := range alerts {
+		responseTime, ok := alert.ResponseTime()
+		if !ok {
+			summary.Unacknowledged++
+			continue
+		}
+
+		summary.Acknowledged++
+		total += responseTime
+		summary.LongestResponse = max(summary.LongestResponse, responseTime)
+	}
+	if summary.Acknowledged > 0 {
+		summary.AverageResponse = total / time.Duration(summary.Acknowledged)
+	}
+
+	return summary
+}
+
+type ReportServiceVisit struct {
+	ID         string
+	Technician string
+	StartedAt  time.Time
+	EndedAt    time.Time
+	Notes      string
+}
+
+func (rsv ReportServiceVisit) Duration() time.Duration {
+	return rsv.EndedAt.Sub(rsv.StartedAt)
+}
+
+// SiteReportSource reads what the reports tell of the sites.
+type SiteReportSource interface {
+	// Sites returns the ids of the sites with devices, sorted
+	Sites(ctx context.Context) ([]string, error)
+	// Devices returns the devices of the site installed before to, sorted by zone and id
+	Devices(ctx context.Context, siteID string, to time.Time) ([]ReportDevice, error)
+	// ZoneActivity counts the pest events of the site per zone and local day of the period
+	ZoneActivity(ctx context.Context, siteID string, from time.Time, to time.Time, location *time.Location) ([]ZoneDayActivity, error)
+	// Alerts returns the alerts raised on the devices of the site within the period, oldest first
+	Alerts(ctx context.Context, siteID string, from time.Time, to time.Time) ([]ReportAlert, error)
+}
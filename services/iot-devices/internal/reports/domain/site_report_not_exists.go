@@ -0,0 +1,24 @@
+package reports_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const siteReportNotExistsErrorMessage = "Site report not exists"
+
+type SiteReportNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (srne SiteReportNotExists) Error() string {
+	return siteReportNotExistsErrorMessage
+}
+
+func (srne SiteReportNotExists) ExtraItems() map[string]interface{} {
+	return srne.items
+}
+
+func NewSiteReportNotExists(id string) *SiteReportNotExists {
+	return &SiteReportNotExists{items: map[string]interface{}{"id": id}}
+}
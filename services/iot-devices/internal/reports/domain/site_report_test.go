@@ -0,0 +1,110 @@
+package reports_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+)
+
+func TestReportMonth(t *testing.T) {
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	t.Run("should cover the local days of the month", func(t *testing.T) {
+		month, err := reports_domain.NewReportMonth("2026-02")
+		require.NoError(t, err)
+
+		from, to := month.Period(madrid)
+
+		assert.Equal(t, "2026-02", month.String())
+		assert.Equal(t, time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC), from.UTC())
+		assert.Equal(t, time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC), to.UTC())
+		assert.Equal(t, 28, month.Days())
+		assert.False(t, month.Over(time.Date(2026, 2, 28, 22, 59, 0, 0, time.UTC), madrid))
+		assert.True(t, month.Over(to, madrid))
+	})
+
+	t.Run("should take the previous month in the timezone of the site", func(t *testing.T) {
+		now := time.Date(2026, 9, 30, 22, 30, 0, 0, time.UTC)
+
+		assert.Equal(t, "2026-08", reports_domain.PreviousReportMonth(now, time.UTC).String())
+		assert.Equal(t, "2026-09", reports_domain.PreviousReportMonth(now, madrid).String())
+		assert.Equal(t, "2025-12", reports_domain.PreviousReportMonth(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), time.UTC).String())
+	})
+
+	for _, value := range []string{"", "2026-13", "2026-9", "09-2026"} {
+		t.Run("should refuse "+value, func(t *testing.T) {
+			_, err := reports_domain.NewReportMonth(value)
+
+			assert.IsType(t, &reports_domain.InvalidSiteReport{}, err)
+		})
+	}
+}
+
+func TestNewSiteReport(t *testing.T) {
+	month, err := reports_domain.NewReportMonth("2026-09")
+	require.NoError(t, err)
+
+	report, err := reports_domain.NewSiteReport("01J9ZK3F4Q8W2X6V5N7B1M0C9D", "site-1", month, 2, "Europe/Madrid", time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, "reports/site-1/2026-09/v2/report.pdf", report.FileKey(reports_domain.PdfReport))
+	assert.Equal(t, "compliance-report-site-1-2026-09-v2.html", report.FileName(reports_domain.HtmlReport))
+
+	_, err = reports_domain.NewSiteReport("01J9ZK3F4Q8W2X6V5N7B1M0C9D", "site-1", month, 0, "Europe/Madrid", time.Now())
+	assert.IsType(t, &reports_domain.InvalidSiteReport{}, err)
+
+	_, err = reports_domain.NewSiteReport("01J9ZK3F4Q8W2X6V5N7B1M0C9D", "site-1", month, 1, "Mars/Olympus", time.Now())
+	assert.IsType(t, &reports_domain.InvalidSiteReport{}, err)
+}
+
+func TestNewZoneActivityHeatmap(t *testing.T) {
+	month, err := reports_domain.NewReportMonth("2026-09")
+	require.NoError(t, err)
+
+	heatmap := reports_domain.NewZoneActivityHeatmap(month, []reports_domain.ZoneDayActivity{
+		{ZoneID: "", Day: 2, Events: 1},
+		{ZoneID: "kitchen", Day: 1, Events: 3},
+		{ZoneID: "basement", Day: 30, Events: 6},
+		{ZoneID: "kitchen", Day: 1, Events: 1},
+		{ZoneID: "kitchen", Day: 31, Events: 9},
+	})
+
+	require.Len(t, heatmap.Zones, 3)
+	assert.Equal(t, 30, heatmap.Days)
+	assert.Equal(t, 6, heatmap.Max)
+	assert.Equal(t, "basement", heatmap.Zones[0].ZoneID)
+	assert.Equal(t, 6, heatmap.Zones[0].Events[29])
+	assert.Equal(t, "kitchen", heatmap.Zones[1].ZoneID)
+	assert.Equal(t, 4, heatmap.Zones[1].Events[0])
+	assert.Equal(t, 4, heatmap.Zones[1].Total)
+	assert.Equal(t, reports_domain.UnzonedDevices, heatmap.Zones[2].ZoneID)
+	assert.InDelta(t, 0.5, heatmap.Intensity(3), 0.001)
+}
+
+func TestSummarizeAlertResponses(t *testing.T) {
+	raisedAt := time.Date(2026, 9, 3, 10, 0, 0, 0, time.UTC)
+	acknowledgedAt := func(after time.Duration) *time.Time {
+		at := raisedAt.Add(after)
+		return &at
+	}
+
+	summary := reports_domain.SummarizeAlertResponses([]reports_domain.ReportAlert{
+		{ID: "alert-1", RaisedAt: raisedAt, AcknowledgedAt: acknowledgedAt(10 * time.Minute)},
+		{ID: "alert-2", RaisedAt: raisedAt, AcknowledgedAt: acknowledgedAt(30 * time.Minute)},
+		{ID: "alert-3", RaisedAt: raisedAt},
+	})
+
+	assert.Equal(t, reports_domain.AlertResponseSummary{
+		Alerts:          3,
+		Acknowledged:    2,
+		Unacknowledged:  1,
+		AverageResponse: 20 * time.Minute,
+		LongestResponse: 30 * time.Minute,
+	}, summary)
+	assert.Equal(t, reports_domain.AlertResponseSummary{}, reports_domain.SummarizeAlertResponses(nil))
+}
@@ -0,0 +1,68 @@
+package reports_infra
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	html_template "html/template"
+	"time"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+)
+
+//go:embed templates
+var reportTemplates embed.FS
+
+// HtmlSiteReportRenderer renders the embedded templates/site_report.html.tmpl, a self
+// contained page with its styles inline, so it can be opened straight from the storage.
+type HtmlSiteReportRenderer struct {
+	template *html_template.Template
+}
+
+func NewHtmlSiteReportRenderer() (*HtmlSiteReportRenderer, error) {
+	template, err := html_template.New("site_report.html.tmpl").
+		Funcs(reportTemplateFuncs(reportFormatting{location: time.UTC}, reports_domain.ZoneActivityHeatmap{})).
+		ParseFS(reportTemplates, "templates/site_report.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	return &HtmlSiteReportRenderer{template: template}, nil
+}
+
+func (r *HtmlSiteReportRenderer) Format() reports_domain.ReportFormat {
+	return reports_domain.HtmlReport
+}
+
+func (r *HtmlSiteReportRenderer) Render(content reports_domain.SiteReportContent) ([]byte, error) {
+	template, err := r.template.Clone()
+	if err != nil {
+		return nil, err
+	}
+	template.Funcs(reportTemplateFuncs(reportFormatting{location: content.Location()}, content.Activity))
+
+	var buffer bytes.Buffer
+	if err := template.Execute(&buffer, content); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func reportTemplateFuncs(formatting reportFormatting, heatmap reports_domain.ZoneActivityHeatmap) html_template.FuncMap {
+	return html_template.FuncMap{
+		"datetime": formatting.datetime,
+		"duration": formatting.duration,
+		"response": formatting.response,
+		"days": func(days int) []int {
+			sequence := make([]int, days)
+			for i := range sequence {
+				sequence[i] = i + 1
+			}
+			return sequence
+		},
+		"heat": func(events int) html_template.CSS {
+			return html_template.CSS(fmt.Sprintf("background-color: rgba(200, 40, 40, %.2f)", heatmap.Intensity(events)))
+		},
+	}
+}
@@ -0,0 +1,27 @@
+package reports_infra
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+)
+
+// ObjectStorageSiteReportStorage keeps the rendered reports in the object storage, which
+// signs the urls to download them.
+type ObjectStorageSiteReportStorage struct {
+	storage amf_object_storage.ObjectStorage
+}
+
+func NewObjectStorageSiteReportStorage(storage amf_object_storage.ObjectStorage) *ObjectStorageSiteReportStorage {
+	return &ObjectStorageSiteReportStorage{storage: storage}
+}
+
+func (s *ObjectStorageSiteReportStorage) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	return s.storage.Put(ctx, key, bytes.NewReader(content), int64(len(content)), contentType)
+}
+
+func (s *ObjectStorageSiteReportStorage) DownloadUrl(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.storage.PresignGet(ctx, key, expiry)
+}
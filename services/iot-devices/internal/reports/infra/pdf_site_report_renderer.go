@@ -0,0 +1,231 @@
+package reports_infra
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+)
+
+const (
+	pdfReportMargin     = 10.0
+	pdfReportLineHeight = 6.0
+	pdfHeatmapZoneWidth = 40.0
+	pdfHeatmapDayWidth  = 7.0
+)
+
+// PdfSiteReportRenderer draws the report on landscape A4 pages with the core PDF fonts, so
+// it needs no font files. Text outside of Latin-1 is approximated by the translator.
+type PdfSiteReportRenderer struct{}
+
+func NewPdfSiteReportRenderer() *PdfSiteReportRenderer {
+	return &PdfSiteReportRenderer{}
+}
+
+func (r *PdfSiteReportRenderer) Format() reports_domain.ReportFormat {
+	return reports_domain.PdfReport
+}
+
+func (r *PdfSiteReportRenderer) Render(content reports_domain.SiteReportContent) ([]byte, error) {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(pdfReportMargin, pdfReportMargin, pdfReportMargin)
+	pdf.SetAutoPageBreak(true, pdfReportMargin)
+	pdf.SetTitle(fmt.Sprintf("Compliance report %s %s", content.SiteID, content.Month), true)
+	pdf.AddPage()
+
+	document := &pdfReportDocument{
+		pdf:        pdf,
+		translate:  pdf.UnicodeTranslatorFromDescriptor(""),
+		formatting: reportFormatting{location: content.Location()},
+	}
+	document.header(content)
+	document.devices(content.Devices)
+	document.activity(content.Activity)
+	document.alerts(content.Alerts, content.AlertSummary)
+	document.serviceVisits(content.ServiceVisits)
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+type pdfReportDocument struct {
+	pdf        *gofpdf.Fpdf
+	translate  func(string) string
+	formatting reportFormatting
+}
+
+func (d *pdfReportDocument) header(content reports_domain.SiteReportContent) {
+	d.pdf.SetFont("Helvetica", "B", 18)
+	d.pdf.CellFormat(0, 10, "Monthly compliance report", "", 1, "", false, 0, "")
+	d.pdf.SetFont("Helvetica", "", 10)
+	d.pdf.SetTextColor(110, 110, 110)
+	d.text(fmt.Sprintf(
+		"Site %s - %s - version %d - generated %s (%s)",
+		content.SiteID,
+		content.Month,
+		content.Version,
+		d.formatting.datetime(content.GeneratedAt),
+		content.Timezone,
+	))
+	d.pdf.SetTextColor(0, 0, 0)
+	d.pdf.Ln(pdfReportLineHeight)
+}
+
+func (d *pdfReportDocument) devices(devices []reports_domain.ReportDevice) {
+	d.section("Device inventory")
+	if len(devices) == 0 {
+		d.text("No devices installed.")
+		return
+	}
+
+	rows := make([][]string, 0, len(devices))
+	for _, device := range devices {
+		lastSeen := "never"
+		if device.LastSeenAt != nil {
+			lastSeen = d.formatting.datetime(device.LastSeenAt)
+		}
+		rows = append(rows, []string{device.ID, device.Model, device.ZoneID, d.formatting.datetime(device.InstalledAt), lastSeen})
+	}
+	d.table([]string{"Device", "Model", "Zone", "Installed", "Last seen"}, []float64{70, 50, 50, 50, 50}, rows)
+}
+
+// activity shades every cell against the busiest one, darker the more events.
+func (d *pdfReportDocument) activity(heatmap reports_domain.ZoneActivityHeatmap) {
+	d.section("Activity per zone")
+	if len(heatmap.Zones) == 0 {
+		d.text("No pest activity recorded.")
+		return
+	}
+
+	dayWidth := min(pdfHeatmapDayWidth, (d.pageWidth()-pdfHeatmapZoneWidth)/float64(heatmap.Days+1))
+	d.pdf.SetFont("Helvetica", "B", 7)
+	d.pdf.CellFormat(pdfHeatmapZoneWidth, pdfReportLineHeight, "Zone", "1", 0, "", false, 0, "")
+	for day := 1; day <= heatmap.Days; day++ {
+		d.pdf.CellFormat(dayWidth, pdfReportLineHeight, strconv.Itoa(day), "1", 0, "C", false, 0, "")
+	}
+	d.pdf.CellFormat(dayWidth, pdfReportLineHeight, "Tot", "1", 1, "C", false, 0, "")
+
+	d.pdf.SetFont("Helvetica", "", 7)
+	for _, zone := range heatmap.Zones {
+		d.pdf.CellFormat(pdfHeatmapZoneWidth, pdfReportLineHeight, d.translate(zone.ZoneID), "1", 0, "", false, 0, "")
+		for _, events := range zone.Events {
+			shade := 255 - int(heatmap.Intensity(events)*200)
+			d.pdf.SetFillColor(255, shade, shade)
+			label := ""
+			if events > 0 {
+				label = strconv.Itoa(events)
+			}
+			d.pdf.CellFormat(dayWidth, pdfReportLineHeight, label, "1", 0, "C", true, 0, "")
+		}
+		d.pdf.CellFormat(dayWidth, pdfReportLineHeight, strconv.Itoa(zone.Total), "1", 1, "C", false, 0, "")
+	}
+	d.pdf.Ln(pdfReportLineHeight)
+}
+
+func (d *pdfReportDocument) alerts(alerts []reports_domain.ReportAlert, summary reports_domain.AlertResponseSummary) {
+	d.section("Alerts")
+	line := fmt.Sprintf(
+		"%d alerts, %d acknowledged, %d not acknowledged.",
+		summary.Alerts,
+		summary.Acknowledged,
+		summary.Unacknowledged,
+	)
+	if summary.Acknowledged > 0 {
+		line += fmt.Sprintf(
+			" Average response time %s, longest %s.",
+			d.formatting.duration(summary.AverageResponse),
+			d.formatting.duration(summary.LongestResponse),
+		)
+	}
+	d.text(line)
+	if len(alerts) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(alerts))
+	for _, alert := range alerts {
+		rows = append(rows, []string{
+			alert.RuleName,
+			alert.DeviceID,
+			alert.Status,
+			d.formatting.datetime(alert.RaisedAt),
+			d.formatting.datetime(alert.AcknowledgedAt),
+			d.formatting.response(alert),
+		})
+	}
+	d.table([]string{"Rule", "Device", "Status", "Raised", "Acknowledged", "Response time"}, []float64{70, 50, 30, 40, 40, 30}, rows)
+}
+
+func (d *pdfReportDocument) serviceVisits(visits []reports_domain.ReportServiceVisit) {
+	d.section("Service visits")
+	if len(visits) == 0 {
+		d.text("No service visits recorded.")
+		return
+	}
+
+	rows := make([][]string, 0, len(visits))
+	for _, visit := range visits {
+		rows = append(rows, []string{
+			visit.Technician,
+			d.formatting.datetime(visit.StartedAt),
+			d.formatting.datetime(visit.EndedAt),
+			d.formatting.duration(visit.Duration()),
+			visit.Notes,
+		})
+	}
+	d.table([]string{"Technician", "Started", "Ended", "Duration", "Notes"}, []float64{50, 40, 40, 25, 122}, rows)
+}
+
+func (d *pdfReportDocument) section(title string) {
+	d.pdf.SetFont("Helvetica", "B", 13)
+	d.pdf.CellFormat(0, 9, title, "", 1, "", false, 0, "")
+	d.pdf.SetFont("Helvetica", "", 10)
+}
+
+func (d *pdfReportDocument) text(line string) {
+	d.pdf.MultiCell(0, pdfReportLineHeight, d.translate(line), "", "", false)
+}
+
+// table cuts the values too long for their column, the notes of the visits included.
+func (d *pdfReportDocument) table(headers []string, widths []float64, rows [][]string) {
+	d.pdf.SetFont("Helvetica", "B", 9)
+	for i, header := range headers {
+		d.pdf.CellFormat(widths[i], pdfReportLineHeight, header, "1", 0, "", false, 0, "")
+	}
+	d.pdf.Ln(-1)
+
+	d.pdf.SetFont("Helvetica", "", 9)
+	for _, row := range rows {
+		for i, value := range row {
+			d.pdf.CellFormat(widths[i], pdfReportLineHeight, d.fit(d.translate(value), widths[i]), "1", 0, "", false, 0, "")
+		}
+		d.pdf.Ln(-1)
+	}
+	d.pdf.Ln(pdfReportLineHeight)
+}
+
+func (d *pdfReportDocument) fit(value string, width float64) string {
+	available := width - 2*d.pdf.GetCellMargin()
+	if d.pdf.GetStringWidth(value) <= available {
+		return value
+	}
+
+	for len(value) > 0 && d.pdf.GetStringWidth(value+"...") > available {
+		value = value[:len(value)-1]
+	}
+
+	return value + "..."
+}
+
+func (d *pdfReportDocument) pageWidth() float64 {
+	width, _ := d.pdf.GetPageSize()
+
+	return width - 2*pdfReportMargin
+}
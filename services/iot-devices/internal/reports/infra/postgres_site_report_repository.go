@@ -0,0 +1,146 @@
+package reports_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	siteReportColumns = `id, site_id, month, version, timezone, generated_at`
+
+	insertSiteReportQuery = `
+INSERT INTO site_reports (` + siteReportColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6)`
+	findSiteReportQuery = `
+SELECT ` + siteReportColumns + ` FROM site_reports
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR site_id IN (SELECT id FROM sites WHERE tenant_id = $2))`
+	searchSiteReportsBySiteQuery = `
+SELECT ` + siteReportColumns + ` FROM site_reports
+WHERE site_id = $1 AND ($2 = '' OR month = $2)
+  AND ($3::VARCHAR IS NULL OR site_id IN (SELECT id FROM sites WHERE tenant_id = $3))
+ORDER BY month DESC, version DESC`
+	findLatestSiteReportVersionQuery = `
+SELECT COALESCE(MAX(version), 0) FROM site_reports
+WHERE site_id = $1 AND month = $2
+  AND ($3::VARCHAR IS NULL OR site_id IN (SELECT id FROM sites WHERE tenant_id = $3))`
+)
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// PostgresSiteReportRepository never updates a report, every generation inserts a version.
+type PostgresSiteReportRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresSiteReportRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresSiteReportRepository {
+	return &PostgresSiteReportRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresSiteReportRepository) Save(ctx context.Context, report reports_domain.SiteReport) error {
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		insertSiteReportQuery,
+		report.ID,
+		report.SiteID,
+		report.Month.String(),
+		report.Version,
+		report.Timezone,
+		report.GeneratedAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresSiteReportRepository) Find(ctx context.Context, id string) (*reports_domain.SiteReport, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := scanSiteReport(r.connectionPool.Reader().QueryRowContext(ctx, findSiteReportQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+func (r *PostgresSiteReportRepository) SearchBySite(
+	ctx context.Context,
+	siteID string,
+	month *reports_domain.ReportMonth,
+) ([]reports_domain.SiteReport, error) {
+	monthFilter := ""
+	if month != nil {
+		monthFilter = month.String()
+	}
+
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchSiteReportsBySiteQuery, siteID, monthFilter, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	reports := make([]reports_domain.SiteReport, 0)
+	for rows.Next() {
+		report, err := scanSiteReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// LatestVersion reads from the writer, as it numbers the version about to be saved.
+func (r *PostgresSiteReportRepository) LatestVersion(
+	ctx context.Context,
+	siteID string,
+	month reports_domain.ReportMonth,
+) (int, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int
+	err = r.connectionPool.Writer().QueryRowContext(ctx, findLatestSiteReportVersionQuery, siteID, month.String(), scope).Scan(&version)
+
+	return version, err
+}
+
+func scanSiteReport(row rowScanner) (reports_domain.SiteReport, error) {
+	var (
+		report reports_domain.SiteReport
+		month  string
+	)
+
+	err := row.Scan(&report.ID, &report.SiteID, &month, &report.Version, &report.Timezone, &report.GeneratedAt)
+	if err != nil {
+		return reports_domain.SiteReport{}, err
+	}
+
+	report.Month, err = reports_domain.NewReportMonth(month)
+	if err != nil {
+		return reports_domain.SiteReport{}, err
+	}
+
+	return report, nil
+}
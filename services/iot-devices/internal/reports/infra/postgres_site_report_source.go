@@ -0,0 +1,201 @@
+package reports_infra
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	searchReportSitesQuery = `
+SELECT DISTINCT site_id FROM spcd_iot_devices
+WHERE site_id IS NOT NULL AND site_id <> '' AND ($1::VARCHAR IS NULL OR tenant_id = $1)
+ORDER BY site_id`
+
+	searchReportDevicesQuery = `
+SELECT id, COALESCE(model, ''), COALESCE(zone_id, ''), created_at
+FROM spcd_iot_devices
+WHERE site_id = $1 AND (created_at IS NULL OR created_at < $2::TIMESTAMPTZ AT TIME ZONE 'UTC')
+  AND ($3::VARCHAR IS NULL OR tenant_id = $3)
+ORDER BY zone_id NULLS LAST, id`
+
+	// Pest events are the readings with a pest metric above zero, the trap resets left out
+	searchReportZoneActivityQuery = `
+SELECT COALESCE(d.zone_id, ''), EXTRACT(DAY FROM r.recorded_at AT TIME ZONE $4)::INTEGER, COUNT(*)
+FROM telemetry_readings r
+JOIN spcd_iot_devices d ON d.id = r.device_id
+WHERE d.site_id = $1 AND r.recorded_at >= $2 AND r.recorded_at < $3
+    AND EXISTS (SELECT 1 FROM jsonb_each_text(r.metrics) m WHERE m.key = ANY($5) AND m.value::DOUBLE PRECISION > 0)
+    AND ($6::VARCHAR IS NULL OR d.tenant_id = $6)
+GROUP BY 1, 2`
+
+	searchReportAlertsQuery = `
+SELECT a.id, a.rule_name, a.device_id, a.status, a.value, a.raised_at, a.acknowledged_at, a.resolved_at
+FROM alerts a
+JOIN spcd_iot_devices d ON d.id = a.device_id
+WHERE d.site_id = $1 AND a.raised_at >= $2 AND a.raised_at < $3
+  AND ($4::VARCHAR IS NULL OR d.tenant_id = $4)
+ORDER BY a.raised_at, a.id`
+)
+
+type PostgresSiteReportSource struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresSiteReportSource(connectionPool amf_sqldb.ConnectionPool) *PostgresSiteReportSource {
+	return &PostgresSiteReportSource{connectionPool: connectionPool}
+}
+
+func (s *PostgresSiteReportSource) Sites(ctx context.Context) ([]string, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.connectionPool.Reader().QueryContext(ctx, searchReportSitesQuery, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	sites := make([]string, 0)
+	for rows.Next() {
+		var siteID string
+		if err := rows.Scan(&siteID); err != nil {
+			return nil, err
+		}
+		sites = append(sites, siteID)
+	}
+
+	return sites, rows.Err()
+}
+
+func (s *PostgresSiteReportSource) Devices(ctx context.Context, siteID string, to time.Time) ([]reports_domain.ReportDevice, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.connectionPool.Reader().QueryContext(ctx, searchReportDevicesQuery, siteID, to.UTC(), scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	devices := make([]reports_domain.ReportDevice, 0)
+	for rows.Next() {
+		var (
+			device      reports_domain.ReportDevice
+			installedAt sql.NullTime
+		)
+		if err := rows.Scan(&device.ID, &device.Model, &device.ZoneID, &installedAt); err != nil {
+			return nil, err
+		}
+		// created_at has no time zone, and is written in UTC
+		if installedAt.Valid {
+			device.InstalledAt = installedAt.Time.UTC()
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+func (s *PostgresSiteReportSource) ZoneActivity(
+	ctx context.Context,
+	siteID string,
+	from time.Time,
+	to time.Time,
+	location *time.Location,
+) ([]reports_domain.ZoneDayActivity, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.connectionPool.Reader().QueryContext(
+		ctx,
+		searchReportZoneActivityQuery,
+		siteID,
+		from.UTC(),
+		to.UTC(),
+		location.String(),
+		pq.Array(pestcontrol_domain.PestMetrics),
+		scope,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	activity := make([]reports_domain.ZoneDayActivity, 0)
+	for rows.Next() {
+		var dayActivity reports_domain.ZoneDayActivity
+		if err := rows.Scan(&dayActivity.ZoneID, &dayActivity.Day, &dayActivity.Events); err != nil {
+			return nil, err
+		}
+		activity = append(activity, dayActivity)
+	}
+
+	return activity, rows.Err()
+}
+
+func (s *PostgresSiteReportSource) Alerts(
+	ctx context.Context,
+	siteID string,
+	from time.Time,
+	to time.Time,
+) ([]reports_domain.ReportAlert, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.connectionPool.Reader().QueryContext(ctx, searchReportAlertsQuery, siteID, from.UTC(), to.UTC(), scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	alerts := make([]reports_domain.ReportAlert, 0)
+	for rows.Next() {
+		var (
+			alert                      reports_domain.ReportAlert
+			acknowledgedAt, resolvedAt sql.NullTime
+		)
+		err := rows.Scan(
+			&alert.ID,
+			&alert.RuleName,
+			&alert.DeviceID,
+			&alert.Status,
+			&alert.Value,
+			&alert.RaisedAt,
+			&acknowledgedAt,
+			&resolvedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		alert.AcknowledgedAt = timeFrom(acknowledgedAt)
+		alert.ResolvedAt = timeFrom(resolvedAt)
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, rows.Err()
+}
+
+func timeFrom(at sql.NullTime) *time.Time {
+	if !at.Valid {
+		return nil
+	}
+
+	return &at.Time
+}
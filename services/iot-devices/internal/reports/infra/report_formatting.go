@@ -0,0 +1,51 @@
+package reports_infra
+
+import (
+	"fmt"
+	"time"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+)
+
+const reportDateTimeLayout = "2006-01-02 15:04"
+
+// reportFormatting tells the times and durations of a report the same way in every format.
+type reportFormatting struct {
+	location *time.Location
+}
+
+// datetime takes a time or a pointer to one, which is told as empty when nil or unknown.
+func (rf reportFormatting) datetime(at interface{}) string {
+	switch at := at.(type) {
+	case time.Time:
+		if at.IsZero() {
+			return ""
+		}
+		return at.In(rf.location).Format(reportDateTimeLayout)
+	case *time.Time:
+		if at == nil {
+			return ""
+		}
+		return rf.datetime(*at)
+	}
+
+	return ""
+}
+
+func (rf reportFormatting) duration(duration time.Duration) string {
+	duration = duration.Round(time.Minute)
+	if duration < time.Hour {
+		return fmt.Sprintf("%dm", int(duration.Minutes()))
+	}
+
+	return fmt.Sprintf("%dh %02dm", int(duration.Hours()), int(duration.Minutes())%60)
+}
+
+func (rf reportFormatting) response(alert reports_domain.ReportAlert) string {
+	responseTime, ok := alert.ResponseTime()
+	if !ok {
+		return "-"
+	}
+
+	return rf.duration(responseTime)
+}
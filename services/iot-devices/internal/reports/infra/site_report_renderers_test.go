@@ -0,0 +1,82 @@
+package reports_infra_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+	reports_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/infra"
+)
+
+func siteReportContent(t *testing.T) reports_domain.SiteReportContent {
+	month, err := reports_domain.NewReportMonth("2026-09")
+	require.NoError(t, err)
+
+	raisedAt := time.Date(2026, 9, 3, 8, 0, 0, 0, time.UTC)
+	acknowledgedAt := raisedAt.Add(95 * time.Minute)
+	alerts := []reports_domain.ReportAlert{
+		{ID: "alert-1", RuleName: "Trap <triggered>", DeviceID: "trap-1", Status: "resolved", RaisedAt: raisedAt, AcknowledgedAt: &acknowledgedAt},
+		{ID: "alert-2", RuleName: "Bait consumed", DeviceID: "bait-1", Status: "open", RaisedAt: raisedAt},
+	}
+
+	return reports_domain.SiteReportContent{
+		SiteID:      "site-1",
+		Month:       month,
+		Version:     2,
+		Timezone:    "Europe/Madrid",
+		GeneratedAt: time.Date(2026, 10, 1, 1, 0, 0, 0, time.UTC),
+		Devices: []reports_domain.ReportDevice{
+			{ID: "trap-1", Model: "snap-trap", ZoneID: "kitchen", InstalledAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), LastSeenAt: &raisedAt},
+			{ID: "bait-1", Model: "bait-station", InstalledAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Activity: reports_domain.NewZoneActivityHeatmap(month, []reports_domain.ZoneDayActivity{
+			{ZoneID: "kitchen", Day: 3, Events: 5},
+			{ZoneID: "", Day: 4, Events: 1},
+		}),
+		Alerts:       alerts,
+		AlertSummary: reports_domain.SummarizeAlertResponses(alerts),
+		ServiceVisits: []reports_domain.ReportServiceVisit{
+			{ID: "visit-1", Technician: "Begoña", StartedAt: raisedAt, EndedAt: raisedAt.Add(2 * time.Hour), Notes: strings.Repeat("Replaced the glue boards. ", 20)},
+		},
+	}
+}
+
+func TestHtmlSiteReportRenderer(t *testing.T) {
+	renderer, err := reports_infra.NewHtmlSiteReportRenderer()
+	require.NoError(t, err)
+
+	rendered, err := renderer.Render(siteReportContent(t))
+	require.NoError(t, err)
+
+	html := string(rendered)
+	assert.Equal(t, reports_domain.HtmlReport, renderer.Format())
+	assert.Contains(t, html, "Site site-1 &middot; 2026-09 &middot; version 2")
+	assert.Contains(t, html, "<td>2026-09-03 10:00</td>", "times are told in the timezone of the site")
+	assert.Contains(t, html, "Trap &lt;triggered&gt;")
+	assert.Contains(t, html, "Average response time 1h 35m, longest 1h 35m.")
+	assert.Contains(t, html, `style="background-color: rgba(200, 40, 40, 1.00)">5</td>`)
+	assert.Contains(t, html, "<th>"+reports_domain.UnzonedDevices+"</th>")
+	assert.Contains(t, html, "<td>never</td>")
+	assert.Contains(t, html, "<td>Begoña</td>")
+	assert.Contains(t, html, "<th>30</th><th>Total</th>", "the heatmap has a column per day of the month")
+}
+
+func TestPdfSiteReportRenderer(t *testing.T) {
+	renderer := reports_infra.NewPdfSiteReportRenderer()
+
+	rendered, err := renderer.Render(siteReportContent(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, reports_domain.PdfReport, renderer.Format())
+	assert.True(t, bytes.HasPrefix(rendered, []byte("%PDF-")))
+	assert.True(t, bytes.HasSuffix(bytes.TrimSpace(rendered), []byte("%%EOF")))
+
+	empty, err := renderer.Render(reports_domain.SiteReportContent{SiteID: "site-2", Timezone: "UTC"})
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(empty, []byte("%PDF-")))
+}
@@ -0,0 +1,66 @@
+package streaming_application
+
+import (
+	"context"
+
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// StreamEventRecorder logs every streamed event and broadcasts it to the replicas, once
+// completed with the tenant, the site and the model of its device, which dashboards
+// filter by.
+// Backfilled readings tell nothing live, so they are left out.
+type StreamEventRecorder struct {
+	directory    connectivity_domain.DeviceDirectory
+	log          streaming_domain.StreamEventLog
+	broadcaster  streaming_domain.StreamEventBroadcaster
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewStreamEventRecorder(
+	directory connectivity_domain.DeviceDirectory,
+	log streaming_domain.StreamEventLog,
+	broadcaster streaming_domain.StreamEventBroadcaster,
+	timeProvider amf_utils.DateTimeProvider,
+) *StreamEventRecorder {
+	return &StreamEventRecorder{directory: directory, log: log, broadcaster: broadcaster, timeProvider: timeProvider}
+}
+
+func (r *StreamEventRecorder) Handle(event amf_bus.Event) error {
+	ctx := amf_tenancy.WithAllTenants(context.Background())
+	data := event.Data()
+	if backfilled, _ := data["backfilled"].(bool); backfilled {
+		return nil
+	}
+
+	streamEvent := streaming_domain.StreamEvent{Name: event.Name(), OccurredAt: r.timeProvider.Now(), Data: data}
+	streamEvent.TenantID, _ = data["tenant_id"].(string)
+	streamEvent.DeviceID, _ = data["device_id"].(string)
+	streamEvent.SiteID, _ = data["site_id"].(string)
+	streamEvent.DeviceType, _ = data["model"].(string)
+
+	if streamEvent.DeviceID != "" && (streamEvent.TenantID == "" || streamEvent.SiteID == "" || streamEvent.DeviceType == "") {
+		profile, err := r.directory.Find(ctx, streamEvent.DeviceID)
+		if err != nil {
+			return err
+		}
+		if profile != nil {
+			streamEvent.SiteID, streamEvent.DeviceType = profile.SiteID, profile.Model
+			if streamEvent.TenantID == "" {
+				streamEvent.TenantID = profile.TenantID
+			}
+		}
+	}
+
+	logged, err := r.log.Append(ctx, streamEvent)
+	if err != nil {
+		return err
+	}
+
+	return r.broadcaster.Broadcast(ctx, logged)
+}
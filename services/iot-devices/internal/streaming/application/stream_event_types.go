@@ -0,0 +1,18 @@
+package streaming_application
+
+import (
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+// StreamEventTypes are the events streamed live to the dashboards.
+var StreamEventTypes = []string{
+	telemetry_domain.ReadingIngestedEventName,
+	alerting_domain.AlertOpenedEventName,
+	alerting_domain.AlertAcknowledgedEventName,
+	alerting_domain.AlertResolvedEventName,
+	alerting_domain.AlertEscalatedEventName,
+	connectivity_domain.DeviceWentOfflineEventName,
+	connectivity_domain.DeviceCameBackOnlineEventName,
+}
@@ -0,0 +1,144 @@
+package streaming_application
+
+import (
+	"context"
+	"sync"
+
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+)
+
+// StreamHub hands the events broadcast to this replica to its connected clients. Clients
+// that fall behind are dropped rather than waited for, and resume from the log once they
+// connect again.
+type StreamHub struct {
+	log           streaming_domain.StreamEventLog
+	broadcaster   streaming_domain.StreamEventBroadcaster
+	clientBuffer  int
+	replayLimit   int
+	mutex         sync.Mutex
+	subscriptions map[*StreamSubscription]struct{}
+}
+
+func NewStreamHub(
+	log streaming_domain.StreamEventLog,
+	broadcaster streaming_domain.StreamEventBroadcaster,
+	clientBuffer int,
+	replayLimit int,
+) *StreamHub {
+	return &StreamHub{
+		log:           log,
+		broadcaster:   broadcaster,
+		clientBuffer:  clientBuffer,
+		replayLimit:   replayLimit,
+		subscriptions: map[*StreamSubscription]struct{}{},
+	}
+}
+
+// Run listens to the broadcast events until the context ends.
+func (h *StreamHub) Run(ctx context.Context) error {
+	return h.broadcaster.Listen(ctx, h.dispatch)
+}
+
+// Subscribe returns the subscription to the live events matching the filter, along with
+// the ones logged after lastEventID, if any, that the client missed. The subscription
+// starts before reading the log, so no event falls in between, and skips the events
+// already replayed.
+func (h *StreamHub) Subscribe(
+	ctx context.Context,
+	filter streaming_domain.StreamFilter,
+	lastEventID string,
+) (*StreamSubscription, []streaming_domain.StreamEvent, error) {
+	subscription := &StreamSubscription{
+		hub:      h,
+		filter:   filter,
+		events:   make(chan streaming_domain.StreamEvent, h.clientBuffer),
+		replayed: map[string]struct{}{},
+	}
+
+	h.mutex.Lock()
+	h.subscriptions[subscription] = struct{}{}
+	h.mutex.Unlock()
+
+	if lastEventID == "" {
+		return subscription, nil, nil
+	}
+
+	logged, err := h.log.After(ctx, lastEventID, h.replayLimit)
+	if err != nil {
+		subscription.Close()
+		return nil, nil, err
+	}
+
+	missed := make([]streaming_domain.StreamEvent, 0, len(logged))
+	subscription.mutex.Lock()
+	for _, event := range logged {
+		subscription.replayed[event.ID] = struct{}{}
+		if filter.Matches(event) {
+			missed = append(missed, event)
+		}
+	}
+	subscription.mutex.Unlock()
+
+	return subscription, missed, nil
+}
+
+func (h *StreamHub) dispatch(event streaming_domain.StreamEvent) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for subscription := range h.subscriptions {
+		if !subscription.filter.Matches(event) || subscription.wasReplayed(event.ID) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			h.remove(subscription)
+		}
+	}
+}
+
+// remove expects the hub to be locked.
+func (h *StreamHub) remove(subscription *StreamSubscription) {
+	if _, ok := h.subscriptions[subscription]; !ok {
+		return
+	}
+
+	delete(h.subscriptions, subscription)
+	close(subscription.events)
+}
+
+// StreamSubscription carries the live events of a client. Its channel is closed once the
+// client falls behind or the subscription is closed.
+type StreamSubscription struct {
+	hub      *StreamHub
+	filter   streaming_domain.StreamFilter
+	events   chan streaming_domain.StreamEvent
+	mutex    sync.Mutex
+	replayed map[string]struct{}
+}
+
+func (s *StreamSubscription) Events() <-chan streaming_domain.StreamEvent {
+	return s.events
+}
+
+func (s *StreamSubscription) Close() {
+	s.hub.mutex.Lock()
+	defer s.hub.mutex.Unlock()
+
+	s.hub.remove(s)
+}
+
+// wasReplayed forgets the replayed events as they come live, as each one comes only once.
+func (s *StreamSubscription) wasReplayed(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.replayed[id]; !ok {
+		return false
+	}
+	delete(s.replayed, id)
+
+	return true
+}
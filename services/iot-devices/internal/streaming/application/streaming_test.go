@@ -0,0 +1,142 @@
+package streaming_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	connectivity_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain"
+	connectivity_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/connectivity/domain/mocks"
+	streaming_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/application"
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+	streaming_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain/mocks"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestStreamEventRecorder(t *testing.T) {
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	reading, err := telemetry_domain.NewReading(
+		amf_utils.NewUlid().String(),
+		"device-1",
+		timeProvider.Now(),
+		timeProvider.Now(),
+		map[string]float64{"trap_triggered": 1},
+	)
+	require.NoError(t, err)
+
+	t.Run("should log and broadcast the events with the tenant, site and model of their device", func(t *testing.T) {
+		directory := connectivity_domain_mocks.NewDeviceDirectory(t)
+		directory.On("Find", mock.Anything, "device-1").
+			Return(&connectivity_domain.DeviceProfile{DeviceID: "device-1", TenantID: "tenant-1", SiteID: "site-1", Model: "snap_trap"}, nil).Once()
+
+		log := streaming_domain_mocks.NewStreamEventLog(t)
+		log.On("Append", mock.Anything, mock.MatchedBy(func(event streaming_domain.StreamEvent) bool {
+			return event.Name == telemetry_domain.ReadingIngestedEventName && event.TenantID == "tenant-1" && event.SiteID == "site-1" &&
+				event.DeviceType == "snap_trap" && event.OccurredAt.Equal(timeProvider.Now())
+		})).Return(func(_ context.Context, event streaming_domain.StreamEvent) (streaming_domain.StreamEvent, error) {
+			event.ID = "1-0"
+			return event, nil
+		}).Once()
+
+		broadcaster := streaming_domain_mocks.NewStreamEventBroadcaster(t)
+		broadcaster.On("Broadcast", mock.Anything, mock.MatchedBy(func(event streaming_domain.StreamEvent) bool {
+			return event.ID == "1-0"
+		})).Return(nil).Once()
+
+		recorder := streaming_application.NewStreamEventRecorder(directory, log, broadcaster, timeProvider)
+
+		assert.NoError(t, recorder.Handle(telemetry_domain.NewReadingIngested(reading)))
+	})
+
+	t.Run("should keep the tenant of the events carrying it", func(t *testing.T) {
+		directory := connectivity_domain_mocks.NewDeviceDirectory(t)
+		directory.On("Find", mock.Anything, "device-1").Return(nil, nil).Once()
+
+		log := streaming_domain_mocks.NewStreamEventLog(t)
+		log.On("Append", mock.Anything, mock.MatchedBy(func(event streaming_domain.StreamEvent) bool {
+			return event.TenantID == "tenant-1"
+		})).Return(streaming_domain.StreamEvent{ID: "1-0"}, nil).Once()
+
+		broadcaster := streaming_domain_mocks.NewStreamEventBroadcaster(t)
+		broadcaster.On("Broadcast", mock.Anything, mock.Anything).Return(nil).Once()
+
+		recorder := streaming_application.NewStreamEventRecorder(directory, log, broadcaster, timeProvider)
+		alert := alerting_domain.Alert{ID: "alert-1", TenantID: "tenant-1", DeviceID: "device-1"}
+
+		assert.NoError(t, recorder.Handle(alerting_domain.NewAlertOpened(alert)))
+	})
+
+	t.Run("should leave the backfilled readings out", func(t *testing.T) {
+		recorder := streaming_application.NewStreamEventRecorder(
+			connectivity_domain_mocks.NewDeviceDirectory(t),
+			streaming_domain_mocks.NewStreamEventLog(t),
+			streaming_domain_mocks.NewStreamEventBroadcaster(t),
+			timeProvider,
+		)
+
+		assert.NoError(t, recorder.Handle(telemetry_domain.NewReadingIngested(reading.AsBackfilled())))
+	})
+}
+
+func TestStreamHub(t *testing.T) {
+	ctx := context.Background()
+	siteEvent := func(id string, siteID string) streaming_domain.StreamEvent {
+		return streaming_domain.StreamEvent{ID: id, Name: alerting_domain.AlertOpenedEventName, SiteID: siteID}
+	}
+
+	// runHub broadcasts the events to the hub once the subscriptions are in place
+	runHub := func(t *testing.T, hub *streaming_application.StreamHub, broadcaster *streaming_domain_mocks.StreamEventBroadcaster, events ...streaming_domain.StreamEvent) {
+		broadcaster.On("Listen", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			handle := args.Get(1).(func(event streaming_domain.StreamEvent))
+			for _, event := range events {
+				handle(event)
+			}
+		}).Return(nil).Once()
+
+		require.NoError(t, hub.Run(ctx))
+	}
+
+	t.Run("should replay the missed events and skip them once they come live", func(t *testing.T) {
+		log := streaming_domain_mocks.NewStreamEventLog(t)
+		log.On("After", ctx, "1-0", 100).Return([]streaming_domain.StreamEvent{siteEvent("2-0", "site-1"), siteEvent("3-0", "site-2")}, nil).Once()
+		broadcaster := streaming_domain_mocks.NewStreamEventBroadcaster(t)
+		hub := streaming_application.NewStreamHub(log, broadcaster, 10, 100)
+
+		subscription, missed, err := hub.Subscribe(ctx, streaming_domain.StreamFilter{SiteID: "site-1"}, "1-0")
+		require.NoError(t, err)
+		assert.Equal(t, []streaming_domain.StreamEvent{siteEvent("2-0", "site-1")}, missed)
+
+		runHub(t, hub, broadcaster, siteEvent("2-0", "site-1"), siteEvent("4-0", "site-2"), siteEvent("5-0", "site-1"))
+
+		assert.Equal(t, "5-0", (<-subscription.Events()).ID)
+		assert.Empty(t, subscription.Events())
+		subscription.Close()
+	})
+
+	t.Run("should drop the clients falling behind", func(t *testing.T) {
+		broadcaster := streaming_domain_mocks.NewStreamEventBroadcaster(t)
+		hub := streaming_application.NewStreamHub(streaming_domain_mocks.NewStreamEventLog(t), broadcaster, 1, 100)
+
+		subscription, missed, err := hub.Subscribe(ctx, streaming_domain.StreamFilter{}, "")
+		require.NoError(t, err)
+		assert.Empty(t, missed)
+
+		runHub(t, hub, broadcaster, siteEvent("1-0", "site-1"), siteEvent("2-0", "site-1"))
+
+		assert.Equal(t, "1-0", (<-subscription.Events()).ID)
+		select {
+		case _, open := <-subscription.Events():
+			assert.False(t, open)
+		case <-time.After(time.Second):
+			t.Fatal("subscription not closed")
+		}
+		subscription.Close()
+	})
+}
@@ -0,0 +1,24 @@
+package streaming_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidStreamResumptionErrorMessage = "Invalid stream resumption"
+
+type InvalidStreamResumption struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (isr InvalidStreamResumption) Error() string {
+	return invalidStreamResumptionErrorMessage
+}
+
+func (isr InvalidStreamResumption) ExtraItems() map[string]interface{} {
+	return isr.items
+}
+
+func NewInvalidStreamResumption(lastEventID string) *InvalidStreamResumption {
+	return &InvalidStreamResumption{items: map[string]interface{}{"last_event_id": lastEventID}}
+}
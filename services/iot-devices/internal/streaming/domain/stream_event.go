@@ -0,0 +1,50 @@
+package streaming_domain
+
+import (
+	"context"
+	"time"
+)
+
+// StreamEvent is a domain event as the dashboards see it: who it is about, so they can
+// filter it, along with its data. ID is given by the StreamEventLog, and orders the events
+// of every replica.
+type StreamEvent struct {
+	ID         string
+	Name       string
+	TenantID   string
+	SiteID     string
+	DeviceID   string
+	DeviceType string
+	OccurredAt time.Time
+	Data       map[string]interface{}
+}
+
+// StreamFilter keeps the events of a tenant, a site or a device type. Empty fields match
+// every event.
+type StreamFilter struct {
+	TenantID   string
+	SiteID     string
+	DeviceType string
+}
+
+func (sf StreamFilter) Matches(event StreamEvent) bool {
+	return (sf.TenantID == "" || sf.TenantID == event.TenantID) &&
+		(sf.SiteID == "" || sf.SiteID == event.SiteID) &&
+		(sf.DeviceType == "" || sf.DeviceType == event.DeviceType)
+}
+
+// StreamEventLog keeps the latest events in order, so clients that lost their connection
+// resume right after the last event they got.
+type StreamEventLog interface {
+	// Append stores the event and returns it along with the id it got
+	Append(ctx context.Context, event StreamEvent) (StreamEvent, error)
+	// After returns up to limit events stored after the one with the id, oldest first
+	After(ctx context.Context, lastEventID string, limit int) ([]StreamEvent, error)
+}
+
+// StreamEventBroadcaster fans the events out to every replica.
+type StreamEventBroadcaster interface {
+	Broadcast(ctx context.Context, event StreamEvent) error
+	// Listen calls handle with every event broadcast by any replica until the context ends
+	Listen(ctx context.Context, handle func(event StreamEvent)) error
+}
@@ -0,0 +1,29 @@
+package streaming_domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+)
+
+func TestStreamFilter(t *testing.T) {
+	event := streaming_domain.StreamEvent{TenantID: "tenant-1", SiteID: "site-1", DeviceType: "snap_trap"}
+
+	for _, tc := range []struct {
+		name    string
+		filter  streaming_domain.StreamFilter
+		matches bool
+	}{
+		{name: "should match every event without filters", filter: streaming_domain.StreamFilter{}, matches: true},
+		{name: "should match the events of the tenant", filter: streaming_domain.StreamFilter{TenantID: "tenant-1"}, matches: true},
+		{name: "should match all the filters at once", filter: streaming_domain.StreamFilter{SiteID: "site-1", DeviceType: "snap_trap"}, matches: true},
+		{name: "should not match other sites", filter: streaming_domain.StreamFilter{SiteID: "site-2"}, matches: false},
+		{name: "should not match other device types", filter: streaming_domain.StreamFilter{TenantID: "tenant-1", DeviceType: "bait_station"}, matches: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.matches, tc.filter.Matches(event))
+		})
+	}
+}
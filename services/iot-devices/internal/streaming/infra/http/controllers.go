@@ -0,0 +1,233 @@
+package streaming_http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	streaming_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/application"
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+// LastEventIdHeader is sent back by the EventSource of the browsers when they reconnect.
+// Clients that cannot set headers, like the WebSocket ones, use the last_event_id query
+// parameter instead.
+const LastEventIdHeader = "Last-Event-ID"
+
+type streamEventMessage struct {
+	ID         string                 `json:"id,omitempty"`
+	Type       string                 `json:"type"`
+	TenantID   string                 `json:"tenant_id,omitempty"`
+	SiteID     string                 `json:"site_id,omitempty"`
+	DeviceID   string                 `json:"device_id,omitempty"`
+	DeviceType string                 `json:"device_type,omitempty"`
+	OccurredAt *time.Time             `json:"occurred_at,omitempty"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+}
+
+func newStreamEventMessage(event streaming_domain.StreamEvent) streamEventMessage {
+	return streamEventMessage{
+		ID:         event.ID,
+		Type:       event.Name,
+		TenantID:   event.TenantID,
+		SiteID:     event.SiteID,
+		DeviceID:   event.DeviceID,
+		DeviceType: event.DeviceType,
+		OccurredAt: &event.OccurredAt,
+		Data:       event.Data,
+	}
+}
+
+// NewStreamEventsController streams the events as Server-Sent Events, first the ones
+// missed since the Last-Event-ID and then the live ones, with a comment every heartbeat
+// interval to keep proxies from closing idle connections. Streams outlive the server
+// write timeout, so it is lifted for them.
+func NewStreamEventsController(
+	hub *streaming_application.StreamHub,
+	heartbeatInterval time.Duration,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscription, missed, ok := subscribe(w, r, hub, jarm)
+		if !ok {
+			return
+		}
+		defer subscription.Close()
+
+		responseController := http.NewResponseController(w)
+		_ = responseController.SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		for _, event := range missed {
+			if err := writeServerSentEvent(w, event); err != nil {
+				return
+			}
+		}
+		if err := responseController.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			var err error
+			select {
+			case <-r.Context().Done():
+				return
+			case event, open := <-subscription.Events():
+				if !open {
+					return
+				}
+				err = writeServerSentEvent(w, event)
+			case <-heartbeat.C:
+				_, err = fmt.Fprint(w, ": heartbeat\n\n")
+			}
+
+			if err == nil {
+				err = responseController.Flush()
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeServerSentEvent(w http.ResponseWriter, event streaming_domain.StreamEvent) error {
+	data, err := json.Marshal(newStreamEventMessage(event))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Name, data)
+
+	return err
+}
+
+// NewStreamEventsWebSocketController streams the same events as JSON messages over a
+// WebSocket, with a heartbeat message every heartbeat interval. Anything the client sends
+// is ignored, but tells when it goes away.
+func NewStreamEventsWebSocketController(
+	hub *streaming_application.StreamHub,
+	heartbeatInterval time.Duration,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscription, missed, ok := subscribe(w, r, hub, jarm)
+		if !ok {
+			return
+		}
+		defer subscription.Close()
+
+		server := websocket.Server{
+			// Origins are left to the CORS policy, as for every other route
+			Handshake: func(*websocket.Config, *http.Request) error { return nil },
+			Handler: func(conn *websocket.Conn) {
+				_ = conn.SetDeadline(time.Time{})
+
+				gone := make(chan struct{})
+				go func() {
+					defer close(gone)
+					var discarded []byte
+					for websocket.Message.Receive(conn, &discarded) == nil {
+					}
+				}()
+
+				for _, event := range missed {
+					if err := websocket.JSON.Send(conn, newStreamEventMessage(event)); err != nil {
+						return
+					}
+				}
+
+				heartbeat := time.NewTicker(heartbeatInterval)
+				defer heartbeat.Stop()
+
+				for {
+					var err error
+					select {
+					case <-gone:
+						return
+					case event, open := <-subscription.Events():
+						if !open {
+							return
+						}
+						err = websocket.JSON.Send(conn, newStreamEventMessage(event))
+					case <-heartbeat.C:
+						err = websocket.JSON.Send(conn, streamEventMessage{Type: "heartbeat"})
+					}
+
+					if err != nil {
+						return
+					}
+				}
+			},
+		}
+
+		server.ServeHTTP(w, r)
+	}
+}
+
+// subscribe reads the filters and the last event id of the request, writing the error
+// response when the subscription fails.
+func subscribe(
+	w http.ResponseWriter,
+	r *http.Request,
+	hub *streaming_application.StreamHub,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) (*streaming_application.StreamSubscription, []streaming_domain.StreamEvent, bool) {
+	params := r.URL.Query()
+	tenantID, _ := amf_tenancy.TenantFromContext(r.Context())
+	filter := streaming_domain.StreamFilter{
+		TenantID:   tenantID,
+		SiteID:     params.Get("filter[site_id]"),
+		DeviceType: params.Get("filter[device_type]"),
+	}
+
+	lastEventID := r.Header.Get(LastEventIdHeader)
+	if lastEventID == "" {
+		lastEventID = params.Get("last_event_id")
+	}
+
+	subscription, missed, err := hub.Subscribe(r.Context(), filter, lastEventID)
+	if err != nil {
+		writeStreamingError(w, r, jarm, err)
+		return nil, nil, false
+	}
+
+	return subscription, missed, true
+}
+
+func writeStreamingError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *streaming_domain.InvalidStreamResumption:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+	}
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
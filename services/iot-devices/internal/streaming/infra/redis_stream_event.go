@@ -0,0 +1,55 @@
+package streaming_infra
+
+import (
+	"encoding/json"
+	"time"
+
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+)
+
+const (
+	streamEventsKey     = "streaming:events"
+	streamEventsChannel = "streaming:events"
+)
+
+type redisStreamEvent struct {
+	ID         string                 `json:"id,omitempty"`
+	Name       string                 `json:"name"`
+	TenantID   string                 `json:"tenant_id,omitempty"`
+	SiteID     string                 `json:"site_id,omitempty"`
+	DeviceID   string                 `json:"device_id,omitempty"`
+	DeviceType string                 `json:"device_type,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+func marshalStreamEvent(event streaming_domain.StreamEvent) ([]byte, error) {
+	return json.Marshal(redisStreamEvent{
+		ID:         event.ID,
+		Name:       event.Name,
+		TenantID:   event.TenantID,
+		SiteID:     event.SiteID,
+		DeviceID:   event.DeviceID,
+		DeviceType: event.DeviceType,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Data,
+	})
+}
+
+func unmarshalStreamEvent(raw []byte) (streaming_domain.StreamEvent, error) {
+	var event redisStreamEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return streaming_domain.StreamEvent{}, err
+	}
+
+	return streaming_domain.StreamEvent{
+		ID:         event.ID,
+		Name:       event.Name,
+		TenantID:   event.TenantID,
+		SiteID:     event.SiteID,
+		DeviceID:   event.DeviceID,
+		DeviceType: event.DeviceType,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Data,
+	}, nil
+}
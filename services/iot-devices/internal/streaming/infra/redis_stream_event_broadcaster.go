@@ -0,0 +1,57 @@
+package streaming_infra
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+)
+
+// RedisStreamEventBroadcaster publishes the events on a Redis channel every replica
+// listens to. Messages published while a replica is not listening are lost to it, which
+// the StreamEventLog makes up for.
+type RedisStreamEventBroadcaster struct {
+	redisClient *redis.Client
+}
+
+func NewRedisStreamEventBroadcaster(redisClient *redis.Client) *RedisStreamEventBroadcaster {
+	return &RedisStreamEventBroadcaster{redisClient: redisClient}
+}
+
+func (b *RedisStreamEventBroadcaster) Broadcast(ctx context.Context, event streaming_domain.StreamEvent) error {
+	payload, err := marshalStreamEvent(event)
+	if err != nil {
+		return err
+	}
+
+	return b.redisClient.Publish(ctx, streamEventsChannel, payload).Err()
+}
+
+func (b *RedisStreamEventBroadcaster) Listen(ctx context.Context, handle func(event streaming_domain.StreamEvent)) error {
+	subscription := b.redisClient.Subscribe(ctx, streamEventsChannel)
+	defer subscription.Close()
+
+	// Wait for the subscription to be confirmed, so no event is missed from here on
+	if _, err := subscription.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := subscription.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			event, err := unmarshalStreamEvent([]byte(message.Payload))
+			if err != nil {
+				continue
+			}
+			handle(event)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package streaming_infra
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/redis/go-redis/v9"
+
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+)
+
+var redisStreamIdPattern = regexp.MustCompile(`^\d+-\d+$`)
+
+// RedisStreamEventLog keeps the events in a Redis stream capped around the retention,
+// whose entry ids are the ids of the events.
+type RedisStreamEventLog struct {
+	redisClient *redis.Client
+	retention   int64
+}
+
+func NewRedisStreamEventLog(redisClient *redis.Client, retention int64) *RedisStreamEventLog {
+	return &RedisStreamEventLog{redisClient: redisClient, retention: retention}
+}
+
+func (l *RedisStreamEventLog) Append(ctx context.Context, event streaming_domain.StreamEvent) (streaming_domain.StreamEvent, error) {
+	event.ID = ""
+	payload, err := marshalStreamEvent(event)
+	if err != nil {
+		return streaming_domain.StreamEvent{}, err
+	}
+
+	id, err := l.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamEventsKey,
+		MaxLen: l.retention,
+		Approx: true,
+		Values: map[string]interface{}{"event": payload},
+	}).Result()
+	if err != nil {
+		return streaming_domain.StreamEvent{}, err
+	}
+	event.ID = id
+
+	return event, nil
+}
+
+// After reads from the id itself, as not every Redis takes exclusive ranges, and skips it.
+// Ids trimmed off the stream get the oldest events kept.
+func (l *RedisStreamEventLog) After(ctx context.Context, lastEventID string, limit int) ([]streaming_domain.StreamEvent, error) {
+	if !redisStreamIdPattern.MatchString(lastEventID) {
+		return nil, streaming_domain.NewInvalidStreamResumption(lastEventID)
+	}
+
+	messages, err := l.redisClient.XRangeN(ctx, streamEventsKey, lastEventID, "+", int64(limit)+1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]streaming_domain.StreamEvent, 0, len(messages))
+	for _, message := range messages {
+		if message.ID == lastEventID {
+			continue
+		}
+
+		payload, _ := message.Values["event"].(string)
+		event, err := unmarshalStreamEvent([]byte(payload))
+		if err != nil {
+			return nil, err
+		}
+		event.ID = message.ID
+		events = append(events, event)
+	}
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
@@ -0,0 +1,152 @@
+package streaming_infra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	streaming_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/application"
+	streaming_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/domain"
+	streaming_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/streaming/infra"
+)
+
+type RedisStreamEventsTestSuite struct {
+	suite.Suite
+	miniRedis   *miniredis.Miniredis
+	redisClient *redis.Client
+	log         *streaming_infra.RedisStreamEventLog
+	ctx         context.Context
+}
+
+func (suite *RedisStreamEventsTestSuite) SetupTest() {
+	miniRedis, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.miniRedis = miniRedis
+	suite.redisClient = redis.NewClient(&redis.Options{Addr: miniRedis.Addr()})
+	suite.log = streaming_infra.NewRedisStreamEventLog(suite.redisClient, 100)
+	suite.ctx = context.Background()
+}
+
+func (suite *RedisStreamEventsTestSuite) TearDownTest() {
+	suite.miniRedis.Close()
+}
+
+func (suite *RedisStreamEventsTestSuite) TestAfterReturnsTheEventsLoggedAfterTheId() {
+	ids := make([]string, 0, 3)
+	for _, deviceID := range []string{"device-1", "device-2", "device-3"} {
+		event, err := suite.log.Append(suite.ctx, streaming_domain.StreamEvent{
+			Name:       "telemetry.reading_ingested",
+			DeviceID:   deviceID,
+			OccurredAt: time.Now(),
+			Data:       map[string]interface{}{"device_id": deviceID},
+		})
+		suite.Require().NoError(err)
+		ids = append(ids, event.ID)
+	}
+
+	events, err := suite.log.After(suite.ctx, ids[0], 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(events, 2)
+	suite.Equal(ids[1], events[0].ID)
+	suite.Equal("device-3", events[1].DeviceID)
+	suite.Equal("device-3", events[1].Data["device_id"])
+
+	events, err = suite.log.After(suite.ctx, ids[0], 1)
+	suite.Require().NoError(err)
+	suite.Len(events, 1)
+}
+
+func (suite *RedisStreamEventsTestSuite) TestAfterRejectsIdsNotFromTheLog() {
+	_, err := suite.log.After(suite.ctx, "yesterday", 10)
+
+	suite.IsType(&streaming_domain.InvalidStreamResumption{}, err)
+}
+
+func (suite *RedisStreamEventsTestSuite) TestBroadcastReachesEveryListener() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+
+	received := make(chan streaming_domain.StreamEvent, 2)
+	for i := 0; i < 2; i++ {
+		listener := streaming_infra.NewRedisStreamEventBroadcaster(redis.NewClient(&redis.Options{Addr: suite.miniRedis.Addr()}))
+		go func() {
+			_ = listener.Listen(ctx, func(event streaming_domain.StreamEvent) { received <- event })
+		}()
+	}
+	suite.Eventually(func() bool {
+		return suite.miniRedis.PubSubNumSub("streaming:events")["streaming:events"] == 2
+	}, time.Second, 10*time.Millisecond)
+
+	broadcaster := streaming_infra.NewRedisStreamEventBroadcaster(suite.redisClient)
+	suite.Require().NoError(broadcaster.Broadcast(suite.ctx, streaming_domain.StreamEvent{ID: "1-0", Name: "alerting.alert_opened"}))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-received:
+			suite.Equal("1-0", event.ID)
+		case <-time.After(time.Second):
+			suite.Fail("event not received by every listener")
+		}
+	}
+}
+
+func (suite *RedisStreamEventsTestSuite) TestHubNeverReachesTheEventsOfAnotherTenant() {
+	ctx, cancel := context.WithCancel(suite.ctx)
+	defer cancel()
+
+	broadcaster := streaming_infra.NewRedisStreamEventBroadcaster(suite.redisClient)
+	record := func(tenantID string, deviceID string) streaming_domain.StreamEvent {
+		event, err := suite.log.Append(suite.ctx, streaming_domain.StreamEvent{
+			Name:       "telemetry.reading_ingested",
+			TenantID:   tenantID,
+			SiteID:     "site-1",
+			DeviceID:   deviceID,
+			OccurredAt: time.Now(),
+		})
+		suite.Require().NoError(err)
+		suite.Require().NoError(broadcaster.Broadcast(suite.ctx, event))
+		return event
+	}
+
+	first := record("tenant-1", "device-1")
+	record("tenant-2", "device-2")
+	missed := record("tenant-1", "device-1")
+
+	hub := streaming_application.NewStreamHub(
+		suite.log,
+		streaming_infra.NewRedisStreamEventBroadcaster(redis.NewClient(&redis.Options{Addr: suite.miniRedis.Addr()})),
+		10,
+		100,
+	)
+	go func() { _ = hub.Run(ctx) }()
+	suite.Eventually(func() bool {
+		return suite.miniRedis.PubSubNumSub("streaming:events")["streaming:events"] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// The site filter of a client is the same for every tenant, only its tenant tells them apart
+	subscription, replayed, err := hub.Subscribe(ctx, streaming_domain.StreamFilter{TenantID: "tenant-1", SiteID: "site-1"}, first.ID)
+	suite.Require().NoError(err)
+	defer subscription.Close()
+	suite.Require().Len(replayed, 1)
+	suite.Equal(missed.ID, replayed[0].ID)
+
+	record("tenant-2", "device-2")
+	live := record("tenant-1", "device-1")
+
+	select {
+	case event := <-subscription.Events():
+		suite.Equal(live.ID, event.ID)
+		suite.Equal("tenant-1", event.TenantID)
+	case <-time.After(time.Second):
+		suite.Fail("event of the tenant not received")
+	}
+	suite.Empty(subscription.Events())
+}
+
+func TestRedisStreamEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisStreamEventsTestSuite))
+}
@@ -0,0 +1,17 @@
+package telemetry_application
+
+import "time"
+
+const ArchiveUplinkFrameCommandName = "ArchiveUplinkFrameCommand"
+
+type ArchiveUplinkFrameCommand struct {
+	ID         string
+	DeviceID   string
+	ReceivedAt time.Time
+	Payload    []byte
+	Metadata   map[string]string
+}
+
+func (c ArchiveUplinkFrameCommand) Type() string {
+	return ArchiveUplinkFrameCommandName
+}
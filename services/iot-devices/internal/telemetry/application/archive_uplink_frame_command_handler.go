@@ -0,0 +1,31 @@
+package telemetry_application
+
+import (
+	"context"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type ArchiveUplinkFrameCommandHandler struct {
+	archive telemetry_domain.UplinkArchive
+}
+
+func NewArchiveUplinkFrameCommandHandler(archive telemetry_domain.UplinkArchive) *ArchiveUplinkFrameCommandHandler {
+	return &ArchiveUplinkFrameCommandHandler{archive: archive}
+}
+
+func (h ArchiveUplinkFrameCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*ArchiveUplinkFrameCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	frame, err := telemetry_domain.NewUplinkFrame(cmd.ID, cmd.DeviceID, cmd.ReceivedAt, cmd.Payload, cmd.Metadata)
+	if err != nil {
+		return err
+	}
+
+	return h.archive.Save(ctx, frame)
+}
@@ -0,0 +1,32 @@
+package telemetry_application
+
+import (
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+type DeviceClockResponse struct {
+	DeviceID    string `jsonapi:"primary,device_clocks"`
+	OffsetMs    int64  `jsonapi:"attr,offset_ms"`
+	RoundTripMs int64  `jsonapi:"attr,round_trip_ms"`
+	Samples     int64  `jsonapi:"attr,samples"`
+	Drifting    bool   `jsonapi:"attr,drifting"`
+	EstimatedAt string `jsonapi:"attr,estimated_at,omitempty"`
+}
+
+func NewDeviceClockResponse(clock telemetry_domain.DeviceClock) *DeviceClockResponse {
+	response := &DeviceClockResponse{
+		DeviceID:    clock.DeviceID,
+		OffsetMs:    clock.Offset.Milliseconds(),
+		RoundTripMs: clock.RoundTrip.Milliseconds(),
+		Samples:     clock.Samples,
+		Drifting:    clock.Drifting,
+	}
+
+	if !clock.EstimatedAt.IsZero() {
+		response.EstimatedAt = clock.EstimatedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
@@ -0,0 +1,11 @@
+package telemetry_application
+
+const FindDeviceClockQueryName = "FindDeviceClockQuery"
+
+type FindDeviceClockQuery struct {
+	DeviceID string
+}
+
+func (q FindDeviceClockQuery) Type() string {
+	return FindDeviceClockQueryName
+}
@@ -0,0 +1,35 @@
+package telemetry_application
+
+import (
+	"context"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindDeviceClockQueryHandler struct {
+	repository telemetry_domain.DeviceClockRepository
+}
+
+func NewFindDeviceClockQueryHandler(repository telemetry_domain.DeviceClockRepository) *FindDeviceClockQueryHandler {
+	return &FindDeviceClockQueryHandler{repository: repository}
+}
+
+// Handle answers a device that never synced its clock with no offset at all.
+func (h FindDeviceClockQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindDeviceClockQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	clock, err := h.repository.Find(ctx, q.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	if clock == nil {
+		return NewDeviceClockResponse(telemetry_domain.DeviceClock{DeviceID: q.DeviceID}), nil
+	}
+
+	return NewDeviceClockResponse(*clock), nil
+}
@@ -0,0 +1,11 @@
+package telemetry_application
+
+const FindUplinkBacklogQueryName = "FindUplinkBacklogQuery"
+
+type FindUplinkBacklogQuery struct {
+	DeviceID string
+}
+
+func (q FindUplinkBacklogQuery) Type() string {
+	return FindUplinkBacklogQueryName
+}
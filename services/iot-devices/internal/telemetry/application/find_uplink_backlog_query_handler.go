@@ -0,0 +1,31 @@
+package telemetry_application
+
+import (
+	"context"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindUplinkBacklogQueryHandler struct {
+	repository telemetry_domain.UplinkBacklogRepository
+}
+
+func NewFindUplinkBacklogQueryHandler(repository telemetry_domain.UplinkBacklogRepository) *FindUplinkBacklogQueryHandler {
+	return &FindUplinkBacklogQueryHandler{repository: repository}
+}
+
+func (h FindUplinkBacklogQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindUplinkBacklogQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	backlog, err := h.repository.Find(ctx, q.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUplinkBacklogResponse(backlog), nil
+}
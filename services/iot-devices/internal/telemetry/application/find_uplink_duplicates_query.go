@@ -0,0 +1,11 @@
+package telemetry_application
+
+const FindUplinkDuplicatesQueryName = "FindUplinkDuplicatesQuery"
+
+type FindUplinkDuplicatesQuery struct {
+	DeviceID string
+}
+
+func (q FindUplinkDuplicatesQuery) Type() string {
+	return FindUplinkDuplicatesQueryName
+}
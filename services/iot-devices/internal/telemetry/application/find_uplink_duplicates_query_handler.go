@@ -0,0 +1,31 @@
+package telemetry_application
+
+import (
+	"context"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindUplinkDuplicatesQueryHandler struct {
+	registry telemetry_domain.UplinkSequenceRegistry
+}
+
+func NewFindUplinkDuplicatesQueryHandler(registry telemetry_domain.UplinkSequenceRegistry) *FindUplinkDuplicatesQueryHandler {
+	return &FindUplinkDuplicatesQueryHandler{registry: registry}
+}
+
+func (h FindUplinkDuplicatesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindUplinkDuplicatesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	duplicates, err := h.registry.Duplicates(ctx, q.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUplinkDuplicatesResponse(duplicates), nil
+}
@@ -0,0 +1,33 @@
+package telemetry_application
+
+import (
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+)
+
+const IngestBacklogCommandName = "IngestBacklogCommand"
+
+type BacklogReading struct {
+	ID         string
+	RecordedAt time.Time
+	Metrics    map[string]float64
+	PestEvents []pestcontrol_domain.PestEvent
+}
+
+// IngestBacklogCommand carries a batch of the readings a device buffered while offline,
+// in sequence order, and how many it still has buffered.
+type IngestBacklogCommand struct {
+	DeviceID  string
+	Remaining int64
+	Readings  []BacklogReading
+}
+
+func (c IngestBacklogCommand) Type() string {
+	return IngestBacklogCommandName
+}
+
+// BlockingKey serializes the batches of a device, as each one updates its backlog.
+func (c IngestBacklogCommand) BlockingKey() string {
+	return "uplink_backlog:" + c.DeviceID
+}
@@ -0,0 +1,101 @@
+package telemetry_application
+
+import (
+	"context"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type IngestBacklogCommandHandler struct {
+	repository        telemetry_domain.ReadingRepository
+	clocks            telemetry_domain.DeviceClockRepository
+	backlogRepository telemetry_domain.UplinkBacklogRepository
+	eventBus          amf_event_bus.Bus
+	timeProvider      amf_utils.DateTimeProvider
+}
+
+func NewIngestBacklogCommandHandler(
+	repository telemetry_domain.ReadingRepository,
+	clocks telemetry_domain.DeviceClockRepository,
+	backlogRepository telemetry_domain.UplinkBacklogRepository,
+	eventBus amf_event_bus.Bus,
+	timeProvider amf_utils.DateTimeProvider,
+) *IngestBacklogCommandHandler {
+	return &IngestBacklogCommandHandler{
+		repository:        repository,
+		clocks:            clocks,
+		backlogRepository: backlogRepository,
+		eventBus:          eventBus,
+		timeProvider:      timeProvider,
+	}
+}
+
+// Handle saves the readings, all of them backfilled and corrected with the offset of the
+// device clock along with their pest events, in a single batch, so a batch is either
+// rejected or fully stored. Only the readings not stored before are announced, and the
+// period they cover published, for its rollups to be rebuilt. Like the backfilled
+// uplinks, the pest events raise no alerts.
+func (h IngestBacklogCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*IngestBacklogCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+	if len(cmd.Readings) == 0 {
+		return telemetry_domain.NewInvalidReading(cmd.DeviceID, "readings")
+	}
+
+	clockOffset, err := clockOffsetOf(ctx, h.clocks, cmd.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	now := h.timeProvider.Now()
+	readings := make([]telemetry_domain.Reading, 0, len(cmd.Readings))
+	for _, backlogReading := range cmd.Readings {
+		reading, err := telemetry_domain.NewReading(backlogReading.ID, cmd.DeviceID, backlogReading.RecordedAt, now, backlogReading.Metrics)
+		if err != nil {
+			return err
+		}
+		readings = append(readings, reading.WithPestEvents(backlogReading.PestEvents).CorrectedBy(clockOffset).AsBackfilled())
+	}
+
+	inserted, err := h.repository.SaveAll(ctx, readings)
+	if err != nil {
+		return err
+	}
+
+	for _, reading := range inserted {
+		h.eventBus.Publish(telemetry_domain.NewReadingIngested(reading))
+	}
+	if len(inserted) > 0 {
+		from, to := recordedBetween(inserted)
+		h.eventBus.Publish(telemetry_domain.NewReadingsBackfilled(cmd.DeviceID, from, to, len(inserted)))
+	}
+
+	backlog, err := h.backlogRepository.Find(ctx, cmd.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	_, newest := recordedBetween(readings)
+	return h.backlogRepository.Save(ctx, backlog.Flushed(len(inserted), cmd.Remaining, newest, now))
+}
+
+func recordedBetween(readings []telemetry_domain.Reading) (time.Time, time.Time) {
+	from, to := readings[0].RecordedAt, readings[0].RecordedAt
+	for _, reading := range readings {
+		if reading.RecordedAt.Before(from) {
+			from = reading.RecordedAt
+		}
+		if reading.RecordedAt.After(to) {
+			to = reading.RecordedAt
+		}
+	}
+
+	return from, to
+}
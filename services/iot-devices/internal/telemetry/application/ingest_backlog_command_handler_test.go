@@ -0,0 +1,189 @@
+package telemetry_application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain/mocks"
+
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestIngestBacklogCommandHandler(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+	command := &telemetry_application.IngestBacklogCommand{
+		DeviceID:  "device-1",
+		Remaining: 40,
+		Readings: []telemetry_application.BacklogReading{
+			{ID: amf_utils.NewUlid().String(), RecordedAt: now.Add(-3 * time.Hour), Metrics: map[string]float64{"battery_mv": 2900}},
+			{ID: amf_utils.NewUlid().String(), RecordedAt: now.Add(-2 * time.Hour), Metrics: map[string]float64{"battery_mv": 2890}},
+		},
+	}
+
+	t.Run("should save the readings as backfilled and track the backlog", func(t *testing.T) {
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		backlogs := telemetry_domain_mocks.NewUplinkBacklogRepository(t)
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(nil, nil).Once()
+		saved := []string{}
+		repository.On("SaveAll", ctx, mock.Anything).Return(
+			func(_ context.Context, readings []telemetry_domain.Reading) ([]telemetry_domain.Reading, error) {
+				for _, reading := range readings {
+					assert.True(t, reading.Backfilled)
+					saved = append(saved, reading.ID)
+				}
+				return readings, nil
+			},
+		).Once()
+		backlogs.On("Find", ctx, "device-1").Return(telemetry_domain.UplinkBacklog{DeviceID: "device-1", Backfilled: 10}, nil).Once()
+		backlogs.On("Save", ctx, telemetry_domain.UplinkBacklog{
+			DeviceID:   "device-1",
+			Remaining:  40,
+			Lag:        2 * time.Hour,
+			Backfilled: 12,
+			UpdatedAt:  now,
+		}).Return(nil).Once()
+
+		eventBus, backfilled := amf_event_bus.NewEventBus(), make(eventRecorder, 1)
+		eventBus.Subscribe(telemetry_domain.ReadingsBackfilledEventName, backfilled)
+
+		handler := telemetry_application.NewIngestBacklogCommandHandler(repository, clocks, backlogs, eventBus, timeProvider)
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, []string{command.Readings[0].ID, command.Readings[1].ID}, saved)
+		event := <-backfilled
+		assert.Equal(t, now.Add(-3*time.Hour), event.Data()["from"])
+		assert.Equal(t, now.Add(-2*time.Hour), event.Data()["to"])
+		assert.Equal(t, 2, event.Data()["readings"])
+	})
+
+	t.Run("should store nothing when a reading is invalid", func(t *testing.T) {
+		invalid := *command
+		invalid.Readings = append([]telemetry_application.BacklogReading{}, command.Readings...)
+		invalid.Readings[1].Metrics = nil
+
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(nil, nil).Maybe()
+
+		handler := telemetry_application.NewIngestBacklogCommandHandler(
+			telemetry_domain_mocks.NewReadingRepository(t),
+			clocks,
+			telemetry_domain_mocks.NewUplinkBacklogRepository(t),
+			amf_event_bus.NewEventBus(),
+			timeProvider,
+		)
+
+		assert.IsType(t, &telemetry_domain.InvalidReading{}, handler.Handle(ctx, &invalid))
+	})
+
+	t.Run("should neither announce nor track a batch failing to be stored", func(t *testing.T) {
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(nil, nil).Once()
+		repository.On("SaveAll", ctx, mock.Anything).Return(nil, errors.New("connection reset")).Once()
+
+		eventBus, ingested := amf_event_bus.NewEventBus(), make(eventRecorder, 2)
+		eventBus.Subscribe(telemetry_domain.ReadingIngestedEventName, ingested)
+
+		handler := telemetry_application.NewIngestBacklogCommandHandler(
+			repository,
+			clocks,
+			telemetry_domain_mocks.NewUplinkBacklogRepository(t),
+			eventBus,
+			timeProvider,
+		)
+
+		assert.Error(t, handler.Handle(ctx, command))
+		assert.Len(t, ingested, 0)
+	})
+	t.Run("should announce only the readings not stored before", func(t *testing.T) {
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		backlogs := telemetry_domain_mocks.NewUplinkBacklogRepository(t)
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(nil, nil).Once()
+		repository.On("SaveAll", ctx, mock.Anything).Return(
+			func(_ context.Context, readings []telemetry_domain.Reading) ([]telemetry_domain.Reading, error) {
+				return readings[1:], nil
+			},
+		).Once()
+		backlogs.On("Find", ctx, "device-1").Return(telemetry_domain.UplinkBacklog{DeviceID: "device-1", Backfilled: 10}, nil).Once()
+		backlogs.On("Save", ctx, mock.MatchedBy(func(backlog telemetry_domain.UplinkBacklog) bool {
+			return backlog.Backfilled == 11 && backlog.Lag == 2*time.Hour
+		})).Return(nil).Once()
+
+		eventBus, ingested, backfilled := amf_event_bus.NewEventBus(), make(eventRecorder, 2), make(eventRecorder, 1)
+		eventBus.Subscribe(telemetry_domain.ReadingIngestedEventName, ingested)
+		eventBus.Subscribe(telemetry_domain.ReadingsBackfilledEventName, backfilled)
+
+		handler := telemetry_application.NewIngestBacklogCommandHandler(repository, clocks, backlogs, eventBus, timeProvider)
+		require.NoError(t, handler.Handle(ctx, command))
+
+		event := <-ingested
+		assert.Equal(t, command.Readings[1].ID, event.Data()["id"])
+		assert.Len(t, ingested, 0)
+		event = <-backfilled
+		assert.Equal(t, now.Add(-2*time.Hour), event.Data()["from"])
+		assert.Equal(t, 1, event.Data()["readings"])
+	})
+
+	t.Run("should announce nothing when every reading was stored before", func(t *testing.T) {
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		backlogs := telemetry_domain_mocks.NewUplinkBacklogRepository(t)
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(nil, nil).Once()
+		repository.On("SaveAll", ctx, mock.Anything).Return([]telemetry_domain.Reading{}, nil).Once()
+		backlogs.On("Find", ctx, "device-1").Return(telemetry_domain.UplinkBacklog{DeviceID: "device-1", Backfilled: 10}, nil).Once()
+		backlogs.On("Save", ctx, mock.MatchedBy(func(backlog telemetry_domain.UplinkBacklog) bool {
+			return backlog.Backfilled == 10 && backlog.Remaining == 40
+		})).Return(nil).Once()
+
+		eventBus, ingested, backfilled := amf_event_bus.NewEventBus(), make(eventRecorder, 2), make(eventRecorder, 1)
+		eventBus.Subscribe(telemetry_domain.ReadingIngestedEventName, ingested)
+		eventBus.Subscribe(telemetry_domain.ReadingsBackfilledEventName, backfilled)
+
+		handler := telemetry_application.NewIngestBacklogCommandHandler(repository, clocks, backlogs, eventBus, timeProvider)
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assert.Len(t, ingested, 0)
+		assert.Len(t, backfilled, 0)
+	})
+
+	t.Run("should keep the pest events of the readings, corrected by the device clock", func(t *testing.T) {
+		trapTriggered, err := pestcontrol_domain.NewTrapTriggered(
+			amf_utils.NewUlid().String(),
+			"device-1",
+			pestcontrol_domain.SnapTrapDevice,
+			now.Add(-3*time.Hour),
+		)
+		require.NoError(t, err)
+		withEvents := *command
+		withEvents.Readings = append([]telemetry_application.BacklogReading{}, command.Readings...)
+		withEvents.Readings[0].PestEvents = []pestcontrol_domain.PestEvent{trapTriggered}
+
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		backlogs := telemetry_domain_mocks.NewUplinkBacklogRepository(t)
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(&telemetry_domain.DeviceClock{DeviceID: "device-1", Offset: time.Minute}, nil).Once()
+		repository.On("SaveAll", ctx, mock.MatchedBy(func(readings []telemetry_domain.Reading) bool {
+			return len(readings[0].PestEvents) == 1 && readings[0].PestEvents[0].OccurredAt.Equal(now.Add(-3*time.Hour+time.Minute))
+		})).Return([]telemetry_domain.Reading{}, nil).Once()
+		backlogs.On("Find", ctx, "device-1").Return(telemetry_domain.UplinkBacklog{DeviceID: "device-1"}, nil).Once()
+		backlogs.On("Save", ctx, mock.Anything).Return(nil).Once()
+
+		handler := telemetry_application.NewIngestBacklogCommandHandler(repository, clocks, backlogs, amf_event_bus.NewEventBus(), timeProvider)
+
+		require.NoError(t, handler.Handle(ctx, &withEvents))
+	})
+}
@@ -0,0 +1,30 @@
+package telemetry_application
+
+import (
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+)
+
+const IngestReadingCommandName = "IngestReadingCommand"
+
+type IngestReadingCommand struct {
+	ID         string
+	DeviceID   string
+	RecordedAt time.Time
+	Metrics    map[string]float64
+	PestEvents []pestcontrol_domain.PestEvent
+}
+
+func NewIngestReadingCommand(id string, deviceID string, recordedAt time.Time, metrics map[string]float64) *IngestReadingCommand {
+	return &IngestReadingCommand{
+		ID:         id,
+		DeviceID:   deviceID,
+		RecordedAt: recordedAt,
+		Metrics:    metrics,
+	}
+}
+
+func (irc IngestReadingCommand) Type() string {
+	return IngestReadingCommandName
+}
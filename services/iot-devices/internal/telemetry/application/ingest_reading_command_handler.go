@@ -0,0 +1,87 @@
+package telemetry_application
+
+import (
+	"context"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type IngestReadingCommandHandler struct {
+	repository    telemetry_domain.ReadingRepository
+	clocks        telemetry_domain.DeviceClockRepository
+	eventBus      amf_event_bus.Bus
+	backfillAfter time.Duration
+	timeProvider  amf_utils.DateTimeProvider
+}
+
+func NewIngestReadingCommandHandler(
+	repository telemetry_domain.ReadingRepository,
+	clocks telemetry_domain.DeviceClockRepository,
+	eventBus amf_event_bus.Bus,
+	backfillAfter time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *IngestReadingCommandHandler {
+	return &IngestReadingCommandHandler{
+		repository:    repository,
+		clocks:        clocks,
+		eventBus:      eventBus,
+		backfillAfter: backfillAfter,
+		timeProvider:  timeProvider,
+	}
+}
+
+// Handle corrects the time the reading was recorded with the offset of the device clock,
+// and marks the readings received later than backfillAfter as backfilled, so they raise
+// no alerts about the current state of the device. The pest events reported along the
+// reading are published once it is stored, unless it is backfilled.
+func (h IngestReadingCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*IngestReadingCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	reading, err := telemetry_domain.NewReading(cmd.ID, cmd.DeviceID, cmd.RecordedAt, h.timeProvider.Now(), cmd.Metrics)
+	if err != nil {
+		return err
+	}
+	clockOffset, err := clockOffsetOf(ctx, h.clocks, cmd.DeviceID)
+	if err != nil {
+		return err
+	}
+	reading = reading.WithPestEvents(cmd.PestEvents).CorrectedBy(clockOffset)
+	if reading.LateAfter(h.backfillAfter) {
+		reading = reading.AsBackfilled()
+	}
+
+	if err := h.repository.Save(ctx, reading); err != nil {
+		return err
+	}
+
+	h.eventBus.Publish(telemetry_domain.NewReadingIngested(reading))
+	if reading.Backfilled {
+		h.eventBus.Publish(telemetry_domain.NewReadingsBackfilled(reading.DeviceID, reading.RecordedAt, reading.RecordedAt, 1))
+		return nil
+	}
+
+	for _, pestEvent := range reading.PestEvents {
+		h.eventBus.Publish(pestcontrol_domain.NewPestEventReported(pestEvent))
+	}
+
+	return nil
+}
+
+// clockOffsetOf is no offset at all for the devices that never synced their clock.
+func clockOffsetOf(ctx context.Context, clocks telemetry_domain.DeviceClockRepository, deviceID string) (time.Duration, error) {
+	clock, err := clocks.Find(ctx, deviceID)
+	if err != nil || clock == nil {
+		return 0, err
+	}
+
+	return clock.Offset, nil
+}
@@ -0,0 +1,141 @@
+package telemetry_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain/mocks"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type eventRecorder chan amf_bus.Event
+
+func (r eventRecorder) Handle(event amf_bus.Event) error {
+	r <- event
+	return nil
+}
+
+func TestIngestReadingCommandHandler(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	recordedAt := timeProvider.Now().Add(-time.Minute)
+
+	t.Run("should save the reading and publish it", func(t *testing.T) {
+		command := telemetry_application.NewIngestReadingCommand(
+			amf_utils.NewUlid().String(),
+			"device-1",
+			recordedAt,
+			map[string]float64{"battery_mv": 2900},
+		)
+		reading, _ := telemetry_domain.NewReading(command.ID, command.DeviceID, recordedAt, timeProvider.Now(), command.Metrics)
+
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		repository.On("Save", ctx, reading).Return(nil).Once()
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(nil, nil).Once()
+
+		eventBus, recorder := amf_event_bus.NewEventBus(), make(eventRecorder, 1)
+		eventBus.Subscribe(telemetry_domain.ReadingIngestedEventName, recorder)
+
+		handler := telemetry_application.NewIngestReadingCommandHandler(repository, clocks, eventBus, time.Hour, timeProvider)
+		err := handler.Handle(ctx, command)
+
+		assert.NoError(t, err)
+		event := <-recorder
+		assert.Equal(t, "device-1", event.Data()["device_id"])
+		assert.Equal(t, command.Metrics, event.Data()["metrics"])
+	})
+
+	t.Run("should mark late readings as backfilled and publish their period", func(t *testing.T) {
+		lateRecordedAt := timeProvider.Now().Add(-2 * time.Hour)
+		command := telemetry_application.NewIngestReadingCommand(
+			amf_utils.NewUlid().String(),
+			"device-1",
+			lateRecordedAt,
+			map[string]float64{"battery_mv": 2900},
+		)
+		reading, _ := telemetry_domain.NewReading(command.ID, command.DeviceID, lateRecordedAt, timeProvider.Now(), command.Metrics)
+
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		repository.On("Save", ctx, reading.AsBackfilled()).Return(nil).Once()
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(nil, nil).Once()
+
+		eventBus, ingested, backfilled := amf_event_bus.NewEventBus(), make(eventRecorder, 1), make(eventRecorder, 1)
+		eventBus.Subscribe(telemetry_domain.ReadingIngestedEventName, ingested)
+		eventBus.Subscribe(telemetry_domain.ReadingsBackfilledEventName, backfilled)
+
+		handler := telemetry_application.NewIngestReadingCommandHandler(repository, clocks, eventBus, time.Hour, timeProvider)
+		err := handler.Handle(ctx, command)
+
+		assert.NoError(t, err)
+		assert.Equal(t, true, (<-ingested).Data()["backfilled"])
+		assert.Equal(t, lateRecordedAt, (<-backfilled).Data()["from"])
+	})
+
+	t.Run("should correct the time the reading was recorded by the offset of the device clock", func(t *testing.T) {
+		command := telemetry_application.NewIngestReadingCommand(
+			amf_utils.NewUlid().String(),
+			"device-1",
+			recordedAt,
+			map[string]float64{"battery_mv": 2900},
+		)
+
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		var saved telemetry_domain.Reading
+		repository.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(telemetry_domain.Reading)
+		}).Return(nil).Once()
+		clocks := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		clocks.On("Find", ctx, "device-1").Return(&telemetry_domain.DeviceClock{DeviceID: "device-1", Offset: -90 * time.Minute}, nil).Once()
+
+		handler := telemetry_application.NewIngestReadingCommandHandler(repository, clocks, amf_event_bus.NewEventBus(), time.Hour, timeProvider)
+		err := handler.Handle(ctx, command)
+
+		assert.NoError(t, err)
+		assert.Equal(t, recordedAt, saved.RawRecordedAt)
+		assert.Equal(t, recordedAt.Add(-90*time.Minute), saved.RecordedAt)
+		assert.Equal(t, -90*time.Minute, saved.ClockOffset)
+		assert.True(t, saved.Backfilled)
+	})
+
+	t.Run("should reject readings without metrics", func(t *testing.T) {
+		command := telemetry_application.NewIngestReadingCommand(amf_utils.NewUlid().String(), "device-1", recordedAt, nil)
+
+		handler := telemetry_application.NewIngestReadingCommandHandler(
+			telemetry_domain_mocks.NewReadingRepository(t),
+			telemetry_domain_mocks.NewDeviceClockRepository(t),
+			amf_event_bus.NewEventBus(),
+			time.Hour,
+			timeProvider,
+		)
+		err := handler.Handle(ctx, command)
+
+		assert.IsType(t, &telemetry_domain.InvalidReading{}, err)
+	})
+
+	t.Run("should reject readings without device", func(t *testing.T) {
+		command := telemetry_application.NewIngestReadingCommand(amf_utils.NewUlid().String(), "", recordedAt, map[string]float64{"battery_mv": 2900})
+
+		handler := telemetry_application.NewIngestReadingCommandHandler(
+			telemetry_domain_mocks.NewReadingRepository(t),
+			telemetry_domain_mocks.NewDeviceClockRepository(t),
+			amf_event_bus.NewEventBus(),
+			time.Hour,
+			timeProvider,
+		)
+		err := handler.Handle(ctx, command)
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+}
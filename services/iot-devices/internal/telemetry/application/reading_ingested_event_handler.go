@@ -0,0 +1,44 @@
+package telemetry_application
+
+import (
+	"context"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+// ReadingIngestedEventHandler folds every live reading into its rollups as it arrives.
+// Backfilled readings are left to the ReadingsBackfilledEventHandler, which rebuilds the
+// days they fall in, so they are never counted twice.
+type ReadingIngestedEventHandler struct {
+	rollups telemetry_domain.RollupRepository
+}
+
+func NewReadingIngestedEventHandler(rollups telemetry_domain.RollupRepository) *ReadingIngestedEventHandler {
+	return &ReadingIngestedEventHandler{rollups: rollups}
+}
+
+func (h ReadingIngestedEventHandler) Handle(event amf_bus.Event) error {
+	data := event.Data()
+	if backfilled, _ := data["backfilled"].(bool); backfilled {
+		return nil
+	}
+
+	id, _ := data["id"].(string)
+	deviceID, _ := data["device_id"].(string)
+	recordedAt, _ := data["recorded_at"].(time.Time)
+	metrics, ok := data["metrics"].(map[string]float64)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid reading ingested event")
+	}
+
+	return h.rollups.Add(amf_tenancy.WithAllTenants(context.Background()), telemetry_domain.Reading{
+		ID:         id,
+		DeviceID:   deviceID,
+		RecordedAt: recordedAt,
+		Metrics:    metrics,
+	})
+}
@@ -0,0 +1,68 @@
+package telemetry_application
+
+import (
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+type ReadingResponse struct {
+	ID         string             `jsonapi:"primary,readings"`
+	DeviceID   string             `jsonapi:"attr,device_id"`
+	Type       string             `jsonapi:"attr,type"`
+	RecordedAt string             `jsonapi:"attr,recorded_at"`
+	ReceivedAt string             `jsonapi:"attr,received_at"`
+	Metrics    map[string]float64 `jsonapi:"attr,metrics"`
+}
+
+// NewReadingResponse keeps only the metrics filtered by, if any.
+func NewReadingResponse(reading telemetry_domain.Reading, metrics []string) *ReadingResponse {
+	readingType := telemetry_domain.LiveReading
+	if reading.Backfilled {
+		readingType = telemetry_domain.BackfilledReading
+	}
+
+	response := &ReadingResponse{
+		ID:         reading.ID,
+		DeviceID:   reading.DeviceID,
+		Type:       string(readingType),
+		RecordedAt: reading.RecordedAt.UTC().Format(time.RFC3339Nano),
+		ReceivedAt: reading.ReceivedAt.UTC().Format(time.RFC3339Nano),
+		Metrics:    reading.Metrics,
+	}
+
+	if len(metrics) > 0 {
+		response.Metrics = make(map[string]float64, len(metrics))
+		for _, metric := range metrics {
+			if value, ok := reading.Metrics[metric]; ok {
+				response.Metrics[metric] = value
+			}
+		}
+	}
+
+	return response
+}
+
+// ReadingsPageResponse is a page of readings, with the cursor of the next page, empty on
+// the last one.
+type ReadingsPageResponse struct {
+	Readings   []*ReadingResponse
+	PageSize   int
+	NextCursor string
+}
+
+// NewReadingsPageResponse takes up to one reading more than the page size, the one that
+// tells there is a next page.
+func NewReadingsPageResponse(readings []telemetry_domain.Reading, pageSize int, metrics []string) *ReadingsPageResponse {
+	response := &ReadingsPageResponse{Readings: make([]*ReadingResponse, 0, pageSize), PageSize: pageSize}
+	if len(readings) > pageSize {
+		readings = readings[:pageSize]
+		response.NextCursor = telemetry_domain.NewReadingCursorOf(readings[pageSize-1]).Encode()
+	}
+
+	for _, reading := range readings {
+		response.Readings = append(response.Readings, NewReadingResponse(reading, metrics))
+	}
+
+	return response
+}
@@ -0,0 +1,33 @@
+package telemetry_application
+
+import (
+	"context"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+// ReadingsBackfilledEventHandler rebuilds the rollups of the days the backfilled readings
+// of a device fall in.
+type ReadingsBackfilledEventHandler struct {
+	rollups telemetry_domain.RollupRepository
+}
+
+func NewReadingsBackfilledEventHandler(rollups telemetry_domain.RollupRepository) *ReadingsBackfilledEventHandler {
+	return &ReadingsBackfilledEventHandler{rollups: rollups}
+}
+
+func (h ReadingsBackfilledEventHandler) Handle(event amf_bus.Event) error {
+	data := event.Data()
+	deviceID, _ := data["device_id"].(string)
+	from, _ := data["from"].(time.Time)
+	to, ok := data["to"].(time.Time)
+	if deviceID == "" || !ok {
+		return amf_bus.NewInvalidDto("invalid readings backfilled event")
+	}
+
+	return rebuildRollups(amf_tenancy.WithAllTenants(context.Background()), h.rollups, deviceID, from, to)
+}
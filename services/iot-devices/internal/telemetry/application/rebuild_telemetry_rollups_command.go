@@ -0,0 +1,17 @@
+package telemetry_application
+
+import "time"
+
+const RebuildTelemetryRollupsCommandName = "RebuildTelemetryRollupsCommand"
+
+// RebuildTelemetryRollupsCommand computes again the rollups of the days covering
+// [From, To] from the readings. An empty DeviceID rebuilds them for every device.
+type RebuildTelemetryRollupsCommand struct {
+	DeviceID string
+	From     time.Time
+	To       time.Time
+}
+
+func (c RebuildTelemetryRollupsCommand) Type() string {
+	return RebuildTelemetryRollupsCommandName
+}
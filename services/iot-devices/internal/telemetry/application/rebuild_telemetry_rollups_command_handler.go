@@ -0,0 +1,49 @@
+package telemetry_application
+
+import (
+	"context"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type RebuildTelemetryRollupsCommandHandler struct {
+	rollups telemetry_domain.RollupRepository
+}
+
+func NewRebuildTelemetryRollupsCommandHandler(rollups telemetry_domain.RollupRepository) *RebuildTelemetryRollupsCommandHandler {
+	return &RebuildTelemetryRollupsCommandHandler{rollups: rollups}
+}
+
+func (h RebuildTelemetryRollupsCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*RebuildTelemetryRollupsCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+	if cmd.From.IsZero() || cmd.To.Before(cmd.From) {
+		return telemetry_domain.NewInvalidTelemetrySeries(cmd.DeviceID, "to", "before from")
+	}
+
+	return rebuildRollups(ctx, h.rollups, cmd.DeviceID, cmd.From, cmd.To)
+}
+
+// rebuildRollups goes a day at a time, so rebuilding a long period never holds the
+// rollups of more than one day locked.
+func rebuildRollups(
+	ctx context.Context,
+	rollups telemetry_domain.RollupRepository,
+	deviceID string,
+	from time.Time,
+	to time.Time,
+) error {
+	firstDay, end := telemetry_domain.RollupDaysCovering(from, to)
+	for day := firstDay; day.Before(end); day = day.Add(24 * time.Hour) {
+		if err := rollups.Rebuild(ctx, deviceID, day, day.Add(24*time.Hour)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
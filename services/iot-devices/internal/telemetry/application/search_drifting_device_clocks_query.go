@@ -0,0 +1,9 @@
+package telemetry_application
+
+const SearchDriftingDeviceClocksQueryName = "SearchDriftingDeviceClocksQuery"
+
+type SearchDriftingDeviceClocksQuery struct{}
+
+func (q SearchDriftingDeviceClocksQuery) Type() string {
+	return SearchDriftingDeviceClocksQueryName
+}
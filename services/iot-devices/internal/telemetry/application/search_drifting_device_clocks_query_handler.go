@@ -0,0 +1,37 @@
+package telemetry_application
+
+import (
+	"context"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchDriftingDeviceClocksQueryHandler struct {
+	repository telemetry_domain.DeviceClockRepository
+}
+
+func NewSearchDriftingDeviceClocksQueryHandler(
+	repository telemetry_domain.DeviceClockRepository,
+) *SearchDriftingDeviceClocksQueryHandler {
+	return &SearchDriftingDeviceClocksQueryHandler{repository: repository}
+}
+
+func (h SearchDriftingDeviceClocksQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	if _, ok := query.(*SearchDriftingDeviceClocksQuery); !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	clocks, err := h.repository.SearchDrifting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*DeviceClockResponse, 0, len(clocks))
+	for _, clock := range clocks {
+		response = append(response, NewDeviceClockResponse(clock))
+	}
+
+	return response, nil
+}
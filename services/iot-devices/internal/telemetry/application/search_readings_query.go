@@ -0,0 +1,22 @@
+package telemetry_application
+
+import "time"
+
+const SearchReadingsQueryName = "SearchReadingsQuery"
+
+// SearchReadingsQuery pages the readings of either a device or a site, newest first.
+// Cursor is the one of the previous page, empty for the first one.
+type SearchReadingsQuery struct {
+	DeviceID    string
+	SiteID      string
+	From        time.Time
+	To          time.Time
+	Metrics     []string
+	ReadingType string
+	Cursor      string
+	PageSize    int
+}
+
+func (q SearchReadingsQuery) Type() string {
+	return SearchReadingsQueryName
+}
@@ -0,0 +1,82 @@
+package telemetry_application
+
+import (
+	"context"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	defaultReadingsPageSize = 100
+	maxReadingsPageSize     = 1000
+)
+
+type SearchReadingsQueryHandler struct {
+	repository telemetry_domain.ReadingRepository
+}
+
+func NewSearchReadingsQueryHandler(repository telemetry_domain.ReadingRepository) *SearchReadingsQueryHandler {
+	return &SearchReadingsQueryHandler{repository: repository}
+}
+
+// Handle asks for one reading more than the page holds, which tells whether there is a
+// next page without counting them all.
+func (h SearchReadingsQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchReadingsQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	scopeID := q.DeviceID
+	if scopeID == "" {
+		scopeID = q.SiteID
+	}
+
+	criteria := telemetry_domain.ReadingCriteria{
+		DeviceID: q.DeviceID,
+		SiteID:   q.SiteID,
+		From:     q.From,
+		To:       q.To,
+		Metrics:  q.Metrics,
+		Limit:    q.PageSize,
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return nil, telemetry_domain.NewInvalidReadingSearch(scopeID, "to", "not after from")
+	}
+
+	if criteria.Limit == 0 {
+		criteria.Limit = defaultReadingsPageSize
+	}
+	pageSizeValidator := domain_validation.NewDomainValidator(domain_validation.Int64Range(1, maxReadingsPageSize))
+	if err := pageSizeValidator.Validate(int64(criteria.Limit), telemetry_domain.NewInvalidReadingSearch(scopeID, "page_size", "out of range")); err != nil {
+		return nil, err
+	}
+
+	if q.ReadingType != "" {
+		readingType, err := telemetry_domain.NewReadingType(scopeID, q.ReadingType)
+		if err != nil {
+			return nil, err
+		}
+		criteria.Type = readingType
+	}
+
+	if q.Cursor != "" {
+		cursor, err := telemetry_domain.DecodeReadingCursor(scopeID, q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		criteria.After = &cursor
+	}
+
+	pageSize := criteria.Limit
+	criteria.Limit++
+	readings, err := h.repository.Search(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReadingsPageResponse(readings, pageSize, q.Metrics), nil
+}
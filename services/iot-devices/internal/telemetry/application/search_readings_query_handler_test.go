@@ -0,0 +1,93 @@
+package telemetry_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain/mocks"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestSearchReadingsQueryHandler(t *testing.T) {
+	ctx := context.Background()
+	now := amf_utils.NewFixedTimeProvider().Now()
+
+	readings := make([]telemetry_domain.Reading, 0, 3)
+	for i := 0; i < 3; i++ {
+		reading, err := telemetry_domain.NewReading(
+			amf_utils.NewUlid().String(),
+			"device-1",
+			now.Add(-time.Duration(i)*time.Minute),
+			now,
+			map[string]float64{"battery": 3.1, "temperature": 21},
+		)
+		require.NoError(t, err)
+		readings = append(readings, reading)
+	}
+
+	t.Run("should page the readings handing the cursor of the last one", func(t *testing.T) {
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		repository.On("Search", ctx, mock.MatchedBy(func(criteria telemetry_domain.ReadingCriteria) bool {
+			return criteria.DeviceID == "device-1" && criteria.Limit == 3 && criteria.After == nil &&
+				criteria.Type == telemetry_domain.LiveReading
+		})).Return(readings, nil).Once()
+
+		handler := telemetry_application.NewSearchReadingsQueryHandler(repository)
+		response, err := handler.Handle(ctx, &telemetry_application.SearchReadingsQuery{
+			DeviceID:    "device-1",
+			Metrics:     []string{"battery"},
+			ReadingType: "live",
+			PageSize:    2,
+		})
+		require.NoError(t, err)
+
+		page := response.(*telemetry_application.ReadingsPageResponse)
+		assert.Len(t, page.Readings, 2)
+		assert.Equal(t, map[string]float64{"battery": 3.1}, page.Readings[0].Metrics)
+		assert.Equal(t, "live", page.Readings[0].Type)
+
+		cursor, err := telemetry_domain.DecodeReadingCursor("device-1", page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, readings[1].ID, cursor.ID)
+		assert.True(t, readings[1].RecordedAt.Equal(cursor.RecordedAt))
+	})
+
+	t.Run("should continue after the cursor and end on the last page", func(t *testing.T) {
+		cursor := telemetry_domain.NewReadingCursorOf(readings[1])
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		repository.On("Search", ctx, mock.MatchedBy(func(criteria telemetry_domain.ReadingCriteria) bool {
+			return criteria.SiteID == "site-1" && criteria.After != nil && criteria.After.ID == readings[1].ID
+		})).Return(readings[2:], nil).Once()
+
+		handler := telemetry_application.NewSearchReadingsQueryHandler(repository)
+		response, err := handler.Handle(ctx, &telemetry_application.SearchReadingsQuery{SiteID: "site-1", Cursor: cursor.Encode()})
+		require.NoError(t, err)
+
+		page := response.(*telemetry_application.ReadingsPageResponse)
+		assert.Len(t, page.Readings, 1)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("should reject broken cursors", func(t *testing.T) {
+		handler := telemetry_application.NewSearchReadingsQueryHandler(telemetry_domain_mocks.NewReadingRepository(t))
+		_, err := handler.Handle(ctx, &telemetry_application.SearchReadingsQuery{DeviceID: "device-1", Cursor: "not-a-cursor"})
+
+		assert.IsType(t, &telemetry_domain.InvalidReadingSearch{}, err)
+	})
+
+	t.Run("should reject pages too large", func(t *testing.T) {
+		handler := telemetry_application.NewSearchReadingsQueryHandler(telemetry_domain_mocks.NewReadingRepository(t))
+		_, err := handler.Handle(ctx, &telemetry_application.SearchReadingsQuery{DeviceID: "device-1", PageSize: 5000})
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+}
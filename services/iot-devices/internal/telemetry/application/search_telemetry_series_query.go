@@ -0,0 +1,20 @@
+package telemetry_application
+
+import "time"
+
+const SearchTelemetrySeriesQueryName = "SearchTelemetrySeriesQuery"
+
+// SearchTelemetrySeriesQuery charts either a device or a site over [From, To). A zero To
+// is now and a zero From the day before To, and an empty Resolution is picked from the
+// range.
+type SearchTelemetrySeriesQuery struct {
+	DeviceID   string
+	SiteID     string
+	From       time.Time
+	To         time.Time
+	Resolution string
+}
+
+func (q SearchTelemetrySeriesQuery) Type() string {
+	return SearchTelemetrySeriesQueryName
+}
@@ -0,0 +1,85 @@
+package telemetry_application
+
+import (
+	"context"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const defaultTelemetrySeriesRange = 24 * time.Hour
+
+type SearchTelemetrySeriesQueryHandler struct {
+	rollups      telemetry_domain.RollupRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewSearchTelemetrySeriesQueryHandler(
+	rollups telemetry_domain.RollupRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *SearchTelemetrySeriesQueryHandler {
+	return &SearchTelemetrySeriesQueryHandler{rollups: rollups, timeProvider: timeProvider}
+}
+
+// Handle charts the range at the resolution asked for, if any, but never reads raw
+// readings over a range longer than the automatic pick would. Sites are charted hourly at
+// the finest, as the readings of their devices never line up.
+func (h SearchTelemetrySeriesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchTelemetrySeriesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	scopeID := q.DeviceID
+	if scopeID == "" {
+		scopeID = q.SiteID
+	}
+
+	to := q.To
+	if to.IsZero() {
+		to = h.timeProvider.Now()
+	}
+	from := q.From
+	if from.IsZero() {
+		from = to.Add(-defaultTelemetrySeriesRange)
+	}
+	if !from.Before(to) {
+		return nil, telemetry_domain.NewInvalidTelemetrySeries(scopeID, "to", "not after from")
+	}
+
+	resolution := telemetry_domain.RollupResolutionFor(from, to)
+	if q.Resolution != "" {
+		requested, err := telemetry_domain.NewRollupResolution(q.Resolution)
+		if err != nil {
+			return nil, telemetry_domain.NewInvalidTelemetrySeries(scopeID, "resolution", "unknown")
+		}
+		if requested == telemetry_domain.RawResolution && resolution != telemetry_domain.RawResolution {
+			return nil, telemetry_domain.NewInvalidTelemetrySeries(scopeID, "resolution", "range too long for raw readings")
+		}
+		resolution = requested
+	}
+	if q.DeviceID == "" && resolution == telemetry_domain.RawResolution {
+		if q.Resolution != "" {
+			return nil, telemetry_domain.NewInvalidTelemetrySeries(scopeID, "resolution", "no raw readings for sites")
+		}
+		resolution = telemetry_domain.HourlyResolution
+	}
+
+	var (
+		rollups []telemetry_domain.Rollup
+		err     error
+	)
+	if q.DeviceID != "" {
+		rollups, err = h.rollups.SearchByDevice(ctx, q.DeviceID, resolution, from, to)
+	} else {
+		rollups, err = h.rollups.SearchBySite(ctx, q.SiteID, resolution, from, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTelemetrySeriesResponse(q.DeviceID, q.SiteID, resolution, from, to, rollups), nil
+}
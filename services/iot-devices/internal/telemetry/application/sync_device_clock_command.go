@@ -0,0 +1,23 @@
+package telemetry_application
+
+import "time"
+
+const SyncDeviceClockCommandName = "SyncDeviceClockCommand"
+
+// SyncDeviceClockCommand carries the round trip of the previous exchange of a device: when
+// it sent the uplink and got the answer, by its own clock, and the server time answered.
+type SyncDeviceClockCommand struct {
+	DeviceID   string
+	SentAt     time.Time
+	ServerTime time.Time
+	ReceivedAt time.Time
+}
+
+func (c SyncDeviceClockCommand) Type() string {
+	return SyncDeviceClockCommandName
+}
+
+// BlockingKey serializes the syncs of a device, as each one counts on the previous estimation.
+func (c SyncDeviceClockCommand) BlockingKey() string {
+	return "device_clock:" + c.DeviceID
+}
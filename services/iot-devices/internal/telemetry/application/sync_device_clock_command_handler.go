@@ -0,0 +1,69 @@
+package telemetry_application
+
+import (
+	"context"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type SyncDeviceClockCommandHandler struct {
+	repository     telemetry_domain.DeviceClockRepository
+	eventBus       amf_event_bus.Bus
+	driftThreshold time.Duration
+	maxRoundTrip   time.Duration
+	timeProvider   amf_utils.DateTimeProvider
+}
+
+func NewSyncDeviceClockCommandHandler(
+	repository telemetry_domain.DeviceClockRepository,
+	eventBus amf_event_bus.Bus,
+	driftThreshold time.Duration,
+	maxRoundTrip time.Duration,
+	timeProvider amf_utils.DateTimeProvider,
+) *SyncDeviceClockCommandHandler {
+	return &SyncDeviceClockCommandHandler{
+		repository:     repository,
+		eventBus:       eventBus,
+		driftThreshold: driftThreshold,
+		maxRoundTrip:   maxRoundTrip,
+		timeProvider:   timeProvider,
+	}
+}
+
+// Handle estimates the offset of the device clock and flags the device once its drift
+// goes beyond the threshold.
+func (h SyncDeviceClockCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*SyncDeviceClockCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	sample, err := telemetry_domain.NewClockSyncSample(cmd.DeviceID, cmd.SentAt, cmd.ServerTime, cmd.ReceivedAt, h.maxRoundTrip)
+	if err != nil {
+		return err
+	}
+
+	clock, err := h.repository.Find(ctx, cmd.DeviceID)
+	if err != nil {
+		return err
+	}
+	if clock == nil {
+		clock = &telemetry_domain.DeviceClock{DeviceID: cmd.DeviceID}
+	}
+
+	synced, startedDrifting := clock.Synced(sample, h.driftThreshold, h.timeProvider.Now())
+	if err := h.repository.Save(ctx, synced); err != nil {
+		return err
+	}
+
+	if startedDrifting {
+		h.eventBus.Publish(telemetry_domain.NewDeviceClockDrifting(synced))
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package telemetry_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain/mocks"
+
+	amf_event_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/event"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestSyncDeviceClockCommandHandler(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+
+	// The device clock is 5 minutes behind: it sent at now-5m by its clock, the server
+	// answered 1s later and the answer took another second
+	command := &telemetry_application.SyncDeviceClockCommand{
+		DeviceID:   "device-1",
+		SentAt:     now.Add(-5 * time.Minute),
+		ServerTime: now.Add(time.Second),
+		ReceivedAt: now.Add(-5*time.Minute + 2*time.Second),
+	}
+
+	t.Run("should estimate the offset and flag the device drifting beyond the threshold", func(t *testing.T) {
+		var saved telemetry_domain.DeviceClock
+		repository := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		repository.On("Find", ctx, "device-1").Return(&telemetry_domain.DeviceClock{DeviceID: "device-1", Samples: 3}, nil).Once()
+		repository.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(telemetry_domain.DeviceClock)
+		}).Return(nil).Once()
+
+		eventBus, drifting := amf_event_bus.NewEventBus(), make(eventRecorder, 1)
+		eventBus.Subscribe(telemetry_domain.DeviceClockDriftingEventName, drifting)
+
+		handler := telemetry_application.NewSyncDeviceClockCommandHandler(repository, eventBus, 2*time.Minute, 30*time.Second, timeProvider)
+		assert.NoError(t, handler.Handle(ctx, command))
+
+		assert.Equal(t, 5*time.Minute, saved.Offset)
+		assert.Equal(t, 2*time.Second, saved.RoundTrip)
+		assert.Equal(t, int64(4), saved.Samples)
+		assert.True(t, saved.Drifting)
+		assert.Equal(t, now, saved.EstimatedAt)
+		assert.Equal(t, "device-1", (<-drifting).Data()["device_id"])
+	})
+
+	t.Run("should not flag again the devices already drifting", func(t *testing.T) {
+		repository := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		repository.On("Find", ctx, "device-1").Return(&telemetry_domain.DeviceClock{DeviceID: "device-1", Drifting: true}, nil).Once()
+		repository.On("Save", ctx, mock.MatchedBy(func(clock telemetry_domain.DeviceClock) bool {
+			return clock.Drifting
+		})).Return(nil).Once()
+
+		eventBus, drifting := amf_event_bus.NewEventBus(), make(eventRecorder, 1)
+		eventBus.Subscribe(telemetry_domain.DeviceClockDriftingEventName, drifting)
+
+		handler := telemetry_application.NewSyncDeviceClockCommandHandler(repository, eventBus, 2*time.Minute, 30*time.Second, timeProvider)
+		assert.NoError(t, handler.Handle(ctx, command))
+
+		assert.Empty(t, drifting)
+	})
+
+	t.Run("should stop flagging the devices back within the threshold", func(t *testing.T) {
+		repository := telemetry_domain_mocks.NewDeviceClockRepository(t)
+		repository.On("Find", ctx, "device-1").Return(nil, nil).Once()
+		repository.On("Save", ctx, mock.MatchedBy(func(clock telemetry_domain.DeviceClock) bool {
+			return !clock.Drifting && clock.Samples == 1
+		})).Return(nil).Once()
+
+		handler := telemetry_application.NewSyncDeviceClockCommandHandler(
+			repository,
+			amf_event_bus.NewEventBus(),
+			10*time.Minute,
+			30*time.Second,
+			timeProvider,
+		)
+
+		assert.NoError(t, handler.Handle(ctx, command))
+	})
+
+	t.Run("should reject round trips too long to tell the offset", func(t *testing.T) {
+		slow := *command
+		slow.ReceivedAt = slow.SentAt.Add(time.Minute)
+
+		handler := telemetry_application.NewSyncDeviceClockCommandHandler(
+			telemetry_domain_mocks.NewDeviceClockRepository(t),
+			amf_event_bus.NewEventBus(),
+			2*time.Minute,
+			30*time.Second,
+			timeProvider,
+		)
+
+		assert.IsType(t, &telemetry_domain.InvalidClockSyncSample{}, handler.Handle(ctx, &slow))
+	})
+}
@@ -0,0 +1,135 @@
+package telemetry_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain/mocks"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestRebuildTelemetryRollupsCommandHandler(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should rebuild a day at a time the whole days covering the range", func(t *testing.T) {
+		rollups := telemetry_domain_mocks.NewRollupRepository(t)
+		for i := 0; i < 3; i++ {
+			from := day.Add(time.Duration(i) * 24 * time.Hour)
+			rollups.On("Rebuild", ctx, "device-1", from, from.Add(24*time.Hour)).Return(nil).Once()
+		}
+
+		handler := telemetry_application.NewRebuildTelemetryRollupsCommandHandler(rollups)
+		err := handler.Handle(ctx, &telemetry_application.RebuildTelemetryRollupsCommand{
+			DeviceID: "device-1",
+			From:     day.Add(23 * time.Hour),
+			To:       day.Add(48*time.Hour + time.Minute),
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject ranges ending before they start", func(t *testing.T) {
+		handler := telemetry_application.NewRebuildTelemetryRollupsCommandHandler(telemetry_domain_mocks.NewRollupRepository(t))
+		err := handler.Handle(ctx, &telemetry_application.RebuildTelemetryRollupsCommand{From: day, To: day.Add(-time.Hour)})
+
+		assert.IsType(t, &telemetry_domain.InvalidTelemetrySeries{}, err)
+	})
+}
+
+func TestRollupEventHandlers(t *testing.T) {
+	recordedAt := time.Date(2026, 10, 17, 10, 5, 0, 0, time.UTC)
+	reading, err := telemetry_domain.NewReading(amf_utils.NewUlid().String(), "device-1", recordedAt, recordedAt, map[string]float64{"battery": 3.1})
+	assert.NoError(t, err)
+
+	t.Run("should add the live readings to their rollups", func(t *testing.T) {
+		rollups := telemetry_domain_mocks.NewRollupRepository(t)
+		rollups.On("Add", mock.Anything, mock.MatchedBy(func(added telemetry_domain.Reading) bool {
+			return added.DeviceID == "device-1" && added.RecordedAt.Equal(recordedAt) && added.Metrics["battery"] == 3.1
+		})).Return(nil).Once()
+
+		handler := telemetry_application.NewReadingIngestedEventHandler(rollups)
+
+		assert.NoError(t, handler.Handle(telemetry_domain.NewReadingIngested(reading)))
+	})
+
+	t.Run("should leave the backfilled readings to the rebuild of their days", func(t *testing.T) {
+		handler := telemetry_application.NewReadingIngestedEventHandler(telemetry_domain_mocks.NewRollupRepository(t))
+
+		assert.NoError(t, handler.Handle(telemetry_domain.NewReadingIngested(reading.AsBackfilled())))
+	})
+
+	t.Run("should rebuild the days the backfilled readings fall in", func(t *testing.T) {
+		day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+		rollups := telemetry_domain_mocks.NewRollupRepository(t)
+		rollups.On("Rebuild", mock.Anything, "device-1", day, day.Add(24*time.Hour)).Return(nil).Once()
+
+		handler := telemetry_application.NewReadingsBackfilledEventHandler(rollups)
+		event := telemetry_domain.NewReadingsBackfilled("device-1", recordedAt, recordedAt.Add(time.Hour), 12)
+
+		assert.NoError(t, handler.Handle(event))
+	})
+}
+
+func TestSearchTelemetrySeriesQueryHandler(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := amf_utils.NewFixedTimeProvider()
+	now := timeProvider.Now()
+
+	for _, tc := range []struct {
+		name       string
+		from       time.Time
+		resolution telemetry_domain.RollupResolution
+	}{
+		{name: "should chart the raw readings of short ranges", from: now.Add(-24 * time.Hour), resolution: telemetry_domain.RawResolution},
+		{name: "should chart hourly rollups of ranges of weeks", from: now.Add(-30 * 24 * time.Hour), resolution: telemetry_domain.HourlyResolution},
+		{name: "should chart daily rollups of ranges of months", from: now.Add(-365 * 24 * time.Hour), resolution: telemetry_domain.DailyResolution},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rollups := telemetry_domain_mocks.NewRollupRepository(t)
+			rollups.On("SearchByDevice", ctx, "device-1", tc.resolution, tc.from, now).Return([]telemetry_domain.Rollup{{
+				Resolution:  tc.resolution,
+				BucketStart: tc.from,
+				Metrics:     map[string]telemetry_domain.MetricRollup{"battery": {Readings: 2, Sum: 6, Min: 2.9, Max: 3.1}},
+			}}, nil).Once()
+
+			handler := telemetry_application.NewSearchTelemetrySeriesQueryHandler(rollups, timeProvider)
+			response, err := handler.Handle(ctx, &telemetry_application.SearchTelemetrySeriesQuery{DeviceID: "device-1", From: tc.from})
+			assert.NoError(t, err)
+
+			series := response.(*telemetry_application.TelemetrySeriesResponse)
+			assert.Equal(t, tc.resolution.Value(), series.Resolution)
+			assert.Len(t, series.Points, 1)
+			assert.Equal(t, 3.0, series.Points[0]["metrics"].(map[string]interface{})["battery"].(map[string]interface{})["avg"])
+		})
+	}
+
+	t.Run("should chart sites hourly at the finest", func(t *testing.T) {
+		rollups := telemetry_domain_mocks.NewRollupRepository(t)
+		rollups.On("SearchBySite", ctx, "site-1", telemetry_domain.HourlyResolution, now.Add(-24*time.Hour), now).
+			Return([]telemetry_domain.Rollup{}, nil).Once()
+
+		handler := telemetry_application.NewSearchTelemetrySeriesQueryHandler(rollups, timeProvider)
+		_, err := handler.Handle(ctx, &telemetry_application.SearchTelemetrySeriesQuery{SiteID: "site-1"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should refuse raw readings over long ranges", func(t *testing.T) {
+		handler := telemetry_application.NewSearchTelemetrySeriesQueryHandler(telemetry_domain_mocks.NewRollupRepository(t), timeProvider)
+		_, err := handler.Handle(ctx, &telemetry_application.SearchTelemetrySeriesQuery{
+			DeviceID:   "device-1",
+			From:       now.Add(-30 * 24 * time.Hour),
+			Resolution: "raw",
+		})
+
+		assert.IsType(t, &telemetry_domain.InvalidTelemetrySeries{}, err)
+	})
+}
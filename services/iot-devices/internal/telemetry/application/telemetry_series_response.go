@@ -0,0 +1,60 @@
+package telemetry_application
+
+import (
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+type TelemetrySeriesResponse struct {
+	ID         string                   `jsonapi:"primary,telemetry_series"`
+	DeviceID   string                   `jsonapi:"attr,device_id,omitempty"`
+	SiteID     string                   `jsonapi:"attr,site_id,omitempty"`
+	Resolution string                   `jsonapi:"attr,resolution"`
+	From       string                   `jsonapi:"attr,from"`
+	To         string                   `jsonapi:"attr,to"`
+	Points     []map[string]interface{} `jsonapi:"attr,points"`
+}
+
+func NewTelemetrySeriesResponse(
+	deviceID string,
+	siteID string,
+	resolution telemetry_domain.RollupResolution,
+	from time.Time,
+	to time.Time,
+	rollups []telemetry_domain.Rollup,
+) *TelemetrySeriesResponse {
+	id := deviceID
+	if id == "" {
+		id = siteID
+	}
+
+	points := make([]map[string]interface{}, 0, len(rollups))
+	for _, rollup := range rollups {
+		metrics := make(map[string]interface{}, len(rollup.Metrics))
+		for name, metric := range rollup.Metrics {
+			metrics[name] = map[string]interface{}{
+				"readings": metric.Readings,
+				"sum":      metric.Sum,
+				"min":      metric.Min,
+				"avg":      metric.Avg(),
+				"max":      metric.Max,
+			}
+		}
+
+		points = append(points, map[string]interface{}{
+			"at":      rollup.BucketStart.Format(time.RFC3339),
+			"metrics": metrics,
+		})
+	}
+
+	return &TelemetrySeriesResponse{
+		ID:         id,
+		DeviceID:   deviceID,
+		SiteID:     siteID,
+		Resolution: resolution.Value(),
+		From:       from.UTC().Format(time.RFC3339),
+		To:         to.UTC().Format(time.RFC3339),
+		Points:     points,
+	}
+}
@@ -0,0 +1,30 @@
+package telemetry_application
+
+import (
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+type UplinkBacklogResponse struct {
+	DeviceID   string `jsonapi:"primary,uplink_backlogs"`
+	Remaining  int64  `jsonapi:"attr,remaining"`
+	LagSeconds int64  `jsonapi:"attr,lag_seconds"`
+	Backfilled int64  `jsonapi:"attr,backfilled"`
+	UpdatedAt  string `jsonapi:"attr,updated_at,omitempty"`
+}
+
+func NewUplinkBacklogResponse(backlog telemetry_domain.UplinkBacklog) *UplinkBacklogResponse {
+	response := &UplinkBacklogResponse{
+		DeviceID:   backlog.DeviceID,
+		Remaining:  backlog.Remaining,
+		LagSeconds: int64(backlog.Lag.Seconds()),
+		Backfilled: backlog.Backfilled,
+	}
+
+	if !backlog.UpdatedAt.IsZero() {
+		response.UpdatedAt = backlog.UpdatedAt.Format(time.RFC3339)
+	}
+
+	return response
+}
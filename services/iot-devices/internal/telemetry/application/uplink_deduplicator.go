@@ -0,0 +1,42 @@
+package telemetry_application
+
+import (
+	"context"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// UplinkDeduplicator drops the uplinks already received before they reach the command
+// bus, so retransmissions produce neither readings nor alerts.
+type UplinkDeduplicator struct {
+	registry     telemetry_domain.UplinkSequenceRegistry
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewUplinkDeduplicator(
+	registry telemetry_domain.UplinkSequenceRegistry,
+	timeProvider amf_utils.DateTimeProvider,
+) *UplinkDeduplicator {
+	return &UplinkDeduplicator{registry: registry, timeProvider: timeProvider}
+}
+
+// Register returns the state the uplink was at before being received this time.
+func (d *UplinkDeduplicator) Register(
+	ctx context.Context,
+	deviceID string,
+	sequence int64,
+) (telemetry_domain.UplinkSequenceState, error) {
+	return d.registry.Register(ctx, deviceID, sequence, d.timeProvider.Now())
+}
+
+// Ingested lets the retransmissions of an uplink be dropped as duplicates.
+func (d *UplinkDeduplicator) Ingested(ctx context.Context, deviceID string, sequence int64) error {
+	return d.registry.Ingested(ctx, deviceID, sequence)
+}
+
+// Forget lets the retransmission of an uplink whose ingestion failed be ingested.
+func (d *UplinkDeduplicator) Forget(ctx context.Context, deviceID string, sequence int64) error {
+	return d.registry.Unregister(ctx, deviceID, sequence)
+}
@@ -0,0 +1,26 @@
+package telemetry_application
+
+import (
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+type UplinkDuplicatesResponse struct {
+	DeviceID        string `jsonapi:"primary,uplink_duplicates"`
+	Duplicates      int64  `jsonapi:"attr,duplicates"`
+	LastDuplicateAt string `jsonapi:"attr,last_duplicate_at,omitempty"`
+}
+
+func NewUplinkDuplicatesResponse(duplicates telemetry_domain.UplinkDuplicates) *UplinkDuplicatesResponse {
+	response := &UplinkDuplicatesResponse{
+		DeviceID:   duplicates.DeviceID,
+		Duplicates: duplicates.Count,
+	}
+
+	if duplicates.LastAt != nil {
+		response.LastDuplicateAt = duplicates.LastAt.Format(time.RFC3339)
+	}
+
+	return response
+}
@@ -0,0 +1,140 @@
+package telemetry_application
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+type UplinkReplayOutcome string
+
+const (
+	UnchangedUplinkReplay   UplinkReplayOutcome = "unchanged"
+	CreatedUplinkReplay     UplinkReplayOutcome = "created"
+	UpdatedUplinkReplay     UplinkReplayOutcome = "updated"
+	UndecodableUplinkReplay UplinkReplayOutcome = "undecodable"
+)
+
+// UplinkReplayResult compares a reading stored for a frame with the one the current
+// decoder gets from it. Current is nil when the frame never produced the reading, and
+// Replayed is nil when the frame still cannot be decoded.
+type UplinkReplayResult struct {
+	Frame    telemetry_domain.UplinkFrame
+	Outcome  UplinkReplayOutcome
+	Current  *telemetry_domain.Reading
+	Replayed *telemetry_domain.Reading
+	Err      error
+}
+
+// UplinkReplayer decodes the archived frames again, fixing the readings written by a
+// wrong decoder. Readings share the id of their frame, so replaying twice writes nothing
+// new. Replays do not publish the readings, since alerts on old data are of no use, but
+// they rebuild the rollups of the days whose readings they write.
+type UplinkReplayer struct {
+	archive    telemetry_domain.UplinkArchive
+	decoder    telemetry_domain.UplinkDecoder
+	repository telemetry_domain.ReadingRepository
+	rollups    telemetry_domain.RollupRepository
+}
+
+func NewUplinkReplayer(
+	archive telemetry_domain.UplinkArchive,
+	decoder telemetry_domain.UplinkDecoder,
+	repository telemetry_domain.ReadingRepository,
+	rollups telemetry_domain.RollupRepository,
+) *UplinkReplayer {
+	return &UplinkReplayer{archive: archive, decoder: decoder, repository: repository, rollups: rollups}
+}
+
+// Replay reports every reading of the frames of the device received in [from, to), and
+// the frames that cannot be decoded. A dry run only reports what the replay would write.
+func (r *UplinkReplayer) Replay(
+	ctx context.Context,
+	deviceID string,
+	from time.Time,
+	to time.Time,
+	dryRun bool,
+	report func(result UplinkReplayResult) error,
+) error {
+	touchedDays := map[time.Time]bool{}
+	err := r.archive.Stream(ctx, deviceID, from, to, func(frame telemetry_domain.UplinkFrame) error {
+		replayed, err := r.decoder.Decode(frame)
+		if err != nil {
+			// The readings of undecodable frames are unknown, except the one sharing its id
+			current, findErr := r.repository.Find(ctx, frame.ID)
+			if findErr != nil {
+				return findErr
+			}
+
+			return report(UplinkReplayResult{Frame: frame, Outcome: UndecodableUplinkReplay, Current: current, Err: err})
+		}
+
+		for _, reading := range replayed {
+			result, err := r.replay(ctx, frame, reading, dryRun)
+			if err != nil {
+				return err
+			}
+			if err := report(result); err != nil {
+				return err
+			}
+			if dryRun || result.Outcome == UnchangedUplinkReplay {
+				continue
+			}
+
+			// An updated reading can move to another day, leaving the one it was in stale too
+			touchedDays[telemetry_domain.DailyResolution.Bucket(result.Replayed.RecordedAt)] = true
+			if result.Current != nil {
+				touchedDays[telemetry_domain.DailyResolution.Bucket(result.Current.RecordedAt)] = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for day := range touchedDays {
+		if err := rebuildRollups(ctx, r.rollups, deviceID, day, day); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *UplinkReplayer) replay(
+	ctx context.Context,
+	frame telemetry_domain.UplinkFrame,
+	replayed telemetry_domain.Reading,
+	dryRun bool,
+) (UplinkReplayResult, error) {
+	current, err := r.repository.Find(ctx, replayed.ID)
+	if err != nil {
+		return UplinkReplayResult{}, err
+	}
+
+	// Readings first written by a replay were never seen live, and the others keep the
+	// clock offset they were corrected with when they were
+	if current == nil {
+		replayed = replayed.AsBackfilled()
+	} else {
+		replayed = replayed.CorrectedBy(current.ClockOffset)
+	}
+
+	result := UplinkReplayResult{Frame: frame, Outcome: UnchangedUplinkReplay, Current: current, Replayed: &replayed}
+	switch {
+	case current == nil:
+		result.Outcome = CreatedUplinkReplay
+	case !current.RecordedAt.Equal(replayed.RecordedAt) || !reflect.DeepEqual(current.Metrics, replayed.Metrics):
+		result.Outcome = UpdatedUplinkReplay
+	}
+
+	if dryRun || result.Outcome == UnchangedUplinkReplay {
+		return result, nil
+	}
+
+	return result, r.repository.Replace(ctx, replayed)
+}
@@ -0,0 +1,133 @@
+package telemetry_application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain/mocks"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestUplinkReplayer(t *testing.T) {
+	ctx := context.Background()
+	now := amf_utils.NewFixedTimeProvider().Now()
+	from, to := now.Add(-time.Hour), now
+
+	newFrame := func(t *testing.T) telemetry_domain.UplinkFrame {
+		frame, err := telemetry_domain.NewUplinkFrame(amf_utils.NewUlid().String(), "device-1", now.Add(-time.Minute), []byte("{}"), nil)
+		require.NoError(t, err)
+		return frame
+	}
+	newReading := func(t *testing.T, frame telemetry_domain.UplinkFrame, batteryMv float64) telemetry_domain.Reading {
+		reading, err := telemetry_domain.NewReading(frame.ID, frame.DeviceID, frame.ReceivedAt, frame.ReceivedAt, map[string]float64{"battery_mv": batteryMv})
+		require.NoError(t, err)
+		return reading
+	}
+	streaming := func(archive *telemetry_domain_mocks.UplinkArchive, frames ...telemetry_domain.UplinkFrame) {
+		archive.On("Stream", ctx, "device-1", from, to, mock.Anything).Run(func(args mock.Arguments) {
+			fn := args.Get(4).(func(telemetry_domain.UplinkFrame) error)
+			for _, frame := range frames {
+				require.NoError(t, fn(frame))
+			}
+		}).Return(nil).Once()
+	}
+	replay := func(t *testing.T, replayer *telemetry_application.UplinkReplayer, dryRun bool) []telemetry_application.UplinkReplayResult {
+		results := []telemetry_application.UplinkReplayResult{}
+		require.NoError(t, replayer.Replay(ctx, "device-1", from, to, dryRun, func(result telemetry_application.UplinkReplayResult) error {
+			results = append(results, result)
+			return nil
+		}))
+		return results
+	}
+
+	t.Run("should replace the readings decoded differently and skip the unchanged ones", func(t *testing.T) {
+		archive := telemetry_domain_mocks.NewUplinkArchive(t)
+		decoder := telemetry_domain_mocks.NewUplinkDecoder(t)
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		changed, unchanged, missing := newFrame(t), newFrame(t), newFrame(t)
+
+		streaming(archive, changed, unchanged, missing)
+		repository.On("Find", ctx, changed.ID).Return(&telemetry_domain.Reading{ID: changed.ID, RecordedAt: changed.ReceivedAt, Metrics: map[string]float64{"battery_mv": 29}}, nil).Once()
+		repository.On("Find", ctx, unchanged.ID).Return(&telemetry_domain.Reading{ID: unchanged.ID, RecordedAt: unchanged.ReceivedAt, Metrics: map[string]float64{"battery_mv": 2900}}, nil).Once()
+		repository.On("Find", ctx, missing.ID).Return(nil, nil).Once()
+		for _, frame := range []telemetry_domain.UplinkFrame{changed, unchanged, missing} {
+			decoder.On("Decode", frame).Return([]telemetry_domain.Reading{newReading(t, frame, 2900)}, nil).Once()
+		}
+		repository.On("Replace", ctx, newReading(t, changed, 2900)).Return(nil).Once()
+		repository.On("Replace", ctx, newReading(t, missing, 2900).AsBackfilled()).Return(nil).Once()
+		rollups := telemetry_domain_mocks.NewRollupRepository(t)
+		day := telemetry_domain.DailyResolution.Bucket(now.Add(-time.Minute))
+		rollups.On("Rebuild", ctx, "device-1", day, day.Add(24*time.Hour)).Return(nil).Once()
+
+		results := replay(t, telemetry_application.NewUplinkReplayer(archive, decoder, repository, rollups), false)
+
+		require.Len(t, results, 3)
+		assert.Equal(t, telemetry_application.UpdatedUplinkReplay, results[0].Outcome)
+		assert.Equal(t, telemetry_application.UnchangedUplinkReplay, results[1].Outcome)
+		assert.Equal(t, telemetry_application.CreatedUplinkReplay, results[2].Outcome)
+	})
+
+	t.Run("should rebuild the rollups of the days a replayed reading moved from and to", func(t *testing.T) {
+		archive := telemetry_domain_mocks.NewUplinkArchive(t)
+		decoder := telemetry_domain_mocks.NewUplinkDecoder(t)
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		rollups := telemetry_domain_mocks.NewRollupRepository(t)
+		frame := newFrame(t)
+		dayBefore := frame.ReceivedAt.Add(-24 * time.Hour)
+
+		streaming(archive, frame)
+		repository.On("Find", ctx, frame.ID).Return(&telemetry_domain.Reading{ID: frame.ID, RecordedAt: dayBefore, Metrics: map[string]float64{"battery_mv": 2900}}, nil).Once()
+		decoder.On("Decode", frame).Return([]telemetry_domain.Reading{newReading(t, frame, 2900)}, nil).Once()
+		repository.On("Replace", ctx, newReading(t, frame, 2900)).Return(nil).Once()
+		for _, recordedAt := range []time.Time{dayBefore, frame.ReceivedAt} {
+			day := telemetry_domain.DailyResolution.Bucket(recordedAt)
+			rollups.On("Rebuild", ctx, "device-1", day, day.Add(24*time.Hour)).Return(nil).Once()
+		}
+
+		results := replay(t, telemetry_application.NewUplinkReplayer(archive, decoder, repository, rollups), false)
+
+		require.Len(t, results, 1)
+		assert.Equal(t, telemetry_application.UpdatedUplinkReplay, results[0].Outcome)
+	})
+
+	t.Run("should not write on dry runs", func(t *testing.T) {
+		archive := telemetry_domain_mocks.NewUplinkArchive(t)
+		decoder := telemetry_domain_mocks.NewUplinkDecoder(t)
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		frame := newFrame(t)
+
+		streaming(archive, frame)
+		repository.On("Find", ctx, frame.ID).Return(nil, nil).Once()
+		decoder.On("Decode", frame).Return([]telemetry_domain.Reading{newReading(t, frame, 2900)}, nil).Once()
+
+		results := replay(t, telemetry_application.NewUplinkReplayer(archive, decoder, repository, telemetry_domain_mocks.NewRollupRepository(t)), true)
+
+		require.Len(t, results, 1)
+		assert.Equal(t, telemetry_application.CreatedUplinkReplay, results[0].Outcome)
+	})
+
+	t.Run("should report the frames still undecodable", func(t *testing.T) {
+		archive := telemetry_domain_mocks.NewUplinkArchive(t)
+		decoder := telemetry_domain_mocks.NewUplinkDecoder(t)
+		repository := telemetry_domain_mocks.NewReadingRepository(t)
+		frame := newFrame(t)
+
+		streaming(archive, frame)
+		repository.On("Find", ctx, frame.ID).Return(nil, nil).Once()
+		decoder.On("Decode", frame).Return(nil, telemetry_domain.NewInvalidReading("device-1", "metrics")).Once()
+
+		results := replay(t, telemetry_application.NewUplinkReplayer(archive, decoder, repository, telemetry_domain_mocks.NewRollupRepository(t)), false)
+
+		require.Len(t, results, 1)
+		assert.Equal(t, telemetry_application.UndecodableUplinkReplay, results[0].Outcome)
+		assert.IsType(t, &telemetry_domain.InvalidReading{}, results[0].Err)
+	})
+}
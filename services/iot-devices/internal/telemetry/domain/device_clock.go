@@ -0,0 +1,82 @@
+package telemetry_domain
+
+import (
+	"context"
+	"time"
+)
+
+// ClockSyncSample is a round trip between a device and the server: the device sent an
+// uplink at SentAt, the server answered with its time, and the device got the answer at
+// ReceivedAt. Both device times come from the device clock.
+type ClockSyncSample struct {
+	DeviceID   string
+	SentAt     time.Time
+	ServerTime time.Time
+	ReceivedAt time.Time
+}
+
+// NewClockSyncSample rejects the round trips longer than maxRoundTrip, as the longer the
+// round trip the less the server time tells about the device clock.
+func NewClockSyncSample(
+	deviceID string,
+	sentAt time.Time,
+	serverTime time.Time,
+	receivedAt time.Time,
+	maxRoundTrip time.Duration,
+) (ClockSyncSample, error) {
+	if sentAt.IsZero() || serverTime.IsZero() || receivedAt.IsZero() {
+		return ClockSyncSample{}, NewInvalidClockSyncSample(deviceID, "missing times")
+	}
+	if receivedAt.Before(sentAt) {
+		return ClockSyncSample{}, NewInvalidClockSyncSample(deviceID, "received before sent")
+	}
+	if receivedAt.Sub(sentAt) > maxRoundTrip {
+		return ClockSyncSample{}, NewInvalidClockSyncSample(deviceID, "round trip too long")
+	}
+
+	return ClockSyncSample{DeviceID: deviceID, SentAt: sentAt, ServerTime: serverTime, ReceivedAt: receivedAt}, nil
+}
+
+func (s ClockSyncSample) RoundTrip() time.Duration {
+	return s.ReceivedAt.Sub(s.SentAt)
+}
+
+// Offset is what the device clock lags behind the server clock, taking the server time as
+// read halfway through the round trip.
+func (s ClockSyncSample) Offset() time.Duration {
+	return s.ServerTime.Sub(s.SentAt.Add(s.RoundTrip() / 2))
+}
+
+// DeviceClock is the latest estimation of the offset of a device clock. Device times
+// plus the offset give server times.
+type DeviceClock struct {
+	DeviceID    string
+	Offset      time.Duration
+	RoundTrip   time.Duration
+	Samples     int64
+	Drifting    bool
+	EstimatedAt time.Time
+}
+
+// Synced estimates the offset from the latest sample, as drift keeps growing and older
+// samples only get staler. It tells whether the device just started drifting beyond
+// the threshold.
+func (dc DeviceClock) Synced(sample ClockSyncSample, driftThreshold time.Duration, now time.Time) (DeviceClock, bool) {
+	wasDrifting := dc.Drifting
+
+	dc.DeviceID = sample.DeviceID
+	dc.Offset = sample.Offset()
+	dc.RoundTrip = sample.RoundTrip()
+	dc.Samples++
+	dc.Drifting = dc.Offset > driftThreshold || -dc.Offset > driftThreshold
+	dc.EstimatedAt = now
+
+	return dc, dc.Drifting && !wasDrifting
+}
+
+type DeviceClockRepository interface {
+	Save(ctx context.Context, clock DeviceClock) error
+	// Find returns nil when the device never synced its clock
+	Find(ctx context.Context, deviceID string) (*DeviceClock, error)
+	SearchDrifting(ctx context.Context) ([]DeviceClock, error)
+}
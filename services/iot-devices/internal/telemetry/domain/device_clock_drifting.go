@@ -0,0 +1,29 @@
+package telemetry_domain
+
+import "time"
+
+const DeviceClockDriftingEventName = "telemetry.device_clock_drifting"
+
+type DeviceClockDrifting struct {
+	clock DeviceClock
+}
+
+func NewDeviceClockDrifting(clock DeviceClock) DeviceClockDrifting {
+	return DeviceClockDrifting{clock: clock}
+}
+
+func (dcd DeviceClockDrifting) Name() string {
+	return DeviceClockDriftingEventName
+}
+
+func (dcd DeviceClockDrifting) Type() string {
+	return "domain_event"
+}
+
+func (dcd DeviceClockDrifting) Data() map[string]interface{} {
+	return map[string]interface{}{
+		"device_id":      dcd.clock.DeviceID,
+		"offset_seconds": dcd.clock.Offset.Seconds(),
+		"at":             dcd.clock.EstimatedAt.Format(time.RFC3339),
+	}
+}
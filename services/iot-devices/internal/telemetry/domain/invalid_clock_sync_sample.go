@@ -0,0 +1,24 @@
+package telemetry_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidClockSyncSampleErrorMessage = "Invalid clock sync sample"
+
+type InvalidClockSyncSample struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (icss InvalidClockSyncSample) Error() string {
+	return invalidClockSyncSampleErrorMessage
+}
+
+func (icss InvalidClockSyncSample) ExtraItems() map[string]interface{} {
+	return icss.items
+}
+
+func NewInvalidClockSyncSample(deviceID string, reason string) *InvalidClockSyncSample {
+	return &InvalidClockSyncSample{items: map[string]interface{}{"device_id": deviceID, "reason": reason}}
+}
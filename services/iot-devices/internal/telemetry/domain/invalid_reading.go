@@ -0,0 +1,24 @@
+package telemetry_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidReadingErrorMessage = "Invalid reading"
+
+type InvalidReading struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ir InvalidReading) Error() string {
+	return invalidReadingErrorMessage
+}
+
+func (ir InvalidReading) ExtraItems() map[string]interface{} {
+	return ir.items
+}
+
+func NewInvalidReading(deviceID string, field string) *InvalidReading {
+	return &InvalidReading{items: map[string]interface{}{"device_id": deviceID, "field": field}}
+}
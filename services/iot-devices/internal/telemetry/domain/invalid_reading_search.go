@@ -0,0 +1,24 @@
+package telemetry_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidReadingSearchErrorMessage = "Invalid reading search"
+
+type InvalidReadingSearch struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (irs InvalidReadingSearch) Error() string {
+	return invalidReadingSearchErrorMessage
+}
+
+func (irs InvalidReadingSearch) ExtraItems() map[string]interface{} {
+	return irs.items
+}
+
+func NewInvalidReadingSearch(scopeID string, field string, reason string) *InvalidReadingSearch {
+	return &InvalidReadingSearch{items: map[string]interface{}{"id": scopeID, "field": field, "reason": reason}}
+}
@@ -0,0 +1,24 @@
+package telemetry_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidTelemetrySeriesErrorMessage = "Invalid telemetry series"
+
+type InvalidTelemetrySeries struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (its InvalidTelemetrySeries) Error() string {
+	return invalidTelemetrySeriesErrorMessage
+}
+
+func (its InvalidTelemetrySeries) ExtraItems() map[string]interface{} {
+	return its.items
+}
+
+func NewInvalidTelemetrySeries(scopeID string, field string, reason string) *InvalidTelemetrySeries {
+	return &InvalidTelemetrySeries{items: map[string]interface{}{"id": scopeID, "field": field, "reason": reason}}
+}
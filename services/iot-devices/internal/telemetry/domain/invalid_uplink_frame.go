@@ -0,0 +1,24 @@
+package telemetry_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidUplinkFrameErrorMessage = "Invalid uplink frame"
+
+type InvalidUplinkFrame struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (iuf InvalidUplinkFrame) Error() string {
+	return invalidUplinkFrameErrorMessage
+}
+
+func (iuf InvalidUplinkFrame) ExtraItems() map[string]interface{} {
+	return iuf.items
+}
+
+func NewInvalidUplinkFrame(deviceID string, field string) *InvalidUplinkFrame {
+	return &InvalidUplinkFrame{items: map[string]interface{}{"device_id": deviceID, "field": field}}
+}
@@ -0,0 +1,100 @@
+package telemetry_domain
+
+import (
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const deviceIdMaxLength = 50
+
+// Reading is a decoded uplink of a device: the metrics it measured at the time it recorded them.
+type Reading struct {
+	ID       string
+	DeviceID string
+	// RecordedAt is the time the device recorded the reading in server time, and
+	// RawRecordedAt the same time as the device clock told it
+	RecordedAt    time.Time
+	RawRecordedAt time.Time
+	ClockOffset   time.Duration
+	ReceivedAt    time.Time
+	Metrics       map[string]float64
+	// Backfilled readings arrived long after they were recorded, like the ones devices
+	// buffer while offline, so they tell nothing about the device right now
+	Backfilled bool
+	// PestEvents are the events pest control devices reported along the reading. Only the
+	// metrics they stand for, merged into Metrics, are stored
+	PestEvents []pestcontrol_domain.PestEvent
+}
+
+func NewReading(
+	id string,
+	deviceID string,
+	recordedAt time.Time,
+	receivedAt time.Time,
+	metrics map[string]float64,
+) (Reading, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidReading(deviceID, "id")); err != nil {
+		return Reading{}, err
+	}
+
+	deviceIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(deviceIdMaxLength),
+	)
+	if err := deviceIdValidator.Validate(deviceID, NewInvalidReading(deviceID, "device_id")); err != nil {
+		return Reading{}, err
+	}
+
+	if recordedAt.IsZero() {
+		return Reading{}, NewInvalidReading(deviceID, "recorded_at")
+	}
+
+	if len(metrics) == 0 {
+		return Reading{}, NewInvalidReading(deviceID, "metrics")
+	}
+
+	return Reading{
+		ID:            id,
+		DeviceID:      deviceID,
+		RecordedAt:    recordedAt,
+		RawRecordedAt: recordedAt,
+		ReceivedAt:    receivedAt,
+		Metrics:       metrics,
+	}, nil
+}
+
+// CorrectedBy moves the time the reading was recorded to server time, given the offset
+// of the device clock.
+func (r Reading) CorrectedBy(clockOffset time.Duration) Reading {
+	r.RecordedAt = r.RawRecordedAt.Add(clockOffset)
+	r.ClockOffset = clockOffset
+	if r.PestEvents != nil {
+		pestEvents := make([]pestcontrol_domain.PestEvent, 0, len(r.PestEvents))
+		for _, pestEvent := range r.PestEvents {
+			pestEvents = append(pestEvents, pestEvent.CorrectedBy(clockOffset))
+		}
+		r.PestEvents = pestEvents
+	}
+
+	return r
+}
+
+func (r Reading) WithPestEvents(pestEvents []pestcontrol_domain.PestEvent) Reading {
+	r.PestEvents = pestEvents
+
+	return r
+}
+
+func (r Reading) AsBackfilled() Reading {
+	r.Backfilled = true
+
+	return r
+}
+
+// LateAfter tells whether the reading was received more than delay after it was recorded.
+func (r Reading) LateAfter(delay time.Duration) bool {
+	return r.ReceivedAt.Sub(r.RecordedAt) > delay
+}
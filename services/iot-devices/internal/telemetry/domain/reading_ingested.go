@@ -0,0 +1,29 @@
+package telemetry_domain
+
+const ReadingIngestedEventName = "telemetry.reading_ingested"
+
+type ReadingIngested struct {
+	reading Reading
+}
+
+func NewReadingIngested(reading Reading) ReadingIngested {
+	return ReadingIngested{reading: reading}
+}
+
+func (ri ReadingIngested) Name() string {
+	return ReadingIngestedEventName
+}
+
+func (ri ReadingIngested) Type() string {
+	return "domain_event"
+}
+
+func (ri ReadingIngested) Data() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          ri.reading.ID,
+		"device_id":   ri.reading.DeviceID,
+		"recorded_at": ri.reading.RecordedAt,
+		"metrics":     ri.reading.Metrics,
+		"backfilled":  ri.reading.Backfilled,
+	}
+}
@@ -0,0 +1,17 @@
+package telemetry_domain
+
+import "context"
+
+type ReadingRepository interface {
+	Save(ctx context.Context, reading Reading) error
+	// SaveAll stores either every reading or none of them, and returns the ones inserted,
+	// leaving out the ones already stored
+	SaveAll(ctx context.Context, readings []Reading) ([]Reading, error)
+	// Find returns nil when there is no reading with the id
+	Find(ctx context.Context, id string) (*Reading, error)
+	// Replace overwrites the decoded values of the reading, keeping when it was received,
+	// or saves it when missing
+	Replace(ctx context.Context, reading Reading) error
+	// Search returns up to criteria.Limit readings matching the criteria, newest first
+	Search(ctx context.Context, criteria ReadingCriteria) ([]Reading, error)
+}
@@ -0,0 +1,76 @@
+package telemetry_domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+type ReadingType string
+
+const (
+	LiveReading       ReadingType = "live"
+	BackfilledReading ReadingType = "backfilled"
+)
+
+var readingTypes = map[string]struct{}{
+	string(LiveReading):       {},
+	string(BackfilledReading): {},
+}
+
+func NewReadingType(scopeID string, value string) (ReadingType, error) {
+	err := domain_validation.NewDomainValidator(domain_validation.In(readingTypes)).
+		Validate(value, NewInvalidReadingSearch(scopeID, "type", "unknown"))
+	if err != nil {
+		return "", err
+	}
+
+	return ReadingType(value), nil
+}
+
+// ReadingCriteria searches the readings of a device, or of the devices of a site, newest
+// first. Zero times leave the range open, Metrics matches the readings with any of them,
+// and After continues the search right after the last reading of the previous page.
+type ReadingCriteria struct {
+	DeviceID string
+	SiteID   string
+	From     time.Time
+	To       time.Time
+	Metrics  []string
+	Type     ReadingType
+	After    *ReadingCursor
+	Limit    int
+}
+
+// ReadingCursor is the position of a reading in the search order, the keyset the next
+// page starts after.
+type ReadingCursor struct {
+	RecordedAt time.Time
+	ID         string
+}
+
+func NewReadingCursorOf(reading Reading) ReadingCursor {
+	return ReadingCursor{RecordedAt: reading.RecordedAt, ID: reading.ID}
+}
+
+// DecodeReadingCursor reads the opaque cursors handed to the clients by Encode.
+func DecodeReadingCursor(scopeID string, encoded string) (ReadingCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ReadingCursor{}, NewInvalidReadingSearch(scopeID, "cursor", "not a cursor")
+	}
+
+	rawRecordedAt, id, found := strings.Cut(string(decoded), "|")
+	recordedAt, err := time.Parse(time.RFC3339Nano, rawRecordedAt)
+	if !found || err != nil || id == "" {
+		return ReadingCursor{}, NewInvalidReadingSearch(scopeID, "cursor", "not a cursor")
+	}
+
+	return ReadingCursor{RecordedAt: recordedAt, ID: id}, nil
+}
+
+func (rc ReadingCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(rc.RecordedAt.UTC().Format(time.RFC3339Nano) + "|" + rc.ID))
+}
@@ -0,0 +1,35 @@
+package telemetry_domain
+
+import "time"
+
+const ReadingsBackfilledEventName = "telemetry.readings_backfilled"
+
+// ReadingsBackfilled tells that readings recorded in [From, To] arrived late, so anything
+// computed from the readings of that period, like rollups, has to be computed again.
+type ReadingsBackfilled struct {
+	deviceID string
+	from     time.Time
+	to       time.Time
+	readings int
+}
+
+func NewReadingsBackfilled(deviceID string, from time.Time, to time.Time, readings int) ReadingsBackfilled {
+	return ReadingsBackfilled{deviceID: deviceID, from: from, to: to, readings: readings}
+}
+
+func (rb ReadingsBackfilled) Name() string {
+	return ReadingsBackfilledEventName
+}
+
+func (rb ReadingsBackfilled) Type() string {
+	return "domain_event"
+}
+
+func (rb ReadingsBackfilled) Data() map[string]interface{} {
+	return map[string]interface{}{
+		"device_id": rb.deviceID,
+		"from":      rb.from,
+		"to":        rb.to,
+		"readings":  rb.readings,
+	}
+}
@@ -0,0 +1,115 @@
+package telemetry_domain
+
+import (
+	"context"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+type RollupResolution string
+
+const (
+	RawResolution    RollupResolution = "raw"
+	HourlyResolution RollupResolution = "hour"
+	DailyResolution  RollupResolution = "day"
+)
+
+// Longest ranges charted with each resolution, which keep a series of readings every five
+// minutes under a few thousand points.
+const (
+	rawResolutionMaxRange    = 2 * 24 * time.Hour
+	hourlyResolutionMaxRange = 90 * 24 * time.Hour
+)
+
+var rollupResolutions = map[string]struct{}{
+	string(RawResolution):    {},
+	string(HourlyResolution): {},
+	string(DailyResolution):  {},
+}
+
+func NewRollupResolution(value string) (RollupResolution, error) {
+	err := domain_validation.NewDomainValidator(domain_validation.In(rollupResolutions)).
+		Validate(value, NewInvalidTelemetrySeries("", "resolution", "unknown"))
+	if err != nil {
+		return "", err
+	}
+
+	return RollupResolution(value), nil
+}
+
+// RollupResolutionFor is the finest resolution that keeps the series of [from, to] short.
+func RollupResolutionFor(from time.Time, to time.Time) RollupResolution {
+	switch span := to.Sub(from); {
+	case span <= rawResolutionMaxRange:
+		return RawResolution
+	case span <= hourlyResolutionMaxRange:
+		return HourlyResolution
+	default:
+		return DailyResolution
+	}
+}
+
+// Bucket is the start of the bucket the time falls in. Buckets are in UTC, and every
+// reading is a bucket of its own at raw resolution.
+func (r RollupResolution) Bucket(at time.Time) time.Time {
+	at = at.UTC()
+
+	switch r {
+	case HourlyResolution:
+		return at.Truncate(time.Hour)
+	case DailyResolution:
+		return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	default:
+		return at
+	}
+}
+
+// RollupDaysCovering is the range of whole days covering [from, to], the one to rebuild
+// for the readings of that period.
+func RollupDaysCovering(from time.Time, to time.Time) (time.Time, time.Time) {
+	return DailyResolution.Bucket(from), DailyResolution.Bucket(to).Add(24 * time.Hour)
+}
+
+func (r RollupResolution) Value() string {
+	return string(r)
+}
+
+// MetricRollup summarizes the values a metric took in a bucket. The sum is what counters
+// like trap_triggered or bait_consumed_g are charted with, and min, avg and max are for
+// gauges like the battery or the temperature.
+type MetricRollup struct {
+	Readings int64
+	Sum      float64
+	Min      float64
+	Max      float64
+}
+
+func (mr MetricRollup) Avg() float64 {
+	if mr.Readings == 0 {
+		return 0
+	}
+
+	return mr.Sum / float64(mr.Readings)
+}
+
+// Rollup is a bucket of the readings of a device, or of all the devices of a site.
+type Rollup struct {
+	Resolution  RollupResolution
+	BucketStart time.Time
+	Metrics     map[string]MetricRollup
+}
+
+// RollupRepository keeps the hourly and daily rollups of every device, along with the site
+// the device was at, so sites are charted adding up the rollups of their devices.
+type RollupRepository interface {
+	// Add folds a reading into the hourly and daily buckets it falls in
+	Add(ctx context.Context, reading Reading) error
+	// Rebuild computes again from the readings the buckets of [from, to), which must start
+	// and end at day boundaries. An empty device id rebuilds the buckets of every device
+	Rebuild(ctx context.Context, deviceID string, from time.Time, to time.Time) error
+	// SearchByDevice and SearchBySite return the buckets of [from, to) in order. Devices
+	// are read from the readings themselves at raw resolution, which sites have not
+	SearchByDevice(ctx context.Context, deviceID string, resolution RollupResolution, from time.Time, to time.Time) ([]Rollup, error)
+	SearchBySite(ctx context.Context, siteID string, resolution RollupResolution, from time.Time, to time.Time) ([]Rollup, error)
+}
@@ -0,0 +1,36 @@
+package telemetry_domain
+
+import (
+	"context"
+	"time"
+)
+
+// UplinkBacklog is how far behind a device flushing its buffered readings is: the
+// readings it reported as still buffered, and the age of the newest one it sent.
+type UplinkBacklog struct {
+	DeviceID   string
+	Remaining  int64
+	Lag        time.Duration
+	Backfilled int64
+	UpdatedAt  time.Time
+}
+
+// Flushed accounts for a batch of backfilled readings, the newest recorded at newest.
+func (ub UplinkBacklog) Flushed(readings int, remaining int64, newest time.Time, now time.Time) UplinkBacklog {
+	ub.Remaining = remaining
+	ub.Lag = now.Sub(newest)
+	if ub.Lag < 0 {
+		ub.Lag = 0
+	}
+	ub.Backfilled += int64(readings)
+	ub.UpdatedAt = now
+
+	return ub
+}
+
+type UplinkBacklogRepository interface {
+	Save(ctx context.Context, backlog UplinkBacklog) error
+	// Find returns an empty backlog for the devices that never sent one
+	Find(ctx context.Context, deviceID string) (UplinkBacklog, error)
+	SearchAll(ctx context.Context) ([]UplinkBacklog, error)
+}
@@ -0,0 +1,38 @@
+package telemetry_domain
+
+import (
+	"context"
+	"time"
+)
+
+// UplinkDuplicates counts the uplinks of a device dropped as retransmissions of an uplink
+// already received.
+type UplinkDuplicates struct {
+	DeviceID string
+	Count    int64
+	LastAt   *time.Time
+}
+
+// UplinkSequenceState is where the uplink of a sequence number is at: never received,
+// received and still being ingested, or already ingested.
+type UplinkSequenceState string
+
+const (
+	UnseenUplinkSequence    UplinkSequenceState = "unseen"
+	IngestingUplinkSequence UplinkSequenceState = "ingesting"
+	IngestedUplinkSequence  UplinkSequenceState = "ingested"
+)
+
+// UplinkSequenceRegistry remembers the sequence numbers the devices sent lately. Devices
+// retransmit when they miss the response, and gateway replicas may forward a frame twice,
+// both with the same sequence number.
+type UplinkSequenceRegistry interface {
+	// Register registers the sequence number of the device as being ingested and returns
+	// the state it had before, counting the uplink as a duplicate when already ingested
+	Register(ctx context.Context, deviceID string, sequence int64, at time.Time) (UplinkSequenceState, error)
+	// Ingested marks the sequence number of the device as ingested
+	Ingested(ctx context.Context, deviceID string, sequence int64) error
+	// Unregister forgets the sequence number of the device, so its retransmission is not a duplicate
+	Unregister(ctx context.Context, deviceID string, sequence int64) error
+	Duplicates(ctx context.Context, deviceID string) (UplinkDuplicates, error)
+}
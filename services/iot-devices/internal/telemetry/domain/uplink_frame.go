@@ -0,0 +1,69 @@
+package telemetry_domain
+
+import (
+	"context"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+// UplinkFrame is an uplink as the device sent it, kept before decoding so readings can be
+// decoded again when a decoder turns out to be wrong. The reading decoded from a plain
+// uplink shares the id of its frame.
+type UplinkFrame struct {
+	ID         string
+	DeviceID   string
+	ReceivedAt time.Time
+	Payload    []byte
+	// Metadata keeps how the frame was received, like the content type or the client ip
+	Metadata map[string]string
+}
+
+func NewUplinkFrame(
+	id string,
+	deviceID string,
+	receivedAt time.Time,
+	payload []byte,
+	metadata map[string]string,
+) (UplinkFrame, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidUplinkFrame(deviceID, "id")); err != nil {
+		return UplinkFrame{}, err
+	}
+
+	deviceIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(deviceIdMaxLength),
+	)
+	if err := deviceIdValidator.Validate(deviceID, NewInvalidUplinkFrame(deviceID, "device_id")); err != nil {
+		return UplinkFrame{}, err
+	}
+
+	if receivedAt.IsZero() {
+		return UplinkFrame{}, NewInvalidUplinkFrame(deviceID, "received_at")
+	}
+
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	return UplinkFrame{
+		ID:         id,
+		DeviceID:   deviceID,
+		ReceivedAt: receivedAt,
+		Payload:    payload,
+		Metadata:   metadata,
+	}, nil
+}
+
+type UplinkArchive interface {
+	Save(ctx context.Context, frame UplinkFrame) error
+	// Stream calls fn with the frames of the device received in [from, to), oldest first,
+	// stopping at the first error
+	Stream(ctx context.Context, deviceID string, from time.Time, to time.Time, fn func(frame UplinkFrame) error) error
+}
+
+// UplinkDecoder turns the payload of a frame into the readings it carries: one for the
+// plain uplinks, and the buffered ones in sequence order for the backlog batches.
+type UplinkDecoder interface {
+	Decode(frame UplinkFrame) ([]Reading, error)
+}
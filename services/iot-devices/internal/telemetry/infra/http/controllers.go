@@ -0,0 +1,516 @@
+package telemetry_http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/jsonapi"
+	"github.com/gorilla/mux"
+
+	downlinks_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/application"
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_query_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// ServerTimeHeader carries the server time in every answer to an uplink.
+const ServerTimeHeader = "X-Server-Time"
+
+// NewIngestReadingController decodes the frame archived for the uplink, so replays go
+// through the very same decoder, and answers with the downlink commands queued for the
+// device, as sleepy devices only listen right after they uplink. The acks of the
+// commands received before travel along the uplink, and so does the round trip of the
+// previous exchange, which syncs the device clock before its readings are corrected.
+// Duplicate uplinks skip the ingestion and get the same answer.
+func NewIngestReadingController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	decoder telemetry_domain.UplinkDecoder,
+	timeProvider amf_utils.DateTimeProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerError()
+			jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+			return
+		}
+
+		deviceID := mux.Vars(r)["deviceId"]
+		if isDuplicateUplink(r.Context()) {
+			writeUplinkResponse(w, r, commandBus, queryBus, timeProvider, jarm, deviceID, downlinkAcks(deviceID, requestParams))
+			return
+		}
+
+		if err := syncDeviceClock(r, commandBus, deviceID, requestParams); err != nil {
+			writeIngestionError(w, r, jarm, err)
+			return
+		}
+
+		readings, err := decodeArchivedUplink(r, decoder, deviceID)
+		if err == nil && len(readings) != 1 {
+			err = telemetry_domain.NewInvalidReading(deviceID, "readings")
+		}
+		if err == nil {
+			reading := readings[0]
+			command := telemetry_application.NewIngestReadingCommand(reading.ID, reading.DeviceID, reading.RecordedAt, reading.Metrics)
+			command.PestEvents = reading.PestEvents
+			err = commandBus.Dispatch(r.Context(), command)
+		}
+
+		if err != nil {
+			writeIngestionError(w, r, jarm, err)
+			return
+		}
+
+		writeUplinkResponse(w, r, commandBus, queryBus, timeProvider, jarm, deviceID, downlinkAcks(deviceID, requestParams))
+	}
+}
+
+// NewIngestBacklogController takes the readings devices buffered while offline. They are
+// stored in sequence order as backfilled, so they raise no alerts, and the uplink gets
+// the same answer as any other.
+func NewIngestBacklogController(
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	decoder telemetry_domain.UplinkDecoder,
+	timeProvider amf_utils.DateTimeProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := amf_http_server.AllParamsRequest(r)
+		if err != nil {
+			ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerError()
+			jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+			return
+		}
+
+		deviceID := mux.Vars(r)["deviceId"]
+		if err := syncDeviceClock(r, commandBus, deviceID, requestParams); err != nil {
+			writeIngestionError(w, r, jarm, err)
+			return
+		}
+
+		readings, err := decodeArchivedUplink(r, decoder, deviceID)
+		if err == nil {
+			remaining, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", "remaining"}, requestParams, float64(0)).(float64)
+			command := &telemetry_application.IngestBacklogCommand{
+				DeviceID:  deviceID,
+				Remaining: int64(remaining),
+				Readings:  make([]telemetry_application.BacklogReading, 0, len(readings)),
+			}
+			for _, reading := range readings {
+				command.Readings = append(command.Readings, telemetry_application.BacklogReading{
+					ID:         reading.ID,
+					RecordedAt: reading.RecordedAt,
+					Metrics:    reading.Metrics,
+					PestEvents: reading.PestEvents,
+				})
+			}
+			err = commandBus.Dispatch(r.Context(), command)
+		}
+
+		if err != nil {
+			writeIngestionError(w, r, jarm, err)
+			return
+		}
+
+		writeUplinkResponse(w, r, commandBus, queryBus, timeProvider, jarm, deviceID, downlinkAcks(deviceID, requestParams))
+	}
+}
+
+// syncDeviceClock takes the round trip the device reports, if any. Unusable round trips are
+// left out, as they are no reason to reject the readings.
+func syncDeviceClock(
+	r *http.Request,
+	commandBus amf_command_bus.Bus,
+	deviceID string,
+	requestParams map[string]interface{},
+) error {
+	clockSync, ok := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", "clock_sync"}, requestParams, nil).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	command := &telemetry_application.SyncDeviceClockCommand{
+		DeviceID:   deviceID,
+		SentAt:     clockSyncTime(clockSync, "sent_at"),
+		ServerTime: clockSyncTime(clockSync, "server_time"),
+		ReceivedAt: clockSyncTime(clockSync, "received_at"),
+	}
+	switch err := commandBus.Dispatch(r.Context(), command); err.(type) {
+	case nil, *telemetry_domain.InvalidClockSyncSample:
+		return nil
+	default:
+		return err
+	}
+}
+
+func clockSyncTime(clockSync map[string]interface{}, key string) time.Time {
+	raw, _ := clockSync[key].(string)
+	parsed, _ := time.Parse(time.RFC3339Nano, raw)
+
+	return parsed
+}
+
+func decodeArchivedUplink(
+	r *http.Request,
+	decoder telemetry_domain.UplinkDecoder,
+	deviceID string,
+) ([]telemetry_domain.Reading, error) {
+	archived, ok := archivedUplinkFrameFrom(r.Context())
+	if !ok {
+		return nil, fmt.Errorf("uplink frame not archived")
+	}
+
+	return decoder.Decode(telemetry_domain.UplinkFrame{
+		ID:         archived.ID,
+		DeviceID:   deviceID,
+		ReceivedAt: archived.ReceivedAt,
+		Payload:    archived.Payload,
+		Metadata:   archived.Metadata,
+	})
+}
+
+func writeIngestionError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *telemetry_domain.InvalidReading:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *pestcontrol_domain.InvalidPestEvent:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+	}
+}
+
+func NewGetUplinkBacklogController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &telemetry_application.FindUplinkBacklogQuery{DeviceID: mux.Vars(r)["deviceId"]}
+		queryResponse, err := queryBus.Ask(r.Context(), query)
+		if err != nil {
+			ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+			jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	}
+}
+
+func NewGetUplinkDuplicatesController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &telemetry_application.FindUplinkDuplicatesQuery{DeviceID: mux.Vars(r)["deviceId"]}
+		queryResponse, err := queryBus.Ask(r.Context(), query)
+		if err != nil {
+			ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+			jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	}
+}
+
+func NewGetDeviceClockController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := &telemetry_application.FindDeviceClockQuery{DeviceID: mux.Vars(r)["deviceId"]}
+		queryResponse, err := queryBus.Ask(r.Context(), query)
+		if err != nil {
+			ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+			jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	}
+}
+
+func NewSearchDriftingDeviceClocksController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		queryResponse, err := queryBus.Ask(r.Context(), &telemetry_application.SearchDriftingDeviceClocksQuery{})
+		if err != nil {
+			ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+			jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+			return
+		}
+
+		jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+	}
+}
+
+func NewGetDeviceTelemetryController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeTelemetrySeries(w, r, queryBus, jarm, &telemetry_application.SearchTelemetrySeriesQuery{DeviceID: mux.Vars(r)["deviceId"]})
+	}
+}
+
+func NewGetSiteTelemetryController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeTelemetrySeries(w, r, queryBus, jarm, &telemetry_application.SearchTelemetrySeriesQuery{SiteID: mux.Vars(r)["siteId"]})
+	}
+}
+
+// writeTelemetrySeries completes the query with the RFC3339 range and the resolution of
+// the filters, all of them optional.
+func writeTelemetrySeries(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query *telemetry_application.SearchTelemetrySeriesQuery,
+) {
+	filters := r.URL.Query()
+	query.Resolution = filters.Get("filter[resolution]")
+
+	var err error
+	for field, at := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+		rawTime := filters.Get("filter[" + field + "]")
+		if rawTime == "" {
+			continue
+		}
+		if *at, err = time.Parse(time.RFC3339, rawTime); err != nil {
+			err = telemetry_domain.NewInvalidTelemetrySeries(query.DeviceID+query.SiteID, field, "not a RFC3339 time")
+			break
+		}
+	}
+
+	var queryResponse interface{}
+	if err == nil {
+		queryResponse, err = queryBus.Ask(r.Context(), query)
+	}
+
+	if err != nil {
+		writeQueryError(w, r, jarm, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, queryResponse, http.StatusOK)
+}
+
+func NewGetDeviceReadingsController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeReadingsPage(w, r, queryBus, jarm, &telemetry_application.SearchReadingsQuery{DeviceID: mux.Vars(r)["deviceId"]})
+	}
+}
+
+func NewGetSiteReadingsController(
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeReadingsPage(w, r, queryBus, jarm, &telemetry_application.SearchReadingsQuery{SiteID: mux.Vars(r)["siteId"]})
+	}
+}
+
+// writeReadingsPage completes the query with the RFC3339 range, the comma separated
+// metrics and the type of the filters, and with the page[size] and page[cursor] of the
+// page. The next link repeats the request with the cursor of the next page.
+func writeReadingsPage(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus amf_query_bus.Bus,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	query *telemetry_application.SearchReadingsQuery,
+) {
+	params := r.URL.Query()
+	scopeID := query.DeviceID + query.SiteID
+	query.ReadingType = params.Get("filter[type]")
+	query.Cursor = params.Get("page[cursor]")
+	if metrics := params.Get("filter[metric]"); metrics != "" {
+		query.Metrics = strings.Split(metrics, ",")
+	}
+
+	var err error
+	for field, at := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+		rawTime := params.Get("filter[" + field + "]")
+		if rawTime == "" {
+			continue
+		}
+		if *at, err = time.Parse(time.RFC3339, rawTime); err != nil {
+			err = telemetry_domain.NewInvalidReadingSearch(scopeID, field, "not a RFC3339 time")
+			break
+		}
+	}
+	if rawPageSize := params.Get("page[size]"); err == nil && rawPageSize != "" {
+		if query.PageSize, err = strconv.Atoi(rawPageSize); err != nil {
+			err = telemetry_domain.NewInvalidReadingSearch(scopeID, "page_size", "not a number")
+		}
+	}
+
+	var queryResponse interface{}
+	if err == nil {
+		queryResponse, err = queryBus.Ask(r.Context(), query)
+	}
+	if err != nil {
+		writeQueryError(w, r, jarm, err)
+		return
+	}
+
+	page := queryResponse.(*telemetry_application.ReadingsPageResponse)
+	links := jsonapi.Links{"self": r.URL.RequestURI()}
+	if page.NextCursor != "" {
+		next := r.URL.Query()
+		next.Set("page[cursor]", page.NextCursor)
+		links["next"] = r.URL.Path + "?" + next.Encode()
+	}
+
+	document := amf_json_api.NewDocument(page.Readings).
+		WithLinks(links).
+		WithMeta(jsonapi.Meta{"page_size": page.PageSize, "count": len(page.Readings)}).
+		WithSparseFieldsets(params)
+	jarm.WriteResponse(r.Context(), w, document, http.StatusOK)
+}
+
+func writeQueryError(w http.ResponseWriter, r *http.Request, jarm *amf_json_api.JsonApiResponseMiddleware, err error) {
+	switch typedErr := err.(type) {
+	case *telemetry_domain.InvalidTelemetrySeries:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *telemetry_domain.InvalidReadingSearch:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *pestcontrol_domain.InvalidPestEvent:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	case *domain_validation.DomainValidationError:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+			metadataItemsFrom(typedErr.ExtraItems())...,
+		)
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+	default:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+	}
+}
+
+func writeUplinkResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	commandBus amf_command_bus.Bus,
+	queryBus amf_query_bus.Bus,
+	timeProvider amf_utils.DateTimeProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+	deviceID string,
+	acks []*downlinks_application.AcknowledgeDownlinkCommand,
+) {
+	// Devices send it back along their next uplink, to sync their clock
+	w.Header().Set(ServerTimeHeader, timeProvider.Now().UTC().Format(time.RFC3339Nano))
+
+	for _, ack := range acks {
+		// Devices repeat their uplinks when they miss the response, so the acks of
+		// unknown or already acknowledged commands are expected
+		switch err := commandBus.Dispatch(r.Context(), ack); err.(type) {
+		case nil, *downlinks_domain.DownlinkCommandNotExists, *downlinks_domain.DownlinkCommandTransitionNotAllowed:
+		default:
+			ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+			jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	queryResponse, err := queryBus.Ask(r.Context(), &downlinks_application.FindPendingDownlinksQuery{DeviceID: deviceID})
+	if err != nil {
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+		return
+	}
+
+	downlinks, _ := queryResponse.([]*downlinks_application.DownlinkResponse)
+	if len(downlinks) == 0 {
+		jarm.WriteResponse(r.Context(), w, nil, http.StatusNoContent)
+		return
+	}
+
+	sent := &downlinks_application.MarkDownlinksSentCommand{DeviceID: deviceID, IDs: make([]string, 0, len(downlinks))}
+	for _, downlink := range downlinks {
+		sent.IDs = append(sent.IDs, downlink.ID)
+	}
+	if err := commandBus.Dispatch(r.Context(), sent); err != nil {
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+		jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+		return
+	}
+
+	jarm.WriteResponse(r.Context(), w, downlinks, http.StatusOK)
+}
+
+func downlinkAcks(deviceID string, requestParams map[string]interface{}) []*downlinks_application.AcknowledgeDownlinkCommand {
+	rawAcks, _ := amf_utils.GetInMapValueOrDefault([]string{"data", "attributes", "downlink_acks"}, requestParams, nil).([]interface{})
+
+	acks := make([]*downlinks_application.AcknowledgeDownlinkCommand, 0, len(rawAcks))
+	for _, rawAck := range rawAcks {
+		ack, _ := rawAck.(map[string]interface{})
+		id, _ := ack["id"].(string)
+		status, _ := ack["status"].(string)
+		result, _ := ack["result"].(string)
+		acks = append(acks, &downlinks_application.AcknowledgeDownlinkCommand{
+			DeviceID: deviceID,
+			ID:       id,
+			Status:   status,
+			Result:   result,
+		})
+	}
+
+	return acks
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
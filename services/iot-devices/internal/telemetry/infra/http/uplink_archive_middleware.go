@@ -0,0 +1,72 @@
+package telemetry_http
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+
+	amf_command_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type archivedUplinkFrameKey struct{}
+
+// NewUplinkArchiveMiddleware archives the raw uplink before anything else reads it, so
+// the frames rejected by the schema or the decoder can be replayed once they are fixed.
+// Uplinks that cannot be archived fail, and devices send them again. Duplicate uplinks
+// are not archived, as replaying them would write their readings twice.
+func NewUplinkArchiveMiddleware(
+	commandBus amf_command_bus.Bus,
+	ulidProvider amf_utils.UlidProvider,
+	timeProvider amf_utils.DateTimeProvider,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) amf_http_server.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isDuplicateUplink(r.Context()) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			payload, err := io.ReadAll(amf_http_server.CloneRequest(r).Body)
+			if err != nil {
+				ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayload()
+				jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+				return
+			}
+
+			command := &telemetry_application.ArchiveUplinkFrameCommand{
+				ID:         ulidProvider.New().String(),
+				DeviceID:   mux.Vars(r)["deviceId"],
+				ReceivedAt: timeProvider.Now(),
+				Payload:    payload,
+				Metadata: map[string]string{
+					"content_type": r.Header.Get("Content-Type"),
+					"user_agent":   r.UserAgent(),
+					"client_ip":    amf_http_server.ClientIp(r),
+					"request_id":   r.Header.Get(amf_http_server.HeaderRequestIdentifier),
+				},
+			}
+			if err := commandBus.Dispatch(r.Context(), command); err != nil {
+				ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+				jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), archivedUplinkFrameKey{}, command)))
+		})
+	}
+}
+
+func archivedUplinkFrameFrom(ctx context.Context) (*telemetry_application.ArchiveUplinkFrameCommand, bool) {
+	command, ok := ctx.Value(archivedUplinkFrameKey{}).(*telemetry_application.ArchiveUplinkFrameCommand)
+
+	return command, ok
+}
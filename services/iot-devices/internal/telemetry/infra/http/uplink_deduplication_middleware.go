@@ -0,0 +1,95 @@
+package telemetry_http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+)
+
+const (
+	uplinkIngestingErrorMessage = "the uplink is still being ingested, retransmit it later"
+	uplinkIngestingRetryAfter   = "1"
+)
+
+type duplicateUplinkKey struct{}
+
+type sequencedUplink struct {
+	Data struct {
+		Attributes struct {
+			Sequence *int64 `json:"sequence"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// NewUplinkDeduplicationMiddleware flags the uplinks whose sequence number was already
+// ingested from the device. Flagged uplinks are neither archived nor ingested, but still
+// get the response devices wait for. Uplinks without sequence number are never flagged.
+// Retransmissions arriving while the uplink is still being ingested are answered as
+// unavailable, since it may still fail, and the sequence number of an uplink failing to
+// be ingested is forgotten, so the device can retransmit it.
+func NewUplinkDeduplicationMiddleware(
+	deduplicator *telemetry_application.UplinkDeduplicator,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) amf_http_server.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			payload, err := io.ReadAll(amf_http_server.CloneRequest(r).Body)
+			if err != nil {
+				ctx, writer, errResponse := r.Context(), w, json_api_response.NewBadRequestForInvalidPayload()
+				jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusBadRequest, err)
+				return
+			}
+
+			// Payloads that are not JSON are left to the schema and the decoder
+			var uplink sequencedUplink
+			if json.Unmarshal(payload, &uplink) != nil || uplink.Data.Attributes.Sequence == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			deviceID, sequence := mux.Vars(r)["deviceId"], *uplink.Data.Attributes.Sequence
+			state, err := deduplicator.Register(r.Context(), deviceID, sequence)
+			if err != nil {
+				ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+				jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+				return
+			}
+			if state == telemetry_domain.IngestingUplinkSequence {
+				w.Header().Set("Retry-After", uplinkIngestingRetryAfter)
+				ctx, writer, errResponse := r.Context(), w, json_api_response.NewUnavailable(uplinkIngestingErrorMessage)
+				jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusServiceUnavailable, nil)
+				return
+			}
+
+			duplicate := state == telemetry_domain.IngestedUplinkSequence
+			recorder := amf_http_server.NewRequestStatusRecorder(w)
+			next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), duplicateUplinkKey{}, duplicate)))
+			if duplicate {
+				return
+			}
+
+			// The response is already written, and the sequence expires anyway if these fail
+			if recorder.Status >= http.StatusBadRequest {
+				_ = deduplicator.Forget(context.WithoutCancel(r.Context()), deviceID, sequence)
+				return
+			}
+			_ = deduplicator.Ingested(context.WithoutCancel(r.Context()), deviceID, sequence)
+		})
+	}
+}
+
+func isDuplicateUplink(ctx context.Context) bool {
+	duplicate, _ := ctx.Value(duplicateUplinkKey{}).(bool)
+
+	return duplicate
+}
@@ -0,0 +1,102 @@
+package telemetry_http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gorilla/mux"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	telemetry_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/application"
+	telemetry_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/infra"
+	telemetry_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/infra/http"
+
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestUplinkDeduplicationMiddleware(t *testing.T) {
+	miniRedis, err := miniredis.Run()
+	require.NoError(t, err)
+	defer miniRedis.Close()
+
+	registry := telemetry_infra.NewRedisUplinkSequenceRegistry(redis.NewClient(&redis.Options{Addr: miniRedis.Addr()}), time.Minute)
+	middleware := telemetry_http.NewUplinkDeduplicationMiddleware(
+		telemetry_application.NewUplinkDeduplicator(registry, amf_utils.NewFixedTimeProvider()),
+		amf_json_api.NewJsonApiResponseMiddleware(logger.NewNullLogger()),
+	)
+
+	// The ingestion fails on the first attempt, so the statuses are the ones of each attempt
+	statuses := []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK}
+	attempts := 0
+	router := mux.NewRouter()
+	router.Handle("/devices/{deviceId}/uplinks", middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statuses[attempts])
+		attempts++
+	})))
+
+	uplink := func() int {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/devices/device-1/uplinks", strings.NewReader(`{"data":{"attributes":{"sequence":7}}}`))
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	t.Run("should ingest the retransmission of an uplink whose ingestion failed", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, uplink())
+		assert.Equal(t, http.StatusOK, uplink())
+
+		duplicates, err := registry.Duplicates(context.Background(), "device-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), duplicates.Count)
+	})
+
+	t.Run("should flag the retransmission of an uplink ingested", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, uplink())
+
+		duplicates, err := registry.Duplicates(context.Background(), "device-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), duplicates.Count)
+	})
+	t.Run("should ask to retransmit later the retransmissions of an uplink still being ingested", func(t *testing.T) {
+		var retransmission *httptest.ResponseRecorder
+		ingested := 0
+		router := mux.NewRouter()
+		router.Handle("/devices/{deviceId}/uplinks", middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ingested++
+			if retransmission == nil {
+				// The device retransmits the uplink while the original is being ingested
+				retransmission = httptest.NewRecorder()
+				router.ServeHTTP(retransmission, httptest.NewRequest(
+					http.MethodPost,
+					"/devices/device-1/uplinks",
+					strings.NewReader(`{"data":{"attributes":{"sequence":8}}}`),
+				))
+			}
+		})))
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(
+			http.MethodPost,
+			"/devices/device-1/uplinks",
+			strings.NewReader(`{"data":{"attributes":{"sequence":8}}}`),
+		))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, http.StatusServiceUnavailable, retransmission.Code)
+		assert.NotEmpty(t, retransmission.Header().Get("Retry-After"))
+		assert.Equal(t, 1, ingested)
+
+		duplicates, err := registry.Duplicates(context.Background(), "device-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), duplicates.Count)
+	})
+}
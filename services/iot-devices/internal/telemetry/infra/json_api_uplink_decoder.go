@@ -0,0 +1,215 @@
+package telemetry_infra
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// JsonApiUplinkDecoder decodes the uplinks sent as a JSON:API document, with either the
+// recorded_at time and the metrics among the attributes, or the readings of a backlog.
+// Pest control devices report their events along with their device_kind, and the events
+// are validated for that kind of device. Pest metrics are only taken from those events.
+type JsonApiUplinkDecoder struct{}
+
+func NewJsonApiUplinkDecoder() *JsonApiUplinkDecoder {
+	return &JsonApiUplinkDecoder{}
+}
+
+type jsonApiReading struct {
+	Sequence   *int64                     `json:"sequence"`
+	RecordedAt string                     `json:"recorded_at"`
+	Metrics    map[string]json.RawMessage `json:"metrics"`
+	DeviceKind string                     `json:"device_kind"`
+	Events     []jsonApiPestEvent         `json:"events"`
+}
+
+type jsonApiPestEvent struct {
+	Type              string   `json:"type"`
+	OccurredAt        string   `json:"occurred_at"`
+	BaitConsumedGrams *float64 `json:"bait_consumed_grams"`
+	GlueBoardCoverage *float64 `json:"glue_board_coverage"`
+	Tamper            string   `json:"tamper"`
+}
+
+type jsonApiUplink struct {
+	Data struct {
+		Attributes struct {
+			jsonApiReading
+			Readings []jsonApiReading `json:"readings"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+func (d *JsonApiUplinkDecoder) Decode(frame telemetry_domain.UplinkFrame) ([]telemetry_domain.Reading, error) {
+	var uplink jsonApiUplink
+	if err := json.Unmarshal(frame.Payload, &uplink); err != nil {
+		return nil, telemetry_domain.NewInvalidReading(frame.DeviceID, "payload")
+	}
+
+	attributes := uplink.Data.Attributes
+	if attributes.Readings == nil {
+		reading, err := d.decodeReading(frame.ID, frame, attributes.jsonApiReading)
+		if err != nil {
+			return nil, err
+		}
+
+		return []telemetry_domain.Reading{reading}, nil
+	}
+
+	return d.decodeBacklog(frame, attributes.Readings)
+}
+
+// decodeBacklog sorts the buffered readings by sequence number. Their ids come from the
+// device, the sequence number and the time they were recorded, so flushing the same
+// readings again does not store them twice.
+func (d *JsonApiUplinkDecoder) decodeBacklog(frame telemetry_domain.UplinkFrame, rawReadings []jsonApiReading) ([]telemetry_domain.Reading, error) {
+	if len(rawReadings) == 0 {
+		return nil, telemetry_domain.NewInvalidReading(frame.DeviceID, "readings")
+	}
+	for _, rawReading := range rawReadings {
+		if rawReading.Sequence == nil {
+			return nil, telemetry_domain.NewInvalidReading(frame.DeviceID, "readings.sequence")
+		}
+	}
+
+	sort.SliceStable(rawReadings, func(i, j int) bool {
+		return *rawReadings[i].Sequence < *rawReadings[j].Sequence
+	})
+
+	readings := make([]telemetry_domain.Reading, 0, len(rawReadings))
+	for _, rawReading := range rawReadings {
+		recordedAt, err := time.Parse(time.RFC3339, rawReading.RecordedAt)
+		if err != nil {
+			return nil, telemetry_domain.NewInvalidReading(frame.DeviceID, "readings.recorded_at")
+		}
+
+		id := amf_utils.NewDeterministicUlid(
+			recordedAt,
+			fmt.Sprintf("%s/%d/%d", frame.DeviceID, *rawReading.Sequence, recordedAt.UnixNano()),
+		).String()
+		reading, err := d.decodeReading(id, frame, rawReading)
+		if err != nil {
+			return nil, err
+		}
+		readings = append(readings, reading.AsBackfilled())
+	}
+
+	return readings, nil
+}
+
+func (d *JsonApiUplinkDecoder) decodeReading(
+	id string,
+	frame telemetry_domain.UplinkFrame,
+	rawReading jsonApiReading,
+) (telemetry_domain.Reading, error) {
+	recordedAt, err := time.Parse(time.RFC3339, rawReading.RecordedAt)
+	if err != nil {
+		return telemetry_domain.Reading{}, telemetry_domain.NewInvalidReading(frame.DeviceID, "recorded_at")
+	}
+
+	metrics := make(map[string]float64, len(rawReading.Metrics))
+	for name, rawValue := range rawReading.Metrics {
+		var value float64
+		if slices.Contains(pestcontrol_domain.PestMetrics, name) || json.Unmarshal(rawValue, &value) != nil {
+			return telemetry_domain.Reading{}, telemetry_domain.NewInvalidReading(frame.DeviceID, "metrics."+name)
+		}
+		metrics[name] = value
+	}
+
+	pestEvents, err := d.decodePestEvents(id, frame, rawReading)
+	if err != nil {
+		return telemetry_domain.Reading{}, err
+	}
+	mergePestMetrics(metrics, pestEvents)
+
+	reading, err := telemetry_domain.NewReading(id, frame.DeviceID, recordedAt, frame.ReceivedAt, metrics)
+	if err != nil {
+		return telemetry_domain.Reading{}, err
+	}
+
+	return reading.WithPestEvents(pestEvents), nil
+}
+
+// decodePestEvents sorts the events by the time they occurred. Their ids come from the
+// reading and their position, so decoding the same frame again gives the same events.
+func (d *JsonApiUplinkDecoder) decodePestEvents(
+	readingID string,
+	frame telemetry_domain.UplinkFrame,
+	rawReading jsonApiReading,
+) ([]pestcontrol_domain.PestEvent, error) {
+	if len(rawReading.Events) == 0 {
+		return nil, nil
+	}
+
+	deviceKind := pestcontrol_domain.PestDeviceKind(rawReading.DeviceKind)
+	pestEvents := make([]pestcontrol_domain.PestEvent, 0, len(rawReading.Events))
+	for i, rawEvent := range rawReading.Events {
+		occurredAt, err := time.Parse(time.RFC3339, rawEvent.OccurredAt)
+		if err != nil {
+			return nil, telemetry_domain.NewInvalidReading(frame.DeviceID, "events.occurred_at")
+		}
+
+		id := amf_utils.NewDeterministicUlid(occurredAt, fmt.Sprintf("%s/%d", readingID, i)).String()
+		pestEvent, err := decodePestEvent(id, frame.DeviceID, deviceKind, occurredAt, rawEvent)
+		if err != nil {
+			return nil, err
+		}
+		pestEvents = append(pestEvents, pestEvent)
+	}
+
+	sort.SliceStable(pestEvents, func(i, j int) bool {
+		return pestEvents[i].OccurredAt.Before(pestEvents[j].OccurredAt)
+	})
+
+	return pestEvents, nil
+}
+
+func decodePestEvent(
+	id string,
+	deviceID string,
+	deviceKind pestcontrol_domain.PestDeviceKind,
+	occurredAt time.Time,
+	rawEvent jsonApiPestEvent,
+) (pestcontrol_domain.PestEvent, error) {
+	switch pestcontrol_domain.PestEventType(rawEvent.Type) {
+	case pestcontrol_domain.TrapTriggeredEvent:
+		return pestcontrol_domain.NewTrapTriggered(id, deviceID, deviceKind, occurredAt)
+	case pestcontrol_domain.TrapResetEvent:
+		return pestcontrol_domain.NewTrapReset(id, deviceID, deviceKind, occurredAt)
+	case pestcontrol_domain.BaitConsumedEvent:
+		if rawEvent.BaitConsumedGrams == nil {
+			return pestcontrol_domain.PestEvent{}, pestcontrol_domain.NewInvalidPestEvent(id, "bait_consumed_grams", "is required")
+		}
+		return pestcontrol_domain.NewBaitConsumed(id, deviceID, deviceKind, occurredAt, *rawEvent.BaitConsumedGrams)
+	case pestcontrol_domain.GlueBoardCoverageEvent:
+		if rawEvent.GlueBoardCoverage == nil {
+			return pestcontrol_domain.PestEvent{}, pestcontrol_domain.NewInvalidPestEvent(id, "glue_board_coverage", "is required")
+		}
+		return pestcontrol_domain.NewGlueBoardCoverage(id, deviceID, deviceKind, occurredAt, *rawEvent.GlueBoardCoverage)
+	case pestcontrol_domain.TamperEvent:
+		return pestcontrol_domain.NewTamper(id, deviceID, deviceKind, occurredAt, pestcontrol_domain.TamperKind(rawEvent.Tamper))
+	default:
+		return pestcontrol_domain.PestEvent{}, pestcontrol_domain.NewInvalidPestEvent(id, "type", "is not a known event")
+	}
+}
+
+// mergePestMetrics stores the metrics of the events along the reading: the grams of bait
+// add up, and the latest event wins for the rest.
+func mergePestMetrics(metrics map[string]float64, pestEvents []pestcontrol_domain.PestEvent) {
+	for _, pestEvent := range pestEvents {
+		for name, value := range pestEvent.Metrics() {
+			if name == pestcontrol_domain.BaitConsumedMetric {
+				value += metrics[name]
+			}
+			metrics[name] = value
+		}
+	}
+}
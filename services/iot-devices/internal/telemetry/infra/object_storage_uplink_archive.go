@@ -0,0 +1,112 @@
+package telemetry_infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+)
+
+const (
+	uplinkArchivePrefix    = "uplinks"
+	uplinkArchiveDayLayout = "2006-01-02"
+)
+
+// ObjectStorageUplinkArchive keeps each frame as a JSON object partitioned by the day it
+// was received and by device: uplinks/<day>/<device id>/<frame id>.json. Frame ids are
+// ULIDs, so the keys of a partition sort by receipt.
+type ObjectStorageUplinkArchive struct {
+	storage amf_object_storage.ObjectStorage
+}
+
+func NewObjectStorageUplinkArchive(storage amf_object_storage.ObjectStorage) *ObjectStorageUplinkArchive {
+	return &ObjectStorageUplinkArchive{storage: storage}
+}
+
+type archivedUplinkFrame struct {
+	ID         string            `json:"id"`
+	DeviceID   string            `json:"device_id"`
+	ReceivedAt time.Time         `json:"received_at"`
+	Metadata   map[string]string `json:"metadata"`
+	Payload    []byte            `json:"payload"`
+}
+
+func (a *ObjectStorageUplinkArchive) Save(ctx context.Context, frame telemetry_domain.UplinkFrame) error {
+	content, err := json.Marshal(archivedUplinkFrame{
+		ID:         frame.ID,
+		DeviceID:   frame.DeviceID,
+		ReceivedAt: frame.ReceivedAt.UTC(),
+		Metadata:   frame.Metadata,
+		Payload:    frame.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%s%s.json", uplinkPartition(frame.ReceivedAt, frame.DeviceID), frame.ID)
+
+	return a.storage.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "application/json")
+}
+
+func (a *ObjectStorageUplinkArchive) Stream(
+	ctx context.Context,
+	deviceID string,
+	from time.Time,
+	to time.Time,
+	fn func(frame telemetry_domain.UplinkFrame) error,
+) error {
+	for day := from.UTC().Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+		keys, err := a.storage.List(ctx, uplinkPartition(day, deviceID))
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			frame, err := a.read(ctx, key)
+			if err != nil {
+				return err
+			}
+			if frame.ReceivedAt.Before(from) || !frame.ReceivedAt.Before(to) {
+				continue
+			}
+
+			if err := fn(frame); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *ObjectStorageUplinkArchive) read(ctx context.Context, key string) (telemetry_domain.UplinkFrame, error) {
+	reader, err := a.storage.Get(ctx, key)
+	if err != nil {
+		return telemetry_domain.UplinkFrame{}, err
+	}
+	defer reader.Close()
+
+	var archived archivedUplinkFrame
+	if err := json.NewDecoder(reader).Decode(&archived); err != nil {
+		return telemetry_domain.UplinkFrame{}, fmt.Errorf("archived frame %s: %w", key, err)
+	}
+
+	return telemetry_domain.UplinkFrame{
+		ID:         archived.ID,
+		DeviceID:   archived.DeviceID,
+		ReceivedAt: archived.ReceivedAt,
+		Payload:    archived.Payload,
+		Metadata:   archived.Metadata,
+	}, nil
+}
+
+// uplinkPartition escapes the device id, as it may hold slashes.
+func uplinkPartition(day time.Time, deviceID string) string {
+	return fmt.Sprintf("%s/%s/%s/", uplinkArchivePrefix, day.UTC().Format(uplinkArchiveDayLayout), url.PathEscape(deviceID))
+}
@@ -0,0 +1,122 @@
+package telemetry_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	deviceClockColumns = `device_id, offset_ms, round_trip_ms, samples, drifting, estimated_at`
+
+	upsertDeviceClockQuery = `
+INSERT INTO device_clocks (` + deviceClockColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (device_id) DO UPDATE SET
+    offset_ms = EXCLUDED.offset_ms,
+    round_trip_ms = EXCLUDED.round_trip_ms,
+    samples = EXCLUDED.samples,
+    drifting = EXCLUDED.drifting,
+    estimated_at = EXCLUDED.estimated_at`
+	findDeviceClockQuery = `
+SELECT ` + deviceClockColumns + ` FROM device_clocks
+WHERE device_id = $1 AND ($2::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $2))`
+	searchDriftingDeviceClocksQuery = `
+SELECT ` + deviceClockColumns + ` FROM device_clocks
+WHERE drifting AND ($1::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $1))
+ORDER BY device_id`
+)
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+type PostgresDeviceClockRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresDeviceClockRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresDeviceClockRepository {
+	return &PostgresDeviceClockRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresDeviceClockRepository) Save(ctx context.Context, clock telemetry_domain.DeviceClock) error {
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertDeviceClockQuery,
+		clock.DeviceID,
+		clock.Offset.Milliseconds(),
+		clock.RoundTrip.Milliseconds(),
+		clock.Samples,
+		clock.Drifting,
+		clock.EstimatedAt.UTC(),
+	)
+
+	return err
+}
+
+// Find reads from the writer, as every ingested reading is corrected with the offset
+// estimated right before.
+func (r *PostgresDeviceClockRepository) Find(ctx context.Context, deviceID string) (*telemetry_domain.DeviceClock, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clock, err := scanDeviceClock(r.connectionPool.Writer().QueryRowContext(ctx, findDeviceClockQuery, deviceID, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &clock, nil
+}
+
+func (r *PostgresDeviceClockRepository) SearchDrifting(ctx context.Context) ([]telemetry_domain.DeviceClock, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchDriftingDeviceClocksQuery, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	clocks := make([]telemetry_domain.DeviceClock, 0)
+	for rows.Next() {
+		clock, err := scanDeviceClock(rows)
+		if err != nil {
+			return nil, err
+		}
+		clocks = append(clocks, clock)
+	}
+
+	return clocks, rows.Err()
+}
+
+func scanDeviceClock(row rowScanner) (telemetry_domain.DeviceClock, error) {
+	var (
+		clock       telemetry_domain.DeviceClock
+		offsetMs    int64
+		roundTripMs int64
+	)
+
+	err := row.Scan(&clock.DeviceID, &offsetMs, &roundTripMs, &clock.Samples, &clock.Drifting, &clock.EstimatedAt)
+	if err != nil {
+		return telemetry_domain.DeviceClock{}, err
+	}
+
+	clock.Offset = time.Duration(offsetMs) * time.Millisecond
+	clock.RoundTrip = time.Duration(roundTripMs) * time.Millisecond
+
+	return clock, nil
+}
@@ -0,0 +1,237 @@
+package telemetry_infra
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const insertReadingQuery = `
+INSERT INTO telemetry_readings (id, device_id, recorded_at, raw_recorded_at, clock_offset_ms, received_at, metrics, backfilled)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (id) DO NOTHING`
+
+const replaceReadingQuery = `
+INSERT INTO telemetry_readings (id, device_id, recorded_at, raw_recorded_at, clock_offset_ms, received_at, metrics, backfilled)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (id) DO UPDATE SET
+    recorded_at = EXCLUDED.recorded_at,
+    raw_recorded_at = EXCLUDED.raw_recorded_at,
+    clock_offset_ms = EXCLUDED.clock_offset_ms,
+    metrics = EXCLUDED.metrics`
+
+const readingColumns = `id, device_id, recorded_at, raw_recorded_at, clock_offset_ms, received_at, metrics, backfilled`
+
+const findReadingQuery = `
+SELECT ` + readingColumns + `
+FROM telemetry_readings
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $2))`
+
+const searchReadingsQuery = `
+SELECT ` + readingColumns + `
+FROM telemetry_readings
+WHERE ($1::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $1))`
+
+type PostgresReadingRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresReadingRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresReadingRepository {
+	return &PostgresReadingRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresReadingRepository) Save(ctx context.Context, reading telemetry_domain.Reading) error {
+	return r.write(ctx, insertReadingQuery, reading)
+}
+
+// SaveAll stores the readings in a single transaction.
+func (r *PostgresReadingRepository) SaveAll(
+	ctx context.Context,
+	readings []telemetry_domain.Reading,
+) ([]telemetry_domain.Reading, error) {
+	tx, err := r.connectionPool.Writer().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	inserted := make([]telemetry_domain.Reading, 0, len(readings))
+	for _, reading := range readings {
+		rows, err := r.exec(ctx, tx, insertReadingQuery, reading)
+		if err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		if rows > 0 {
+			inserted = append(inserted, reading)
+		}
+	}
+
+	return inserted, tx.Commit()
+}
+
+func (r *PostgresReadingRepository) Replace(ctx context.Context, reading telemetry_domain.Reading) error {
+	return r.write(ctx, replaceReadingQuery, reading)
+}
+
+// Find reads from the writer, as replays compare against it right before replacing.
+func (r *PostgresReadingRepository) Find(ctx context.Context, id string) (*telemetry_domain.Reading, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reading, err := scanReading(r.connectionPool.Writer().QueryRowContext(ctx, findReadingQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &reading, nil
+}
+
+// Search reads from the reader, walking the (recorded_at, id) keyset backwards, so every
+// page is as fast as the first one whatever its depth.
+func (r *PostgresReadingRepository) Search(
+	ctx context.Context,
+	criteria telemetry_domain.ReadingCriteria,
+) ([]telemetry_domain.Reading, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, args := []string{}, []any{scope}
+	where := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, fmt.Sprintf("$%d", len(args))))
+	}
+
+	if criteria.DeviceID != "" {
+		where("device_id = %s", criteria.DeviceID)
+	}
+	if criteria.SiteID != "" {
+		where("device_id IN (SELECT id FROM spcd_iot_devices WHERE site_id = %s)", criteria.SiteID)
+	}
+	if !criteria.From.IsZero() {
+		where("recorded_at >= %s", criteria.From.UTC())
+	}
+	if !criteria.To.IsZero() {
+		where("recorded_at < %s", criteria.To.UTC())
+	}
+	if len(criteria.Metrics) > 0 {
+		where("metrics ?| %s", pq.Array(criteria.Metrics))
+	}
+	if criteria.Type != "" {
+		where("backfilled = %s", criteria.Type == telemetry_domain.BackfilledReading)
+	}
+	if criteria.After != nil {
+		args = append(args, criteria.After.RecordedAt.UTC(), criteria.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(recorded_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query := searchReadingsQuery
+	if len(conditions) > 0 {
+		query += " AND " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, criteria.Limit)
+	query += fmt.Sprintf(" ORDER BY recorded_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	readings := make([]telemetry_domain.Reading, 0, criteria.Limit)
+	for rows.Next() {
+		reading, err := scanReading(rows)
+		if err != nil {
+			return nil, err
+		}
+		readings = append(readings, reading)
+	}
+
+	return readings, rows.Err()
+}
+
+func (r *PostgresReadingRepository) write(ctx context.Context, query string, reading telemetry_domain.Reading) error {
+	_, err := r.exec(ctx, r.connectionPool.Writer(), query, reading)
+
+	return err
+}
+
+type readingExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// exec returns the number of rows written, none for the readings already stored.
+func (r *PostgresReadingRepository) exec(
+	ctx context.Context,
+	executor readingExecutor,
+	query string,
+	reading telemetry_domain.Reading,
+) (int64, error) {
+	metrics, err := json.Marshal(reading.Metrics)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := executor.ExecContext(
+		ctx,
+		query,
+		reading.ID,
+		reading.DeviceID,
+		reading.RecordedAt.UTC(),
+		reading.RawRecordedAt.UTC(),
+		reading.ClockOffset.Milliseconds(),
+		reading.ReceivedAt.UTC(),
+		metrics,
+		reading.Backfilled,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func scanReading(row rowScanner) (telemetry_domain.Reading, error) {
+	var (
+		reading       telemetry_domain.Reading
+		clockOffsetMs int64
+		metrics       []byte
+	)
+	err := row.Scan(
+		&reading.ID,
+		&reading.DeviceID,
+		&reading.RecordedAt,
+		&reading.RawRecordedAt,
+		&clockOffsetMs,
+		&reading.ReceivedAt,
+		&metrics,
+		&reading.Backfilled,
+	)
+	if err != nil {
+		return telemetry_domain.Reading{}, err
+	}
+
+	if err := json.Unmarshal(metrics, &reading.Metrics); err != nil {
+		return telemetry_domain.Reading{}, err
+	}
+	reading.ClockOffset = time.Duration(clockOffsetMs) * time.Millisecond
+
+	return reading, nil
+}
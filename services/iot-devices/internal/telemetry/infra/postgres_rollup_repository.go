@@ -0,0 +1,246 @@
+package telemetry_infra
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	rollupColumns = `resolution, device_id, bucket_start, metric, site_id, readings, sum, min, max`
+
+	addRollupReadingQuery = `
+INSERT INTO telemetry_rollups (` + rollupColumns + `)
+SELECT b.resolution, $1, b.bucket_start, m.key,
+    COALESCE((SELECT site_id FROM spcd_iot_devices WHERE id = $1), ''),
+    1, m.value::DOUBLE PRECISION, m.value::DOUBLE PRECISION, m.value::DOUBLE PRECISION
+FROM (VALUES ('hour', $2::TIMESTAMPTZ), ('day', $3::TIMESTAMPTZ)) AS b(resolution, bucket_start)
+CROSS JOIN jsonb_each_text($4::JSONB) AS m
+ON CONFLICT (resolution, device_id, bucket_start, metric) DO UPDATE SET
+    readings = telemetry_rollups.readings + EXCLUDED.readings,
+    sum = telemetry_rollups.sum + EXCLUDED.sum,
+    min = LEAST(telemetry_rollups.min, EXCLUDED.min),
+    max = GREATEST(telemetry_rollups.max, EXCLUDED.max)`
+
+	deleteRollupsQuery = `
+DELETE FROM telemetry_rollups
+WHERE ($1 = '' OR device_id = $1) AND bucket_start >= $2 AND bucket_start < $3
+  AND ($4::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $4))`
+
+	rebuildRollupsQuery = `
+INSERT INTO telemetry_rollups (` + rollupColumns + `)
+SELECT b.resolution, r.device_id, date_trunc(b.resolution, r.recorded_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC', m.key,
+    COALESCE(d.site_id, ''), COUNT(*), SUM(m.value::DOUBLE PRECISION), MIN(m.value::DOUBLE PRECISION), MAX(m.value::DOUBLE PRECISION)
+FROM telemetry_readings r
+CROSS JOIN (VALUES ('hour'), ('day')) AS b(resolution)
+CROSS JOIN LATERAL jsonb_each_text(r.metrics) AS m
+LEFT JOIN spcd_iot_devices d ON d.id = r.device_id
+WHERE ($1 = '' OR r.device_id = $1) AND r.recorded_at >= $2 AND r.recorded_at < $3
+  AND ($4::VARCHAR IS NULL OR d.tenant_id = $4)
+GROUP BY 1, 2, 3, 4, 5
+ON CONFLICT (resolution, device_id, bucket_start, metric) DO UPDATE SET
+    readings = EXCLUDED.readings,
+    sum = EXCLUDED.sum,
+    min = EXCLUDED.min,
+    max = EXCLUDED.max`
+
+	searchDeviceRollupsQuery = `
+SELECT bucket_start, metric, readings, sum, min, max
+FROM telemetry_rollups
+WHERE resolution = $1 AND device_id = $2 AND bucket_start >= $3 AND bucket_start < $4
+  AND ($5::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $5))
+ORDER BY bucket_start, metric`
+
+	searchSiteRollupsQuery = `
+SELECT bucket_start, metric, SUM(readings), SUM(sum), MIN(min), MAX(max)
+FROM telemetry_rollups
+WHERE resolution = $1 AND site_id = $2 AND bucket_start >= $3 AND bucket_start < $4
+  AND ($5::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $5))
+GROUP BY bucket_start, metric
+ORDER BY bucket_start, metric`
+
+	searchDeviceReadingsQuery = `
+SELECT recorded_at, metrics
+FROM telemetry_readings
+WHERE device_id = $1 AND recorded_at >= $2 AND recorded_at < $3
+  AND ($4::VARCHAR IS NULL OR device_id IN (SELECT id FROM spcd_iot_devices WHERE tenant_id = $4))
+ORDER BY recorded_at, id`
+)
+
+type PostgresRollupRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresRollupRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresRollupRepository {
+	return &PostgresRollupRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresRollupRepository) Add(ctx context.Context, reading telemetry_domain.Reading) error {
+	metrics, err := json.Marshal(reading.Metrics)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.connectionPool.Writer().ExecContext(
+		ctx,
+		addRollupReadingQuery,
+		reading.DeviceID,
+		telemetry_domain.HourlyResolution.Bucket(reading.RecordedAt),
+		telemetry_domain.DailyResolution.Bucket(reading.RecordedAt),
+		metrics,
+	)
+
+	return err
+}
+
+// Rebuild replaces the buckets in a single transaction, so charts never see them half built.
+func (r *PostgresRollupRepository) Rebuild(ctx context.Context, deviceID string, from time.Time, to time.Time) error {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.connectionPool.Writer().BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, query := range []string{deleteRollupsQuery, rebuildRollupsQuery} {
+		if _, err := tx.ExecContext(ctx, query, deviceID, from.UTC(), to.UTC(), scope); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresRollupRepository) SearchByDevice(
+	ctx context.Context,
+	deviceID string,
+	resolution telemetry_domain.RollupResolution,
+	from time.Time,
+	to time.Time,
+) ([]telemetry_domain.Rollup, error) {
+	if resolution == telemetry_domain.RawResolution {
+		return r.searchReadings(ctx, deviceID, from, to)
+	}
+
+	return r.search(ctx, searchDeviceRollupsQuery, resolution, deviceID, from, to)
+}
+
+func (r *PostgresRollupRepository) SearchBySite(
+	ctx context.Context,
+	siteID string,
+	resolution telemetry_domain.RollupResolution,
+	from time.Time,
+	to time.Time,
+) ([]telemetry_domain.Rollup, error) {
+	return r.search(ctx, searchSiteRollupsQuery, resolution, siteID, from, to)
+}
+
+// search reads the buckets a metric per row, so the rows of a bucket come one after the other.
+func (r *PostgresRollupRepository) search(
+	ctx context.Context,
+	query string,
+	resolution telemetry_domain.RollupResolution,
+	scopeID string,
+	from time.Time,
+	to time.Time,
+) ([]telemetry_domain.Rollup, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, query, resolution.Value(), scopeID, resolution.Bucket(from), to.UTC(), scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	rollups := make([]telemetry_domain.Rollup, 0)
+	for rows.Next() {
+		var (
+			bucketStart time.Time
+			metric      string
+			summary     telemetry_domain.MetricRollup
+		)
+		if err := rows.Scan(&bucketStart, &metric, &summary.Readings, &summary.Sum, &summary.Min, &summary.Max); err != nil {
+			return nil, err
+		}
+
+		last := len(rollups) - 1
+		if last < 0 || !rollups[last].BucketStart.Equal(bucketStart) {
+			rollups = append(rollups, telemetry_domain.Rollup{
+				Resolution:  resolution,
+				BucketStart: bucketStart.UTC(),
+				Metrics:     map[string]telemetry_domain.MetricRollup{},
+			})
+			last++
+		}
+		rollups[last].Metrics[metric] = summary
+	}
+
+	return rollups, rows.Err()
+}
+
+func (r *PostgresRollupRepository) searchReadings(
+	ctx context.Context,
+	deviceID string,
+	from time.Time,
+	to time.Time,
+) ([]telemetry_domain.Rollup, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchDeviceReadingsQuery, deviceID, from.UTC(), to.UTC(), scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	rollups := make([]telemetry_domain.Rollup, 0)
+	for rows.Next() {
+		rollup, err := scanReadingRollup(rows)
+		if err != nil {
+			return nil, err
+		}
+		rollups = append(rollups, rollup)
+	}
+
+	return rollups, rows.Err()
+}
+
+// scanReadingRollup makes a bucket of a single reading, every metric summarizing one value.
+func scanReadingRollup(row rowScanner) (telemetry_domain.Rollup, error) {
+	var (
+		recordedAt time.Time
+		rawMetrics []byte
+		metrics    map[string]float64
+	)
+	if err := row.Scan(&recordedAt, &rawMetrics); err != nil {
+		return telemetry_domain.Rollup{}, err
+	}
+	if err := json.Unmarshal(rawMetrics, &metrics); err != nil {
+		return telemetry_domain.Rollup{}, err
+	}
+
+	rollup := telemetry_domain.Rollup{
+		Resolution:  telemetry_domain.RawResolution,
+		BucketStart: recordedAt.UTC(),
+		Metrics:     make(map[string]telemetry_domain.MetricRollup, len(metrics)),
+	}
+	for name, value := range metrics {
+		rollup.Metrics[name] = telemetry_domain.MetricRollup{Readings: 1, Sum: value, Min: value, Max: value}
+	}
+
+	return rollup, nil
+}
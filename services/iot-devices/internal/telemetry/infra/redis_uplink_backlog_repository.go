@@ -0,0 +1,89 @@
+package telemetry_infra
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+const uplinkBacklogsKey = "telemetry:uplink_backlogs"
+
+// RedisUplinkBacklogRepository keeps the backlog of every device as JSON in a hash by
+// device id.
+type RedisUplinkBacklogRepository struct {
+	redisClient *redis.Client
+}
+
+func NewRedisUplinkBacklogRepository(redisClient *redis.Client) *RedisUplinkBacklogRepository {
+	return &RedisUplinkBacklogRepository{redisClient: redisClient}
+}
+
+type storedUplinkBacklog struct {
+	Remaining  int64     `json:"remaining"`
+	LagMs      int64     `json:"lag_ms"`
+	Backfilled int64     `json:"backfilled"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (r *RedisUplinkBacklogRepository) Save(ctx context.Context, backlog telemetry_domain.UplinkBacklog) error {
+	stored, err := json.Marshal(storedUplinkBacklog{
+		Remaining:  backlog.Remaining,
+		LagMs:      backlog.Lag.Milliseconds(),
+		Backfilled: backlog.Backfilled,
+		UpdatedAt:  backlog.UpdatedAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.HSet(ctx, uplinkBacklogsKey, backlog.DeviceID, stored).Err()
+}
+
+func (r *RedisUplinkBacklogRepository) Find(ctx context.Context, deviceID string) (telemetry_domain.UplinkBacklog, error) {
+	raw, err := r.redisClient.HGet(ctx, uplinkBacklogsKey, deviceID).Result()
+	if err == redis.Nil {
+		return telemetry_domain.UplinkBacklog{DeviceID: deviceID}, nil
+	}
+	if err != nil {
+		return telemetry_domain.UplinkBacklog{}, err
+	}
+
+	return uplinkBacklogFrom(deviceID, raw)
+}
+
+func (r *RedisUplinkBacklogRepository) SearchAll(ctx context.Context) ([]telemetry_domain.UplinkBacklog, error) {
+	raws, err := r.redisClient.HGetAll(ctx, uplinkBacklogsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	backlogs := make([]telemetry_domain.UplinkBacklog, 0, len(raws))
+	for deviceID, raw := range raws {
+		backlog, err := uplinkBacklogFrom(deviceID, raw)
+		if err != nil {
+			return nil, err
+		}
+		backlogs = append(backlogs, backlog)
+	}
+
+	return backlogs, nil
+}
+
+func uplinkBacklogFrom(deviceID string, raw string) (telemetry_domain.UplinkBacklog, error) {
+	var stored storedUplinkBacklog
+	if err := json.Unmarshal([]byte(raw), &stored); err != nil {
+		return telemetry_domain.UplinkBacklog{}, err
+	}
+
+	return telemetry_domain.UplinkBacklog{
+		DeviceID:   deviceID,
+		Remaining:  stored.Remaining,
+		Lag:        time.Duration(stored.LagMs) * time.Millisecond,
+		Backfilled: stored.Backfilled,
+		UpdatedAt:  stored.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package telemetry_infra
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+const (
+	uplinkSequenceKeyPrefix   = "telemetry:uplink_sequence"
+	uplinkDuplicatesKey       = "telemetry:uplink_duplicates"
+	uplinkDuplicatesLastAtKey = "telemetry:uplink_duplicates_last_at"
+)
+
+// RedisUplinkSequenceRegistry keeps the state of every sequence number in a key expiring
+// after the ttl, which only needs to outlast the retransmissions, and the duplicate counts
+// of the devices in a hash along with the unix time in milliseconds of their last
+// duplicate. Sequence numbers left ingesting by a replica dying meanwhile are only
+// forgotten once their key expires.
+type RedisUplinkSequenceRegistry struct {
+	redisClient *redis.Client
+	ttl         time.Duration
+}
+
+func NewRedisUplinkSequenceRegistry(redisClient *redis.Client, ttl time.Duration) *RedisUplinkSequenceRegistry {
+	return &RedisUplinkSequenceRegistry{redisClient: redisClient, ttl: ttl}
+}
+
+func (r *RedisUplinkSequenceRegistry) Register(
+	ctx context.Context,
+	deviceID string,
+	sequence int64,
+	at time.Time,
+) (telemetry_domain.UplinkSequenceState, error) {
+	key := r.sequenceKey(deviceID, sequence)
+	registered, err := r.redisClient.SetNX(ctx, key, string(telemetry_domain.IngestingUplinkSequence), r.ttl).Result()
+	if err != nil {
+		return "", err
+	}
+	if registered {
+		return telemetry_domain.UnseenUplinkSequence, nil
+	}
+
+	// Unregistered right after failing to register, so its retransmission is on its way
+	state, err := r.redisClient.Get(ctx, key).Result()
+	if err == redis.Nil || state == string(telemetry_domain.IngestingUplinkSequence) {
+		return telemetry_domain.IngestingUplinkSequence, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	_, err = r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, uplinkDuplicatesKey, deviceID, 1)
+		pipe.HSet(ctx, uplinkDuplicatesLastAtKey, deviceID, at.UnixMilli())
+		return nil
+	})
+
+	return telemetry_domain.IngestedUplinkSequence, err
+}
+
+func (r *RedisUplinkSequenceRegistry) Ingested(ctx context.Context, deviceID string, sequence int64) error {
+	err := r.redisClient.SetArgs(
+		ctx,
+		r.sequenceKey(deviceID, sequence),
+		string(telemetry_domain.IngestedUplinkSequence),
+		redis.SetArgs{Mode: "XX", KeepTTL: true},
+	).Err()
+	if err == redis.Nil {
+		return nil
+	}
+
+	return err
+}
+
+func (r *RedisUplinkSequenceRegistry) Unregister(ctx context.Context, deviceID string, sequence int64) error {
+	return r.redisClient.Del(ctx, r.sequenceKey(deviceID, sequence)).Err()
+}
+
+func (r *RedisUplinkSequenceRegistry) Duplicates(ctx context.Context, deviceID string) (telemetry_domain.UplinkDuplicates, error) {
+	duplicates := telemetry_domain.UplinkDuplicates{DeviceID: deviceID}
+
+	count, err := r.redisClient.HGet(ctx, uplinkDuplicatesKey, deviceID).Int64()
+	if err == redis.Nil {
+		return duplicates, nil
+	}
+	if err != nil {
+		return telemetry_domain.UplinkDuplicates{}, err
+	}
+	duplicates.Count = count
+
+	rawLastAt, err := r.redisClient.HGet(ctx, uplinkDuplicatesLastAtKey, deviceID).Result()
+	if err == redis.Nil {
+		return duplicates, nil
+	}
+	if err != nil {
+		return telemetry_domain.UplinkDuplicates{}, err
+	}
+
+	lastAtMilli, err := strconv.ParseInt(rawLastAt, 10, 64)
+	if err != nil {
+		return telemetry_domain.UplinkDuplicates{}, err
+	}
+	lastAt := time.UnixMilli(lastAtMilli)
+	duplicates.LastAt = &lastAt
+
+	return duplicates, nil
+}
+
+func (r *RedisUplinkSequenceRegistry) sequenceKey(deviceID string, sequence int64) string {
+	return fmt.Sprintf("%s:%s:%d", uplinkSequenceKeyPrefix, deviceID, sequence)
+}
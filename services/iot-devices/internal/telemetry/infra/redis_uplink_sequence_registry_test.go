@@ -0,0 +1,119 @@
+package telemetry_infra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/infra"
+)
+
+type RedisUplinkSequenceRegistryTestSuite struct {
+	suite.Suite
+	miniRedis *miniredis.Miniredis
+	registry  *telemetry_infra.RedisUplinkSequenceRegistry
+	ctx       context.Context
+}
+
+func (suite *RedisUplinkSequenceRegistryTestSuite) SetupTest() {
+	miniRedis, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.miniRedis = miniRedis
+	suite.registry = telemetry_infra.NewRedisUplinkSequenceRegistry(redis.NewClient(&redis.Options{Addr: miniRedis.Addr()}), time.Minute)
+	suite.ctx = context.Background()
+}
+
+func (suite *RedisUplinkSequenceRegistryTestSuite) TearDownTest() {
+	suite.miniRedis.Close()
+}
+
+func (suite *RedisUplinkSequenceRegistryTestSuite) TestRegisterFlagsAndCountsRetransmissions() {
+	now := time.Now().Truncate(time.Millisecond)
+
+	state, err := suite.registry.Register(suite.ctx, "device-1", 7, now)
+	suite.Require().NoError(err)
+	suite.Equal(telemetry_domain.UnseenUplinkSequence, state)
+	suite.Require().NoError(suite.registry.Ingested(suite.ctx, "device-1", 7))
+
+	state, err = suite.registry.Register(suite.ctx, "device-1", 7, now.Add(time.Second))
+	suite.Require().NoError(err)
+	suite.Equal(telemetry_domain.IngestedUplinkSequence, state)
+
+	state, err = suite.registry.Register(suite.ctx, "device-2", 7, now)
+	suite.Require().NoError(err)
+	suite.Equal(telemetry_domain.UnseenUplinkSequence, state)
+
+	duplicates, err := suite.registry.Duplicates(suite.ctx, "device-1")
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), duplicates.Count)
+	suite.True(now.Add(time.Second).Equal(*duplicates.LastAt))
+}
+
+func (suite *RedisUplinkSequenceRegistryTestSuite) TestRegisterTellsApartTheRetransmissionsOfUplinksBeingIngested() {
+	now := time.Now()
+
+	_, err := suite.registry.Register(suite.ctx, "device-1", 7, now)
+	suite.Require().NoError(err)
+
+	state, err := suite.registry.Register(suite.ctx, "device-1", 7, now)
+	suite.Require().NoError(err)
+	suite.Equal(telemetry_domain.IngestingUplinkSequence, state)
+
+	duplicates, err := suite.registry.Duplicates(suite.ctx, "device-1")
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), duplicates.Count)
+}
+
+func (suite *RedisUplinkSequenceRegistryTestSuite) TestIngestedKeepsTheTtl() {
+	now := time.Now()
+
+	_, err := suite.registry.Register(suite.ctx, "device-1", 7, now)
+	suite.Require().NoError(err)
+	suite.miniRedis.FastForward(50 * time.Second)
+	suite.Require().NoError(suite.registry.Ingested(suite.ctx, "device-1", 7))
+	suite.miniRedis.FastForward(20 * time.Second)
+
+	state, err := suite.registry.Register(suite.ctx, "device-1", 7, now.Add(70*time.Second))
+	suite.Require().NoError(err)
+	suite.Equal(telemetry_domain.UnseenUplinkSequence, state)
+}
+
+func (suite *RedisUplinkSequenceRegistryTestSuite) TestUnregisterForgetsTheSequence() {
+	now := time.Now()
+
+	_, err := suite.registry.Register(suite.ctx, "device-1", 7, now)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.registry.Unregister(suite.ctx, "device-1", 7))
+
+	state, err := suite.registry.Register(suite.ctx, "device-1", 7, now)
+	suite.Require().NoError(err)
+	suite.Equal(telemetry_domain.UnseenUplinkSequence, state)
+}
+
+func (suite *RedisUplinkSequenceRegistryTestSuite) TestRegisterForgetsSequencesAfterTheTtl() {
+	now := time.Now()
+
+	_, err := suite.registry.Register(suite.ctx, "device-1", 7, now)
+	suite.Require().NoError(err)
+	suite.miniRedis.FastForward(2 * time.Minute)
+
+	state, err := suite.registry.Register(suite.ctx, "device-1", 7, now.Add(2*time.Minute))
+	suite.Require().NoError(err)
+	suite.Equal(telemetry_domain.UnseenUplinkSequence, state)
+}
+
+func (suite *RedisUplinkSequenceRegistryTestSuite) TestDuplicatesOfDevicesWithoutDuplicates() {
+	duplicates, err := suite.registry.Duplicates(suite.ctx, "device-1")
+	suite.Require().NoError(err)
+	suite.Equal(int64(0), duplicates.Count)
+	suite.Nil(duplicates.LastAt)
+}
+
+func TestRedisUplinkSequenceRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisUplinkSequenceRegistryTestSuite))
+}
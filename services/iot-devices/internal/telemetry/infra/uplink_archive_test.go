@@ -0,0 +1,191 @@
+package telemetry_infra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/infra"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestObjectStorageUplinkArchive(t *testing.T) {
+	ctx := context.Background()
+	archive := telemetry_infra.NewObjectStorageUplinkArchive(amf_object_storage.NewFilesystemObjectStorage(t.TempDir()))
+	midnight := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	frames := []telemetry_domain.UplinkFrame{}
+	for _, receivedAt := range []time.Time{midnight.Add(-time.Hour), midnight.Add(time.Hour), midnight.Add(25 * time.Hour)} {
+		frame, err := telemetry_domain.NewUplinkFrame(amf_utils.NewUlid().String(), "sites/1/trap", receivedAt, []byte(`{"data":{}}`), map[string]string{"client_ip": "10.0.0.1"})
+		require.NoError(t, err)
+		require.NoError(t, archive.Save(ctx, frame))
+		frames = append(frames, frame)
+	}
+
+	other, err := telemetry_domain.NewUplinkFrame(amf_utils.NewUlid().String(), "sites/1/trap-2", midnight.Add(time.Hour), []byte(`{}`), nil)
+	require.NoError(t, err)
+	require.NoError(t, archive.Save(ctx, other))
+
+	t.Run("should stream the frames of the device received in the range across days", func(t *testing.T) {
+		streamed := []telemetry_domain.UplinkFrame{}
+		err := archive.Stream(ctx, "sites/1/trap", midnight.Add(-2*time.Hour), midnight.Add(26*time.Hour), func(frame telemetry_domain.UplinkFrame) error {
+			streamed = append(streamed, frame)
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, streamed, 3)
+		for i, frame := range streamed {
+			assert.Equal(t, frames[i].ID, frame.ID)
+			assert.Equal(t, frames[i].Payload, frame.Payload)
+			assert.Equal(t, frames[i].Metadata, frame.Metadata)
+			assert.True(t, frames[i].ReceivedAt.Equal(frame.ReceivedAt))
+		}
+	})
+
+	t.Run("should leave out the frames outside the range", func(t *testing.T) {
+		streamed := []string{}
+		err := archive.Stream(ctx, "sites/1/trap", midnight, midnight.Add(2*time.Hour), func(frame telemetry_domain.UplinkFrame) error {
+			streamed = append(streamed, frame.ID)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{frames[1].ID}, streamed)
+	})
+}
+
+func TestJsonApiUplinkDecoder(t *testing.T) {
+	decoder := telemetry_infra.NewJsonApiUplinkDecoder()
+	receivedAt := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	newFrame := func(t *testing.T, payload string) telemetry_domain.UplinkFrame {
+		frame, err := telemetry_domain.NewUplinkFrame(amf_utils.NewUlid().String(), "device-1", receivedAt, []byte(payload), nil)
+		require.NoError(t, err)
+		return frame
+	}
+
+	t.Run("should decode the reading with the id of the frame", func(t *testing.T) {
+		frame := newFrame(t, `{"data":{"attributes":{"recorded_at":"2026-10-19T09:59:00Z","metrics":{"battery_mv":2900}}}}`)
+
+		readings, err := decoder.Decode(frame)
+
+		require.NoError(t, err)
+		require.Len(t, readings, 1)
+		assert.Equal(t, frame.ID, readings[0].ID)
+		assert.Equal(t, receivedAt, readings[0].ReceivedAt)
+		assert.Equal(t, map[string]float64{"battery_mv": 2900}, readings[0].Metrics)
+		assert.False(t, readings[0].Backfilled)
+	})
+
+	t.Run("should decode backlogs in sequence order with stable ids", func(t *testing.T) {
+		payload := `{"data":{"attributes":{"remaining":3,"readings":[
+			{"sequence":8,"recorded_at":"2026-10-19T08:10:00Z","metrics":{"battery_mv":2890}},
+			{"sequence":7,"recorded_at":"2026-10-19T08:00:00Z","metrics":{"battery_mv":2900}}
+		]}}}`
+
+		readings, err := decoder.Decode(newFrame(t, payload))
+		require.NoError(t, err)
+		retransmitted, err := decoder.Decode(newFrame(t, payload))
+		require.NoError(t, err)
+
+		require.Len(t, readings, 2)
+		assert.Equal(t, float64(2900), readings[0].Metrics["battery_mv"])
+		assert.Equal(t, float64(2890), readings[1].Metrics["battery_mv"])
+		assert.True(t, readings[0].Backfilled)
+		assert.NotEqual(t, readings[0].ID, readings[1].ID)
+		assert.Equal(t, readings[0].ID, retransmitted[0].ID)
+	})
+
+	t.Run("should reject metrics that are not numbers", func(t *testing.T) {
+		_, err := decoder.Decode(newFrame(t, `{"data":{"attributes":{"recorded_at":"2026-10-19T09:59:00Z","metrics":{"battery_mv":"low"}}}}`))
+
+		assert.IsType(t, &telemetry_domain.InvalidReading{}, err)
+	})
+
+	t.Run("should reject payloads that are not JSON", func(t *testing.T) {
+		_, err := decoder.Decode(newFrame(t, `not json`))
+
+		assert.IsType(t, &telemetry_domain.InvalidReading{}, err)
+	})
+
+	t.Run("should decode the pest events of the device kind along the reading", func(t *testing.T) {
+		readings, err := decoder.Decode(newFrame(t, `{"data":{"attributes":{
+			"recorded_at":"2026-10-19T07:59:00Z",
+			"metrics":{"battery_mv":2900},
+			"device_kind":"bait_station",
+			"events":[
+				{"type":"tamper","occurred_at":"2026-10-19T07:58:00Z","tamper":"opened"},
+				{"type":"bait_consumed","occurred_at":"2026-10-19T07:30:00Z","bait_consumed_grams":1.5},
+				{"type":"bait_consumed","occurred_at":"2026-10-19T07:45:00Z","bait_consumed_grams":2}
+			]
+		}}}`))
+
+		require.NoError(t, err)
+		require.Len(t, readings, 1)
+		assert.Equal(t, map[string]float64{
+			"battery_mv":                          2900,
+			pestcontrol_domain.BaitConsumedMetric: 3.5,
+			pestcontrol_domain.TamperMetric:       1,
+		}, readings[0].Metrics)
+
+		pestEvents := readings[0].PestEvents
+		require.Len(t, pestEvents, 3)
+		assert.Equal(t, pestcontrol_domain.BaitConsumedEvent, pestEvents[0].Type)
+		assert.Equal(t, pestcontrol_domain.BaitConsumedEvent, pestEvents[1].Type)
+		assert.Equal(t, pestcontrol_domain.TamperEvent, pestEvents[2].Type)
+		assert.Equal(t, pestcontrol_domain.OpenedTamper, pestEvents[2].Tamper)
+		assert.Equal(t, pestcontrol_domain.BaitStationDevice, pestEvents[2].DeviceKind)
+	})
+
+	t.Run("should decode the same pest event ids from the same frame", func(t *testing.T) {
+		frame := newFrame(t, `{"data":{"attributes":{
+			"recorded_at":"2026-10-19T07:59:00Z",
+			"device_kind":"snap_trap",
+			"events":[{"type":"trap_triggered","occurred_at":"2026-10-19T07:58:00Z"}]
+		}}}`)
+
+		first, err := decoder.Decode(frame)
+		require.NoError(t, err)
+		second, err := decoder.Decode(frame)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]float64{pestcontrol_domain.TrapTriggeredMetric: 1}, first[0].Metrics)
+		assert.Equal(t, first[0].PestEvents, second[0].PestEvents)
+	})
+
+	t.Run("should reject the uplinks with invalid pest events", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			events   string
+			expected error
+		}{
+			{name: "an event the device kind does not report", events: `"device_kind":"bait_station","events":[{"type":"trap_triggered","occurred_at":"2026-10-19T07:58:00Z"}]`, expected: &pestcontrol_domain.InvalidPestEvent{}},
+			{name: "an unknown device kind", events: `"device_kind":"mouse_trap","events":[{"type":"tamper","occurred_at":"2026-10-19T07:58:00Z","tamper":"opened"}]`, expected: &domain_validation.DomainValidationError{}},
+			{name: "an unknown event", events: `"device_kind":"snap_trap","events":[{"type":"trap_armed","occurred_at":"2026-10-19T07:58:00Z"}]`, expected: &pestcontrol_domain.InvalidPestEvent{}},
+			{name: "a value out of range", events: `"device_kind":"insect_light_trap","events":[{"type":"glue_board_coverage","occurred_at":"2026-10-19T07:58:00Z","glue_board_coverage":120}]`, expected: &domain_validation.DomainValidationError{}},
+			{name: "a missing value", events: `"device_kind":"bait_station","events":[{"type":"bait_consumed","occurred_at":"2026-10-19T07:58:00Z"}]`, expected: &pestcontrol_domain.InvalidPestEvent{}},
+		}
+
+		for _, scenario := range tests {
+			t.Run(scenario.name, func(t *testing.T) {
+				_, err := decoder.Decode(newFrame(t, `{"data":{"attributes":{"recorded_at":"2026-10-19T07:59:00Z",`+scenario.events+`}}}`))
+
+				assert.IsType(t, scenario.expected, err)
+			})
+		}
+	})
+
+	t.Run("should reject the pest metrics not reported as events", func(t *testing.T) {
+		_, err := decoder.Decode(newFrame(t, `{"data":{"attributes":{"recorded_at":"2026-10-19T07:59:00Z","metrics":{"trap_triggered":1}}}}`))
+
+		assert.IsType(t, &telemetry_domain.InvalidReading{}, err)
+	})
+}
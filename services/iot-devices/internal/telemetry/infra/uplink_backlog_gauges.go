@@ -0,0 +1,53 @@
+package telemetry_infra
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	otel_metric "go.opentelemetry.io/otel/metric"
+
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+)
+
+const (
+	uplinkBacklogRemainingGaugeName = "telemetry.uplink_backlog.remaining"
+	uplinkBacklogLagGaugeName       = "telemetry.uplink_backlog.lag"
+)
+
+// RegisterUplinkBacklogGauges reports the backlog of every device on each collection,
+// labelled by device id.
+func RegisterUplinkBacklogGauges(meter otel_metric.Meter, repository telemetry_domain.UplinkBacklogRepository) error {
+	remaining, err := meter.Int64ObservableGauge(
+		uplinkBacklogRemainingGaugeName,
+		otel_metric.WithDescription("Readings the device reported as still buffered"),
+	)
+	if err != nil {
+		return err
+	}
+
+	lag, err := meter.Float64ObservableGauge(
+		uplinkBacklogLagGaugeName,
+		otel_metric.WithDescription("Age of the newest backfilled reading of the device"),
+		otel_metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, observer otel_metric.Observer) error {
+		backlogs, err := repository.SearchAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, backlog := range backlogs {
+			device := otel_metric.WithAttributes(attribute.String("device_id", backlog.DeviceID))
+			observer.ObserveInt64(remaining, backlog.Remaining, device)
+			observer.ObserveFloat64(lag, backlog.Lag.Seconds(), device)
+		}
+
+		return nil
+	}, remaining, lag)
+
+	return err
+}
@@ -0,0 +1,12 @@
+package tenancy_application
+
+const AssignDeviceToZoneCommandName = "AssignDeviceToZoneCommand"
+
+type AssignDeviceToZoneCommand struct {
+	DeviceID string
+	ZoneID   string
+}
+
+func (c AssignDeviceToZoneCommand) Type() string {
+	return AssignDeviceToZoneCommandName
+}
@@ -0,0 +1,53 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+// AssignDeviceToZoneCommandHandler sets a provisioned device up in a zone of the tenant.
+// The tenant claims the device when it is not assigned yet.
+type AssignDeviceToZoneCommandHandler struct {
+	zones   tenancy_domain.ZoneRepository
+	devices tenancy_domain.DeviceRepository
+}
+
+func NewAssignDeviceToZoneCommandHandler(
+	zones tenancy_domain.ZoneRepository,
+	devices tenancy_domain.DeviceRepository,
+) *AssignDeviceToZoneCommandHandler {
+	return &AssignDeviceToZoneCommandHandler{zones: zones, devices: devices}
+}
+
+func (h AssignDeviceToZoneCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*AssignDeviceToZoneCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	zone, err := h.zones.Find(ctx, cmd.ZoneID)
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return tenancy_domain.NewZoneNotExists(cmd.ZoneID)
+	}
+
+	device, err := h.devices.FindAssignable(ctx, cmd.DeviceID)
+	if err != nil {
+		return err
+	}
+	if device == nil {
+		return tenancy_domain.NewDeviceNotExists(cmd.DeviceID)
+	}
+
+	assigned, err := device.AssignTo(*zone)
+	if err != nil {
+		return err
+	}
+
+	return h.devices.Assign(ctx, assigned)
+}
@@ -0,0 +1,13 @@
+package tenancy_application
+
+const CreateCustomerCommandName = "CreateCustomerCommand"
+
+type CreateCustomerCommand struct {
+	ID       string
+	TenantID string
+	Name     string
+}
+
+func (c CreateCustomerCommand) Type() string {
+	return CreateCustomerCommandName
+}
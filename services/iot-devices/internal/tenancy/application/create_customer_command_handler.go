@@ -0,0 +1,36 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type CreateCustomerCommandHandler struct {
+	customers    tenancy_domain.CustomerRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewCreateCustomerCommandHandler(
+	customers tenancy_domain.CustomerRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *CreateCustomerCommandHandler {
+	return &CreateCustomerCommandHandler{customers: customers, timeProvider: timeProvider}
+}
+
+func (h CreateCustomerCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*CreateCustomerCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	customer, err := tenancy_domain.NewCustomer(cmd.ID, cmd.TenantID, cmd.Name, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.customers.Save(ctx, customer)
+}
@@ -0,0 +1,14 @@
+package tenancy_application
+
+const CreateSiteCommandName = "CreateSiteCommand"
+
+type CreateSiteCommand struct {
+	ID         string
+	CustomerID string
+	Name       string
+	Timezone   string
+}
+
+func (c CreateSiteCommand) Type() string {
+	return CreateSiteCommandName
+}
@@ -0,0 +1,48 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// CreateSiteCommandHandler sets a site up for a customer of the tenant, the customers of
+// the other tenants being as unknown as the ones that do not exist.
+type CreateSiteCommandHandler struct {
+	customers    tenancy_domain.CustomerRepository
+	sites        tenancy_domain.SiteRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewCreateSiteCommandHandler(
+	customers tenancy_domain.CustomerRepository,
+	sites tenancy_domain.SiteRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *CreateSiteCommandHandler {
+	return &CreateSiteCommandHandler{customers: customers, sites: sites, timeProvider: timeProvider}
+}
+
+func (h CreateSiteCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*CreateSiteCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	customer, err := h.customers.Find(ctx, cmd.CustomerID)
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		return tenancy_domain.NewCustomerNotExists(cmd.CustomerID)
+	}
+
+	site, err := tenancy_domain.NewSite(cmd.ID, *customer, cmd.Name, cmd.Timezone, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.sites.Save(ctx, site)
+}
@@ -0,0 +1,13 @@
+package tenancy_application
+
+const CreateZoneCommandName = "CreateZoneCommand"
+
+type CreateZoneCommand struct {
+	ID     string
+	SiteID string
+	Name   string
+}
+
+func (c CreateZoneCommand) Type() string {
+	return CreateZoneCommandName
+}
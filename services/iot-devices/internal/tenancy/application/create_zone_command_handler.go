@@ -0,0 +1,46 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type CreateZoneCommandHandler struct {
+	sites        tenancy_domain.SiteRepository
+	zones        tenancy_domain.ZoneRepository
+	timeProvider amf_utils.DateTimeProvider
+}
+
+func NewCreateZoneCommandHandler(
+	sites tenancy_domain.SiteRepository,
+	zones tenancy_domain.ZoneRepository,
+	timeProvider amf_utils.DateTimeProvider,
+) *CreateZoneCommandHandler {
+	return &CreateZoneCommandHandler{sites: sites, zones: zones, timeProvider: timeProvider}
+}
+
+func (h CreateZoneCommandHandler) Handle(ctx context.Context, command amf_bus.Dto) error {
+	cmd, ok := command.(*CreateZoneCommand)
+	if !ok {
+		return amf_bus.NewInvalidDto("invalid command")
+	}
+
+	site, err := h.sites.Find(ctx, cmd.SiteID)
+	if err != nil {
+		return err
+	}
+	if site == nil {
+		return tenancy_domain.NewSiteNotExists(cmd.SiteID)
+	}
+
+	zone, err := tenancy_domain.NewZone(cmd.ID, *site, cmd.Name, h.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.zones.Save(ctx, zone)
+}
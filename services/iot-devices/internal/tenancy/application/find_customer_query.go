@@ -0,0 +1,11 @@
+package tenancy_application
+
+const FindCustomerQueryName = "FindCustomerQuery"
+
+type FindCustomerQuery struct {
+	ID string
+}
+
+func (q FindCustomerQuery) Type() string {
+	return FindCustomerQueryName
+}
@@ -0,0 +1,34 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindCustomerQueryHandler struct {
+	customers tenancy_domain.CustomerRepository
+}
+
+func NewFindCustomerQueryHandler(customers tenancy_domain.CustomerRepository) *FindCustomerQueryHandler {
+	return &FindCustomerQueryHandler{customers: customers}
+}
+
+func (h FindCustomerQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindCustomerQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	customer, err := h.customers.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, tenancy_domain.NewCustomerNotExists(q.ID)
+	}
+
+	return NewCustomerResponse(*customer), nil
+}
@@ -0,0 +1,11 @@
+package tenancy_application
+
+const FindDeviceQueryName = "FindDeviceQuery"
+
+type FindDeviceQuery struct {
+	ID string
+}
+
+func (q FindDeviceQuery) Type() string {
+	return FindDeviceQueryName
+}
@@ -0,0 +1,34 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindDeviceQueryHandler struct {
+	devices tenancy_domain.DeviceRepository
+}
+
+func NewFindDeviceQueryHandler(devices tenancy_domain.DeviceRepository) *FindDeviceQueryHandler {
+	return &FindDeviceQueryHandler{devices: devices}
+}
+
+func (h FindDeviceQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindDeviceQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	device, err := h.devices.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		return nil, tenancy_domain.NewDeviceNotExists(q.ID)
+	}
+
+	return NewDeviceResponse(*device), nil
+}
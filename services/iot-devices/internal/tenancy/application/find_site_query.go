@@ -0,0 +1,11 @@
+package tenancy_application
+
+const FindSiteQueryName = "FindSiteQuery"
+
+type FindSiteQuery struct {
+	ID string
+}
+
+func (q FindSiteQuery) Type() string {
+	return FindSiteQueryName
+}
@@ -0,0 +1,34 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindSiteQueryHandler struct {
+	sites tenancy_domain.SiteRepository
+}
+
+func NewFindSiteQueryHandler(sites tenancy_domain.SiteRepository) *FindSiteQueryHandler {
+	return &FindSiteQueryHandler{sites: sites}
+}
+
+func (h FindSiteQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindSiteQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	site, err := h.sites.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if site == nil {
+		return nil, tenancy_domain.NewSiteNotExists(q.ID)
+	}
+
+	return NewSiteResponse(*site), nil
+}
@@ -0,0 +1,11 @@
+package tenancy_application
+
+const FindZoneQueryName = "FindZoneQuery"
+
+type FindZoneQuery struct {
+	ID string
+}
+
+func (q FindZoneQuery) Type() string {
+	return FindZoneQueryName
+}
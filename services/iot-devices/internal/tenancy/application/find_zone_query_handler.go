@@ -0,0 +1,34 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type FindZoneQueryHandler struct {
+	zones tenancy_domain.ZoneRepository
+}
+
+func NewFindZoneQueryHandler(zones tenancy_domain.ZoneRepository) *FindZoneQueryHandler {
+	return &FindZoneQueryHandler{zones: zones}
+}
+
+func (h FindZoneQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*FindZoneQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	zone, err := h.zones.Find(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	if zone == nil {
+		return nil, tenancy_domain.NewZoneNotExists(q.ID)
+	}
+
+	return NewZoneResponse(*zone), nil
+}
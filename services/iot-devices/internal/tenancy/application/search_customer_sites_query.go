@@ -0,0 +1,11 @@
+package tenancy_application
+
+const SearchCustomerSitesQueryName = "SearchCustomerSitesQuery"
+
+type SearchCustomerSitesQuery struct {
+	CustomerID string
+}
+
+func (q SearchCustomerSitesQuery) Type() string {
+	return SearchCustomerSitesQueryName
+}
@@ -0,0 +1,36 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchCustomerSitesQueryHandler struct {
+	sites tenancy_domain.SiteRepository
+}
+
+func NewSearchCustomerSitesQueryHandler(sites tenancy_domain.SiteRepository) *SearchCustomerSitesQueryHandler {
+	return &SearchCustomerSitesQueryHandler{sites: sites}
+}
+
+func (h SearchCustomerSitesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchCustomerSitesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	sites, err := h.sites.SearchByCustomer(ctx, q.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*SiteResponse, 0, len(sites))
+	for _, site := range sites {
+		response = append(response, NewSiteResponse(site))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,9 @@
+package tenancy_application
+
+const SearchCustomersQueryName = "SearchCustomersQuery"
+
+type SearchCustomersQuery struct{}
+
+func (q SearchCustomersQuery) Type() string {
+	return SearchCustomersQueryName
+}
@@ -0,0 +1,35 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchCustomersQueryHandler struct {
+	customers tenancy_domain.CustomerRepository
+}
+
+func NewSearchCustomersQueryHandler(customers tenancy_domain.CustomerRepository) *SearchCustomersQueryHandler {
+	return &SearchCustomersQueryHandler{customers: customers}
+}
+
+func (h SearchCustomersQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	if _, ok := query.(*SearchCustomersQuery); !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	customers, err := h.customers.Search(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*CustomerResponse, 0, len(customers))
+	for _, customer := range customers {
+		response = append(response, NewCustomerResponse(customer))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,11 @@
+package tenancy_application
+
+const SearchSiteZonesQueryName = "SearchSiteZonesQuery"
+
+type SearchSiteZonesQuery struct {
+	SiteID string
+}
+
+func (q SearchSiteZonesQuery) Type() string {
+	return SearchSiteZonesQueryName
+}
@@ -0,0 +1,36 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchSiteZonesQueryHandler struct {
+	zones tenancy_domain.ZoneRepository
+}
+
+func NewSearchSiteZonesQueryHandler(zones tenancy_domain.ZoneRepository) *SearchSiteZonesQueryHandler {
+	return &SearchSiteZonesQueryHandler{zones: zones}
+}
+
+func (h SearchSiteZonesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchSiteZonesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	zones, err := h.zones.SearchBySite(ctx, q.SiteID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*ZoneResponse, 0, len(zones))
+	for _, zone := range zones {
+		response = append(response, NewZoneResponse(zone))
+	}
+
+	return response, nil
+}
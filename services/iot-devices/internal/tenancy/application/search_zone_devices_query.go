@@ -0,0 +1,11 @@
+package tenancy_application
+
+const SearchZoneDevicesQueryName = "SearchZoneDevicesQuery"
+
+type SearchZoneDevicesQuery struct {
+	ZoneID string
+}
+
+func (q SearchZoneDevicesQuery) Type() string {
+	return SearchZoneDevicesQueryName
+}
@@ -0,0 +1,36 @@
+package tenancy_application
+
+import (
+	"context"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_bus "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+type SearchZoneDevicesQueryHandler struct {
+	devices tenancy_domain.DeviceRepository
+}
+
+func NewSearchZoneDevicesQueryHandler(devices tenancy_domain.DeviceRepository) *SearchZoneDevicesQueryHandler {
+	return &SearchZoneDevicesQueryHandler{devices: devices}
+}
+
+func (h SearchZoneDevicesQueryHandler) Handle(ctx context.Context, query amf_bus.Dto) (interface{}, error) {
+	q, ok := query.(*SearchZoneDevicesQuery)
+	if !ok {
+		return nil, amf_bus.NewInvalidDto("invalid query")
+	}
+
+	devices, err := h.devices.SearchByZone(ctx, q.ZoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*DeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		response = append(response, NewDeviceResponse(device))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,71 @@
+package tenancy_application
+
+import (
+	"time"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+)
+
+type CustomerResponse struct {
+	ID        string `jsonapi:"primary,customers"`
+	Name      string `jsonapi:"attr,name"`
+	CreatedAt string `jsonapi:"attr,created_at"`
+}
+
+func NewCustomerResponse(customer tenancy_domain.Customer) *CustomerResponse {
+	return &CustomerResponse{
+		ID:        customer.ID,
+		Name:      customer.Name,
+		CreatedAt: customer.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type SiteResponse struct {
+	ID         string `jsonapi:"primary,sites"`
+	CustomerID string `jsonapi:"attr,customer_id"`
+	Name       string `jsonapi:"attr,name"`
+	Timezone   string `jsonapi:"attr,timezone"`
+	CreatedAt  string `jsonapi:"attr,created_at"`
+}
+
+func NewSiteResponse(site tenancy_domain.Site) *SiteResponse {
+	return &SiteResponse{
+		ID:         site.ID,
+		CustomerID: site.CustomerID,
+		Name:       site.Name,
+		Timezone:   site.Timezone,
+		CreatedAt:  site.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type ZoneResponse struct {
+	ID        string `jsonapi:"primary,zones"`
+	SiteID    string `jsonapi:"attr,site_id"`
+	Name      string `jsonapi:"attr,name"`
+	CreatedAt string `jsonapi:"attr,created_at"`
+}
+
+func NewZoneResponse(zone tenancy_domain.Zone) *ZoneResponse {
+	return &ZoneResponse{
+		ID:        zone.ID,
+		SiteID:    zone.SiteID,
+		Name:      zone.Name,
+		CreatedAt: zone.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type DeviceResponse struct {
+	ID     string `jsonapi:"primary,devices"`
+	SiteID string `jsonapi:"attr,site_id,omitempty"`
+	ZoneID string `jsonapi:"attr,zone_id,omitempty"`
+	Model  string `jsonapi:"attr,model,omitempty"`
+}
+
+func NewDeviceResponse(device tenancy_domain.Device) *DeviceResponse {
+	return &DeviceResponse{
+		ID:     device.ID,
+		SiteID: device.SiteID,
+		ZoneID: device.ZoneID,
+		Model:  device.Model,
+	}
+}
@@ -0,0 +1,113 @@
+package tenancy_application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	tenancy_application "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/application"
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+	tenancy_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain/mocks"
+
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestTenancyHierarchyHandlers(t *testing.T) {
+	ctx := amf_tenancy.WithTenant(context.Background(), "tenant-1")
+	ulidProvider, timeProvider := amf_utils.NewFixedUlidProvider(), amf_utils.NewFixedTimeProvider()
+	customer, err := tenancy_domain.NewCustomer(amf_utils.NewUlid().String(), "tenant-1", "Acme Foods", timeProvider.Now())
+	require.NoError(t, err)
+	site, err := tenancy_domain.NewSite(amf_utils.NewUlid().String(), customer, "Madrid warehouse", "Europe/Madrid", timeProvider.Now())
+	require.NoError(t, err)
+	zone, err := tenancy_domain.NewZone(amf_utils.NewUlid().String(), site, "Loading dock", timeProvider.Now())
+	require.NoError(t, err)
+
+	t.Run("should create the site under the customer of the tenant", func(t *testing.T) {
+		customers := tenancy_domain_mocks.NewCustomerRepository(t)
+		sites := tenancy_domain_mocks.NewSiteRepository(t)
+		id := ulidProvider.New().String()
+
+		customers.On("Find", ctx, customer.ID).Return(&customer, nil).Once()
+		sites.On("Save", ctx, mock.MatchedBy(func(site tenancy_domain.Site) bool {
+			return site.ID == id && site.TenantID == "tenant-1" && site.CustomerID == customer.ID
+		})).Return(nil).Once()
+
+		handler := tenancy_application.NewCreateSiteCommandHandler(customers, sites, timeProvider)
+		require.NoError(t, handler.Handle(ctx, &tenancy_application.CreateSiteCommand{
+			ID:         id,
+			CustomerID: customer.ID,
+			Name:       "Barcelona warehouse",
+		}))
+	})
+
+	t.Run("should refuse a site for a customer out of the tenant scope", func(t *testing.T) {
+		customers := tenancy_domain_mocks.NewCustomerRepository(t)
+
+		customers.On("Find", ctx, "customer-of-tenant-2").Return(nil, nil).Once()
+
+		handler := tenancy_application.NewCreateSiteCommandHandler(customers, tenancy_domain_mocks.NewSiteRepository(t), timeProvider)
+		err := handler.Handle(ctx, &tenancy_application.CreateSiteCommand{
+			ID:         ulidProvider.New().String(),
+			CustomerID: "customer-of-tenant-2",
+			Name:       "Barcelona warehouse",
+		})
+
+		assert.IsType(t, &tenancy_domain.CustomerNotExists{}, err)
+	})
+
+	t.Run("should refuse a zone for a site out of the tenant scope", func(t *testing.T) {
+		sites := tenancy_domain_mocks.NewSiteRepository(t)
+
+		sites.On("Find", ctx, "site-of-tenant-2").Return(nil, nil).Once()
+
+		handler := tenancy_application.NewCreateZoneCommandHandler(sites, tenancy_domain_mocks.NewZoneRepository(t), timeProvider)
+		err := handler.Handle(ctx, &tenancy_application.CreateZoneCommand{
+			ID:     ulidProvider.New().String(),
+			SiteID: "site-of-tenant-2",
+			Name:   "Kitchen",
+		})
+
+		assert.IsType(t, &tenancy_domain.SiteNotExists{}, err)
+	})
+
+	t.Run("should claim the devices not assigned yet for the tenant", func(t *testing.T) {
+		zones := tenancy_domain_mocks.NewZoneRepository(t)
+		devices := tenancy_domain_mocks.NewDeviceRepository(t)
+
+		zones.On("Find", ctx, zone.ID).Return(&zone, nil).Once()
+		devices.On("FindAssignable", ctx, "device-1").Return(&tenancy_domain.Device{ID: "device-1"}, nil).Once()
+		devices.On("Assign", ctx, tenancy_domain.Device{ID: "device-1", TenantID: "tenant-1", SiteID: site.ID, ZoneID: zone.ID}).
+			Return(nil).Once()
+
+		handler := tenancy_application.NewAssignDeviceToZoneCommandHandler(zones, devices)
+		require.NoError(t, handler.Handle(ctx, &tenancy_application.AssignDeviceToZoneCommand{DeviceID: "device-1", ZoneID: zone.ID}))
+	})
+
+	t.Run("should never hand a device of another tenant over", func(t *testing.T) {
+		zones := tenancy_domain_mocks.NewZoneRepository(t)
+		devices := tenancy_domain_mocks.NewDeviceRepository(t)
+
+		zones.On("Find", ctx, zone.ID).Return(&zone, nil).Once()
+		devices.On("FindAssignable", ctx, "device-2").Return(&tenancy_domain.Device{ID: "device-2", TenantID: "tenant-2"}, nil).Once()
+
+		handler := tenancy_application.NewAssignDeviceToZoneCommandHandler(zones, devices)
+		err := handler.Handle(ctx, &tenancy_application.AssignDeviceToZoneCommand{DeviceID: "device-2", ZoneID: zone.ID})
+
+		assert.IsType(t, &tenancy_domain.DeviceNotExists{}, err)
+	})
+
+	t.Run("should answer not found for the devices out of the tenant scope", func(t *testing.T) {
+		devices := tenancy_domain_mocks.NewDeviceRepository(t)
+
+		devices.On("Find", ctx, "device-2").Return(nil, nil).Once()
+
+		handler := tenancy_application.NewFindDeviceQueryHandler(devices)
+		_, err := handler.Handle(ctx, &tenancy_application.FindDeviceQuery{ID: "device-2"})
+
+		assert.IsType(t, &tenancy_domain.DeviceNotExists{}, err)
+	})
+}
@@ -0,0 +1,58 @@
+package tenancy_domain
+
+import (
+	"context"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const (
+	tenantIdMaxLength = 50
+	nameMaxLength     = 100
+)
+
+// Customer is a company the tenant serves, owning the sites its devices are set up at.
+type Customer struct {
+	ID        string
+	TenantID  string
+	Name      string
+	CreatedAt time.Time
+}
+
+func NewCustomer(id string, tenantID string, name string, now time.Time) (Customer, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidCustomer(id, "id", "must be a ULID")); err != nil {
+		return Customer{}, err
+	}
+
+	tenantIdValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(tenantIdMaxLength),
+	)
+	if err := tenantIdValidator.Validate(tenantID, NewInvalidCustomer(id, "tenant_id", "must be a non empty string")); err != nil {
+		return Customer{}, err
+	}
+
+	nameValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(nameMaxLength),
+	)
+	if err := nameValidator.Validate(name, NewInvalidCustomer(id, "name", "must be a non empty string")); err != nil {
+		return Customer{}, err
+	}
+
+	return Customer{
+		ID:        id,
+		TenantID:  tenantID,
+		Name:      name,
+		CreatedAt: now,
+	}, nil
+}
+
+// CustomerRepository only reaches the customers of the tenant scope of the context.
+type CustomerRepository interface {
+	Save(ctx context.Context, customer Customer) error
+	// Find returns nil when there is no customer for the id within the scope
+	Find(ctx context.Context, id string) (*Customer, error)
+	Search(ctx context.Context) ([]Customer, error)
+}
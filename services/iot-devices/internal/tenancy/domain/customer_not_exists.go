@@ -0,0 +1,24 @@
+package tenancy_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const customerNotExistsErrorMessage = "Customer not exists"
+
+type CustomerNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (cne CustomerNotExists) Error() string {
+	return customerNotExistsErrorMessage
+}
+
+func (cne CustomerNotExists) ExtraItems() map[string]interface{} {
+	return cne.items
+}
+
+func NewCustomerNotExists(id string) *CustomerNotExists {
+	return &CustomerNotExists{items: map[string]interface{}{"id": id}}
+}
@@ -0,0 +1,43 @@
+package tenancy_domain
+
+import (
+	"context"
+)
+
+// Device is a provisioned device as the tenancy sees it: the zone it is set up in, and
+// through it the site and the tenant it belongs to. Devices without tenant are not
+// assigned yet.
+type Device struct {
+	ID       string
+	TenantID string
+	SiteID   string
+	ZoneID   string
+	Model    string
+}
+
+func (d Device) Assigned() bool {
+	return d.TenantID != ""
+}
+
+// AssignTo sets the device up in the zone. Devices can be moved across the zones of their
+// tenant, but never handed over to another tenant, to whom they do not exist.
+func (d Device) AssignTo(zone Zone) (Device, error) {
+	if d.Assigned() && d.TenantID != zone.TenantID {
+		return Device{}, NewDeviceNotExists(d.ID)
+	}
+
+	d.TenantID, d.SiteID, d.ZoneID = zone.TenantID, zone.SiteID, zone.ID
+
+	return d, nil
+}
+
+// DeviceRepository only reaches the devices of the tenant scope of the context.
+type DeviceRepository interface {
+	// Find returns nil when there is no device for the id within the scope
+	Find(ctx context.Context, id string) (*Device, error)
+	// FindAssignable returns the device within the scope or not assigned yet, if any
+	FindAssignable(ctx context.Context, id string) (*Device, error)
+	// Assign stores the tenant, the site and the zone of the device
+	Assign(ctx context.Context, device Device) error
+	SearchByZone(ctx context.Context, zoneID string) ([]Device, error)
+}
@@ -0,0 +1,24 @@
+package tenancy_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidCustomerErrorMessage = "Invalid customer"
+
+type InvalidCustomer struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ic InvalidCustomer) Error() string {
+	return invalidCustomerErrorMessage
+}
+
+func (ic InvalidCustomer) ExtraItems() map[string]interface{} {
+	return ic.items
+}
+
+func NewInvalidCustomer(id string, field string, reason string) *InvalidCustomer {
+	return &InvalidCustomer{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
@@ -0,0 +1,24 @@
+package tenancy_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidSiteErrorMessage = "Invalid site"
+
+type InvalidSite struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (is InvalidSite) Error() string {
+	return invalidSiteErrorMessage
+}
+
+func (is InvalidSite) ExtraItems() map[string]interface{} {
+	return is.items
+}
+
+func NewInvalidSite(id string, field string, reason string) *InvalidSite {
+	return &InvalidSite{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
@@ -0,0 +1,24 @@
+package tenancy_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidZoneErrorMessage = "Invalid zone"
+
+type InvalidZone struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (iz InvalidZone) Error() string {
+	return invalidZoneErrorMessage
+}
+
+func (iz InvalidZone) ExtraItems() map[string]interface{} {
+	return iz.items
+}
+
+func NewInvalidZone(id string, field string, reason string) *InvalidZone {
+	return &InvalidZone{items: map[string]interface{}{"id": id, "field": field, "reason": reason}}
+}
@@ -0,0 +1,61 @@
+package tenancy_domain
+
+import (
+	"context"
+	"time"
+	_ "time/tzdata"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+const defaultSiteTimezone = "UTC"
+
+// Site is a premise of a customer, like a warehouse or a restaurant. It belongs to the
+// tenant of its customer.
+type Site struct {
+	ID         string
+	TenantID   string
+	CustomerID string
+	Name       string
+	Timezone   string
+	CreatedAt  time.Time
+}
+
+// NewSite sets the site up for the customer, in UTC when no timezone is given.
+func NewSite(id string, customer Customer, name string, timezone string, now time.Time) (Site, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidSite(id, "id", "must be a ULID")); err != nil {
+		return Site{}, err
+	}
+
+	nameValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(nameMaxLength),
+	)
+	if err := nameValidator.Validate(name, NewInvalidSite(id, "name", "must be a non empty string")); err != nil {
+		return Site{}, err
+	}
+
+	if timezone == "" {
+		timezone = defaultSiteTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return Site{}, NewInvalidSite(id, "timezone", "must be an IANA timezone")
+	}
+
+	return Site{
+		ID:         id,
+		TenantID:   customer.TenantID,
+		CustomerID: customer.ID,
+		Name:       name,
+		Timezone:   timezone,
+		CreatedAt:  now,
+	}, nil
+}
+
+// SiteRepository only reaches the sites of the tenant scope of the context.
+type SiteRepository interface {
+	Save(ctx context.Context, site Site) error
+	// Find returns nil when there is no site for the id within the scope
+	Find(ctx context.Context, id string) (*Site, error)
+	SearchByCustomer(ctx context.Context, customerID string) ([]Site, error)
+}
@@ -0,0 +1,24 @@
+package tenancy_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const siteNotExistsErrorMessage = "Site not exists"
+
+type SiteNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (sne SiteNotExists) Error() string {
+	return siteNotExistsErrorMessage
+}
+
+func (sne SiteNotExists) ExtraItems() map[string]interface{} {
+	return sne.items
+}
+
+func NewSiteNotExists(id string) *SiteNotExists {
+	return &SiteNotExists{items: map[string]interface{}{"id": id}}
+}
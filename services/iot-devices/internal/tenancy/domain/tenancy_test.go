@@ -0,0 +1,78 @@
+package tenancy_domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestTenancyHierarchy(t *testing.T) {
+	now := time.Now()
+	customer, err := tenancy_domain.NewCustomer(amf_utils.NewUlid().String(), "tenant-1", "Acme Foods", now)
+	require.NoError(t, err)
+
+	t.Run("should hand the tenant of the customer down to its sites and zones", func(t *testing.T) {
+		site, err := tenancy_domain.NewSite(amf_utils.NewUlid().String(), customer, "Madrid warehouse", "", now)
+		require.NoError(t, err)
+		zone, err := tenancy_domain.NewZone(amf_utils.NewUlid().String(), site, "Loading dock", now)
+		require.NoError(t, err)
+
+		assert.Equal(t, "tenant-1", site.TenantID)
+		assert.Equal(t, customer.ID, site.CustomerID)
+		assert.Equal(t, "UTC", site.Timezone)
+		assert.Equal(t, "tenant-1", zone.TenantID)
+		assert.Equal(t, site.ID, zone.SiteID)
+	})
+
+	t.Run("should refuse a customer without tenant", func(t *testing.T) {
+		_, err := tenancy_domain.NewCustomer(amf_utils.NewUlid().String(), "", "Acme Foods", now)
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+
+	t.Run("should refuse a site with an unknown timezone", func(t *testing.T) {
+		_, err := tenancy_domain.NewSite(amf_utils.NewUlid().String(), customer, "Madrid warehouse", "Mars/Olympus", now)
+
+		assert.IsType(t, &tenancy_domain.InvalidSite{}, err)
+	})
+
+	t.Run("should refuse a zone without name", func(t *testing.T) {
+		site, err := tenancy_domain.NewSite(amf_utils.NewUlid().String(), customer, "Madrid warehouse", "Europe/Madrid", now)
+		require.NoError(t, err)
+
+		_, err = tenancy_domain.NewZone(amf_utils.NewUlid().String(), site, "", now)
+
+		assert.IsType(t, &domain_validation.DomainValidationError{}, err)
+	})
+}
+
+func TestDeviceAssignTo(t *testing.T) {
+	zone := tenancy_domain.Zone{ID: "zone-1", TenantID: "tenant-1", SiteID: "site-1"}
+
+	t.Run("should assign the unassigned devices to the tenant of the zone", func(t *testing.T) {
+		device, err := tenancy_domain.Device{ID: "trap-1", Model: "snap-v2"}.AssignTo(zone)
+
+		require.NoError(t, err)
+		assert.Equal(t, tenancy_domain.Device{ID: "trap-1", TenantID: "tenant-1", SiteID: "site-1", ZoneID: "zone-1", Model: "snap-v2"}, device)
+	})
+
+	t.Run("should move the devices across the zones of their tenant", func(t *testing.T) {
+		device, err := tenancy_domain.Device{ID: "trap-1", TenantID: "tenant-1", SiteID: "site-2", ZoneID: "zone-2"}.AssignTo(zone)
+
+		require.NoError(t, err)
+		assert.Equal(t, "zone-1", device.ZoneID)
+	})
+
+	t.Run("should never hand a device over to another tenant", func(t *testing.T) {
+		_, err := tenancy_domain.Device{ID: "trap-1", TenantID: "tenant-2", SiteID: "site-2", ZoneID: "zone-2"}.AssignTo(zone)
+
+		assert.IsType(t, &tenancy_domain.DeviceNotExists{}, err)
+	})
+}
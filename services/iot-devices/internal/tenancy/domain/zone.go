@@ -0,0 +1,48 @@
+package tenancy_domain
+
+import (
+	"context"
+	"time"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+)
+
+// Zone is an area of a site, like a kitchen or a loading dock, the devices are set up in.
+// It belongs to the tenant of its site.
+type Zone struct {
+	ID        string
+	TenantID  string
+	SiteID    string
+	Name      string
+	CreatedAt time.Time
+}
+
+func NewZone(id string, site Site, name string, now time.Time) (Zone, error) {
+	if err := domain_validation.NewDomainValidator(domain_validation.ULIDIdentifier()).Validate(id, NewInvalidZone(id, "id", "must be a ULID")); err != nil {
+		return Zone{}, err
+	}
+
+	nameValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.MaxLength(nameMaxLength),
+	)
+	if err := nameValidator.Validate(name, NewInvalidZone(id, "name", "must be a non empty string")); err != nil {
+		return Zone{}, err
+	}
+
+	return Zone{
+		ID:        id,
+		TenantID:  site.TenantID,
+		SiteID:    site.ID,
+		Name:      name,
+		CreatedAt: now,
+	}, nil
+}
+
+// ZoneRepository only reaches the zones of the tenant scope of the context.
+type ZoneRepository interface {
+	Save(ctx context.Context, zone Zone) error
+	// Find returns nil when there is no zone for the id within the scope
+	Find(ctx context.Context, id string) (*Zone, error)
+	SearchBySite(ctx context.Context, siteID string) ([]Zone, error)
+}
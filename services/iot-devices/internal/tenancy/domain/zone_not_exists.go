@@ -0,0 +1,24 @@
+package tenancy_domain
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const zoneNotExistsErrorMessage = "Zone not exists"
+
+type ZoneNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (zne ZoneNotExists) Error() string {
+	return zoneNotExistsErrorMessage
+}
+
+func (zne ZoneNotExists) ExtraItems() map[string]interface{} {
+	return zne.items
+}
+
+func NewZoneNotExists(id string) *ZoneNotExists {
+	return &ZoneNotExists{items: map[string]interface{}{"id": id}}
+}
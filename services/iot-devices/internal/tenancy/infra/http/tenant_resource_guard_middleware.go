@@ -0,0 +1,124 @@
+package tenancy_http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+type resourceGuard struct {
+	variable string
+	notFound func(id string) error
+	exists   func(ctx context.Context, id string) (bool, error)
+}
+
+// TenantResourceGuard answers not found for the resources in the path of the request that
+// are out of its tenant scope: the customers, sites, zones and devices, and the resources
+// of the other contexts added with Guard. It also covers the state kept out of the
+// database, like the connectivity or the live streams of a device, whose stores know
+// nothing about tenants. Its middleware must run after the tenant middleware.
+type TenantResourceGuard struct {
+	guards []resourceGuard
+	jarm   *amf_json_api.JsonApiResponseMiddleware
+}
+
+func NewTenantResourceGuard(
+	customerRepository tenancy_domain.CustomerRepository,
+	siteRepository tenancy_domain.SiteRepository,
+	zoneRepository tenancy_domain.ZoneRepository,
+	deviceRepository tenancy_domain.DeviceRepository,
+	jarm *amf_json_api.JsonApiResponseMiddleware,
+) *TenantResourceGuard {
+	guard := &TenantResourceGuard{jarm: jarm}
+	guard.Guard(
+		"tenantId",
+		func(id string) error { return amf_tenancy.NewMissingTenantScope() },
+		func(ctx context.Context, id string) (bool, error) {
+			scope, err := amf_tenancy.ScopeFromContext(ctx)
+
+			return err == nil && scope.Includes(id), err
+		},
+	)
+	guard.Guard(
+		"customerId",
+		func(id string) error { return tenancy_domain.NewCustomerNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			customer, err := customerRepository.Find(ctx, id)
+
+			return customer != nil, err
+		},
+	)
+	guard.Guard(
+		"siteId",
+		func(id string) error { return tenancy_domain.NewSiteNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			site, err := siteRepository.Find(ctx, id)
+
+			return site != nil, err
+		},
+	)
+	guard.Guard(
+		"zoneId",
+		func(id string) error { return tenancy_domain.NewZoneNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			zone, err := zoneRepository.Find(ctx, id)
+
+			return zone != nil, err
+		},
+	)
+	guard.Guard(
+		"deviceId",
+		func(id string) error { return tenancy_domain.NewDeviceNotExists(id) },
+		func(ctx context.Context, id string) (bool, error) {
+			device, err := deviceRepository.Find(ctx, id)
+
+			return device != nil, err
+		},
+	)
+
+	return guard
+}
+
+// Guard refuses the requests whose path variable names a resource that exists tells is
+// out of the tenant scope of the context. Guards are added while wiring the routes, before
+// serving any request.
+func (trg *TenantResourceGuard) Guard(
+	variable string,
+	notFound func(id string) error,
+	exists func(ctx context.Context, id string) (bool, error),
+) {
+	trg.guards = append(trg.guards, resourceGuard{variable: variable, notFound: notFound, exists: exists})
+}
+
+func (trg *TenantResourceGuard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		for _, guard := range trg.guards {
+			id, ok := vars[guard.variable]
+			if !ok {
+				continue
+			}
+
+			exists, err := guard.exists(r.Context(), id)
+			if err != nil {
+				writeInternalServerError(w, r, trg.jarm, err)
+				return
+			}
+			if !exists {
+				notFound := guard.notFound(id)
+				ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(notFound.Error())
+				trg.jarm.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, notFound)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
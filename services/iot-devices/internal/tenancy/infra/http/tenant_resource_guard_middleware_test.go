@@ -0,0 +1,140 @@
+package tenancy_http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+	tenancy_domain_mocks "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain/mocks"
+	tenancy_http "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/infra/http"
+
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+// ownedBy finds the resources the way the tenant scoped repositories do: only the ones
+// of a tenant within the scope of the context.
+func ownedBy(owners map[string]string) func(ctx context.Context, id string) (bool, error) {
+	return func(ctx context.Context, id string) (bool, error) {
+		scope, err := amf_tenancy.ScopeFromContext(ctx)
+		if err != nil {
+			return false, err
+		}
+		tenantID, ok := owners[id]
+
+		return ok && scope.Includes(tenantID), nil
+	}
+}
+
+func TestTenantResourceGuard(t *testing.T) {
+	devices := map[string]string{"trap-1": "tenant-1", "trap-2": "tenant-2"}
+	deviceRepository := tenancy_domain_mocks.NewDeviceRepository(t)
+	deviceRepository.On("Find", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, id string) (*tenancy_domain.Device, error) {
+			if id == "broken" {
+				return nil, errors.New("connection refused")
+			}
+			if found, err := ownedBy(devices)(ctx, id); err != nil || !found {
+				return nil, err
+			}
+
+			return &tenancy_domain.Device{ID: id, TenantID: devices[id]}, nil
+		},
+	).Maybe()
+
+	guard := tenancy_http.NewTenantResourceGuard(
+		tenancy_domain_mocks.NewCustomerRepository(t),
+		tenancy_domain_mocks.NewSiteRepository(t),
+		tenancy_domain_mocks.NewZoneRepository(t),
+		deviceRepository,
+		amf_json_api.NewJsonApiResponseMiddleware(logger.NewNullLogger()),
+	)
+	notFound := func(id string) error { return errors.New(id + " not found") }
+	guard.Guard("alertId", notFound, ownedBy(map[string]string{"alert-1": "tenant-1", "alert-2": "tenant-2"}))
+	guard.Guard("alertRuleId", notFound, ownedBy(map[string]string{"rule-1": "tenant-1", "rule-2": "tenant-2"}))
+	guard.Guard("campaignId", notFound, ownedBy(map[string]string{"campaign-1": "tenant-1", "campaign-2": "tenant-2"}))
+	guard.Guard("exportId", notFound, ownedBy(map[string]string{"export-1": "tenant-1", "export-2": "tenant-2"}))
+	guard.Guard("reportId", notFound, ownedBy(map[string]string{"report-1": "tenant-1", "report-2": "tenant-2"}))
+	guard.Guard("imageId", notFound, ownedBy(map[string]string{"image-1": "tenant-1", "image-2": "tenant-2"}))
+
+	router := mux.NewRouter()
+	for _, path := range []string{
+		"/alert-escalation-policies/{tenantId}",
+		"/alerts/{alertId}/acknowledge",
+		"/alert-rules/{alertRuleId}",
+		"/firmware-campaigns/{campaignId}",
+		"/exports/{exportId}",
+		"/reports/{reportId}",
+		"/glue-board-images/{imageId}",
+		"/devices/{deviceId}/downlinks/{commandId}",
+		"/devices/{deviceId}/connectivity",
+		"/devices/{deviceId}/uplinks/duplicates",
+	} {
+		router.Handle(path, guard.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+	}
+	request := func(ctx context.Context, path string) int {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil).WithContext(ctx))
+		return recorder.Code
+	}
+	tenant1 := amf_tenancy.WithTenant(context.Background(), "tenant-1")
+
+	t.Run("should let in the requests for the resources of the tenant", func(t *testing.T) {
+		for _, path := range []string{
+			"/alert-escalation-policies/tenant-1",
+			"/alerts/alert-1/acknowledge",
+			"/alert-rules/rule-1",
+			"/firmware-campaigns/campaign-1",
+			"/exports/export-1",
+			"/reports/report-1",
+			"/glue-board-images/image-1",
+			"/devices/trap-1/downlinks/command-1",
+			"/devices/trap-1/connectivity",
+			"/devices/trap-1/uplinks/duplicates",
+		} {
+			assert.Equal(t, http.StatusOK, request(tenant1, path), path)
+		}
+	})
+
+	t.Run("should answer not found for the resources of another tenant", func(t *testing.T) {
+		for _, path := range []string{
+			"/alert-escalation-policies/tenant-2",
+			"/alerts/alert-2/acknowledge",
+			"/alert-rules/rule-2",
+			"/firmware-campaigns/campaign-2",
+			"/exports/export-2",
+			"/reports/report-2",
+			"/glue-board-images/image-2",
+			"/devices/trap-2/downlinks/command-1",
+			"/devices/trap-2/connectivity",
+			"/devices/trap-2/uplinks/duplicates",
+		} {
+			assert.Equal(t, http.StatusNotFound, request(tenant1, path), path)
+		}
+	})
+
+	t.Run("should let the system processes reach the resources of every tenant", func(t *testing.T) {
+		allTenants := amf_tenancy.WithAllTenants(context.Background())
+
+		assert.Equal(t, http.StatusOK, request(allTenants, "/alerts/alert-2/acknowledge"))
+		assert.Equal(t, http.StatusOK, request(allTenants, "/devices/trap-2/downlinks/command-1"))
+	})
+
+	t.Run("should answer not found for the resources of nobody", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, request(tenant1, "/exports/export-3"))
+		assert.Equal(t, http.StatusNotFound, request(tenant1, "/devices/trap-3/connectivity"))
+	})
+
+	t.Run("should fail the requests whose resources cannot be found", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, request(tenant1, "/devices/broken/connectivity"))
+		assert.Equal(t, http.StatusInternalServerError, request(context.Background(), "/alerts/alert-1/acknowledge"))
+	})
+}
@@ -0,0 +1,107 @@
+package tenancy_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	customerColumns = `id, tenant_id, name, created_at`
+
+	upsertCustomerQuery = `
+INSERT INTO customers (` + customerColumns + `)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (id) DO UPDATE SET
+    name = EXCLUDED.name
+WHERE customers.tenant_id = EXCLUDED.tenant_id`
+	findCustomerQuery = `
+SELECT ` + customerColumns + ` FROM customers
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchCustomersQuery = `
+SELECT ` + customerColumns + ` FROM customers
+WHERE ($1::VARCHAR IS NULL OR tenant_id = $1)
+ORDER BY name, id`
+)
+
+type PostgresCustomerRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresCustomerRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresCustomerRepository {
+	return &PostgresCustomerRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresCustomerRepository) Save(ctx context.Context, customer tenancy_domain.Customer) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, customer.TenantID); err != nil {
+		return err
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertCustomerQuery,
+		customer.ID,
+		customer.TenantID,
+		customer.Name,
+		customer.CreatedAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresCustomerRepository) Find(ctx context.Context, id string) (*tenancy_domain.Customer, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := scanCustomer(r.connectionPool.Reader().QueryRowContext(ctx, findCustomerQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+func (r *PostgresCustomerRepository) Search(ctx context.Context) ([]tenancy_domain.Customer, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchCustomersQuery, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	customers := make([]tenancy_domain.Customer, 0)
+	for rows.Next() {
+		customer, err := scanCustomer(rows)
+		if err != nil {
+			return nil, err
+		}
+		customers = append(customers, customer)
+	}
+
+	return customers, rows.Err()
+}
+
+func scanCustomer(row rowScanner) (tenancy_domain.Customer, error) {
+	var customer tenancy_domain.Customer
+	err := row.Scan(&customer.ID, &customer.TenantID, &customer.Name, &customer.CreatedAt)
+
+	return customer, err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
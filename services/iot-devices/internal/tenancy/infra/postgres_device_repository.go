@@ -0,0 +1,117 @@
+package tenancy_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	deviceSelect = `
+SELECT id, tenant_id, COALESCE(site_id, ''), COALESCE(zone_id, ''), COALESCE(model, '')
+FROM spcd_iot_devices`
+
+	findDeviceQuery = deviceSelect + `
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	findAssignableDeviceQuery = deviceSelect + `
+WHERE id = $1 AND (tenant_id = '' OR $2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchDevicesByZoneQuery = deviceSelect + `
+WHERE zone_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY id`
+
+	// Devices are never taken from another tenant, even if the caller was mistaken
+	assignDeviceQuery = `
+UPDATE spcd_iot_devices SET tenant_id = $2, site_id = $3, zone_id = $4
+WHERE id = $1 AND tenant_id IN ('', $2)`
+)
+
+type PostgresDeviceRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresDeviceRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresDeviceRepository {
+	return &PostgresDeviceRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresDeviceRepository) Find(ctx context.Context, id string) (*tenancy_domain.Device, error) {
+	return r.find(ctx, r.connectionPool.Reader(), findDeviceQuery, id)
+}
+
+// FindAssignable reads from the writer, as the device is assigned right after.
+func (r *PostgresDeviceRepository) FindAssignable(ctx context.Context, id string) (*tenancy_domain.Device, error) {
+	return r.find(ctx, r.connectionPool.Writer(), findAssignableDeviceQuery, id)
+}
+
+func (r *PostgresDeviceRepository) Assign(ctx context.Context, device tenancy_domain.Device) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, device.TenantID); err != nil {
+		return err
+	}
+
+	result, err := r.connectionPool.Writer().ExecContext(ctx, assignDeviceQuery, device.ID, device.TenantID, device.SiteID, device.ZoneID)
+	if err != nil {
+		return err
+	}
+
+	assigned, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if assigned == 0 {
+		return tenancy_domain.NewDeviceNotExists(device.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgresDeviceRepository) SearchByZone(ctx context.Context, zoneID string) ([]tenancy_domain.Device, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchDevicesByZoneQuery, zoneID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	devices := make([]tenancy_domain.Device, 0)
+	for rows.Next() {
+		device, err := scanDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+func (r *PostgresDeviceRepository) find(ctx context.Context, db *sql.DB, query string, id string) (*tenancy_domain.Device, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := scanDevice(db.QueryRowContext(ctx, query, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &device, nil
+}
+
+func scanDevice(row rowScanner) (tenancy_domain.Device, error) {
+	var device tenancy_domain.Device
+	err := row.Scan(&device.ID, &device.TenantID, &device.SiteID, &device.ZoneID, &device.Model)
+
+	return device, err
+}
@@ -0,0 +1,106 @@
+package tenancy_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	siteColumns = `id, tenant_id, customer_id, name, timezone, created_at`
+
+	upsertSiteQuery = `
+INSERT INTO sites (` + siteColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (id) DO UPDATE SET
+    name = EXCLUDED.name,
+    timezone = EXCLUDED.timezone
+WHERE sites.tenant_id = EXCLUDED.tenant_id`
+	findSiteQuery = `
+SELECT ` + siteColumns + ` FROM sites
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchSitesByCustomerQuery = `
+SELECT ` + siteColumns + ` FROM sites
+WHERE customer_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY name, id`
+)
+
+type PostgresSiteRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresSiteRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresSiteRepository {
+	return &PostgresSiteRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresSiteRepository) Save(ctx context.Context, site tenancy_domain.Site) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, site.TenantID); err != nil {
+		return err
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertSiteQuery,
+		site.ID,
+		site.TenantID,
+		site.CustomerID,
+		site.Name,
+		site.Timezone,
+		site.CreatedAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresSiteRepository) Find(ctx context.Context, id string) (*tenancy_domain.Site, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	site, err := scanSite(r.connectionPool.Reader().QueryRowContext(ctx, findSiteQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &site, nil
+}
+
+func (r *PostgresSiteRepository) SearchByCustomer(ctx context.Context, customerID string) ([]tenancy_domain.Site, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchSitesByCustomerQuery, customerID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	sites := make([]tenancy_domain.Site, 0)
+	for rows.Next() {
+		site, err := scanSite(rows)
+		if err != nil {
+			return nil, err
+		}
+		sites = append(sites, site)
+	}
+
+	return sites, rows.Err()
+}
+
+func scanSite(row rowScanner) (tenancy_domain.Site, error) {
+	var site tenancy_domain.Site
+	err := row.Scan(&site.ID, &site.TenantID, &site.CustomerID, &site.Name, &site.Timezone, &site.CreatedAt)
+
+	return site, err
+}
@@ -0,0 +1,218 @@
+package tenancy_infra_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	readQueryPattern   = regexp.MustCompile(`(?is)\b(SELECT\b.+\bFROM|DELETE\s+FROM|UPDATE\s+\w+\s+SET)\b`)
+	insertQueryPattern = regexp.MustCompile(`(?is)^\s*INSERT\b`)
+	scopePattern       = regexp.MustCompile(`\$(\d+)::VARCHAR IS NULL OR`)
+	placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+	// unscopedQueries are the queries left out of the tenant scope on purpose
+	unscopedQueries = map[string]string{
+		"findFirmwareImageQuery":         "firmware images are a catalog shared by every tenant",
+		"searchAllFirmwareImagesQuery":   "firmware images are a catalog shared by every tenant",
+		"shortestReportingIntervalQuery": "device models are a catalog shared by every tenant",
+		"assignDeviceQuery":              "assignments are ensured within the scope, and only claim the devices without tenant",
+	}
+)
+
+// repositoryFile is a postgres repository of any bounded context, with its queries
+// resolved from the constants they are built from.
+type repositoryFile struct {
+	file    *ast.File
+	queries map[string]string
+	// fragments are the constants only used to build other queries
+	fragments map[string]bool
+}
+
+// TestPostgresTenantIsolation guards every query reading, updating or deleting rows of the
+// postgres repositories from reaching the rows of the tenants out of the scope of the context.
+func TestPostgresTenantIsolation(t *testing.T) {
+	paths, err := filepath.Glob("../../*/infra/postgres_*.go")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		repository := parseRepositoryFile(t, path)
+
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			t.Run("should filter every query by the tenant scope bound as its last arg", func(t *testing.T) {
+				for name, query := range repository.scopedQueries() {
+					scope := scopePattern.FindStringSubmatch(query)
+					if !assert.NotNil(t, scope, "%s is not filtered by the tenant scope", name) {
+						continue
+					}
+					assert.Equal(t, lastPlaceholder(query), scope[1], "%s does not bind the tenant scope as its last arg", name)
+				}
+			})
+
+			t.Run("should read the tenant scope from the context wherever a query is run", func(t *testing.T) {
+				// Helpers reading the scope themselves, like search, scope their callers
+				scopedFuncs := map[string]bool{"ScopeFromContext": true}
+				for _, fn := range repository.funcs() {
+					if callsAny(fn, map[string]bool{"ScopeFromContext": true}) {
+						scopedFuncs[fn.Name.Name] = true
+					}
+				}
+
+				queries := repository.scopedQueries()
+				for _, fn := range repository.funcs() {
+					for name := range queries {
+						if !references(fn, name) {
+							continue
+						}
+						assert.True(t, callsAny(fn, scopedFuncs), "%s runs %s without the tenant scope of the context", fn.Name.Name, name)
+					}
+				}
+			})
+		})
+	}
+}
+
+func parseRepositoryFile(t *testing.T, path string) repositoryFile {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	require.NoError(t, err)
+
+	repository := repositoryFile{file: file, queries: map[string]string{}, fragments: map[string]bool{}}
+	specs := make([]*ast.ValueSpec, 0)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			specs = append(specs, spec.(*ast.ValueSpec))
+		}
+	}
+
+	// Constants can be built from the ones declared after them
+	for resolved := -1; resolved != len(repository.queries); {
+		resolved = len(repository.queries)
+		for _, spec := range specs {
+			for i, name := range spec.Names {
+				if i >= len(spec.Values) {
+					continue
+				}
+				if query, ok := repository.evaluate(spec.Values[i]); ok {
+					repository.queries[name.Name] = query
+				}
+			}
+		}
+	}
+
+	for _, spec := range specs {
+		for _, value := range spec.Values {
+			ast.Inspect(value, func(node ast.Node) bool {
+				if ident, ok := node.(*ast.Ident); ok {
+					repository.fragments[ident.Name] = true
+				}
+				return true
+			})
+		}
+	}
+
+	return repository
+}
+
+func (r repositoryFile) evaluate(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.Ident:
+		value, ok := r.queries[e.Name]
+		return value, ok
+	case *ast.ParenExpr:
+		return r.evaluate(e.X)
+	case *ast.BinaryExpr:
+		left, leftOk := r.evaluate(e.X)
+		right, rightOk := r.evaluate(e.Y)
+		return left + right, leftOk && rightOk && e.Op == token.ADD
+	}
+
+	return "", false
+}
+
+// scopedQueries are the queries reaching existing rows, the plain inserts left out.
+func (r repositoryFile) scopedQueries() map[string]string {
+	queries := map[string]string{}
+	for name, query := range r.queries {
+		if r.fragments[name] || insertQueryPattern.MatchString(query) || !readQueryPattern.MatchString(query) {
+			continue
+		}
+		if _, ok := unscopedQueries[name]; ok {
+			continue
+		}
+		queries[name] = query
+	}
+
+	return queries
+}
+
+func (r repositoryFile) funcs() []*ast.FuncDecl {
+	funcs := make([]*ast.FuncDecl, 0)
+	for _, decl := range r.file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+			funcs = append(funcs, fn)
+		}
+	}
+
+	return funcs
+}
+
+func callsAny(fn *ast.FuncDecl, names map[string]bool) bool {
+	found := false
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return !found
+		}
+		switch callee := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			found = found || names[callee.Sel.Name]
+		case *ast.Ident:
+			found = found || names[callee.Name]
+		}
+		return !found
+	})
+
+	return found
+}
+
+func references(fn *ast.FuncDecl, name string) bool {
+	found := false
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+func lastPlaceholder(query string) string {
+	last := 0
+	for _, match := range placeholderPattern.FindAllStringSubmatch(query, -1) {
+		if position, _ := strconv.Atoi(match[1]); position > last {
+			last = position
+		}
+	}
+
+	return strconv.Itoa(last)
+}
@@ -0,0 +1,104 @@
+package tenancy_infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	zoneColumns = `id, tenant_id, site_id, name, created_at`
+
+	upsertZoneQuery = `
+INSERT INTO zones (` + zoneColumns + `)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (id) DO UPDATE SET
+    name = EXCLUDED.name
+WHERE zones.tenant_id = EXCLUDED.tenant_id`
+	findZoneQuery = `
+SELECT ` + zoneColumns + ` FROM zones
+WHERE id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)`
+	searchZonesBySiteQuery = `
+SELECT ` + zoneColumns + ` FROM zones
+WHERE site_id = $1 AND ($2::VARCHAR IS NULL OR tenant_id = $2)
+ORDER BY name, id`
+)
+
+type PostgresZoneRepository struct {
+	connectionPool amf_sqldb.ConnectionPool
+}
+
+func NewPostgresZoneRepository(connectionPool amf_sqldb.ConnectionPool) *PostgresZoneRepository {
+	return &PostgresZoneRepository{connectionPool: connectionPool}
+}
+
+func (r *PostgresZoneRepository) Save(ctx context.Context, zone tenancy_domain.Zone) error {
+	if err := amf_tenancy.EnsureWithinScope(ctx, zone.TenantID); err != nil {
+		return err
+	}
+
+	_, err := r.connectionPool.Writer().ExecContext(
+		ctx,
+		upsertZoneQuery,
+		zone.ID,
+		zone.TenantID,
+		zone.SiteID,
+		zone.Name,
+		zone.CreatedAt.UTC(),
+	)
+
+	return err
+}
+
+func (r *PostgresZoneRepository) Find(ctx context.Context, id string) (*tenancy_domain.Zone, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := scanZone(r.connectionPool.Reader().QueryRowContext(ctx, findZoneQuery, id, scope))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+func (r *PostgresZoneRepository) SearchBySite(ctx context.Context, siteID string) ([]tenancy_domain.Zone, error) {
+	scope, err := amf_tenancy.ScopeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.connectionPool.Reader().QueryContext(ctx, searchZonesBySiteQuery, siteID, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer amf_sqldb.CloseRows(rows)
+
+	zones := make([]tenancy_domain.Zone, 0)
+	for rows.Next() {
+		zone, err := scanZone(rows)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+
+	return zones, rows.Err()
+}
+
+func scanZone(row rowScanner) (tenancy_domain.Zone, error) {
+	var zone tenancy_domain.Zone
+	err := row.Scan(&zone.ID, &zone.TenantID, &zone.SiteID, &zone.Name, &zone.CreatedAt)
+
+	return zone, err
+}
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS telemetry_readings (
+    id VARCHAR(50) PRIMARY KEY,
+    device_id VARCHAR(50) NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    metrics JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS telemetry_readings_device_recorded_at_idx ON telemetry_readings (device_id, recorded_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS telemetry_readings CASCADE;
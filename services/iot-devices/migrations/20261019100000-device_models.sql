@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS device_models (
+    name VARCHAR(50) PRIMARY KEY,
+    reporting_interval_seconds INTEGER NOT NULL CHECK (reporting_interval_seconds > 0)
+);
+
+ALTER TABLE spcd_iot_devices ADD COLUMN IF NOT EXISTS model VARCHAR(50) REFERENCES device_models (name);
+ALTER TABLE spcd_iot_devices ADD COLUMN IF NOT EXISTS site_id VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS spcd_iot_devices_site_id_idx ON spcd_iot_devices (site_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS spcd_iot_devices_site_id_idx;
+ALTER TABLE spcd_iot_devices DROP COLUMN IF EXISTS site_id;
+ALTER TABLE spcd_iot_devices DROP COLUMN IF EXISTS model;
+DROP TABLE IF EXISTS device_models CASCADE;
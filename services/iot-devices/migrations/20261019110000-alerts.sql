@@ -0,0 +1,46 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS alerts (
+    id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT '',
+    rule_id VARCHAR(50) NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    device_id VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    occurrences INTEGER NOT NULL,
+    raised_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_triggered_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    cleared_at TIMESTAMP WITH TIME ZONE,
+    acknowledged_by VARCHAR(100),
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    snoozed_until TIMESTAMP WITH TIME ZONE,
+    resolved_by VARCHAR(100),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    escalated_to VARCHAR(100),
+    escalated_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS alerts_active_rule_device_idx ON alerts (rule_id, device_id) WHERE status <> 'resolved';
+CREATE INDEX IF NOT EXISTS alerts_status_raised_at_idx ON alerts (status, raised_at);
+
+CREATE TABLE IF NOT EXISTS alert_audit_entries (
+    id VARCHAR(50) PRIMARY KEY,
+    alert_id VARCHAR(50) NOT NULL REFERENCES alerts (id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS alert_audit_entries_alert_id_idx ON alert_audit_entries (alert_id, at);
+
+CREATE TABLE IF NOT EXISTS alert_escalation_policies (
+    tenant_id VARCHAR(50) PRIMARY KEY,
+    escalate_after_seconds INTEGER NOT NULL,
+    supervisor VARCHAR(100) NOT NULL
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS alert_escalation_policies CASCADE;
+DROP TABLE IF EXISTS alert_audit_entries CASCADE;
+DROP TABLE IF EXISTS alerts CASCADE;
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS user_notification_preferences (
+    user_id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT '',
+    language VARCHAR(2) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    email VARCHAR(320) NOT NULL DEFAULT '',
+    phone VARCHAR(20) NOT NULL DEFAULT '',
+    channels TEXT[] NOT NULL DEFAULT '{}',
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    quiet_hours_from VARCHAR(5),
+    quiet_hours_to VARCHAR(5)
+);
+
+CREATE INDEX IF NOT EXISTS user_notification_preferences_tenant_id_idx ON user_notification_preferences (tenant_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_notification_preferences CASCADE;
\ No newline at end of file
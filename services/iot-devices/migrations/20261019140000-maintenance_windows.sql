@@ -0,0 +1,37 @@
+-- +migrate Up
+ALTER TABLE spcd_iot_devices ADD COLUMN IF NOT EXISTS zone_id VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS spcd_iot_devices_zone_id_idx ON spcd_iot_devices (zone_id);
+
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+    id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT '',
+    scope VARCHAR(10) NOT NULL,
+    scope_id VARCHAR(50) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    summary JSONB
+);
+
+CREATE INDEX IF NOT EXISTS maintenance_windows_open_idx ON maintenance_windows (tenant_id, starts_at, ends_at) WHERE closed_at IS NULL;
+CREATE INDEX IF NOT EXISTS maintenance_windows_tenant_id_idx ON maintenance_windows (tenant_id, starts_at);
+
+CREATE TABLE IF NOT EXISTS maintenance_suppressed_events (
+    id VARCHAR(50) PRIMARY KEY,
+    window_id VARCHAR(50) NOT NULL REFERENCES maintenance_windows (id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    device_id VARCHAR(50) NOT NULL,
+    alert_id VARCHAR(50) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS maintenance_suppressed_events_window_id_idx ON maintenance_suppressed_events (window_id, occurred_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS maintenance_suppressed_events CASCADE;
+DROP TABLE IF EXISTS maintenance_windows CASCADE;
+DROP INDEX IF EXISTS spcd_iot_devices_zone_id_idx;
+ALTER TABLE spcd_iot_devices DROP COLUMN IF EXISTS zone_id;
\ No newline at end of file
@@ -0,0 +1,50 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS firmware_images (
+    id VARCHAR(50) PRIMARY KEY,
+    version VARCHAR(50) NOT NULL,
+    models TEXT[] NOT NULL,
+    size BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    signature BYTEA NOT NULL,
+    chunk_size BIGINT NOT NULL,
+    chunk_checksums TEXT[] NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    uploaded_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS firmware_campaigns (
+    id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT '',
+    firmware_id VARCHAR(50) NOT NULL REFERENCES firmware_images (id),
+    cohort_site_id VARCHAR(50) NOT NULL DEFAULT '',
+    cohort_model VARCHAR(50) NOT NULL DEFAULT '',
+    cohort_device_ids TEXT[] NOT NULL DEFAULT '{}',
+    stages INTEGER[] NOT NULL,
+    current_stage INTEGER NOT NULL DEFAULT 0,
+    failure_threshold DOUBLE PRECISION NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    halt_reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS firmware_campaigns_tenant_id_idx ON firmware_campaigns (tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS firmware_campaigns_running_idx ON firmware_campaigns (created_at) WHERE status = 'running';
+
+CREATE TABLE IF NOT EXISTS firmware_updates (
+    campaign_id VARCHAR(50) NOT NULL REFERENCES firmware_campaigns (id) ON DELETE CASCADE,
+    device_id VARCHAR(50) NOT NULL,
+    firmware_id VARCHAR(50) NOT NULL,
+    stage INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    error VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (campaign_id, device_id)
+);
+
+CREATE INDEX IF NOT EXISTS firmware_updates_device_id_idx ON firmware_updates (device_id, firmware_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS firmware_updates CASCADE;
+DROP TABLE IF EXISTS firmware_campaigns CASCADE;
+DROP TABLE IF EXISTS firmware_images CASCADE;
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS downlink_commands (
+    id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL DEFAULT '',
+    device_id VARCHAR(50) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    priority SMALLINT NOT NULL,
+    dedupe_key VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    result VARCHAR(255) NOT NULL DEFAULT '',
+    queued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS downlink_commands_pending_idx ON downlink_commands (device_id, priority DESC, queued_at)
+    WHERE status IN ('queued', 'sent');
+CREATE INDEX IF NOT EXISTS downlink_commands_device_id_idx ON downlink_commands (device_id, queued_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS downlink_commands CASCADE;
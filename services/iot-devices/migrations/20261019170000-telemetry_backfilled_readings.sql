@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE telemetry_readings ADD COLUMN IF NOT EXISTS backfilled BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE telemetry_readings DROP COLUMN IF EXISTS backfilled;
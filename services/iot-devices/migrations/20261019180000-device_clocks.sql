@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS device_clocks (
+    device_id VARCHAR(50) PRIMARY KEY,
+    offset_ms BIGINT NOT NULL,
+    round_trip_ms BIGINT NOT NULL,
+    samples BIGINT NOT NULL DEFAULT 0,
+    drifting BOOLEAN NOT NULL DEFAULT FALSE,
+    estimated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS device_clocks_drifting_idx ON device_clocks (device_id) WHERE drifting;
+
+ALTER TABLE telemetry_readings ADD COLUMN IF NOT EXISTS raw_recorded_at TIMESTAMP WITH TIME ZONE;
+UPDATE telemetry_readings SET raw_recorded_at = recorded_at WHERE raw_recorded_at IS NULL;
+ALTER TABLE telemetry_readings ALTER COLUMN raw_recorded_at SET NOT NULL;
+ALTER TABLE telemetry_readings ADD COLUMN IF NOT EXISTS clock_offset_ms BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE telemetry_readings DROP COLUMN IF EXISTS clock_offset_ms;
+ALTER TABLE telemetry_readings DROP COLUMN IF EXISTS raw_recorded_at;
+
+DROP TABLE IF EXISTS device_clocks CASCADE;
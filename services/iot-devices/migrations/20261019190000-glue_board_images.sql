@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS glue_board_images (
+    id VARCHAR(50) PRIMARY KEY,
+    device_id VARCHAR(50) NOT NULL,
+    event_id VARCHAR(50) NOT NULL DEFAULT '',
+    content_type VARCHAR(30) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    format VARCHAR(10) NOT NULL DEFAULT '',
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    checksum VARCHAR(64) NOT NULL DEFAULT '',
+    failure_reason VARCHAR(255) NOT NULL DEFAULT '',
+    captured_at TIMESTAMP WITH TIME ZONE,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS glue_board_images_device_id_idx ON glue_board_images (device_id, requested_at DESC);
+CREATE INDEX IF NOT EXISTS glue_board_images_event_id_idx ON glue_board_images (event_id) WHERE event_id <> '';
+
+-- +migrate Down
+DROP TABLE IF EXISTS glue_board_images CASCADE;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS telemetry_rollups (
+    resolution VARCHAR(10) NOT NULL,
+    device_id VARCHAR(50) NOT NULL,
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    metric VARCHAR(100) NOT NULL,
+    site_id VARCHAR(50) NOT NULL DEFAULT '',
+    readings BIGINT NOT NULL,
+    sum DOUBLE PRECISION NOT NULL,
+    min DOUBLE PRECISION NOT NULL,
+    max DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (resolution, device_id, bucket_start, metric)
+);
+
+CREATE INDEX IF NOT EXISTS telemetry_rollups_device_bucket_idx ON telemetry_rollups (device_id, bucket_start);
+CREATE INDEX IF NOT EXISTS telemetry_rollups_site_idx ON telemetry_rollups (resolution, site_id, bucket_start);
+
+-- +migrate Down
+DROP TABLE IF EXISTS telemetry_rollups CASCADE;
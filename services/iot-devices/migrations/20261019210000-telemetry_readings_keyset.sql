@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS telemetry_readings_device_keyset_idx ON telemetry_readings (device_id, recorded_at DESC, id DESC);
+
+-- +migrate Down
+DROP INDEX IF EXISTS telemetry_readings_device_keyset_idx;
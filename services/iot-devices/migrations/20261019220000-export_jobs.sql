@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS export_jobs (
+    id VARCHAR(50) PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL,
+    from_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    to_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    columns JSONB NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    row_count INTEGER NOT NULL DEFAULT 0,
+    failure_reason VARCHAR(255) NOT NULL DEFAULT '',
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS export_jobs_status_idx ON export_jobs (status, requested_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS export_jobs CASCADE;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS service_visits (
+    id VARCHAR(50) PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL,
+    technician VARCHAR(100) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    notes TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS service_visits_site_started_at_idx ON service_visits (site_id, started_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS service_visits CASCADE;
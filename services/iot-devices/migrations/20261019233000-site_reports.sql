@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS site_reports (
+    id VARCHAR(50) PRIMARY KEY,
+    site_id VARCHAR(50) NOT NULL,
+    month VARCHAR(7) NOT NULL,
+    version INTEGER NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    generated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (site_id, month, version)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS site_reports CASCADE;
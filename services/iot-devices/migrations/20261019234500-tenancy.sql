@@ -0,0 +1,48 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS customers (
+    id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS customers_tenant_id_idx ON customers (tenant_id);
+
+CREATE TABLE IF NOT EXISTS sites (
+    id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL,
+    customer_id VARCHAR(50) NOT NULL REFERENCES customers (id),
+    name VARCHAR(100) NOT NULL,
+    timezone VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS sites_tenant_id_idx ON sites (tenant_id);
+CREATE INDEX IF NOT EXISTS sites_customer_id_idx ON sites (customer_id);
+
+CREATE TABLE IF NOT EXISTS zones (
+    id VARCHAR(50) PRIMARY KEY,
+    tenant_id VARCHAR(50) NOT NULL,
+    site_id VARCHAR(50) NOT NULL REFERENCES sites (id),
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS zones_tenant_id_idx ON zones (tenant_id);
+CREATE INDEX IF NOT EXISTS zones_site_id_idx ON zones (site_id);
+
+-- Devices and alert rules without tenant are not assigned to any yet
+ALTER TABLE spcd_iot_devices ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS spcd_iot_devices_tenant_id_idx ON spcd_iot_devices (tenant_id);
+
+ALTER TABLE alert_rules ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(50) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS alert_rules_tenant_id_idx ON alert_rules (tenant_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS alert_rules_tenant_id_idx;
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS tenant_id;
+DROP INDEX IF EXISTS spcd_iot_devices_tenant_id_idx;
+ALTER TABLE spcd_iot_devices DROP COLUMN IF EXISTS tenant_id;
+DROP TABLE IF EXISTS zones CASCADE;
+DROP TABLE IF EXISTS sites CASCADE;
+DROP TABLE IF EXISTS customers CASCADE;
@@ -0,0 +1,12 @@
+-- +migrate Up
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP WITH TIME ZONE;
+
+DROP INDEX IF EXISTS webhook_deliveries_pending_idx;
+CREATE INDEX IF NOT EXISTS webhook_deliveries_claimable_idx ON webhook_deliveries (created_at) WHERE status IN ('pending', 'sending');
+
+-- +migrate Down
+DROP INDEX IF EXISTS webhook_deliveries_claimable_idx;
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (created_at) WHERE status = 'pending';
+
+UPDATE webhook_deliveries SET status = 'pending' WHERE status = 'sending';
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS claimed_at;
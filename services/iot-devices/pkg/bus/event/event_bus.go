@@ -6,6 +6,11 @@ import (
 	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
 )
 
+type Bus interface {
+	Subscribe(topic string, handler EventHandler)
+	Publish(event bus.Event)
+}
+
 type EventBus struct {
 	subscribers map[string][]chan bus.Event
 }
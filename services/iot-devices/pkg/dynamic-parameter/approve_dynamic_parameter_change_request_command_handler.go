@@ -0,0 +1,100 @@
+package dynamic_parameter
+
+import (
+	"context"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const approveDynamicParameterChangeRequestCmdName = "approve_dynamic_parameter_change_request_command"
+
+type ApproveDynamicParameterChangeRequestCommand struct {
+	Name       string
+	ID         string
+	ApprovedBy string
+}
+
+func (adc *ApproveDynamicParameterChangeRequestCommand) Type() string {
+	return approveDynamicParameterChangeRequestCmdName
+}
+
+type ApproveDynamicParameterChangeRequestCommandHandler struct {
+	changer        *DynamicParameterChanger
+	changeRequests DynamicParameterChangeRequestRepository
+	auditLog       DynamicParameterAuditLog
+	ulidProvider   utils.UlidProvider
+	timeProvider   utils.DateTimeProvider
+}
+
+func NewApproveDynamicParameterChangeRequestCommandHandler(
+	changer *DynamicParameterChanger,
+	changeRequests DynamicParameterChangeRequestRepository,
+	auditLog DynamicParameterAuditLog,
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+) ApproveDynamicParameterChangeRequestCommandHandler {
+	return ApproveDynamicParameterChangeRequestCommandHandler{
+		changer:        changer,
+		changeRequests: changeRequests,
+		auditLog:       auditLog,
+		ulidProvider:   ulidProvider,
+		timeProvider:   timeProvider,
+	}
+}
+
+// Handle applies the requested change once approved by a key owner other than the requester.
+// The request stays pending when the change can no longer be applied.
+func (ah ApproveDynamicParameterChangeRequestCommandHandler) Handle(ctx context.Context, command bus.Dto) error {
+	approveCommand, ok := command.(*ApproveDynamicParameterChangeRequestCommand)
+	if !ok {
+		return bus.NewInvalidDto("Invalid command")
+	}
+
+	request, err := findChangeRequest(ctx, ah.changeRequests, ParameterName(approveCommand.Name), approveCommand.ID)
+	if err != nil {
+		return err
+	}
+
+	now := ah.timeProvider.Now()
+	approved, err := request.Approve(approveCommand.ApprovedBy, now)
+	if err != nil {
+		return err
+	}
+
+	if err := ah.changer.Apply(ctx, approved.Change()); err != nil {
+		return err
+	}
+
+	if err := ah.changeRequests.Save(ctx, approved); err != nil {
+		return err
+	}
+
+	return ah.auditLog.Record(ctx, NewDynamicParameterAuditEntry(
+		ah.ulidProvider.New().String(),
+		approved.Name,
+		ChangeApprovedAuditAction,
+		approveCommand.ApprovedBy,
+		approved.Value,
+		approved.ID,
+		now,
+	))
+}
+
+func findChangeRequest(
+	ctx context.Context,
+	changeRequests DynamicParameterChangeRequestRepository,
+	name ParameterName,
+	id string,
+) (*DynamicParameterChangeRequest, error) {
+	request, err := changeRequests.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request == nil || request.Name != name {
+		return nil, NewDynamicParameterChangeRequestNotExists(name, id)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,125 @@
+package dynamic_parameter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+func TestApproveDynamicParameterChangeRequest(t *testing.T) {
+	rootCtx := context.Background()
+	repository := new(DynamicParameterRepositoryMock)
+	schedule := new(DynamicParameterScheduleRepositoryMock)
+	changeRequests := new(DynamicParameterChangeRequestRepositoryMock)
+	auditLog := new(DynamicParameterAuditLogMock)
+	ulidProvider, timeProvider := utils.NewFixedUlidProvider(), utils.NewFixedTimeProvider()
+	parameters := map[string]interface{}{"reporting_rate": 60}
+	handler := dynamic_parameter.NewApproveDynamicParameterChangeRequestCommandHandler(
+		dynamic_parameter.NewDynamicParameterChanger(
+			dynamic_parameter.NewDynamicParameterRetriever(repository, parameters),
+			repository,
+			schedule,
+			ulidProvider,
+			timeProvider,
+		),
+		changeRequests,
+		auditLog,
+		ulidProvider,
+		timeProvider,
+	)
+	name, now := dynamic_parameter.ParameterName("reporting_rate"), timeProvider.Now()
+	request := dynamic_parameter.NewDynamicParameterChangeRequest(
+		"change-request-id",
+		dynamic_parameter.DynamicParameterChange{Name: name, Value: float64(10)},
+		"alice@example.com",
+		now.Add(-time.Minute),
+		now.Add(time.Hour),
+	)
+
+	t.Run("Apply the change approved by a different key owner", func(t *testing.T) {
+		command := &dynamic_parameter.ApproveDynamicParameterChangeRequestCommand{
+			Name:       name.Value(),
+			ID:         request.ID,
+			ApprovedBy: "bob@example.com",
+		}
+		approved, _ := request.Approve(command.ApprovedBy, now)
+
+		changeRequests.ShouldFind(rootCtx, request.ID, &request)
+		repository.ShouldSearchDynamicParameter(rootCtx, name, nil)
+		repository.ShouldSave(rootCtx, dynamic_parameter.DynamicParameter{Name: name, DefaultValue: 60, DynamicValue: float64(10)})
+		schedule.ShouldReplaceForParameter(rootCtx, name, []dynamic_parameter.DynamicParameterTransition{})
+		changeRequests.ShouldSave(rootCtx, approved)
+		auditLog.ShouldRecord(rootCtx, dynamic_parameter.NewDynamicParameterAuditEntry(
+			ulidProvider.New().String(),
+			name,
+			dynamic_parameter.ChangeApprovedAuditAction,
+			command.ApprovedBy,
+			float64(10),
+			request.ID,
+			now,
+		))
+
+		assert.NoError(t, handler.Handle(rootCtx, command))
+		assert.Equal(t, dynamic_parameter.ApprovedChangeRequest, approved.Status)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+
+	t.Run("Forbid the requester approving its own change", func(t *testing.T) {
+		command := &dynamic_parameter.ApproveDynamicParameterChangeRequestCommand{
+			Name:       name.Value(),
+			ID:         request.ID,
+			ApprovedBy: request.RequestedBy,
+		}
+
+		changeRequests.ShouldFind(rootCtx, request.ID, &request)
+
+		err := handler.Handle(rootCtx, command)
+
+		assert.IsType(t, &dynamic_parameter.ForbiddenDynamicParameterChangeApproval{}, err)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+
+	t.Run("Refuse approving an expired change request", func(t *testing.T) {
+		expired := request
+		expired.PendingUntil = now
+
+		command := &dynamic_parameter.ApproveDynamicParameterChangeRequestCommand{
+			Name:       name.Value(),
+			ID:         expired.ID,
+			ApprovedBy: "bob@example.com",
+		}
+
+		changeRequests.ShouldFind(rootCtx, expired.ID, &expired)
+
+		err := handler.Handle(rootCtx, command)
+
+		assert.IsType(t, &dynamic_parameter.DynamicParameterChangeRequestNotPending{}, err)
+		assert.Equal(t, "expired", err.(*dynamic_parameter.DynamicParameterChangeRequestNotPending).ExtraItems()["status"])
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+
+	t.Run("Change request of another parameter", func(t *testing.T) {
+		command := &dynamic_parameter.ApproveDynamicParameterChangeRequestCommand{
+			Name:       "ff_test_feature_flag",
+			ID:         request.ID,
+			ApprovedBy: "bob@example.com",
+		}
+
+		changeRequests.ShouldFind(rootCtx, request.ID, &request)
+
+		err := handler.Handle(rootCtx, command)
+
+		assert.IsType(t, &dynamic_parameter.DynamicParameterChangeRequestNotExists{}, err)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+}
@@ -0,0 +1,61 @@
+package dynamic_parameter
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+)
+
+func HandleApproveDynamicParameterChangeRequest(
+	bus command.Bus,
+	responseMiddleware *json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		approvedBy, _ := http_server.StaticApiKeyOwnerFromContext(r.Context())
+		cmd := &ApproveDynamicParameterChangeRequestCommand{
+			Name:       mux.Vars(r)["parameterName"],
+			ID:         mux.Vars(r)["changeRequestId"],
+			ApprovedBy: approvedBy,
+		}
+
+		err := bus.Dispatch(r.Context(), cmd)
+
+		switch err.(type) {
+		case nil:
+			ctx, writer, statusCode := r.Context(), w, http.StatusNoContent
+			responseMiddleware.WriteResponse(ctx, writer, nil, statusCode)
+			return
+		case *DynamicParameterNotExists, *DynamicParameterChangeRequestNotExists:
+			ctx, writer, response := r.Context(), w, json_api_response.NewNotFound(err.Error())
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusNotFound, err)
+			return
+		case *ForbiddenDynamicParameterChangeApproval:
+			ctx, writer, response := r.Context(), w, json_api_response.NewForbidden(err.Error())
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusForbidden, err)
+			return
+		case *DynamicParameterChangeRequestNotPending:
+			ctx, writer, response := r.Context(), w, json_api_response.NewConflictWithDetails(
+				err.Error(),
+				metadataItemsFrom(err.(*DynamicParameterChangeRequestNotPending).ExtraItems())...,
+			)
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusConflict, err)
+			return
+		case *InvalidDynamicParameterSchedule:
+			ctx, writer, response := r.Context(), w, json_api_response.NewConflictWithDetails(
+				err.Error(),
+				metadataItemsFrom(err.(*InvalidDynamicParameterSchedule).ExtraItems())...,
+			)
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusConflict, err)
+			return
+		default:
+			ctx, writer, response := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusInternalServerError, err)
+			return
+		}
+	}
+}
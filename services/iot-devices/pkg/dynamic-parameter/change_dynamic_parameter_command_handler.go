@@ -2,15 +2,22 @@ package dynamic_parameter
 
 import (
 	"context"
+	"time"
 
 	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
 )
 
 const changeDynamicParameterCmdName = "change_dynamic_parameter_command"
 
 type ChangeDynamicParameterCommand struct {
-	Name  string
-	Value interface{}
+	Name          string
+	Value         interface{}
+	EffectiveFrom *time.Time
+	ExpiresAt     *time.Time
+	// ChangeRequestID identifies the change request created when the parameter requires approval
+	ChangeRequestID string
+	RequestedBy     string
 }
 
 func (cdp *ChangeDynamicParameterCommand) Type() string {
@@ -18,26 +25,93 @@ func (cdp *ChangeDynamicParameterCommand) Type() string {
 }
 
 type ChangeDynamicParameterCommandHandler struct {
-	retriever  *DynamicParameterRetriever
-	repository DynamicParameterRepository
+	changer        *DynamicParameterChanger
+	policy         *DynamicParameterApprovalPolicy
+	changeRequests DynamicParameterChangeRequestRepository
+	auditLog       DynamicParameterAuditLog
+	ulidProvider   utils.UlidProvider
+	timeProvider   utils.DateTimeProvider
 }
 
-func NewChangeDynamicParameterCommandHandler(retriever *DynamicParameterRetriever, repository DynamicParameterRepository) ChangeDynamicParameterCommandHandler {
-	return ChangeDynamicParameterCommandHandler{retriever: retriever, repository: repository}
+func NewChangeDynamicParameterCommandHandler(
+	changer *DynamicParameterChanger,
+	policy *DynamicParameterApprovalPolicy,
+	changeRequests DynamicParameterChangeRequestRepository,
+	auditLog DynamicParameterAuditLog,
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+) ChangeDynamicParameterCommandHandler {
+	return ChangeDynamicParameterCommandHandler{
+		changer:        changer,
+		policy:         policy,
+		changeRequests: changeRequests,
+		auditLog:       auditLog,
+		ulidProvider:   ulidProvider,
+		timeProvider:   timeProvider,
+	}
 }
 
+// Handle applies the change, or stores it as a pending change request when the
+// parameter requires approval.
 func (fd ChangeDynamicParameterCommandHandler) Handle(ctx context.Context, command bus.Dto) error {
 	dpCommand, ok := command.(*ChangeDynamicParameterCommand)
 	if !ok {
 		return bus.NewInvalidDto("Invalid command")
 	}
 
-	parameter, err := fd.retriever.Get(ctx, ParameterName(dpCommand.Name))
-	if err != nil {
+	change := DynamicParameterChange{
+		Name:          ParameterName(dpCommand.Name),
+		Value:         dpCommand.Value,
+		EffectiveFrom: dpCommand.EffectiveFrom,
+		ExpiresAt:     dpCommand.ExpiresAt,
+	}
+
+	if fd.policy.RequiresApproval(change.Name) {
+		return fd.requestChange(ctx, change, dpCommand)
+	}
+
+	if err := fd.changer.Apply(ctx, change); err != nil {
+		return err
+	}
+
+	return fd.auditLog.Record(ctx, NewDynamicParameterAuditEntry(
+		fd.ulidProvider.New().String(),
+		change.Name,
+		ChangedAuditAction,
+		dpCommand.RequestedBy,
+		change.Value,
+		"",
+		fd.timeProvider.Now(),
+	))
+}
+
+func (fd ChangeDynamicParameterCommandHandler) requestChange(
+	ctx context.Context,
+	change DynamicParameterChange,
+	dpCommand *ChangeDynamicParameterCommand,
+) error {
+	if err := fd.changer.Guard(ctx, change); err != nil {
 		return err
 	}
 
-	updatedParameter := parameter.WithNewValue(dpCommand.Value)
+	id := dpCommand.ChangeRequestID
+	if id == "" {
+		id = fd.ulidProvider.New().String()
+	}
+
+	now := fd.timeProvider.Now()
+	request := NewDynamicParameterChangeRequest(id, change, dpCommand.RequestedBy, now, now.Add(fd.policy.RequestTTL()))
+	if err := fd.changeRequests.Save(ctx, request); err != nil {
+		return err
+	}
 
-	return fd.repository.Save(ctx, updatedParameter)
+	return fd.auditLog.Record(ctx, NewDynamicParameterAuditEntry(
+		fd.ulidProvider.New().String(),
+		change.Name,
+		ChangeRequestedAuditAction,
+		dpCommand.RequestedBy,
+		change.Value,
+		request.ID,
+		now,
+	))
 }
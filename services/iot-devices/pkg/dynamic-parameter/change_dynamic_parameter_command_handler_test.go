@@ -4,18 +4,36 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
 )
 
 func TestChangeDynamicParameter(t *testing.T) {
 	rootCtx := context.Background()
 	repository := new(DynamicParameterRepositoryMock)
+	schedule := new(DynamicParameterScheduleRepositoryMock)
+	ulidProvider, timeProvider := utils.NewFixedUlidProvider(), utils.NewFixedTimeProvider()
 	parameters := map[string]interface{}{"test_flag": "a value"}
-	handler := dynamic_parameter.NewChangeDynamicParameterCommandHandler(dynamic_parameter.NewDynamicParameterRetriever(repository, parameters), repository)
+	auditLog := new(DynamicParameterAuditLogMock)
+	handler := dynamic_parameter.NewChangeDynamicParameterCommandHandler(
+		dynamic_parameter.NewDynamicParameterChanger(
+			dynamic_parameter.NewDynamicParameterRetriever(repository, parameters),
+			repository,
+			schedule,
+			ulidProvider,
+			timeProvider,
+		),
+		dynamic_parameter.NewDynamicParameterApprovalPolicy(time.Hour),
+		new(DynamicParameterChangeRequestRepositoryMock),
+		auditLog,
+		ulidProvider,
+		timeProvider,
+	)
 
 	t.Run("Change dynamic without any error", func(t *testing.T) {
 		command := &dynamic_parameter.ChangeDynamicParameterCommand{Name: "test_flag", Value: "newValue"}
@@ -28,19 +46,150 @@ func TestChangeDynamicParameter(t *testing.T) {
 			DefaultValue: value,
 			DynamicValue: command.Value,
 		})
+		schedule.ShouldReplaceForParameter(rootCtx, dynamic_parameter.ParameterName(command.Name), []dynamic_parameter.DynamicParameterTransition{})
+		auditLog.ShouldRecord(rootCtx, changedEntry(ulidProvider, timeProvider, command))
 		err := handler.Handle(rootCtx, command)
 
 		assert.NoError(t, err)
 
-		mock.AssertExpectationsForObjects(t, repository)
+		mock.AssertExpectationsForObjects(t, repository, schedule, auditLog)
+	})
+
+	t.Run("Change dynamic right away and schedule its expiry", func(t *testing.T) {
+		expiresAt := timeProvider.Now().Add(24 * time.Hour)
+		command := &dynamic_parameter.ChangeDynamicParameterCommand{Name: "test_flag", Value: "newValue", ExpiresAt: &expiresAt}
+		name := dynamic_parameter.ParameterName(command.Name)
+
+		repository.ShouldSearchDynamicParameter(rootCtx, name, "currentValue")
+		repository.ShouldSave(rootCtx, dynamic_parameter.DynamicParameter{
+			Name:         name,
+			DefaultValue: parameters[command.Name],
+			DynamicValue: command.Value,
+		})
+		schedule.ShouldReplaceForParameter(rootCtx, name, []dynamic_parameter.DynamicParameterTransition{
+			dynamic_parameter.NewExpiryTransition(ulidProvider.New().String(), name, "currentValue", expiresAt),
+		})
+		auditLog.ShouldRecord(rootCtx, changedEntry(ulidProvider, timeProvider, command))
+		err := handler.Handle(rootCtx, command)
+
+		assert.NoError(t, err)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, auditLog)
+	})
+
+	t.Run("Schedule dynamic change effective in the future", func(t *testing.T) {
+		effectiveFrom := timeProvider.Now().Add(time.Hour)
+		expiresAt := effectiveFrom.Add(24 * time.Hour)
+		command := &dynamic_parameter.ChangeDynamicParameterCommand{
+			Name:          "test_flag",
+			Value:         "newValue",
+			EffectiveFrom: &effectiveFrom,
+			ExpiresAt:     &expiresAt,
+		}
+		name := dynamic_parameter.ParameterName(command.Name)
+
+		repository.ShouldSearchDynamicParameter(rootCtx, name, nil)
+		schedule.ShouldReplaceForParameter(rootCtx, name, []dynamic_parameter.DynamicParameterTransition{
+			dynamic_parameter.NewActivationTransition(ulidProvider.New().String(), name, command.Value, effectiveFrom, &expiresAt),
+		})
+		auditLog.ShouldRecord(rootCtx, changedEntry(ulidProvider, timeProvider, command))
+		err := handler.Handle(rootCtx, command)
+
+		assert.NoError(t, err)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, auditLog)
+	})
+}
+
+func TestChangeSensitiveDynamicParameter(t *testing.T) {
+	rootCtx := context.Background()
+	repository := new(DynamicParameterRepositoryMock)
+	schedule := new(DynamicParameterScheduleRepositoryMock)
+	changeRequests := new(DynamicParameterChangeRequestRepositoryMock)
+	auditLog := new(DynamicParameterAuditLogMock)
+	ulidProvider, timeProvider := utils.NewFixedUlidProvider(), utils.NewFixedTimeProvider()
+	parameters := map[string]interface{}{"reporting_rate": 60}
+	handler := dynamic_parameter.NewChangeDynamicParameterCommandHandler(
+		dynamic_parameter.NewDynamicParameterChanger(
+			dynamic_parameter.NewDynamicParameterRetriever(repository, parameters),
+			repository,
+			schedule,
+			ulidProvider,
+			timeProvider,
+		),
+		dynamic_parameter.NewDynamicParameterApprovalPolicy(time.Hour, "reporting_rate"),
+		changeRequests,
+		auditLog,
+		ulidProvider,
+		timeProvider,
+	)
+
+	t.Run("Store a pending change request instead of changing the parameter", func(t *testing.T) {
+		command := &dynamic_parameter.ChangeDynamicParameterCommand{
+			Name:            "reporting_rate",
+			Value:           float64(10),
+			ChangeRequestID: "change-request-id",
+			RequestedBy:     "alice@example.com",
+		}
+		name, now := dynamic_parameter.ParameterName(command.Name), timeProvider.Now()
+
+		repository.ShouldSearchDynamicParameter(rootCtx, name, nil)
+		changeRequests.ShouldSave(rootCtx, dynamic_parameter.NewDynamicParameterChangeRequest(
+			command.ChangeRequestID,
+			dynamic_parameter.DynamicParameterChange{Name: name, Value: command.Value},
+			command.RequestedBy,
+			now,
+			now.Add(time.Hour),
+		))
+		auditLog.ShouldRecord(rootCtx, dynamic_parameter.NewDynamicParameterAuditEntry(
+			ulidProvider.New().String(),
+			name,
+			dynamic_parameter.ChangeRequestedAuditAction,
+			command.RequestedBy,
+			command.Value,
+			command.ChangeRequestID,
+			now,
+		))
+		err := handler.Handle(rootCtx, command)
+
+		assert.NoError(t, err)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+
+	t.Run("Reject invalid changes before requesting approval", func(t *testing.T) {
+		expiresAt := timeProvider.Now().Add(-time.Minute)
+		command := &dynamic_parameter.ChangeDynamicParameterCommand{Name: "reporting_rate", Value: float64(10), ExpiresAt: &expiresAt}
+
+		err := handler.Handle(rootCtx, command)
+
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterSchedule{}, err)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
 	})
 }
 
 func TestChangeDynamicParameterFail(t *testing.T) {
 	rootCtx := context.Background()
 	repository := new(DynamicParameterRepositoryMock)
+	schedule := new(DynamicParameterScheduleRepositoryMock)
+	timeProvider := utils.NewFixedTimeProvider()
 	parameters := map[string]interface{}{"test_flag": true}
-	handler := dynamic_parameter.NewChangeDynamicParameterCommandHandler(dynamic_parameter.NewDynamicParameterRetriever(repository, parameters), repository)
+	ulidProvider := utils.NewFixedUlidProvider()
+	handler := dynamic_parameter.NewChangeDynamicParameterCommandHandler(
+		dynamic_parameter.NewDynamicParameterChanger(
+			dynamic_parameter.NewDynamicParameterRetriever(repository, parameters),
+			repository,
+			schedule,
+			ulidProvider,
+			timeProvider,
+		),
+		dynamic_parameter.NewDynamicParameterApprovalPolicy(time.Hour),
+		new(DynamicParameterChangeRequestRepositoryMock),
+		new(DynamicParameterAuditLogMock),
+		ulidProvider,
+		timeProvider,
+	)
 
 	t.Run("Dynamic parameter not mapped in configuration", func(t *testing.T) {
 		command := &dynamic_parameter.ChangeDynamicParameterCommand{Name: "invalid_param"}
@@ -50,7 +199,7 @@ func TestChangeDynamicParameterFail(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "Dynamic parameter not exists")
 
-		mock.AssertExpectationsForObjects(t, repository)
+		mock.AssertExpectationsForObjects(t, repository, schedule)
 	})
 
 	t.Run("Error getting dynamic parameter from repository", func(t *testing.T) {
@@ -62,7 +211,7 @@ func TestChangeDynamicParameterFail(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "some error")
 
-		mock.AssertExpectationsForObjects(t, repository)
+		mock.AssertExpectationsForObjects(t, repository, schedule)
 	})
 
 	t.Run("Error saving dynamic parameter", func(t *testing.T) {
@@ -81,6 +230,50 @@ func TestChangeDynamicParameterFail(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "some error")
 
-		mock.AssertExpectationsForObjects(t, repository)
+		mock.AssertExpectationsForObjects(t, repository, schedule)
 	})
+
+	t.Run("Expiry already in the past", func(t *testing.T) {
+		expiresAt := timeProvider.Now().Add(-time.Minute)
+		command := &dynamic_parameter.ChangeDynamicParameterCommand{Name: "test_flag", Value: false, ExpiresAt: &expiresAt}
+
+		err := handler.Handle(rootCtx, command)
+
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterSchedule{}, err)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule)
+	})
+
+	t.Run("Expiry before the change becomes effective", func(t *testing.T) {
+		effectiveFrom := timeProvider.Now().Add(2 * time.Hour)
+		expiresAt := timeProvider.Now().Add(time.Hour)
+		command := &dynamic_parameter.ChangeDynamicParameterCommand{
+			Name:          "test_flag",
+			Value:         false,
+			EffectiveFrom: &effectiveFrom,
+			ExpiresAt:     &expiresAt,
+		}
+
+		err := handler.Handle(rootCtx, command)
+
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterSchedule{}, err)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule)
+	})
+}
+
+func changedEntry(
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+	command *dynamic_parameter.ChangeDynamicParameterCommand,
+) dynamic_parameter.DynamicParameterAuditEntry {
+	return dynamic_parameter.NewDynamicParameterAuditEntry(
+		ulidProvider.New().String(),
+		dynamic_parameter.ParameterName(command.Name),
+		dynamic_parameter.ChangedAuditAction,
+		command.RequestedBy,
+		command.Value,
+		"",
+		timeProvider.Now(),
+	)
 }
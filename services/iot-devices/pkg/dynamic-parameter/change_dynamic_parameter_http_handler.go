@@ -1,18 +1,27 @@
 package dynamic_parameter
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
 	http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
 	json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
 	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
 	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
 )
 
-func HandleChangeDynamicParameter(bus command.Bus, responseMiddleware *json_api.JsonApiResponseMiddleware) http.HandlerFunc {
+// HandleChangeDynamicParameter answers 202 with the change request when the parameter
+// requires approval, and 204 when the change has been applied.
+func HandleChangeDynamicParameter(
+	commandBus command.Bus,
+	queryBus query.Bus,
+	responseMiddleware *json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		requestParams, err := http_server.AllParamsRequest(r)
 		if err != nil {
@@ -28,19 +37,47 @@ func HandleChangeDynamicParameter(bus command.Bus, responseMiddleware *json_api.
 
 		parameterName := mux.Vars(r)["parameterName"]
 		parameterValue := utils.GetInMapValueOrDefault([]string{"data", "attributes", "value"}, requestParams, nil)
-		cmd := &ChangeDynamicParameterCommand{Name: parameterName, Value: parameterValue}
 
-		err = bus.Dispatch(r.Context(), cmd)
+		effectiveFrom, err := optionalTimeAttribute(requestParams, "effective_from")
+		if err != nil {
+			ctx, writer, response := r.Context(), w, json_api_response.NewBadRequest("effective_from must be a RFC3339 date")
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusBadRequest, err)
+			return
+		}
+
+		expiresAt, err := optionalTimeAttribute(requestParams, "expires_at")
+		if err != nil {
+			ctx, writer, response := r.Context(), w, json_api_response.NewBadRequest("expires_at must be a RFC3339 date")
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusBadRequest, err)
+			return
+		}
+
+		requestedBy, _ := http_server.StaticApiKeyOwnerFromContext(r.Context())
+		cmd := &ChangeDynamicParameterCommand{
+			Name:            parameterName,
+			Value:           parameterValue,
+			EffectiveFrom:   effectiveFrom,
+			ExpiresAt:       expiresAt,
+			ChangeRequestID: utils.NewUlid().String(),
+			RequestedBy:     requestedBy,
+		}
+
+		err = commandBus.Dispatch(r.Context(), cmd)
 
 		switch err.(type) {
 		case nil:
-			ctx, writer, statusCode := r.Context(), w, http.StatusNoContent
-			responseMiddleware.WriteResponse(ctx, writer, nil, statusCode)
+			writeChangeResult(w, r, queryBus, responseMiddleware, parameterName, cmd.ChangeRequestID)
 			return
 		case *DynamicParameterNotExists:
 			ctx, writer, response := r.Context(), w, json_api_response.NewNotFound(err.Error())
 			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusNotFound, err)
 			return
+		case *InvalidDynamicParameterSchedule:
+			ctx, writer, response := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+				metadataItemsFrom(err.(*InvalidDynamicParameterSchedule).ExtraItems())...,
+			)
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusBadRequest, err)
+			return
 		default:
 			ctx, writer, response := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
 			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusInternalServerError, err)
@@ -48,3 +85,54 @@ func HandleChangeDynamicParameter(bus command.Bus, responseMiddleware *json_api.
 		}
 	}
 }
+
+func writeChangeResult(
+	w http.ResponseWriter,
+	r *http.Request,
+	queryBus query.Bus,
+	responseMiddleware *json_api.JsonApiResponseMiddleware,
+	parameterName string,
+	changeRequestID string,
+) {
+	changeRequest, err := queryBus.Ask(r.Context(), &FindDynamicParameterChangeRequestQuery{Name: parameterName, ID: changeRequestID})
+
+	switch err.(type) {
+	case nil:
+		ctx, writer := r.Context(), w
+		responseMiddleware.WriteResponse(ctx, writer, changeRequest, http.StatusAccepted)
+	case *DynamicParameterChangeRequestNotExists:
+		ctx, writer, statusCode := r.Context(), w, http.StatusNoContent
+		responseMiddleware.WriteResponse(ctx, writer, nil, statusCode)
+	default:
+		ctx, writer, response := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+		responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusInternalServerError, err)
+	}
+}
+
+func optionalTimeAttribute(requestParams map[string]interface{}, attribute string) (*time.Time, error) {
+	rawValue := utils.GetInMapValueOrDefault([]string{"data", "attributes", attribute}, requestParams, nil)
+	if rawValue == nil {
+		return nil, nil
+	}
+
+	value, ok := rawValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a string", attribute)
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
+
+func metadataItemsFrom(items map[string]interface{}) []json_api_response.MetadataItem {
+	metadata := make([]json_api_response.MetadataItem, 0, len(items))
+	for key, value := range items {
+		metadata = append(metadata, json_api_response.NewMetadataItem(key, value))
+	}
+
+	return metadata
+}
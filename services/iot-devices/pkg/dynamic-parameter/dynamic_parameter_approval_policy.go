@@ -0,0 +1,50 @@
+package dynamic_parameter
+
+import (
+	"strings"
+	"time"
+)
+
+// DynamicParameterApprovalPolicy lists the sensitive parameters whose changes
+// must be approved by a second key owner. With no sensitive parameters every
+// change is applied right away.
+type DynamicParameterApprovalPolicy struct {
+	sensitive  map[ParameterName]struct{}
+	requestTTL time.Duration
+}
+
+func NewDynamicParameterApprovalPolicy(requestTTL time.Duration, sensitive ...ParameterName) *DynamicParameterApprovalPolicy {
+	policy := &DynamicParameterApprovalPolicy{
+		sensitive:  make(map[ParameterName]struct{}, len(sensitive)),
+		requestTTL: requestTTL,
+	}
+
+	for _, name := range sensitive {
+		policy.sensitive[name] = struct{}{}
+	}
+
+	return policy
+}
+
+func NewDynamicParameterApprovalPolicyFromCommaSeparatedString(
+	requestTTL time.Duration,
+	rawNames string,
+) *DynamicParameterApprovalPolicy {
+	names := make([]ParameterName, 0)
+	for _, rawName := range strings.Split(rawNames, ",") {
+		if name := strings.TrimSpace(rawName); name != "" {
+			names = append(names, ParameterName(name))
+		}
+	}
+
+	return NewDynamicParameterApprovalPolicy(requestTTL, names...)
+}
+
+func (ap *DynamicParameterApprovalPolicy) RequiresApproval(name ParameterName) bool {
+	_, sensitive := ap.sensitive[name]
+	return sensitive
+}
+
+func (ap *DynamicParameterApprovalPolicy) RequestTTL() time.Duration {
+	return ap.requestTTL
+}
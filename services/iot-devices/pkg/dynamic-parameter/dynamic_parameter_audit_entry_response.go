@@ -0,0 +1,25 @@
+package dynamic_parameter
+
+import "time"
+
+type DynamicParameterAuditEntryResponse struct {
+	ID              string      `jsonapi:"primary,dynamic_parameter_audit_entry"`
+	Name            string      `jsonapi:"attr,name"`
+	Action          string      `jsonapi:"attr,action"`
+	Actor           string      `jsonapi:"attr,actor"`
+	Value           interface{} `jsonapi:"attr,value"`
+	ChangeRequestID string      `jsonapi:"attr,change_request_id,omitempty"`
+	At              string      `jsonapi:"attr,at"`
+}
+
+func NewDynamicParameterAuditEntryResponse(entry DynamicParameterAuditEntry) *DynamicParameterAuditEntryResponse {
+	return &DynamicParameterAuditEntryResponse{
+		ID:              entry.ID,
+		Name:            entry.Name.Value(),
+		Action:          entry.Action.Value(),
+		Actor:           entry.Actor,
+		Value:           entry.Value,
+		ChangeRequestID: entry.ChangeRequestID,
+		At:              entry.At.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,63 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	ChangedAuditAction              AuditAction = "changed"
+	ChangeRequestedAuditAction      AuditAction = "change_requested"
+	ChangeApprovedAuditAction       AuditAction = "change_approved"
+	ChangeRejectedAuditAction       AuditAction = "change_rejected"
+	ChangeRequestExpiredAuditAction AuditAction = "change_request_expired"
+	TransitionAppliedAuditAction    AuditAction = "transition_applied"
+	ImportedAuditAction             AuditAction = "imported"
+)
+
+const (
+	schedulerAuditActor        = "scheduler"
+	defaultAuditLogSearchLimit = 100
+)
+
+func (aa AuditAction) Value() string {
+	return string(aa)
+}
+
+type DynamicParameterAuditEntry struct {
+	ID              string
+	Name            ParameterName
+	Action          AuditAction
+	Actor           string
+	Value           ParameterValue
+	ChangeRequestID string
+	At              time.Time
+}
+
+func NewDynamicParameterAuditEntry(
+	id string,
+	name ParameterName,
+	action AuditAction,
+	actor string,
+	value ParameterValue,
+	changeRequestID string,
+	at time.Time,
+) DynamicParameterAuditEntry {
+	return DynamicParameterAuditEntry{
+		ID:              id,
+		Name:            name,
+		Action:          action,
+		Actor:           actor,
+		Value:           value,
+		ChangeRequestID: changeRequestID,
+		At:              at,
+	}
+}
+
+type DynamicParameterAuditLog interface {
+	Record(ctx context.Context, entry DynamicParameterAuditEntry) error
+	// SearchByParameter returns the latest entries first
+	SearchByParameter(ctx context.Context, name ParameterName, limit int) ([]DynamicParameterAuditEntry, error)
+}
@@ -0,0 +1,36 @@
+package dynamic_parameter_test
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+)
+
+type DynamicParameterAuditLogMock struct {
+	mock.Mock
+}
+
+func (al *DynamicParameterAuditLogMock) Record(ctx context.Context, entry dynamic_parameter.DynamicParameterAuditEntry) error {
+	args := al.Called(ctx, entry)
+
+	return args.Error(0)
+}
+
+func (al *DynamicParameterAuditLogMock) SearchByParameter(
+	ctx context.Context,
+	name dynamic_parameter.ParameterName,
+	limit int,
+) ([]dynamic_parameter.DynamicParameterAuditEntry, error) {
+	args := al.Called(ctx, name, limit)
+
+	return args.Get(0).([]dynamic_parameter.DynamicParameterAuditEntry), args.Error(1)
+}
+
+func (al *DynamicParameterAuditLogMock) ShouldRecord(ctx context.Context, entry dynamic_parameter.DynamicParameterAuditEntry) {
+	al.
+		On("Record", ctx, entry).
+		Once().
+		Return(nil)
+}
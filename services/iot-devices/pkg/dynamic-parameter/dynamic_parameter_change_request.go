@@ -0,0 +1,111 @@
+package dynamic_parameter
+
+import "time"
+
+type ChangeRequestStatus string
+
+const (
+	PendingChangeRequest  ChangeRequestStatus = "pending"
+	ApprovedChangeRequest ChangeRequestStatus = "approved"
+	RejectedChangeRequest ChangeRequestStatus = "rejected"
+	ExpiredChangeRequest  ChangeRequestStatus = "expired"
+)
+
+func (crs ChangeRequestStatus) Value() string {
+	return string(crs)
+}
+
+// DynamicParameterChangeRequest is a change of a sensitive parameter waiting for
+// the approval of a key owner other than the one who requested it.
+type DynamicParameterChangeRequest struct {
+	ID            string
+	Name          ParameterName
+	Value         ParameterValue
+	EffectiveFrom *time.Time
+	ExpiresAt     *time.Time
+	Status        ChangeRequestStatus
+	RequestedBy   string
+	RequestedAt   time.Time
+	PendingUntil  time.Time
+	DecidedBy     string
+	DecidedAt     *time.Time
+}
+
+func NewDynamicParameterChangeRequest(
+	id string,
+	change DynamicParameterChange,
+	requestedBy string,
+	requestedAt time.Time,
+	pendingUntil time.Time,
+) DynamicParameterChangeRequest {
+	return DynamicParameterChangeRequest{
+		ID:            id,
+		Name:          change.Name,
+		Value:         change.Value,
+		EffectiveFrom: change.EffectiveFrom,
+		ExpiresAt:     change.ExpiresAt,
+		Status:        PendingChangeRequest,
+		RequestedBy:   requestedBy,
+		RequestedAt:   requestedAt,
+		PendingUntil:  pendingUntil,
+	}
+}
+
+func (cr DynamicParameterChangeRequest) Change() DynamicParameterChange {
+	return DynamicParameterChange{
+		Name:          cr.Name,
+		Value:         cr.Value,
+		EffectiveFrom: cr.EffectiveFrom,
+		ExpiresAt:     cr.ExpiresAt,
+	}
+}
+
+// StatusAt reports pending requests past their deadline as expired, even
+// before the scheduler has stored them as such.
+func (cr DynamicParameterChangeRequest) StatusAt(now time.Time) ChangeRequestStatus {
+	if cr.Status == PendingChangeRequest && !now.Before(cr.PendingUntil) {
+		return ExpiredChangeRequest
+	}
+
+	return cr.Status
+}
+
+func (cr DynamicParameterChangeRequest) Approve(approvedBy string, now time.Time) (DynamicParameterChangeRequest, error) {
+	if err := cr.guardPending(now); err != nil {
+		return cr, err
+	}
+
+	if approvedBy == "" || approvedBy == cr.RequestedBy {
+		return cr, NewForbiddenDynamicParameterChangeApproval(cr.Name, cr.ID, approvedBy)
+	}
+
+	return cr.decided(ApprovedChangeRequest, approvedBy, now), nil
+}
+
+func (cr DynamicParameterChangeRequest) Reject(rejectedBy string, now time.Time) (DynamicParameterChangeRequest, error) {
+	if err := cr.guardPending(now); err != nil {
+		return cr, err
+	}
+
+	return cr.decided(RejectedChangeRequest, rejectedBy, now), nil
+}
+
+func (cr DynamicParameterChangeRequest) Expire(now time.Time) DynamicParameterChangeRequest {
+	return cr.decided(ExpiredChangeRequest, "", now)
+}
+
+func (cr DynamicParameterChangeRequest) guardPending(now time.Time) error {
+	if status := cr.StatusAt(now); status != PendingChangeRequest {
+		return NewDynamicParameterChangeRequestNotPending(cr.Name, cr.ID, status)
+	}
+
+	return nil
+}
+
+func (cr DynamicParameterChangeRequest) decided(status ChangeRequestStatus, by string, at time.Time) DynamicParameterChangeRequest {
+	cr.Status = status
+	cr.DecidedBy = by
+	cr.DecidedAt = &at
+
+	return cr
+}
@@ -0,0 +1,27 @@
+package dynamic_parameter
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const changeRequestNotExistsErrorMessage = "Dynamic parameter change request not exists"
+
+type DynamicParameterChangeRequestNotExists struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (crn DynamicParameterChangeRequestNotExists) Error() string {
+	return changeRequestNotExistsErrorMessage
+}
+
+func (crn DynamicParameterChangeRequestNotExists) ExtraItems() map[string]interface{} {
+	return crn.items
+}
+
+func NewDynamicParameterChangeRequestNotExists(name ParameterName, id string) *DynamicParameterChangeRequestNotExists {
+	return &DynamicParameterChangeRequestNotExists{items: map[string]interface{}{
+		"name":              name.Value(),
+		"change_request_id": id,
+	}}
+}
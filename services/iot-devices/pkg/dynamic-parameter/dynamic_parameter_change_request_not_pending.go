@@ -0,0 +1,32 @@
+package dynamic_parameter
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const changeRequestNotPendingErrorMessage = "Dynamic parameter change request is not pending"
+
+type DynamicParameterChangeRequestNotPending struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (crp DynamicParameterChangeRequestNotPending) Error() string {
+	return changeRequestNotPendingErrorMessage
+}
+
+func (crp DynamicParameterChangeRequestNotPending) ExtraItems() map[string]interface{} {
+	return crp.items
+}
+
+func NewDynamicParameterChangeRequestNotPending(
+	name ParameterName,
+	id string,
+	status ChangeRequestStatus,
+) *DynamicParameterChangeRequestNotPending {
+	return &DynamicParameterChangeRequestNotPending{items: map[string]interface{}{
+		"name":              name.Value(),
+		"change_request_id": id,
+		"status":            status.Value(),
+	}}
+}
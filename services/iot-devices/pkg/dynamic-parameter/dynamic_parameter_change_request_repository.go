@@ -0,0 +1,11 @@
+package dynamic_parameter
+
+import "context"
+
+type DynamicParameterChangeRequestRepository interface {
+	Save(ctx context.Context, request DynamicParameterChangeRequest) error
+	// Find returns nil when the change request does not exist
+	Find(ctx context.Context, id string) (*DynamicParameterChangeRequest, error)
+	SearchByParameter(ctx context.Context, name ParameterName) ([]DynamicParameterChangeRequest, error)
+	SearchPending(ctx context.Context) ([]DynamicParameterChangeRequest, error)
+}
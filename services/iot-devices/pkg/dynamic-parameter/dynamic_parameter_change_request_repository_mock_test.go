@@ -0,0 +1,79 @@
+package dynamic_parameter_test
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+)
+
+type DynamicParameterChangeRequestRepositoryMock struct {
+	mock.Mock
+}
+
+func (cr *DynamicParameterChangeRequestRepositoryMock) Save(
+	ctx context.Context,
+	request dynamic_parameter.DynamicParameterChangeRequest,
+) error {
+	args := cr.Called(ctx, request)
+
+	return args.Error(0)
+}
+
+func (cr *DynamicParameterChangeRequestRepositoryMock) Find(
+	ctx context.Context,
+	id string,
+) (*dynamic_parameter.DynamicParameterChangeRequest, error) {
+	args := cr.Called(ctx, id)
+
+	return args.Get(0).(*dynamic_parameter.DynamicParameterChangeRequest), args.Error(1)
+}
+
+func (cr *DynamicParameterChangeRequestRepositoryMock) SearchByParameter(
+	ctx context.Context,
+	name dynamic_parameter.ParameterName,
+) ([]dynamic_parameter.DynamicParameterChangeRequest, error) {
+	args := cr.Called(ctx, name)
+
+	return args.Get(0).([]dynamic_parameter.DynamicParameterChangeRequest), args.Error(1)
+}
+
+func (cr *DynamicParameterChangeRequestRepositoryMock) SearchPending(
+	ctx context.Context,
+) ([]dynamic_parameter.DynamicParameterChangeRequest, error) {
+	args := cr.Called(ctx)
+
+	return args.Get(0).([]dynamic_parameter.DynamicParameterChangeRequest), args.Error(1)
+}
+
+func (cr *DynamicParameterChangeRequestRepositoryMock) ShouldSave(
+	ctx context.Context,
+	request dynamic_parameter.DynamicParameterChangeRequest,
+) {
+	cr.
+		On("Save", ctx, request).
+		Once().
+		Return(nil)
+}
+
+func (cr *DynamicParameterChangeRequestRepositoryMock) ShouldFind(
+	ctx context.Context,
+	id string,
+	request *dynamic_parameter.DynamicParameterChangeRequest,
+) {
+	cr.
+		On("Find", ctx, id).
+		Once().
+		Return(request, nil)
+}
+
+func (cr *DynamicParameterChangeRequestRepositoryMock) ShouldSearchPending(
+	ctx context.Context,
+	requests []dynamic_parameter.DynamicParameterChangeRequest,
+) {
+	cr.
+		On("SearchPending", ctx).
+		Once().
+		Return(requests, nil)
+}
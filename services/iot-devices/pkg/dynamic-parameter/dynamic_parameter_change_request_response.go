@@ -0,0 +1,46 @@
+package dynamic_parameter
+
+import "time"
+
+type DynamicParameterChangeRequestResponse struct {
+	ID            string      `jsonapi:"primary,dynamic_parameter_change_request"`
+	Name          string      `jsonapi:"attr,name"`
+	Value         interface{} `jsonapi:"attr,value"`
+	EffectiveFrom *string     `jsonapi:"attr,effective_from,omitempty"`
+	ExpiresAt     *string     `jsonapi:"attr,expires_at,omitempty"`
+	Status        string      `jsonapi:"attr,status"`
+	RequestedBy   string      `jsonapi:"attr,requested_by"`
+	RequestedAt   string      `jsonapi:"attr,requested_at"`
+	PendingUntil  string      `jsonapi:"attr,pending_until"`
+	DecidedBy     string      `jsonapi:"attr,decided_by,omitempty"`
+	DecidedAt     *string     `jsonapi:"attr,decided_at,omitempty"`
+}
+
+func NewDynamicParameterChangeRequestResponse(
+	request DynamicParameterChangeRequest,
+	now time.Time,
+) *DynamicParameterChangeRequestResponse {
+	return &DynamicParameterChangeRequestResponse{
+		ID:            request.ID,
+		Name:          request.Name.Value(),
+		Value:         request.Value,
+		EffectiveFrom: optionalRFC3339(request.EffectiveFrom),
+		ExpiresAt:     optionalRFC3339(request.ExpiresAt),
+		Status:        request.StatusAt(now).Value(),
+		RequestedBy:   request.RequestedBy,
+		RequestedAt:   request.RequestedAt.UTC().Format(time.RFC3339),
+		PendingUntil:  request.PendingUntil.UTC().Format(time.RFC3339),
+		DecidedBy:     request.DecidedBy,
+		DecidedAt:     optionalRFC3339(request.DecidedAt),
+	}
+}
+
+func optionalRFC3339(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+
+	formatted := value.UTC().Format(time.RFC3339)
+
+	return &formatted
+}
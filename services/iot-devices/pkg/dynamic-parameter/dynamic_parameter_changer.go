@@ -0,0 +1,107 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"time"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type DynamicParameterChange struct {
+	Name          ParameterName
+	Value         ParameterValue
+	EffectiveFrom *time.Time
+	ExpiresAt     *time.Time
+}
+
+// DynamicParameterChanger applies a change right away unless it becomes effective
+// in the future. Any new change supersedes the transitions still pending for the parameter.
+type DynamicParameterChanger struct {
+	retriever    *DynamicParameterRetriever
+	repository   DynamicParameterRepository
+	schedule     DynamicParameterScheduleRepository
+	ulidProvider utils.UlidProvider
+	timeProvider utils.DateTimeProvider
+}
+
+func NewDynamicParameterChanger(
+	retriever *DynamicParameterRetriever,
+	repository DynamicParameterRepository,
+	schedule DynamicParameterScheduleRepository,
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+) *DynamicParameterChanger {
+	return &DynamicParameterChanger{
+		retriever:    retriever,
+		repository:   repository,
+		schedule:     schedule,
+		ulidProvider: ulidProvider,
+		timeProvider: timeProvider,
+	}
+}
+
+// Guard checks the change could be applied now without applying it.
+func (dc *DynamicParameterChanger) Guard(ctx context.Context, change DynamicParameterChange) error {
+	if err := dc.guardSchedule(change, dc.timeProvider.Now()); err != nil {
+		return err
+	}
+
+	_, err := dc.retriever.Get(ctx, change.Name)
+
+	return err
+}
+
+func (dc *DynamicParameterChanger) Apply(ctx context.Context, change DynamicParameterChange) error {
+	now := dc.timeProvider.Now()
+	if err := dc.guardSchedule(change, now); err != nil {
+		return err
+	}
+
+	parameter, err := dc.retriever.Get(ctx, change.Name)
+	if err != nil {
+		return err
+	}
+
+	if change.EffectiveFrom != nil && change.EffectiveFrom.After(now) {
+		activation := NewActivationTransition(
+			dc.ulidProvider.New().String(),
+			change.Name,
+			change.Value,
+			*change.EffectiveFrom,
+			change.ExpiresAt,
+		)
+
+		return dc.schedule.ReplaceForParameter(ctx, change.Name, []DynamicParameterTransition{activation})
+	}
+
+	updatedParameter := parameter.WithNewValue(change.Value)
+	if err := dc.repository.Save(ctx, updatedParameter); err != nil {
+		return err
+	}
+
+	transitions := make([]DynamicParameterTransition, 0, 1)
+	if change.ExpiresAt != nil {
+		transitions = append(
+			transitions,
+			NewExpiryTransition(dc.ulidProvider.New().String(), change.Name, parameter.DynamicValue, *change.ExpiresAt),
+		)
+	}
+
+	return dc.schedule.ReplaceForParameter(ctx, change.Name, transitions)
+}
+
+func (dc *DynamicParameterChanger) guardSchedule(change DynamicParameterChange, now time.Time) error {
+	if change.ExpiresAt == nil {
+		return nil
+	}
+
+	if !change.ExpiresAt.After(now) {
+		return NewInvalidDynamicParameterSchedule(change.Name, "expires_at must be in the future")
+	}
+
+	if change.EffectiveFrom != nil && !change.ExpiresAt.After(*change.EffectiveFrom) {
+		return NewInvalidDynamicParameterSchedule(change.Name, "expires_at must be after effective_from")
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// DynamicParameterOverrides are the dynamic values stored for the parameters,
+// i.e. everything that differs from the YAML defaults.
+type DynamicParameterOverrides map[ParameterName]ParameterValue
+
+func NewDynamicParameterOverridesFromMap(values map[string]interface{}) DynamicParameterOverrides {
+	overrides := make(DynamicParameterOverrides, len(values))
+	for name, value := range values {
+		overrides[ParameterName(name)] = value
+	}
+
+	return overrides
+}
+
+func (dpo DynamicParameterOverrides) ToPlain() map[string]interface{} {
+	plain := make(map[string]interface{}, len(dpo))
+	for name, value := range dpo {
+		plain[name.Value()] = value
+	}
+
+	return plain
+}
+
+type DynamicParameterOverridesRepository interface {
+	SearchOverrides(ctx context.Context) (DynamicParameterOverrides, error)
+	// ReplaceOverrides leaves exactly the given overrides in place in a single transaction,
+	// which check can refuse by returning an error once it sees the overrides it replaces
+	ReplaceOverrides(
+		ctx context.Context,
+		overrides DynamicParameterOverrides,
+		check func(current DynamicParameterOverrides) error,
+	) error
+}
+
+type OverrideChangeKind string
+
+const (
+	AddedOverride   OverrideChangeKind = "added"
+	ChangedOverride OverrideChangeKind = "changed"
+	RemovedOverride OverrideChangeKind = "removed"
+)
+
+func (ock OverrideChangeKind) Value() string {
+	return string(ock)
+}
+
+type DynamicParameterOverrideChange struct {
+	Name     ParameterName
+	Kind     OverrideChangeKind
+	Current  ParameterValue
+	Imported ParameterValue
+}
+
+// DiffDynamicParameterOverrides lists what importing the given overrides would
+// change, sorted by parameter name.
+func DiffDynamicParameterOverrides(current, imported DynamicParameterOverrides) []DynamicParameterOverrideChange {
+	changes := make([]DynamicParameterOverrideChange, 0)
+
+	for name, importedValue := range imported {
+		currentValue, exists := current[name]
+		switch {
+		case !exists:
+			changes = append(changes, DynamicParameterOverrideChange{Name: name, Kind: AddedOverride, Imported: importedValue})
+		case !reflect.DeepEqual(currentValue, importedValue):
+			changes = append(changes, DynamicParameterOverrideChange{
+				Name:     name,
+				Kind:     ChangedOverride,
+				Current:  currentValue,
+				Imported: importedValue,
+			})
+		}
+	}
+
+	for name, currentValue := range current {
+		if _, exists := imported[name]; !exists {
+			changes = append(changes, DynamicParameterOverrideChange{Name: name, Kind: RemovedOverride, Current: currentValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
@@ -0,0 +1,30 @@
+package dynamic_parameter
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// ParseDynamicParameterOverridesDocument reads the overrides of a json api document
+// like the one exported, so an export can be imported as is.
+func ParseDynamicParameterOverridesDocument(raw []byte) (map[string]interface{}, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+
+	return overridesFromDocument(document)
+}
+
+func overridesFromDocument(document map[string]interface{}) (map[string]interface{}, error) {
+	rawOverrides := utils.GetInMapValueOrDefault([]string{"data", "attributes", "overrides"}, document, nil)
+
+	overrides, ok := rawOverrides.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("data.attributes.overrides must be an object")
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,52 @@
+package dynamic_parameter
+
+import "time"
+
+type DynamicParameterOverridesResponse struct {
+	ID         string                 `jsonapi:"primary,dynamic_parameter_overrides"`
+	ExportedAt string                 `jsonapi:"attr,exported_at"`
+	Overrides  map[string]interface{} `jsonapi:"attr,overrides"`
+}
+
+func NewDynamicParameterOverridesResponse(
+	id string,
+	overrides DynamicParameterOverrides,
+	exportedAt time.Time,
+) *DynamicParameterOverridesResponse {
+	return &DynamicParameterOverridesResponse{
+		ID:         id,
+		ExportedAt: exportedAt.UTC().Format(time.RFC3339),
+		Overrides:  overrides.ToPlain(),
+	}
+}
+
+type DynamicParameterOverridesImportResponse struct {
+	ID      string                                   `jsonapi:"primary,dynamic_parameter_overrides_import"`
+	DryRun  bool                                     `jsonapi:"attr,dry_run"`
+	Changes []DynamicParameterOverrideChangeResponse `jsonapi:"attr,changes"`
+}
+
+type DynamicParameterOverrideChangeResponse struct {
+	Name     string      `json:"name"`
+	Kind     string      `json:"kind"`
+	Current  interface{} `json:"current"`
+	Imported interface{} `json:"imported"`
+}
+
+func NewDynamicParameterOverridesImportResponse(
+	id string,
+	dryRun bool,
+	changes []DynamicParameterOverrideChange,
+) *DynamicParameterOverridesImportResponse {
+	responses := make([]DynamicParameterOverrideChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		responses = append(responses, DynamicParameterOverrideChangeResponse{
+			Name:     change.Name.Value(),
+			Kind:     change.Kind.Value(),
+			Current:  change.Current,
+			Imported: change.Imported,
+		})
+	}
+
+	return &DynamicParameterOverridesImportResponse{ID: id, DryRun: dryRun, Changes: responses}
+}
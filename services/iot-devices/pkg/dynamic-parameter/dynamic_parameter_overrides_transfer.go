@@ -0,0 +1,170 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// DynamicParameterOverridesTransfer exports the overrides of an environment and
+// imports them into another one, replacing whatever overrides it had.
+type DynamicParameterOverridesTransfer struct {
+	retriever    *DynamicParameterRetriever
+	repository   DynamicParameterOverridesRepository
+	schedule     DynamicParameterScheduleRepository
+	policy       *DynamicParameterApprovalPolicy
+	auditLog     DynamicParameterAuditLog
+	ulidProvider utils.UlidProvider
+	timeProvider utils.DateTimeProvider
+}
+
+func NewDynamicParameterOverridesTransfer(
+	retriever *DynamicParameterRetriever,
+	repository DynamicParameterOverridesRepository,
+	schedule DynamicParameterScheduleRepository,
+	policy *DynamicParameterApprovalPolicy,
+	auditLog DynamicParameterAuditLog,
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+) *DynamicParameterOverridesTransfer {
+	return &DynamicParameterOverridesTransfer{
+		retriever:    retriever,
+		repository:   repository,
+		schedule:     schedule,
+		policy:       policy,
+		auditLog:     auditLog,
+		ulidProvider: ulidProvider,
+		timeProvider: timeProvider,
+	}
+}
+
+func (ot *DynamicParameterOverridesTransfer) Export(ctx context.Context) (DynamicParameterOverrides, error) {
+	return ot.repository.SearchOverrides(ctx)
+}
+
+// Plan validates the imported overrides against the declared parameters and
+// returns the changes importing them would make, without applying anything.
+func (ot *DynamicParameterOverridesTransfer) Plan(
+	ctx context.Context,
+	imported DynamicParameterOverrides,
+) ([]DynamicParameterOverrideChange, error) {
+	current, err := ot.repository.SearchOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ot.plan(current, imported)
+}
+
+func (ot *DynamicParameterOverridesTransfer) plan(
+	current DynamicParameterOverrides,
+	imported DynamicParameterOverrides,
+) ([]DynamicParameterOverrideChange, error) {
+	reasons := make(map[string]interface{})
+	for name, value := range imported {
+		if reason := ot.invalidReason(name, value); reason != "" {
+			reasons[name.Value()] = reason
+		}
+	}
+
+	changes := DiffDynamicParameterOverrides(current, imported)
+	for _, change := range changes {
+		if _, invalid := reasons[change.Name.Value()]; !invalid && ot.policy.RequiresApproval(change.Name) {
+			reasons[change.Name.Value()] = "requires approval, change it through a change request"
+		}
+	}
+
+	if len(reasons) > 0 {
+		return nil, NewInvalidDynamicParameterOverrides(reasons)
+	}
+
+	return changes, nil
+}
+
+// Import replaces every override at once, then supersedes the transitions still
+// pending for the parameters it changes and records them in the audit log. The plan is
+// made again against the overrides the replacement reads, so a sensitive override
+// approved meanwhile is not changed without approval. The schedule and audit writes are
+// not part of the replacement, so failing them leaves the overrides imported.
+func (ot *DynamicParameterOverridesTransfer) Import(
+	ctx context.Context,
+	imported DynamicParameterOverrides,
+	importedBy string,
+) ([]DynamicParameterOverrideChange, error) {
+	changes, err := ot.Plan(ctx, imported)
+	if err != nil || len(changes) == 0 {
+		return changes, err
+	}
+
+	err = ot.repository.ReplaceOverrides(ctx, imported, func(current DynamicParameterOverrides) error {
+		changes, err = ot.plan(current, imported)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := ot.timeProvider.Now()
+	for _, change := range changes {
+		if err := ot.schedule.ReplaceForParameter(ctx, change.Name, []DynamicParameterTransition{}); err != nil {
+			return nil, err
+		}
+
+		entry := NewDynamicParameterAuditEntry(
+			ot.ulidProvider.New().String(),
+			change.Name,
+			ImportedAuditAction,
+			importedBy,
+			change.Imported,
+			"",
+			now,
+		)
+		if err := ot.auditLog.Record(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+func (ot *DynamicParameterOverridesTransfer) invalidReason(name ParameterName, value ParameterValue) string {
+	defaultValue, declared := ot.retriever.Declared(name)
+	if !declared {
+		return "not declared"
+	}
+
+	if value == nil {
+		return "value is null, leave the parameter out to remove its override"
+	}
+
+	if defaultValue == nil {
+		return ""
+	}
+
+	if expected, actual := valueKind(defaultValue), valueKind(value); expected != actual {
+		return fmt.Sprintf("expected a %s value but got a %s one", expected, actual)
+	}
+
+	return ""
+}
+
+func valueKind(value interface{}) string {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Map:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
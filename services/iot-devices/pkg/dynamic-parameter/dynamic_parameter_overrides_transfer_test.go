@@ -0,0 +1,228 @@
+package dynamic_parameter_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/jsonapi"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type DynamicParameterOverridesTransferTestSuite struct {
+	suite.Suite
+	redisClient *redis.Client
+	miniRedis   *miniredis.Miniredis
+	repository  *dynamic_parameter.RedisDynamicParameterRepository
+	schedule    *dynamic_parameter.RedisDynamicParameterScheduleRepository
+	auditLog    *dynamic_parameter.RedisDynamicParameterAuditLog
+	transfer    *dynamic_parameter.DynamicParameterOverridesTransfer
+	ctx         context.Context
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) SetupSuite() {
+	miniRedis, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.miniRedis = miniRedis
+
+	suite.redisClient = redis.NewClient(&redis.Options{
+		Addr: miniRedis.Addr(),
+	})
+	suite.repository = dynamic_parameter.NewRedisDynamicParameterRepository(suite.redisClient)
+	suite.schedule = dynamic_parameter.NewRedisDynamicParameterScheduleRepository(suite.redisClient)
+	suite.auditLog = dynamic_parameter.NewRedisDynamicParameterAuditLog(suite.redisClient)
+
+	parameters := map[string]interface{}{
+		"reporting_rate":       60,
+		"ff_test_feature_flag": false,
+		"allowed_regions":      []interface{}{"eu-west"},
+		"master_key_rotation":  "720h",
+	}
+	suite.transfer = dynamic_parameter.NewDynamicParameterOverridesTransfer(
+		dynamic_parameter.NewDynamicParameterRetriever(suite.repository, parameters),
+		suite.repository,
+		suite.schedule,
+		dynamic_parameter.NewDynamicParameterApprovalPolicy(time.Hour, "master_key_rotation"),
+		suite.auditLog,
+		utils.NewRandomUlidProvider(),
+		utils.NewFixedTimeProvider(),
+	)
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) TearDownSuite() {
+	suite.miniRedis.Close()
+	_ = suite.redisClient.Close()
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.miniRedis.FlushAll()
+
+	suite.Require().NoError(suite.repository.Save(suite.ctx, dynamic_parameter.DynamicParameter{
+		Name:         "reporting_rate",
+		DynamicValue: 30,
+	}))
+	suite.Require().NoError(suite.repository.Save(suite.ctx, dynamic_parameter.DynamicParameter{
+		Name:         "ff_test_feature_flag",
+		DynamicValue: true,
+	}))
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) TestExportOnlyReturnsOverrides() {
+	suite.NoError(suite.schedule.Save(suite.ctx, dynamic_parameter.NewExpiryTransition("expiry", "reporting_rate", nil, time.Now())))
+
+	overrides, err := suite.transfer.Export(suite.ctx)
+
+	suite.NoError(err)
+	suite.Equal(dynamic_parameter.DynamicParameterOverrides{
+		"reporting_rate":       float64(30),
+		"ff_test_feature_flag": true,
+	}, overrides)
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) TestExportSkipsNullValues() {
+	suite.NoError(suite.miniRedis.Set("dynamic_parameter:allowed_regions", "null"))
+
+	overrides, err := suite.transfer.Export(suite.ctx)
+
+	suite.NoError(err)
+	suite.NotContains(overrides, dynamic_parameter.ParameterName("allowed_regions"))
+	suite.Len(overrides, 2)
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) TestPlanShowsTheDiffWithoutApplyingIt() {
+	changes, err := suite.transfer.Plan(suite.ctx, dynamic_parameter.NewDynamicParameterOverridesFromMap(map[string]interface{}{
+		"reporting_rate":  float64(15),
+		"allowed_regions": []interface{}{"eu-west", "us-east"},
+	}))
+
+	suite.NoError(err)
+	suite.Equal([]dynamic_parameter.DynamicParameterOverrideChange{
+		{Name: "allowed_regions", Kind: dynamic_parameter.AddedOverride, Imported: []interface{}{"eu-west", "us-east"}},
+		{Name: "ff_test_feature_flag", Kind: dynamic_parameter.RemovedOverride, Current: true},
+		{Name: "reporting_rate", Kind: dynamic_parameter.ChangedOverride, Current: float64(30), Imported: float64(15)},
+	}, changes)
+
+	overrides, err := suite.transfer.Export(suite.ctx)
+	suite.NoError(err)
+	suite.Len(overrides, 2)
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) TestImportReplacesEveryOverride() {
+	suite.NoError(suite.schedule.Save(suite.ctx, dynamic_parameter.NewExpiryTransition("expiry", "reporting_rate", nil, time.Now())))
+
+	changes, err := suite.transfer.Import(suite.ctx, dynamic_parameter.NewDynamicParameterOverridesFromMap(map[string]interface{}{
+		"reporting_rate": float64(15),
+	}), "alice@example.com")
+
+	suite.NoError(err)
+	suite.Len(changes, 2)
+
+	overrides, err := suite.transfer.Export(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(dynamic_parameter.DynamicParameterOverrides{"reporting_rate": float64(15)}, overrides)
+
+	transitions, err := suite.schedule.SearchByParameter(suite.ctx, "reporting_rate")
+	suite.NoError(err)
+	suite.Empty(transitions)
+
+	entries, err := suite.auditLog.SearchByParameter(suite.ctx, "ff_test_feature_flag", 10)
+	suite.NoError(err)
+	suite.Len(entries, 1)
+	suite.Equal(dynamic_parameter.ImportedAuditAction, entries[0].Action)
+	suite.Equal("alice@example.com", entries[0].Actor)
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) TestImportRejectsInvalidOverridesWithoutApplyingAny() {
+	_, err := suite.transfer.Import(suite.ctx, dynamic_parameter.NewDynamicParameterOverridesFromMap(map[string]interface{}{
+		"reporting_rate":       "fast",
+		"ff_test_feature_flag": false,
+		"undeclared_parameter": 1,
+		"master_key_rotation":  "24h",
+	}), "alice@example.com")
+
+	suite.IsType(&dynamic_parameter.InvalidDynamicParameterOverrides{}, err)
+	reasons := err.(*dynamic_parameter.InvalidDynamicParameterOverrides).ExtraItems()
+	suite.Equal("expected a number value but got a string one", reasons["reporting_rate"])
+	suite.Equal("not declared", reasons["undeclared_parameter"])
+	suite.Contains(reasons["master_key_rotation"], "requires approval")
+	suite.NotContains(reasons, "ff_test_feature_flag")
+
+	overrides, err := suite.transfer.Export(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(float64(30), overrides["reporting_rate"])
+}
+
+func (suite *DynamicParameterOverridesTransferTestSuite) TestImportDoesNotRemoveTheSensitiveOverridesApprovedMeanwhile() {
+	transfer := dynamic_parameter.NewDynamicParameterOverridesTransfer(
+		dynamic_parameter.NewDynamicParameterRetriever(suite.repository, map[string]interface{}{
+			"reporting_rate":      60,
+			"master_key_rotation": "720h",
+		}),
+		approvedWhilePlanning{RedisDynamicParameterRepository: suite.repository, approve: func() {
+			suite.Require().NoError(suite.repository.Save(suite.ctx, dynamic_parameter.DynamicParameter{
+				Name:         "master_key_rotation",
+				DynamicValue: "24h",
+			}))
+		}},
+		suite.schedule,
+		dynamic_parameter.NewDynamicParameterApprovalPolicy(time.Hour, "master_key_rotation"),
+		suite.auditLog,
+		utils.NewRandomUlidProvider(),
+		utils.NewFixedTimeProvider(),
+	)
+
+	_, err := transfer.Import(suite.ctx, dynamic_parameter.NewDynamicParameterOverridesFromMap(map[string]interface{}{
+		"reporting_rate": float64(15),
+	}), "alice@example.com")
+
+	suite.IsType(&dynamic_parameter.InvalidDynamicParameterOverrides{}, err)
+	suite.Contains(err.(*dynamic_parameter.InvalidDynamicParameterOverrides).ExtraItems()["master_key_rotation"], "requires approval")
+	overrides, err := suite.transfer.Export(suite.ctx)
+	suite.NoError(err)
+	suite.Equal("24h", overrides["master_key_rotation"])
+	suite.Equal(float64(30), overrides["reporting_rate"])
+}
+
+// approvedWhilePlanning approves a change request between the plan of an import and its
+// replacement of the overrides.
+type approvedWhilePlanning struct {
+	*dynamic_parameter.RedisDynamicParameterRepository
+	approve func()
+}
+
+func (r approvedWhilePlanning) ReplaceOverrides(
+	ctx context.Context,
+	overrides dynamic_parameter.DynamicParameterOverrides,
+	check func(current dynamic_parameter.DynamicParameterOverrides) error,
+) error {
+	r.approve()
+	return r.RedisDynamicParameterRepository.ReplaceOverrides(ctx, overrides, check)
+}
+
+func TestDynamicParameterOverridesTransferTestSuite(t *testing.T) {
+	suite.Run(t, new(DynamicParameterOverridesTransferTestSuite))
+}
+
+func TestExportedDocumentCanBeImported(t *testing.T) {
+	overrides := dynamic_parameter.DynamicParameterOverrides{"reporting_rate": float64(15), "ff_test_feature_flag": true}
+	response := dynamic_parameter.NewDynamicParameterOverridesResponse("export-id", overrides, time.Now())
+
+	var document bytes.Buffer
+	require.NoError(t, jsonapi.MarshalPayload(&document, response))
+
+	parsed, err := dynamic_parameter.ParseDynamicParameterOverridesDocument(document.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, overrides, dynamic_parameter.NewDynamicParameterOverridesFromMap(parsed))
+
+	_, err = dynamic_parameter.ParseDynamicParameterOverridesDocument([]byte(`{"data":{}}`))
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package dynamic_parameter
 import (
 	"net/http"
 
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
 	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
 	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
 	http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
@@ -16,8 +17,11 @@ type DynamicParameterRouteRegisterer func(router *http_server.Router)
 type DynamicParameterRouterRegistererOpsFunc func(ops *DynamicParameterRouterRegistererOps)
 
 type DynamicParameterRouterRegistererOps struct {
-	FetchPath  string
-	UpdatePath string
+	FetchPath          string
+	UpdatePath         string
+	ChangeRequestsPath string
+	AuditLogPath       string
+	OverridesPath      string
 
 	CommandBus command.Bus
 	QueryBus   query.Bus
@@ -34,8 +38,11 @@ func (rro *DynamicParameterRouterRegistererOps) apply(ops ...DynamicParameterRou
 
 func NewDefaultDynamicParameterRouterRegistererOps() *DynamicParameterRouterRegistererOps {
 	return &DynamicParameterRouterRegistererOps{
-		FetchPath:  "/system/dynamic-parameters/{parameterName}",
-		UpdatePath: "/system/dynamic-parameters/{parameterName}",
+		FetchPath:          "/system/dynamic-parameters/{parameterName}",
+		UpdatePath:         "/system/dynamic-parameters/{parameterName}",
+		ChangeRequestsPath: "/system/dynamic-parameters/{parameterName}/change-requests",
+		AuditLogPath:       "/system/dynamic-parameters/{parameterName}/audit-log",
+		OverridesPath:      "/system/dynamic-parameter-overrides",
 
 		CommandBus: nil,
 		QueryBus:   nil,
@@ -65,6 +72,24 @@ func WithUpdatePath(path string) DynamicParameterRouterRegistererOpsFunc {
 	}
 }
 
+func WithChangeRequestsPath(path string) DynamicParameterRouterRegistererOpsFunc {
+	return func(ops *DynamicParameterRouterRegistererOps) {
+		ops.ChangeRequestsPath = path
+	}
+}
+
+func WithAuditLogPath(path string) DynamicParameterRouterRegistererOpsFunc {
+	return func(ops *DynamicParameterRouterRegistererOps) {
+		ops.AuditLogPath = path
+	}
+}
+
+func WithOverridesPath(path string) DynamicParameterRouterRegistererOpsFunc {
+	return func(ops *DynamicParameterRouterRegistererOps) {
+		ops.OverridesPath = path
+	}
+}
+
 func WithAuthMiddleware(middleware http_server.Middleware) DynamicParameterRouterRegistererOpsFunc {
 	return func(ops *DynamicParameterRouterRegistererOps) {
 		ops.AuthMiddleware = middleware
@@ -104,7 +129,49 @@ func RegisterDynamicParametersRoutes(ops ...DynamicParameterRouterRegistererOpsF
 
 		router.Put(
 			options.UpdatePath,
-			HandleChangeDynamicParameter(options.CommandBus, responseMiddleware),
+			HandleChangeDynamicParameter(options.CommandBus, options.QueryBus, responseMiddleware),
+			options.AuthMiddleware,
+		)
+
+		router.Get(
+			options.ChangeRequestsPath,
+			HandleGetDynamicParameterChangeRequests(options.QueryBus, responseMiddleware),
+			options.AuthMiddleware,
+		)
+
+		router.Get(
+			options.ChangeRequestsPath+"/{changeRequestId}",
+			HandleGetDynamicParameterChangeRequest(options.QueryBus, responseMiddleware),
+			options.AuthMiddleware,
+		)
+
+		router.Post(
+			options.ChangeRequestsPath+"/{changeRequestId}/approve",
+			HandleApproveDynamicParameterChangeRequest(options.CommandBus, responseMiddleware),
+			options.AuthMiddleware,
+		)
+
+		router.Post(
+			options.ChangeRequestsPath+"/{changeRequestId}/reject",
+			HandleRejectDynamicParameterChangeRequest(options.CommandBus, responseMiddleware),
+			options.AuthMiddleware,
+		)
+
+		router.Get(
+			options.AuditLogPath,
+			HandleGetDynamicParameterAuditLog(options.QueryBus, responseMiddleware),
+			options.AuthMiddleware,
+		)
+
+		router.Get(
+			options.OverridesPath,
+			HandleExportDynamicParameterOverrides(options.QueryBus, responseMiddleware),
+			options.AuthMiddleware,
+		)
+
+		router.Post(
+			options.OverridesPath+"/import",
+			HandleImportDynamicParameterOverrides(options.CommandBus, options.QueryBus, responseMiddleware),
 			options.AuthMiddleware,
 		)
 	}
@@ -112,19 +179,51 @@ func RegisterDynamicParametersRoutes(ops ...DynamicParameterRouterRegistererOpsF
 
 func RegisterDynamicParameterBusesOperations(
 	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
 	retriever *DynamicParameterRetriever,
 	repository DynamicParameterRepository,
+	overrides DynamicParameterOverridesRepository,
+	schedule DynamicParameterScheduleRepository,
+	changeRequests DynamicParameterChangeRequestRepository,
+	auditLog DynamicParameterAuditLog,
+	policy *DynamicParameterApprovalPolicy,
 	commandBus command.Bus,
 	queryBus query.Bus,
 ) {
-	findQueryHandler := NewFindDynamicParameterQueryHandler(ulidProvider, retriever)
-	changeCommandHandler := NewChangeDynamicParameterCommandHandler(retriever, repository)
+	changer := NewDynamicParameterChanger(retriever, repository, schedule, ulidProvider, timeProvider)
+	transfer := NewDynamicParameterOverridesTransfer(retriever, overrides, schedule, policy, auditLog, ulidProvider, timeProvider)
+
+	queryHandlers := map[bus.Dto]query.QueryHandler{
+		&FindDynamicParameterQuery{}:                 NewFindDynamicParameterQueryHandler(ulidProvider, retriever, schedule),
+		&FindDynamicParameterChangeRequestQuery{}:    NewFindDynamicParameterChangeRequestQueryHandler(changeRequests, timeProvider),
+		&SearchDynamicParameterChangeRequestsQuery{}: NewSearchDynamicParameterChangeRequestsQueryHandler(retriever, changeRequests, timeProvider),
+		&SearchDynamicParameterAuditLogQuery{}:       NewSearchDynamicParameterAuditLogQueryHandler(retriever, auditLog),
+		&ExportDynamicParameterOverridesQuery{}:      NewExportDynamicParameterOverridesQueryHandler(transfer, ulidProvider, timeProvider),
+		&PlanDynamicParameterOverridesImportQuery{}:  NewPlanDynamicParameterOverridesImportQueryHandler(transfer, ulidProvider),
+	}
+
+	for dto, handler := range queryHandlers {
+		if err := queryBus.RegisterQuery(dto, handler); err != nil {
+			panic(err)
+		}
+	}
 
-	if err := queryBus.RegisterQuery(&FindDynamicParameterQuery{}, findQueryHandler); err != nil {
-		panic(err)
+	commandHandlers := map[bus.Dto]command.CommandHandler{
+		&ChangeDynamicParameterCommand{}: NewChangeDynamicParameterCommandHandler(
+			changer, policy, changeRequests, auditLog, ulidProvider, timeProvider,
+		),
+		&ApproveDynamicParameterChangeRequestCommand{}: NewApproveDynamicParameterChangeRequestCommandHandler(
+			changer, changeRequests, auditLog, ulidProvider, timeProvider,
+		),
+		&RejectDynamicParameterChangeRequestCommand{}: NewRejectDynamicParameterChangeRequestCommandHandler(
+			changeRequests, auditLog, ulidProvider, timeProvider,
+		),
+		&ImportDynamicParameterOverridesCommand{}: NewImportDynamicParameterOverridesCommandHandler(transfer),
 	}
 
-	if err := commandBus.RegisterCommand(&ChangeDynamicParameterCommand{}, changeCommandHandler); err != nil {
-		panic(err)
+	for dto, handler := range commandHandlers {
+		if err := commandBus.RegisterCommand(dto, handler); err != nil {
+			panic(err)
+		}
 	}
 }
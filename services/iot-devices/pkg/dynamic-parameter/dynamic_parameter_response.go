@@ -1,17 +1,54 @@
 package dynamic_parameter
 
+import "time"
+
 type DynamicParameterResponse struct {
-	ID           string      `jsonapi:"primary,dynamic_parameter"`
-	Name         string      `jsonapi:"attr,name"`
-	DefaultValue interface{} `jsonapi:"attr,default_value"`
-	DynamicValue interface{} `jsonapi:"attr,dynamic_value"`
+	ID                 string                               `jsonapi:"primary,dynamic_parameter"`
+	Name               string                               `jsonapi:"attr,name"`
+	DefaultValue       interface{}                          `jsonapi:"attr,default_value"`
+	DynamicValue       interface{}                          `jsonapi:"attr,dynamic_value"`
+	PendingTransitions []DynamicParameterTransitionResponse `jsonapi:"attr,pending_transitions"`
+}
+
+type DynamicParameterTransitionResponse struct {
+	Kind      string      `json:"kind"`
+	Value     interface{} `json:"value"`
+	At        string      `json:"at"`
+	ExpiresAt *string     `json:"expires_at,omitempty"`
 }
 
 func NewDynamicParameterFromParameter(id string, parameter *DynamicParameter) *DynamicParameterResponse {
 	return &DynamicParameterResponse{
-		ID:           id,
-		Name:         parameter.Name.Value(),
-		DefaultValue: parameter.DefaultValue,
-		DynamicValue: parameter.DynamicValue,
+		ID:                 id,
+		Name:               parameter.Name.Value(),
+		DefaultValue:       parameter.DefaultValue,
+		DynamicValue:       parameter.DynamicValue,
+		PendingTransitions: make([]DynamicParameterTransitionResponse, 0),
+	}
+}
+
+func (dpr *DynamicParameterResponse) WithPendingTransitions(transitions []DynamicParameterTransition) *DynamicParameterResponse {
+	pending := make([]DynamicParameterTransitionResponse, 0, len(transitions))
+	for _, transition := range transitions {
+		pending = append(pending, NewDynamicParameterTransitionResponse(transition))
+	}
+
+	dpr.PendingTransitions = pending
+
+	return dpr
+}
+
+func NewDynamicParameterTransitionResponse(transition DynamicParameterTransition) DynamicParameterTransitionResponse {
+	response := DynamicParameterTransitionResponse{
+		Kind:  transition.Kind.Value(),
+		Value: transition.Value,
+		At:    transition.At.UTC().Format(time.RFC3339),
 	}
+
+	if transition.ExpiresAt != nil {
+		expiresAt := transition.ExpiresAt.UTC().Format(time.RFC3339)
+		response.ExpiresAt = &expiresAt
+	}
+
+	return response
 }
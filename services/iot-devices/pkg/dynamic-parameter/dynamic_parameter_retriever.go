@@ -2,6 +2,7 @@ package dynamic_parameter
 
 import (
 	"context"
+	"sync"
 
 	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
 )
@@ -9,6 +10,7 @@ import (
 type DynamicParameterRetriever struct {
 	repository       DynamicParameterRepository
 	parametersConfig map[string]interface{}
+	lock             sync.RWMutex
 }
 
 func NewDynamicParameterRetriever(
@@ -18,6 +20,7 @@ func NewDynamicParameterRetriever(
 	return &DynamicParameterRetriever{
 		repository:       repository,
 		parametersConfig: parametersConfig,
+		lock:             sync.RWMutex{},
 	}
 }
 
@@ -30,15 +33,11 @@ func NewDynamicParameterRetrieverFromConfigFile(
 }
 
 func (dr *DynamicParameterRetriever) Get(ctx context.Context, name ParameterName) (*DynamicParameter, error) {
-	defaultValue, ok := dr.parametersConfig[name.Value()]
+	defaultValue, ok := dr.Declared(name)
 	if !ok {
 		return nil, NewDynamicParameterNotExists(name)
 	}
 
-	if value, ok := defaultValue.(map[interface{}]interface{}); ok {
-		defaultValue = utils.MapInterfaceInterfaceToStringInterface(value)
-	}
-
 	parameterValue, err := dr.repository.Search(ctx, name)
 	if err != nil {
 		return nil, err
@@ -50,3 +49,34 @@ func (dr *DynamicParameterRetriever) Get(ctx context.Context, name ParameterName
 		DynamicValue: parameterValue,
 	}, nil
 }
+
+// ReplaceParametersConfig swaps the whole set of default values at once, so
+// concurrent readers see either the previous config or the new one, never a mix.
+func (dr *DynamicParameterRetriever) ReplaceParametersConfig(parametersConfig map[string]interface{}) {
+	dr.lock.Lock()
+	defer dr.lock.Unlock()
+
+	dr.parametersConfig = parametersConfig
+}
+
+// Declared returns the default value of the parameter when it is declared in the config.
+func (dr *DynamicParameterRetriever) Declared(name ParameterName) (ParameterValue, bool) {
+	defaultValue, ok := dr.defaultValue(name)
+	if !ok {
+		return nil, false
+	}
+
+	if value, ok := defaultValue.(map[interface{}]interface{}); ok {
+		defaultValue = utils.MapInterfaceInterfaceToStringInterface(value)
+	}
+
+	return defaultValue, true
+}
+
+func (dr *DynamicParameterRetriever) defaultValue(name ParameterName) (interface{}, bool) {
+	dr.lock.RLock()
+	defer dr.lock.RUnlock()
+
+	value, ok := dr.parametersConfig[name.Value()]
+	return value, ok
+}
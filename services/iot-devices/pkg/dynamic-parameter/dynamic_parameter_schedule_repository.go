@@ -0,0 +1,15 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"time"
+)
+
+type DynamicParameterScheduleRepository interface {
+	// ReplaceForParameter drops every pending transition of the parameter and stores the given ones
+	ReplaceForParameter(ctx context.Context, name ParameterName, transitions []DynamicParameterTransition) error
+	Save(ctx context.Context, transition DynamicParameterTransition) error
+	Delete(ctx context.Context, transition DynamicParameterTransition) error
+	SearchByParameter(ctx context.Context, name ParameterName) ([]DynamicParameterTransition, error)
+	SearchDue(ctx context.Context, now time.Time) ([]DynamicParameterTransition, error)
+}
@@ -0,0 +1,98 @@
+package dynamic_parameter_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+)
+
+type DynamicParameterScheduleRepositoryMock struct {
+	mock.Mock
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) ReplaceForParameter(
+	ctx context.Context,
+	name dynamic_parameter.ParameterName,
+	transitions []dynamic_parameter.DynamicParameterTransition,
+) error {
+	args := ds.Called(ctx, name, transitions)
+
+	return args.Error(0)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) Save(ctx context.Context, transition dynamic_parameter.DynamicParameterTransition) error {
+	args := ds.Called(ctx, transition)
+
+	return args.Error(0)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) Delete(ctx context.Context, transition dynamic_parameter.DynamicParameterTransition) error {
+	args := ds.Called(ctx, transition)
+
+	return args.Error(0)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) SearchByParameter(
+	ctx context.Context,
+	name dynamic_parameter.ParameterName,
+) ([]dynamic_parameter.DynamicParameterTransition, error) {
+	args := ds.Called(ctx, name)
+
+	return args.Get(0).([]dynamic_parameter.DynamicParameterTransition), args.Error(1)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) SearchDue(ctx context.Context, now time.Time) ([]dynamic_parameter.DynamicParameterTransition, error) {
+	args := ds.Called(ctx, now)
+
+	return args.Get(0).([]dynamic_parameter.DynamicParameterTransition), args.Error(1)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) ShouldReplaceForParameter(
+	ctx context.Context,
+	name dynamic_parameter.ParameterName,
+	transitions []dynamic_parameter.DynamicParameterTransition,
+) {
+	ds.
+		On("ReplaceForParameter", ctx, name, transitions).
+		Once().
+		Return(nil)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) ShouldSave(ctx context.Context, transition dynamic_parameter.DynamicParameterTransition) {
+	ds.
+		On("Save", ctx, transition).
+		Once().
+		Return(nil)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) ShouldDelete(ctx context.Context, transition dynamic_parameter.DynamicParameterTransition) {
+	ds.
+		On("Delete", ctx, transition).
+		Once().
+		Return(nil)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) ShouldSearchByParameter(
+	ctx context.Context,
+	name dynamic_parameter.ParameterName,
+	transitions []dynamic_parameter.DynamicParameterTransition,
+) {
+	ds.
+		On("SearchByParameter", ctx, name).
+		Once().
+		Return(transitions, nil)
+}
+
+func (ds *DynamicParameterScheduleRepositoryMock) ShouldSearchDue(
+	ctx context.Context,
+	now time.Time,
+	transitions []dynamic_parameter.DynamicParameterTransition,
+) {
+	ds.
+		On("SearchDue", ctx, now).
+		Once().
+		Return(transitions, nil)
+}
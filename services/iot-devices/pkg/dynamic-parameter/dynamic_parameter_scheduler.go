@@ -0,0 +1,172 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"log/slog"
+
+	distributed_sync "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/distributed-sync"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const schedulerMutexKey = "dynamic_parameter_scheduler"
+
+// DynamicParameterScheduler applies the transitions that are due and expires the
+// change requests nobody approved in time. Runs are serialized across replicas
+// through the distributed mutex.
+type DynamicParameterScheduler struct {
+	retriever      *DynamicParameterRetriever
+	repository     DynamicParameterRepository
+	schedule       DynamicParameterScheduleRepository
+	changeRequests DynamicParameterChangeRequestRepository
+	auditLog       DynamicParameterAuditLog
+	mutex          distributed_sync.MutexService
+	ulidProvider   utils.UlidProvider
+	timeProvider   utils.DateTimeProvider
+	logger         logger.Logger
+}
+
+func NewDynamicParameterScheduler(
+	retriever *DynamicParameterRetriever,
+	repository DynamicParameterRepository,
+	schedule DynamicParameterScheduleRepository,
+	changeRequests DynamicParameterChangeRequestRepository,
+	auditLog DynamicParameterAuditLog,
+	mutex distributed_sync.MutexService,
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+	logger logger.Logger,
+) *DynamicParameterScheduler {
+	return &DynamicParameterScheduler{
+		retriever:      retriever,
+		repository:     repository,
+		schedule:       schedule,
+		changeRequests: changeRequests,
+		auditLog:       auditLog,
+		mutex:          mutex,
+		ulidProvider:   ulidProvider,
+		timeProvider:   timeProvider,
+		logger:         logger,
+	}
+}
+
+// Run applies the transitions that are due and expires the change requests nobody
+// approved in time.
+func (ds *DynamicParameterScheduler) Run(ctx context.Context) error {
+	_, err := ds.mutex.Mutex(ctx, schedulerMutexKey, func() (interface{}, error) {
+		if err := ds.applyDue(ctx); err != nil {
+			return nil, err
+		}
+
+		return nil, ds.expireChangeRequests(ctx)
+	})
+
+	return err
+}
+
+func (ds *DynamicParameterScheduler) applyDue(ctx context.Context) error {
+	transitions, err := ds.schedule.SearchDue(ctx, ds.timeProvider.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, transition := range transitions {
+		if err := ds.apply(ctx, transition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ds *DynamicParameterScheduler) apply(ctx context.Context, transition DynamicParameterTransition) error {
+	parameter, err := ds.retriever.Get(ctx, transition.Name)
+	if err != nil {
+		if _, notExists := err.(*DynamicParameterNotExists); notExists {
+			ds.logger.Warn(
+				ctx,
+				"discarding transition of a parameter no longer declared",
+				slog.String("name", transition.Name.Value()),
+				slog.String("transition_id", transition.ID),
+			)
+			return ds.schedule.Delete(ctx, transition)
+		}
+		return err
+	}
+
+	if err := ds.repository.Save(ctx, parameter.WithNewValue(transition.Value)); err != nil {
+		return err
+	}
+
+	if transition.Kind == ActivationTransition && transition.ExpiresAt != nil {
+		expiry := NewExpiryTransition(ds.ulidProvider.New().String(), transition.Name, parameter.DynamicValue, *transition.ExpiresAt)
+		if err := ds.schedule.Save(ctx, expiry); err != nil {
+			return err
+		}
+	}
+
+	entry := NewDynamicParameterAuditEntry(
+		ds.ulidProvider.New().String(),
+		transition.Name,
+		TransitionAppliedAuditAction,
+		schedulerAuditActor,
+		transition.Value,
+		"",
+		ds.timeProvider.Now(),
+	)
+	if err := ds.auditLog.Record(ctx, entry); err != nil {
+		return err
+	}
+
+	ds.logger.Info(
+		ctx,
+		"dynamic parameter transition applied",
+		slog.String("name", transition.Name.Value()),
+		slog.String("kind", transition.Kind.Value()),
+		slog.String("transition_id", transition.ID),
+	)
+
+	return ds.schedule.Delete(ctx, transition)
+}
+
+func (ds *DynamicParameterScheduler) expireChangeRequests(ctx context.Context) error {
+	requests, err := ds.changeRequests.SearchPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := ds.timeProvider.Now()
+	for _, request := range requests {
+		if request.StatusAt(now) != ExpiredChangeRequest {
+			continue
+		}
+
+		expired := request.Expire(now)
+		if err := ds.changeRequests.Save(ctx, expired); err != nil {
+			return err
+		}
+
+		entry := NewDynamicParameterAuditEntry(
+			ds.ulidProvider.New().String(),
+			expired.Name,
+			ChangeRequestExpiredAuditAction,
+			schedulerAuditActor,
+			expired.Value,
+			expired.ID,
+			now,
+		)
+		if err := ds.auditLog.Record(ctx, entry); err != nil {
+			return err
+		}
+
+		ds.logger.Info(
+			ctx,
+			"dynamic parameter change request expired",
+			slog.String("name", expired.Name.Value()),
+			slog.String("change_request_id", expired.ID),
+			slog.String("requested_by", expired.RequestedBy),
+		)
+	}
+
+	return nil
+}
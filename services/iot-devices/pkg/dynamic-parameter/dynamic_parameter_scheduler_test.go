@@ -0,0 +1,126 @@
+package dynamic_parameter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+type inProcessMutex struct{}
+
+func (m inProcessMutex) Mutex(_ context.Context, _ string, fn func() (interface{}, error)) (interface{}, error) {
+	return fn()
+}
+
+func TestDynamicParameterScheduler(t *testing.T) {
+	rootCtx := context.Background()
+	repository := new(DynamicParameterRepositoryMock)
+	schedule := new(DynamicParameterScheduleRepositoryMock)
+	changeRequests := new(DynamicParameterChangeRequestRepositoryMock)
+	auditLog := new(DynamicParameterAuditLogMock)
+	ulidProvider, timeProvider := utils.NewFixedUlidProvider(), utils.NewFixedTimeProvider()
+	parameters := map[string]interface{}{"reporting_rate": 60}
+	scheduler := dynamic_parameter.NewDynamicParameterScheduler(
+		dynamic_parameter.NewDynamicParameterRetriever(repository, parameters),
+		repository,
+		schedule,
+		changeRequests,
+		auditLog,
+		inProcessMutex{},
+		ulidProvider,
+		timeProvider,
+		logger.NewNullLogger(),
+	)
+	name := dynamic_parameter.ParameterName("reporting_rate")
+
+	t.Run("Apply due activation and schedule its expiry", func(t *testing.T) {
+		expiresAt := timeProvider.Now().Add(24 * time.Hour)
+		activation := dynamic_parameter.NewActivationTransition("activation-id", name, 10, timeProvider.Now(), &expiresAt)
+
+		schedule.ShouldSearchDue(rootCtx, timeProvider.Now(), []dynamic_parameter.DynamicParameterTransition{activation})
+		repository.ShouldSearchDynamicParameter(rootCtx, name, float64(30))
+		repository.ShouldSave(rootCtx, dynamic_parameter.DynamicParameter{Name: name, DefaultValue: 60, DynamicValue: 10})
+		schedule.ShouldSave(rootCtx, dynamic_parameter.NewExpiryTransition(ulidProvider.New().String(), name, float64(30), expiresAt))
+		schedule.ShouldDelete(rootCtx, activation)
+		auditLog.ShouldRecord(rootCtx, transitionAppliedEntry(ulidProvider, timeProvider, activation))
+		changeRequests.ShouldSearchPending(rootCtx, []dynamic_parameter.DynamicParameterChangeRequest{})
+
+		assert.NoError(t, scheduler.Run(rootCtx))
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+
+	t.Run("Apply due expiry restoring the previous value", func(t *testing.T) {
+		expiry := dynamic_parameter.NewExpiryTransition("expiry-id", name, nil, timeProvider.Now())
+
+		schedule.ShouldSearchDue(rootCtx, timeProvider.Now(), []dynamic_parameter.DynamicParameterTransition{expiry})
+		repository.ShouldSearchDynamicParameter(rootCtx, name, 10)
+		repository.ShouldSave(rootCtx, dynamic_parameter.DynamicParameter{Name: name, DefaultValue: 60, DynamicValue: nil})
+		schedule.ShouldDelete(rootCtx, expiry)
+		auditLog.ShouldRecord(rootCtx, transitionAppliedEntry(ulidProvider, timeProvider, expiry))
+		changeRequests.ShouldSearchPending(rootCtx, []dynamic_parameter.DynamicParameterChangeRequest{})
+
+		assert.NoError(t, scheduler.Run(rootCtx))
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+
+	t.Run("Discard transitions of parameters no longer declared", func(t *testing.T) {
+		expiry := dynamic_parameter.NewExpiryTransition("expiry-id", "removed_parameter", nil, timeProvider.Now())
+
+		schedule.ShouldSearchDue(rootCtx, timeProvider.Now(), []dynamic_parameter.DynamicParameterTransition{expiry})
+		schedule.ShouldDelete(rootCtx, expiry)
+		changeRequests.ShouldSearchPending(rootCtx, []dynamic_parameter.DynamicParameterChangeRequest{})
+
+		assert.NoError(t, scheduler.Run(rootCtx))
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+
+	t.Run("Expire change requests nobody approved in time", func(t *testing.T) {
+		now := timeProvider.Now()
+		change := dynamic_parameter.DynamicParameterChange{Name: name, Value: float64(10)}
+		overdue := dynamic_parameter.NewDynamicParameterChangeRequest("overdue", change, "alice@example.com", now.Add(-2*time.Hour), now)
+		pending := dynamic_parameter.NewDynamicParameterChangeRequest("pending", change, "alice@example.com", now, now.Add(time.Hour))
+
+		schedule.ShouldSearchDue(rootCtx, now, []dynamic_parameter.DynamicParameterTransition{})
+		changeRequests.ShouldSearchPending(rootCtx, []dynamic_parameter.DynamicParameterChangeRequest{overdue, pending})
+		changeRequests.ShouldSave(rootCtx, overdue.Expire(now))
+		auditLog.ShouldRecord(rootCtx, dynamic_parameter.NewDynamicParameterAuditEntry(
+			ulidProvider.New().String(),
+			name,
+			dynamic_parameter.ChangeRequestExpiredAuditAction,
+			"scheduler",
+			float64(10),
+			"overdue",
+			now,
+		))
+
+		assert.NoError(t, scheduler.Run(rootCtx))
+
+		mock.AssertExpectationsForObjects(t, repository, schedule, changeRequests, auditLog)
+	})
+}
+
+func transitionAppliedEntry(
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+	transition dynamic_parameter.DynamicParameterTransition,
+) dynamic_parameter.DynamicParameterAuditEntry {
+	return dynamic_parameter.NewDynamicParameterAuditEntry(
+		ulidProvider.New().String(),
+		transition.Name,
+		dynamic_parameter.TransitionAppliedAuditAction,
+		"scheduler",
+		transition.Value,
+		"",
+		timeProvider.Now(),
+	)
+}
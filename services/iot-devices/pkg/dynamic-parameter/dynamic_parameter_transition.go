@@ -0,0 +1,61 @@
+package dynamic_parameter
+
+import "time"
+
+type TransitionKind string
+
+const (
+	// ActivationTransition sets the parameter value once it becomes effective
+	ActivationTransition TransitionKind = "activation"
+	// ExpiryTransition restores the value the parameter had before the change
+	ExpiryTransition TransitionKind = "expiry"
+)
+
+func (tk TransitionKind) Value() string {
+	return string(tk)
+}
+
+type DynamicParameterTransition struct {
+	ID        string
+	Name      ParameterName
+	Kind      TransitionKind
+	Value     ParameterValue
+	At        time.Time
+	ExpiresAt *time.Time
+}
+
+func NewActivationTransition(
+	id string,
+	name ParameterName,
+	value ParameterValue,
+	effectiveFrom time.Time,
+	expiresAt *time.Time,
+) DynamicParameterTransition {
+	return DynamicParameterTransition{
+		ID:        id,
+		Name:      name,
+		Kind:      ActivationTransition,
+		Value:     value,
+		At:        effectiveFrom,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func NewExpiryTransition(
+	id string,
+	name ParameterName,
+	previousValue ParameterValue,
+	expiresAt time.Time,
+) DynamicParameterTransition {
+	return DynamicParameterTransition{
+		ID:    id,
+		Name:  name,
+		Kind:  ExpiryTransition,
+		Value: previousValue,
+		At:    expiresAt,
+	}
+}
+
+func (dt DynamicParameterTransition) IsDue(now time.Time) bool {
+	return !dt.At.After(now)
+}
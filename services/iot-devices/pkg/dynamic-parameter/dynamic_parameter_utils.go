@@ -1,51 +1,73 @@
 package dynamic_parameter
 
 import (
-	"fmt"
 	"os"
-	"reflect"
-	"strconv"
 
 	"gopkg.in/yaml.v3"
+
+	domain_validation "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain/validation"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
 )
 
+const parameterNamePattern = "^[a-zA-Z0-9_.-]+$"
+
 func InitDefaultDynamicParametersConfigFromYamlFile(filename string) map[string]interface{} {
-	parameters := make(map[string]interface{})
+	parameters, err := LoadDynamicParametersConfigFromYamlFile(filename)
+	if err != nil {
+		panic(err)
+	}
 
+	return parameters
+}
+
+func LoadDynamicParametersConfigFromYamlFile(filename string) (map[string]interface{}, error) {
 	yamlFile, err := os.ReadFile(filename)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	return ParseDynamicParametersConfig(yamlFile)
+}
+
+// ParseDynamicParametersConfig decodes and validates a whole parameters document.
+// Any invalid entry rejects the full document, so a partially broken file is never applied.
+func ParseDynamicParametersConfig(content []byte) (map[string]interface{}, error) {
+	parameters := make(map[string]interface{})
+
+	if yamlErr := yaml.Unmarshal(content, &parameters); yamlErr != nil {
+		return nil, NewInvalidDynamicParametersConfig(yamlErr.Error())
 	}
 
-	if yamlErr := yaml.Unmarshal(yamlFile, &parameters); yamlErr != nil {
-		panic(yamlErr)
+	if len(parameters) == 0 {
+		return nil, NewInvalidDynamicParametersConfig("no parameters declared")
 	}
 
-	return parameters
+	nameValidator := domain_validation.NewDomainValidator(
+		domain_validation.NotEmpty(),
+		domain_validation.Regex(parameterNamePattern),
+	)
+
+	for name, value := range parameters {
+		if err := nameValidator.Validate(name, nil); err != nil {
+			return nil, NewInvalidDynamicParametersConfigForParameter(name, "invalid parameter name")
+		}
+
+		if value == nil {
+			return nil, NewInvalidDynamicParametersConfigForParameter(name, "parameter without default value")
+		}
+
+		if mapValue, ok := value.(map[interface{}]interface{}); ok {
+			parameters[name] = utils.MapInterfaceInterfaceToStringInterface(mapValue)
+		}
+	}
+
+	return parameters, nil
 }
 
 func BoolDynamicParameterValue(parameter *DynamicParameter) (bool, error) {
-	value := parameter.DefaultIfDynamicIsNil()
-	switch reflect.TypeOf(value).Kind() {
-	case reflect.String:
-		return strconv.ParseBool(fmt.Sprintf("%v", value))
-	case reflect.Bool:
-		return value.(bool), nil
-	default:
-		return false, NewInvalidDynamicParameterType(parameter.Name, "bool")
-	}
+	return Value[bool](parameter)
 }
 
 func IntDynamicParameterValue(parameter *DynamicParameter) (int, error) {
-	value := parameter.DefaultIfDynamicIsNil()
-	switch reflect.TypeOf(value).Kind() {
-	case reflect.String:
-		return strconv.Atoi(fmt.Sprintf("%v", value))
-	case reflect.Float64:
-		return int(value.(float64)), nil
-	case reflect.Int:
-		return value.(int), nil
-	default:
-		return 0, NewInvalidDynamicParameterType(parameter.Name, "int")
-	}
+	return Value[int](parameter)
 }
@@ -0,0 +1,185 @@
+package dynamic_parameter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// Value returns the effective value of the parameter (dynamic if set, default
+// otherwise) converted to T. Scalars accept their string representation, a
+// time.Duration accepts "90s"-like strings or a number of seconds, and any other
+// type (maps, structs) is decoded through its JSON representation.
+func Value[T any](parameter *DynamicParameter) (T, error) {
+	var result T
+
+	if parameter == nil {
+		return result, NewInvalidDynamicParameterTypeFromValue("", typeName(result), nil, nil)
+	}
+
+	value := parameter.DefaultIfDynamicIsNil()
+	if value == nil {
+		return result, NewInvalidDynamicParameterTypeFromValue(parameter.Name, typeName(result), nil, nil)
+	}
+
+	converted, err := convertValue[T](value)
+	if err != nil {
+		return result, NewInvalidDynamicParameterTypeFromValue(parameter.Name, typeName(result), value, err)
+	}
+
+	return converted, nil
+}
+
+// ValueOrDefault behaves like Value but falls back to the given value on any error.
+func ValueOrDefault[T any](parameter *DynamicParameter, fallback T) T {
+	value, err := Value[T](parameter)
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func convertValue[T any](value interface{}) (T, error) {
+	var (
+		result    T
+		converted interface{}
+		err       error
+	)
+
+	switch any(result).(type) {
+	case bool:
+		converted, err = toBool(value)
+	case string:
+		converted, err = toString(value)
+	case int:
+		var i int64
+		i, err = toInt64(value)
+		converted = int(i)
+	case int64:
+		converted, err = toInt64(value)
+	case float64:
+		converted, err = toFloat64(value)
+	case time.Duration:
+		converted, err = toDuration(value)
+	case []string:
+		converted, err = toStringSlice(value)
+	default:
+		err = decodeValue(value, &result)
+		return result, err
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	return converted.(T), nil
+}
+
+func toString(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	return "", fmt.Errorf("%T is not a string", value)
+}
+
+func toBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	default:
+		return false, fmt.Errorf("%T is not a bool", value)
+	}
+}
+
+// toInt64 truncates the fractional part of floats, like IntDynamicParameterValue always
+// did with the values stored as JSON numbers.
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		// float64(math.MaxInt64) rounds up to 2^63, which is already out of range
+		if math.IsNaN(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is out of the integer range", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("%T is not an integer", value)
+	}
+}
+
+func toFloat64(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("%T is not a number", value)
+	}
+}
+
+func toDuration(value interface{}) (time.Duration, error) {
+	if s, ok := value.(string); ok {
+		return time.ParseDuration(s)
+	}
+
+	seconds, err := toFloat64(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func toStringSlice(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%T item is not a string", item)
+			}
+			result = append(result, s)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%T is not a list", value)
+	}
+}
+
+func decodeValue(value interface{}, target interface{}) error {
+	if mapValue, ok := value.(map[interface{}]interface{}); ok {
+		value = utils.MapInterfaceInterfaceToStringInterface(mapValue)
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bytes, target)
+}
+
+func typeName(value interface{}) string {
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,115 @@
+package dynamic_parameter_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+)
+
+type retryPolicy struct {
+	MaxRetries      int      `json:"max_retries"`
+	InitialInterval string   `json:"initial_interval"`
+	RetryOn         []string `json:"retry_on"`
+}
+
+func parameterWith(defaultValue interface{}, dynamicValue interface{}) *dynamic_parameter.DynamicParameter {
+	return &dynamic_parameter.DynamicParameter{
+		Name:         "test_parameter",
+		DefaultValue: defaultValue,
+		DynamicValue: dynamicValue,
+	}
+}
+
+func TestDynamicParameterValue(t *testing.T) {
+	t.Run("it should read scalar values", func(t *testing.T) {
+		boolValue, err := dynamic_parameter.Value[bool](parameterWith(false, "true"))
+		assert.NoError(t, err)
+		assert.True(t, boolValue)
+
+		intValue, err := dynamic_parameter.Value[int](parameterWith(3, float64(7)))
+		assert.NoError(t, err)
+		assert.Equal(t, 7, intValue)
+
+		floatValue, err := dynamic_parameter.Value[float64](parameterWith(2, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, float64(2), floatValue)
+
+		stringValue, err := dynamic_parameter.Value[string](parameterWith("eu-west", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, "eu-west", stringValue)
+	})
+
+	t.Run("it should read durations from strings and seconds", func(t *testing.T) {
+		fromString, err := dynamic_parameter.Value[time.Duration](parameterWith("1m30s", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 90*time.Second, fromString)
+
+		fromSeconds, err := dynamic_parameter.Value[time.Duration](parameterWith(15, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 15*time.Second, fromSeconds)
+	})
+
+	t.Run("it should read string slices", func(t *testing.T) {
+		value, err := dynamic_parameter.Value[[]string](parameterWith([]interface{}{"a", "b"}, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, value)
+	})
+
+	t.Run("it should decode structs and maps", func(t *testing.T) {
+		raw := map[string]interface{}{
+			"max_retries":      3,
+			"initial_interval": "100ms",
+			"retry_on":         []interface{}{"timeout"},
+		}
+
+		policy, err := dynamic_parameter.Value[retryPolicy](parameterWith(raw, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, retryPolicy{MaxRetries: 3, InitialInterval: "100ms", RetryOn: []string{"timeout"}}, policy)
+
+		asMap, err := dynamic_parameter.Value[map[string]interface{}](parameterWith(raw, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, float64(3), asMap["max_retries"])
+	})
+
+	t.Run("it should truncate the floats stored for integers", func(t *testing.T) {
+		intValue, err := dynamic_parameter.Value[int](parameterWith(3.5, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, intValue)
+
+		intValue, err = dynamic_parameter.IntDynamicParameterValue(parameterWith(0, float64(3)))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, intValue)
+	})
+
+	t.Run("it should fail with a typed error on nil values", func(t *testing.T) {
+		_, err := dynamic_parameter.Value[bool](parameterWith(nil, nil))
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterType{}, err)
+
+		_, err = dynamic_parameter.Value[bool](nil)
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterType{}, err)
+	})
+
+	t.Run("it should fail with a typed error on conversion errors", func(t *testing.T) {
+		_, err := dynamic_parameter.Value[int](parameterWith(1e19, nil))
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterType{}, err)
+
+		_, err = dynamic_parameter.Value[int64](parameterWith(math.NaN(), nil))
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterType{}, err)
+
+		_, err = dynamic_parameter.Value[[]string](parameterWith([]interface{}{"a", 1}, nil))
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterType{}, err)
+
+		_, err = dynamic_parameter.Value[retryPolicy](parameterWith("not an object", nil))
+		assert.IsType(t, &dynamic_parameter.InvalidDynamicParameterType{}, err)
+		assert.Equal(t, "Invalid dynamic parameter type", err.Error())
+	})
+
+	t.Run("it should fall back to the given value", func(t *testing.T) {
+		assert.Equal(t, 10, dynamic_parameter.ValueOrDefault(parameterWith("nope", nil), 10))
+		assert.Equal(t, 4, dynamic_parameter.ValueOrDefault(parameterWith(4, nil), 10))
+	})
+}
@@ -0,0 +1,150 @@
+package dynamic_parameter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otel_metric "go.opentelemetry.io/otel/metric"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+)
+
+const (
+	configReloadsCounterName = "dynamic_parameters.config.reloads"
+	configReloadSucceeded    = "succeeded"
+	configReloadFailed       = "failed"
+)
+
+// DynamicParametersConfigWatcher polls the defaults file and swaps the new
+// config into the retriever once it has been fully validated. An invalid file
+// keeps the previous config in place until a valid one is written.
+type DynamicParametersConfigWatcher struct {
+	filePath  string
+	retriever *DynamicParameterRetriever
+	logger    logger.Logger
+	reloads   otel_metric.Int64Counter
+
+	lock        sync.Mutex
+	lastModTime time.Time
+	lastSize    int64
+	lastHash    []byte
+}
+
+func NewDynamicParametersConfigWatcher(
+	filePath string,
+	retriever *DynamicParameterRetriever,
+	logger logger.Logger,
+	meter otel_metric.Meter,
+) (*DynamicParametersConfigWatcher, error) {
+	reloads, err := meter.Int64Counter(
+		configReloadsCounterName,
+		otel_metric.WithDescription("Number of dynamic parameters config reloads by status"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher := &DynamicParametersConfigWatcher{
+		filePath:  filePath,
+		retriever: retriever,
+		logger:    logger,
+		reloads:   reloads,
+	}
+
+	// The retriever was built from the current file, so it is taken as the baseline
+	if content, statErr := watcher.readIfChanged(); statErr == nil && content != nil {
+		watcher.lastHash = hashOf(content)
+	}
+
+	return watcher, nil
+}
+
+// Check reloads the config if the file changed since the last check. It matches
+// utils.ExecutorFunc so it can be driven by utils.IntervalExecutor. Rejected reloads are
+// logged and counted here, so they are not returned to be logged again.
+func (w *DynamicParametersConfigWatcher) Check(ctx context.Context) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	content, err := w.readIfChanged()
+	if err != nil {
+		w.registerFailure(ctx, err)
+		return nil
+	}
+
+	if content == nil {
+		return nil
+	}
+
+	hash := hashOf(content)
+	if bytes.Equal(hash, w.lastHash) {
+		return nil
+	}
+	w.lastHash = hash
+
+	parameters, err := ParseDynamicParametersConfig(content)
+	if err != nil {
+		w.registerFailure(ctx, err)
+		return nil
+	}
+
+	w.retriever.ReplaceParametersConfig(parameters)
+	w.registerSuccess(ctx, len(parameters))
+
+	return nil
+}
+
+func (w *DynamicParametersConfigWatcher) readIfChanged() ([]byte, error) {
+	info, err := os.Stat(w.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.ModTime().Equal(w.lastModTime) && info.Size() == w.lastSize {
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(w.filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	w.lastModTime, w.lastSize = info.ModTime(), info.Size()
+
+	return content, nil
+}
+
+func (w *DynamicParametersConfigWatcher) registerSuccess(ctx context.Context, parametersCount int) {
+	w.reloads.Add(ctx, 1, otel_metric.WithAttributes(attribute.String("status", configReloadSucceeded)))
+	w.logger.Info(
+		ctx,
+		"dynamic parameters config reloaded",
+		slog.String("file_path", w.filePath),
+		slog.Int("parameters", parametersCount),
+	)
+}
+
+func (w *DynamicParametersConfigWatcher) registerFailure(ctx context.Context, err error) {
+	w.reloads.Add(ctx, 1, otel_metric.WithAttributes(attribute.String("status", configReloadFailed)))
+
+	items := []slog.Attr{
+		slog.String("file_path", w.filePath),
+		logger.ErrValue("error", err),
+	}
+	if invalidConfigErr, ok := err.(*InvalidDynamicParametersConfig); ok {
+		items = append(items, slog.Any("details", invalidConfigErr.ExtraItems()))
+	}
+
+	w.logger.Error(ctx, "dynamic parameters config reload rejected, keeping previous config", items...)
+}
+
+func hashOf(content []byte) []byte {
+	sum := sha256.Sum256(content)
+	return sum[:]
+}
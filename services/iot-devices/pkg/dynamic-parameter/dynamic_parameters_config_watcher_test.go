@@ -0,0 +1,125 @@
+package dynamic_parameter_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdk_metric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+)
+
+func TestDynamicParametersConfigWatcher(t *testing.T) {
+	rootCtx := context.Background()
+	configFilePath := filepath.Join(t.TempDir(), "dynamic-parameters.yaml")
+	writeConfigFile(t, configFilePath, "test_flag: false\n", time.Now().Add(-time.Minute))
+
+	repository := new(DynamicParameterRepositoryMock)
+	retriever := dynamic_parameter.NewDynamicParameterRetrieverFromConfigFile(repository, configFilePath)
+
+	metricReader := sdk_metric.NewManualReader()
+	meter := sdk_metric.NewMeterProvider(sdk_metric.WithReader(metricReader)).Meter("test")
+
+	watcher, err := dynamic_parameter.NewDynamicParametersConfigWatcher(configFilePath, retriever, logger.NewNullLogger(), meter)
+	require.NoError(t, err)
+
+	t.Run("Keep config when file has not changed", func(t *testing.T) {
+		assert.NoError(t, watcher.Check(rootCtx))
+		assert.Equal(t, map[string]int64{}, reloadsByStatus(t, metricReader))
+	})
+
+	t.Run("Swap config when file contains a valid document", func(t *testing.T) {
+		writeConfigFile(t, configFilePath, "test_flag: true\nnew_flag: 5\n", time.Now())
+
+		assert.NoError(t, watcher.Check(rootCtx))
+
+		repository.ShouldSearchDynamicParameter(rootCtx, dynamic_parameter.ParameterName("new_flag"), nil)
+		parameter, err := retriever.Get(rootCtx, dynamic_parameter.ParameterName("new_flag"))
+		assert.NoError(t, err)
+		assert.Equal(t, 5, parameter.DefaultValue)
+		assert.Equal(t, map[string]int64{"succeeded": 1}, reloadsByStatus(t, metricReader))
+	})
+
+	t.Run("Keep previous config when file contains an invalid document", func(t *testing.T) {
+		writeConfigFile(t, configFilePath, "test_flag: false\nbroken_flag:\n", time.Now().Add(time.Minute))
+
+		assert.NoError(t, watcher.Check(rootCtx))
+
+		repository.ShouldSearchDynamicParameter(rootCtx, dynamic_parameter.ParameterName("test_flag"), nil)
+		parameter, err := retriever.Get(rootCtx, dynamic_parameter.ParameterName("test_flag"))
+		assert.NoError(t, err)
+		assert.Equal(t, true, parameter.DefaultValue)
+		assert.Equal(t, map[string]int64{"succeeded": 1, "failed": 1}, reloadsByStatus(t, metricReader))
+	})
+
+	t.Run("Keep previous config when file is not valid yaml", func(t *testing.T) {
+		writeConfigFile(t, configFilePath, "test_flag: [true\n", time.Now().Add(2*time.Minute))
+
+		assert.NoError(t, watcher.Check(rootCtx))
+
+		repository.ShouldSearchDynamicParameter(rootCtx, dynamic_parameter.ParameterName("new_flag"), nil)
+		_, err := retriever.Get(rootCtx, dynamic_parameter.ParameterName("new_flag"))
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"succeeded": 1, "failed": 2}, reloadsByStatus(t, metricReader))
+	})
+}
+
+func TestParseDynamicParametersConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		expectsError bool
+	}{
+		{name: "it should accept a valid document", content: "ff_flag: true\nrate: 10\n", expectsError: false},
+		{name: "it should fail on an empty document", content: "", expectsError: true},
+		{name: "it should fail on a parameter without default", content: "ff_flag:\n", expectsError: true},
+		{name: "it should fail on an invalid parameter name", content: "\"ff flag\": true\n", expectsError: true},
+		{name: "it should fail if root is not a mapping", content: "- ff_flag\n", expectsError: true},
+	}
+
+	for _, scenario := range tests {
+		t.Run(scenario.name, func(t *testing.T) {
+			_, err := dynamic_parameter.ParseDynamicParametersConfig([]byte(scenario.content))
+
+			if scenario.expectsError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func writeConfigFile(t *testing.T, path string, content string, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func reloadsByStatus(t *testing.T, reader *sdk_metric.ManualReader) map[string]int64 {
+	var resourceMetrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &resourceMetrics))
+
+	reloads := make(map[string]int64)
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, m := range scopeMetrics.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, point := range sum.DataPoints {
+				status, _ := point.Attributes.Value("status")
+				reloads[status.AsString()] = point.Value
+			}
+		}
+	}
+
+	return reloads
+}
@@ -0,0 +1,16 @@
+package dynamic_parameter
+
+import (
+	"net/http"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+)
+
+func HandleExportDynamicParameterOverrides(bus query.Bus, responseMiddleware *json_api.JsonApiResponseMiddleware) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := bus.Ask(r.Context(), &ExportDynamicParameterOverridesQuery{})
+
+		writeDynamicParameterQueryResponse(w, r, responseMiddleware, response, err)
+	}
+}
@@ -0,0 +1,47 @@
+package dynamic_parameter
+
+import (
+	"context"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const exportDynamicParameterOverridesQueryName = "export_dynamic_parameter_overrides_query"
+
+type ExportDynamicParameterOverridesQuery struct{}
+
+func (eoq ExportDynamicParameterOverridesQuery) Type() string {
+	return exportDynamicParameterOverridesQueryName
+}
+
+type ExportDynamicParameterOverridesQueryHandler struct {
+	transfer     *DynamicParameterOverridesTransfer
+	ulidProvider utils.UlidProvider
+	timeProvider utils.DateTimeProvider
+}
+
+func NewExportDynamicParameterOverridesQueryHandler(
+	transfer *DynamicParameterOverridesTransfer,
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+) ExportDynamicParameterOverridesQueryHandler {
+	return ExportDynamicParameterOverridesQueryHandler{
+		transfer:     transfer,
+		ulidProvider: ulidProvider,
+		timeProvider: timeProvider,
+	}
+}
+
+func (eh ExportDynamicParameterOverridesQueryHandler) Handle(ctx context.Context, dto bus.Dto) (interface{}, error) {
+	if _, ok := dto.(*ExportDynamicParameterOverridesQuery); !ok {
+		return nil, bus.NewInvalidDto("Invalid query")
+	}
+
+	overrides, err := eh.transfer.Export(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDynamicParameterOverridesResponse(eh.ulidProvider.New().String(), overrides, eh.timeProvider.Now()), nil
+}
@@ -0,0 +1,45 @@
+package dynamic_parameter
+
+import (
+	"context"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const findDynamicParameterChangeRequestQueryName = "find_dynamic_parameter_change_request_query"
+
+type FindDynamicParameterChangeRequestQuery struct {
+	Name string
+	ID   string
+}
+
+func (fcq FindDynamicParameterChangeRequestQuery) Type() string {
+	return findDynamicParameterChangeRequestQueryName
+}
+
+type FindDynamicParameterChangeRequestQueryHandler struct {
+	changeRequests DynamicParameterChangeRequestRepository
+	timeProvider   utils.DateTimeProvider
+}
+
+func NewFindDynamicParameterChangeRequestQueryHandler(
+	changeRequests DynamicParameterChangeRequestRepository,
+	timeProvider utils.DateTimeProvider,
+) FindDynamicParameterChangeRequestQueryHandler {
+	return FindDynamicParameterChangeRequestQueryHandler{changeRequests: changeRequests, timeProvider: timeProvider}
+}
+
+func (fh FindDynamicParameterChangeRequestQueryHandler) Handle(ctx context.Context, dto bus.Dto) (interface{}, error) {
+	query, ok := dto.(*FindDynamicParameterChangeRequestQuery)
+	if !ok {
+		return nil, bus.NewInvalidDto("Invalid query")
+	}
+
+	request, err := findChangeRequest(ctx, fh.changeRequests, ParameterName(query.Name), query.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDynamicParameterChangeRequestResponse(*request, fh.timeProvider.Now()), nil
+}
@@ -20,13 +20,15 @@ func (fpq FindDynamicParameterQuery) Type() string {
 type FindDynamicParameterQueryHandler struct {
 	ulidProvider utils.UlidProvider
 	retriever    *DynamicParameterRetriever
+	schedule     DynamicParameterScheduleRepository
 }
 
 func NewFindDynamicParameterQueryHandler(
 	ulidProvider utils.UlidProvider,
 	retriever *DynamicParameterRetriever,
+	schedule DynamicParameterScheduleRepository,
 ) FindDynamicParameterQueryHandler {
-	return FindDynamicParameterQueryHandler{ulidProvider: ulidProvider, retriever: retriever}
+	return FindDynamicParameterQueryHandler{ulidProvider: ulidProvider, retriever: retriever, schedule: schedule}
 }
 
 func (fd FindDynamicParameterQueryHandler) Handle(ctx context.Context, dto bus.Dto) (interface{}, error) {
@@ -40,5 +42,11 @@ func (fd FindDynamicParameterQueryHandler) Handle(ctx context.Context, dto bus.D
 		return nil, err
 	}
 
-	return NewDynamicParameterFromParameter(fd.ulidProvider.New().String(), parameter), nil
+	transitions, err := fd.schedule.SearchByParameter(ctx, parameter.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDynamicParameterFromParameter(fd.ulidProvider.New().String(), parameter).
+		WithPendingTransitions(transitions), nil
 }
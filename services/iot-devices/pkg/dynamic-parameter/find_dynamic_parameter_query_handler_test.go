@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,11 +16,13 @@ import (
 func TestFindDynamicParameter(t *testing.T) {
 	rootCtx := context.Background()
 	repository := new(DynamicParameterRepositoryMock)
+	schedule := new(DynamicParameterScheduleRepositoryMock)
 	parameters := map[string]interface{}{"test_flag": true}
 	ulidProvider := utils.NewFixedUlidProvider()
 	handler := dynamic_parameter.NewFindDynamicParameterQueryHandler(
 		ulidProvider,
 		dynamic_parameter.NewDynamicParameterRetriever(repository, parameters),
+		schedule,
 	)
 
 	t.Run("Find dynamic parameter without errors", func(t *testing.T) {
@@ -27,49 +30,79 @@ func TestFindDynamicParameter(t *testing.T) {
 
 		value := parameters[query.Name]
 		parameterResponse := &dynamic_parameter.DynamicParameterResponse{
-			ID:           ulidProvider.New().String(),
-			Name:         query.Name,
-			DefaultValue: value,
-			DynamicValue: &value,
+			ID:                 ulidProvider.New().String(),
+			Name:               query.Name,
+			DefaultValue:       value,
+			DynamicValue:       &value,
+			PendingTransitions: []dynamic_parameter.DynamicParameterTransitionResponse{},
 		}
 
 		repository.ShouldSearchDynamicParameter(rootCtx, dynamic_parameter.ParameterName(query.Name), &value)
+		schedule.ShouldSearchByParameter(rootCtx, dynamic_parameter.ParameterName(query.Name), []dynamic_parameter.DynamicParameterTransition{})
 		response, err := handler.Handle(rootCtx, query)
 
 		assert.NoError(t, err)
 		assert.Equal(t, response, parameterResponse)
 
-		mock.AssertExpectationsForObjects(t, repository)
+		mock.AssertExpectationsForObjects(t, repository, schedule)
 	})
 
 	t.Run("Find dynamic when dynamic values is not stored", func(t *testing.T) {
 		query := &dynamic_parameter.FindDynamicParameterQuery{Name: "test_flag"}
 
 		parameterResponse := &dynamic_parameter.DynamicParameterResponse{
-			ID:           ulidProvider.New().String(),
-			Name:         query.Name,
-			DefaultValue: parameters[query.Name],
-			DynamicValue: nil,
+			ID:                 ulidProvider.New().String(),
+			Name:               query.Name,
+			DefaultValue:       parameters[query.Name],
+			DynamicValue:       nil,
+			PendingTransitions: []dynamic_parameter.DynamicParameterTransitionResponse{},
 		}
 
 		repository.ShouldSearchDynamicParameter(rootCtx, dynamic_parameter.ParameterName(query.Name), nil)
+		schedule.ShouldSearchByParameter(rootCtx, dynamic_parameter.ParameterName(query.Name), []dynamic_parameter.DynamicParameterTransition{})
 		response, err := handler.Handle(rootCtx, query)
 
 		assert.NoError(t, err)
 		assert.Equal(t, response, parameterResponse)
 
-		mock.AssertExpectationsForObjects(t, repository)
+		mock.AssertExpectationsForObjects(t, repository, schedule)
+	})
+
+	t.Run("Find dynamic parameter with pending transitions", func(t *testing.T) {
+		query := &dynamic_parameter.FindDynamicParameterQuery{Name: "test_flag"}
+		name := dynamic_parameter.ParameterName(query.Name)
+		effectiveFrom := time.Date(2024, 12, 31, 22, 0, 0, 0, time.UTC)
+		expiresAt := effectiveFrom.Add(24 * time.Hour)
+
+		repository.ShouldSearchDynamicParameter(rootCtx, name, nil)
+		schedule.ShouldSearchByParameter(rootCtx, name, []dynamic_parameter.DynamicParameterTransition{
+			dynamic_parameter.NewActivationTransition(ulidProvider.New().String(), name, false, effectiveFrom, &expiresAt),
+		})
+		response, err := handler.Handle(rootCtx, query)
+
+		assert.NoError(t, err)
+		expiresAtText := "2025-01-01T22:00:00Z"
+		assert.Equal(t, []dynamic_parameter.DynamicParameterTransitionResponse{{
+			Kind:      "activation",
+			Value:     false,
+			At:        "2024-12-31T22:00:00Z",
+			ExpiresAt: &expiresAtText,
+		}}, response.(*dynamic_parameter.DynamicParameterResponse).PendingTransitions)
+
+		mock.AssertExpectationsForObjects(t, repository, schedule)
 	})
 }
 
 func TestFindDynamicParameterFail(t *testing.T) {
 	rootCtx := context.Background()
 	repository := new(DynamicParameterRepositoryMock)
+	schedule := new(DynamicParameterScheduleRepositoryMock)
 	parameters := map[string]interface{}{"test_flag": true}
 	ulidProvider := utils.NewFixedUlidProvider()
 	handler := dynamic_parameter.NewFindDynamicParameterQueryHandler(
 		ulidProvider,
 		dynamic_parameter.NewDynamicParameterRetriever(repository, parameters),
+		schedule,
 	)
 
 	t.Run("Dynamic parameter is not mapped in configuration", func(t *testing.T) {
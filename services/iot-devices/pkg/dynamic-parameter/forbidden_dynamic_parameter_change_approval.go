@@ -0,0 +1,32 @@
+package dynamic_parameter
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const forbiddenChangeApprovalErrorMessage = "Dynamic parameter change must be approved by a different key owner"
+
+type ForbiddenDynamicParameterChangeApproval struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (fca ForbiddenDynamicParameterChangeApproval) Error() string {
+	return forbiddenChangeApprovalErrorMessage
+}
+
+func (fca ForbiddenDynamicParameterChangeApproval) ExtraItems() map[string]interface{} {
+	return fca.items
+}
+
+func NewForbiddenDynamicParameterChangeApproval(
+	name ParameterName,
+	id string,
+	approvedBy string,
+) *ForbiddenDynamicParameterChangeApproval {
+	return &ForbiddenDynamicParameterChangeApproval{items: map[string]interface{}{
+		"name":              name.Value(),
+		"change_request_id": id,
+		"approved_by":       approvedBy,
+	}}
+}
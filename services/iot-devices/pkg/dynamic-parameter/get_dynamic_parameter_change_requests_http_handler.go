@@ -0,0 +1,60 @@
+package dynamic_parameter
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+)
+
+func HandleGetDynamicParameterChangeRequests(bus query.Bus, responseMiddleware *json_api.JsonApiResponseMiddleware) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parameterName := mux.Vars(r)["parameterName"]
+		response, err := bus.Ask(r.Context(), &SearchDynamicParameterChangeRequestsQuery{Name: parameterName})
+
+		writeDynamicParameterQueryResponse(w, r, responseMiddleware, response, err)
+	}
+}
+
+func HandleGetDynamicParameterChangeRequest(bus query.Bus, responseMiddleware *json_api.JsonApiResponseMiddleware) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := bus.Ask(r.Context(), &FindDynamicParameterChangeRequestQuery{
+			Name: mux.Vars(r)["parameterName"],
+			ID:   mux.Vars(r)["changeRequestId"],
+		})
+
+		writeDynamicParameterQueryResponse(w, r, responseMiddleware, response, err)
+	}
+}
+
+func HandleGetDynamicParameterAuditLog(bus query.Bus, responseMiddleware *json_api.JsonApiResponseMiddleware) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parameterName := mux.Vars(r)["parameterName"]
+		response, err := bus.Ask(r.Context(), &SearchDynamicParameterAuditLogQuery{Name: parameterName})
+
+		writeDynamicParameterQueryResponse(w, r, responseMiddleware, response, err)
+	}
+}
+
+func writeDynamicParameterQueryResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	responseMiddleware *json_api.JsonApiResponseMiddleware,
+	response interface{},
+	err error,
+) {
+	switch err.(type) {
+	case nil:
+		ctx, writer := r.Context(), w
+		responseMiddleware.WriteResponse(ctx, writer, response, http.StatusOK)
+	case *DynamicParameterNotExists, *DynamicParameterChangeRequestNotExists:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewNotFound(err.Error())
+		responseMiddleware.WriteErrorResponse(ctx, writer, errResponse, http.StatusNotFound, err)
+	default:
+		ctx, writer, errResponse := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+		responseMiddleware.WriteErrorResponse(ctx, writer, errResponse, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,39 @@
+package dynamic_parameter
+
+import (
+	"context"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+const importDynamicParameterOverridesCmdName = "import_dynamic_parameter_overrides_command"
+
+type ImportDynamicParameterOverridesCommand struct {
+	Overrides  map[string]interface{}
+	ImportedBy string
+}
+
+func (ioc *ImportDynamicParameterOverridesCommand) Type() string {
+	return importDynamicParameterOverridesCmdName
+}
+
+type ImportDynamicParameterOverridesCommandHandler struct {
+	transfer *DynamicParameterOverridesTransfer
+}
+
+func NewImportDynamicParameterOverridesCommandHandler(
+	transfer *DynamicParameterOverridesTransfer,
+) ImportDynamicParameterOverridesCommandHandler {
+	return ImportDynamicParameterOverridesCommandHandler{transfer: transfer}
+}
+
+func (ih ImportDynamicParameterOverridesCommandHandler) Handle(ctx context.Context, command bus.Dto) error {
+	importCommand, ok := command.(*ImportDynamicParameterOverridesCommand)
+	if !ok {
+		return bus.NewInvalidDto("Invalid command")
+	}
+
+	_, err := ih.transfer.Import(ctx, NewDynamicParameterOverridesFromMap(importCommand.Overrides), importCommand.ImportedBy)
+
+	return err
+}
@@ -0,0 +1,64 @@
+package dynamic_parameter
+
+import (
+	"net/http"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/command"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus/query"
+	http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+)
+
+// HandleImportDynamicParameterOverrides answers with the changes the import makes.
+// With the dry_run query param set to true nothing is applied.
+func HandleImportDynamicParameterOverrides(
+	commandBus command.Bus,
+	queryBus query.Bus,
+	responseMiddleware *json_api.JsonApiResponseMiddleware,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requestParams, err := http_server.AllParamsRequest(r)
+		if err != nil {
+			ctx, writer, response := r.Context(), w, json_api_response.NewInternalServerError()
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusInternalServerError, err)
+			return
+		}
+
+		overrides, err := overridesFromDocument(requestParams)
+		if err != nil {
+			ctx, writer, response := r.Context(), w, json_api_response.NewBadRequest(err.Error())
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusBadRequest, err)
+			return
+		}
+
+		plan, err := queryBus.Ask(r.Context(), &PlanDynamicParameterOverridesImportQuery{Overrides: overrides})
+		if err == nil && r.URL.Query().Get("dry_run") != "true" {
+			importedBy, _ := http_server.StaticApiKeyOwnerFromContext(r.Context())
+			err = commandBus.Dispatch(r.Context(), &ImportDynamicParameterOverridesCommand{
+				Overrides:  overrides,
+				ImportedBy: importedBy,
+			})
+			if err == nil {
+				plan.(*DynamicParameterOverridesImportResponse).DryRun = false
+			}
+		}
+
+		switch err.(type) {
+		case nil:
+			ctx, writer := r.Context(), w
+			responseMiddleware.WriteResponse(ctx, writer, plan, http.StatusOK)
+			return
+		case *InvalidDynamicParameterOverrides:
+			ctx, writer, response := r.Context(), w, json_api_response.NewBadRequestForInvalidPayloadWithDetails(
+				metadataItemsFrom(err.(*InvalidDynamicParameterOverrides).ExtraItems())...,
+			)
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusBadRequest, err)
+			return
+		default:
+			ctx, writer, response := r.Context(), w, json_api_response.NewInternalServerErrorWithDetails(err.Error())
+			responseMiddleware.WriteErrorResponse(ctx, writer, response, http.StatusInternalServerError, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package dynamic_parameter
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidParameterOverridesErrorMessage = "Invalid dynamic parameter overrides"
+
+// InvalidDynamicParameterOverrides holds the reason every rejected parameter was rejected for.
+type InvalidDynamicParameterOverrides struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ipo InvalidDynamicParameterOverrides) Error() string {
+	return invalidParameterOverridesErrorMessage
+}
+
+func (ipo InvalidDynamicParameterOverrides) ExtraItems() map[string]interface{} {
+	return ipo.items
+}
+
+func NewInvalidDynamicParameterOverrides(reasons map[string]interface{}) *InvalidDynamicParameterOverrides {
+	return &InvalidDynamicParameterOverrides{items: reasons}
+}
@@ -0,0 +1,27 @@
+package dynamic_parameter
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidParameterScheduleErrorMessage = "Invalid dynamic parameter schedule"
+
+type InvalidDynamicParameterSchedule struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ips InvalidDynamicParameterSchedule) Error() string {
+	return invalidParameterScheduleErrorMessage
+}
+
+func (ips InvalidDynamicParameterSchedule) ExtraItems() map[string]interface{} {
+	return ips.items
+}
+
+func NewInvalidDynamicParameterSchedule(name ParameterName, reason string) *InvalidDynamicParameterSchedule {
+	return &InvalidDynamicParameterSchedule{items: map[string]interface{}{
+		"name":   name.Value(),
+		"reason": reason,
+	}}
+}
@@ -1,6 +1,8 @@
 package dynamic_parameter
 
 import (
+	"fmt"
+
 	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
 )
 
@@ -19,9 +21,29 @@ func (ipt InvalidDynamicParameterType) ExtraItems() map[string]interface{} {
 	return ipt.items
 }
 
+func (ipt InvalidDynamicParameterType) Unwrap() error {
+	return ipt.Previous()
+}
+
 func NewInvalidDynamicParameterType(name ParameterName, toType string) *InvalidDynamicParameterType {
 	return &InvalidDynamicParameterType{items: map[string]interface{}{
 		"name":          name.Value(),
 		"expected_type": toType,
 	}}
 }
+
+func NewInvalidDynamicParameterTypeFromValue(
+	name ParameterName,
+	toType string,
+	value interface{},
+	previous error,
+) *InvalidDynamicParameterType {
+	return &InvalidDynamicParameterType{
+		items: map[string]interface{}{
+			"name":          name.Value(),
+			"expected_type": toType,
+			"actual_type":   fmt.Sprintf("%T", value),
+		},
+		RootDomainError: domain.NewDomainErrorWithPrevious(previous),
+	}
+}
@@ -0,0 +1,33 @@
+package dynamic_parameter
+
+import (
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+)
+
+const invalidParametersConfigErrorMessage = "Invalid dynamic parameters config"
+
+type InvalidDynamicParametersConfig struct {
+	items map[string]interface{}
+	domain.RootDomainError
+}
+
+func (ipc InvalidDynamicParametersConfig) Error() string {
+	return invalidParametersConfigErrorMessage
+}
+
+func (ipc InvalidDynamicParametersConfig) ExtraItems() map[string]interface{} {
+	return ipc.items
+}
+
+func NewInvalidDynamicParametersConfig(reason string) *InvalidDynamicParametersConfig {
+	return &InvalidDynamicParametersConfig{items: map[string]interface{}{
+		"reason": reason,
+	}}
+}
+
+func NewInvalidDynamicParametersConfigForParameter(name string, reason string) *InvalidDynamicParametersConfig {
+	return &InvalidDynamicParametersConfig{items: map[string]interface{}{
+		"name":   name,
+		"reason": reason,
+	}}
+}
@@ -0,0 +1,45 @@
+package dynamic_parameter
+
+import (
+	"context"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const planDynamicParameterOverridesImportQueryName = "plan_dynamic_parameter_overrides_import_query"
+
+// PlanDynamicParameterOverridesImportQuery is the dry run of an import.
+type PlanDynamicParameterOverridesImportQuery struct {
+	Overrides map[string]interface{}
+}
+
+func (pq PlanDynamicParameterOverridesImportQuery) Type() string {
+	return planDynamicParameterOverridesImportQueryName
+}
+
+type PlanDynamicParameterOverridesImportQueryHandler struct {
+	transfer     *DynamicParameterOverridesTransfer
+	ulidProvider utils.UlidProvider
+}
+
+func NewPlanDynamicParameterOverridesImportQueryHandler(
+	transfer *DynamicParameterOverridesTransfer,
+	ulidProvider utils.UlidProvider,
+) PlanDynamicParameterOverridesImportQueryHandler {
+	return PlanDynamicParameterOverridesImportQueryHandler{transfer: transfer, ulidProvider: ulidProvider}
+}
+
+func (ph PlanDynamicParameterOverridesImportQueryHandler) Handle(ctx context.Context, dto bus.Dto) (interface{}, error) {
+	query, ok := dto.(*PlanDynamicParameterOverridesImportQuery)
+	if !ok {
+		return nil, bus.NewInvalidDto("Invalid query")
+	}
+
+	changes, err := ph.transfer.Plan(ctx, NewDynamicParameterOverridesFromMap(query.Overrides))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDynamicParameterOverridesImportResponse(ph.ulidProvider.New().String(), true, changes), nil
+}
@@ -0,0 +1,93 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	auditLogPrefix     = "dynamic_parameter_audit:"
+	auditLogMaxEntries = 1000
+)
+
+type plainDynamicParameterAuditEntry struct {
+	ID              string      `json:"id"`
+	Name            string      `json:"name"`
+	Action          string      `json:"action"`
+	Actor           string      `json:"actor"`
+	Value           interface{} `json:"value"`
+	ChangeRequestID string      `json:"change_request_id,omitempty"`
+	At              time.Time   `json:"at"`
+}
+
+// RedisDynamicParameterAuditLog keeps the latest entries of every parameter in
+// a capped list, newest first.
+type RedisDynamicParameterAuditLog struct {
+	client *redis.Client
+}
+
+func NewRedisDynamicParameterAuditLog(client *redis.Client) *RedisDynamicParameterAuditLog {
+	return &RedisDynamicParameterAuditLog{client: client}
+}
+
+func (r *RedisDynamicParameterAuditLog) Record(ctx context.Context, entry DynamicParameterAuditEntry) error {
+	bytes, err := json.Marshal(plainDynamicParameterAuditEntry{
+		ID:              entry.ID,
+		Name:            entry.Name.Value(),
+		Action:          entry.Action.Value(),
+		Actor:           entry.Actor,
+		Value:           entry.Value,
+		ChangeRequestID: entry.ChangeRequestID,
+		At:              entry.At,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, r.key(entry.Name), bytes)
+		pipe.LTrim(ctx, r.key(entry.Name), 0, auditLogMaxEntries-1)
+		return nil
+	})
+
+	return err
+}
+
+func (r *RedisDynamicParameterAuditLog) SearchByParameter(
+	ctx context.Context,
+	name ParameterName,
+	limit int,
+) ([]DynamicParameterAuditEntry, error) {
+	values, err := r.client.LRange(ctx, r.key(name), 0, int64(limit-1)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	entries := make([]DynamicParameterAuditEntry, 0, len(values))
+	for _, value := range values {
+		var plain plainDynamicParameterAuditEntry
+		if err := json.Unmarshal([]byte(value), &plain); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, DynamicParameterAuditEntry{
+			ID:              plain.ID,
+			Name:            ParameterName(plain.Name),
+			Action:          AuditAction(plain.Action),
+			Actor:           plain.Actor,
+			Value:           plain.Value,
+			ChangeRequestID: plain.ChangeRequestID,
+			At:              plain.At,
+		})
+	}
+
+	return entries, nil
+}
+
+func (r *RedisDynamicParameterAuditLog) key(name ParameterName) string {
+	return auditLogPrefix + name.Value()
+}
@@ -0,0 +1,177 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	changeRequestPrefix       = "dynamic_parameter_change_request:"
+	changeRequestRequestsKey  = changeRequestPrefix + "requests"
+	changeRequestPendingKey   = changeRequestPrefix + "pending"
+	changeRequestParameterKey = changeRequestPrefix + "parameter:"
+)
+
+type plainDynamicParameterChangeRequest struct {
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`
+	Value         interface{} `json:"value"`
+	EffectiveFrom *time.Time  `json:"effective_from,omitempty"`
+	ExpiresAt     *time.Time  `json:"expires_at,omitempty"`
+	Status        string      `json:"status"`
+	RequestedBy   string      `json:"requested_by"`
+	RequestedAt   time.Time   `json:"requested_at"`
+	PendingUntil  time.Time   `json:"pending_until"`
+	DecidedBy     string      `json:"decided_by,omitempty"`
+	DecidedAt     *time.Time  `json:"decided_at,omitempty"`
+}
+
+// RedisDynamicParameterChangeRequestRepository keeps change requests in a hash
+// indexed by a set of pending ids and a set of ids per parameter.
+type RedisDynamicParameterChangeRequestRepository struct {
+	client *redis.Client
+}
+
+func NewRedisDynamicParameterChangeRequestRepository(client *redis.Client) *RedisDynamicParameterChangeRequestRepository {
+	return &RedisDynamicParameterChangeRequestRepository{client: client}
+}
+
+func (r *RedisDynamicParameterChangeRequestRepository) Save(ctx context.Context, request DynamicParameterChangeRequest) error {
+	bytes, err := r.encode(request)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, changeRequestRequestsKey, request.ID, bytes)
+		pipe.SAdd(ctx, r.parameterKey(request.Name), request.ID)
+
+		if request.Status == PendingChangeRequest {
+			pipe.SAdd(ctx, changeRequestPendingKey, request.ID)
+		} else {
+			pipe.SRem(ctx, changeRequestPendingKey, request.ID)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *RedisDynamicParameterChangeRequestRepository) Find(ctx context.Context, id string) (*DynamicParameterChangeRequest, error) {
+	raw, err := r.client.HGet(ctx, changeRequestRequestsKey, id).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := r.decode([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func (r *RedisDynamicParameterChangeRequestRepository) SearchByParameter(
+	ctx context.Context,
+	name ParameterName,
+) ([]DynamicParameterChangeRequest, error) {
+	ids, err := r.client.SMembers(ctx, r.parameterKey(name)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	return r.searchByIds(ctx, ids)
+}
+
+func (r *RedisDynamicParameterChangeRequestRepository) SearchPending(ctx context.Context) ([]DynamicParameterChangeRequest, error) {
+	ids, err := r.client.SMembers(ctx, changeRequestPendingKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	return r.searchByIds(ctx, ids)
+}
+
+func (r *RedisDynamicParameterChangeRequestRepository) searchByIds(
+	ctx context.Context,
+	ids []string,
+) ([]DynamicParameterChangeRequest, error) {
+	requests := make([]DynamicParameterChangeRequest, 0, len(ids))
+	if len(ids) == 0 {
+		return requests, nil
+	}
+
+	values, err := r.client.HMGet(ctx, changeRequestRequestsKey, ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		request, err := r.decode([]byte(raw))
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].RequestedAt.After(requests[j].RequestedAt)
+	})
+
+	return requests, nil
+}
+
+func (r *RedisDynamicParameterChangeRequestRepository) encode(request DynamicParameterChangeRequest) ([]byte, error) {
+	return json.Marshal(plainDynamicParameterChangeRequest{
+		ID:            request.ID,
+		Name:          request.Name.Value(),
+		Value:         request.Value,
+		EffectiveFrom: request.EffectiveFrom,
+		ExpiresAt:     request.ExpiresAt,
+		Status:        request.Status.Value(),
+		RequestedBy:   request.RequestedBy,
+		RequestedAt:   request.RequestedAt,
+		PendingUntil:  request.PendingUntil,
+		DecidedBy:     request.DecidedBy,
+		DecidedAt:     request.DecidedAt,
+	})
+}
+
+func (r *RedisDynamicParameterChangeRequestRepository) decode(raw []byte) (DynamicParameterChangeRequest, error) {
+	var plain plainDynamicParameterChangeRequest
+	if err := json.Unmarshal(raw, &plain); err != nil {
+		return DynamicParameterChangeRequest{}, err
+	}
+
+	return DynamicParameterChangeRequest{
+		ID:            plain.ID,
+		Name:          ParameterName(plain.Name),
+		Value:         plain.Value,
+		EffectiveFrom: plain.EffectiveFrom,
+		ExpiresAt:     plain.ExpiresAt,
+		Status:        ChangeRequestStatus(plain.Status),
+		RequestedBy:   plain.RequestedBy,
+		RequestedAt:   plain.RequestedAt,
+		PendingUntil:  plain.PendingUntil,
+		DecidedBy:     plain.DecidedBy,
+		DecidedAt:     plain.DecidedAt,
+	}, nil
+}
+
+func (r *RedisDynamicParameterChangeRequestRepository) parameterKey(name ParameterName) string {
+	return changeRequestParameterKey + name.Value()
+}
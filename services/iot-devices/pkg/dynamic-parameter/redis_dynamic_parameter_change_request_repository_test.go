@@ -0,0 +1,114 @@
+package dynamic_parameter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+)
+
+type RedisDynamicParameterChangeRequestRepositoryTestSuite struct {
+	suite.Suite
+	redisClient *redis.Client
+	miniRedis   *miniredis.Miniredis
+	repository  *dynamic_parameter.RedisDynamicParameterChangeRequestRepository
+	auditLog    *dynamic_parameter.RedisDynamicParameterAuditLog
+	ctx         context.Context
+	now         time.Time
+}
+
+func (suite *RedisDynamicParameterChangeRequestRepositoryTestSuite) SetupSuite() {
+	miniRedis, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.miniRedis = miniRedis
+
+	suite.redisClient = redis.NewClient(&redis.Options{
+		Addr: miniRedis.Addr(),
+	})
+	suite.repository = dynamic_parameter.NewRedisDynamicParameterChangeRequestRepository(suite.redisClient)
+	suite.auditLog = dynamic_parameter.NewRedisDynamicParameterAuditLog(suite.redisClient)
+}
+
+func (suite *RedisDynamicParameterChangeRequestRepositoryTestSuite) TearDownSuite() {
+	suite.miniRedis.Close()
+	_ = suite.redisClient.Close()
+}
+
+func (suite *RedisDynamicParameterChangeRequestRepositoryTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.now = time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	suite.miniRedis.FlushAll()
+}
+
+func (suite *RedisDynamicParameterChangeRequestRepositoryTestSuite) TestDecidedRequestsLeaveThePendingIndex() {
+	name := dynamic_parameter.ParameterName("reporting_rate")
+	expiresAt := suite.now.Add(24 * time.Hour)
+	request := dynamic_parameter.NewDynamicParameterChangeRequest(
+		"change-request-id",
+		dynamic_parameter.DynamicParameterChange{Name: name, Value: float64(10), ExpiresAt: &expiresAt},
+		"alice@example.com",
+		suite.now,
+		suite.now.Add(time.Hour),
+	)
+
+	suite.NoError(suite.repository.Save(suite.ctx, request))
+
+	pending, err := suite.repository.SearchPending(suite.ctx)
+	suite.NoError(err)
+	suite.Len(pending, 1)
+
+	approved, err := request.Approve("bob@example.com", suite.now)
+	suite.NoError(err)
+	suite.NoError(suite.repository.Save(suite.ctx, approved))
+
+	pending, err = suite.repository.SearchPending(suite.ctx)
+	suite.NoError(err)
+	suite.Empty(pending)
+
+	found, err := suite.repository.Find(suite.ctx, request.ID)
+	suite.NoError(err)
+	suite.Equal(dynamic_parameter.ApprovedChangeRequest, found.Status)
+	suite.Equal("bob@example.com", found.DecidedBy)
+	suite.True(expiresAt.Equal(*found.ExpiresAt))
+
+	byParameter, err := suite.repository.SearchByParameter(suite.ctx, name)
+	suite.NoError(err)
+	suite.Len(byParameter, 1)
+}
+
+func (suite *RedisDynamicParameterChangeRequestRepositoryTestSuite) TestFindMissingRequest() {
+	found, err := suite.repository.Find(suite.ctx, "missing")
+	suite.NoError(err)
+	suite.Nil(found)
+}
+
+func (suite *RedisDynamicParameterChangeRequestRepositoryTestSuite) TestAuditLogReturnsLatestEntriesFirst() {
+	name := dynamic_parameter.ParameterName("reporting_rate")
+
+	suite.NoError(suite.auditLog.Record(suite.ctx, dynamic_parameter.NewDynamicParameterAuditEntry(
+		"first", name, dynamic_parameter.ChangeRequestedAuditAction, "alice@example.com", float64(10), "change-request-id", suite.now,
+	)))
+	suite.NoError(suite.auditLog.Record(suite.ctx, dynamic_parameter.NewDynamicParameterAuditEntry(
+		"second", name, dynamic_parameter.ChangeApprovedAuditAction, "bob@example.com", float64(10), "change-request-id", suite.now,
+	)))
+
+	entries, err := suite.auditLog.SearchByParameter(suite.ctx, name, 10)
+	suite.NoError(err)
+	suite.Len(entries, 2)
+	suite.Equal("second", entries[0].ID)
+	suite.Equal(dynamic_parameter.ChangeApprovedAuditAction, entries[0].Action)
+	suite.Equal("bob@example.com", entries[0].Actor)
+
+	latest, err := suite.auditLog.SearchByParameter(suite.ctx, name, 1)
+	suite.NoError(err)
+	suite.Len(latest, 1)
+}
+
+func TestDynamicParameterChangeRequestRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisDynamicParameterChangeRequestRepositoryTestSuite))
+}
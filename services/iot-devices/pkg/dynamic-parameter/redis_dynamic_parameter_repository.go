@@ -4,11 +4,52 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/redis/go-redis/v9"
 )
 
-const prefix = "dynamic_parameter:"
+const (
+	prefix                      = "dynamic_parameter:"
+	maxReplaceOverridesAttempts = 3
+	overrideCreatedMeanwhile    = "override created meanwhile"
+)
+
+// replaceOverridesScript leaves the overrides given as pairs of key and value in ARGV,
+// after the pattern of the override keys, removing the others. KEYS are the overrides
+// the transaction read, and any other one found means it was created after reading them.
+var replaceOverridesScript = redis.NewScript(`
+local read = {}
+for _, key in ipairs(KEYS) do
+	read[key] = true
+end
+
+local cursor = "0"
+repeat
+	local result = redis.call("SCAN", cursor, "MATCH", ARGV[1], "COUNT", 100)
+	cursor = result[1]
+	for _, key in ipairs(result[2]) do
+		if not read[key] then
+			return redis.error_reply("` + overrideCreatedMeanwhile + `")
+		end
+	end
+until cursor == "0"
+
+local kept = {}
+for i = 2, #ARGV, 2 do
+	kept[ARGV[i]] = true
+end
+for _, key in ipairs(KEYS) do
+	if not kept[key] then
+		redis.call("DEL", key)
+	end
+end
+for i = 2, #ARGV, 2 do
+	redis.call("SET", ARGV[i], ARGV[i + 1])
+end
+
+return "OK"
+`)
 
 type RedisDynamicParameterRepository struct {
 	client *redis.Client
@@ -18,7 +59,12 @@ func NewRedisDynamicParameterRepository(client *redis.Client) *RedisDynamicParam
 	return &RedisDynamicParameterRepository{client: client}
 }
 
+// Save stores the dynamic value of the parameter, or removes its override when there is no dynamic value.
 func (r *RedisDynamicParameterRepository) Save(ctx context.Context, parameter DynamicParameter) error {
+	if parameter.DynamicValue == nil {
+		return r.client.Del(ctx, r.key(parameter.Name.Value())).Err()
+	}
+
 	bytes, err := json.Marshal(&parameter.DynamicValue)
 	if err != nil {
 		return err
@@ -46,6 +92,122 @@ func (r *RedisDynamicParameterRepository) Search(ctx context.Context, name Param
 	return plainValue, nil
 }
 
+func (r *RedisDynamicParameterRepository) SearchOverrides(ctx context.Context) (DynamicParameterOverrides, error) {
+	keys, err := scanOverrideKeys(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return readOverrides(ctx, r.client, keys)
+}
+
+func readOverrides(ctx context.Context, client redis.Cmdable, keys []string) (DynamicParameterOverrides, error) {
+	overrides := make(DynamicParameterOverrides, len(keys))
+	if len(keys) == 0 {
+		return overrides, nil
+	}
+
+	values, err := client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var plainValue interface{}
+		if err := json.Unmarshal([]byte(raw), &plainValue); err != nil {
+			return nil, err
+		}
+		// A null value is no override, like the ones saved before expiries removed the key
+		if plainValue == nil {
+			continue
+		}
+		overrides[ParameterName(strings.TrimPrefix(keys[i], prefix))] = plainValue
+	}
+
+	return overrides, nil
+}
+
+func (r *RedisDynamicParameterRepository) ReplaceOverrides(
+	ctx context.Context,
+	overrides DynamicParameterOverrides,
+	check func(current DynamicParameterOverrides) error,
+) error {
+	encoded := make([]interface{}, 0, 2*len(overrides)+1)
+	encoded = append(encoded, prefix+"*")
+	for name, value := range overrides {
+		bytes, err := json.Marshal(&value)
+		if err != nil {
+			return err
+		}
+		encoded = append(encoded, r.key(name.Value()), bytes)
+	}
+
+	for attempt := 0; attempt < maxReplaceOverridesAttempts; attempt++ {
+		err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+			return r.replaceOverrides(ctx, tx, encoded, check)
+		})
+		if !errors.Is(err, redis.TxFailedErr) && !isOverrideCreatedMeanwhile(err) {
+			return err
+		}
+	}
+
+	return redis.TxFailedErr
+}
+
+// replaceOverrides reads and watches the current overrides within the transaction, so
+// check sees the ones it replaces, and the transaction fails if any of them changes
+// before it is applied. The script fails it too on the overrides created after the scan.
+func (r *RedisDynamicParameterRepository) replaceOverrides(
+	ctx context.Context,
+	tx *redis.Tx,
+	encoded []interface{},
+	check func(current DynamicParameterOverrides) error,
+) error {
+	currentKeys, err := scanOverrideKeys(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if len(currentKeys) > 0 {
+		if err := tx.Watch(ctx, currentKeys...).Err(); err != nil {
+			return err
+		}
+	}
+
+	current, err := readOverrides(ctx, tx, currentKeys)
+	if err != nil {
+		return err
+	}
+	if err := check(current); err != nil {
+		return err
+	}
+
+	_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		replaceOverridesScript.Eval(ctx, pipe, currentKeys, encoded...)
+		return nil
+	})
+
+	return err
+}
+
+func isOverrideCreatedMeanwhile(err error) bool {
+	return err != nil && strings.Contains(err.Error(), overrideCreatedMeanwhile)
+}
+
+func scanOverrideKeys(ctx context.Context, client redis.Cmdable) ([]string, error) {
+	keys := make([]string, 0)
+	iterator := client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	for iterator.Next(ctx) {
+		keys = append(keys, iterator.Val())
+	}
+
+	return keys, iterator.Err()
+}
+
 func (r *RedisDynamicParameterRepository) key(value string) string {
 	return prefix + value
 }
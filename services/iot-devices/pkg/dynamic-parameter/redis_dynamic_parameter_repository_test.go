@@ -2,6 +2,7 @@ package dynamic_parameter_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/alicebob/miniredis/v2"
@@ -43,6 +44,7 @@ func (suite *RedisDynamicParameterRepositoryTestSuite) TearDownSuite() {
 
 func (suite *RedisDynamicParameterRepositoryTestSuite) SetupTest() {
 	suite.ctx = context.Background()
+	suite.miniRedis.FlushAll()
 
 	// Set a redis feature flags that will be checked during the tests
 	_ = suite.miniRedis.Set("dynamic_parameter:fake_boolean_flag", "1")
@@ -89,6 +91,55 @@ func (suite *RedisDynamicParameterRepositoryTestSuite) TestSaveDynamicParameterW
 	suite.Equal("true", value)
 }
 
+func (suite *RedisDynamicParameterRepositoryTestSuite) TestSaveDynamicParameterWithoutDynamicValueRemovesTheOverride() {
+	repository := dynamic_parameter.NewRedisDynamicParameterRepository(suite.redisClient)
+
+	err := repository.Save(suite.ctx, dynamic_parameter.DynamicParameter{
+		Name:         dynamic_parameter.ParameterName("fake_boolean_flag"),
+		DefaultValue: false,
+		DynamicValue: nil,
+	})
+
+	suite.NoError(err)
+
+	keys := suite.redisClient.Keys(suite.ctx, "dynamic_parameter:fake_boolean_flag").Val()
+	suite.Equal(0, len(keys))
+}
+
+func (suite *RedisDynamicParameterRepositoryTestSuite) TestReplaceOverridesRemovesTheOnesCreatedWhileChecking() {
+	repository := dynamic_parameter.NewRedisDynamicParameterRepository(suite.redisClient)
+	seen := []dynamic_parameter.DynamicParameterOverrides{}
+
+	err := repository.ReplaceOverrides(suite.ctx, dynamic_parameter.DynamicParameterOverrides{"reporting_rate": float64(15)}, func(current dynamic_parameter.DynamicParameterOverrides) error {
+		seen = append(seen, current)
+		if len(seen) == 1 {
+			// Created after the overrides were read, so the replacement has to start over
+			suite.NoError(suite.miniRedis.Set("dynamic_parameter:created_meanwhile", "true"))
+		}
+		return nil
+	})
+
+	suite.NoError(err)
+	suite.Len(seen, 2)
+	suite.Contains(seen[1], dynamic_parameter.ParameterName("created_meanwhile"))
+	overrides, err := repository.SearchOverrides(suite.ctx)
+	suite.NoError(err)
+	suite.Equal(dynamic_parameter.DynamicParameterOverrides{"reporting_rate": float64(15)}, overrides)
+}
+
+func (suite *RedisDynamicParameterRepositoryTestSuite) TestReplaceOverridesWritesNothingWhenTheCheckFails() {
+	repository := dynamic_parameter.NewRedisDynamicParameterRepository(suite.redisClient)
+	refused := errors.New("refused")
+
+	err := repository.ReplaceOverrides(suite.ctx, dynamic_parameter.DynamicParameterOverrides{"reporting_rate": float64(15)}, func(dynamic_parameter.DynamicParameterOverrides) error {
+		return refused
+	})
+
+	suite.ErrorIs(err, refused)
+	suite.True(suite.miniRedis.Exists("dynamic_parameter:fake_boolean_flag"))
+	suite.False(suite.miniRedis.Exists("dynamic_parameter:reporting_rate"))
+}
+
 func TestDynamicParameterRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(RedisDynamicParameterRepositoryTestSuite))
 }
@@ -0,0 +1,211 @@
+package dynamic_parameter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	schedulePrefix         = "dynamic_parameter_schedule:"
+	scheduleDueKey         = schedulePrefix + "due"
+	scheduleTransitionsKey = schedulePrefix + "transitions"
+	scheduleParameterKey   = schedulePrefix + "parameter:"
+)
+
+type plainDynamicParameterTransition struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Kind      string      `json:"kind"`
+	Value     interface{} `json:"value"`
+	At        time.Time   `json:"at"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+}
+
+// RedisDynamicParameterScheduleRepository keeps transitions in a hash indexed
+// by a sorted set on due time, plus a set of transition ids per parameter.
+type RedisDynamicParameterScheduleRepository struct {
+	client *redis.Client
+}
+
+func NewRedisDynamicParameterScheduleRepository(client *redis.Client) *RedisDynamicParameterScheduleRepository {
+	return &RedisDynamicParameterScheduleRepository{client: client}
+}
+
+func (r *RedisDynamicParameterScheduleRepository) ReplaceForParameter(
+	ctx context.Context,
+	name ParameterName,
+	transitions []DynamicParameterTransition,
+) error {
+	previousIds, err := r.client.SMembers(ctx, r.parameterKey(name)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	encoded := make(map[string][]byte, len(transitions))
+	for _, transition := range transitions {
+		bytes, err := r.encode(transition)
+		if err != nil {
+			return err
+		}
+		encoded[transition.ID] = bytes
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(previousIds) > 0 {
+			pipe.ZRem(ctx, scheduleDueKey, stringsToInterfaces(previousIds)...)
+			pipe.HDel(ctx, scheduleTransitionsKey, previousIds...)
+			pipe.Del(ctx, r.parameterKey(name))
+		}
+
+		for _, transition := range transitions {
+			r.queueSave(ctx, pipe, transition, encoded[transition.ID])
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *RedisDynamicParameterScheduleRepository) Save(ctx context.Context, transition DynamicParameterTransition) error {
+	bytes, err := r.encode(transition)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		r.queueSave(ctx, pipe, transition, bytes)
+		return nil
+	})
+
+	return err
+}
+
+func (r *RedisDynamicParameterScheduleRepository) Delete(ctx context.Context, transition DynamicParameterTransition) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, scheduleDueKey, transition.ID)
+		pipe.HDel(ctx, scheduleTransitionsKey, transition.ID)
+		pipe.SRem(ctx, r.parameterKey(transition.Name), transition.ID)
+		return nil
+	})
+
+	return err
+}
+
+func (r *RedisDynamicParameterScheduleRepository) SearchByParameter(
+	ctx context.Context,
+	name ParameterName,
+) ([]DynamicParameterTransition, error) {
+	ids, err := r.client.SMembers(ctx, r.parameterKey(name)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	return r.searchByIds(ctx, ids)
+}
+
+func (r *RedisDynamicParameterScheduleRepository) SearchDue(
+	ctx context.Context,
+	now time.Time,
+) ([]DynamicParameterTransition, error) {
+	ids, err := r.client.ZRangeByScore(ctx, scheduleDueKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	return r.searchByIds(ctx, ids)
+}
+
+func (r *RedisDynamicParameterScheduleRepository) searchByIds(
+	ctx context.Context,
+	ids []string,
+) ([]DynamicParameterTransition, error) {
+	transitions := make([]DynamicParameterTransition, 0, len(ids))
+	if len(ids) == 0 {
+		return transitions, nil
+	}
+
+	values, err := r.client.HMGet(ctx, scheduleTransitionsKey, ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		transition, err := r.decode([]byte(raw))
+		if err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, transition)
+	}
+
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].At.Before(transitions[j].At)
+	})
+
+	return transitions, nil
+}
+
+func (r *RedisDynamicParameterScheduleRepository) queueSave(
+	ctx context.Context,
+	pipe redis.Pipeliner,
+	transition DynamicParameterTransition,
+	encoded []byte,
+) {
+	pipe.HSet(ctx, scheduleTransitionsKey, transition.ID, encoded)
+	pipe.ZAdd(ctx, scheduleDueKey, redis.Z{Score: float64(transition.At.UnixMilli()), Member: transition.ID})
+	pipe.SAdd(ctx, r.parameterKey(transition.Name), transition.ID)
+}
+
+func (r *RedisDynamicParameterScheduleRepository) encode(transition DynamicParameterTransition) ([]byte, error) {
+	return json.Marshal(plainDynamicParameterTransition{
+		ID:        transition.ID,
+		Name:      transition.Name.Value(),
+		Kind:      transition.Kind.Value(),
+		Value:     transition.Value,
+		At:        transition.At,
+		ExpiresAt: transition.ExpiresAt,
+	})
+}
+
+func (r *RedisDynamicParameterScheduleRepository) decode(raw []byte) (DynamicParameterTransition, error) {
+	var plain plainDynamicParameterTransition
+	if err := json.Unmarshal(raw, &plain); err != nil {
+		return DynamicParameterTransition{}, err
+	}
+
+	return DynamicParameterTransition{
+		ID:        plain.ID,
+		Name:      ParameterName(plain.Name),
+		Kind:      TransitionKind(plain.Kind),
+		Value:     plain.Value,
+		At:        plain.At,
+		ExpiresAt: plain.ExpiresAt,
+	}, nil
+}
+
+func (r *RedisDynamicParameterScheduleRepository) parameterKey(name ParameterName) string {
+	return scheduleParameterKey + name.Value()
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+
+	return result
+}
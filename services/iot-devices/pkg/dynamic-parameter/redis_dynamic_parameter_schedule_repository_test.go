@@ -0,0 +1,98 @@
+package dynamic_parameter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	dynamic_parameter "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/dynamic-parameter"
+)
+
+type RedisDynamicParameterScheduleRepositoryTestSuite struct {
+	suite.Suite
+	redisClient *redis.Client
+	miniRedis   *miniredis.Miniredis
+	repository  *dynamic_parameter.RedisDynamicParameterScheduleRepository
+	ctx         context.Context
+	now         time.Time
+}
+
+func (suite *RedisDynamicParameterScheduleRepositoryTestSuite) SetupSuite() {
+	miniRedis, err := miniredis.Run()
+	suite.Require().NoError(err)
+	suite.miniRedis = miniRedis
+
+	suite.redisClient = redis.NewClient(&redis.Options{
+		Addr: miniRedis.Addr(),
+	})
+	suite.repository = dynamic_parameter.NewRedisDynamicParameterScheduleRepository(suite.redisClient)
+}
+
+func (suite *RedisDynamicParameterScheduleRepositoryTestSuite) TearDownSuite() {
+	suite.miniRedis.Close()
+	_ = suite.redisClient.Close()
+}
+
+func (suite *RedisDynamicParameterScheduleRepositoryTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.now = time.Date(2025, 1, 10, 8, 0, 0, 0, time.UTC)
+	suite.miniRedis.FlushAll()
+}
+
+func (suite *RedisDynamicParameterScheduleRepositoryTestSuite) TestReplaceForParameterDropsPreviousTransitions() {
+	name := dynamic_parameter.ParameterName("reporting_rate")
+	expiresAt := suite.now.Add(time.Hour)
+
+	suite.NoError(suite.repository.ReplaceForParameter(suite.ctx, name, []dynamic_parameter.DynamicParameterTransition{
+		dynamic_parameter.NewActivationTransition("first", name, float64(10), suite.now, &expiresAt),
+	}))
+	suite.NoError(suite.repository.ReplaceForParameter(suite.ctx, name, []dynamic_parameter.DynamicParameterTransition{
+		dynamic_parameter.NewExpiryTransition("second", name, float64(30), expiresAt),
+	}))
+
+	transitions, err := suite.repository.SearchByParameter(suite.ctx, name)
+	suite.NoError(err)
+	suite.Len(transitions, 1)
+	suite.Equal("second", transitions[0].ID)
+	suite.Equal(dynamic_parameter.ExpiryTransition, transitions[0].Kind)
+	suite.Equal(float64(30), transitions[0].Value)
+	suite.True(expiresAt.Equal(transitions[0].At))
+}
+
+func (suite *RedisDynamicParameterScheduleRepositoryTestSuite) TestSearchDueOnlyReturnsTransitionsDue() {
+	name := dynamic_parameter.ParameterName("reporting_rate")
+	other := dynamic_parameter.ParameterName("ff_test_feature_flag")
+
+	suite.NoError(suite.repository.Save(suite.ctx, dynamic_parameter.NewExpiryTransition("due", name, nil, suite.now)))
+	suite.NoError(suite.repository.Save(suite.ctx, dynamic_parameter.NewExpiryTransition("later", other, true, suite.now.Add(time.Minute))))
+
+	transitions, err := suite.repository.SearchDue(suite.ctx, suite.now)
+	suite.NoError(err)
+	suite.Len(transitions, 1)
+	suite.Equal("due", transitions[0].ID)
+	suite.Nil(transitions[0].Value)
+}
+
+func (suite *RedisDynamicParameterScheduleRepositoryTestSuite) TestDeleteRemovesTransitionFromEveryIndex() {
+	name := dynamic_parameter.ParameterName("reporting_rate")
+	transition := dynamic_parameter.NewExpiryTransition("due", name, nil, suite.now)
+
+	suite.NoError(suite.repository.Save(suite.ctx, transition))
+	suite.NoError(suite.repository.Delete(suite.ctx, transition))
+
+	due, err := suite.repository.SearchDue(suite.ctx, suite.now)
+	suite.NoError(err)
+	suite.Empty(due)
+
+	byParameter, err := suite.repository.SearchByParameter(suite.ctx, name)
+	suite.NoError(err)
+	suite.Empty(byParameter)
+}
+
+func TestDynamicParameterScheduleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisDynamicParameterScheduleRepositoryTestSuite))
+}
@@ -0,0 +1,74 @@
+package dynamic_parameter
+
+import (
+	"context"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const rejectDynamicParameterChangeRequestCmdName = "reject_dynamic_parameter_change_request_command"
+
+type RejectDynamicParameterChangeRequestCommand struct {
+	Name       string
+	ID         string
+	RejectedBy string
+}
+
+func (rdc *RejectDynamicParameterChangeRequestCommand) Type() string {
+	return rejectDynamicParameterChangeRequestCmdName
+}
+
+type RejectDynamicParameterChangeRequestCommandHandler struct {
+	changeRequests DynamicParameterChangeRequestRepository
+	auditLog       DynamicParameterAuditLog
+	ulidProvider   utils.UlidProvider
+	timeProvider   utils.DateTimeProvider
+}
+
+func NewRejectDynamicParameterChangeRequestCommandHandler(
+	changeRequests DynamicParameterChangeRequestRepository,
+	auditLog DynamicParameterAuditLog,
+	ulidProvider utils.UlidProvider,
+	timeProvider utils.DateTimeProvider,
+) RejectDynamicParameterChangeRequestCommandHandler {
+	return RejectDynamicParameterChangeRequestCommandHandler{
+		changeRequests: changeRequests,
+		auditLog:       auditLog,
+		ulidProvider:   ulidProvider,
+		timeProvider:   timeProvider,
+	}
+}
+
+// Handle discards a pending change request. The requester may reject it too, to withdraw it.
+func (rh RejectDynamicParameterChangeRequestCommandHandler) Handle(ctx context.Context, command bus.Dto) error {
+	rejectCommand, ok := command.(*RejectDynamicParameterChangeRequestCommand)
+	if !ok {
+		return bus.NewInvalidDto("Invalid command")
+	}
+
+	request, err := findChangeRequest(ctx, rh.changeRequests, ParameterName(rejectCommand.Name), rejectCommand.ID)
+	if err != nil {
+		return err
+	}
+
+	now := rh.timeProvider.Now()
+	rejected, err := request.Reject(rejectCommand.RejectedBy, now)
+	if err != nil {
+		return err
+	}
+
+	if err := rh.changeRequests.Save(ctx, rejected); err != nil {
+		return err
+	}
+
+	return rh.auditLog.Record(ctx, NewDynamicParameterAuditEntry(
+		rh.ulidProvider.New().String(),
+		rejected.Name,
+		ChangeRejectedAuditAction,
+		rejectCommand.RejectedBy,
+		rejected.Value,
+		rejected.ID,
+		now,
+	))
+}
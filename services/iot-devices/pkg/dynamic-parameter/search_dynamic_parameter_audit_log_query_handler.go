@@ -0,0 +1,54 @@
+package dynamic_parameter
+
+import (
+	"context"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+)
+
+const searchDynamicParameterAuditLogQueryName = "search_dynamic_parameter_audit_log_query"
+
+type SearchDynamicParameterAuditLogQuery struct {
+	Name string
+}
+
+func (saq SearchDynamicParameterAuditLogQuery) Type() string {
+	return searchDynamicParameterAuditLogQueryName
+}
+
+type SearchDynamicParameterAuditLogQueryHandler struct {
+	retriever *DynamicParameterRetriever
+	auditLog  DynamicParameterAuditLog
+}
+
+func NewSearchDynamicParameterAuditLogQueryHandler(
+	retriever *DynamicParameterRetriever,
+	auditLog DynamicParameterAuditLog,
+) SearchDynamicParameterAuditLogQueryHandler {
+	return SearchDynamicParameterAuditLogQueryHandler{retriever: retriever, auditLog: auditLog}
+}
+
+// Handle returns the latest audit entries of the parameter, newest first.
+func (sh SearchDynamicParameterAuditLogQueryHandler) Handle(ctx context.Context, dto bus.Dto) (interface{}, error) {
+	query, ok := dto.(*SearchDynamicParameterAuditLogQuery)
+	if !ok {
+		return nil, bus.NewInvalidDto("Invalid query")
+	}
+
+	parameter, err := sh.retriever.Get(ctx, ParameterName(query.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := sh.auditLog.SearchByParameter(ctx, parameter.Name, defaultAuditLogSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*DynamicParameterAuditEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, NewDynamicParameterAuditEntryResponse(entry))
+	}
+
+	return responses, nil
+}
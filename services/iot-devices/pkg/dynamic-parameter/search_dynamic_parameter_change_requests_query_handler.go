@@ -0,0 +1,62 @@
+package dynamic_parameter
+
+import (
+	"context"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/bus"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+const searchDynamicParameterChangeRequestsQueryName = "search_dynamic_parameter_change_requests_query"
+
+type SearchDynamicParameterChangeRequestsQuery struct {
+	Name string
+}
+
+func (scq SearchDynamicParameterChangeRequestsQuery) Type() string {
+	return searchDynamicParameterChangeRequestsQueryName
+}
+
+type SearchDynamicParameterChangeRequestsQueryHandler struct {
+	retriever      *DynamicParameterRetriever
+	changeRequests DynamicParameterChangeRequestRepository
+	timeProvider   utils.DateTimeProvider
+}
+
+func NewSearchDynamicParameterChangeRequestsQueryHandler(
+	retriever *DynamicParameterRetriever,
+	changeRequests DynamicParameterChangeRequestRepository,
+	timeProvider utils.DateTimeProvider,
+) SearchDynamicParameterChangeRequestsQueryHandler {
+	return SearchDynamicParameterChangeRequestsQueryHandler{
+		retriever:      retriever,
+		changeRequests: changeRequests,
+		timeProvider:   timeProvider,
+	}
+}
+
+// Handle returns the change requests of the parameter, latest first.
+func (sh SearchDynamicParameterChangeRequestsQueryHandler) Handle(ctx context.Context, dto bus.Dto) (interface{}, error) {
+	query, ok := dto.(*SearchDynamicParameterChangeRequestsQuery)
+	if !ok {
+		return nil, bus.NewInvalidDto("Invalid query")
+	}
+
+	parameter, err := sh.retriever.Get(ctx, ParameterName(query.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := sh.changeRequests.SearchByParameter(ctx, parameter.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := sh.timeProvider.Now()
+	responses := make([]*DynamicParameterChangeRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, NewDynamicParameterChangeRequestResponse(request, now))
+	}
+
+	return responses, nil
+}
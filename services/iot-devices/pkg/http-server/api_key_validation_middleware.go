@@ -1,6 +1,7 @@
 package http_server
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 
@@ -15,6 +16,10 @@ const (
 	staticApiKeyUsageInformationMessage = "an static api key has been used"
 )
 
+type staticApiKeyOwner string
+
+const contextKeyStaticApiKeyOwner staticApiKeyOwner = "static_api_key_owner"
+
 type StaticApiKey struct {
 	Owner string
 	Key   string
@@ -72,7 +77,7 @@ func (kvm *ApiKeyValidationMiddleware) Middleware(next http.Handler) http.Handle
 		if apiKey := req.Header.Get(kvm.headerName); apiKey != "" {
 			if key, exists := kvm.keys.SearchByKey(apiKey); exists {
 				kvm.registerStaticApiKeyUsage(key, req)
-				next.ServeHTTP(w, req)
+				next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), contextKeyStaticApiKeyOwner, key.Owner)))
 				return
 			}
 		}
@@ -106,3 +111,10 @@ func (kvm *ApiKeyValidationMiddleware) registerStaticApiKeyUsage(
 		)
 	}
 }
+
+// StaticApiKeyOwnerFromContext returns the owner of the static api key that
+// authorized the request, if any.
+func StaticApiKeyOwnerFromContext(ctx context.Context) (string, bool) {
+	owner, ok := ctx.Value(contextKeyStaticApiKeyOwner).(string)
+	return owner, ok
+}
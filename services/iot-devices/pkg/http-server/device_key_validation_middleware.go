@@ -0,0 +1,70 @@
+package http_server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+)
+
+const (
+	HeaderDeviceKey = "X-Device-Key"
+
+	deviceIdPathVariable         = "deviceId"
+	invalidDeviceKeyErrorMessage = "invalid device key provided"
+)
+
+// DeviceKey derives the key of a device from the secret, so every device gets its own
+// without storing any, and the key of a device is of no use for another.
+func DeviceKey(secret string, deviceID string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(deviceID))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeviceKeyValidationMiddleware lets a device only reach the routes of the device in
+// their path, checking the key derived for it. Without secret no key is valid.
+type DeviceKeyValidationMiddleware struct {
+	secret string
+
+	responseMiddleware *json_api.JsonApiResponseMiddleware
+}
+
+func NewDeviceKeyValidationMiddleware(
+	responseMiddleware *json_api.JsonApiResponseMiddleware,
+	secret string,
+) *DeviceKeyValidationMiddleware {
+	return &DeviceKeyValidationMiddleware{secret: secret, responseMiddleware: responseMiddleware}
+}
+
+func (dkm *DeviceKeyValidationMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		deviceID := mux.Vars(req)[deviceIdPathVariable]
+		if dkm.secret == "" || deviceID == "" || !dkm.validKey(deviceID, req.Header.Get(HeaderDeviceKey)) {
+			err, code := json_api_response.NewUnauthorized(invalidDeviceKeyErrorMessage), http.StatusUnauthorized
+			dkm.responseMiddleware.WriteErrorResponse(req.Context(), w, err, code, nil)
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+func (dkm *DeviceKeyValidationMiddleware) validKey(deviceID string, key string) bool {
+	given, err := hex.DecodeString(key)
+	if err != nil {
+		return false
+	}
+	expected, err := hex.DecodeString(DeviceKey(dkm.secret, deviceID))
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(expected, given)
+}
@@ -0,0 +1,53 @@
+package http_server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+)
+
+func TestDeviceKeyValidationMiddleware(t *testing.T) {
+	responseMiddleware := amf_json_api.NewJsonApiResponseMiddleware(logger.NewNullLogger())
+	newRouter := func(secret string) *mux.Router {
+		router := mux.NewRouter()
+		router.Handle("/devices/{deviceId}/uplinks", amf_http_server.NewDeviceKeyValidationMiddleware(responseMiddleware, secret).Middleware(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		))
+		return router
+	}
+	request := func(router *mux.Router, deviceID string, key string) int {
+		req := httptest.NewRequest(http.MethodPost, "/devices/"+deviceID+"/uplinks", nil)
+		if key != "" {
+			req.Header.Set(amf_http_server.HeaderDeviceKey, key)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	router := newRouter("secret")
+
+	t.Run("should let the device in with its key", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, request(router, "trap-1", amf_http_server.DeviceKey("secret", "trap-1")))
+	})
+
+	t.Run("should refuse the key of another device", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, request(router, "trap-2", amf_http_server.DeviceKey("secret", "trap-1")))
+	})
+
+	t.Run("should refuse the requests without key or with a key of another secret", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, request(router, "trap-1", ""))
+		assert.Equal(t, http.StatusUnauthorized, request(router, "trap-1", amf_http_server.DeviceKey("other", "trap-1")))
+		assert.Equal(t, http.StatusUnauthorized, request(router, "trap-1", "not hex"))
+	})
+
+	t.Run("should refuse every key without secret", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, request(newRouter(""), "trap-1", amf_http_server.DeviceKey("", "trap-1")))
+	})
+}
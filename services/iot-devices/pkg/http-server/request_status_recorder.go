@@ -15,3 +15,9 @@ func (rsr *RequestStatusRecorder) WriteHeader(status int) {
 	rsr.Status = status
 	rsr.ResponseWriter.WriteHeader(status)
 }
+
+// Unwrap lets http.ResponseController reach the flushing and deadlines of the
+// underlying writer, which the streamed responses rely on.
+func (rsr *RequestStatusRecorder) Unwrap() http.ResponseWriter {
+	return rsr.ResponseWriter
+}
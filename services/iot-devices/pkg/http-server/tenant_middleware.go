@@ -0,0 +1,56 @@
+package http_server
+
+import (
+	"net/http"
+
+	json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	json_api_response "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api/response"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+const (
+	HeaderTenantIdentifier = "X-Tenant-Id"
+	HeaderTenantApiKey     = "X-Tenant-Api-Key"
+
+	tenantIdentifierMaxLength     = 50
+	invalidTenantProvidedErrorMsg = "invalid tenant provided"
+	tenantMismatchErrorMsg        = "tenant does not match the credential"
+)
+
+// TenantMiddleware scopes the request to the tenant owning the api key that authenticated
+// it, so it has to run after an ApiKeyValidationMiddleware whose keys are owned by the
+// tenants. Requests without tenant are refused, so they never reach the rows of any, and
+// so are those naming in the tenant header another tenant than the one of their key.
+type TenantMiddleware struct {
+	responseMiddleware *json_api.JsonApiResponseMiddleware
+}
+
+func NewTenantMiddleware(responseMiddleware *json_api.JsonApiResponseMiddleware) *TenantMiddleware {
+	return &TenantMiddleware{responseMiddleware: responseMiddleware}
+}
+
+func (tm *TenantMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		tenantID, _ := StaticApiKeyOwnerFromContext(req.Context())
+		if tenantID == "" || len(tenantID) > tenantIdentifierMaxLength {
+			err, code := json_api_response.NewUnauthorized(invalidTenantProvidedErrorMsg), http.StatusUnauthorized
+			tm.responseMiddleware.WriteErrorResponse(req.Context(), w, err, code, nil)
+			return
+		}
+		if requested := req.Header.Get(HeaderTenantIdentifier); requested != "" && requested != tenantID {
+			err, code := json_api_response.NewForbidden(tenantMismatchErrorMsg), http.StatusForbidden
+			tm.responseMiddleware.WriteErrorResponse(req.Context(), w, err, code, nil)
+			return
+		}
+
+		next.ServeHTTP(w, req.WithContext(tenancy.WithTenant(req.Context(), tenantID)))
+	})
+}
+
+// AllTenantsMiddleware lifts the tenant scope of the routes serving the system rather
+// than a tenant, like the healthcheck and the dynamic parameters.
+func AllTenantsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(w, req.WithContext(tenancy.WithAllTenants(req.Context())))
+	})
+}
@@ -0,0 +1,77 @@
+package http_server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	amf_http_server "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/http-server"
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+func TestTenantMiddleware(t *testing.T) {
+	responseMiddleware := amf_json_api.NewJsonApiResponseMiddleware(logger.NewNullLogger())
+	apiKeys := amf_http_server.NewApiKeyValidationMiddleware(
+		responseMiddleware,
+		amf_http_server.WithHeaderName(amf_http_server.HeaderTenantApiKey),
+		amf_http_server.WithKeysByOwner(amf_http_server.StaticApiKeysFromPipedString("tenant-1,key-1|tenant-2,key-2")...),
+	)
+
+	var scopedTo string
+	handler := apiKeys.Middleware(amf_http_server.NewTenantMiddleware(responseMiddleware).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopedTo, _ = tenancy.TenantFromContext(r.Context())
+		}),
+	))
+	request := func(headers map[string]string) int {
+		scopedTo = ""
+		req := httptest.NewRequest(http.MethodGet, "/customers", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	t.Run("should scope the request to the tenant owning the api key", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, request(map[string]string{amf_http_server.HeaderTenantApiKey: "key-1"}))
+		assert.Equal(t, "tenant-1", scopedTo)
+
+		assert.Equal(t, http.StatusOK, request(map[string]string{
+			amf_http_server.HeaderTenantApiKey:     "key-2",
+			amf_http_server.HeaderTenantIdentifier: "tenant-2",
+		}))
+		assert.Equal(t, "tenant-2", scopedTo)
+	})
+
+	t.Run("should refuse a tenant header naming another tenant than the one of the key", func(t *testing.T) {
+		code := request(map[string]string{
+			amf_http_server.HeaderTenantApiKey:     "key-1",
+			amf_http_server.HeaderTenantIdentifier: "tenant-2",
+		})
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Empty(t, scopedTo)
+	})
+
+	t.Run("should refuse a tenant header without api key", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, request(map[string]string{amf_http_server.HeaderTenantIdentifier: "tenant-1"}))
+		assert.Empty(t, scopedTo)
+	})
+
+	t.Run("should refuse the requests authenticated without tenant", func(t *testing.T) {
+		unauthenticated := amf_http_server.NewTenantMiddleware(responseMiddleware).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/customers", nil)
+		req.Header.Set(amf_http_server.HeaderTenantIdentifier, "tenant-1")
+
+		unauthenticated.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	})
+}
@@ -0,0 +1,106 @@
+package json_api
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/google/jsonapi"
+)
+
+// Document is a payload written along with the top level links and meta of the response,
+// which jsonapi.MarshalPayload leaves out, and trimmed to the sparse fieldsets asked for.
+type Document struct {
+	payload interface{}
+	links   jsonapi.Links
+	meta    jsonapi.Meta
+	fields  map[string]map[string]struct{}
+}
+
+func NewDocument(payload interface{}) *Document {
+	return &Document{payload: payload}
+}
+
+func (d *Document) WithLinks(links jsonapi.Links) *Document {
+	d.links = links
+	return d
+}
+
+func (d *Document) WithMeta(meta jsonapi.Meta) *Document {
+	d.meta = meta
+	return d
+}
+
+// WithSparseFieldsets keeps only the attributes listed in the fields[type] parameters of
+// the query for the resources of each type. Types not listed keep all their attributes.
+func (d *Document) WithSparseFieldsets(query url.Values) *Document {
+	d.fields = map[string]map[string]struct{}{}
+	for key, values := range query {
+		if !strings.HasPrefix(key, "fields[") || !strings.HasSuffix(key, "]") || len(values) == 0 {
+			continue
+		}
+
+		fields := map[string]struct{}{}
+		for _, field := range strings.Split(values[0], ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields[field] = struct{}{}
+			}
+		}
+		d.fields[key[len("fields["):len(key)-1]] = fields
+	}
+
+	return d
+}
+
+func (d *Document) marshal(w io.Writer) error {
+	payload, err := jsonapi.Marshal(d.payload)
+	if err != nil {
+		return err
+	}
+
+	var links *jsonapi.Links
+	if len(d.links) > 0 {
+		links = &d.links
+	}
+	var meta *jsonapi.Meta
+	if len(d.meta) > 0 {
+		meta = &d.meta
+	}
+
+	switch typedPayload := payload.(type) {
+	case *jsonapi.ManyPayload:
+		typedPayload.Links, typedPayload.Meta = links, meta
+		d.trim(typedPayload.Data...)
+		d.trim(typedPayload.Included...)
+	case *jsonapi.OnePayload:
+		typedPayload.Links, typedPayload.Meta = links, meta
+		d.trim(typedPayload.Data)
+		d.trim(typedPayload.Included...)
+	}
+
+	return json.NewEncoder(w).Encode(payload)
+}
+
+func (d *Document) trim(nodes ...*jsonapi.Node) {
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+
+		fields, ok := d.fields[node.Type]
+		if !ok {
+			continue
+		}
+		for attribute := range node.Attributes {
+			if _, kept := fields[attribute]; !kept {
+				delete(node.Attributes, attribute)
+			}
+		}
+		for relationship := range node.Relationships {
+			if _, kept := fields[relationship]; !kept {
+				delete(node.Relationships, relationship)
+			}
+		}
+	}
+}
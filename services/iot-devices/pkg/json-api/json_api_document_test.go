@@ -0,0 +1,62 @@
+package json_api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amf_json_api "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/json-api"
+	"github.com/AntonioMartinezFernandez/services/iot-devices/pkg/logger"
+)
+
+type documentTestResource struct {
+	ID    string `jsonapi:"primary,things"`
+	Name  string `jsonapi:"attr,name"`
+	Color string `jsonapi:"attr,color"`
+}
+
+func TestJsonApiDocument(t *testing.T) {
+	jarm := amf_json_api.NewJsonApiResponseMiddleware(logger.NewNullLogger())
+	resources := []*documentTestResource{{ID: "1", Name: "trap", Color: "red"}, {ID: "2", Name: "station", Color: "black"}}
+
+	t.Run("should write the top level links and meta along with the resources", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		document := amf_json_api.NewDocument(resources).
+			WithLinks(jsonapi.Links{"self": "/things", "next": "/things?page[cursor]=abc"}).
+			WithMeta(jsonapi.Meta{"count": 2})
+
+		jarm.WriteResponse(context.Background(), recorder, document, http.StatusOK)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, "/things?page[cursor]=abc", body["links"].(map[string]interface{})["next"])
+		assert.Equal(t, 2.0, body["meta"].(map[string]interface{})["count"])
+		assert.Len(t, body["data"], 2)
+		assert.NotContains(t, body, "errors")
+	})
+
+	t.Run("should keep only the attributes of the sparse fieldsets", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		document := amf_json_api.NewDocument(resources).WithSparseFieldsets(url.Values{
+			"fields[things]": {"name"},
+			"fields[others]": {"color"},
+		})
+
+		jarm.WriteResponse(context.Background(), recorder, document, http.StatusOK)
+
+		var body struct {
+			Data  []map[string]interface{} `json:"data"`
+			Links map[string]interface{}   `json:"links"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+		assert.Equal(t, map[string]interface{}{"name": "trap"}, body.Data[0]["attributes"])
+		assert.Nil(t, body.Links)
+	})
+}
@@ -35,6 +35,8 @@ func (jrm *JsonApiResponseMiddleware) WriteErrorResponse(
 	}
 }
 
+// WriteResponse marshals the payload as is, unless it is a Document, which adds its links,
+// meta and sparse fieldsets to it.
 func (jrm *JsonApiResponseMiddleware) WriteResponse(
 	ctx context.Context,
 	writer http.ResponseWriter,
@@ -48,6 +50,14 @@ func (jrm *JsonApiResponseMiddleware) WriteResponse(
 		return
 	}
 
+	if document, ok := payload.(*Document); ok {
+		if err := document.marshal(writer); err != nil {
+			jrm.logger.Error(ctx, "error marshalling json api document", logger.ErrValue("error", err))
+			writer.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
 	if err := jsonapi.MarshalPayload(writer, payload); err != nil {
 		jrm.logger.Error(ctx, "error marshalling json api response", logger.ErrValue("error", err))
 		writer.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,63 @@
+package object_storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+)
+
+func TestFilesystemObjectStorage(t *testing.T) {
+	ctx := context.Background()
+	storage := amf_object_storage.NewFilesystemObjectStorage(t.TempDir())
+	content := []byte("0123456789")
+
+	require.NoError(t, storage.Put(ctx, "firmware/image.bin", bytes.NewReader(content), int64(len(content)), "application/octet-stream"))
+
+	t.Run("should read the whole object", func(t *testing.T) {
+		reader, err := storage.Get(ctx, "firmware/image.bin")
+		require.NoError(t, err)
+		defer reader.Close()
+
+		read, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, content, read)
+	})
+
+	t.Run("should read a range of the object", func(t *testing.T) {
+		reader, err := storage.GetRange(ctx, "firmware/image.bin", 4, 3)
+		require.NoError(t, err)
+		defer reader.Close()
+
+		read, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("456"), read)
+	})
+
+	t.Run("should list the keys below a prefix", func(t *testing.T) {
+		require.NoError(t, storage.Put(ctx, "uplinks/b.json", bytes.NewReader(content), int64(len(content)), "application/json"))
+		require.NoError(t, storage.Put(ctx, "uplinks/a.json", bytes.NewReader(content), int64(len(content)), "application/json"))
+
+		keys, err := storage.List(ctx, "uplinks/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"uplinks/a.json", "uplinks/b.json"}, keys)
+	})
+
+	t.Run("should not escape its base directory", func(t *testing.T) {
+		_, err := storage.Get(ctx, "../../etc/passwd")
+
+		assert.ErrorIs(t, err, amf_object_storage.ErrObjectNotFound)
+	})
+
+	t.Run("should report deleted objects as not found", func(t *testing.T) {
+		require.NoError(t, storage.Delete(ctx, "firmware/image.bin"))
+
+		_, err := storage.Get(ctx, "firmware/image.bin")
+		assert.ErrorIs(t, err, amf_object_storage.ErrObjectNotFound)
+	})
+}
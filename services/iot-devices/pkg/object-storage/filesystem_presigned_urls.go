@@ -0,0 +1,161 @@
+package object_storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	presignedExpiresParam   = "X-Expires"
+	presignedSignatureParam = "X-Signature"
+)
+
+type FilesystemObjectStorageOpsFunc func(*FilesystemObjectStorage)
+
+// WithPresignedUrls signs the urls of the objects below baseUrl, where the handler of the
+// storage has to be served for them to work.
+func WithPresignedUrls(baseUrl string, secret string) FilesystemObjectStorageOpsFunc {
+	return func(s *FilesystemObjectStorage) {
+		s.presignBaseUrl = strings.TrimSuffix(baseUrl, "/")
+		s.presignSecret = []byte(secret)
+	}
+}
+
+// WithMaxUploadSize rejects the uploads to the presigned urls larger than maxSize bytes.
+func WithMaxUploadSize(maxSize int64) FilesystemObjectStorageOpsFunc {
+	return func(s *FilesystemObjectStorage) {
+		s.maxUploadSize = maxSize
+	}
+}
+
+func WithClock(now func() time.Time) FilesystemObjectStorageOpsFunc {
+	return func(s *FilesystemObjectStorage) {
+		s.now = now
+	}
+}
+
+// PresignedUrlHandler serves the uploads and downloads of the presigned urls, as the
+// filesystem has no server of its own. The method, the key and the expiry are signed,
+// so an upload url is of no use to download the object, nor to upload any other.
+func (s *FilesystemObjectStorage) PresignedUrlHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.presignBaseUrl == "" {
+			http.Error(w, ErrPresignedUrlsNotEnabled.Error(), http.StatusNotFound)
+			return
+		}
+
+		basePath, err := s.PresignedUrlBasePath()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		key := strings.TrimPrefix(r.URL.Path, basePath+"/")
+
+		if r.Method != http.MethodGet && r.Method != http.MethodPut {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.validSignature(r.Method, key, r.URL.Query()) {
+			http.Error(w, "invalid or expired signature", http.StatusForbidden)
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			if s.maxUploadSize > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, s.maxUploadSize)
+			}
+			err := s.Put(r.Context(), key, r.Body, r.ContentLength, r.Header.Get("Content-Type"))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		object, err := s.Get(r.Context(), key)
+		if errors.Is(err, ErrObjectNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer object.Close()
+
+		seeker, _ := object.(io.ReadSeeker)
+		http.ServeContent(w, r, key, time.Time{}, seeker)
+	})
+}
+
+func (s *FilesystemObjectStorage) presign(method string, key string, expiry time.Duration) (string, error) {
+	if s.presignBaseUrl == "" {
+		return "", ErrPresignedUrlsNotEnabled
+	}
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(s.now().Add(expiry).Unix(), 10)
+	query := url.Values{}
+	query.Set(presignedExpiresParam, expires)
+	query.Set(presignedSignatureParam, s.signature(method, key, expires))
+
+	escaped := make([]string, 0)
+	for _, segment := range strings.Split(key, "/") {
+		escaped = append(escaped, url.PathEscape(segment))
+	}
+
+	return fmt.Sprintf("%s/%s?%s", s.presignBaseUrl, strings.Join(escaped, "/"), query.Encode()), nil
+}
+
+func (s *FilesystemObjectStorage) validSignature(method string, key string, query url.Values) bool {
+	expires := query.Get(presignedExpiresParam)
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || s.now().Unix() > expiresAt {
+		return false
+	}
+
+	expected, err := hex.DecodeString(s.signature(method, key, expires))
+	if err != nil {
+		return false
+	}
+	given, err := hex.DecodeString(query.Get(presignedSignatureParam))
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(expected, given)
+}
+
+func (s *FilesystemObjectStorage) signature(method string, key string, expires string) string {
+	mac := hmac.New(sha256.New, s.presignSecret)
+	mac.Write([]byte(method + "\n" + key + "\n" + expires))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// PresignedUrlBasePath is the path the handler of the storage has to be served below.
+func (s *FilesystemObjectStorage) PresignedUrlBasePath() (string, error) {
+	parsed, err := url.Parse(s.presignBaseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(parsed.Path, "/"), nil
+}
@@ -0,0 +1,102 @@
+package object_storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amf_object_storage "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/object-storage"
+)
+
+func TestFilesystemObjectStoragePresignedUrls(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	storage := amf_object_storage.NewFilesystemObjectStorage(
+		t.TempDir(),
+		amf_object_storage.WithPresignedUrls(server.URL+"/objects", "secret"),
+		amf_object_storage.WithClock(func() time.Time { return now }),
+		amf_object_storage.WithMaxUploadSize(16),
+	)
+	mux.Handle("/objects/", storage.PresignedUrlHandler())
+	content := []byte("glue board")
+
+	upload := func(t *testing.T, url string) *http.Response {
+		request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(content))
+		require.NoError(t, err)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		response.Body.Close()
+		return response
+	}
+
+	t.Run("should upload to and download from the presigned urls", func(t *testing.T) {
+		uploadUrl, err := storage.PresignPut(ctx, "glue-boards/device 1/image.jpg", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, upload(t, uploadUrl).StatusCode)
+
+		downloadUrl, err := storage.PresignGet(ctx, "glue-boards/device 1/image.jpg", time.Minute)
+		require.NoError(t, err)
+		response, err := http.Get(downloadUrl)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		read, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, content, read)
+	})
+
+	t.Run("should not upload with a download url", func(t *testing.T) {
+		downloadUrl, err := storage.PresignGet(ctx, "glue-boards/image.jpg", time.Minute)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, upload(t, downloadUrl).StatusCode)
+	})
+
+	t.Run("should not upload another object with the url", func(t *testing.T) {
+		uploadUrl, err := storage.PresignPut(ctx, "glue-boards/image.jpg", time.Minute)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, upload(t, strings.Replace(uploadUrl, "image.jpg", "other.jpg", 1)).StatusCode)
+	})
+
+	t.Run("should reject expired urls", func(t *testing.T) {
+		uploadUrl, err := storage.PresignPut(ctx, "glue-boards/image.jpg", -time.Second)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusForbidden, upload(t, uploadUrl).StatusCode)
+	})
+
+	t.Run("should reject uploads over the max size without storing them", func(t *testing.T) {
+		uploadUrl, err := storage.PresignPut(ctx, "glue-boards/large.jpg", time.Minute)
+		require.NoError(t, err)
+
+		request, err := http.NewRequest(http.MethodPut, uploadUrl, bytes.NewReader(bytes.Repeat([]byte("x"), 17)))
+		require.NoError(t, err)
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, response.StatusCode)
+		_, err = storage.Get(ctx, "glue-boards/large.jpg")
+		assert.ErrorIs(t, err, amf_object_storage.ErrObjectNotFound)
+	})
+
+	t.Run("should not presign without a base url", func(t *testing.T) {
+		_, err := amf_object_storage.NewFilesystemObjectStorage(t.TempDir()).PresignGet(ctx, "glue-boards/image.jpg", time.Minute)
+
+		assert.ErrorIs(t, err, amf_object_storage.ErrPresignedUrlsNotEnabled)
+	})
+}
@@ -0,0 +1,111 @@
+package object_storage
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// unknownSizePartSize bounds the memory uploads of unknown size take, which are sent in
+// parts buffered one at a time. Otherwise parts are sized for the largest object possible.
+const unknownSizePartSize = 16 << 20
+
+// MinioObjectStorage keeps the objects in a bucket of any S3 compatible storage.
+type MinioObjectStorage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewMinioObjectStorage(endpoint string, accessKey string, secretKey string, useSSL bool, bucket string) (*MinioObjectStorage, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MinioObjectStorage{client: client, bucket: bucket}, nil
+}
+
+func (s *MinioObjectStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	options := minio.PutObjectOptions{ContentType: contentType}
+	if size < 0 {
+		options.PartSize = unknownSizePartSize
+	}
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, content, size, options)
+
+	return err
+}
+
+func (s *MinioObjectStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.get(ctx, key, minio.GetObjectOptions{})
+}
+
+func (s *MinioObjectStorage) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	options := minio.GetObjectOptions{}
+	if err := options.SetRange(offset, offset+length-1); err != nil {
+		return nil, err
+	}
+
+	return s.get(ctx, key, options)
+}
+
+func (s *MinioObjectStorage) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *MinioObjectStorage) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		keys = append(keys, object.Key)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func (s *MinioObjectStorage) PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	presigned, err := s.client.PresignedPutObject(ctx, s.bucket, key, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	return presigned.String(), nil
+}
+
+func (s *MinioObjectStorage) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	presigned, err := s.client.PresignedGetObject(ctx, s.bucket, key, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return presigned.String(), nil
+}
+
+// get stats the object first, as minio only reports missing objects on the first read.
+func (s *MinioObjectStorage) get(ctx context.Context, key string, options minio.GetObjectOptions) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrObjectNotFound
+		}
+		return nil, err
+	}
+
+	return object, nil
+}
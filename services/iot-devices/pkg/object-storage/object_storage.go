@@ -0,0 +1,31 @@
+package object_storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrObjectNotFound          = errors.New("object not found")
+	ErrPresignedUrlsNotEnabled = errors.New("presigned urls not enabled")
+)
+
+// ObjectStorage keeps binary objects, like firmware images, addressed by key.
+// Keys are slash separated paths relative to the bucket or base directory.
+type ObjectStorage interface {
+	// Put reads the content until EOF. The size is -1 when not known in advance
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get returns ErrObjectNotFound when there is no object for the key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetRange reads length bytes of the object starting at offset
+	GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// List returns the keys starting with the prefix, sorted
+	List(ctx context.Context, prefix string) ([]string, error)
+	// PresignPut returns a url anyone can upload the object to with a PUT until it expires
+	PresignPut(ctx context.Context, key string, expiry time.Duration) (string, error)
+	// PresignGet returns a url anyone can download the object from until it expires
+	PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,22 @@
+package tenancy
+
+import "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/domain"
+
+const missingTenantScopeErrorMessage = "missing tenant scope"
+
+type MissingTenantScope struct {
+	domain.RootCriticalError
+	items map[string]interface{}
+}
+
+func (mts *MissingTenantScope) Error() string {
+	return missingTenantScopeErrorMessage
+}
+
+func (mts *MissingTenantScope) ExtraItems() map[string]interface{} {
+	return mts.items
+}
+
+func NewMissingTenantScope() *MissingTenantScope {
+	return &MissingTenantScope{items: map[string]interface{}{}}
+}
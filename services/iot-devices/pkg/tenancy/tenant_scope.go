@@ -0,0 +1,85 @@
+package tenancy
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+type tenantScope string
+
+const contextKeyTenantScope tenantScope = "tenant_scope"
+
+// Scope is the set of tenants whose rows a context reaches: the single tenant of the
+// requests of its users, or every tenant for the processes acting on behalf of all of
+// them, like the workers and the devices themselves.
+type Scope struct {
+	tenantID   string
+	allTenants bool
+}
+
+// WithTenant scopes the context to the rows of the tenant.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, contextKeyTenantScope, Scope{tenantID: tenantID})
+}
+
+// WithAllTenants lifts the tenant scope, for the system processes only.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyTenantScope, Scope{allTenants: true})
+}
+
+// ScopeFromContext fails when the context was never scoped, so forgetting to scope a
+// context reads nothing instead of the rows of every tenant.
+func ScopeFromContext(ctx context.Context) (Scope, error) {
+	scope, ok := ctx.Value(contextKeyTenantScope).(Scope)
+	if !ok {
+		return Scope{}, NewMissingTenantScope()
+	}
+
+	return scope, nil
+}
+
+// TenantFromContext returns the tenant of the context, if scoped to a single one.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	scope, err := ScopeFromContext(ctx)
+	if err != nil || scope.allTenants {
+		return "", false
+	}
+
+	return scope.tenantID, true
+}
+
+func (s Scope) TenantID() string {
+	return s.tenantID
+}
+
+func (s Scope) AllTenants() bool {
+	return s.allTenants
+}
+
+// Includes tells whether the rows of the tenant are within the scope.
+func (s Scope) Includes(tenantID string) bool {
+	return s.allTenants || s.tenantID == tenantID
+}
+
+// Value binds the scope as a query argument: NULL for every tenant, the tenant otherwise.
+// Queries filter with ($n::VARCHAR IS NULL OR tenant_id = $n).
+func (s Scope) Value() (driver.Value, error) {
+	if s.allTenants {
+		return nil, nil
+	}
+
+	return s.tenantID, nil
+}
+
+// EnsureWithinScope keeps the rows of a tenant from being written on behalf of another.
+func EnsureWithinScope(ctx context.Context, tenantID string) error {
+	scope, err := ScopeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !scope.Includes(tenantID) {
+		return NewMissingTenantScope()
+	}
+
+	return nil
+}
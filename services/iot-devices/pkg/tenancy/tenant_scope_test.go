@@ -0,0 +1,96 @@
+package tenancy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+)
+
+func TestScopeFromContext(t *testing.T) {
+	t.Run("should fail closed for the contexts never scoped", func(t *testing.T) {
+		_, err := amf_tenancy.ScopeFromContext(context.Background())
+
+		assert.IsType(t, &amf_tenancy.MissingTenantScope{}, err)
+	})
+
+	t.Run("should only include the tenant of a tenant scope", func(t *testing.T) {
+		scope, err := amf_tenancy.ScopeFromContext(amf_tenancy.WithTenant(context.Background(), "tenant-1"))
+
+		require.NoError(t, err)
+		assert.True(t, scope.Includes("tenant-1"))
+		assert.False(t, scope.Includes("tenant-2"))
+		assert.False(t, scope.Includes(""))
+
+		value, err := scope.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "tenant-1", value)
+	})
+
+	t.Run("should bind the unassigned rows as a tenant of their own", func(t *testing.T) {
+		scope, err := amf_tenancy.ScopeFromContext(amf_tenancy.WithTenant(context.Background(), ""))
+
+		require.NoError(t, err)
+		assert.False(t, scope.Includes("tenant-1"))
+
+		value, err := scope.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "", value)
+	})
+
+	t.Run("should include every tenant of the all tenants scope", func(t *testing.T) {
+		scope, err := amf_tenancy.ScopeFromContext(amf_tenancy.WithAllTenants(context.Background()))
+
+		require.NoError(t, err)
+		assert.True(t, scope.Includes("tenant-1"))
+		assert.True(t, scope.Includes("tenant-2"))
+
+		value, err := scope.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("should let the innermost scope win", func(t *testing.T) {
+		ctx := amf_tenancy.WithTenant(amf_tenancy.WithAllTenants(context.Background()), "tenant-1")
+
+		tenantID, ok := amf_tenancy.TenantFromContext(ctx)
+
+		assert.True(t, ok)
+		assert.Equal(t, "tenant-1", tenantID)
+	})
+}
+
+func TestTenantFromContext(t *testing.T) {
+	for name, ctx := range map[string]context.Context{
+		"a context never scoped": context.Background(),
+		"the all tenants scope":  amf_tenancy.WithAllTenants(context.Background()),
+	} {
+		t.Run("should have no tenant for "+name, func(t *testing.T) {
+			_, ok := amf_tenancy.TenantFromContext(ctx)
+
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestEnsureWithinScope(t *testing.T) {
+	t.Run("should refuse to write the rows of another tenant", func(t *testing.T) {
+		err := amf_tenancy.EnsureWithinScope(amf_tenancy.WithTenant(context.Background(), "tenant-1"), "tenant-2")
+
+		assert.IsType(t, &amf_tenancy.MissingTenantScope{}, err)
+	})
+
+	t.Run("should refuse to write from a context never scoped", func(t *testing.T) {
+		err := amf_tenancy.EnsureWithinScope(context.Background(), "tenant-1")
+
+		assert.IsType(t, &amf_tenancy.MissingTenantScope{}, err)
+	})
+
+	t.Run("should write the rows of the own tenant or on behalf of all", func(t *testing.T) {
+		assert.NoError(t, amf_tenancy.EnsureWithinScope(amf_tenancy.WithTenant(context.Background(), "tenant-1"), "tenant-1"))
+		assert.NoError(t, amf_tenancy.EnsureWithinScope(amf_tenancy.WithAllTenants(context.Background()), "tenant-2"))
+	})
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"github.com/oklog/ulid"
 	"math/rand"
 	"sync"
@@ -60,3 +62,10 @@ func NewUlid() Ulid {
 	entropy := ulid.Monotonic(rand.New(rand.NewSource(t.UnixNano())), 0)
 	return Ulid(ulid.MustNew(ulid.Timestamp(t), entropy).String())
 }
+
+// NewDeterministicUlid always returns the same ULID for the time and seed, so the ids of
+// things received more than once, like retransmitted readings, do not change.
+func NewDeterministicUlid(t time.Time, seed string) Ulid {
+	entropy := sha256.Sum256([]byte(seed))
+	return Ulid(ulid.MustNew(ulid.Timestamp(t), bytes.NewReader(entropy[:])).String())
+}
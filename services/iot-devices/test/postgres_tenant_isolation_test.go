@@ -0,0 +1,526 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/AntonioMartinezFernandez/services/iot-devices/configs"
+	alerting_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/domain"
+	alerting_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/alerting/infra"
+	downlinks_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/domain"
+	downlinks_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/downlinks/infra"
+	exports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/domain"
+	exports_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/exports/infra"
+	firmware_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/domain"
+	firmware_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/firmware/infra"
+	maintenance_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/domain"
+	maintenance_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/maintenance/infra"
+	notifications_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/domain"
+	notifications_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/notifications/infra"
+	pestcontrol_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/domain"
+	pestcontrol_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/pestcontrol/infra"
+	reports_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/domain"
+	reports_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/reports/infra"
+	telemetry_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/domain"
+	telemetry_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/telemetry/infra"
+	tenancy_domain "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/domain"
+	tenancy_infra "github.com/AntonioMartinezFernandez/services/iot-devices/internal/tenancy/infra"
+
+	amf_sqldb "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb"
+	amf_pgsql "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/sqldb/pgsql"
+	amf_tenancy "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/tenancy"
+	amf_utils "github.com/AntonioMartinezFernandez/services/iot-devices/pkg/utils"
+)
+
+// tenantRows are the rows seeded for a tenant, one of each tenant scoped repository.
+type tenantRows struct {
+	tenantID       string
+	customerID     string
+	siteID         string
+	zoneID         string
+	deviceID       string
+	alertRuleID    string
+	alertID        string
+	commandID      string
+	exportID       string
+	campaignID     string
+	windowID       string
+	subscriptionID string
+	deliveryID     string
+	userID         string
+	imageID        string
+	visitID        string
+	reportID       string
+	readingID      string
+}
+
+// isolationCase finds the row of a tenant, and searches the rows by the keys of a tenant,
+// with the repository under test.
+type isolationCase struct {
+	name   string
+	id     func(rows tenantRows) string
+	find   func(ctx context.Context, rows tenantRows) (bool, error)
+	search func(ctx context.Context, rows tenantRows) ([]string, error)
+}
+
+// TestPostgresTenantIsolation seeds two tenants and checks that, scoped to one of them,
+// no repository finds or searches the rows of the other one.
+func TestPostgresTenantIsolation(t *testing.T) {
+	pool := newPostgresSchema(t)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	firmwareImage, err := firmware_domain.NewFirmwareImage(amf_utils.NewUlid().String(), "1.0.0", []string{"trap"}, []byte("firmware"), []byte("signature"), 1024, now)
+	require.NoError(t, err)
+	require.NoError(t, firmware_infra.NewPostgresFirmwareImageRepository(pool).Save(amf_tenancy.WithAllTenants(context.Background()), firmwareImage))
+
+	tenants := []tenantRows{
+		seedTenant(t, pool, "tenant-1", firmwareImage.ID, now),
+		seedTenant(t, pool, "tenant-2", firmwareImage.ID, now),
+	}
+
+	for _, isolation := range isolationCases(pool, now) {
+		t.Run(isolation.name, func(t *testing.T) {
+			for _, own := range tenants {
+				for _, other := range tenants {
+					if own.tenantID == other.tenantID {
+						continue
+					}
+					ctx := amf_tenancy.WithTenant(context.Background(), own.tenantID)
+
+					found, err := isolation.find(ctx, own)
+					require.NoError(t, err)
+					assert.True(t, found, "%s does not find its own row", own.tenantID)
+
+					found, err = isolation.find(ctx, other)
+					require.NoError(t, err)
+					assert.False(t, found, "%s finds the row of %s", own.tenantID, other.tenantID)
+
+					if isolation.search == nil {
+						continue
+					}
+
+					ids, err := isolation.search(ctx, own)
+					require.NoError(t, err)
+					assert.Contains(t, ids, isolation.id(own), "%s does not search its own row", own.tenantID)
+
+					ids, err = isolation.search(ctx, other)
+					require.NoError(t, err)
+					assert.NotContains(t, ids, isolation.id(other), "%s searches the row of %s", own.tenantID, other.tenantID)
+				}
+			}
+		})
+	}
+}
+
+func isolationCases(pool amf_sqldb.ConnectionPool, now time.Time) []isolationCase {
+	customers := tenancy_infra.NewPostgresCustomerRepository(pool)
+	sites := tenancy_infra.NewPostgresSiteRepository(pool)
+	zones := tenancy_infra.NewPostgresZoneRepository(pool)
+	devices := tenancy_infra.NewPostgresDeviceRepository(pool)
+	alertRules := alerting_infra.NewPostgresAlertRuleRepository(pool)
+	alerts := alerting_infra.NewPostgresAlertRepository(pool)
+	escalationPolicies := alerting_infra.NewPostgresAlertEscalationPolicyRepository(pool)
+	commands := downlinks_infra.NewPostgresDownlinkCommandRepository(pool)
+	exportJobs := exports_infra.NewPostgresExportJobRepository(pool)
+	campaigns := firmware_infra.NewPostgresFirmwareCampaignRepository(pool)
+	windows := maintenance_infra.NewPostgresMaintenanceWindowRepository(pool)
+	subscriptions := notifications_infra.NewPostgresWebhookSubscriptionRepository(pool)
+	deliveries := notifications_infra.NewPostgresWebhookDeliveryRepository(pool)
+	preferences := notifications_infra.NewPostgresUserNotificationPreferencesRepository(pool)
+	images := pestcontrol_infra.NewPostgresGlueBoardImageRepository(pool)
+	visits := pestcontrol_infra.NewPostgresServiceVisitRepository(pool)
+	reports := reports_infra.NewPostgresSiteReportRepository(pool)
+	readings := telemetry_infra.NewPostgresReadingRepository(pool)
+
+	return []isolationCase{
+		{
+			name: "customers",
+			id:   func(rows tenantRows) string { return rows.customerID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				customer, err := customers.Find(ctx, rows.customerID)
+				return customer != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := customers.Search(ctx)
+				return idsOf(found, err, func(customer tenancy_domain.Customer) string { return customer.ID })
+			},
+		},
+		{
+			name: "sites",
+			id:   func(rows tenantRows) string { return rows.siteID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				site, err := sites.Find(ctx, rows.siteID)
+				return site != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := sites.SearchByCustomer(ctx, rows.customerID)
+				return idsOf(found, err, func(site tenancy_domain.Site) string { return site.ID })
+			},
+		},
+		{
+			name: "zones",
+			id:   func(rows tenantRows) string { return rows.zoneID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				zone, err := zones.Find(ctx, rows.zoneID)
+				return zone != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := zones.SearchBySite(ctx, rows.siteID)
+				return idsOf(found, err, func(zone tenancy_domain.Zone) string { return zone.ID })
+			},
+		},
+		{
+			name: "devices",
+			id:   func(rows tenantRows) string { return rows.deviceID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				device, err := devices.Find(ctx, rows.deviceID)
+				return device != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := devices.SearchByZone(ctx, rows.zoneID)
+				return idsOf(found, err, func(device tenancy_domain.Device) string { return device.ID })
+			},
+		},
+		{
+			name: "alert rules",
+			id:   func(rows tenantRows) string { return rows.alertRuleID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				rule, err := alertRules.Find(ctx, rows.alertRuleID)
+				return rule != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := alertRules.SearchEnabledFor(ctx, rows.deviceID)
+				return idsOf(found, err, func(rule alerting_domain.AlertRule) string { return rule.ID })
+			},
+		},
+		{
+			name: "alerts",
+			id:   func(rows tenantRows) string { return rows.alertID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				alert, err := alerts.Find(ctx, rows.alertID)
+				return alert != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := alerts.Search(ctx, alerting_domain.AlertFilter{DeviceID: rows.deviceID})
+				return idsOf(found, err, func(alert alerting_domain.Alert) string { return alert.ID })
+			},
+		},
+		{
+			name: "alert escalation policies",
+			id:   func(rows tenantRows) string { return rows.tenantID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				policy, err := escalationPolicies.Find(ctx, rows.tenantID)
+				return policy != nil, err
+			},
+		},
+		{
+			name: "downlink commands",
+			id:   func(rows tenantRows) string { return rows.commandID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				command, err := commands.Find(ctx, rows.deviceID, rows.commandID)
+				return command != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := commands.SearchByDevice(ctx, rows.deviceID, 10)
+				return idsOf(found, err, func(command downlinks_domain.DownlinkCommand) string { return command.ID })
+			},
+		},
+		{
+			name: "export jobs",
+			id:   func(rows tenantRows) string { return rows.exportID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				job, err := exportJobs.Find(ctx, rows.exportID)
+				return job != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := exportJobs.SearchClaimable(ctx, now, 10)
+				return idsOf(found, err, func(job exports_domain.ExportJob) string { return job.ID })
+			},
+		},
+		{
+			name: "firmware campaigns",
+			id:   func(rows tenantRows) string { return rows.campaignID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				campaign, err := campaigns.Find(ctx, rows.campaignID)
+				return campaign != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := campaigns.SearchByTenant(ctx, rows.tenantID)
+				return idsOf(found, err, func(campaign firmware_domain.FirmwareCampaign) string { return campaign.ID })
+			},
+		},
+		{
+			name: "maintenance windows",
+			id:   func(rows tenantRows) string { return rows.windowID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				window, err := windows.Find(ctx, rows.windowID)
+				return window != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := windows.SearchByTenant(ctx, rows.tenantID)
+				return idsOf(found, err, func(window maintenance_domain.MaintenanceWindow) string { return window.ID })
+			},
+		},
+		{
+			name: "webhook subscriptions",
+			id:   func(rows tenantRows) string { return rows.subscriptionID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				subscription, err := subscriptions.Find(ctx, rows.subscriptionID)
+				return subscription != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := subscriptions.SearchByTenant(ctx, rows.tenantID)
+				return idsOf(found, err, func(subscription notifications_domain.WebhookSubscription) string { return subscription.ID })
+			},
+		},
+		{
+			name: "webhook deliveries",
+			id:   func(rows tenantRows) string { return rows.deliveryID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				delivery, err := deliveries.Find(ctx, rows.deliveryID)
+				return delivery != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := deliveries.SearchBySubscription(ctx, rows.subscriptionID, 10)
+				return idsOf(found, err, func(delivery notifications_domain.WebhookDelivery) string { return delivery.ID })
+			},
+		},
+		{
+			name: "notification preferences",
+			id:   func(rows tenantRows) string { return rows.userID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				found, err := preferences.Find(ctx, rows.userID)
+				return found != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := preferences.SearchByTenant(ctx, rows.tenantID)
+				return idsOf(found, err, func(found notifications_domain.UserNotificationPreferences) string { return found.UserID })
+			},
+		},
+		{
+			name: "glue board images",
+			id:   func(rows tenantRows) string { return rows.imageID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				image, err := images.Find(ctx, rows.imageID)
+				return image != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := images.SearchByDevice(ctx, rows.deviceID)
+				return idsOf(found, err, func(image pestcontrol_domain.GlueBoardImage) string { return image.ID })
+			},
+		},
+		{
+			name: "service visits",
+			id:   func(rows tenantRows) string { return rows.visitID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				visit, err := visits.Find(ctx, rows.visitID)
+				return visit != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := visits.SearchBySite(ctx, rows.siteID, now.Add(-24*time.Hour), now.Add(24*time.Hour))
+				return idsOf(found, err, func(visit pestcontrol_domain.ServiceVisit) string { return visit.ID })
+			},
+		},
+		{
+			name: "site reports",
+			id:   func(rows tenantRows) string { return rows.reportID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				report, err := reports.Find(ctx, rows.reportID)
+				return report != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := reports.SearchBySite(ctx, rows.siteID, nil)
+				return idsOf(found, err, func(report reports_domain.SiteReport) string { return report.ID })
+			},
+		},
+		{
+			name: "readings",
+			id:   func(rows tenantRows) string { return rows.readingID },
+			find: func(ctx context.Context, rows tenantRows) (bool, error) {
+				reading, err := readings.Find(ctx, rows.readingID)
+				return reading != nil, err
+			},
+			search: func(ctx context.Context, rows tenantRows) ([]string, error) {
+				found, err := readings.Search(ctx, telemetry_domain.ReadingCriteria{DeviceID: rows.deviceID, Limit: 10})
+				return idsOf(found, err, func(reading telemetry_domain.Reading) string { return reading.ID })
+			},
+		},
+	}
+}
+
+// seedTenant stores, on behalf of the tenant, a row of each tenant scoped repository.
+func seedTenant(t *testing.T, pool *amf_pgsql.PgsqlConnectionPool, tenantID string, firmwareID string, now time.Time) tenantRows {
+	t.Helper()
+	ctx := amf_tenancy.WithTenant(context.Background(), tenantID)
+	newID := func() string { return amf_utils.NewUlid().String() }
+	rows := tenantRows{tenantID: tenantID, deviceID: tenantID + "-trap", userID: tenantID + "-user"}
+
+	customer, err := tenancy_domain.NewCustomer(newID(), tenantID, "Customer", now)
+	require.NoError(t, err)
+	require.NoError(t, tenancy_infra.NewPostgresCustomerRepository(pool).Save(ctx, customer))
+	rows.customerID = customer.ID
+
+	site, err := tenancy_domain.NewSite(newID(), customer, "Site", "Europe/Madrid", now)
+	require.NoError(t, err)
+	require.NoError(t, tenancy_infra.NewPostgresSiteRepository(pool).Save(ctx, site))
+	rows.siteID = site.ID
+
+	zone, err := tenancy_domain.NewZone(newID(), site, "Zone", now)
+	require.NoError(t, err)
+	require.NoError(t, tenancy_infra.NewPostgresZoneRepository(pool).Save(ctx, zone))
+	rows.zoneID = zone.ID
+
+	// Devices are provisioned without tenant, then assigned to a zone of one
+	_, err = pool.Writer().Exec(`INSERT INTO spcd_iot_devices (id, created_at) VALUES ($1, $2)`, rows.deviceID, now)
+	require.NoError(t, err)
+	device := tenancy_domain.Device{ID: rows.deviceID}
+	device, err = device.AssignTo(zone)
+	require.NoError(t, err)
+	require.NoError(t, tenancy_infra.NewPostgresDeviceRepository(pool).Assign(ctx, device))
+
+	rule, err := alerting_domain.NewAlertRule(newID(), tenantID, "Low battery", "battery_mv < 2400", rows.deviceID, true, now)
+	require.NoError(t, err)
+	require.NoError(t, alerting_infra.NewPostgresAlertRuleRepository(pool).Save(ctx, rule))
+	rows.alertRuleID = rule.ID
+
+	alert := alerting_domain.NewAlert(newID(), tenantID, rule.ID, rule.Name, rows.deviceID, 2300, now)
+	require.NoError(t, alerting_infra.NewPostgresAlertRepository(pool).Save(ctx, alert))
+	rows.alertID = alert.ID
+
+	policy, err := alerting_domain.NewAlertEscalationPolicy(tenantID, time.Hour, "supervisor@example.com")
+	require.NoError(t, err)
+	require.NoError(t, alerting_infra.NewPostgresAlertEscalationPolicyRepository(pool).Save(ctx, policy))
+
+	command, err := downlinks_domain.NewDownlinkCommand(newID(), tenantID, rows.deviceID, downlinks_domain.RebootDownlinkCommand, nil, 5, "", now.Add(time.Hour), now)
+	require.NoError(t, err)
+	require.NoError(t, downlinks_infra.NewPostgresDownlinkCommandRepository(pool).Save(ctx, command))
+	rows.commandID = command.ID
+
+	spec, err := exports_domain.NewExportSpec(site.ID, now.Add(-24*time.Hour), now, "csv", nil, "")
+	require.NoError(t, err)
+	job, err := exports_domain.NewExportJob(newID(), spec, now)
+	require.NoError(t, err)
+	require.NoError(t, exports_infra.NewPostgresExportJobRepository(pool).Save(ctx, job))
+	rows.exportID = job.ID
+
+	cohort := firmware_domain.FirmwareCohort{SiteID: site.ID}
+	campaign, err := firmware_domain.NewFirmwareCampaign(newID(), tenantID, firmwareID, cohort, []int{100}, 0.5, now)
+	require.NoError(t, err)
+	require.NoError(t, firmware_infra.NewPostgresFirmwareCampaignRepository(pool).Save(ctx, campaign))
+	rows.campaignID = campaign.ID
+
+	window, err := maintenance_domain.NewMaintenanceWindow(newID(), tenantID, maintenance_domain.SiteMaintenanceScope, site.ID, "", now, now.Add(time.Hour), now)
+	require.NoError(t, err)
+	require.NoError(t, maintenance_infra.NewPostgresMaintenanceWindowRepository(pool).Save(ctx, window))
+	rows.windowID = window.ID
+
+	subscription, err := notifications_domain.NewWebhookSubscription(newID(), tenantID, "https://example.com/hooks", "a-secret-long-enough", nil, now)
+	require.NoError(t, err)
+	require.NoError(t, notifications_infra.NewPostgresWebhookSubscriptionRepository(pool).Save(ctx, subscription))
+	rows.subscriptionID = subscription.ID
+
+	delivery := notifications_domain.NewWebhookDelivery(newID(), subscription.ID, "alert.raised", []byte(`{}`), now)
+	require.NoError(t, notifications_infra.NewPostgresWebhookDeliveryRepository(pool).Save(ctx, delivery))
+	rows.deliveryID = delivery.ID
+
+	preferences, err := notifications_domain.NewUserNotificationPreferences(
+		rows.userID, tenantID, notifications_domain.EnglishNotificationLanguage, "Europe/Madrid", "", "", nil, nil, nil,
+	)
+	require.NoError(t, err)
+	require.NoError(t, notifications_infra.NewPostgresUserNotificationPreferencesRepository(pool).Save(ctx, preferences))
+
+	image, err := pestcontrol_domain.NewGlueBoardImage(newID(), rows.deviceID, newID(), "image/jpeg", now)
+	require.NoError(t, err)
+	require.NoError(t, pestcontrol_infra.NewPostgresGlueBoardImageRepository(pool).Save(ctx, image))
+	rows.imageID = image.ID
+
+	visit, err := pestcontrol_domain.NewServiceVisit(newID(), site.ID, "Technician", now, now.Add(time.Hour), "")
+	require.NoError(t, err)
+	require.NoError(t, pestcontrol_infra.NewPostgresServiceVisitRepository(pool).Save(ctx, visit))
+	rows.visitID = visit.ID
+
+	month, err := reports_domain.NewReportMonth(now.Format("2006-01"))
+	require.NoError(t, err)
+	report, err := reports_domain.NewSiteReport(newID(), site.ID, month, 1, "Europe/Madrid", now)
+	require.NoError(t, err)
+	require.NoError(t, reports_infra.NewPostgresSiteReportRepository(pool).Save(ctx, report))
+	rows.reportID = report.ID
+
+	reading, err := telemetry_domain.NewReading(newID(), rows.deviceID, now, now, map[string]float64{"battery_mv": 2300})
+	require.NoError(t, err)
+	require.NoError(t, telemetry_infra.NewPostgresReadingRepository(pool).Save(ctx, reading))
+	rows.readingID = reading.ID
+
+	return rows
+}
+
+// newPostgresSchema migrates a schema of its own in the database of the environment, dropped
+// once the test finishes. It skips the test when there is no database to reach.
+func newPostgresSchema(t *testing.T) *amf_pgsql.PgsqlConnectionPool {
+	t.Helper()
+	config := configs.LoadEnvConfig()
+	if config.PgsqlHost == "" {
+		t.Skip("PGSQL_HOST is not set")
+	}
+
+	dsn := func(searchPath string) string {
+		params := url.Values{"sslmode": {"disable"}}
+		if searchPath != "" {
+			params.Set("search_path", searchPath)
+		}
+
+		return (&url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(config.PgsqlUser, config.PgsqlPassword),
+			Host:     fmt.Sprintf("%s:%d", config.PgsqlHost, config.PgsqlPort),
+			Path:     config.PgsqlDatabase,
+			RawQuery: params.Encode(),
+		}).String()
+	}
+
+	admin, err := sql.Open("postgres", dsn(""))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = admin.Close() })
+	if err := admin.Ping(); err != nil {
+		t.Skipf("postgres is not reachable: %s", err)
+	}
+
+	schema := "tenant_isolation_" + strings.ToLower(amf_utils.NewUlid().String())
+	_, err = admin.Exec(`CREATE SCHEMA ` + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	client, err := sql.Open("postgres", dsn(schema))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	_, err = amf_sqldb.NewPgsqlDatabaseMigrator(client, "../migrations", "migrations").Up()
+	require.NoError(t, err)
+
+	pool, err := amf_pgsql.WithWriterOnly(client)
+	require.NoError(t, err)
+
+	return pool
+}
+
+func idsOf[T any](items []T, err error, id func(item T) string) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, id(item))
+	}
+
+	return ids, nil
+}